@@ -28,4 +28,7 @@ const (
 
 	// CronExecutionConfigName refers to CronExecutionConfig.
 	CronExecutionConfigName ConfigName = "cron"
+
+	// ControllerExecutionConfigName refers to ControllerExecutionConfig.
+	ControllerExecutionConfigName ConfigName = "controllers"
 )
@@ -17,6 +17,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,6 +44,14 @@ type JobExecutionConfig struct {
 	// +optional
 	DefaultPendingTimeoutSeconds *int64 `json:"defaultPendingTimeoutSeconds,omitempty"`
 
+	// DefaultMaxRuntimeSeconds is the default maximum duration that a Job may run
+	// for from its start time, if the Job does not specify maxRuntimeSeconds. Jobs
+	// that exceed this duration will be killed. Set to 0 to disable.
+	//
+	// Default: 0
+	// +optional
+	DefaultMaxRuntimeSeconds *int64 `json:"defaultMaxRuntimeSeconds,omitempty"`
+
 	// DeleteKillingTasksTimeoutSeconds is the duration we delete the task to kill
 	// it instead of using active deadline, if previous efforts were ineffective.
 	// Set this value to 0 to immediately use deletion.
@@ -59,8 +68,352 @@ type JobExecutionConfig struct {
 	// Default: 120
 	// +optional
 	ForceDeleteKillingTasksTimeoutSeconds *int64 `json:"forceDeleteKillingTasksTimeoutSeconds,omitempty"`
+
+	// NodeLostReplaceTimeoutSeconds is the duration after a task's node is deemed
+	// to be lost (e.g. the node became unreachable), before the task is force
+	// deleted and replaced with a new task. Tasks replaced in this manner do not
+	// count towards the Job's maxAttempts. Set this value to 0 to disable.
+	//
+	// Default: 300
+	// +optional
+	NodeLostReplaceTimeoutSeconds *int64 `json:"nodeLostReplaceTimeoutSeconds,omitempty"`
+
+	// TTLAfterFinishedPolicy specifies what to do with a Job once its TTL after it
+	// has finished has expired. Select between "DeleteJob" (default), which deletes
+	// the Job together with all of its tasks, or "DeleteTasks", which only deletes
+	// the Job's tasks but retains the Job object.
+	//
+	// Default: DeleteJob
+	// +optional
+	TTLAfterFinishedPolicy TTLAfterFinishedPolicy `json:"ttlAfterFinishedPolicy,omitempty"`
+
+	// MaxFinishedJobsPerJobConfig is the maximum number of finished Jobs to retain
+	// for each JobConfig. Once exceeded, the oldest finished Jobs will be deleted
+	// regardless of their TTL. Jobs that do not belong to any JobConfig are not
+	// affected. Set to 0 to disable.
+	//
+	// Default: 0
+	// +optional
+	MaxFinishedJobsPerJobConfig *int64 `json:"maxFinishedJobsPerJobConfig,omitempty"`
+
+	// MaxConcurrentJobsPerNamespace is the maximum number of Jobs that can be
+	// running concurrently in a single namespace. Jobs that exceed this limit will
+	// remain queued until other Jobs in the same namespace have finished. Set to 0
+	// to disable.
+	//
+	// Default: 0
+	// +optional
+	MaxConcurrentJobsPerNamespace *int64 `json:"maxConcurrentJobsPerNamespace,omitempty"`
+
+	// MaxDetailedTaskRefs is the maximum number of finished tasks of a Job for
+	// which full details are retained in the Job's status. Older finished tasks
+	// are compacted by dropping their container states, messages and progress,
+	// which keeps Jobs with many task attempts well under the object size limit.
+	// The most recent tasks and unfinished tasks are never compacted. Set to 0 to
+	// disable.
+	//
+	// Default: 0
+	// +optional
+	MaxDetailedTaskRefs *int64 `json:"maxDetailedTaskRefs,omitempty"`
+
+	// NamespaceMaxConcurrentJobs overrides MaxConcurrentJobsPerNamespace for
+	// specific namespaces, keyed by the name of the namespace. Set a value of 0 to
+	// disable the limit for a namespace.
+	// +optional
+	NamespaceMaxConcurrentJobs map[string]int64 `json:"namespaceMaxConcurrentJobs,omitempty"`
+
+	// GlobalStartRateLimit limits the rate at which Jobs are started across all
+	// namespaces. This helps to smooth out bursts of task creation, such as after
+	// the controller is restarted or when many Jobs are backfilled at once. Jobs
+	// that exceed the rate limit will remain queued. If not specified, no limit
+	// will be applied.
+	//
+	// +optional
+	GlobalStartRateLimit *RateLimitSpec `json:"globalStartRateLimit,omitempty"`
+
+	// NamespaceStartRateLimit limits the rate at which Jobs are started in each
+	// namespace, applied independently to each namespace. Jobs that exceed the
+	// rate limit will remain queued. If not specified, no limit will be applied.
+	//
+	// +optional
+	NamespaceStartRateLimit *RateLimitSpec `json:"namespaceStartRateLimit,omitempty"`
+
+	// PausedNamespaces is a list of namespaces in which no new Jobs will be started.
+	// Jobs can still be created in a paused namespace, but will remain queued until
+	// the namespace is removed from this list. This is useful for draining a
+	// namespace before performing maintenance, without losing any scheduled Jobs.
+	//
+	// +optional
+	PausedNamespaces []string `json:"pausedNamespaces,omitempty"`
+
+	// ImagePolicy restricts the container images that may be used in the pod
+	// templates of JobConfigs and Jobs, and is enforced at admission. If not
+	// specified, all images are allowed.
+	//
+	// +optional
+	ImagePolicy *ImagePolicySpec `json:"imagePolicy,omitempty"`
+
+	// AdhocJobQuota limits the number of ad-hoc Jobs that each user may create
+	// within an hour, and is enforced at admission. This protects shared clusters
+	// from scripts which repeatedly trigger Jobs. If not specified, no limit will
+	// be applied.
+	//
+	// +optional
+	AdhocJobQuota *AdhocJobQuotaSpec `json:"adhocJobQuota,omitempty"`
+
+	// CloudEvents controls the emission of CloudEvents for the lifecycle of Jobs,
+	// which allows external systems to react to Jobs without polling the API. If
+	// not specified, no events will be emitted.
+	//
+	// +optional
+	CloudEvents *CloudEventsSpec `json:"cloudEvents,omitempty"`
+
+	// Notifications controls how notifications that are configured on JobConfigs
+	// are sent.
+	//
+	// +optional
+	Notifications *NotificationsSpec `json:"notifications,omitempty"`
+
+	// Alerting controls how alerts that are configured on JobConfigs are sent to
+	// incident management services.
+	//
+	// +optional
+	Alerting *AlertingSpec `json:"alerting,omitempty"`
+
+	// Callbacks controls how callbacks that are specified in Job templates are
+	// delivered.
+	//
+	// +optional
+	Callbacks *CallbacksSpec `json:"callbacks,omitempty"`
+}
+
+// CallbacksSpec specifies how Job callbacks are delivered.
+type CallbacksSpec struct {
+	// MaxAttempts is the maximum number of attempts to deliver each callback,
+	// after which the callback is marked as Failed.
+	//
+	// Default: 5
+	// +optional
+	MaxAttempts *int64 `json:"maxAttempts,omitempty"`
+
+	// BackoffSeconds is the delay before the first retry of a failed delivery,
+	// which is doubled for each subsequent retry up to MaxBackoffSeconds.
+	//
+	// Default: 10
+	// +optional
+	BackoffSeconds *int64 `json:"backoffSeconds,omitempty"`
+
+	// MaxBackoffSeconds is the maximum delay between retries.
+	//
+	// Default: 600
+	// +optional
+	MaxBackoffSeconds *int64 `json:"maxBackoffSeconds,omitempty"`
+
+	// TimeoutSeconds is the timeout of each HTTP request.
+	//
+	// Default: 10
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
 }
 
+// AlertingSpec specifies how JobConfig alerts are sent.
+type AlertingSpec struct {
+	// PagerDutyEventsURL is the URL of the PagerDuty Events API v2.
+	//
+	// Default: https://events.pagerduty.com/v2/enqueue
+	// +optional
+	PagerDutyEventsURL string `json:"pagerDutyEventsURL,omitempty"`
+
+	// OpsgenieAPIURL is the base URL of the Opsgenie API. Use
+	// https://api.eu.opsgenie.com for accounts in the EU region.
+	//
+	// Default: https://api.opsgenie.com
+	// +optional
+	OpsgenieAPIURL string `json:"opsgenieAPIURL,omitempty"`
+
+	// JobURLTemplate is a URL template that links to a Job, which will be included
+	// in alerts if specified. The following variables will be substituted:
+	// ${job.namespace}, ${job.name}, ${jobconfig.name}.
+	//
+	// +optional
+	JobURLTemplate string `json:"jobURLTemplate,omitempty"`
+
+	// TimeoutSeconds is the timeout of each HTTP request.
+	//
+	// Default: 10
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// NotificationsSpec specifies how JobConfig notifications are sent.
+type NotificationsSpec struct {
+	// JobURLTemplate is a URL template that links to a Job, which will be included
+	// in notifications if specified. The following variables will be substituted:
+	// ${job.namespace}, ${job.name}, ${jobconfig.name}.
+	//
+	// +optional
+	JobURLTemplate string `json:"jobURLTemplate,omitempty"`
+
+	// TimeoutSeconds is the timeout of each HTTP request to a webhook.
+	//
+	// Default: 10
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// Email configures how Email notifications are sent. If not specified, Email
+	// notifications cannot be sent.
+	//
+	// +optional
+	Email *EmailNotificationsSpec `json:"email,omitempty"`
+}
+
+// EmailNotificationsSpec specifies the SMTP server and templates used to send
+// Email notifications. Since it contains credentials, it should be specified in
+// the dynamic config Secret instead of the ConfigMap.
+type EmailNotificationsSpec struct {
+	// Host is the hostname of the SMTP server.
+	Host string `json:"host"`
+
+	// Port is the port of the SMTP server. STARTTLS will be used if the server
+	// supports it.
+	//
+	// Default: 587
+	// +optional
+	Port int64 `json:"port,omitempty"`
+
+	// Username to authenticate with the SMTP server using PLAIN authentication.
+	// If empty, no authentication will be performed.
+	//
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Password to authenticate with the SMTP server.
+	//
+	// +optional
+	Password string `json:"password,omitempty"`
+
+	// From is the email address that notifications are sent from.
+	From string `json:"from"`
+
+	// SubjectTemplate is a Go template for the subject of each email. See
+	// BodyTemplate for the available fields.
+	//
+	// Default: [Furiko] {{ .Title }}
+	// +optional
+	SubjectTemplate string `json:"subjectTemplate,omitempty"`
+
+	// BodyTemplate is a Go template for the plain text body of each email. The
+	// following fields are available: .Title, .Namespace, .JobName,
+	// .JobConfigName, .Trigger, .Result, .Reason, .Message, .Duration, .URL.
+	//
+	// +optional
+	BodyTemplate string `json:"bodyTemplate,omitempty"`
+}
+
+// CloudEventsSpec specifies how CloudEvents are emitted. Events are sent in
+// structured content mode over HTTP, and are delivered on a best-effort basis
+// without retries.
+type CloudEventsSpec struct {
+	// Target is the URL that events will be sent to using HTTP POST. If empty, no
+	// events will be emitted.
+	//
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Source is the value of the source attribute of emitted events.
+	//
+	// Default: furiko.io/execution-controller
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Types is a list of event types to emit. If empty, all event types will be
+	// emitted.
+	//
+	// +optional
+	Types []string `json:"types,omitempty"`
+
+	// TimeoutSeconds is the timeout of each HTTP request.
+	//
+	// Default: 10
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// AdhocJobQuotaSpec specifies limits on the number of ad-hoc Jobs that each user
+// may create within an hour. Users are identified by the username of the
+// creation request, which for service accounts is in the form of
+// "system:serviceaccount:<namespace>:<name>".
+type AdhocJobQuotaSpec struct {
+	// MaxJobsPerJobConfig is the maximum number of ad-hoc Jobs that a user may
+	// create for a single JobConfig within an hour. Set to 0 to disable.
+	//
+	// +optional
+	MaxJobsPerJobConfig *int64 `json:"maxJobsPerJobConfig,omitempty"`
+
+	// MaxJobsPerNamespace is the maximum number of ad-hoc Jobs that a user may
+	// create in a single namespace within an hour. Set to 0 to disable.
+	//
+	// +optional
+	MaxJobsPerNamespace *int64 `json:"maxJobsPerNamespace,omitempty"`
+
+	// ExemptUsers is a list of usernames that are not subject to the quota, such
+	// as the service account of the controller which creates Jobs to retry other
+	// Jobs.
+	//
+	// +optional
+	ExemptUsers []string `json:"exemptUsers,omitempty"`
+}
+
+// ImagePolicySpec specifies restrictions on container images.
+type ImagePolicySpec struct {
+	// AllowedRegistries is a list of registries that images may be pulled from,
+	// such as "gcr.io" or "registry.example.com:5000". Images that do not specify
+	// a registry are pulled from "docker.io". If empty, all registries are
+	// allowed.
+	//
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+
+	// RequireDigest specifies that all images must be referenced by their digest,
+	// such as "alpine@sha256:...", instead of a mutable tag.
+	//
+	// +optional
+	RequireDigest bool `json:"requireDigest,omitempty"`
+
+	// BlockedTags is a list of image tags that may not be used, such as "latest".
+	// Images that specify neither a tag nor a digest are treated as using the
+	// "latest" tag.
+	//
+	// +optional
+	BlockedTags []string `json:"blockedTags,omitempty"`
+}
+
+// RateLimitSpec configures a token bucket rate limiter.
+type RateLimitSpec struct {
+	// QPS is the rate at which tokens are added to the bucket per second. May be a
+	// fractional value. Set to 0 to disable the rate limit.
+	QPS float64 `json:"qps"`
+
+	// Burst is the maximum number of tokens in the bucket. If not specified,
+	// defaults to QPS rounded up to the nearest integer, with a minimum of 1.
+	//
+	// +optional
+	Burst int64 `json:"burst,omitempty"`
+}
+
+// TTLAfterFinishedPolicy specifies what to do with a Job once its TTL has expired.
+type TTLAfterFinishedPolicy string
+
+const (
+	// TTLAfterFinishedPolicyDeleteJob means that the Job and all of its tasks will
+	// be deleted.
+	TTLAfterFinishedPolicyDeleteJob TTLAfterFinishedPolicy = "DeleteJob"
+
+	// TTLAfterFinishedPolicyDeleteTasks means that only the tasks of the Job will
+	// be deleted, and the Job will be retained.
+	TTLAfterFinishedPolicyDeleteTasks TTLAfterFinishedPolicy = "DeleteTasks"
+)
+
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -74,6 +427,45 @@ type JobConfigExecutionConfig struct {
 	// Default: 20
 	// +optional
 	MaxEnqueuedJobs *int64 `json:"maxEnqueuedJobs,omitempty"`
+
+	// ResourceUsageSampleIntervalSeconds is the interval at which the resource
+	// usage of running tasks is sampled from the metrics API, and recorded in the
+	// status of their JobConfigs. Requires metrics-server to be installed in the
+	// cluster. Set this value to 0 to disable.
+	//
+	// Default: 0
+	// +optional
+	ResourceUsageSampleIntervalSeconds *int64 `json:"resourceUsageSampleIntervalSeconds,omitempty"`
+
+	// ResourceRecommendationMarginPercent is the margin in percent to add to the
+	// peak resource usage when recommending resource requests. Only used if the
+	// ResourceRecommendations feature gate is enabled.
+	//
+	// Default: 20
+	// +optional
+	ResourceRecommendationMarginPercent *int64 `json:"resourceRecommendationMarginPercent,omitempty"`
+
+	// ResourcePrices is an optional price table used to estimate the cost of each
+	// task from its sampled resource usage. If not specified, costs will not be
+	// estimated.
+	//
+	// +optional
+	ResourcePrices *ResourcePrices `json:"resourcePrices,omitempty"`
+}
+
+// ResourcePrices is a price table for computing the estimated cost of tasks.
+type ResourcePrices struct {
+	// Price of a single CPU core per hour, charged using the total CPU time
+	// consumed by the task.
+	//
+	// +optional
+	CPUCoreHour *resource.Quantity `json:"cpuCoreHour,omitempty"`
+
+	// Price of a single GiB of memory per hour, charged using the peak memory
+	// usage of the task over its entire running duration.
+	//
+	// +optional
+	MemoryGiBHour *resource.Quantity `json:"memoryGiBHour,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -161,6 +553,23 @@ type CronExecutionConfig struct {
 	MaxDowntimeThresholdSeconds int64 `json:"maxDowntimeThresholdSeconds,omitempty"`
 }
 
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ControllerExecutionConfig defines runtime global configuration for the
+// controllers in the execution-controller.
+type ControllerExecutionConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Concurrency controls the number of workers for individual controllers, and
+	// takes precedence over the controllerConcurrency in the bootstrap config.
+	// Running controllers will scale their workers up or down to match any changes
+	// without requiring a restart.
+	//
+	// +optional
+	Concurrency *ExecutionControllerConcurrencySpec `json:"concurrency,omitempty"`
+}
+
 func init() {
-	SchemeBuilder.Register(&JobExecutionConfig{}, &CronExecutionConfig{})
+	SchemeBuilder.Register(&JobExecutionConfig{}, &CronExecutionConfig{}, &ControllerExecutionConfig{})
 }
@@ -43,12 +43,94 @@ type WebhookServerSpec struct {
 	BindAddress string `json:"bindAddress,omitempty"`
 
 	// TLSCertFile is the path to the X.509 certificate to use for serving webhook
-	// requests over HTTPS.
-	TLSCertFile string `json:"tlsCertFile"`
+	// requests over HTTPS. Not used if CertProvisioner is enabled.
+	// +optional
+	TLSCertFile string `json:"tlsCertFile,omitempty"`
 
 	// TLSPrivateKeyFile is the path to the private key which corresponds to
-	// TLSCertFile, to use for serving webhook requests over HTTPS.
-	TLSPrivateKeyFile string `json:"tlsPrivateKeyFile"`
+	// TLSCertFile, to use for serving webhook requests over HTTPS. Not used if
+	// CertProvisioner is enabled.
+	// +optional
+	TLSPrivateKeyFile string `json:"tlsPrivateKeyFile,omitempty"`
+
+	// CertProvisioner controls the built-in TLS certificate provisioner, which
+	// generates a self-signed CA and serving certificate, rotates them before they
+	// expire, and patches the CA bundle of all webhook configurations and CRD
+	// conversion webhooks. This removes the need for an external certificate
+	// manager such as cert-manager.
+	// +optional
+	CertProvisioner *CertProvisionerSpec `json:"certProvisioner,omitempty"`
+}
+
+type CertProvisionerSpec struct {
+	// Enabled controls whether the built-in certificate provisioner is enabled.
+	//
+	// Default: false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Secret is the Secret that the generated CA and serving certificate will be
+	// stored in, which allows all replicas of the webhook server to share the same
+	// certificates across restarts.
+	//
+	// Defaults to:
+	//  - namespace: furiko-system
+	//  - name: execution-webhook-certs
+	//
+	// +optional
+	Secret *ObjectReference `json:"secret,omitempty"`
+
+	// Service is the Service that routes webhook requests to the webhook server,
+	// which is used to determine the DNS names of the serving certificate.
+	//
+	// Defaults to:
+	//  - namespace: furiko-system
+	//  - name: execution-webhook-service
+	//
+	// +optional
+	Service *ObjectReference `json:"service,omitempty"`
+
+	// MutatingWebhookConfigurations is a list of names of
+	// MutatingWebhookConfigurations whose CA bundle should be patched.
+	// +optional
+	MutatingWebhookConfigurations []string `json:"mutatingWebhookConfigurations,omitempty"`
+
+	// ValidatingWebhookConfigurations is a list of names of
+	// ValidatingWebhookConfigurations whose CA bundle should be patched.
+	// +optional
+	ValidatingWebhookConfigurations []string `json:"validatingWebhookConfigurations,omitempty"`
+
+	// CustomResourceDefinitions is a list of names of CustomResourceDefinitions
+	// whose conversion webhook CA bundle should be patched.
+	// +optional
+	CustomResourceDefinitions []string `json:"customResourceDefinitions,omitempty"`
+
+	// CAValidity is the duration that a generated CA certificate is valid for.
+	//
+	// Default: 87600h (10 years)
+	// +optional
+	CAValidity metav1.Duration `json:"caValidity,omitempty"`
+
+	// CertValidity is the duration that a generated serving certificate is valid
+	// for.
+	//
+	// Default: 8760h (1 year)
+	// +optional
+	CertValidity metav1.Duration `json:"certValidity,omitempty"`
+
+	// RotateBefore is the duration before a certificate expires that it will be
+	// rotated. Must be less than both CAValidity and CertValidity.
+	//
+	// Default: 720h (30 days)
+	// +optional
+	RotateBefore metav1.Duration `json:"rotateBefore,omitempty"`
+
+	// ResyncInterval is the interval at which the Secret and CA bundles are
+	// periodically checked and repaired if necessary.
+	//
+	// Default: 10m
+	// +optional
+	ResyncInterval metav1.Duration `json:"resyncInterval,omitempty"`
 }
 
 func init() {
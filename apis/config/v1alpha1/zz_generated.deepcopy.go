@@ -22,9 +22,145 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerSpec) DeepCopyInto(out *APIServerSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthCacheTTLSeconds != nil {
+		in, out := &in.AuthCacheTTLSeconds, &out.AuthCacheTTLSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerSpec.
+func (in *APIServerSpec) DeepCopy() *APIServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCredentialsSpec) DeepCopyInto(out *AWSCredentialsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCredentialsSpec.
+func (in *AWSCredentialsSpec) DeepCopy() *AWSCredentialsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCredentialsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdhocJobQuotaSpec) DeepCopyInto(out *AdhocJobQuotaSpec) {
+	*out = *in
+	if in.MaxJobsPerJobConfig != nil {
+		in, out := &in.MaxJobsPerJobConfig, &out.MaxJobsPerJobConfig
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxJobsPerNamespace != nil {
+		in, out := &in.MaxJobsPerNamespace, &out.MaxJobsPerNamespace
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExemptUsers != nil {
+		in, out := &in.ExemptUsers, &out.ExemptUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdhocJobQuotaSpec.
+func (in *AdhocJobQuotaSpec) DeepCopy() *AdhocJobQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdhocJobQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertingSpec) DeepCopyInto(out *AlertingSpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingSpec.
+func (in *AlertingSpec) DeepCopy() *AlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArchiveSpec) DeepCopyInto(out *ArchiveSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArchiveSpec.
+func (in *ArchiveSpec) DeepCopy() *ArchiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArchiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogSpec) DeepCopyInto(out *AuditLogSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogSpec.
+func (in *AuditLogSpec) DeepCopy() *AuditLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BootstrapConfigSpec) DeepCopyInto(out *BootstrapConfigSpec) {
 	*out = *in
@@ -39,395 +175,1418 @@ func (in *BootstrapConfigSpec) DeepCopyInto(out *BootstrapConfigSpec) {
 		*out = new(HTTPSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.StatsD != nil {
+		in, out := &in.StatsD, &out.StatsD
+		*out = new(StatsDSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = new(ShardingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Informers != nil {
+		in, out := &in.Informers, &out.Informers
+		*out = new(InformersSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventRecorder != nil {
+		in, out := &in.EventRecorder, &out.EventRecorder
+		*out = new(EventRecorderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FaultInjection != nil {
+		in, out := &in.FaultInjection, &out.FaultInjection
+		*out = new(FaultInjectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapConfigSpec.
+func (in *BootstrapConfigSpec) DeepCopy() *BootstrapConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CallbacksSpec) DeepCopyInto(out *CallbacksSpec) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BackoffSeconds != nil {
+		in, out := &in.BackoffSeconds, &out.BackoffSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxBackoffSeconds != nil {
+		in, out := &in.MaxBackoffSeconds, &out.MaxBackoffSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CallbacksSpec.
+func (in *CallbacksSpec) DeepCopy() *CallbacksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CallbacksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertProvisionerSpec) DeepCopyInto(out *CertProvisionerSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.MutatingWebhookConfigurations != nil {
+		in, out := &in.MutatingWebhookConfigurations, &out.MutatingWebhookConfigurations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidatingWebhookConfigurations != nil {
+		in, out := &in.ValidatingWebhookConfigurations, &out.ValidatingWebhookConfigurations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomResourceDefinitions != nil {
+		in, out := &in.CustomResourceDefinitions, &out.CustomResourceDefinitions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.CAValidity = in.CAValidity
+	out.CertValidity = in.CertValidity
+	out.RotateBefore = in.RotateBefore
+	out.ResyncInterval = in.ResyncInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertProvisionerSpec.
+func (in *CertProvisionerSpec) DeepCopy() *CertProvisionerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertProvisionerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsSpec) DeepCopyInto(out *CloudEventsSpec) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsSpec.
+func (in *CloudEventsSpec) DeepCopy() *CloudEventsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Concurrency) DeepCopyInto(out *Concurrency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Concurrency.
+func (in *Concurrency) DeepCopy() *Concurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(Concurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerExecutionConfig) DeepCopyInto(out *ControllerExecutionConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(ExecutionControllerConcurrencySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerExecutionConfig.
+func (in *ControllerExecutionConfig) DeepCopy() *ControllerExecutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerExecutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControllerExecutionConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerConfigSpec) DeepCopyInto(out *ControllerManagerConfigSpec) {
+	*out = *in
+	if in.LeaderElection != nil {
+		in, out := &in.LeaderElection, &out.LeaderElection
+		*out = new(LeaderElectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.DrainTimeout = in.DrainTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerConfigSpec.
+func (in *ControllerManagerConfigSpec) DeepCopy() *ControllerManagerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronExecutionConfig) DeepCopyInto(out *CronExecutionConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.CronHashNames != nil {
+		in, out := &in.CronHashNames, &out.CronHashNames
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CronHashSecondsByDefault != nil {
+		in, out := &in.CronHashSecondsByDefault, &out.CronHashSecondsByDefault
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CronHashFields != nil {
+		in, out := &in.CronHashFields, &out.CronHashFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultTimezone != nil {
+		in, out := &in.DefaultTimezone, &out.DefaultTimezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxMissedSchedules != nil {
+		in, out := &in.MaxMissedSchedules, &out.MaxMissedSchedules
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronExecutionConfig.
+func (in *CronExecutionConfig) DeepCopy() *CronExecutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CronExecutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CronExecutionConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugSpec) DeepCopyInto(out *DebugSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugSpec.
+func (in *DebugSpec) DeepCopy() *DebugSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicConfigsSpec) DeepCopyInto(out *DynamicConfigsSpec) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.FurikoConfig != nil {
+		in, out := &in.FurikoConfig, &out.FurikoConfig
+		*out = new(FurikoConfigLoaderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remote != nil {
+		in, out := &in.Remote, &out.Remote
+		*out = new(RemoteConfigLoaderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfigsSpec.
+func (in *DynamicConfigsSpec) DeepCopy() *DynamicConfigsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicConfigsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationsSpec) DeepCopyInto(out *EmailNotificationsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationsSpec.
+func (in *EmailNotificationsSpec) DeepCopy() *EmailNotificationsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRecorderSpec) DeepCopyInto(out *EventRecorderSpec) {
+	*out = *in
+	if in.BurstSize != nil {
+		in, out := &in.BurstSize, &out.BurstSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MaxEventsPerObjectPerMinute != nil {
+		in, out := &in.MaxEventsPerObjectPerMinute, &out.MaxEventsPerObjectPerMinute
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxSimilarEvents != nil {
+		in, out := &in.MaxSimilarEvents, &out.MaxSimilarEvents
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AggregationIntervalSeconds != nil {
+		in, out := &in.AggregationIntervalSeconds, &out.AggregationIntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRecorderSpec.
+func (in *EventRecorderSpec) DeepCopy() *EventRecorderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRecorderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSinksSpec) DeepCopyInto(out *EventSinksSpec) {
+	*out = *in
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(KafkaSinkSpec)
+		**out = **in
+	}
+	if in.Pushgateway != nil {
+		in, out := &in.Pushgateway, &out.Pushgateway
+		*out = new(PushgatewaySinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Grafana != nil {
+		in, out := &in.Grafana, &out.Grafana
+		*out = new(GrafanaSinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.FlushInterval = in.FlushInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSinksSpec.
+func (in *EventSinksSpec) DeepCopy() *EventSinksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSinksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionControllerConcurrencySpec) DeepCopyInto(out *ExecutionControllerConcurrencySpec) {
+	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.JobConfig != nil {
+		in, out := &in.JobConfig, &out.JobConfig
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.JobQueue != nil {
+		in, out := &in.JobQueue, &out.JobQueue
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.Cron != nil {
+		in, out := &in.Cron, &out.Cron
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.JobGroup != nil {
+		in, out := &in.JobGroup, &out.JobGroup
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.Alert != nil {
+		in, out := &in.Alert, &out.Alert
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.Callback != nil {
+		in, out := &in.Callback, &out.Callback
+		*out = new(Concurrency)
+		**out = **in
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(Concurrency)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionControllerConcurrencySpec.
+func (in *ExecutionControllerConcurrencySpec) DeepCopy() *ExecutionControllerConcurrencySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionControllerConcurrencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionControllerConfig) DeepCopyInto(out *ExecutionControllerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.BootstrapConfigSpec.DeepCopyInto(&out.BootstrapConfigSpec)
+	in.ControllerManagerConfigSpec.DeepCopyInto(&out.ControllerManagerConfigSpec)
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllerConcurrency != nil {
+		in, out := &in.ControllerConcurrency, &out.ControllerConcurrency
+		*out = new(ExecutionControllerConcurrencySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerRateLimiters != nil {
+		in, out := &in.ControllerRateLimiters, &out.ControllerRateLimiters
+		*out = new(ExecutionControllerRateLimitersSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueryServer != nil {
+		in, out := &in.QueryServer, &out.QueryServer
+		*out = new(QueryServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(APIServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventSinks != nil {
+		in, out := &in.EventSinks, &out.EventSinks
+		*out = new(EventSinksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(ArchiveSpec)
+		**out = **in
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]TriggerSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionControllerConfig.
+func (in *ExecutionControllerConfig) DeepCopy() *ExecutionControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExecutionControllerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionControllerRateLimitersSpec) DeepCopyInto(out *ExecutionControllerRateLimitersSpec) {
+	*out = *in
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobConfig != nil {
+		in, out := &in.JobConfig, &out.JobConfig
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobQueue != nil {
+		in, out := &in.JobQueue, &out.JobQueue
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cron != nil {
+		in, out := &in.Cron, &out.Cron
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobGroup != nil {
+		in, out := &in.JobGroup, &out.JobGroup
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alert != nil {
+		in, out := &in.Alert, &out.Alert
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Callback != nil {
+		in, out := &in.Callback, &out.Callback
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archive != nil {
+		in, out := &in.Archive, &out.Archive
+		*out = new(RateLimiterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionControllerRateLimitersSpec.
+func (in *ExecutionControllerRateLimitersSpec) DeepCopy() *ExecutionControllerRateLimitersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionControllerRateLimitersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionWebhookConfig) DeepCopyInto(out *ExecutionWebhookConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.BootstrapConfigSpec.DeepCopyInto(&out.BootstrapConfigSpec)
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = new(WebhookServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionWebhookConfig.
+func (in *ExecutionWebhookConfig) DeepCopy() *ExecutionWebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionWebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExecutionWebhookConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultInjectionSpec) DeepCopyInto(out *FaultInjectionSpec) {
+	*out = *in
+	if in.ErrorProbability != nil {
+		in, out := &in.ErrorProbability, &out.ErrorProbability
+		*out = new(float64)
+		**out = **in
+	}
+	if in.ConflictProbability != nil {
+		in, out := &in.ConflictProbability, &out.ConflictProbability
+		*out = new(float64)
+		**out = **in
+	}
+	if in.DelayProbability != nil {
+		in, out := &in.DelayProbability, &out.DelayProbability
+		*out = new(float64)
+		**out = **in
+	}
+	out.Delay = in.Delay
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultInjectionSpec.
+func (in *FaultInjectionSpec) DeepCopy() *FaultInjectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultInjectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FurikoConfigLoaderSpec) DeepCopyInto(out *FurikoConfigLoaderSpec) {
+	*out = *in
+	if in.ReportStatus != nil {
+		in, out := &in.ReportStatus, &out.ReportStatus
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FurikoConfigLoaderSpec.
+func (in *FurikoConfigLoaderSpec) DeepCopy() *FurikoConfigLoaderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FurikoConfigLoaderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrafanaSinkSpec) DeepCopyInto(out *GrafanaSinkSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrafanaSinkSpec.
+func (in *GrafanaSinkSpec) DeepCopy() *GrafanaSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrafanaSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSpec) DeepCopyInto(out *HTTPSpec) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(HealthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(DebugSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSpec.
+func (in *HTTPSpec) DeepCopy() *HTTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthSpec) DeepCopyInto(out *HealthSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthSpec.
+func (in *HealthSpec) DeepCopy() *HealthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicySpec) DeepCopyInto(out *ImagePolicySpec) {
+	*out = *in
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockedTags != nil {
+		in, out := &in.BlockedTags, &out.BlockedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicySpec.
+func (in *ImagePolicySpec) DeepCopy() *ImagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InformerScopeSpec) DeepCopyInto(out *InformerScopeSpec) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ResyncPeriod = in.ResyncPeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InformerScopeSpec.
+func (in *InformerScopeSpec) DeepCopy() *InformerScopeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InformerScopeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InformersSpec) DeepCopyInto(out *InformersSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(InformerScopeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobConfigs != nil {
+		in, out := &in.JobConfigs, &out.JobConfigs
+		*out = new(InformerScopeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(InformerScopeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InformersSpec.
+func (in *InformersSpec) DeepCopy() *InformersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InformersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfigExecutionConfig) DeepCopyInto(out *JobConfigExecutionConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.MaxEnqueuedJobs != nil {
+		in, out := &in.MaxEnqueuedJobs, &out.MaxEnqueuedJobs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResourceUsageSampleIntervalSeconds != nil {
+		in, out := &in.ResourceUsageSampleIntervalSeconds, &out.ResourceUsageSampleIntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResourceRecommendationMarginPercent != nil {
+		in, out := &in.ResourceRecommendationMarginPercent, &out.ResourceRecommendationMarginPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResourcePrices != nil {
+		in, out := &in.ResourcePrices, &out.ResourcePrices
+		*out = new(ResourcePrices)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigExecutionConfig.
+func (in *JobConfigExecutionConfig) DeepCopy() *JobConfigExecutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfigExecutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobConfigExecutionConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobExecutionConfig) DeepCopyInto(out *JobExecutionConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.DefaultTTLSecondsAfterFinished != nil {
+		in, out := &in.DefaultTTLSecondsAfterFinished, &out.DefaultTTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultPendingTimeoutSeconds != nil {
+		in, out := &in.DefaultPendingTimeoutSeconds, &out.DefaultPendingTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultMaxRuntimeSeconds != nil {
+		in, out := &in.DefaultMaxRuntimeSeconds, &out.DefaultMaxRuntimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeleteKillingTasksTimeoutSeconds != nil {
+		in, out := &in.DeleteKillingTasksTimeoutSeconds, &out.DeleteKillingTasksTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ForceDeleteKillingTasksTimeoutSeconds != nil {
+		in, out := &in.ForceDeleteKillingTasksTimeoutSeconds, &out.ForceDeleteKillingTasksTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeLostReplaceTimeoutSeconds != nil {
+		in, out := &in.NodeLostReplaceTimeoutSeconds, &out.NodeLostReplaceTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxFinishedJobsPerJobConfig != nil {
+		in, out := &in.MaxFinishedJobsPerJobConfig, &out.MaxFinishedJobsPerJobConfig
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxConcurrentJobsPerNamespace != nil {
+		in, out := &in.MaxConcurrentJobsPerNamespace, &out.MaxConcurrentJobsPerNamespace
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxDetailedTaskRefs != nil {
+		in, out := &in.MaxDetailedTaskRefs, &out.MaxDetailedTaskRefs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NamespaceMaxConcurrentJobs != nil {
+		in, out := &in.NamespaceMaxConcurrentJobs, &out.NamespaceMaxConcurrentJobs
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GlobalStartRateLimit != nil {
+		in, out := &in.GlobalStartRateLimit, &out.GlobalStartRateLimit
+		*out = new(RateLimitSpec)
+		**out = **in
+	}
+	if in.NamespaceStartRateLimit != nil {
+		in, out := &in.NamespaceStartRateLimit, &out.NamespaceStartRateLimit
+		*out = new(RateLimitSpec)
+		**out = **in
+	}
+	if in.PausedNamespaces != nil {
+		in, out := &in.PausedNamespaces, &out.PausedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdhocJobQuota != nil {
+		in, out := &in.AdhocJobQuota, &out.AdhocJobQuota
+		*out = new(AdhocJobQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudEvents != nil {
+		in, out := &in.CloudEvents, &out.CloudEvents
+		*out = new(CloudEventsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(AlertingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Callbacks != nil {
+		in, out := &in.Callbacks, &out.Callbacks
+		*out = new(CallbacksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobExecutionConfig.
+func (in *JobExecutionConfig) DeepCopy() *JobExecutionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JobExecutionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobExecutionConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaSinkSpec) DeepCopyInto(out *KafkaSinkSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSinkSpec.
+func (in *KafkaSinkSpec) DeepCopy() *KafkaSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderElectionSpec) DeepCopyInto(out *LeaderElectionSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	out.LeaseDuration = in.LeaseDuration
+	out.RenewDeadline = in.RenewDeadline
+	out.RetryPeriod = in.RetryPeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionSpec.
+func (in *LeaderElectionSpec) DeepCopy() *LeaderElectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderElectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapConfigSpec.
-func (in *BootstrapConfigSpec) DeepCopy() *BootstrapConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
+func (in *MetricsSpec) DeepCopy() *MetricsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BootstrapConfigSpec)
+	out := new(MetricsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Concurrency) DeepCopyInto(out *Concurrency) {
+func (in *NATSTriggerSpec) DeepCopyInto(out *NATSTriggerSpec) {
 	*out = *in
+	if in.WaitTimeSeconds != nil {
+		in, out := &in.WaitTimeSeconds, &out.WaitTimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Concurrency.
-func (in *Concurrency) DeepCopy() *Concurrency {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATSTriggerSpec.
+func (in *NATSTriggerSpec) DeepCopy() *NATSTriggerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Concurrency)
+	out := new(NATSTriggerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerManagerConfigSpec) DeepCopyInto(out *ControllerManagerConfigSpec) {
+func (in *NotificationsSpec) DeepCopyInto(out *NotificationsSpec) {
 	*out = *in
-	if in.LeaderElection != nil {
-		in, out := &in.LeaderElection, &out.LeaderElection
-		*out = new(LeaderElectionSpec)
-		(*in).DeepCopyInto(*out)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailNotificationsSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerConfigSpec.
-func (in *ControllerManagerConfigSpec) DeepCopy() *ControllerManagerConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsSpec.
+func (in *NotificationsSpec) DeepCopy() *NotificationsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerManagerConfigSpec)
+	out := new(NotificationsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CronExecutionConfig) DeepCopyInto(out *CronExecutionConfig) {
+func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	if in.CronHashNames != nil {
-		in, out := &in.CronHashNames, &out.CronHashNames
-		*out = new(bool)
-		**out = **in
-	}
-	if in.CronHashSecondsByDefault != nil {
-		in, out := &in.CronHashSecondsByDefault, &out.CronHashSecondsByDefault
-		*out = new(bool)
-		**out = **in
-	}
-	if in.CronHashFields != nil {
-		in, out := &in.CronHashFields, &out.CronHashFields
-		*out = new(bool)
-		**out = **in
-	}
-	if in.DefaultTimezone != nil {
-		in, out := &in.DefaultTimezone, &out.DefaultTimezone
+	if in.UsernamePrefix != nil {
+		in, out := &in.UsernamePrefix, &out.UsernamePrefix
 		*out = new(string)
 		**out = **in
 	}
-	if in.MaxMissedSchedules != nil {
-		in, out := &in.MaxMissedSchedules, &out.MaxMissedSchedules
-		*out = new(int64)
+	if in.GroupsPrefix != nil {
+		in, out := &in.GroupsPrefix, &out.GroupsPrefix
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronExecutionConfig.
-func (in *CronExecutionConfig) DeepCopy() *CronExecutionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSpec.
+func (in *OIDCSpec) DeepCopy() *OIDCSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CronExecutionConfig)
+	out := new(OIDCSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CronExecutionConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicConfigsSpec) DeepCopyInto(out *DynamicConfigsSpec) {
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
-	if in.ConfigMap != nil {
-		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(ObjectReference)
-		**out = **in
-	}
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(ObjectReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicConfigsSpec.
-func (in *DynamicConfigsSpec) DeepCopy() *DynamicConfigsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
 	if in == nil {
 		return nil
 	}
-	out := new(DynamicConfigsSpec)
+	out := new(ObjectReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExecutionControllerConcurrencySpec) DeepCopyInto(out *ExecutionControllerConcurrencySpec) {
+func (in *PubSubTriggerSpec) DeepCopyInto(out *PubSubTriggerSpec) {
 	*out = *in
-	if in.Job != nil {
-		in, out := &in.Job, &out.Job
-		*out = new(Concurrency)
-		**out = **in
-	}
-	if in.JobConfig != nil {
-		in, out := &in.JobConfig, &out.JobConfig
-		*out = new(Concurrency)
-		**out = **in
-	}
-	if in.JobQueue != nil {
-		in, out := &in.JobQueue, &out.JobQueue
-		*out = new(Concurrency)
-		**out = **in
-	}
-	if in.Cron != nil {
-		in, out := &in.Cron, &out.Cron
-		*out = new(Concurrency)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionControllerConcurrencySpec.
-func (in *ExecutionControllerConcurrencySpec) DeepCopy() *ExecutionControllerConcurrencySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PubSubTriggerSpec.
+func (in *PubSubTriggerSpec) DeepCopy() *PubSubTriggerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExecutionControllerConcurrencySpec)
+	out := new(PubSubTriggerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExecutionControllerConfig) DeepCopyInto(out *ExecutionControllerConfig) {
+func (in *PushgatewaySinkSpec) DeepCopyInto(out *PushgatewaySinkSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.BootstrapConfigSpec.DeepCopyInto(&out.BootstrapConfigSpec)
-	in.ControllerManagerConfigSpec.DeepCopyInto(&out.ControllerManagerConfigSpec)
-	if in.ControllerConcurrency != nil {
-		in, out := &in.ControllerConcurrency, &out.ControllerConcurrency
-		*out = new(ExecutionControllerConcurrencySpec)
-		(*in).DeepCopyInto(*out)
+	if in.LabelKeys != nil {
+		in, out := &in.LabelKeys, &out.LabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionControllerConfig.
-func (in *ExecutionControllerConfig) DeepCopy() *ExecutionControllerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushgatewaySinkSpec.
+func (in *PushgatewaySinkSpec) DeepCopy() *PushgatewaySinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExecutionControllerConfig)
+	out := new(PushgatewaySinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ExecutionControllerConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExecutionWebhookConfig) DeepCopyInto(out *ExecutionWebhookConfig) {
+func (in *QueryServerSpec) DeepCopyInto(out *QueryServerSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.BootstrapConfigSpec.DeepCopyInto(&out.BootstrapConfigSpec)
-	if in.Webhooks != nil {
-		in, out := &in.Webhooks, &out.Webhooks
-		*out = new(WebhookServerSpec)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionWebhookConfig.
-func (in *ExecutionWebhookConfig) DeepCopy() *ExecutionWebhookConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryServerSpec.
+func (in *QueryServerSpec) DeepCopy() *QueryServerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExecutionWebhookConfig)
+	out := new(QueryServerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ExecutionWebhookConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPSpec) DeepCopyInto(out *HTTPSpec) {
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
 	*out = *in
-	if in.Metrics != nil {
-		in, out := &in.Metrics, &out.Metrics
-		*out = new(MetricsSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Health != nil {
-		in, out := &in.Health, &out.Health
-		*out = new(HealthSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSpec.
-func (in *HTTPSpec) DeepCopy() *HTTPSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPSpec)
+	out := new(RateLimitSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HealthSpec) DeepCopyInto(out *HealthSpec) {
+func (in *RateLimiterSpec) DeepCopyInto(out *RateLimiterSpec) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.BaseDelayMilliseconds != nil {
+		in, out := &in.BaseDelayMilliseconds, &out.BaseDelayMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxDelaySeconds != nil {
+		in, out := &in.MaxDelaySeconds, &out.MaxDelaySeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthSpec.
-func (in *HealthSpec) DeepCopy() *HealthSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimiterSpec.
+func (in *RateLimiterSpec) DeepCopy() *RateLimiterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HealthSpec)
+	out := new(RateLimiterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConfigExecutionConfig) DeepCopyInto(out *JobConfigExecutionConfig) {
+func (in *RemoteConfigLoaderSpec) DeepCopyInto(out *RemoteConfigLoaderSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	if in.MaxEnqueuedJobs != nil {
-		in, out := &in.MaxEnqueuedJobs, &out.MaxEnqueuedJobs
+	if in.S3Credentials != nil {
+		in, out := &in.S3Credentials, &out.S3Credentials
+		*out = new(AWSCredentialsSpec)
+		**out = **in
+	}
+	if in.PollIntervalSeconds != nil {
+		in, out := &in.PollIntervalSeconds, &out.PollIntervalSeconds
 		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigExecutionConfig.
-func (in *JobConfigExecutionConfig) DeepCopy() *JobConfigExecutionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteConfigLoaderSpec.
+func (in *RemoteConfigLoaderSpec) DeepCopy() *RemoteConfigLoaderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConfigExecutionConfig)
+	out := new(RemoteConfigLoaderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JobConfigExecutionConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePrices) DeepCopyInto(out *ResourcePrices) {
+	*out = *in
+	if in.CPUCoreHour != nil {
+		in, out := &in.CPUCoreHour, &out.CPUCoreHour
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-	return nil
+	if in.MemoryGiBHour != nil {
+		in, out := &in.MemoryGiBHour, &out.MemoryGiBHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePrices.
+func (in *ResourcePrices) DeepCopy() *ResourcePrices {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePrices)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobExecutionConfig) DeepCopyInto(out *JobExecutionConfig) {
+func (in *SQSTriggerSpec) DeepCopyInto(out *SQSTriggerSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	if in.DefaultTTLSecondsAfterFinished != nil {
-		in, out := &in.DefaultTTLSecondsAfterFinished, &out.DefaultTTLSecondsAfterFinished
-		*out = new(int64)
-		**out = **in
-	}
-	if in.DefaultPendingTimeoutSeconds != nil {
-		in, out := &in.DefaultPendingTimeoutSeconds, &out.DefaultPendingTimeoutSeconds
-		*out = new(int64)
-		**out = **in
-	}
-	if in.DeleteKillingTasksTimeoutSeconds != nil {
-		in, out := &in.DeleteKillingTasksTimeoutSeconds, &out.DeleteKillingTasksTimeoutSeconds
+	if in.WaitTimeSeconds != nil {
+		in, out := &in.WaitTimeSeconds, &out.WaitTimeSeconds
 		*out = new(int64)
 		**out = **in
 	}
-	if in.ForceDeleteKillingTasksTimeoutSeconds != nil {
-		in, out := &in.ForceDeleteKillingTasksTimeoutSeconds, &out.ForceDeleteKillingTasksTimeoutSeconds
+	if in.VisibilityTimeoutSeconds != nil {
+		in, out := &in.VisibilityTimeoutSeconds, &out.VisibilityTimeoutSeconds
 		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobExecutionConfig.
-func (in *JobExecutionConfig) DeepCopy() *JobExecutionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQSTriggerSpec.
+func (in *SQSTriggerSpec) DeepCopy() *SQSTriggerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JobExecutionConfig)
+	out := new(SQSTriggerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JobExecutionConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LeaderElectionSpec) DeepCopyInto(out *LeaderElectionSpec) {
+func (in *ShardingSpec) DeepCopyInto(out *ShardingSpec) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	out.LeaseDuration = in.LeaseDuration
-	out.RenewDeadline = in.RenewDeadline
-	out.RetryPeriod = in.RetryPeriod
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElectionSpec.
-func (in *LeaderElectionSpec) DeepCopy() *LeaderElectionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingSpec.
+func (in *ShardingSpec) DeepCopy() *ShardingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LeaderElectionSpec)
+	out := new(ShardingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
+func (in *StatsDSpec) DeepCopyInto(out *StatsDSpec) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
 		**out = **in
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
-func (in *MetricsSpec) DeepCopy() *MetricsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatsDSpec.
+func (in *StatsDSpec) DeepCopy() *StatsDSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricsSpec)
+	out := new(StatsDSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
 	*out = *in
+	out.JobConfig = in.JobConfig
+	if in.OptionValues != nil {
+		in, out := &in.OptionValues, &out.OptionValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SQS != nil {
+		in, out := &in.SQS, &out.SQS
+		*out = new(SQSTriggerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PubSub != nil {
+		in, out := &in.PubSub, &out.PubSub
+		*out = new(PubSubTriggerSpec)
+		**out = **in
+	}
+	if in.NATS != nil {
+		in, out := &in.NATS, &out.NATS
+		*out = new(NATSTriggerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
-func (in *ObjectReference) DeepCopy() *ObjectReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSpec.
+func (in *TriggerSpec) DeepCopy() *TriggerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectReference)
+	out := new(TriggerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -435,6 +1594,11 @@ func (in *ObjectReference) DeepCopy() *ObjectReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookServerSpec) DeepCopyInto(out *WebhookServerSpec) {
 	*out = *in
+	if in.CertProvisioner != nil {
+		in, out := &in.CertProvisioner, &out.CertProvisioner
+		*out = new(CertProvisionerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookServerSpec.
@@ -20,4 +20,8 @@ const (
 	// DeleteDependentsFinalizer is used by JobController to ensure that dependents
 	// are deleted before updating the FinishStatus during finalization.
 	DeleteDependentsFinalizer = GroupName + "/delete-dependents-finalizer"
+
+	// ArchiveFinalizer is used by ArchiveController to ensure that Jobs are
+	// written to the archive before they are deleted.
+	ArchiveFinalizer = GroupName + "/archive-finalizer"
 )
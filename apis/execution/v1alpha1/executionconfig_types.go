@@ -0,0 +1,160 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExecutionConfigSpec defines overrides of the dynamic execution configuration
+// for all Jobs and JobConfigs in a namespace. Fields that are not specified
+// fall back to the global dynamic configuration.
+type ExecutionConfigSpec struct {
+	// Jobs overrides the configuration for Jobs in this namespace.
+	//
+	// +optional
+	Jobs *JobExecutionConfigOverrides `json:"jobs,omitempty"`
+
+	// Cron overrides the configuration for scheduling JobConfigs in this
+	// namespace.
+	//
+	// +optional
+	Cron *CronExecutionConfigOverrides `json:"cron,omitempty"`
+
+	// TaskTemplateDefaults specifies default values that will be merged into the
+	// task templates of all JobConfigs and Jobs created in this namespace. Values
+	// that are already specified in the task template take precedence.
+	//
+	// +optional
+	TaskTemplateDefaults *TaskTemplateDefaults `json:"taskTemplateDefaults,omitempty"`
+}
+
+// JobExecutionConfigOverrides defines overrides of the global
+// JobExecutionConfig. Field names correspond to those in JobExecutionConfig.
+type JobExecutionConfigOverrides struct {
+	// DefaultTTLSecondsAfterFinished is the default time-to-live (TTL) for a Job
+	// after it has finished.
+	//
+	// +optional
+	DefaultTTLSecondsAfterFinished *int64 `json:"defaultTTLSecondsAfterFinished,omitempty"`
+
+	// DefaultPendingTimeoutSeconds is default timeout to use if job does not
+	// specify the pending timeout. Set to 0 to disable the default pending
+	// timeout.
+	//
+	// +optional
+	DefaultPendingTimeoutSeconds *int64 `json:"defaultPendingTimeoutSeconds,omitempty"`
+
+	// DefaultMaxRuntimeSeconds is the default maximum duration that a Job may run
+	// for from its start time, if the Job does not specify maxRuntimeSeconds. Set
+	// to 0 to disable.
+	//
+	// +optional
+	DefaultMaxRuntimeSeconds *int64 `json:"defaultMaxRuntimeSeconds,omitempty"`
+
+	// TTLAfterFinishedPolicy specifies what to do with a Job once its TTL after it
+	// has finished has expired.
+	//
+	// +kubebuilder:validation:Enum=DeleteJob;DeleteTasks
+	// +optional
+	TTLAfterFinishedPolicy string `json:"ttlAfterFinishedPolicy,omitempty"`
+
+	// MaxFinishedJobsPerJobConfig is the maximum number of finished Jobs to retain
+	// for each JobConfig. Set to 0 to disable.
+	//
+	// +optional
+	MaxFinishedJobsPerJobConfig *int64 `json:"maxFinishedJobsPerJobConfig,omitempty"`
+
+	// MaxConcurrentJobs is the maximum number of Jobs that can be running
+	// concurrently in this namespace. Set to 0 to disable the limit for this
+	// namespace.
+	//
+	// +optional
+	MaxConcurrentJobs *int64 `json:"maxConcurrentJobs,omitempty"`
+}
+
+// CronExecutionConfigOverrides defines overrides of the global
+// CronExecutionConfig. Field names correspond to those in CronExecutionConfig.
+type CronExecutionConfigOverrides struct {
+	// DefaultTimezone defines a default timezone to use for JobConfigs that do not
+	// specify a timezone.
+	//
+	// +optional
+	DefaultTimezone *string `json:"defaultTimezone,omitempty"`
+
+	// MaxMissedSchedules defines a maximum number of jobs that the controller
+	// should back-schedule after coming back up from downtime. Set this to 0 to
+	// disable back-scheduling.
+	//
+	// +optional
+	MaxMissedSchedules *int64 `json:"maxMissedSchedules,omitempty"`
+}
+
+// TaskTemplateDefaults defines default values for the PodTemplateSpec of tasks.
+type TaskTemplateDefaults struct {
+	// Labels will be added to the task template's labels, if the label key is not
+	// already specified.
+	//
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ServiceAccountName will be used as the task template's serviceAccountName if
+	// it is not specified.
+	//
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// SecurityContext will be used as the task template's pod-level
+	// securityContext if it is not specified.
+	//
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+}
+
+// nolint:lll
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=furikoexecutionconfig;furikoexecutionconfigs,categories=furiko
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ExecutionConfig overrides the dynamic execution configuration for all Jobs
+// and JobConfigs in its namespace, taking precedence over the global dynamic
+// configuration. If multiple ExecutionConfigs exist in the same namespace, they
+// are merged in lexicographical order of their names, such that later names
+// take precedence.
+type ExecutionConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ExecutionConfigSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExecutionConfigList contains a list of ExecutionConfig objects.
+type ExecutionConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExecutionConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExecutionConfig{}, &ExecutionConfigList{})
+}
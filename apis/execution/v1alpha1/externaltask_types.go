@@ -0,0 +1,167 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExternalTaskTemplate describes how to run a task on an external runner,
+// outside of the Kubernetes cluster.
+type ExternalTaskTemplate struct {
+	// Name of the external runner that should run the task. External runners
+	// are agents that run outside of the cluster, which watch for ExternalTasks
+	// that are assigned to them and report the status of the task back.
+	RunnerName string `json:"runnerName"`
+
+	// Command to run on the external runner. Supports context variable
+	// substitution.
+	Command []string `json:"command"`
+
+	// Optional list of environment variables to set when running the command.
+	// Values support context variable substitution.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []ExternalTaskEnvVar `json:"env,omitempty"`
+
+	// Optional working directory to run the command in. If not specified, the
+	// runner's default working directory will be used.
+	//
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+}
+
+// ExternalTaskEnvVar is an environment variable to set for an ExternalTask.
+type ExternalTaskEnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	//
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// ExternalTaskSpec defines the desired state of an ExternalTask.
+type ExternalTaskSpec struct {
+	ExternalTaskTemplate `json:",inline"`
+
+	// If specified, the runner should kill the task once the kill timestamp has
+	// passed, and report its status as Failed.
+	//
+	// +optional
+	KillTimestamp *metav1.Time `json:"killTimestamp,omitempty"`
+}
+
+// ExternalTaskStatus defines the observed state of an ExternalTask, as
+// reported by its runner.
+type ExternalTaskStatus struct {
+	// Phase of the ExternalTask.
+	//
+	// +optional
+	Phase ExternalTaskPhase `json:"phase,omitempty"`
+
+	// Time at which the runner started running the command.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Time at which the command finished running.
+	//
+	// +optional
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+
+	// Exit code of the command, if it has finished running.
+	//
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Unique, one-word, CamelCase reason for the task's status.
+	//
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Descriptive message for the task's status.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Optional key/value outputs emitted by the task.
+	//
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// ExternalTaskPhase is the phase of an ExternalTask.
+type ExternalTaskPhase string
+
+const (
+	// ExternalTaskPending means that the task has not yet been started by a
+	// runner.
+	ExternalTaskPending ExternalTaskPhase = "Pending"
+
+	// ExternalTaskRunning means that the task is currently running.
+	ExternalTaskRunning ExternalTaskPhase = "Running"
+
+	// ExternalTaskSucceeded means that the command exited with a zero exit code.
+	ExternalTaskSucceeded ExternalTaskPhase = "Succeeded"
+
+	// ExternalTaskFailed means that the command exited with a non-zero exit
+	// code, was killed, or could not be started.
+	ExternalTaskFailed ExternalTaskPhase = "Failed"
+)
+
+// IsTerminal returns true if the phase is terminal.
+func (p ExternalTaskPhase) IsTerminal() bool {
+	return p == ExternalTaskSucceeded || p == ExternalTaskFailed
+}
+
+// nolint:lll
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=furikoexternaltask;furikoexternaltasks,categories=furiko
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:printcolumn:name="Runner",type=string,JSONPath=`.spec.runnerName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Exit Code",type=string,JSONPath=`.status.exitCode`
+
+// ExternalTask is the schema for a single task of a Job that is run on an
+// external runner outside of the cluster, instead of as a Pod.
+type ExternalTask struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExternalTaskSpec   `json:"spec,omitempty"`
+	Status ExternalTaskStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExternalTaskList contains a list of ExternalTask objects.
+type ExternalTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExternalTask `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ExternalTask{}, &ExternalTaskList{})
+}
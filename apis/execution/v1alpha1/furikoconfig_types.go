@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+// FurikoConfigSpec defines the dynamic configuration for all Jobs and
+// JobConfigs in the cluster. Each field corresponds to a config name in the
+// dynamic ConfigMap, and fields that are specified here take precedence over
+// those in the ConfigMap.
+type FurikoConfigSpec struct {
+	// Jobs defines the configuration for Jobs.
+	//
+	// +optional
+	Jobs *configv1alpha1.JobExecutionConfig `json:"jobs,omitempty"`
+
+	// JobConfigs defines the configuration for JobConfigs.
+	//
+	// +optional
+	JobConfigs *configv1alpha1.JobConfigExecutionConfig `json:"jobConfigs,omitempty"`
+
+	// Cron defines the configuration for scheduling JobConfigs.
+	//
+	// +optional
+	Cron *configv1alpha1.CronExecutionConfig `json:"cron,omitempty"`
+
+	// Controllers defines the configuration for the controllers.
+	//
+	// +optional
+	Controllers *configv1alpha1.ControllerExecutionConfig `json:"controllers,omitempty"`
+}
+
+// FurikoConfigStatus defines the observed state of a FurikoConfig.
+type FurikoConfigStatus struct {
+	// ObservedGeneration is the most recent generation of the FurikoConfig that
+	// was loaded.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions describe the current state of the FurikoConfig.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+const (
+	// FurikoConfigConditionLoaded indicates whether the latest generation of the
+	// FurikoConfig was successfully loaded.
+	FurikoConfigConditionLoaded = "Loaded"
+)
+
+// nolint:lll
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=furikoconfig;furikoconfigs,categories=furiko
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Loaded",type=string,JSONPath=`.status.conditions[?(@.type=="Loaded")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// FurikoConfig is a cluster-scoped dynamic configuration for Furiko, which is
+// validated against its schema on admission and takes precedence over the
+// dynamic ConfigMap. The status reports the last generation that was loaded.
+type FurikoConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FurikoConfigSpec   `json:"spec,omitempty"`
+	Status FurikoConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FurikoConfigList contains a list of FurikoConfig objects.
+type FurikoConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FurikoConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FurikoConfig{}, &FurikoConfigList{})
+}
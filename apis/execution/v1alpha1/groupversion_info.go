@@ -26,8 +26,13 @@ import (
 const (
 	Version = "v1alpha1"
 
-	KindJob       = "Job"
-	KindJobConfig = "JobConfig"
+	KindJob             = "Job"
+	KindJobConfig       = "JobConfig"
+	KindExternalTask    = "ExternalTask"
+	KindExecutionConfig = "ExecutionConfig"
+	KindJobGroup        = "JobGroup"
+	KindTaskTemplate    = "TaskTemplate"
+	KindFurikoConfig    = "FurikoConfig"
 )
 
 var (
@@ -49,8 +54,13 @@ var SchemeGroupVersion = schema.GroupVersion{
 
 // Declare schema.GroupVersionKind for each Kind in this Group.
 var (
-	GVKJob       = SchemeGroupVersion.WithKind(KindJob)
-	GVKJobConfig = SchemeGroupVersion.WithKind(KindJobConfig)
+	GVKJob             = SchemeGroupVersion.WithKind(KindJob)
+	GVKJobConfig       = SchemeGroupVersion.WithKind(KindJobConfig)
+	GVKExternalTask    = SchemeGroupVersion.WithKind(KindExternalTask)
+	GVKExecutionConfig = SchemeGroupVersion.WithKind(KindExecutionConfig)
+	GVKJobGroup        = SchemeGroupVersion.WithKind(KindJobGroup)
+	GVKTaskTemplate    = SchemeGroupVersion.WithKind(KindTaskTemplate)
+	GVKFurikoConfig    = SchemeGroupVersion.WithKind(KindFurikoConfig)
 )
 
 func Resource(resource string) schema.GroupResource {
@@ -18,7 +18,9 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // JobSpec defines the desired state of a Job.
@@ -95,12 +97,36 @@ type JobSpec struct {
 	// +optional
 	KillTimestamp *metav1.Time `json:"killTimestamp,omitempty"`
 
+	// Specifies whether the Job is suspended. If the Job is not yet started, it
+	// will remain queued and will not be started until it is resumed. If the Job is
+	// already started, no new tasks will be created and any active tasks will be
+	// killed, but the Job will not be considered finished. Setting this field back
+	// to false resumes the Job, which will create new tasks as usual. Tasks that
+	// were killed due to suspension do not count towards the maximum attempts.
+	//
+	// For Jobs with steps, active steps will also be killed, and will be started
+	// again from the beginning once the Job is resumed. Suspending a Job has no
+	// effect once killTimestamp is set.
+	//
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
 	// Specifies the maximum lifetime of a Job that is finished. If not set, it will
 	// be set to the DefaultTTLSecondsAfterFinished configuration value in the
 	// controller.
 	//
 	// +optional
 	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// Specifies the duration in seconds to retain the tasks of the Job after they
+	// have finished, which can be used to inspect finished tasks for debugging.
+	// Once elapsed, finished tasks will be deleted even if the Job has not yet
+	// been deleted, independent of ttlSecondsAfterFinished. The status of deleted
+	// tasks is still retained in the Job's status. If not set, finished tasks will
+	// be retained until the Job is deleted.
+	//
+	// +optional
+	RetainFinishedTasksSeconds *int64 `json:"retainFinishedTasksSeconds,omitempty"`
 }
 
 type JobType string
@@ -125,6 +151,62 @@ type StartPolicySpec struct {
 	//
 	// +optional
 	StartAfter *metav1.Time `json:"startAfter,omitempty"`
+
+	// Specifies a list of dependencies that must be satisfied before the Job can be
+	// started. The Job will remain in the Queued phase until all dependencies are
+	// satisfied. Can be specified together with other fields.
+	//
+	// +optional
+	DependsOn []JobDependency `json:"dependsOn,omitempty"`
+
+	// Specifies the maximum duration in seconds, relative to the creation time of
+	// the Job, to wait for all dependencies to be satisfied. Once exceeded, the Job
+	// will not be started and will terminate with AdmissionError. If not specified,
+	// the Job will wait indefinitely.
+	//
+	// +optional
+	DependsOnTimeoutSeconds *int64 `json:"dependsOnTimeoutSeconds,omitempty"`
+
+	// Specifies the maximum duration in seconds that the Job may remain queued
+	// after it is due to start, which is the later of its schedule time (or
+	// creation time if it was not scheduled) and startAfter. Once exceeded, the Job
+	// will not be started and will finish with the Expired result. If not
+	// specified, defaults to the JobConfig's concurrency.expireAfterSeconds;
+	// otherwise the Job may remain queued indefinitely.
+	//
+	// +optional
+	ExpireAfterSeconds *int64 `json:"expireAfterSeconds,omitempty"`
+
+	// Specifies the priority of the Job relative to other queued Jobs of the same
+	// JobConfig. Queued Jobs with a higher priority will be started first, and Jobs
+	// with the same priority will be started in order of their schedule time (or
+	// creation time if not scheduled). Defaults to 0.
+	//
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// JobDependency refers to a single dependency of a Job. Exactly one of the
+// fields must be specified.
+type JobDependency struct {
+	// Name of a Job in the same namespace. The dependency is satisfied once the
+	// referenced Job has succeeded.
+	//
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// Name of a JobConfig in the same namespace. The dependency is satisfied once
+	// the latest Job of the referenced JobConfig has succeeded within the current
+	// day.
+	//
+	// +optional
+	JobConfigName string `json:"jobConfigName,omitempty"`
+
+	// Timezone used to determine the start of the current day when evaluating a
+	// jobConfigName dependency. Defaults to UTC.
+	//
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
 }
 
 type JobTemplate struct {
@@ -135,9 +217,26 @@ type JobTemplate struct {
 }
 
 type JobTemplateSpec struct {
-	// Describes the tasks to be created for the Job.
+	// Describes the tasks to be created for the Job. Must be specified if steps is
+	// empty, otherwise it is ignored.
+	//
+	// +optional
 	Task JobTaskSpec `json:"task"`
 
+	// Describes a list of named steps to be executed for the Job. Each step creates
+	// a single task, and a step will only be started once all of the steps it
+	// depends on have succeeded. Steps without any dependencies between them may be
+	// run in parallel. If any step fails, no further steps will be started and the
+	// Job will terminate once all running steps are finished.
+	//
+	// Each step is attempted only once, and maxAttempts and retryDelaySeconds do
+	// not apply to Jobs with steps.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Steps []JobStepSpec `json:"steps,omitempty"`
+
 	// Specifies maximum number of attempts for the Job. Each attempt will create a
 	// single task at a time, and if the task fails, the controller will wait
 	// retryDelaySeconds before creating the next task attempt. Once maxAttempts is
@@ -153,10 +252,167 @@ type JobTemplateSpec struct {
 	//
 	// +optional
 	RetryDelaySeconds *int64 `json:"retryDelaySeconds,omitempty"`
+
+	// Optional maximum number of additional attempts for tasks that failed due to
+	// an init container failure, which is reported with the InitFailed reason.
+	// Tasks that failed in this manner will not count towards maxAttempts, up to
+	// the number specified here. Useful if init containers are known to fail
+	// transiently, e.g. when fetching dependencies. If not specified, init
+	// container failures will count towards maxAttempts. Value must be a
+	// non-negative integer.
+	//
+	// +optional
+	MaxInitFailureRetries *int32 `json:"maxInitFailureRetries,omitempty"`
+
+	// Optional maximum number of additional attempts for tasks that were evicted,
+	// such as due to preemption, node drains or node pressure, which is reported
+	// with the Evicted reason. Tasks that were evicted will be recreated
+	// immediately without waiting for retryDelaySeconds, and will not count
+	// towards maxAttempts, up to the number specified here. If not specified,
+	// evicted tasks will count towards maxAttempts. Value must be a non-negative
+	// integer.
+	//
+	// +optional
+	MaxEvictionRetries *int32 `json:"maxEvictionRetries,omitempty"`
+
+	// Optional maximum duration in seconds that the Job may run for, computed from
+	// the time that the Job was started. Once exceeded, a killTimestamp will be
+	// automatically set on the Job, and all of its tasks will be killed. Takes
+	// precedence over defaultMaxRuntimeSeconds in the controller configuration.
+	// Set to 0 to disable. Value must be a non-negative integer.
+	//
+	// +optional
+	MaxRuntimeSeconds *int64 `json:"maxRuntimeSeconds,omitempty"`
+
+	// Optional template used to generate the names of tasks created for the Job,
+	// which can be used to give tasks more meaningful names in logging systems.
+	// If not specified, tasks will be named after the Job, suffixed with either
+	// the retry index or the step name.
+	//
+	// The following context variables are supported:
+	//
+	//  - ${job.name}: Name of the Job.
+	//  - ${task.retry_index}: Retry index of the task, starting from 1.
+	//  - ${task.step}: Name of the step that the task was created for.
+	//  - ${task.hash}: Short hash that uniquely identifies the task.
+	//
+	// The controller guarantees that generated names are unique and valid. Any
+	// invalid characters are replaced with dashes, and a short hash will be
+	// appended to the name if the template does not reference ${task.hash}, or
+	// both ${job.name} and ${task.retry_index} (or ${task.step} for Jobs with
+	// steps). Names longer than 63 characters will be truncated and suffixed with
+	// the hash.
+	//
+	// +optional
+	TaskNameTemplate string `json:"taskNameTemplate,omitempty"`
+
+	// Specifies how tasks are named when a failed task is retried. Defaults to
+	// NewTask, where each retry creates a task with a new name derived from its
+	// retry index. If set to InPlace, the previous task will be deleted before its
+	// replacement is created with the same name, so that systems which are keyed
+	// on the task's identity observe a single task across retries. The retry index
+	// of each task is still recorded in the Job's status. Cannot be used together
+	// with volumeClaimTemplates.
+	//
+	// +optional
+	RetryMode RetryMode `json:"retryMode,omitempty"`
+
+	// Specifies what happens to the tasks of the Job when the Job is deleted.
+	// Defaults to Delete, where all tasks are killed and deleted before the Job is
+	// removed. If set to Orphan, tasks will be left running and are no longer
+	// owned by the Job, and will be labeled with execution.furiko.io/task-orphaned
+	// so that they can be cleaned up externally. Useful for workloads where
+	// in-flight work must not be interrupted by deletion of the Job. Cannot be set
+	// to Orphan together with external.
+	//
+	// +optional
+	TaskDeletionPolicy TaskDeletionPolicy `json:"taskDeletionPolicy,omitempty"`
+
+	// Optional list of patches to be conditionally applied to the pod template of
+	// each task when it is created. Patches are applied in order, and only if
+	// their condition evaluates to true. Context variable substitution is
+	// performed after all patches are applied.
+	//
+	// +optional
+	Patches []JobTemplatePatch `json:"patches,omitempty"`
+
+	// Optional HTTP callback that is invoked when the Job finishes, which allows
+	// upstream systems to wait for the Job without watching the Kubernetes API.
+	//
+	// +optional
+	Callback *CallbackSpec `json:"callback,omitempty"`
+}
+
+// CallbackSpec specifies an HTTP callback that is invoked when a Job finishes.
+//
+// The callback is sent as a JSON payload using HTTP POST, and is signed using
+// HMAC-SHA256 with the signing key. The hex-encoded signature is sent in the
+// X-Furiko-Signature header, prefixed with "sha256=". Failed deliveries are
+// retried with exponential backoff.
+type CallbackSpec struct {
+	// URL that the callback will be sent to. Must be an absolute http or https URL.
+	URL string `json:"url"`
+
+	// Reference to a key in a Secret in the same namespace as the Job, which
+	// contains the key used to sign the payload.
+	SigningKeySecretRef corev1.SecretKeySelector `json:"signingKeySecretRef"`
+}
+
+// JobTemplatePatch describes a patch that is conditionally applied to the pod
+// template of a task.
+type JobTemplatePatch struct {
+	// Condition that must be satisfied for the patch to be applied.
+	Condition JobTemplatePatchCondition `json:"condition"`
+
+	// Strategic merge patch to be applied to the pod template of the task (i.e.
+	// the PodTemplateSpec of the task, including its metadata and spec).
+	//
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Patch runtime.RawExtension `json:"patch"`
+}
+
+// JobTemplatePatchCondition describes a condition on a context variable, such
+// as the value of an option.
+type JobTemplatePatchCondition struct {
+	// Name of the context variable to evaluate, without the enclosing braces (e.g.
+	// option.use_gpu).
+	Variable string `json:"variable"`
+
+	// List of values that the context variable is compared against. The condition
+	// is satisfied if the value of the variable is equal to any of the values. If
+	// not specified, the condition is satisfied if the value of the variable is
+	// "true".
+	//
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// JobStepSpec describes a single named step in the Job.
+type JobStepSpec struct {
+	// Name of the step. Must be a valid DNS label that is unique among all steps
+	// in the Job, and cannot consist of only digits.
+	Name string `json:"name"`
+
+	// Names of other steps that must have succeeded before this step is started.
+	//
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Describes the task to be created for the step.
+	Task JobTaskSpec `json:"task"`
 }
 
 // JobTaskSpec describes a single task in the Job.
 type JobTaskSpec struct {
+	// Optional reference to a TaskTemplate in the same namespace, which is used
+	// as the base for this task. Any fields specified in this task will take
+	// precedence over those in the TaskTemplate, with lists such as containers
+	// being merged by name. The reference is resolved once the Job is created.
+	//
+	// +optional
+	TemplateRef *TaskTemplateReference `json:"templateRef,omitempty"`
+
 	// Describes how to create tasks as Pods.
 	//
 	// The following fields support context variable substitution:
@@ -165,8 +421,20 @@ type JobTaskSpec struct {
 	//  - .spec.containers.*.command.*
 	//  - .spec.containers.*.args.*
 	//  - .spec.containers.*.env.*.value
+	//
+	// Not required if external is specified.
+	//
+	// +optional
 	Template corev1.PodTemplateSpec `json:"template"`
 
+	// Describes how to run tasks on an external runner outside of the cluster,
+	// instead of as Pods. If specified, the template will be ignored, and each
+	// task will be created as an ExternalTask that is picked up by the runner.
+	// Cannot be specified for Jobs with steps.
+	//
+	// +optional
+	External *ExternalTaskTemplate `json:"external,omitempty"`
+
 	// Optional duration in seconds to wait before terminating the task if it is
 	// still pending. This field is useful to prevent jobs from being stuck forever
 	// if the Job has a deadline to start running by. If not set, it will be set to
@@ -176,6 +444,27 @@ type JobTaskSpec struct {
 	// +optional
 	PendingTimeoutSeconds *int64 `json:"pendingTimeoutSeconds,omitempty"`
 
+	// Optional list of reasons that a pending task may be waiting for, which
+	// should terminate the task immediately instead of waiting for the pending
+	// timeout to be exceeded. This is useful for failing fast on errors that are
+	// unlikely to be resolved by waiting, such as ErrImagePull, ImagePullBackOff
+	// or CreateContainerConfigError. Tasks that are terminated in this manner
+	// will have a result of PendingTimeout.
+	//
+	// +optional
+	FailFastPendingReasons []string `json:"failFastPendingReasons,omitempty"`
+
+	// Optional duration in seconds to wait before terminating the task if it is
+	// still running, counting from the time that the task started running. Unlike
+	// PendingTimeoutSeconds, this does not include the time taken for the task to
+	// be scheduled and started. Tasks that are terminated due to the running
+	// timeout will have a result of DeadlineExceeded. If not set, the task may run
+	// indefinitely.
+	//
+	// Value must be a non-negative integer.
+	// +optional
+	RunningTimeoutSeconds *int64 `json:"runningTimeoutSeconds,omitempty"`
+
 	// ForbidForceDeletion, if true, means that tasks are not allowed to be
 	// force deleted. If the node is unresponsive, it may be possible that the task
 	// cannot be killed by normal graceful deletion. The controller may choose to
@@ -188,6 +477,106 @@ type JobTaskSpec struct {
 	//
 	// +optional
 	ForbidForceDeletion bool `json:"forbidForceDeletion,omitempty"`
+
+	// Optional duration in seconds that tasks are given to terminate gracefully
+	// when they are killed. This is used as the termination grace period of the
+	// task, and the controller will also wait for this duration after the kill
+	// timestamp before resorting to killing the task via deletion. Useful for
+	// workloads that need a long time to checkpoint before exiting. If not set,
+	// it will use the DeleteKillingTasksTimeoutSeconds configuration value in the
+	// controller, and the termination grace period specified in the task template.
+	//
+	// Value must be a non-negative integer.
+	// +optional
+	KillGracePeriodSeconds *int64 `json:"killGracePeriodSeconds,omitempty"`
+
+	// Optional hook that is executed in a container of the task before it is
+	// killed, which allows tasks to checkpoint or clean up before they are
+	// terminated. The task will only be killed once the hook exits or its timeout
+	// has elapsed, after which the normal kill and deletion escalation proceeds.
+	// Hooks are only executed for tasks that are running, and cannot be used
+	// together with external.
+	//
+	// +optional
+	PreKillHook *TaskPreKillHook `json:"preKillHook,omitempty"`
+
+	// Optional settings to detect tasks that are stalled, i.e. running tasks
+	// that have not reported any progress for a configured duration. This can be
+	// used to catch hung tasks that would otherwise run until their deadline.
+	// Tasks report progress using the task-progress-percent and
+	// task-progress-message annotations, and cannot be used together with
+	// external.
+	//
+	// +optional
+	StallDetection *TaskStallDetection `json:"stallDetection,omitempty"`
+
+	// Optional list of container names that must succeed for the task to be
+	// considered successful. Failures of all other containers, such as best-effort
+	// sidecars, will be ignored when determining the result of the task. If not
+	// specified, all containers must succeed.
+	//
+	// +optional
+	RequiredContainers []string `json:"requiredContainers,omitempty"`
+
+	// Optional list of ephemeral PersistentVolumeClaims to be created for each
+	// task, which can be used as scratch space. Each claim will be added as a
+	// volume with the given name to the task's pod, which can then be mounted by
+	// its containers. Claims are owned by the task, and will be deleted once the
+	// task is finished, or when the task is deleted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	VolumeClaimTemplates []TaskVolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+}
+
+// TaskPreKillHook describes a command to be executed in a container of a task
+// before the task is killed.
+type TaskPreKillHook struct {
+	// Name of the container to execute the command in. If not specified, defaults
+	// to the first container in the task template.
+	//
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Command to execute in the container. The command is not run in a shell. To
+	// send a custom signal to the main process instead, a command such as
+	// ["kill", "-USR1", "1"] can be used.
+	Command []string `json:"command"`
+
+	// Optional duration in seconds to wait for the command to exit, after which
+	// the task will be killed regardless.
+	//
+	// Default: 30
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// TaskStallDetection describes how to detect and handle stalled tasks.
+type TaskStallDetection struct {
+	// Duration in seconds that a running task can go without reporting any
+	// progress before it is considered to be stalled. The duration is measured
+	// from the last progress update, or from when the task started running if it
+	// has not reported any progress.
+	TimeoutSeconds int64 `json:"timeoutSeconds"`
+
+	// If true, stalled tasks will be killed, and subsequently retried if the Job
+	// has remaining attempts. Otherwise, only an event will be emitted for each
+	// stalled task.
+	//
+	// +optional
+	KillStalledTasks bool `json:"killStalledTasks,omitempty"`
+}
+
+// TaskVolumeClaimTemplate describes a PersistentVolumeClaim to be created for
+// each task.
+type TaskVolumeClaimTemplate struct {
+	// Name of the volume to be added to the task. Must be a valid DNS label, and
+	// must not conflict with the names of other volumes in the pod template.
+	Name string `json:"name"`
+
+	// Spec of the PersistentVolumeClaim to be created.
+	Spec corev1.PersistentVolumeClaimSpec `json:"spec"`
 }
 
 // JobStatus defines the observed state of a Job.
@@ -198,6 +587,18 @@ type JobStatus struct {
 	// Condition stores details about the Job's current condition.
 	Condition JobCondition `json:"condition"`
 
+	// Conditions store the standard conditions of the Job, which allow generic
+	// tooling to inspect the status of the Job. The Progressing and Finished
+	// conditions are always set once the Job has been reconciled. More details
+	// about the Job's current condition can be found in Condition.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
 	// StartTime specifies the time that the Job was started by the controller. If
 	// nil, it means that the Job is Queued. Cannot be changed once set.
 	//
@@ -218,6 +619,135 @@ type JobStatus struct {
 	// +patchStrategy=merge
 	// +listType=atomic
 	Tasks []TaskRef `json:"tasks,omitempty"`
+
+	// Steps contains the status of each step, if the Job specifies steps in its
+	// template.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Steps []JobStepStatus `json:"steps,omitempty"`
+
+	// Outputs contains the key/value outputs emitted by all tasks of the Job. If
+	// multiple tasks emit the same key, the value from the latest created task
+	// takes precedence.
+	//
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// ResourceUsage contains the total resource usage of all tasks of the Job. CPU
+	// time and estimated costs are summed across all tasks, while peak memory is
+	// the highest peak memory of any single task.
+	//
+	// +optional
+	ResourceUsage *TaskResourceUsage `json:"resourceUsage,omitempty"`
+
+	// TemplateRevision is the revision of the JobConfig's template that the Job was
+	// started with. It is only set for Jobs that were created from a JobConfig.
+	//
+	// +optional
+	TemplateRevision string `json:"templateRevision,omitempty"`
+
+	// QueueKey determines the order in which queued Jobs with the same priority are
+	// started, with smaller keys being started first. It is computed from the
+	// schedule time of the Job and persisted when the Job is first reconciled, so
+	// that the start order remains stable across controller restarts.
+	//
+	// +optional
+	QueueKey string `json:"queueKey,omitempty"`
+
+	// Callback contains the delivery status of the Job's callback, if the Job
+	// specifies a callback in its template.
+	//
+	// +optional
+	Callback *CallbackStatus `json:"callback,omitempty"`
+}
+
+// CallbackStatus describes the delivery status of a Job's callback.
+type CallbackStatus struct {
+	// Phase of the callback delivery.
+	Phase CallbackPhase `json:"phase"`
+
+	// Number of delivery attempts made so far.
+	Attempts int32 `json:"attempts"`
+
+	// Time of the most recent delivery attempt.
+	//
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// HTTP status code returned by the most recent delivery attempt, if a
+	// response was received.
+	//
+	// +optional
+	LastResponseCode int32 `json:"lastResponseCode,omitempty"`
+
+	// Human-readable message describing why the most recent delivery attempt
+	// failed, if any.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CallbackPhase is the phase of a Job's callback delivery.
+type CallbackPhase string
+
+const (
+	// CallbackPending means that the callback has not yet been delivered, and
+	// will be retried after a backoff.
+	CallbackPending CallbackPhase = "Pending"
+
+	// CallbackDelivered means that the callback was delivered successfully.
+	CallbackDelivered CallbackPhase = "Delivered"
+
+	// CallbackFailed means that all delivery attempts have failed, and the
+	// callback will not be retried.
+	CallbackFailed CallbackPhase = "Failed"
+)
+
+// JobStepStatus describes the observed state of a single step in the Job.
+type JobStepStatus struct {
+	// Name of the step.
+	Name string `json:"name"`
+
+	// Phase of the step.
+	Phase JobStepPhase `json:"phase"`
+
+	// Name of the task created for the step, if any.
+	//
+	// +optional
+	TaskName string `json:"taskName,omitempty"`
+}
+
+// JobStepPhase is a high-level description of a step's state.
+type JobStepPhase string
+
+const (
+	// JobStepPending means that the step's task is not yet created, or not yet
+	// running.
+	JobStepPending JobStepPhase = "Pending"
+
+	// JobStepRunning means that the step's task is running.
+	JobStepRunning JobStepPhase = "Running"
+
+	// JobStepSucceeded means that the step's task has finished successfully.
+	JobStepSucceeded JobStepPhase = "Succeeded"
+
+	// JobStepFailed means that the step's task has finished unsuccessfully.
+	JobStepFailed JobStepPhase = "Failed"
+
+	// JobStepSkipped means that the step will not be started, because some other
+	// step has failed or the Job was killed.
+	JobStepSkipped JobStepPhase = "Skipped"
+)
+
+// IsTerminal returns true if the JobStepPhase is terminal.
+func (p JobStepPhase) IsTerminal() bool {
+	switch p {
+	case JobStepSucceeded, JobStepFailed, JobStepSkipped:
+		return true
+	}
+	return false
 }
 
 type JobPhase string
@@ -250,6 +780,10 @@ const (
 	// created to retry, but it has not yet started running.
 	JobRetrying JobPhase = "Retrying"
 
+	// JobSuspended means that the job is suspended, and will not create any new
+	// tasks until it is resumed. Any active tasks will be killed.
+	JobSuspended JobPhase = "Suspended"
+
 	// JobSucceeded means that the job was completed successfully.
 	JobSucceeded JobPhase = "Succeeded"
 
@@ -266,6 +800,8 @@ const (
 	// JobDeadlineExceeded means that the job's most recent task had started
 	// running, but was running longer than its active deadline. All retry attempts
 	// have been fully exhausted and the job will stop trying to create new tasks.
+	// This phase is also used if the job was killed after exceeding its maximum
+	// runtime.
 	//
 	// Note that the difference between JobPendingTimeout and JobDeadlineExceeded is
 	// that the active deadline includes both the pending duration and execution
@@ -288,6 +824,10 @@ const (
 	// deleted the task without confirming that the task has been completely killed.
 	JobKilled JobPhase = "Killed"
 
+	// JobExpired means that the job was not started within its expiry window after
+	// it was due to start, and will never be started.
+	JobExpired JobPhase = "Expired"
+
 	// JobFinishedUnknown means that the job is finished but for some reason we do
 	// not know its result.
 	JobFinishedUnknown JobPhase = "FinishedUnknown"
@@ -304,6 +844,7 @@ func (p JobPhase) IsTerminal() bool {
 		JobPendingTimeout,
 		JobDeadlineExceeded,
 		JobAdmissionError,
+		JobExpired,
 		JobFinishedUnknown:
 		return true
 
@@ -312,6 +853,7 @@ func (p JobPhase) IsTerminal() bool {
 		JobRunning,
 		JobRetryBackoff,
 		JobRetrying,
+		JobSuspended,
 		JobKilling,
 		JobQueued:
 		fallthrough
@@ -321,6 +863,16 @@ func (p JobPhase) IsTerminal() bool {
 	}
 }
 
+const (
+	// JobConditionTypeProgressing means that the Job is not yet finished. The
+	// reason of the condition will be the JobPhase of the Job.
+	JobConditionTypeProgressing = "Progressing"
+
+	// JobConditionTypeFinished means that the Job is finished. The reason of the
+	// condition will be the JobResult of the Job once it is finished.
+	JobConditionTypeFinished = "Finished"
+)
+
 // JobCondition holds a possible condition of a Job.
 // Only one of its members may be specified.
 // If none of them is specified, the default one is JobConditionQueueing.
@@ -423,7 +975,8 @@ const (
 	JobResultPendingTimeout JobResult = "PendingTimeout"
 
 	// JobResultDeadlineExceeded means that the Job has failed to finish its last
-	// task within the specified task active deadline.
+	// task within the specified task active deadline or running timeout, or that
+	// the Job was killed after exceeding its maximum runtime.
 	JobResultDeadlineExceeded JobResult = "DeadlineExceeded"
 
 	// JobResultAdmissionError means that the Job could not start due to an error
@@ -431,9 +984,14 @@ const (
 	JobResultAdmissionError JobResult = "AdmissionError"
 
 	// JobResultKilled means that the Job and its tasks, if any, were successfully
-	// killed via KillTimestamp.
+	// killed via KillTimestamp. Jobs that were killed after exceeding their
+	// maximum runtime will use JobResultDeadlineExceeded instead.
 	JobResultKilled JobResult = "Killed"
 
+	// JobResultExpired means that the Job was not started within its expiry window
+	// after it was due to start.
+	JobResultExpired JobResult = "Expired"
+
 	// JobResultFinalStateUnknown means that the Job's tasks were deleted and its
 	// final state is unknown.
 	JobResultFinalStateUnknown JobResult = "FinalStateUnknown"
@@ -445,6 +1003,7 @@ var AllFailedJobResults = []JobResult{
 	JobResultDeadlineExceeded,
 	JobResultAdmissionError,
 	JobResultKilled,
+	JobResultExpired,
 }
 
 func (r JobResult) IsFailed() bool {
@@ -456,11 +1015,48 @@ func (r JobResult) IsFailed() bool {
 	return false
 }
 
+// RetryMode specifies how tasks are named when they are retried.
+type RetryMode string
+
+const (
+	// RetryModeNewTask creates a task with a new name for every retry.
+	RetryModeNewTask RetryMode = "NewTask"
+
+	// RetryModeInPlace deletes the previous task and creates its replacement with
+	// the same name.
+	RetryModeInPlace RetryMode = "InPlace"
+)
+
+// TaskDeletionPolicy specifies what happens to the tasks of a Job when the Job
+// is deleted.
+type TaskDeletionPolicy string
+
+const (
+	// TaskDeletionPolicyDelete kills and deletes all tasks before the Job is
+	// deleted.
+	TaskDeletionPolicyDelete TaskDeletionPolicy = "Delete"
+
+	// TaskDeletionPolicyOrphan removes the Job's ownership of all tasks and labels
+	// them as orphaned, leaving them running after the Job is deleted.
+	TaskDeletionPolicyOrphan TaskDeletionPolicy = "Orphan"
+)
+
 // TaskRef stores information about a Job's owned task.
 type TaskRef struct {
 	// Name of the task. Assumes to share the same namespace as the Job.
 	Name string `json:"name"`
 
+	// Name of the step that the task was created for, if the Job specifies steps.
+	//
+	// +optional
+	Step string `json:"step,omitempty"`
+
+	// Retry index of the task, starting from 1. Tasks created with the InPlace
+	// retry mode share the same name, and are distinguished by their retry index.
+	//
+	// +optional
+	RetryIndex int64 `json:"retryIndex,omitempty"`
+
 	// Creation time of the task.
 	CreationTimestamp metav1.Time `json:"creationTimestamp"`
 
@@ -497,6 +1093,19 @@ type TaskRef struct {
 	// +optional
 	DeletedStatus *TaskStatus `json:"deletedStatus,omitempty"`
 
+	// Suspended is true if the task was killed because the Job was suspended. Such
+	// tasks do not count towards the maximum attempts of the Job.
+	//
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Compacted is true if details of the finished task were dropped to reduce
+	// the size of the Job's status. Compacted tasks do not contain container
+	// states, nor the message and progress of the task's status.
+	//
+	// +optional
+	Compacted bool `json:"compacted,omitempty"`
+
 	// Node name that the task was bound to. May be empty if task was never
 	// scheduled.
 	//
@@ -529,6 +1138,82 @@ type TaskStatus struct {
 	// Descriptive message for the task's status.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Progress of the task as reported by the task itself, if any. For Pod tasks,
+	// progress can be reported by setting the
+	// execution.furiko.io/task-progress-percent and
+	// execution.furiko.io/task-progress-message annotations on the Pod, such as
+	// from a sidecar container.
+	//
+	// +optional
+	Progress *TaskProgress `json:"progress,omitempty"`
+
+	// Outputs emitted by the task, if any. For Pod tasks, outputs can be emitted
+	// by writing a JSON object to the termination message of any container, or by
+	// setting the execution.furiko.io/task-outputs annotation on the Pod to a JSON
+	// object. Values in the annotation take precedence over termination messages.
+	//
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// Resource usage of the task, if resource usage sampling is enabled in the
+	// controller. Requires metrics-server to be installed in the cluster.
+	//
+	// +optional
+	ResourceUsage *TaskResourceUsage `json:"resourceUsage,omitempty"`
+
+	// Location of the task's logs in the log archive, if they were archived. This
+	// is reconciled from the task object, and allows logs to be retrieved after the
+	// task's resources have been deleted.
+	//
+	// +optional
+	LogArchive *TaskLogArchive `json:"logArchive,omitempty"`
+}
+
+// TaskLogArchive describes where the logs of a task were archived to.
+type TaskLogArchive struct {
+	// Key of the archived logs, relative to the log archive URL in the controller
+	// configuration.
+	Key string `json:"key"`
+}
+
+// TaskResourceUsage describes the resources consumed by a task, as sampled from
+// the metrics API while the task was running.
+type TaskResourceUsage struct {
+	// Total CPU time consumed by the task, in CPU-seconds.
+	//
+	// +optional
+	CPUSeconds *resource.Quantity `json:"cpuSeconds,omitempty"`
+
+	// Peak memory usage of the task, summed across all of its containers.
+	//
+	// +optional
+	PeakMemory *resource.Quantity `json:"peakMemory,omitempty"`
+
+	// Estimated cost of the task as a decimal string, computed once the task has
+	// finished using the resource price table in the controller configuration.
+	// The currency is left up to the cluster administrator.
+	//
+	// +optional
+	EstimatedCost string `json:"estimatedCost,omitempty"`
+}
+
+// TaskProgress describes the progress of a task, as reported by the task.
+type TaskProgress struct {
+	// Percentage of the task that is completed, between 0 and 100.
+	//
+	// +optional
+	Percent *int32 `json:"percent,omitempty"`
+
+	// Descriptive message for the task's progress.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The last time that the progress was observed to have changed.
+	//
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 type TaskState string
@@ -601,15 +1286,17 @@ type TaskContainerState struct {
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
-// +kubebuilder:resource:shortName=furikojob;furikojobs
+// +kubebuilder:resource:shortName=fj;furikojob;furikojobs,categories=furiko
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
-// +kubebuilder:printcolumn:name="Created Tasks",type=string,JSONPath=`.status.createdTasks`
-// +kubebuilder:printcolumn:name="Run Time",type=date,JSONPath=`.status.condition.running.startTime`
+// +kubebuilder:printcolumn:name="Result",type=string,JSONPath=`.status.condition.finished.result`
+// +kubebuilder:printcolumn:name="Created Tasks",type=integer,JSONPath=`.status.createdTasks`
+// +kubebuilder:printcolumn:name="Start Time",type=date,JSONPath=`.status.startTime`
 // +kubebuilder:printcolumn:name="Finish Time",type=date,JSONPath=`.status.condition.finished.finishTime`
-// +kubebuilder:webhook:path=/mutating/jobs.execution.furiko.io,mutating=true,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobs,verbs=create;update,versions=*,name=mutating.webhook.jobs.execution.furiko.io,admissionReviewVersions=v1
-// +kubebuilder:webhook:path=/validating/jobs.execution.furiko.io,mutating=false,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobs,verbs=create;update,versions=*,name=validation.webhook.jobs.execution.furiko.io,admissionReviewVersions=v1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:webhook:path=/mutating/jobs.execution.furiko.io,mutating=true,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobs,verbs=create;update,versions=v1alpha1,name=mutating.webhook.jobs.execution.furiko.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validating/jobs.execution.furiko.io,mutating=false,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobs,verbs=create;update,versions=v1alpha1,name=validation.webhook.jobs.execution.furiko.io,admissionReviewVersions=v1
 
 // Job is the schema for a single job execution, which may consist of multiple
 // tasks.
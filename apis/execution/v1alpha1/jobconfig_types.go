@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -43,12 +45,236 @@ type JobConfigSpec struct {
 	//
 	// +optional
 	Option *OptionSpec `json:"option,omitempty"`
+
+	// TemplatePolicy specifies how Jobs created from the JobConfig make use of its
+	// template.
+	// Can be one of: Snapshot, Reference
+	//
+	// If Snapshot, the template is copied into each Job when it is created, and
+	// subsequent changes to the JobConfig's template will not affect existing Jobs.
+	//
+	// If Reference, the template of a Job that is still queued will be refreshed
+	// from the JobConfig's latest template just before it is started, so that
+	// enqueued Jobs pick up any template fixes made in the meantime. Only the
+	// template spec is refreshed; labels, annotations, option values and
+	// substitutions are still evaluated when the Job is created.
+	//
+	// Default: Snapshot
+	// +optional
+	TemplatePolicy TemplatePolicy `json:"templatePolicy,omitempty"`
+
+	// ExecutionOverrides overrides the defaults from the cluster's dynamic Job
+	// execution config for all Jobs created from this JobConfig. Values which are
+	// explicitly specified in a Job's spec still take precedence.
+	//
+	// +optional
+	ExecutionOverrides *JobExecutionOverrides `json:"executionOverrides,omitempty"`
+
+	// Notifications is an optional list of notifications to send to chat services
+	// or by email when Jobs created from the JobConfig finish or run for too long.
+	//
+	// +optional
+	Notifications []NotificationSpec `json:"notifications,omitempty"`
+
+	// Alerts is an optional list of incident management services to open an
+	// incident in when Jobs created from the JobConfig fail consecutively. The
+	// incident is resolved once a subsequent Job succeeds.
+	//
+	// +optional
+	Alerts []AlertSpec `json:"alerts,omitempty"`
+}
+
+// AlertSpec defines an incident that is opened in an incident management
+// service after consecutive failures.
+type AlertSpec struct {
+	// Type of the incident management service.
+	// Can be one of: PagerDuty, Opsgenie
+	Type AlertType `json:"type"`
+
+	// RoutingKeySecretRef refers to a key in a Secret in the same namespace as the
+	// JobConfig, which contains the key used to open incidents. For PagerDuty, this
+	// is the integration key of an Events API v2 integration. For Opsgenie, this is
+	// the API key of an API integration.
+	RoutingKeySecretRef corev1.SecretKeySelector `json:"routingKeySecretRef"`
+
+	// ConsecutiveFailures is the number of consecutive failed Jobs after which an
+	// incident will be opened.
+	//
+	// Default: 1
+	// +optional
+	ConsecutiveFailures int64 `json:"consecutiveFailures,omitempty"`
+}
+
+// AlertType is the type of incident management service that alerts are sent to.
+type AlertType string
+
+const (
+	// AlertTypePagerDuty opens incidents using the PagerDuty Events API v2.
+	AlertTypePagerDuty AlertType = "PagerDuty"
+
+	// AlertTypeOpsgenie opens alerts using the Opsgenie Alert API.
+	AlertTypeOpsgenie AlertType = "Opsgenie"
+)
+
+// NotificationSpec defines a notification that is sent to a chat service via an
+// incoming webhook, or by email.
+type NotificationSpec struct {
+	// Type of the service that the notification is sent to.
+	// Can be one of: Slack, Teams, Email
+	Type NotificationType `json:"type"`
+
+	// WebhookURLSecretRef refers to a key in a Secret in the same namespace as the
+	// JobConfig, which contains the incoming webhook URL to send the notification
+	// to. Required for the Slack and Teams types.
+	//
+	// +optional
+	WebhookURLSecretRef *corev1.SecretKeySelector `json:"webhookURLSecretRef,omitempty"`
+
+	// Email specifies the recipients of the notification. Required for the Email
+	// type, which sends the notification using the SMTP server in the cluster's
+	// dynamic config.
+	//
+	// +optional
+	Email *EmailNotificationSpec `json:"email,omitempty"`
+
+	// On is the list of triggers for which the notification will be sent.
+	// Each item can be one of: Success, Failure, LongRunning
+	//
+	// Each trigger will only send the notification at most once for each Job.
+	On []NotificationTrigger `json:"on"`
+
+	// LongRunningThresholdSeconds is the duration in seconds after a Job has
+	// started, after which the LongRunning trigger fires if the Job is still
+	// running. Required if the LongRunning trigger is specified.
+	//
+	// +optional
+	LongRunningThresholdSeconds *int64 `json:"longRunningThresholdSeconds,omitempty"`
+}
+
+// EmailNotificationSpec specifies the recipients of an email notification.
+type EmailNotificationSpec struct {
+	// To is the list of email addresses to send the notification to.
+	To []string `json:"to"`
+
+	// Cc is the list of email addresses to copy the notification to.
+	//
+	// +optional
+	Cc []string `json:"cc,omitempty"`
 }
 
+// NotificationType is the type of service that a notification is sent to.
+type NotificationType string
+
+const (
+	// NotificationTypeSlack sends notifications to a Slack incoming webhook.
+	NotificationTypeSlack NotificationType = "Slack"
+
+	// NotificationTypeTeams sends notifications to a Microsoft Teams incoming
+	// webhook.
+	NotificationTypeTeams NotificationType = "Teams"
+
+	// NotificationTypeEmail sends notifications by email via SMTP.
+	NotificationTypeEmail NotificationType = "Email"
+)
+
+// NotificationTrigger describes when a notification is sent.
+type NotificationTrigger string
+
+const (
+	// NotificationTriggerSuccess sends the notification when the Job succeeds.
+	NotificationTriggerSuccess NotificationTrigger = "Success"
+
+	// NotificationTriggerFailure sends the notification when the Job finishes
+	// with a failed result.
+	NotificationTriggerFailure NotificationTrigger = "Failure"
+
+	// NotificationTriggerLongRunning sends the notification when the Job is still
+	// running after longRunningThresholdSeconds.
+	NotificationTriggerLongRunning NotificationTrigger = "LongRunning"
+)
+
+// JobExecutionOverrides overrides execution defaults for the Jobs of a
+// JobConfig. The precedence of each value, from highest to lowest, is:
+//  1. The Job's spec (e.g. ttlSecondsAfterFinished, pendingTimeoutSeconds).
+//  2. The JobConfig's executionOverrides.
+//  3. The namespace's ExecutionConfig.
+//  4. The cluster's dynamic config.
+type JobExecutionOverrides struct {
+	// TTLSecondsAfterFinished overrides defaultTTLSecondsAfterFinished, and is
+	// only used if the Job does not specify its own ttlSecondsAfterFinished.
+	//
+	// +optional
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// PendingTimeoutSeconds overrides defaultPendingTimeoutSeconds, and is only
+	// used if the Job's task template does not specify pendingTimeoutSeconds.
+	//
+	// +optional
+	PendingTimeoutSeconds *int64 `json:"pendingTimeoutSeconds,omitempty"`
+
+	// DeleteKillingTasksTimeoutSeconds overrides deleteKillingTasksTimeoutSeconds,
+	// and is only used if the Job's task template does not specify
+	// killGracePeriodSeconds.
+	//
+	// +optional
+	DeleteKillingTasksTimeoutSeconds *int64 `json:"deleteKillingTasksTimeoutSeconds,omitempty"`
+
+	// ForceDeleteKillingTasksTimeoutSeconds overrides
+	// forceDeleteKillingTasksTimeoutSeconds. Set this value to 0 to disable force
+	// deletion for the JobConfig's Jobs.
+	//
+	// +optional
+	ForceDeleteKillingTasksTimeoutSeconds *int64 `json:"forceDeleteKillingTasksTimeoutSeconds,omitempty"`
+}
+
+// TemplatePolicy describes how Jobs make use of the JobConfig's template.
+type TemplatePolicy string
+
+const (
+	// TemplatePolicySnapshot copies the JobConfig's template into the Job at
+	// creation time.
+	TemplatePolicySnapshot TemplatePolicy = "Snapshot"
+
+	// TemplatePolicyReference refreshes the Job's template from the JobConfig when
+	// the Job is started.
+	TemplatePolicyReference TemplatePolicy = "Reference"
+)
+
 // ConcurrencySpec defines how to handle multiple concurrent Jobs for the JobConfig.
 type ConcurrencySpec struct {
 	// Policy describes how to treat concurrent executions of the same JobConfig.
 	Policy ConcurrencyPolicy `json:"policy"`
+
+	// Specifies the default maximum duration in seconds that Jobs of this
+	// JobConfig may remain queued after they are due to start. Once exceeded, the
+	// Job will not be started and will finish with the Expired result. Can be
+	// overridden by each Job's startPolicy.expireAfterSeconds.
+	//
+	// +optional
+	ExpireAfterSeconds *int64 `json:"expireAfterSeconds,omitempty"`
+
+	// Group specifies a concurrency group that is shared between multiple
+	// JobConfigs in the same namespace. Jobs of this JobConfig will not be started
+	// while the group has too many active Jobs, which allows the group to act as a
+	// named lock across JobConfigs (e.g. for Jobs which touch the same database).
+	//
+	// +optional
+	Group *ConcurrencyGroupSpec `json:"group,omitempty"`
+}
+
+// ConcurrencyGroupSpec defines a concurrency group shared between JobConfigs.
+type ConcurrencyGroupSpec struct {
+	// Name of the concurrency group. All JobConfigs in the same namespace that
+	// specify the same group name belong to the same group.
+	Name string `json:"name"`
+
+	// Specifies the maximum number of active Jobs across all JobConfigs in the
+	// group. Jobs of this JobConfig will remain queued while the group has at
+	// least this number of active Jobs.
+	//
+	// Default: 1
+	// +optional
+	MaxConcurrency *int64 `json:"maxConcurrency,omitempty"`
 }
 
 // ScheduleSpec defines how a JobConfig should be automatically scheduled.
@@ -149,6 +375,32 @@ type OptionSpec struct {
 	// Options is a list of job options.
 	// +optional
 	Options []Option `json:"options,omitempty"`
+
+	// Rules is a list of validation rules that are evaluated against the option
+	// values of a Job when it is created, which can be used to express constraints
+	// across multiple options. The Job will be rejected if any rule fails.
+	// +optional
+	Rules []OptionRule `json:"rules,omitempty"`
+}
+
+// OptionRule defines a validation rule over the option values of a Job.
+type OptionRule struct {
+	// Expression is a CEL expression that must evaluate to true for the option
+	// values to be valid. The option values are available in the `option` map,
+	// keyed by the name of each option. For example:
+	//
+	//   option.end_date >= option.start_date
+	//
+	// Bool options are mapped to bool, String and Select options to string, Multi
+	// options to list(string), and Date options to timestamp. Date options that are
+	// not specified will not be present in the map, and can be checked using
+	// `has(option.name)`.
+	Expression string `json:"expression"`
+
+	// Message is the message to be returned if the rule fails. If not specified,
+	// a default message containing the expression will be used.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // Option defines a single job option.
@@ -389,6 +641,16 @@ type JobConfigStatus struct {
 	// Human-readable and high-level representation of the status of the JobConfig.
 	State JobConfigState `json:"state"`
 
+	// Conditions store the standard conditions of the JobConfig, which allow
+	// generic tooling to inspect the status of the JobConfig.
+	//
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
 	// Total number of Jobs queued for the JobConfig. A job that is queued is one
 	// that is not yet started.
 	//
@@ -420,6 +682,85 @@ type JobConfigStatus struct {
 	//
 	// +optional
 	LastScheduled *metav1.Time `json:"lastScheduled,omitempty"`
+
+	// The next time that the JobConfig is expected to be scheduled, if it has an
+	// enabled cron schedule. This field is for informational purposes only.
+	//
+	// +optional
+	NextScheduled *metav1.Time `json:"nextScheduled,omitempty"`
+
+	// Total number of Jobs for the JobConfig that were started. This counter is
+	// cumulative and includes Jobs which have since been deleted.
+	//
+	// +optional
+	TotalStarted int64 `json:"totalStarted,omitempty"`
+
+	// Total number of Jobs for the JobConfig that finished successfully. This
+	// counter is cumulative and includes Jobs which have since been deleted.
+	//
+	// +optional
+	TotalSucceeded int64 `json:"totalSucceeded,omitempty"`
+
+	// Total number of Jobs for the JobConfig that finished with a failed result.
+	// This counter is cumulative and includes Jobs which have since been deleted.
+	//
+	// +optional
+	TotalFailed int64 `json:"totalFailed,omitempty"`
+
+	// Number of Jobs for the JobConfig that finished with a failed result since
+	// the last Job that finished successfully.
+	//
+	// +optional
+	ConsecutiveFailures int64 `json:"consecutiveFailures,omitempty"`
+
+	// The last time that a Job for the JobConfig was started.
+	//
+	// +optional
+	LastStarted *metav1.Time `json:"lastStarted,omitempty"`
+
+	// The last time that a Job for the JobConfig finished successfully.
+	//
+	// +optional
+	LastSucceeded *metav1.Time `json:"lastSucceeded,omitempty"`
+
+	// The last time that a Job for the JobConfig finished with a failed result.
+	//
+	// +optional
+	LastFailed *metav1.Time `json:"lastFailed,omitempty"`
+
+	// A summary of the most recently created Job for the JobConfig.
+	//
+	// +optional
+	LastJob *JobSummary `json:"lastJob,omitempty"`
+
+	// The peak resource usage of each container observed from previous tasks of
+	// the JobConfig. If enabled in the controller, this will be used to recommend
+	// resource requests for future tasks.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ResourceUsage []ContainerResourceUsage `json:"resourceUsage,omitempty"`
+}
+
+// ContainerResourceUsage is the resource usage observed for a single container.
+type ContainerResourceUsage struct {
+	// Name of the container.
+	Name string `json:"name"`
+
+	// Peak CPU usage observed for the container.
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// Peak memory usage observed for the container.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// Number of samples that were observed.
+	Samples int64 `json:"samples"`
+
+	// Time that the resource usage was last updated.
+	LastUpdated metav1.Time `json:"lastUpdated"`
 }
 
 type JobConfigState string
@@ -447,6 +788,47 @@ const (
 	JobConfigExecuting JobConfigState = "Executing"
 )
 
+const (
+	// JobConfigConditionReady means that the JobConfig is valid and ready to create
+	// Jobs.
+	JobConfigConditionReady = "Ready"
+
+	// JobConfigConditionProgressing means that the JobConfig has some Job(s) that
+	// are queued or executing.
+	JobConfigConditionProgressing = "Progressing"
+
+	// JobConfigConditionScheduleActive means that the JobConfig has a schedule
+	// which is enabled, and will be scheduled automatically.
+	JobConfigConditionScheduleActive = "ScheduleActive"
+)
+
+// JobSummary is a summary of the execution of a Job.
+type JobSummary struct {
+	// UID of the Job.
+	UID types.UID `json:"uid"`
+
+	// Name of the Job.
+	Name string `json:"name"`
+
+	// Timestamp that the Job was created.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	// Phase of the Job.
+	Phase JobPhase `json:"phase"`
+
+	// Result of the Job, if it is finished.
+	// +optional
+	Result JobResult `json:"result,omitempty"`
+
+	// Timestamp that the Job was started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Timestamp that the Job was finished.
+	// +optional
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+}
+
 type JobReference struct {
 	// UID of the Job.
 	UID types.UID `json:"uid"`
@@ -469,17 +851,19 @@ type JobReference struct {
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
-// +kubebuilder:resource:shortName=furikojobconfig;furikojobconfigs
+// +kubebuilder:resource:shortName=fjc;furikojobconfig;furikojobconfigs,categories=furiko
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
-// +kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.active`
-// +kubebuilder:printcolumn:name="Queued",type=string,JSONPath=`.status.queued`
+// +kubebuilder:printcolumn:name="Active",type=integer,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queued`
 // +kubebuilder:printcolumn:name="Cron Schedule",type=string,JSONPath=`.spec.schedule.cron.expression`
-// +kubebuilder:printcolumn:name="Timezone",type=string,JSONPath=`.spec.schedule.cron.timezone`
-// +kubebuilder:printcolumn:name="Last Schedule Time",type=date,JSONPath=`.status.lastScheduleTime`
-// +kubebuilder:webhook:path=/mutating/jobconfigs.execution.furiko.io,mutating=true,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobconfigs,verbs=create;update,versions=*,name=mutating.webhook.jobconfigs.execution.furiko.io,admissionReviewVersions=v1
-// +kubebuilder:webhook:path=/validating/jobconfigs.execution.furiko.io,mutating=false,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobconfigs,verbs=create;update,versions=*,name=validation.webhook.jobconfigs.execution.furiko.io,admissionReviewVersions=v1
+// +kubebuilder:printcolumn:name="Timezone",type=string,JSONPath=`.spec.schedule.cron.timezone`,priority=1
+// +kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduled`
+// +kubebuilder:printcolumn:name="Next Schedule",type=date,JSONPath=`.status.nextScheduled`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:webhook:path=/mutating/jobconfigs.execution.furiko.io,mutating=true,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobconfigs,verbs=create;update,versions=v1alpha1,name=mutating.webhook.jobconfigs.execution.furiko.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validating/jobconfigs.execution.furiko.io,mutating=false,failurePolicy=fail,sideEffects=None,groups=execution.furiko.io,resources=jobconfigs,verbs=create;update,versions=v1alpha1,name=validation.webhook.jobconfigs.execution.furiko.io,admissionReviewVersions=v1
 
 // JobConfig is the schema for a single job configuration. Multiple Job objects
 // belong to a single JobConfig.
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobGroupSpec defines the desired state of a JobGroup.
+type JobGroupSpec struct {
+	// Specifies the time to start killing all member Jobs of the JobGroup which
+	// have not yet finished. The kill timestamp is propagated to each member Job
+	// which does not already specify an earlier kill timestamp.
+	//
+	// +optional
+	KillTimestamp *metav1.Time `json:"killTimestamp,omitempty"`
+
+	// Specifies the time-to-live (TTL) of the JobGroup after all of its member Jobs
+	// have finished. Once the TTL expires, the JobGroup and all of its member Jobs
+	// will be deleted. If not specified, the JobGroup will not be deleted.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int64 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// JobGroupStatus defines the observed state of a JobGroup, which is aggregated
+// from the status of its member Jobs.
+type JobGroupStatus struct {
+	// Phase of the JobGroup.
+	//
+	// +optional
+	Phase JobGroupPhase `json:"phase,omitempty"`
+
+	// Total number of member Jobs. Member Jobs that have already been deleted are
+	// not counted.
+	//
+	// +optional
+	Total int64 `json:"total"`
+
+	// Number of member Jobs that are queued and not yet started.
+	//
+	// +optional
+	Queued int64 `json:"queued"`
+
+	// Number of member Jobs that are started and not yet finished.
+	//
+	// +optional
+	Active int64 `json:"active"`
+
+	// Number of member Jobs that have succeeded.
+	//
+	// +optional
+	Succeeded int64 `json:"succeeded"`
+
+	// Number of member Jobs that have finished without succeeding, excluding
+	// those that were killed.
+	//
+	// +optional
+	Failed int64 `json:"failed"`
+
+	// Number of member Jobs that were killed.
+	//
+	// +optional
+	Killed int64 `json:"killed"`
+
+	// The time that the first member Job was started.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time that the last member Job finished, once all member Jobs have
+	// finished.
+	//
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// JobGroupPhase is a label for the condition of a JobGroup at the current time.
+type JobGroupPhase string
+
+const (
+	// JobGroupPending means that none of the member Jobs have started, or that the
+	// JobGroup does not have any member Jobs.
+	JobGroupPending JobGroupPhase = "Pending"
+
+	// JobGroupRunning means that some member Jobs have started, and not all member
+	// Jobs have finished.
+	JobGroupRunning JobGroupPhase = "Running"
+
+	// JobGroupSucceeded means that all member Jobs have succeeded.
+	JobGroupSucceeded JobGroupPhase = "Succeeded"
+
+	// JobGroupFailed means that all member Jobs have finished, and at least one of
+	// them did not succeed.
+	JobGroupFailed JobGroupPhase = "Failed"
+
+	// JobGroupKilled means that all member Jobs have finished after the JobGroup
+	// was killed, and at least one of them was killed.
+	JobGroupKilled JobGroupPhase = "Killed"
+)
+
+// IsTerminal returns true if the JobGroupPhase is terminal.
+func (p JobGroupPhase) IsTerminal() bool {
+	switch p {
+	case JobGroupSucceeded, JobGroupFailed, JobGroupKilled:
+		return true
+	}
+	return false
+}
+
+// nolint:lll
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fjg;furikojobgroup;furikojobgroups,categories=furiko
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.total`
+// +kubebuilder:printcolumn:name="Active",type=integer,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="Succeeded",type=integer,JSONPath=`.status.succeeded`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failed`
+// +kubebuilder:printcolumn:name="Killed",type=integer,JSONPath=`.status.killed`,priority=1
+// +kubebuilder:printcolumn:name="Queued",type=integer,JSONPath=`.status.queued`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// JobGroup groups related Jobs, such as Jobs created by a backfill or a
+// parallel fan-out, so that they can be tracked and operated on as a whole. Jobs
+// are added to a JobGroup in the same namespace by labelling them with the
+// execution.furiko.io/job-group label, whose value is the name of the JobGroup.
+type JobGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JobGroupSpec   `json:"spec,omitempty"`
+	Status JobGroupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JobGroupList contains a list of JobGroup objects.
+type JobGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JobGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JobGroup{}, &JobGroupList{})
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TaskTemplateSpec defines the desired state of a TaskTemplate.
+type TaskTemplateSpec struct {
+	// Describes the task to be created for Jobs whose JobConfigs reference this
+	// TaskTemplate. Fields that are specified in the referencing task take
+	// precedence over those specified here.
+	Task JobTaskSpec `json:"task"`
+}
+
+// TaskTemplateReference is a reference to a TaskTemplate in the same namespace.
+type TaskTemplateReference struct {
+	// Name of the TaskTemplate.
+	Name string `json:"name"`
+}
+
+// nolint:lll
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=furikotasktemplate;furikotasktemplates,categories=furiko
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// TaskTemplate is a reusable task template that can be referenced by the tasks
+// of multiple JobConfigs in the same namespace, which allows a single pod spec
+// to be maintained for each type of workload.
+//
+// References are resolved whenever a Job is created, such that changes to the
+// TaskTemplate only take effect for Jobs that are created after the change.
+type TaskTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TaskTemplateSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskTemplateList contains a list of TaskTemplate objects.
+type TaskTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TaskTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TaskTemplate{}, &TaskTemplateList{})
+}
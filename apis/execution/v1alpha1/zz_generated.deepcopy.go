@@ -22,227 +22,249 @@
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BoolOptionConfig) DeepCopyInto(out *BoolOptionConfig) {
+func (in *AlertSpec) DeepCopyInto(out *AlertSpec) {
 	*out = *in
+	in.RoutingKeySecretRef.DeepCopyInto(&out.RoutingKeySecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoolOptionConfig.
-func (in *BoolOptionConfig) DeepCopy() *BoolOptionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSpec.
+func (in *AlertSpec) DeepCopy() *AlertSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BoolOptionConfig)
+	out := new(AlertSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConcurrencySpec) DeepCopyInto(out *ConcurrencySpec) {
+func (in *BoolOptionConfig) DeepCopyInto(out *BoolOptionConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencySpec.
-func (in *ConcurrencySpec) DeepCopy() *ConcurrencySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoolOptionConfig.
+func (in *BoolOptionConfig) DeepCopy() *BoolOptionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ConcurrencySpec)
+	out := new(BoolOptionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CronSchedule) DeepCopyInto(out *CronSchedule) {
+func (in *CallbackSpec) DeepCopyInto(out *CallbackSpec) {
 	*out = *in
+	in.SigningKeySecretRef.DeepCopyInto(&out.SigningKeySecretRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronSchedule.
-func (in *CronSchedule) DeepCopy() *CronSchedule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CallbackSpec.
+func (in *CallbackSpec) DeepCopy() *CallbackSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CronSchedule)
+	out := new(CallbackSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DateOptionConfig) DeepCopyInto(out *DateOptionConfig) {
+func (in *CallbackStatus) DeepCopyInto(out *CallbackStatus) {
 	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DateOptionConfig.
-func (in *DateOptionConfig) DeepCopy() *DateOptionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CallbackStatus.
+func (in *CallbackStatus) DeepCopy() *CallbackStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DateOptionConfig)
+	out := new(CallbackStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Job) DeepCopyInto(out *Job) {
+func (in *ConcurrencyGroupSpec) DeepCopyInto(out *ConcurrencyGroupSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.MaxConcurrency != nil {
+		in, out := &in.MaxConcurrency, &out.MaxConcurrency
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Job.
-func (in *Job) DeepCopy() *Job {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyGroupSpec.
+func (in *ConcurrencyGroupSpec) DeepCopy() *ConcurrencyGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Job)
+	out := new(ConcurrencyGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Job) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobCondition) DeepCopyInto(out *JobCondition) {
+func (in *ConcurrencySpec) DeepCopyInto(out *ConcurrencySpec) {
 	*out = *in
-	if in.Queueing != nil {
-		in, out := &in.Queueing, &out.Queueing
-		*out = new(JobConditionQueueing)
+	if in.ExpireAfterSeconds != nil {
+		in, out := &in.ExpireAfterSeconds, &out.ExpireAfterSeconds
+		*out = new(int64)
 		**out = **in
 	}
-	if in.Waiting != nil {
-		in, out := &in.Waiting, &out.Waiting
-		*out = new(JobConditionWaiting)
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(ConcurrencyGroupSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Running != nil {
-		in, out := &in.Running, &out.Running
-		*out = new(JobConditionRunning)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencySpec.
+func (in *ConcurrencySpec) DeepCopy() *ConcurrencySpec {
+	if in == nil {
+		return nil
 	}
-	if in.Finished != nil {
-		in, out := &in.Finished, &out.Finished
-		*out = new(JobConditionFinished)
-		(*in).DeepCopyInto(*out)
+	out := new(ConcurrencySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerResourceUsage) DeepCopyInto(out *ContainerResourceUsage) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
 	}
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobCondition.
-func (in *JobCondition) DeepCopy() *JobCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResourceUsage.
+func (in *ContainerResourceUsage) DeepCopy() *ContainerResourceUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(JobCondition)
+	out := new(ContainerResourceUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConditionFinished) DeepCopyInto(out *JobConditionFinished) {
+func (in *CronExecutionConfigOverrides) DeepCopyInto(out *CronExecutionConfigOverrides) {
 	*out = *in
-	if in.CreatedAt != nil {
-		in, out := &in.CreatedAt, &out.CreatedAt
-		*out = (*in).DeepCopy()
+	if in.DefaultTimezone != nil {
+		in, out := &in.DefaultTimezone, &out.DefaultTimezone
+		*out = new(string)
+		**out = **in
 	}
-	if in.StartedAt != nil {
-		in, out := &in.StartedAt, &out.StartedAt
-		*out = (*in).DeepCopy()
+	if in.MaxMissedSchedules != nil {
+		in, out := &in.MaxMissedSchedules, &out.MaxMissedSchedules
+		*out = new(int64)
+		**out = **in
 	}
-	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionFinished.
-func (in *JobConditionFinished) DeepCopy() *JobConditionFinished {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronExecutionConfigOverrides.
+func (in *CronExecutionConfigOverrides) DeepCopy() *CronExecutionConfigOverrides {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConditionFinished)
+	out := new(CronExecutionConfigOverrides)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConditionQueueing) DeepCopyInto(out *JobConditionQueueing) {
+func (in *CronSchedule) DeepCopyInto(out *CronSchedule) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionQueueing.
-func (in *JobConditionQueueing) DeepCopy() *JobConditionQueueing {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronSchedule.
+func (in *CronSchedule) DeepCopy() *CronSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConditionQueueing)
+	out := new(CronSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConditionRunning) DeepCopyInto(out *JobConditionRunning) {
+func (in *DateOptionConfig) DeepCopyInto(out *DateOptionConfig) {
 	*out = *in
-	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
-	in.StartedAt.DeepCopyInto(&out.StartedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionRunning.
-func (in *JobConditionRunning) DeepCopy() *JobConditionRunning {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DateOptionConfig.
+func (in *DateOptionConfig) DeepCopy() *DateOptionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConditionRunning)
+	out := new(DateOptionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConditionWaiting) DeepCopyInto(out *JobConditionWaiting) {
+func (in *EmailNotificationSpec) DeepCopyInto(out *EmailNotificationSpec) {
 	*out = *in
-	if in.CreatedAt != nil {
-		in, out := &in.CreatedAt, &out.CreatedAt
-		*out = (*in).DeepCopy()
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cc != nil {
+		in, out := &in.Cc, &out.Cc
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionWaiting.
-func (in *JobConditionWaiting) DeepCopy() *JobConditionWaiting {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationSpec.
+func (in *EmailNotificationSpec) DeepCopy() *EmailNotificationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConditionWaiting)
+	out := new(EmailNotificationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConfig) DeepCopyInto(out *JobConfig) {
+func (in *ExecutionConfig) DeepCopyInto(out *ExecutionConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfig.
-func (in *JobConfig) DeepCopy() *JobConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionConfig.
+func (in *ExecutionConfig) DeepCopy() *ExecutionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConfig)
+	out := new(ExecutionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JobConfig) DeepCopyObject() runtime.Object {
+func (in *ExecutionConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -250,31 +272,31 @@ func (in *JobConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConfigList) DeepCopyInto(out *JobConfigList) {
+func (in *ExecutionConfigList) DeepCopyInto(out *ExecutionConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]JobConfig, len(*in))
+		*out = make([]ExecutionConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigList.
-func (in *JobConfigList) DeepCopy() *JobConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionConfigList.
+func (in *ExecutionConfigList) DeepCopy() *ExecutionConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConfigList)
+	out := new(ExecutionConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JobConfigList) DeepCopyObject() runtime.Object {
+func (in *ExecutionConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -282,91 +304,103 @@ func (in *JobConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConfigSpec) DeepCopyInto(out *JobConfigSpec) {
+func (in *ExecutionConfigSpec) DeepCopyInto(out *ExecutionConfigSpec) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
-	out.Concurrency = in.Concurrency
-	if in.Schedule != nil {
-		in, out := &in.Schedule, &out.Schedule
-		*out = new(ScheduleSpec)
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(JobExecutionConfigOverrides)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Option != nil {
-		in, out := &in.Option, &out.Option
-		*out = new(OptionSpec)
+	if in.Cron != nil {
+		in, out := &in.Cron, &out.Cron
+		*out = new(CronExecutionConfigOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskTemplateDefaults != nil {
+		in, out := &in.TaskTemplateDefaults, &out.TaskTemplateDefaults
+		*out = new(TaskTemplateDefaults)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigSpec.
-func (in *JobConfigSpec) DeepCopy() *JobConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionConfigSpec.
+func (in *ExecutionConfigSpec) DeepCopy() *ExecutionConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConfigSpec)
+	out := new(ExecutionConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobConfigStatus) DeepCopyInto(out *JobConfigStatus) {
+func (in *ExternalTask) DeepCopyInto(out *ExternalTask) {
 	*out = *in
-	if in.QueuedJobs != nil {
-		in, out := &in.QueuedJobs, &out.QueuedJobs
-		*out = make([]JobReference, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ActiveJobs != nil {
-		in, out := &in.ActiveJobs, &out.ActiveJobs
-		*out = make([]JobReference, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTask.
+func (in *ExternalTask) DeepCopy() *ExternalTask {
+	if in == nil {
+		return nil
 	}
-	if in.LastScheduled != nil {
-		in, out := &in.LastScheduled, &out.LastScheduled
-		*out = (*in).DeepCopy()
+	out := new(ExternalTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExternalTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigStatus.
-func (in *JobConfigStatus) DeepCopy() *JobConfigStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalTaskEnvVar) DeepCopyInto(out *ExternalTaskEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTaskEnvVar.
+func (in *ExternalTaskEnvVar) DeepCopy() *ExternalTaskEnvVar {
 	if in == nil {
 		return nil
 	}
-	out := new(JobConfigStatus)
+	out := new(ExternalTaskEnvVar)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobList) DeepCopyInto(out *JobList) {
+func (in *ExternalTaskList) DeepCopyInto(out *ExternalTaskList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Job, len(*in))
+		*out = make([]ExternalTask, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobList.
-func (in *JobList) DeepCopy() *JobList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTaskList.
+func (in *ExternalTaskList) DeepCopy() *ExternalTaskList {
 	if in == nil {
 		return nil
 	}
-	out := new(JobList)
+	out := new(ExternalTaskList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JobList) DeepCopyObject() runtime.Object {
+func (in *ExternalTaskList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -374,127 +408,1039 @@ func (in *JobList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobReference) DeepCopyInto(out *JobReference) {
+func (in *ExternalTaskSpec) DeepCopyInto(out *ExternalTaskSpec) {
 	*out = *in
-	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
+	in.ExternalTaskTemplate.DeepCopyInto(&out.ExternalTaskTemplate)
+	if in.KillTimestamp != nil {
+		in, out := &in.KillTimestamp, &out.KillTimestamp
 		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobReference.
-func (in *JobReference) DeepCopy() *JobReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTaskSpec.
+func (in *ExternalTaskSpec) DeepCopy() *ExternalTaskSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(JobReference)
+	out := new(ExternalTaskSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobSpec) DeepCopyInto(out *JobSpec) {
+func (in *ExternalTaskStatus) DeepCopyInto(out *ExternalTaskStatus) {
 	*out = *in
-	if in.StartPolicy != nil {
-		in, out := &in.StartPolicy, &out.StartPolicy
-		*out = new(StartPolicySpec)
-		(*in).DeepCopyInto(*out)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(JobTemplateSpec)
-		(*in).DeepCopyInto(*out)
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Substitutions != nil {
-		in, out := &in.Substitutions, &out.Substitutions
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.KillTimestamp != nil {
-		in, out := &in.KillTimestamp, &out.KillTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.TTLSecondsAfterFinished != nil {
-		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
-		*out = new(int64)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSpec.
-func (in *JobSpec) DeepCopy() *JobSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTaskStatus.
+func (in *ExternalTaskStatus) DeepCopy() *ExternalTaskStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(JobSpec)
+	out := new(ExternalTaskStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobStatus) DeepCopyInto(out *JobStatus) {
+func (in *ExternalTaskTemplate) DeepCopyInto(out *ExternalTaskTemplate) {
 	*out = *in
-	in.Condition.DeepCopyInto(&out.Condition)
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
-		*out = (*in).DeepCopy()
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Tasks != nil {
-		in, out := &in.Tasks, &out.Tasks
-		*out = make([]TaskRef, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ExternalTaskEnvVar, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
-func (in *JobStatus) DeepCopy() *JobStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTaskTemplate.
+func (in *ExternalTaskTemplate) DeepCopy() *ExternalTaskTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(JobStatus)
+	out := new(ExternalTaskTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobTaskSpec) DeepCopyInto(out *JobTaskSpec) {
+func (in *FurikoConfig) DeepCopyInto(out *FurikoConfig) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
-	if in.PendingTimeoutSeconds != nil {
-		in, out := &in.PendingTimeoutSeconds, &out.PendingTimeoutSeconds
-		*out = new(int64)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FurikoConfig.
+func (in *FurikoConfig) DeepCopy() *FurikoConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FurikoConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FurikoConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FurikoConfigList) DeepCopyInto(out *FurikoConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FurikoConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FurikoConfigList.
+func (in *FurikoConfigList) DeepCopy() *FurikoConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(FurikoConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FurikoConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FurikoConfigSpec) DeepCopyInto(out *FurikoConfigSpec) {
+	*out = *in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = new(configv1alpha1.JobExecutionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JobConfigs != nil {
+		in, out := &in.JobConfigs, &out.JobConfigs
+		*out = new(configv1alpha1.JobConfigExecutionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cron != nil {
+		in, out := &in.Cron, &out.Cron
+		*out = new(configv1alpha1.CronExecutionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = new(configv1alpha1.ControllerExecutionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FurikoConfigSpec.
+func (in *FurikoConfigSpec) DeepCopy() *FurikoConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FurikoConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FurikoConfigStatus) DeepCopyInto(out *FurikoConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FurikoConfigStatus.
+func (in *FurikoConfigStatus) DeepCopy() *FurikoConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FurikoConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Job) DeepCopyInto(out *Job) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Job.
+func (in *Job) DeepCopy() *Job {
+	if in == nil {
+		return nil
+	}
+	out := new(Job)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Job) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobCondition) DeepCopyInto(out *JobCondition) {
+	*out = *in
+	if in.Queueing != nil {
+		in, out := &in.Queueing, &out.Queueing
+		*out = new(JobConditionQueueing)
+		**out = **in
+	}
+	if in.Waiting != nil {
+		in, out := &in.Waiting, &out.Waiting
+		*out = new(JobConditionWaiting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Running != nil {
+		in, out := &in.Running, &out.Running
+		*out = new(JobConditionRunning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Finished != nil {
+		in, out := &in.Finished, &out.Finished
+		*out = new(JobConditionFinished)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobCondition.
+func (in *JobCondition) DeepCopy() *JobCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(JobCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConditionFinished) DeepCopyInto(out *JobConditionFinished) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionFinished.
+func (in *JobConditionFinished) DeepCopy() *JobConditionFinished {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConditionFinished)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConditionQueueing) DeepCopyInto(out *JobConditionQueueing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionQueueing.
+func (in *JobConditionQueueing) DeepCopy() *JobConditionQueueing {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConditionQueueing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConditionRunning) DeepCopyInto(out *JobConditionRunning) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionRunning.
+func (in *JobConditionRunning) DeepCopy() *JobConditionRunning {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConditionRunning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConditionWaiting) DeepCopyInto(out *JobConditionWaiting) {
+	*out = *in
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConditionWaiting.
+func (in *JobConditionWaiting) DeepCopy() *JobConditionWaiting {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConditionWaiting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfig) DeepCopyInto(out *JobConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfig.
+func (in *JobConfig) DeepCopy() *JobConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfigList) DeepCopyInto(out *JobConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JobConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigList.
+func (in *JobConfigList) DeepCopy() *JobConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfigSpec) DeepCopyInto(out *JobConfigSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	in.Concurrency.DeepCopyInto(&out.Concurrency)
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Option != nil {
+		in, out := &in.Option, &out.Option
+		*out = new(OptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecutionOverrides != nil {
+		in, out := &in.ExecutionOverrides, &out.ExecutionOverrides
+		*out = new(JobExecutionOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]AlertSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigSpec.
+func (in *JobConfigSpec) DeepCopy() *JobConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobConfigStatus) DeepCopyInto(out *JobConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueuedJobs != nil {
+		in, out := &in.QueuedJobs, &out.QueuedJobs
+		*out = make([]JobReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActiveJobs != nil {
+		in, out := &in.ActiveJobs, &out.ActiveJobs
+		*out = make([]JobReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduled != nil {
+		in, out := &in.LastScheduled, &out.LastScheduled
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduled != nil {
+		in, out := &in.NextScheduled, &out.NextScheduled
+		*out = (*in).DeepCopy()
+	}
+	if in.LastStarted != nil {
+		in, out := &in.LastStarted, &out.LastStarted
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSucceeded != nil {
+		in, out := &in.LastSucceeded, &out.LastSucceeded
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailed != nil {
+		in, out := &in.LastFailed, &out.LastFailed
+		*out = (*in).DeepCopy()
+	}
+	if in.LastJob != nil {
+		in, out := &in.LastJob, &out.LastJob
+		*out = new(JobSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = make([]ContainerResourceUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobConfigStatus.
+func (in *JobConfigStatus) DeepCopy() *JobConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobDependency) DeepCopyInto(out *JobDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobDependency.
+func (in *JobDependency) DeepCopy() *JobDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(JobDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobExecutionConfigOverrides) DeepCopyInto(out *JobExecutionConfigOverrides) {
+	*out = *in
+	if in.DefaultTTLSecondsAfterFinished != nil {
+		in, out := &in.DefaultTTLSecondsAfterFinished, &out.DefaultTTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultPendingTimeoutSeconds != nil {
+		in, out := &in.DefaultPendingTimeoutSeconds, &out.DefaultPendingTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultMaxRuntimeSeconds != nil {
+		in, out := &in.DefaultMaxRuntimeSeconds, &out.DefaultMaxRuntimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxFinishedJobsPerJobConfig != nil {
+		in, out := &in.MaxFinishedJobsPerJobConfig, &out.MaxFinishedJobsPerJobConfig
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxConcurrentJobs != nil {
+		in, out := &in.MaxConcurrentJobs, &out.MaxConcurrentJobs
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobExecutionConfigOverrides.
+func (in *JobExecutionConfigOverrides) DeepCopy() *JobExecutionConfigOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(JobExecutionConfigOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobExecutionOverrides) DeepCopyInto(out *JobExecutionOverrides) {
+	*out = *in
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PendingTimeoutSeconds != nil {
+		in, out := &in.PendingTimeoutSeconds, &out.PendingTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeleteKillingTasksTimeoutSeconds != nil {
+		in, out := &in.DeleteKillingTasksTimeoutSeconds, &out.DeleteKillingTasksTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ForceDeleteKillingTasksTimeoutSeconds != nil {
+		in, out := &in.ForceDeleteKillingTasksTimeoutSeconds, &out.ForceDeleteKillingTasksTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobExecutionOverrides.
+func (in *JobExecutionOverrides) DeepCopy() *JobExecutionOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(JobExecutionOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobGroup) DeepCopyInto(out *JobGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobGroup.
+func (in *JobGroup) DeepCopy() *JobGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(JobGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobGroupList) DeepCopyInto(out *JobGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JobGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobGroupList.
+func (in *JobGroupList) DeepCopy() *JobGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(JobGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobGroupSpec) DeepCopyInto(out *JobGroupSpec) {
+	*out = *in
+	if in.KillTimestamp != nil {
+		in, out := &in.KillTimestamp, &out.KillTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobGroupSpec.
+func (in *JobGroupSpec) DeepCopy() *JobGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobGroupStatus) DeepCopyInto(out *JobGroupStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobGroupStatus.
+func (in *JobGroupStatus) DeepCopy() *JobGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobList) DeepCopyInto(out *JobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Job, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobList.
+func (in *JobList) DeepCopy() *JobList {
+	if in == nil {
+		return nil
+	}
+	out := new(JobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobReference) DeepCopyInto(out *JobReference) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobReference.
+func (in *JobReference) DeepCopy() *JobReference {
+	if in == nil {
+		return nil
+	}
+	out := new(JobReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobSpec) DeepCopyInto(out *JobSpec) {
+	*out = *in
+	if in.StartPolicy != nil {
+		in, out := &in.StartPolicy, &out.StartPolicy
+		*out = new(StartPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(JobTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Substitutions != nil {
+		in, out := &in.Substitutions, &out.Substitutions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KillTimestamp != nil {
+		in, out := &in.KillTimestamp, &out.KillTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RetainFinishedTasksSeconds != nil {
+		in, out := &in.RetainFinishedTasksSeconds, &out.RetainFinishedTasksSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSpec.
+func (in *JobSpec) DeepCopy() *JobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStatus) DeepCopyInto(out *JobStatus) {
+	*out = *in
+	in.Condition.DeepCopyInto(&out.Condition)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]TaskRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]JobStepStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(TaskResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Callback != nil {
+		in, out := &in.Callback, &out.Callback
+		*out = new(CallbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
+func (in *JobStatus) DeepCopy() *JobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStepSpec) DeepCopyInto(out *JobStepSpec) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Task.DeepCopyInto(&out.Task)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStepSpec.
+func (in *JobStepSpec) DeepCopy() *JobStepSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStepSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobStepStatus) DeepCopyInto(out *JobStepStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStepStatus.
+func (in *JobStepStatus) DeepCopy() *JobStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobSummary) DeepCopyInto(out *JobSummary) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSummary.
+func (in *JobSummary) DeepCopy() *JobSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(JobSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTaskSpec) DeepCopyInto(out *JobTaskSpec) {
+	*out = *in
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(TaskTemplateReference)
+		**out = **in
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalTaskTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingTimeoutSeconds != nil {
+		in, out := &in.PendingTimeoutSeconds, &out.PendingTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailFastPendingReasons != nil {
+		in, out := &in.FailFastPendingReasons, &out.FailFastPendingReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RunningTimeoutSeconds != nil {
+		in, out := &in.RunningTimeoutSeconds, &out.RunningTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.KillGracePeriodSeconds != nil {
+		in, out := &in.KillGracePeriodSeconds, &out.KillGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PreKillHook != nil {
+		in, out := &in.PreKillHook, &out.PreKillHook
+		*out = new(TaskPreKillHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StallDetection != nil {
+		in, out := &in.StallDetection, &out.StallDetection
+		*out = new(TaskStallDetection)
+		**out = **in
+	}
+	if in.RequiredContainers != nil {
+		in, out := &in.RequiredContainers, &out.RequiredContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]TaskVolumeClaimTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTaskSpec.
+func (in *JobTaskSpec) DeepCopy() *JobTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplate) DeepCopyInto(out *JobTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplate.
+func (in *JobTemplate) DeepCopy() *JobTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplatePatch) DeepCopyInto(out *JobTemplatePatch) {
+	*out = *in
+	in.Condition.DeepCopyInto(&out.Condition)
+	in.Patch.DeepCopyInto(&out.Patch)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTaskSpec.
-func (in *JobTaskSpec) DeepCopy() *JobTaskSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplatePatch.
+func (in *JobTemplatePatch) DeepCopy() *JobTemplatePatch {
 	if in == nil {
 		return nil
 	}
-	out := new(JobTaskSpec)
+	out := new(JobTemplatePatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JobTemplate) DeepCopyInto(out *JobTemplate) {
+func (in *JobTemplatePatchCondition) DeepCopyInto(out *JobTemplatePatchCondition) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplate.
-func (in *JobTemplate) DeepCopy() *JobTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplatePatchCondition.
+func (in *JobTemplatePatchCondition) DeepCopy() *JobTemplatePatchCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(JobTemplate)
+	out := new(JobTemplatePatchCondition)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -503,6 +1449,13 @@ func (in *JobTemplate) DeepCopy() *JobTemplate {
 func (in *JobTemplateSpec) DeepCopyInto(out *JobTemplateSpec) {
 	*out = *in
 	in.Task.DeepCopyInto(&out.Task)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]JobStepSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.MaxAttempts != nil {
 		in, out := &in.MaxAttempts, &out.MaxAttempts
 		*out = new(int32)
@@ -513,6 +1466,33 @@ func (in *JobTemplateSpec) DeepCopyInto(out *JobTemplateSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.MaxInitFailureRetries != nil {
+		in, out := &in.MaxInitFailureRetries, &out.MaxInitFailureRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxEvictionRetries != nil {
+		in, out := &in.MaxEvictionRetries, &out.MaxEvictionRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRuntimeSeconds != nil {
+		in, out := &in.MaxRuntimeSeconds, &out.MaxRuntimeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]JobTemplatePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Callback != nil {
+		in, out := &in.Callback, &out.Callback
+		*out = new(CallbackSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplateSpec.
@@ -550,6 +1530,41 @@ func (in *MultiOptionConfig) DeepCopy() *MultiOptionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSpec) DeepCopyInto(out *NotificationSpec) {
+	*out = *in
+	if in.WebhookURLSecretRef != nil {
+		in, out := &in.WebhookURLSecretRef, &out.WebhookURLSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailNotificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.On != nil {
+		in, out := &in.On, &out.On
+		*out = make([]NotificationTrigger, len(*in))
+		copy(*out, *in)
+	}
+	if in.LongRunningThresholdSeconds != nil {
+		in, out := &in.LongRunningThresholdSeconds, &out.LongRunningThresholdSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSpec.
+func (in *NotificationSpec) DeepCopy() *NotificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Option) DeepCopyInto(out *Option) {
 	*out = *in
@@ -590,6 +1605,21 @@ func (in *Option) DeepCopy() *Option {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OptionRule) DeepCopyInto(out *OptionRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionRule.
+func (in *OptionRule) DeepCopy() *OptionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(OptionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptionSpec) DeepCopyInto(out *OptionSpec) {
 	*out = *in
@@ -600,6 +1630,11 @@ func (in *OptionSpec) DeepCopyInto(out *OptionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]OptionRule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OptionSpec.
@@ -691,6 +1726,21 @@ func (in *StartPolicySpec) DeepCopyInto(out *StartPolicySpec) {
 		in, out := &in.StartAfter, &out.StartAfter
 		*out = (*in).DeepCopy()
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]JobDependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOnTimeoutSeconds != nil {
+		in, out := &in.DependsOnTimeoutSeconds, &out.DependsOnTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExpireAfterSeconds != nil {
+		in, out := &in.ExpireAfterSeconds, &out.ExpireAfterSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartPolicySpec.
@@ -733,6 +1783,70 @@ func (in *TaskContainerState) DeepCopy() *TaskContainerState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskLogArchive) DeepCopyInto(out *TaskLogArchive) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskLogArchive.
+func (in *TaskLogArchive) DeepCopy() *TaskLogArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskLogArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskPreKillHook) DeepCopyInto(out *TaskPreKillHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskPreKillHook.
+func (in *TaskPreKillHook) DeepCopy() *TaskPreKillHook {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskPreKillHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskProgress) DeepCopyInto(out *TaskProgress) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskProgress.
+func (in *TaskProgress) DeepCopy() *TaskProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRef) DeepCopyInto(out *TaskRef) {
 	*out = *in
@@ -768,6 +1882,46 @@ func (in *TaskRef) DeepCopy() *TaskRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskResourceUsage) DeepCopyInto(out *TaskResourceUsage) {
+	*out = *in
+	if in.CPUSeconds != nil {
+		in, out := &in.CPUSeconds, &out.CPUSeconds
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PeakMemory != nil {
+		in, out := &in.PeakMemory, &out.PeakMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResourceUsage.
+func (in *TaskResourceUsage) DeepCopy() *TaskResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskStallDetection) DeepCopyInto(out *TaskStallDetection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskStallDetection.
+func (in *TaskStallDetection) DeepCopy() *TaskStallDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskStallDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
 	*out = *in
@@ -776,6 +1930,28 @@ func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
 		*out = new(JobResult)
 		**out = **in
 	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(TaskProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(TaskResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogArchive != nil {
+		in, out := &in.LogArchive, &out.LogArchive
+		*out = new(TaskLogArchive)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskStatus.
@@ -787,3 +1963,135 @@ func (in *TaskStatus) DeepCopy() *TaskStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTemplate) DeepCopyInto(out *TaskTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTemplate.
+func (in *TaskTemplate) DeepCopy() *TaskTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTemplateDefaults) DeepCopyInto(out *TaskTemplateDefaults) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTemplateDefaults.
+func (in *TaskTemplateDefaults) DeepCopy() *TaskTemplateDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTemplateDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTemplateList) DeepCopyInto(out *TaskTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TaskTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTemplateList.
+func (in *TaskTemplateList) DeepCopy() *TaskTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTemplateReference) DeepCopyInto(out *TaskTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTemplateReference.
+func (in *TaskTemplateReference) DeepCopy() *TaskTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskTemplateSpec) DeepCopyInto(out *TaskTemplateSpec) {
+	*out = *in
+	in.Task.DeepCopyInto(&out.Task)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskTemplateSpec.
+func (in *TaskTemplateSpec) DeepCopy() *TaskTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskVolumeClaimTemplate) DeepCopyInto(out *TaskVolumeClaimTemplate) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskVolumeClaimTemplate.
+func (in *TaskVolumeClaimTemplate) DeepCopy() *TaskVolumeClaimTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskVolumeClaimTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// Fields which are unchanged between v1alpha1 and v1beta1 are converted by
+// round-tripping through JSON, and only fields whose schema differs are
+// converted explicitly.
+
+var _ conversion.Convertible = (*Job)(nil)
+var _ conversion.Convertible = (*JobConfig)(nil)
+
+// ConvertTo converts this Job to the hub version (v1alpha1).
+func (in *Job) ConvertTo(hub conversion.Hub) error {
+	out, ok := hub.(*v1alpha1.Job)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+
+	spec := in.Spec.DeepCopy()
+	spec.OptionValues = nil
+	if err := convertViaJSON(spec, &out.Spec); err != nil {
+		return err
+	}
+	if in.Spec.OptionValues != nil {
+		out.Spec.OptionValues = string(in.Spec.OptionValues.Raw)
+	}
+
+	status := in.Status.DeepCopy()
+	status.Conditions = nil
+	if err := convertViaJSON(status, &out.Status); err != nil {
+		return err
+	}
+	out.Status.Condition = convertConditionsToV1alpha1(in)
+	out.Status.Conditions = filterStandardConditions(in.Status.Conditions)
+
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha1) to this Job.
+func (in *Job) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.Job)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	spec := src.Spec.DeepCopy()
+	spec.OptionValues = ""
+	if err := convertViaJSON(spec, &in.Spec); err != nil {
+		return err
+	}
+	if src.Spec.OptionValues != "" {
+		// optionValues may be specified in either JSON or YAML in v1alpha1.
+		raw, err := yaml.YAMLToJSON([]byte(src.Spec.OptionValues))
+		if err != nil {
+			return fmt.Errorf("cannot convert optionValues to JSON: %w", err)
+		}
+		in.Spec.OptionValues = &runtime.RawExtension{Raw: raw}
+	}
+
+	status := src.Status.DeepCopy()
+	status.Condition = v1alpha1.JobCondition{}
+	status.Conditions = nil
+	if err := convertViaJSON(status, &in.Status); err != nil {
+		return err
+	}
+	in.Status.Conditions = mergeConditions(convertConditionsFromV1alpha1(src), src.Status.Conditions)
+
+	return nil
+}
+
+// ConvertTo converts this JobConfig to the hub version (v1alpha1).
+func (in *JobConfig) ConvertTo(hub conversion.Hub) error {
+	out, ok := hub.(*v1alpha1.JobConfig)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	if err := convertViaJSON(&in.Spec, &out.Spec); err != nil {
+		return err
+	}
+	return convertViaJSON(&in.Status, &out.Status)
+}
+
+// ConvertFrom converts the hub version (v1alpha1) to this JobConfig.
+func (in *JobConfig) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1alpha1.JobConfig)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if err := convertViaJSON(&src.Spec, &in.Spec); err != nil {
+		return err
+	}
+	return convertViaJSON(&src.Status, &in.Status)
+}
+
+// convertConditionsFromV1alpha1 converts the JobCondition of a v1alpha1 Job to
+// standard conditions. Only the condition which is currently set is returned,
+// with its status set to True.
+func convertConditionsFromV1alpha1(rj *v1alpha1.Job) []metav1.Condition {
+	var conditions []metav1.Condition
+	condition := rj.Status.Condition
+
+	if queueing := condition.Queueing; queueing != nil {
+		conditions = append(conditions, metav1.Condition{
+			Type:               JobConditionQueueing,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: rj.CreationTimestamp,
+			Reason:             defaultReason(queueing.Reason, JobConditionQueueing),
+			Message:            queueing.Message,
+		})
+	}
+
+	if waiting := condition.Waiting; waiting != nil {
+		transitionTime := rj.CreationTimestamp
+		if waiting.CreatedAt != nil {
+			transitionTime = *waiting.CreatedAt
+		}
+		conditions = append(conditions, metav1.Condition{
+			Type:               JobConditionWaiting,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: transitionTime,
+			Reason:             defaultReason(waiting.Reason, JobConditionWaiting),
+			Message:            waiting.Message,
+		})
+	}
+
+	if running := condition.Running; running != nil {
+		conditions = append(conditions, metav1.Condition{
+			Type:               JobConditionRunning,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: running.StartedAt,
+			Reason:             JobConditionRunning,
+		})
+	}
+
+	// The finished reason is dropped in favour of the result, which is the more
+	// useful of the two for clients.
+	if finished := condition.Finished; finished != nil {
+		conditions = append(conditions, metav1.Condition{
+			Type:               JobConditionFinished,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: finished.FinishedAt,
+			Reason:             defaultReason(string(finished.Result), JobConditionFinished),
+			Message:            finished.Message,
+		})
+	}
+
+	return conditions
+}
+
+// convertConditionsToV1alpha1 converts the standard conditions of a v1beta1 Job
+// to a v1alpha1 JobCondition. Timestamps which are not stored in the
+// conditions are reconstructed from the rest of the Job's status.
+func convertConditionsToV1alpha1(rj *Job) v1alpha1.JobCondition {
+	var condition v1alpha1.JobCondition
+	conditions := rj.Status.Conditions
+
+	if c := meta.FindStatusCondition(conditions, JobConditionFinished); c != nil && c.Status == metav1.ConditionTrue {
+		condition.Finished = &v1alpha1.JobConditionFinished{
+			StartedAt:  rj.Status.StartTime.DeepCopy(),
+			FinishedAt: c.LastTransitionTime,
+			Result:     v1alpha1.JobResult(c.Reason),
+			Message:    c.Message,
+		}
+		return condition
+	}
+
+	if c := meta.FindStatusCondition(conditions, JobConditionRunning); c != nil && c.Status == metav1.ConditionTrue {
+		running := &v1alpha1.JobConditionRunning{
+			StartedAt: c.LastTransitionTime,
+		}
+		if rj.Status.StartTime != nil {
+			running.CreatedAt = *rj.Status.StartTime
+		}
+		condition.Running = running
+		return condition
+	}
+
+	if c := meta.FindStatusCondition(conditions, JobConditionWaiting); c != nil && c.Status == metav1.ConditionTrue {
+		createdAt := c.LastTransitionTime
+		condition.Waiting = &v1alpha1.JobConditionWaiting{
+			CreatedAt: &createdAt,
+			Reason:    c.Reason,
+			Message:   c.Message,
+		}
+		return condition
+	}
+
+	if c := meta.FindStatusCondition(conditions, JobConditionQueueing); c != nil && c.Status == metav1.ConditionTrue {
+		condition.Queueing = &v1alpha1.JobConditionQueueing{
+			Reason:  c.Reason,
+			Message: c.Message,
+		}
+	}
+
+	return condition
+}
+
+// v1alpha1StandardConditionTypes is the list of condition types which are
+// stored in the Conditions of a v1alpha1 Job, in the order that they are set.
+var v1alpha1StandardConditionTypes = []string{
+	v1alpha1.JobConditionTypeProgressing,
+	v1alpha1.JobConditionTypeFinished,
+}
+
+// mergeConditions merges the standard conditions of a v1alpha1 Job into the
+// conditions converted from its JobCondition. Conditions of the same type are
+// replaced by the standard condition, which is authoritative.
+func mergeConditions(conditions, standard []metav1.Condition) []metav1.Condition {
+	for _, c := range standard {
+		if existing := meta.FindStatusCondition(conditions, c.Type); existing != nil {
+			*existing = c
+			continue
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions
+}
+
+// filterStandardConditions returns the conditions of a v1beta1 Job which are
+// also stored as standard conditions in a v1alpha1 Job.
+func filterStandardConditions(conditions []metav1.Condition) []metav1.Condition {
+	var standard []metav1.Condition
+	for _, conditionType := range v1alpha1StandardConditionTypes {
+		if c := meta.FindStatusCondition(conditions, conditionType); c != nil {
+			standard = append(standard, *c)
+		}
+	}
+	return standard
+}
+
+// defaultReason returns reason if it is not empty, otherwise returns the
+// default reason, since standard conditions require a reason to be set.
+func defaultReason(reason, defaultReason string) string {
+	if reason == "" {
+		return defaultReason
+	}
+	return reason
+}
+
+func convertViaJSON(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
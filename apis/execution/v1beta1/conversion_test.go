@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/apis/execution/v1beta1"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+var (
+	createTime = testutils.Mkmtime("2022-04-01T04:00:00Z")
+	finishTime = testutils.Mkmtimep("2022-04-01T04:01:00Z")
+)
+
+func newJob(tasks ...v1alpha1.TaskRef) *v1alpha1.Job {
+	return &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job",
+			Namespace:         "default",
+			CreationTimestamp: createTime,
+		},
+		Status: v1alpha1.JobStatus{
+			Phase: v1alpha1.JobSucceeded,
+			Tasks: tasks,
+		},
+	}
+}
+
+func TestJob_RoundTrip(t *testing.T) {
+	result := v1alpha1.JobResultSuccess
+	tests := []struct {
+		name string
+		rj   *v1alpha1.Job
+
+		// Checks that the field is preserved in the v1beta1 Job.
+		check func(rj *v1beta1.Job) bool
+	}{
+		{
+			name: "compacted task",
+			rj: newJob(v1alpha1.TaskRef{
+				Name:              "job-1",
+				CreationTimestamp: createTime,
+				FinishTimestamp:   finishTime,
+				Status: v1alpha1.TaskStatus{
+					State:  v1alpha1.TaskSuccess,
+					Result: &result,
+				},
+				Compacted: true,
+			}),
+			check: func(rj *v1beta1.Job) bool {
+				return rj.Status.Tasks[0].Compacted
+			},
+		},
+		{
+			name: "task with log archive",
+			rj: newJob(v1alpha1.TaskRef{
+				Name:              "job-1",
+				CreationTimestamp: createTime,
+				FinishTimestamp:   finishTime,
+				Status: v1alpha1.TaskStatus{
+					State:  v1alpha1.TaskSuccess,
+					Result: &result,
+					LogArchive: &v1alpha1.TaskLogArchive{
+						Key: "default/job-1.log",
+					},
+				},
+			}),
+			check: func(rj *v1beta1.Job) bool {
+				archive := rj.Status.Tasks[0].Status.LogArchive
+				return archive != nil && archive.Key == "default/job-1.log"
+			},
+		},
+		{
+			name: "deleted task with log archive",
+			rj: newJob(v1alpha1.TaskRef{
+				Name:              "job-1",
+				CreationTimestamp: createTime,
+				FinishTimestamp:   finishTime,
+				Status: v1alpha1.TaskStatus{
+					State:  v1alpha1.TaskSuccess,
+					Result: &result,
+				},
+				DeletedStatus: &v1alpha1.TaskStatus{
+					State:  v1alpha1.TaskSuccess,
+					Result: &result,
+					LogArchive: &v1alpha1.TaskLogArchive{
+						Key: "default/job-1.log",
+					},
+				},
+			}),
+			check: func(rj *v1beta1.Job) bool {
+				status := rj.Status.Tasks[0].DeletedStatus
+				return status != nil && status.LogArchive != nil && status.LogArchive.Key == "default/job-1.log"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted := &v1beta1.Job{}
+			if err := converted.ConvertFrom(tt.rj); err != nil {
+				t.Fatalf("ConvertFrom() error = %v", err)
+			}
+			if !tt.check(converted) {
+				t.Errorf("field was not preserved in v1beta1: %+v", converted.Status)
+			}
+
+			got := &v1alpha1.Job{}
+			if err := converted.ConvertTo(got); err != nil {
+				t.Fatalf("ConvertTo() error = %v", err)
+			}
+			if !cmp.Equal(tt.rj.Status.Tasks, got.Status.Tasks) {
+				t.Errorf("tasks not equal after round trip\ndiff = %v", cmp.Diff(tt.rj.Status.Tasks, got.Status.Tasks))
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package v1beta1 contains API Schema definitions for the execution v1beta1 API group
+//
+// Compared to v1alpha1, Job.spec.optionValues is a structured object instead of
+// a JSON/YAML string, and Job.status.conditions uses standard
+// metav1.Conditions instead of a union of condition types.
+//
+// v1alpha1 remains the storage version and the conversion hub, and objects are
+// converted by the conversion webhook served by execution-webhook. Once the
+// storage version is switched to v1beta1 in a future release, existing objects
+// should be rewritten (e.g. using kube-storage-version-migrator, or by reading
+// and writing back every object) before v1alpha1 is removed from
+// status.storedVersions of the CRDs.
+// +kubebuilder:object:generate=true
+// +groupName=execution.furiko.io
+package v1beta1
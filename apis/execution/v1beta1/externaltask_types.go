@@ -0,0 +1,55 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+// ExternalTaskTemplate describes how to run a task on an external runner,
+// outside of the Kubernetes cluster.
+type ExternalTaskTemplate struct {
+	// Name of the external runner that should run the task. External runners
+	// are agents that run outside of the cluster, which watch for ExternalTasks
+	// that are assigned to them and report the status of the task back.
+	RunnerName string `json:"runnerName"`
+
+	// Command to run on the external runner. Supports context variable
+	// substitution.
+	Command []string `json:"command"`
+
+	// Optional list of environment variables to set when running the command.
+	// Values support context variable substitution.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Env []ExternalTaskEnvVar `json:"env,omitempty"`
+
+	// Optional working directory to run the command in. If not specified, the
+	// runner's default working directory will be used.
+	//
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+}
+
+// ExternalTaskEnvVar is an environment variable to set for an ExternalTask.
+type ExternalTaskEnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	//
+	// +optional
+	Value string `json:"value,omitempty"`
+}
@@ -30,6 +30,8 @@ var (
 	configFile      string
 	startupTimeout  time.Duration
 	teardownTimeout time.Duration
+	featureGates    string
+	controllers     string
 )
 
 func initFlags() {
@@ -39,4 +41,10 @@ func initFlags() {
 		"Timeout to start up all controllers, set to negative for no timeout")
 	flag.DurationVar(&teardownTimeout, "teardown-timeout", defaultTeardownTimeout,
 		"Timeout to tear down all controllers, before it will forcibly quit")
+	flag.StringVar(&featureGates, "feature-gates", "",
+		"A set of key=value pairs that enable or disable alpha/beta features, which take precedence over the config")
+	flag.StringVar(&controllers, "controllers", "",
+		"A comma-separated list of controllers to enable, which takes precedence over the config. "+
+			"'*' enables all controllers, 'foo' enables the controller named 'foo', "+
+			"and '-foo' disables the controller named 'foo'")
 }
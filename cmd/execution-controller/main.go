@@ -23,26 +23,56 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/apiserver"
+	"github.com/furiko-io/furiko/pkg/execution/archive"
+	"github.com/furiko-io/furiko/pkg/execution/auditlog"
+	"github.com/furiko-io/furiko/pkg/execution/cloudevents"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/alertcontroller"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/archivecontroller"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/callbackcontroller"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/croncontroller"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/jobconfigcontroller"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/jobcontroller"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/jobgroupcontroller"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/jobqueuecontroller"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/notificationcontroller"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/resourceusagecontroller"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/triggercontroller"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+	"github.com/furiko-io/furiko/pkg/execution/queryserver"
 	"github.com/furiko-io/furiko/pkg/execution/stores/activejobstore"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+	"github.com/furiko-io/furiko/pkg/runtime/features"
 	"github.com/furiko-io/furiko/pkg/runtime/httphandler"
+	"github.com/furiko-io/furiko/pkg/runtime/statsd"
 	"github.com/furiko-io/furiko/pkg/runtime/util"
 )
 
 // +kubebuilder:rbac:groups="",resources=events;pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobconfigs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=jobgroups,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=jobgroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=externaltasks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=executionconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=furikoconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=furikoconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
 
 func main() {
 	initFlags()
@@ -71,6 +101,70 @@ func main() {
 	}
 	klog.Infof("bootstrap configuration loaded:\n%v", optionsMarshaled)
 
+	// Set up feature gates.
+	if err := features.Configure(features.DefaultMutableFeatureGate, options.FeatureGates, featureGates); err != nil {
+		klog.Fatalf("cannot configure feature gates: %v", err)
+	}
+	features.LogAndObserve(features.DefaultMutableFeatureGate)
+
+	// Set up event sinks, which must be done before controllers are created.
+	var sinks []eventsink.Sink
+
+	// The audit log is written as an event sink of state transitions.
+	if spec := options.AuditLog; spec != nil && spec.Enabled != nil && *spec.Enabled {
+		klog.Infof("writing audit log to %v", spec.Output)
+		writer, err := auditlog.Open(spec.Output)
+		if err != nil {
+			klog.Fatalf("cannot open audit log: %v", err)
+		}
+		defer writer.Close()
+		sinks = append(sinks, writer)
+	}
+
+	eventSinksSpec := options.EventSinks
+	if eventSinksSpec == nil {
+		eventSinksSpec = &configv1alpha1.EventSinksSpec{}
+	}
+	if spec := eventSinksSpec.Kafka; spec != nil {
+		klog.Infof("streaming state transitions to kafka topic %v", spec.Topic)
+		sink, err := eventsink.NewKafkaSink(spec)
+		if err != nil {
+			klog.Fatalf("cannot set up kafka event sink: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if spec := eventSinksSpec.Pushgateway; spec != nil {
+		klog.Infof("pushing job metrics to pushgateway %v", spec.URL)
+		sink, err := eventsink.NewPushgatewaySink(spec)
+		if err != nil {
+			klog.Fatalf("cannot set up pushgateway event sink: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if spec := eventSinksSpec.Grafana; spec != nil {
+		klog.Infof("posting job annotations to grafana %v", spec.URL)
+		sink, err := eventsink.NewGrafanaSink(spec)
+		if err != nil {
+			klog.Fatalf("cannot set up grafana event sink: %v", err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	// Set up job archive for the history server. The ArchiveController opens its
+	// own Store to write finished Jobs.
+	var archiveStore *archive.Store
+	if spec := options.Archive; spec != nil {
+		store, err := archive.Open(spec)
+		if err != nil {
+			klog.Fatalf("cannot open job archive: %v", err)
+		}
+		defer store.Close()
+		if err := store.Init(context.Background()); err != nil {
+			klog.Fatalf("cannot initialize job archive: %v", err)
+		}
+		archiveStore = store
+	}
+
 	kubeconfig, err := ctrl.GetConfig()
 	if err != nil {
 		klog.Fatalf("cannot get kubeconfig: %v", err)
@@ -83,6 +177,12 @@ func main() {
 		klog.Fatalf("cannot initialize controllercontext: %v", err)
 	}
 
+	// CloudEvents are sent to the target in the dynamic config, and are always
+	// dispatched in case the target is configured later.
+	sinks = append(sinks, cloudevents.NewHTTPSink(ctrlContext.Configs()))
+	dispatcher := eventsink.NewDispatcher(int(eventSinksSpec.BufferSize), int(eventSinksSpec.MaxBatchSize),
+		eventSinksSpec.FlushInterval.Duration, sinks...)
+
 	// Create controller manager.
 	klog.Info("setting up controller manager")
 	mgr, err := controllermanager.NewControllerManager(
@@ -105,29 +205,96 @@ func main() {
 		ctrlContext.Stores().Register(store)
 	}
 
+	// Determine the controllers to enable.
+	enabledControllers := options.Controllers
+	if controllers != "" {
+		enabledControllers = controllermanager.ParseControllers(controllers)
+	}
+	factories := GetControllerFactories(&options, dispatcher)
+	names := make([]string, 0, len(factories))
+	for _, factory := range factories {
+		names = append(names, factory.Name())
+	}
+	if err := controllermanager.ValidateControllers(enabledControllers, names); err != nil {
+		klog.Fatalf("cannot determine controllers to enable: %v", err)
+	}
+
 	// Set up controllers.
-	for _, factory := range GetControllerFactories() {
+	for _, factory := range factories {
+		if !controllermanager.IsControllerEnabled(factory.Name(), enabledControllers) {
+			klog.Infof("controller %v is disabled", factory.Name())
+			continue
+		}
 		concurrencySpec := options.ControllerConcurrency
 		if concurrencySpec == nil {
 			concurrencySpec = &configv1alpha1.ExecutionControllerConcurrencySpec{}
 		}
+		rateLimiterSpec := options.ControllerRateLimiters
+		if rateLimiterSpec == nil {
+			rateLimiterSpec = &configv1alpha1.ExecutionControllerRateLimitersSpec{}
+		}
 		klog.Infof("setting up controller %v", factory.Name())
-		controller, err := factory.New(ctrlContext, concurrencySpec)
+		controller, err := factory.New(ctrlContext, concurrencySpec, rateLimiterSpec)
 		if err != nil {
 			klog.Fatalf("cannot initialize controller %v: %v", factory.Name(), err)
 		}
 		mgr.Add(controller)
 	}
 
+	// Set up query server and API server, which must be done before informers are started.
+	var handlers []httphandler.Handler
+	var grpcServer *apiserver.GRPCServer
+	if spec := options.QueryServer; spec != nil && spec.Enabled != nil && *spec.Enabled {
+		klog.Info("setting up query server")
+		server, err := queryserver.NewServer(ctrlContext, spec)
+		if err != nil {
+			klog.Fatalf("cannot initialize query server: %v", err)
+		}
+		handlers = append(handlers, server)
+	}
+	if spec := options.APIServer; spec != nil && spec.Enabled != nil && *spec.Enabled {
+		klog.Info("setting up api server")
+		server, err := apiserver.NewServer(ctrlContext, spec)
+		if err != nil {
+			klog.Fatalf("cannot initialize api server: %v", err)
+		}
+		handlers = append(handlers, server)
+		if spec.GRPCBindAddress != "" {
+			grpcServer = apiserver.NewGRPCServer(server)
+		}
+	}
+	if archiveStore != nil {
+		handlers = append(handlers, archive.NewHistoryServer(archiveStore, options.Archive.HistoryPathPrefix))
+	}
+
 	ctx := ctrl.SetupSignalHandler()
+	dispatcher.Start(ctx)
+
+	// Start exporting metrics to StatsD in background.
+	if spec := options.StatsD; spec != nil {
+		exporter, err := statsd.NewExporter(spec, metrics.Registry)
+		if err != nil {
+			klog.Fatalf("cannot initialize statsd exporter: %v", err)
+		}
+		exporter.Start(ctx)
+	}
 
 	// Start HTTP server in background.
 	go func() {
-		if err := httphandler.ListenAndServe(ctx, options.HTTP, mgr); err != nil {
+		if err := httphandler.ListenAndServe(ctx, options.HTTP, mgr, handlers...); err != nil {
 			klog.Fatalf("cannot start http handlers: %v", err)
 		}
 	}()
 
+	// Start gRPC server in background.
+	if grpcServer != nil {
+		go func() {
+			if err := grpcServer.ListenAndServe(ctx, options.APIServer.GRPCBindAddress); err != nil {
+				klog.Fatalf("cannot start grpc server: %v", err)
+			}
+		}()
+	}
+
 	klog.Info("starting manager")
 	if err := mgr.Start(ctx, startupTimeout); err != nil && !errors.Is(err, context.Canceled) {
 		klog.Fatalf("cannot start controller manager: %v", err)
@@ -148,17 +315,27 @@ func main() {
 type ControllerFactory interface {
 	Name() string
 	New(ctx controllercontext.Context,
-		concurrency *configv1alpha1.ExecutionControllerConcurrencySpec) (controllermanager.Controller, error)
+		concurrency *configv1alpha1.ExecutionControllerConcurrencySpec,
+		rateLimiters *configv1alpha1.ExecutionControllerRateLimitersSpec) (controllermanager.Controller, error)
 }
 
 // GetControllerFactories returns a list of ControllerFactory implementations
 // that should be created by this controller manager.
-func GetControllerFactories() []ControllerFactory {
+func GetControllerFactories(
+	options *configv1alpha1.ExecutionControllerConfig, eventSink eventsink.Recorder,
+) []ControllerFactory {
 	return []ControllerFactory{
-		croncontroller.NewFactory(),
-		jobcontroller.NewFactory(),
-		jobconfigcontroller.NewFactory(),
+		alertcontroller.NewFactory(),
+		archivecontroller.NewFactory(options.Archive),
+		callbackcontroller.NewFactory(),
+		croncontroller.NewFactory(eventSink),
+		jobcontroller.NewFactory(eventSink),
+		jobconfigcontroller.NewFactory(eventSink),
+		jobgroupcontroller.NewFactory(),
 		jobqueuecontroller.NewFactory(),
+		notificationcontroller.NewFactory(),
+		resourceusagecontroller.NewFactory(),
+		triggercontroller.NewFactory(options.Triggers),
 	}
 }
 
@@ -30,6 +30,7 @@ var (
 	configFile      string
 	startupTimeout  time.Duration
 	teardownTimeout time.Duration
+	featureGates    string
 )
 
 func initFlags() {
@@ -39,4 +40,6 @@ func initFlags() {
 		"Timeout to start up all controllers, set to negative for no timeout")
 	flag.DurationVar(&teardownTimeout, "teardown-timeout", defaultTeardownTimeout,
 		"Timeout to tear down all controllers, before it will forcibly quit")
+	flag.StringVar(&featureGates, "feature-gates", "",
+		"A set of key=value pairs that enable or disable alpha/beta features, which take precedence over the config")
 }
@@ -21,17 +21,22 @@ import (
 	"errors"
 	"flag"
 
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/webhooks/jobconfigmutatingwebhook"
 	"github.com/furiko-io/furiko/pkg/execution/webhooks/jobconfigvalidatingwebhook"
 	"github.com/furiko-io/furiko/pkg/execution/webhooks/jobmutatingwebhook"
 	"github.com/furiko-io/furiko/pkg/execution/webhooks/jobvalidatingwebhook"
+	"github.com/furiko-io/furiko/pkg/runtime/certprovisioner"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+	"github.com/furiko-io/furiko/pkg/runtime/features"
 	"github.com/furiko-io/furiko/pkg/runtime/httphandler"
+	"github.com/furiko-io/furiko/pkg/runtime/statsd"
 	"github.com/furiko-io/furiko/pkg/runtime/util"
 )
 
@@ -39,6 +44,9 @@ import (
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobs/status,verbs=get
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobconfigs,verbs=get;list;watch
 // +kubebuilder:rbac:groups=execution.furiko.io,resources=jobconfigs/status,verbs=get
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=executionconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=tasktemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=execution.furiko.io,resources=furikoconfigs,verbs=get;list;watch
 
 func main() {
 	initFlags()
@@ -67,6 +75,12 @@ func main() {
 	}
 	klog.Infof("bootstrap configuration loaded:\n%v", optionsMarshaled)
 
+	// Set up feature gates.
+	if err := features.Configure(features.DefaultMutableFeatureGate, options.FeatureGates, featureGates); err != nil {
+		klog.Fatalf("cannot configure feature gates: %v", err)
+	}
+	features.LogAndObserve(features.DefaultMutableFeatureGate)
+
 	kubeconfig, err := ctrl.GetConfig()
 	if err != nil {
 		klog.Fatalf("cannot get kubeconfig: %v", err)
@@ -97,13 +111,47 @@ func main() {
 		mgr.Add(webhook)
 	}
 
+	// Provision webhook certificates if the built-in cert provisioner is enabled.
+	var getCertificate httphandler.GetCertificateFunc
+	if spec := options.Webhooks; spec != nil && spec.CertProvisioner != nil {
+		if enabled := spec.CertProvisioner.Enabled; enabled != nil && *enabled {
+			klog.Info("setting up webhook cert provisioner")
+			apiextensions, err := apiextensionsclientset.NewForConfig(kubeconfig)
+			if err != nil {
+				klog.Fatalf("cannot create apiextensions client: %v", err)
+			}
+			provisioner, err := certprovisioner.New(
+				certprovisioner.NewConfigFromSpec(spec.CertProvisioner),
+				ctrlContext.Clientsets().Kubernetes(),
+				apiextensions,
+			)
+			if err != nil {
+				klog.Fatalf("cannot initialize webhook cert provisioner: %v", err)
+			}
+			if err := provisioner.Start(ctx); err != nil {
+				klog.Fatalf("cannot start webhook cert provisioner: %v", err)
+			}
+			getCertificate = provisioner.GetCertificate
+		}
+	}
+
 	// Start webhook server in background.
 	go func() {
-		if err := httphandler.ListenAndServeWebhooks(ctx, options.Webhooks, webhooks); err != nil {
+		if err := httphandler.ListenAndServeWebhooks(ctx, options.Webhooks, webhooks, getCertificate,
+			mgr.SetServing); err != nil {
 			klog.Fatalf("cannot start webhooks server: %v", err)
 		}
 	}()
 
+	// Start exporting metrics to StatsD in background.
+	if spec := options.StatsD; spec != nil {
+		exporter, err := statsd.NewExporter(spec, metrics.Registry)
+		if err != nil {
+			klog.Fatalf("cannot initialize statsd exporter: %v", err)
+		}
+		exporter.Start(ctx)
+	}
+
 	// Start HTTP server in background.
 	go func() {
 		if err := httphandler.ListenAndServe(ctx, options.HTTP, mgr); err != nil {
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package client provides a high-level client for common operations on
+// Furiko resources, such as running a JobConfig and waiting for the Job to
+// complete, for services that integrate with Furiko.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	// DefaultPollInterval is the default interval between each poll of the Job's
+	// status in WaitForCompletion.
+	DefaultPollInterval = 5 * time.Second
+)
+
+// Client wraps the generated clientset with convenience methods.
+type Client struct {
+	clientset versioned.Interface
+
+	// PollInterval is the interval between each poll of the Job's status in
+	// WaitForCompletion. Defaults to DefaultPollInterval if zero.
+	PollInterval time.Duration
+}
+
+// New returns a new Client using the given clientset.
+func New(clientset versioned.Interface) *Client {
+	return &Client{
+		clientset: clientset,
+	}
+}
+
+// RunOptions contains the options for RunJob.
+type RunOptions struct {
+	// OptionValues specifies the values for the JobConfig's options.
+	OptionValues map[string]interface{}
+
+	// StartPolicy specifies the start policy of the Job. If not specified, the
+	// Job will be started immediately, subject to the JobConfig's concurrency
+	// policy.
+	StartPolicy *execution.StartPolicySpec
+
+	// Labels and Annotations are added to the Job's metadata.
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// RunJob creates a new Job from the JobConfig with the given name.
+func (c *Client) RunJob(
+	ctx context.Context, namespace, jobConfigName string, opts RunOptions,
+) (*execution.Job, error) {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: jobConfigName + "-",
+			Namespace:    namespace,
+			Labels:       opts.Labels,
+			Annotations:  opts.Annotations,
+		},
+		Spec: execution.JobSpec{
+			ConfigName:  jobConfigName,
+			StartPolicy: opts.StartPolicy,
+		},
+	}
+
+	if len(opts.OptionValues) > 0 {
+		optionValues, err := json.Marshal(opts.OptionValues)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal option values")
+		}
+		rj.Spec.OptionValues = string(optionValues)
+	}
+
+	created, err := c.clientset.ExecutionV1alpha1().Jobs(namespace).Create(ctx, rj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create job for jobconfig %v/%v", namespace, jobConfigName)
+	}
+	return created, nil
+}
+
+// KillJob requests the Job to be killed immediately.
+func (c *Client) KillJob(ctx context.Context, namespace, name string) (*execution.Job, error) {
+	rj, err := c.annotate(ctx, namespace, name, jobutil.AnnotationKeyRequestKill, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot kill job %v/%v", namespace, name)
+	}
+	return rj, nil
+}
+
+// RetryJob requests a finished Job to be retried. The retry is performed
+// asynchronously by creating a new Job with the same spec.
+func (c *Client) RetryJob(ctx context.Context, namespace, name string) (*execution.Job, error) {
+	retryTime := ktime.Now().Format(time.RFC3339)
+	rj, err := c.annotate(ctx, namespace, name, jobutil.AnnotationKeyRequestRetry, retryTime)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot retry job %v/%v", namespace, name)
+	}
+	return rj, nil
+}
+
+// WaitForCompletion polls the Job until it is finished and returns the
+// finished Job, or returns an error once the context is done.
+func (c *Client) WaitForCompletion(ctx context.Context, namespace, name string) (*execution.Job, error) {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	var rj *execution.Job
+	if err := wait.PollImmediateUntilWithContext(ctx, interval, func(ctx context.Context) (bool, error) {
+		newRj, err := c.clientset.ExecutionV1alpha1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		rj = newRj
+		return rj.Status.Condition.Finished != nil, nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot wait for job %v/%v to complete", namespace, name)
+	}
+
+	return rj, nil
+}
+
+// ListRuns returns all Jobs that were created from the JobConfig, sorted from
+// the most recently created.
+func (c *Client) ListRuns(ctx context.Context, namespace, jobConfigName string) ([]execution.Job, error) {
+	rjc, err := c.clientset.ExecutionV1alpha1().JobConfigs(namespace).Get(ctx, jobConfigName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get jobconfig %v/%v", namespace, jobConfigName)
+	}
+
+	list, err := c.clientset.ExecutionV1alpha1().Jobs(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: jobconfig.LabelJobsForJobConfig(rjc).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list jobs for jobconfig %v/%v", namespace, jobConfigName)
+	}
+
+	rjs := list.Items
+	sort.SliceStable(rjs, func(i, j int) bool {
+		if !rjs[i].CreationTimestamp.Equal(&rjs[j].CreationTimestamp) {
+			return rjs[j].CreationTimestamp.Before(&rjs[i].CreationTimestamp)
+		}
+		return rjs[i].Name > rjs[j].Name
+	})
+	return rjs, nil
+}
+
+func (c *Client) annotate(ctx context.Context, namespace, name, key, value string) (*execution.Job, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				key: value,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.clientset.ExecutionV1alpha1().Jobs(namespace).
+		Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	ktesting "k8s.io/client-go/testing"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/client"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/fake"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	namespace = "test"
+)
+
+var (
+	jobConfig = &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jobconfig",
+			Namespace: namespace,
+			UID:       "jobconfig-uid",
+		},
+	}
+)
+
+func newJob(name, createTime string, labels map[string]string) *execution.Job {
+	return &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			Labels:            labels,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+		},
+	}
+}
+
+func TestClient_RunJob(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	c := client.New(clientset)
+	rj, err := c.RunJob(context.Background(), namespace, "jobconfig", client.RunOptions{
+		OptionValues: map[string]interface{}{
+			"name":  "world",
+			"count": 3,
+		},
+		Labels: map[string]string{"team": "platform"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "jobconfig-", rj.GenerateName)
+	assert.Equal(t, "jobconfig", rj.Spec.ConfigName)
+	assert.JSONEq(t, `{"name":"world","count":3}`, rj.Spec.OptionValues)
+	assert.Equal(t, "platform", rj.Labels["team"])
+
+	_, err = c.RunJob(context.Background(), namespace, "jobconfig", client.RunOptions{
+		OptionValues: map[string]interface{}{"invalid": make(chan int)},
+	})
+	assert.Error(t, err)
+}
+
+func TestClient_KillJob(t *testing.T) {
+	clientset := fake.NewSimpleClientset(newJob("job", "2022-04-01T00:00:00Z", nil))
+	rj, err := client.New(clientset).KillJob(context.Background(), namespace, "job")
+	if !assert.NoError(t, err) {
+		return
+	}
+	ts, ok, err := jobutil.GetRequestedKillTimestamp(rj)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, ts.IsZero())
+
+	_, err = client.New(clientset).KillJob(context.Background(), namespace, "missing")
+	assert.Error(t, err)
+}
+
+func TestClient_RetryJob(t *testing.T) {
+	now := testutils.Mktime("2022-04-01T01:00:00Z")
+	ktime.Clock = clock.NewFakeClock(now)
+	clientset := fake.NewSimpleClientset(newJob("job", "2022-04-01T00:00:00Z", nil))
+	rj, err := client.New(clientset).RetryJob(context.Background(), namespace, "job")
+	if !assert.NoError(t, err) {
+		return
+	}
+	ts, ok, err := jobutil.GetRequestedRetryTimestamp(rj)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, now.Equal(ts.Time))
+}
+
+func TestClient_WaitForCompletion(t *testing.T) {
+	rj := newJob("job", "2022-04-01T00:00:00Z", nil)
+	clientset := fake.NewSimpleClientset(rj)
+
+	// Mark the Job as finished on the third poll.
+	var polls int
+	clientset.PrependReactor("get", "jobs", func(action ktesting.Action) (bool, runtime.Object, error) {
+		polls++
+		newRj := rj.DeepCopy()
+		if polls >= 3 {
+			newRj.Status.Condition.Finished = &execution.JobConditionFinished{
+				FinishedAt: testutils.Mkmtime("2022-04-01T00:01:00Z"),
+				Result:     execution.JobResultSuccess,
+			}
+		}
+		return true, newRj, nil
+	})
+
+	c := client.New(clientset)
+	c.PollInterval = time.Millisecond
+	finished, err := c.WaitForCompletion(context.Background(), namespace, "job")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, execution.JobResultSuccess, finished.Status.Condition.Finished.Result)
+
+	// Context is cancelled before Job is finished.
+	clientset = fake.NewSimpleClientset(rj)
+	c = client.New(clientset)
+	c.PollInterval = time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.WaitForCompletion(ctx, namespace, "job")
+	assert.Error(t, err)
+}
+
+func TestClient_ListRuns(t *testing.T) {
+	labels := jobconfig.LabelJobsForJobConfig(jobConfig)
+	clientset := fake.NewSimpleClientset(
+		jobConfig,
+		newJob("job1", "2022-04-01T00:00:00Z", labels),
+		newJob("job2", "2022-04-01T02:00:00Z", labels),
+		newJob("job3", "2022-04-01T01:00:00Z", labels),
+		newJob("other", "2022-04-01T03:00:00Z", map[string]string{
+			jobconfig.LabelKeyJobConfigUID: "other-uid",
+		}),
+	)
+	c := client.New(clientset)
+	rjs, err := c.ListRuns(context.Background(), namespace, "jobconfig")
+	if !assert.NoError(t, err) {
+		return
+	}
+	names := make([]string, 0, len(rjs))
+	for _, rj := range rjs {
+		names = append(names, rj.Name)
+	}
+	assert.Equal(t, []string{"job2", "job3", "job1"}, names)
+
+	_, err = c.ListRuns(context.Background(), namespace, "missing")
+	assert.Error(t, err)
+}
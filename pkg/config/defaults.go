@@ -26,12 +26,19 @@ var (
 	DefaultJobExecutionConfig = &configv1alpha1.JobExecutionConfig{
 		DefaultTTLSecondsAfterFinished:        pointer.Int64(3600),
 		DefaultPendingTimeoutSeconds:          pointer.Int64(900),
+		DefaultMaxRuntimeSeconds:              pointer.Int64(0),
 		DeleteKillingTasksTimeoutSeconds:      pointer.Int64(180),
 		ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(120),
+		NodeLostReplaceTimeoutSeconds:         pointer.Int64(300),
+		TTLAfterFinishedPolicy:                configv1alpha1.TTLAfterFinishedPolicyDeleteJob,
+		MaxFinishedJobsPerJobConfig:           pointer.Int64(0),
+		MaxConcurrentJobsPerNamespace:         pointer.Int64(0),
 	}
 
 	DefaultJobConfigExecutionConfig = &configv1alpha1.JobConfigExecutionConfig{
-		MaxEnqueuedJobs: pointer.Int64(20),
+		MaxEnqueuedJobs:                     pointer.Int64(20),
+		ResourceUsageSampleIntervalSeconds:  pointer.Int64(0),
+		ResourceRecommendationMarginPercent: pointer.Int64(20),
 	}
 
 	DefaultCronExecutionConfig = &configv1alpha1.CronExecutionConfig{
@@ -43,4 +50,6 @@ var (
 		MaxDowntimeThresholdSeconds: 300,
 		DefaultTimezone:             pointer.String("UTC"),
 	}
+
+	DefaultControllerExecutionConfig = &configv1alpha1.ControllerExecutionConfig{}
 )
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// ConvertDeprecatedOptionValues converts option values which use deprecated
+// formats in-place to their replacement, and returns a warning for each
+// converted value.
+//
+// Currently, the following deprecated formats are converted:
+//
+//  1. Numeric Unix timestamps for date options, which are converted to RFC3339.
+func ConvertDeprecatedOptionValues(
+	values map[string]interface{},
+	cfg *execution.OptionSpec,
+	fldPath *field.Path,
+) []string {
+	if cfg == nil {
+		return nil
+	}
+
+	var warnings []string
+	for _, option := range cfg.Options {
+		value, ok := values[option.Name]
+		if !ok || option.Type != execution.OptionTypeDate {
+			continue
+		}
+		if t, ok := parseUnixTimestamp(value); ok {
+			converted := t.Format(time.RFC3339)
+			values[option.Name] = converted
+			warnings = append(warnings, fmt.Sprintf(
+				"%v: numeric Unix timestamps for date options are deprecated, use an RFC3339 string instead "+
+					"(converted %v to %q)",
+				fldPath.Key(option.Name), t.Unix(), converted,
+			))
+		}
+	}
+
+	return warnings
+}
+
+// parseUnixTimestamp returns the time for a numeric Unix timestamp in seconds.
+func parseUnixTimestamp(value interface{}) (time.Time, bool) {
+	var sec int64
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) {
+			return time.Time{}, false
+		}
+		sec = int64(v)
+	case int64:
+		sec = v
+	case int:
+		sec = int64(v)
+	default:
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0).UTC(), true
+}
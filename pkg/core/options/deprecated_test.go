@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/options"
+)
+
+func TestConvertDeprecatedOptionValues(t *testing.T) {
+	spec := &execution.OptionSpec{
+		Options: []execution.Option{
+			{
+				Type: execution.OptionTypeDate,
+				Name: "date",
+			},
+			{
+				Type: execution.OptionTypeString,
+				Name: "string",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		spec         *execution.OptionSpec
+		values       map[string]interface{}
+		want         map[string]interface{}
+		wantWarnings []string
+	}{
+		{
+			name:   "nil spec",
+			values: map[string]interface{}{"date": float64(stdTime.Unix())},
+			want:   map[string]interface{}{"date": float64(stdTime.Unix())},
+		},
+		{
+			name: "no deprecated values",
+			spec: spec,
+			values: map[string]interface{}{
+				"date":   mockTime,
+				"string": "value",
+			},
+			want: map[string]interface{}{
+				"date":   mockTime,
+				"string": "value",
+			},
+		},
+		{
+			name: "convert numeric date value",
+			spec: spec,
+			values: map[string]interface{}{
+				"date":   float64(stdTime.Unix()),
+				"string": "value",
+			},
+			want: map[string]interface{}{
+				"date":   "2021-02-09T04:06:09Z",
+				"string": "value",
+			},
+			wantWarnings: []string{
+				`root[date]: numeric Unix timestamps for date options are deprecated, use an RFC3339 string instead ` +
+					`(converted 1612843569 to "2021-02-09T04:06:09Z")`,
+			},
+		},
+		{
+			name:   "do not convert fractional date value",
+			spec:   spec,
+			values: map[string]interface{}{"date": 1.5},
+			want:   map[string]interface{}{"date": 1.5},
+		},
+		{
+			name:   "do not convert numeric value for other option types",
+			spec:   spec,
+			values: map[string]interface{}{"string": float64(1)},
+			want:   map[string]interface{}{"string": float64(1)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := options.ConvertDeprecatedOptionValues(tt.values, tt.spec, rootPath)
+			if !cmp.Equal(tt.wantWarnings, got) {
+				t.Errorf("ConvertDeprecatedOptionValues() warnings not equal\ndiff = %v", cmp.Diff(tt.wantWarnings, got))
+			}
+			if !cmp.Equal(tt.want, tt.values) {
+				t.Errorf("ConvertDeprecatedOptionValues() values not equal\ndiff = %v", cmp.Diff(tt.want, tt.values))
+			}
+		})
+	}
+}
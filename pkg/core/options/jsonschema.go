@@ -0,0 +1,146 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	// JSONSchemaDraft is the JSON Schema dialect of generated schemas.
+	JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+)
+
+// JSONSchema is a subset of JSON Schema that is used to describe the option
+// values that are accepted by a JobConfig.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Examples             []string               `json:"examples,omitempty"`
+	MinLength            *int64                 `json:"minLength,omitempty"`
+	MinItems             *int64                 `json:"minItems,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+}
+
+// GenerateJSONSchema returns a JSON Schema that describes the optionValues of
+// a Job for the given OptionSpec, which can be used by external tools to
+// render and validate option inputs. Option rules are not represented in the
+// schema and are only evaluated when the Job is created.
+func GenerateJSONSchema(spec *execution.OptionSpec) *JSONSchema {
+	schema := &JSONSchema{
+		Schema:               JSONSchemaDraft,
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: pointer.Bool(false),
+	}
+	if spec == nil {
+		return schema
+	}
+
+	for _, option := range spec.Options {
+		schema.Properties[option.Name] = GenerateOptionJSONSchema(option)
+		if option.Required {
+			schema.Required = append(schema.Required, option.Name)
+		}
+	}
+
+	return schema
+}
+
+// GenerateOptionJSONSchema returns a JSON Schema for the value of a single
+// Option.
+func GenerateOptionJSONSchema(option execution.Option) *JSONSchema {
+	schema := &JSONSchema{
+		Title: option.Label,
+	}
+
+	switch option.Type {
+	case execution.OptionTypeBool:
+		schema.Type = "boolean"
+		schema.Default = false
+		if cfg := option.Bool; cfg != nil {
+			schema.Default = cfg.Default
+		}
+
+	case execution.OptionTypeString:
+		schema.Type = "string"
+		if cfg := option.String; cfg != nil && cfg.Default != "" {
+			schema.Default = cfg.Default
+		}
+		if option.Required {
+			schema.MinLength = pointer.Int64(1)
+		}
+
+	case execution.OptionTypeSelect:
+		schema.Type = "string"
+		if cfg := option.Select; cfg != nil {
+			if cfg.Default != "" {
+				schema.Default = cfg.Default
+			}
+			setAllowedValues(schema, cfg.Values, cfg.AllowCustom)
+		}
+		if option.Required {
+			schema.MinLength = pointer.Int64(1)
+		}
+
+	case execution.OptionTypeMulti:
+		schema.Type = "array"
+		schema.Items = &JSONSchema{
+			Type:      "string",
+			MinLength: pointer.Int64(1),
+		}
+		if cfg := option.Multi; cfg != nil {
+			if len(cfg.Default) > 0 {
+				schema.Default = cfg.Default
+			}
+			setAllowedValues(schema.Items, cfg.Values, cfg.AllowCustom)
+		}
+		if option.Required {
+			schema.MinItems = pointer.Int64(1)
+		}
+
+	case execution.OptionTypeDate:
+		// Date options only accept RFC3339 timestamps, regardless of the format
+		// that the value will be substituted in.
+		schema.Type = "string"
+		schema.Format = "date-time"
+	}
+
+	return schema
+}
+
+// setAllowedValues restricts the schema to the list of values, or suggests
+// them as examples if custom values are allowed.
+func setAllowedValues(schema *JSONSchema, values []string, allowCustom bool) {
+	if len(values) == 0 {
+		return
+	}
+	if allowCustom {
+		schema.Examples = values
+		return
+	}
+	schema.Enum = values
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/options"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *execution.OptionSpec
+		want string
+	}{
+		{
+			name: "nil spec",
+			want: `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "additionalProperties": false
+}`,
+		},
+		{
+			name: "all option types",
+			spec: &execution.OptionSpec{
+				Options: []execution.Option{
+					{
+						Type:     execution.OptionTypeBool,
+						Name:     "bool",
+						Label:    "Bool Option",
+						Required: true,
+						Bool: &execution.BoolOptionConfig{
+							Default: true,
+						},
+					},
+					{
+						Type:     execution.OptionTypeString,
+						Name:     "string",
+						Required: true,
+						String: &execution.StringOptionConfig{
+							Default: "hello",
+						},
+					},
+					{
+						Type: execution.OptionTypeSelect,
+						Name: "select",
+						Select: &execution.SelectOptionConfig{
+							Default: "a",
+							Values:  []string{"a", "b"},
+						},
+					},
+					{
+						Type: execution.OptionTypeSelect,
+						Name: "select_custom",
+						Select: &execution.SelectOptionConfig{
+							Values:      []string{"a", "b"},
+							AllowCustom: true,
+						},
+					},
+					{
+						Type:     execution.OptionTypeMulti,
+						Name:     "multi",
+						Required: true,
+						Multi: &execution.MultiOptionConfig{
+							Default:   []string{"a"},
+							Delimiter: ",",
+							Values:    []string{"a", "b", "c"},
+						},
+					},
+					{
+						Type: execution.OptionTypeDate,
+						Name: "date",
+						Date: &execution.DateOptionConfig{
+							Format: "YYYY-MM-DD",
+						},
+					},
+				},
+			},
+			want: `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "bool": {
+      "type": "boolean",
+      "title": "Bool Option",
+      "default": true
+    },
+    "date": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "multi": {
+      "type": "array",
+      "default": [
+        "a"
+      ],
+      "minItems": 1,
+      "items": {
+        "type": "string",
+        "enum": [
+          "a",
+          "b",
+          "c"
+        ],
+        "minLength": 1
+      }
+    },
+    "select": {
+      "type": "string",
+      "default": "a",
+      "enum": [
+        "a",
+        "b"
+      ]
+    },
+    "select_custom": {
+      "type": "string",
+      "examples": [
+        "a",
+        "b"
+      ]
+    },
+    "string": {
+      "type": "string",
+      "default": "hello",
+      "minLength": 1
+    }
+  },
+  "required": [
+    "bool",
+    "string",
+    "multi"
+  ],
+  "additionalProperties": false
+}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.MarshalIndent(options.GenerateJSONSchema(tt.spec), "", "  ")
+			if err != nil {
+				t.Fatalf("cannot marshal schema: %v", err)
+			}
+			if !cmp.Equal(tt.want, string(got)) {
+				t.Errorf("GenerateJSONSchema() not equal\ndiff = %v", cmp.Diff(tt.want, string(got)))
+			}
+		})
+	}
+}
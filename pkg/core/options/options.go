@@ -25,6 +25,7 @@ import (
 
 	"github.com/nleeper/goment"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
@@ -71,6 +72,37 @@ func EvaluateOptions(
 	return eval, allErrs
 }
 
+// ValidateOptionValues validates that the given option values can be evaluated
+// against the OptionSpec. In addition to the errors returned by EvaluateOptions,
+// values specified for options that do not exist in the OptionSpec are rejected,
+// and all rules in the OptionSpec must be satisfied.
+func ValidateOptionValues(
+	options map[string]interface{},
+	cfg *execution.OptionSpec,
+	fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	if cfg != nil {
+		for _, option := range cfg.Options {
+			names.Insert(option.Name)
+		}
+	}
+	for _, name := range sets.StringKeySet(options).List() {
+		if !names.Has(name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, name, names.List()))
+		}
+	}
+
+	// Only evaluate rules if all option values are valid.
+	if _, errs := EvaluateOptions(options, cfg, fldPath); len(errs) > 0 {
+		return append(allErrs, errs...)
+	}
+	allErrs = append(allErrs, EvaluateOptionRules(options, cfg, fldPath)...)
+	return allErrs
+}
+
 // EvaluateOption will evaluate the value of the option against the given Option configuration.
 // It assumes that the option is valid.
 func EvaluateOption(value interface{}, option execution.Option, fldPath *field.Path) (string, *field.Error) {
@@ -331,6 +363,7 @@ func ValidateOptionSpec(spec *execution.OptionSpec, fldPath *field.Path) field.E
 			optionNames[option.Name] = struct{}{}
 			allErrs = append(allErrs, ValidateJobOption(option, fldPath)...)
 		}
+		allErrs = append(allErrs, ValidateOptionRules(spec.Rules, fldPath.Child("rules"))...)
 	}
 	return allErrs
 }
@@ -1671,3 +1671,56 @@ func TestEvaluateOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOptionValues(t *testing.T) {
+	cfg := &execution.OptionSpec{
+		Options: []execution.Option{
+			{
+				Name:     "my_option",
+				Type:     execution.OptionTypeString,
+				Required: true,
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		cfg     *execution.OptionSpec
+		wantErr bool
+	}{
+		{
+			name: "no options to validate",
+		},
+		{
+			name:    "valid option values",
+			options: map[string]interface{}{"my_option": "value"},
+			cfg:     cfg,
+		},
+		{
+			name:    "unknown option name",
+			options: map[string]interface{}{"my_option": "value", "unknown_option": "value"},
+			cfg:     cfg,
+			wantErr: true,
+		},
+		{
+			name:    "unknown option name with nil config",
+			options: map[string]interface{}{"unknown_option": "value"},
+			wantErr: true,
+		},
+		{
+			name:    "missing required option",
+			options: map[string]interface{}{},
+			cfg:     cfg,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			errs := options.ValidateOptionValues(tt.options, tt.cfg, rootPath)
+			if (errs.ToAggregate() != nil) != tt.wantErr {
+				t.Errorf("ValidateOptionValues() error = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
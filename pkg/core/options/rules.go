@@ -0,0 +1,213 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/pkg/errors"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	// ruleOptionVariable is the name of the variable that contains all option
+	// values when evaluating option rules.
+	ruleOptionVariable = "option"
+)
+
+// newRuleEnv returns a new CEL environment for compiling option rules.
+func newRuleEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Declarations(
+			decls.NewVar(ruleOptionVariable, decls.NewMapType(decls.String, decls.Dyn)),
+		),
+	)
+}
+
+// compileOptionRule compiles the expression of an option rule into a program.
+func compileOptionRule(env *cel.Env, rule execution.OptionRule) (cel.Program, error) {
+	ast, issues := env.Compile(rule.Expression)
+	if err := issues.Err(); err != nil {
+		return nil, err
+	}
+	if t := ast.ResultType(); t.GetPrimitive() != exprpb.Type_BOOL && t.GetDyn() == nil {
+		return nil, errors.New("expression must evaluate to bool")
+	}
+	return env.Program(ast)
+}
+
+// ValidateOptionRules validates that all option rules can be compiled.
+func ValidateOptionRules(rules []execution.OptionRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(rules) == 0 {
+		return allErrs
+	}
+
+	env, err := newRuleEnv()
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+
+	for i, rule := range rules {
+		fldPath := fldPath.Index(i).Child("expression")
+		if strings.TrimSpace(rule.Expression) == "" {
+			allErrs = append(allErrs, field.Required(fldPath, ""))
+			continue
+		}
+		if _, err := compileOptionRule(env, rule); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, rule.Expression, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// EvaluateOptionRules evaluates all rules in the OptionSpec against the given
+// option values, and returns an error for each rule that failed. It assumes that
+// the option values were already successfully evaluated with EvaluateOptions.
+func EvaluateOptionRules(
+	options map[string]interface{},
+	cfg *execution.OptionSpec,
+	fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return allErrs
+	}
+
+	env, err := newRuleEnv()
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+
+	vars := map[string]interface{}{
+		ruleOptionVariable: MakeOptionRuleValues(options, cfg),
+	}
+
+	for _, rule := range cfg.Rules {
+		prg, err := compileOptionRule(env, rule)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, rule.Expression, fmt.Sprintf("cannot compile rule: %v", err)))
+			continue
+		}
+
+		out, _, err := prg.Eval(vars)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, rule.Expression, fmt.Sprintf("cannot evaluate rule: %v", err)))
+			continue
+		}
+
+		if ok, isBool := out.Value().(bool); !isBool {
+			allErrs = append(allErrs, field.Invalid(fldPath, rule.Expression,
+				fmt.Sprintf("rule evaluated to %T, expected bool", out.Value())))
+		} else if !ok {
+			message := rule.Message
+			if message == "" {
+				message = fmt.Sprintf("failed rule: %v", rule.Expression)
+			}
+			allErrs = append(allErrs, field.Forbidden(fldPath, message))
+		}
+	}
+
+	return allErrs
+}
+
+// MakeOptionRuleValues returns the typed values of each option to be used when
+// evaluating option rules, with defaults applied for options that were not
+// specified.
+func MakeOptionRuleValues(options map[string]interface{}, cfg *execution.OptionSpec) map[string]interface{} {
+	values := make(map[string]interface{}, len(cfg.Options))
+	for _, option := range cfg.Options {
+		if value, ok := makeOptionRuleValue(options[option.Name], option); ok {
+			values[option.Name] = value
+		}
+	}
+	return values
+}
+
+func makeOptionRuleValue(value interface{}, option execution.Option) (interface{}, bool) {
+	switch option.Type {
+	case execution.OptionTypeBool:
+		if v, ok := value.(bool); ok {
+			return v, true
+		}
+		if option.Bool != nil {
+			return option.Bool.Default, true
+		}
+		return false, true
+
+	case execution.OptionTypeString:
+		v, ok := value.(string)
+		if !ok && option.String != nil {
+			v = option.String.Default
+		}
+		if option.String != nil && option.String.TrimSpaces {
+			v = strings.TrimSpace(v)
+		}
+		return v, true
+
+	case execution.OptionTypeSelect:
+		if v, ok := value.(string); ok {
+			return v, true
+		}
+		if option.Select != nil {
+			return option.Select.Default, true
+		}
+		return "", true
+
+	case execution.OptionTypeMulti:
+		var v []string
+		if vi, ok := value.([]interface{}); ok {
+			for _, vx := range vi {
+				if s, ok := vx.(string); ok {
+					v = append(v, s)
+				}
+			}
+		} else if vs, ok := value.([]string); ok {
+			v = vs
+		}
+		if len(v) == 0 && option.Multi != nil {
+			v = option.Multi.Default
+		}
+		if v == nil {
+			v = []string{}
+		}
+		return v, true
+
+	case execution.OptionTypeDate:
+		switch v := value.(type) {
+		case time.Time:
+			return v, !v.IsZero()
+		case *time.Time:
+			return *v, v != nil && !v.IsZero()
+		case string:
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t, true
+			}
+		}
+		return nil, false
+	}
+
+	return nil, false
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package options_test
+
+import (
+	"strings"
+	"testing"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/options"
+)
+
+var (
+	optionSpecWithDateRange = &execution.OptionSpec{
+		Options: []execution.Option{
+			{
+				Type: execution.OptionTypeDate,
+				Name: "start_date",
+			},
+			{
+				Type: execution.OptionTypeDate,
+				Name: "end_date",
+			},
+			{
+				Type: execution.OptionTypeBool,
+				Name: "dry_run",
+				Bool: &execution.BoolOptionConfig{
+					Default: true,
+				},
+			},
+			{
+				Type: execution.OptionTypeMulti,
+				Name: "targets",
+				Multi: &execution.MultiOptionConfig{
+					Delimiter:   ",",
+					AllowCustom: true,
+				},
+			},
+		},
+		Rules: []execution.OptionRule{
+			{
+				Expression: "!has(option.start_date) || !has(option.end_date) || option.end_date >= option.start_date",
+				Message:    "end_date must not be earlier than start_date",
+			},
+			{
+				Expression: "option.dry_run || size(option.targets) > 0",
+			},
+		},
+	}
+)
+
+func TestValidateOptionRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []execution.OptionRule
+		wantErr string
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:  "valid rules",
+			rules: optionSpecWithDateRange.Rules,
+		},
+		{
+			name: "empty expression",
+			rules: []execution.OptionRule{
+				{Expression: " "},
+			},
+			wantErr: "root[0].expression: Required value",
+		},
+		{
+			name: "cannot compile expression",
+			rules: []execution.OptionRule{
+				{Expression: "option.a >="},
+			},
+			wantErr: "root[0].expression: Invalid value",
+		},
+		{
+			name: "expression does not return bool",
+			rules: []execution.OptionRule{
+				{Expression: `"hello"`},
+			},
+			wantErr: `root[0].expression: Invalid value: "\"hello\"": expression must evaluate to bool`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := options.ValidateOptionRules(tt.rules, rootPath).ToAggregate()
+			if checkRuleError(err, tt.wantErr) {
+				t.Errorf("ValidateOptionRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluateOptionRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]interface{}
+		cfg     *execution.OptionSpec
+		wantErr string
+	}{
+		{
+			name: "nil config",
+		},
+		{
+			name:    "all rules pass with defaults",
+			options: map[string]interface{}{},
+			cfg:     optionSpecWithDateRange,
+		},
+		{
+			name: "all rules pass",
+			options: map[string]interface{}{
+				"start_date": "2022-04-01T00:00:00Z",
+				"end_date":   "2022-04-02T00:00:00Z",
+				"dry_run":    false,
+				"targets":    []interface{}{"a"},
+			},
+			cfg: optionSpecWithDateRange,
+		},
+		{
+			name: "rule failed with message",
+			options: map[string]interface{}{
+				"start_date": "2022-04-02T00:00:00Z",
+				"end_date":   "2022-04-01T00:00:00Z",
+			},
+			cfg:     optionSpecWithDateRange,
+			wantErr: "root: Forbidden: end_date must not be earlier than start_date",
+		},
+		{
+			name: "rule failed without message",
+			options: map[string]interface{}{
+				"dry_run": false,
+			},
+			cfg:     optionSpecWithDateRange,
+			wantErr: "root: Forbidden: failed rule: option.dry_run || size(option.targets) > 0",
+		},
+		{
+			name:    "rule refers to unknown option",
+			options: map[string]interface{}{},
+			cfg: &execution.OptionSpec{
+				Rules: []execution.OptionRule{
+					{Expression: "option.unknown == 'a'"},
+				},
+			},
+			wantErr: "root: Invalid value",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := options.EvaluateOptionRules(tt.options, tt.cfg, rootPath).ToAggregate()
+			if checkRuleError(err, tt.wantErr) {
+				t.Errorf("EvaluateOptionRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func checkRuleError(err error, wantErr string) bool {
+	if (err == nil) != (wantErr == "") {
+		return true
+	}
+	return err != nil && !strings.HasPrefix(err.Error(), wantErr)
+}
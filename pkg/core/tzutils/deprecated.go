@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tzutils
+
+// deprecatedTimezones maps deprecated tzdata names, which are only kept in the
+// "backward" file of tzdata for compatibility, to their canonical replacements.
+// UTC and GMT are not included since they are explicitly supported.
+var deprecatedTimezones = map[string]string{
+	"Brazil/Acre":         "America/Rio_Branco",
+	"Brazil/DeNoronha":    "America/Noronha",
+	"Brazil/East":         "America/Sao_Paulo",
+	"Brazil/West":         "America/Manaus",
+	"Canada/Atlantic":     "America/Halifax",
+	"Canada/Central":      "America/Winnipeg",
+	"Canada/Eastern":      "America/Toronto",
+	"Canada/Mountain":     "America/Edmonton",
+	"Canada/Newfoundland": "America/St_Johns",
+	"Canada/Pacific":      "America/Vancouver",
+	"Canada/Saskatchewan": "America/Regina",
+	"Canada/Yukon":        "America/Whitehorse",
+	"CET":                 "Europe/Brussels",
+	"Chile/Continental":   "America/Santiago",
+	"Chile/EasterIsland":  "Pacific/Easter",
+	"CST6CDT":             "America/Chicago",
+	"Cuba":                "America/Havana",
+	"EET":                 "Europe/Athens",
+	"Egypt":               "Africa/Cairo",
+	"Eire":                "Europe/Dublin",
+	"EST":                 "America/Panama",
+	"EST5EDT":             "America/New_York",
+	"GB":                  "Europe/London",
+	"GB-Eire":             "Europe/London",
+	"Hongkong":            "Asia/Hong_Kong",
+	"HST":                 "Pacific/Honolulu",
+	"Iceland":             "Atlantic/Reykjavik",
+	"Iran":                "Asia/Tehran",
+	"Israel":              "Asia/Jerusalem",
+	"Jamaica":             "America/Jamaica",
+	"Japan":               "Asia/Tokyo",
+	"Kwajalein":           "Pacific/Kwajalein",
+	"Libya":               "Africa/Tripoli",
+	"MET":                 "Europe/Brussels",
+	"Mexico/BajaNorte":    "America/Tijuana",
+	"Mexico/BajaSur":      "America/Mazatlan",
+	"Mexico/General":      "America/Mexico_City",
+	"MST":                 "America/Phoenix",
+	"MST7MDT":             "America/Denver",
+	"Navajo":              "America/Denver",
+	"NZ":                  "Pacific/Auckland",
+	"NZ-CHAT":             "Pacific/Chatham",
+	"Poland":              "Europe/Warsaw",
+	"Portugal":            "Europe/Lisbon",
+	"PRC":                 "Asia/Shanghai",
+	"PST8PDT":             "America/Los_Angeles",
+	"ROC":                 "Asia/Taipei",
+	"ROK":                 "Asia/Seoul",
+	"Singapore":           "Asia/Singapore",
+	"Turkey":              "Europe/Istanbul",
+	"US/Alaska":           "America/Anchorage",
+	"US/Aleutian":         "America/Adak",
+	"US/Arizona":          "America/Phoenix",
+	"US/Central":          "America/Chicago",
+	"US/East-Indiana":     "America/Indiana/Indianapolis",
+	"US/Eastern":          "America/New_York",
+	"US/Hawaii":           "Pacific/Honolulu",
+	"US/Indiana-Starke":   "America/Indiana/Knox",
+	"US/Michigan":         "America/Detroit",
+	"US/Mountain":         "America/Denver",
+	"US/Pacific":          "America/Los_Angeles",
+	"US/Samoa":            "Pacific/Pago_Pago",
+	"W-SU":                "Europe/Moscow",
+	"WET":                 "Europe/Lisbon",
+}
+
+// GetDeprecatedTimezoneReplacement returns the canonical tzdata name that
+// should be used in place of val, and false if val is not a deprecated tzdata
+// name.
+func GetDeprecatedTimezoneReplacement(val string) (string, bool) {
+	replacement, ok := deprecatedTimezones[val]
+	return replacement, ok
+}
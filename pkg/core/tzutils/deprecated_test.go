@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tzutils
+
+import (
+	"testing"
+)
+
+func TestGetDeprecatedTimezoneReplacement(t *testing.T) {
+	tests := []struct {
+		val    string
+		want   string
+		wantOk bool
+	}{
+		{val: "Asia/Singapore"},
+		{val: "UTC"},
+		{val: "UTC+08:00"},
+		{val: "Singapore", want: "Asia/Singapore", wantOk: true},
+		{val: "US/Eastern", want: "America/New_York", wantOk: true},
+		{val: "MST7MDT", want: "America/Denver", wantOk: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.val, func(t *testing.T) {
+			got, ok := GetDeprecatedTimezoneReplacement(tt.val)
+			if ok != tt.wantOk {
+				t.Errorf("GetDeprecatedTimezoneReplacement() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if got != tt.want {
+				t.Errorf("GetDeprecatedTimezoneReplacement() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecatedTimezoneReplacements(t *testing.T) {
+	for deprecated, replacement := range deprecatedTimezones {
+		if _, ok := deprecatedTimezones[replacement]; ok {
+			t.Errorf("replacement %v for %v is also deprecated", replacement, deprecated)
+		}
+		if _, err := ParseTimezone(replacement); err != nil {
+			t.Errorf("cannot parse replacement %v for %v: %v", replacement, deprecated, err)
+		}
+	}
+}
@@ -34,11 +34,11 @@ func ToInternalErrorList(fldPath *field.Path, err error) field.ErrorList {
 }
 
 // ValidateImmutableField validates the new value and the old value are deeply
-// equal and returns an error with a custom message.
+// equal and returns a Forbidden error with a custom message.
 func ValidateImmutableField(newVal, oldVal interface{}, fldPath *field.Path, msg string) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if !apiequality.Semantic.DeepEqual(oldVal, newVal) {
-		allErrs = append(allErrs, field.Invalid(fldPath, newVal, msg))
+		allErrs = append(allErrs, field.Forbidden(fldPath, msg))
 	}
 	return allErrs
 }
@@ -74,7 +74,7 @@ func TestValidateImmutableField(t *testing.T) {
 			oldVal: 0,
 			msg:    "custom error message",
 			want: field.ErrorList{
-				field.Invalid(fldPath, 1, "custom error message"),
+				field.Forbidden(fldPath, "custom error message"),
 			},
 		},
 		{
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	maxCacheEntries = 10000
+)
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errInvalidToken = errors.New("invalid bearer token")
+)
+
+// attributes describes the action performed by a request, which is checked
+// against the RBAC permissions of the user.
+type attributes struct {
+	verb        string
+	group       string
+	resource    string
+	subresource string
+	namespace   string
+	name        string
+}
+
+// auth authenticates requests using TokenReviews and authorizes them using
+// SubjectAccessReviews, caching the results of both for a short duration.
+type auth struct {
+	client    kubernetes.Interface
+	audiences []string
+	ttl       time.Duration
+	oidc      *oidcVerifier
+
+	mu        sync.Mutex
+	users     map[string]cachedUser
+	decisions map[string]cachedDecision
+}
+
+type cachedUser struct {
+	user   authenticationv1.UserInfo
+	expiry time.Time
+}
+
+type cachedDecision struct {
+	allowed bool
+	reason  string
+	expiry  time.Time
+}
+
+func newAuth(client kubernetes.Interface, audiences []string, ttl time.Duration, oidc *oidcVerifier) *auth {
+	return &auth{
+		client:    client,
+		audiences: audiences,
+		ttl:       ttl,
+		oidc:      oidc,
+		users:     make(map[string]cachedUser),
+		decisions: make(map[string]cachedDecision),
+	}
+}
+
+// authenticate returns the user that made the request from the bearer token in
+// its Authorization header, and whether the user can only make read-only
+// requests. ID tokens issued by the OIDC provider are verified locally, while
+// all other tokens are verified using TokenReviews.
+func (a *auth) authenticate(ctx context.Context, header string) (authenticationv1.UserInfo, bool, error) {
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	if !strings.HasPrefix(header, "Bearer ") || token == "" {
+		return authenticationv1.UserInfo{}, false, errMissingToken
+	}
+
+	if a.oidc != nil && a.oidc.isIssuedBy(token) {
+		user, err := a.oidc.verify(ctx, token)
+		if err != nil {
+			klog.V(4).InfoS("apiserver: cannot verify oidc token", "err", err)
+			return authenticationv1.UserInfo{}, false, errInvalidToken
+		}
+		return user, true, nil
+	}
+
+	user, err := a.reviewToken(ctx, token)
+	return user, false, err
+}
+
+// reviewToken returns the user that the token belongs to using a TokenReview.
+func (a *auth) reviewToken(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	// Avoid keeping tokens in memory.
+	hash := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(hash[:])
+	now := ktime.Now().Time
+	a.mu.Lock()
+	cached, ok := a.users[key]
+	a.mu.Unlock()
+	if ok && now.Before(cached.expiry) {
+		return cached.user, nil
+	}
+
+	review, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: a.audiences,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, errors.Wrapf(err, "cannot create tokenreview")
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, errInvalidToken
+	}
+
+	if a.ttl > 0 {
+		a.mu.Lock()
+		if len(a.users) >= maxCacheEntries {
+			a.pruneLocked(now)
+		}
+		a.users[key] = cachedUser{user: review.Status.User, expiry: now.Add(a.ttl)}
+		a.mu.Unlock()
+	}
+	return review.Status.User, nil
+}
+
+// authorize returns whether the user is allowed to perform the action, and the
+// reason if it was denied.
+func (a *auth) authorize(
+	ctx context.Context, user authenticationv1.UserInfo, attrs attributes,
+) (bool, string, error) {
+	key := strings.Join([]string{
+		user.UID, user.Username, strings.Join(user.Groups, ","),
+		attrs.verb, attrs.group, attrs.resource, attrs.subresource, attrs.namespace, attrs.name,
+	}, "/")
+	now := ktime.Now().Time
+	a.mu.Lock()
+	cached, ok := a.decisions[key]
+	a.mu.Unlock()
+	if ok && now.Before(cached.expiry) {
+		return cached.allowed, cached.reason, nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   attrs.namespace,
+				Verb:        attrs.verb,
+				Group:       attrs.group,
+				Resource:    attrs.resource,
+				Subresource: attrs.subresource,
+				Name:        attrs.name,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, "", errors.Wrapf(err, "cannot create subjectaccessreview")
+	}
+
+	allowed := review.Status.Allowed && !review.Status.Denied
+	if a.ttl > 0 {
+		a.mu.Lock()
+		if len(a.decisions) >= maxCacheEntries {
+			a.pruneLocked(now)
+		}
+		a.decisions[key] = cachedDecision{allowed: allowed, reason: review.Status.Reason, expiry: now.Add(a.ttl)}
+		a.mu.Unlock()
+	}
+	return allowed, review.Status.Reason, nil
+}
+
+// pruneLocked removes expired entries from the caches. If the caches are still
+// too large, they are cleared entirely.
+func (a *auth) pruneLocked(now time.Time) {
+	for key, cached := range a.users {
+		if !now.Before(cached.expiry) {
+			delete(a.users, key)
+		}
+	}
+	for key, cached := range a.decisions {
+		if !now.Before(cached.expiry) {
+			delete(a.decisions, key)
+		}
+	}
+	if len(a.users) >= maxCacheEntries {
+		a.users = make(map[string]cachedUser)
+	}
+	if len(a.decisions) >= maxCacheEntries {
+		a.decisions = make(map[string]cachedDecision)
+	}
+}
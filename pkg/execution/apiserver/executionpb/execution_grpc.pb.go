@@ -0,0 +1,446 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package executionpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExecutionServiceClient is the client API for ExecutionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExecutionServiceClient interface {
+	// Gets a Job.
+	GetJob(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error)
+	// Lists Jobs, ordered by creation timestamp from newest to oldest.
+	ListJobs(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ObjectList, error)
+	// Streams all existing Jobs as ADDED events, followed by subsequent events.
+	// The stream is closed with UNAVAILABLE if it falls too far behind, and
+	// clients are expected to reconnect.
+	WatchJobs(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ExecutionService_WatchJobsClient, error)
+	// Kills a Job.
+	KillJob(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error)
+	// Gets a JobConfig.
+	GetJobConfig(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error)
+	// Lists JobConfigs, ordered by creation timestamp from newest to oldest.
+	ListJobConfigs(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ObjectList, error)
+	// Streams all existing JobConfigs as ADDED events, followed by subsequent
+	// events.
+	WatchJobConfigs(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ExecutionService_WatchJobConfigsClient, error)
+	// Runs a JobConfig, returning the created Job.
+	RunJobConfig(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*Object, error)
+}
+
+type executionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExecutionServiceClient(cc grpc.ClientConnInterface) ExecutionServiceClient {
+	return &executionServiceClient{cc}
+}
+
+func (c *executionServiceClient) GetJob(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error) {
+	out := new(Object)
+	err := c.cc.Invoke(ctx, "/furiko.execution.v1alpha1.ExecutionService/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) ListJobs(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ObjectList, error) {
+	out := new(ObjectList)
+	err := c.cc.Invoke(ctx, "/furiko.execution.v1alpha1.ExecutionService/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) WatchJobs(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ExecutionService_WatchJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExecutionService_ServiceDesc.Streams[0], "/furiko.execution.v1alpha1.ExecutionService/WatchJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &executionServiceWatchJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExecutionService_WatchJobsClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type executionServiceWatchJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *executionServiceWatchJobsClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *executionServiceClient) KillJob(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error) {
+	out := new(Object)
+	err := c.cc.Invoke(ctx, "/furiko.execution.v1alpha1.ExecutionService/KillJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) GetJobConfig(ctx context.Context, in *ObjectRequest, opts ...grpc.CallOption) (*Object, error) {
+	out := new(Object)
+	err := c.cc.Invoke(ctx, "/furiko.execution.v1alpha1.ExecutionService/GetJobConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) ListJobConfigs(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ObjectList, error) {
+	out := new(ObjectList)
+	err := c.cc.Invoke(ctx, "/furiko.execution.v1alpha1.ExecutionService/ListJobConfigs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionServiceClient) WatchJobConfigs(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (ExecutionService_WatchJobConfigsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExecutionService_ServiceDesc.Streams[1], "/furiko.execution.v1alpha1.ExecutionService/WatchJobConfigs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &executionServiceWatchJobConfigsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExecutionService_WatchJobConfigsClient interface {
+	Recv() (*WatchEvent, error)
+	grpc.ClientStream
+}
+
+type executionServiceWatchJobConfigsClient struct {
+	grpc.ClientStream
+}
+
+func (x *executionServiceWatchJobConfigsClient) Recv() (*WatchEvent, error) {
+	m := new(WatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *executionServiceClient) RunJobConfig(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*Object, error) {
+	out := new(Object)
+	err := c.cc.Invoke(ctx, "/furiko.execution.v1alpha1.ExecutionService/RunJobConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecutionServiceServer is the server API for ExecutionService service.
+// All implementations must embed UnimplementedExecutionServiceServer
+// for forward compatibility
+type ExecutionServiceServer interface {
+	// Gets a Job.
+	GetJob(context.Context, *ObjectRequest) (*Object, error)
+	// Lists Jobs, ordered by creation timestamp from newest to oldest.
+	ListJobs(context.Context, *ListRequest) (*ObjectList, error)
+	// Streams all existing Jobs as ADDED events, followed by subsequent events.
+	// The stream is closed with UNAVAILABLE if it falls too far behind, and
+	// clients are expected to reconnect.
+	WatchJobs(*WatchRequest, ExecutionService_WatchJobsServer) error
+	// Kills a Job.
+	KillJob(context.Context, *ObjectRequest) (*Object, error)
+	// Gets a JobConfig.
+	GetJobConfig(context.Context, *ObjectRequest) (*Object, error)
+	// Lists JobConfigs, ordered by creation timestamp from newest to oldest.
+	ListJobConfigs(context.Context, *ListRequest) (*ObjectList, error)
+	// Streams all existing JobConfigs as ADDED events, followed by subsequent
+	// events.
+	WatchJobConfigs(*WatchRequest, ExecutionService_WatchJobConfigsServer) error
+	// Runs a JobConfig, returning the created Job.
+	RunJobConfig(context.Context, *RunRequest) (*Object, error)
+	mustEmbedUnimplementedExecutionServiceServer()
+}
+
+// UnimplementedExecutionServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExecutionServiceServer struct {
+}
+
+func (UnimplementedExecutionServiceServer) GetJob(context.Context, *ObjectRequest) (*Object, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedExecutionServiceServer) ListJobs(context.Context, *ListRequest) (*ObjectList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedExecutionServiceServer) WatchJobs(*WatchRequest, ExecutionService_WatchJobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJobs not implemented")
+}
+func (UnimplementedExecutionServiceServer) KillJob(context.Context, *ObjectRequest) (*Object, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KillJob not implemented")
+}
+func (UnimplementedExecutionServiceServer) GetJobConfig(context.Context, *ObjectRequest) (*Object, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobConfig not implemented")
+}
+func (UnimplementedExecutionServiceServer) ListJobConfigs(context.Context, *ListRequest) (*ObjectList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobConfigs not implemented")
+}
+func (UnimplementedExecutionServiceServer) WatchJobConfigs(*WatchRequest, ExecutionService_WatchJobConfigsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJobConfigs not implemented")
+}
+func (UnimplementedExecutionServiceServer) RunJobConfig(context.Context, *RunRequest) (*Object, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJobConfig not implemented")
+}
+func (UnimplementedExecutionServiceServer) mustEmbedUnimplementedExecutionServiceServer() {}
+
+// UnsafeExecutionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExecutionServiceServer will
+// result in compilation errors.
+type UnsafeExecutionServiceServer interface {
+	mustEmbedUnimplementedExecutionServiceServer()
+}
+
+func RegisterExecutionServiceServer(s grpc.ServiceRegistrar, srv ExecutionServiceServer) {
+	s.RegisterService(&ExecutionService_ServiceDesc, srv)
+}
+
+func _ExecutionService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/furiko.execution.v1alpha1.ExecutionService/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).GetJob(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/furiko.execution.v1alpha1.ExecutionService/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).ListJobs(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_WatchJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecutionServiceServer).WatchJobs(m, &executionServiceWatchJobsServer{stream})
+}
+
+type ExecutionService_WatchJobsServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type executionServiceWatchJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *executionServiceWatchJobsServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExecutionService_KillJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).KillJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/furiko.execution.v1alpha1.ExecutionService/KillJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).KillJob(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_GetJobConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).GetJobConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/furiko.execution.v1alpha1.ExecutionService/GetJobConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).GetJobConfig(ctx, req.(*ObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_ListJobConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).ListJobConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/furiko.execution.v1alpha1.ExecutionService/ListJobConfigs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).ListJobConfigs(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionService_WatchJobConfigs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecutionServiceServer).WatchJobConfigs(m, &executionServiceWatchJobConfigsServer{stream})
+}
+
+type ExecutionService_WatchJobConfigsServer interface {
+	Send(*WatchEvent) error
+	grpc.ServerStream
+}
+
+type executionServiceWatchJobConfigsServer struct {
+	grpc.ServerStream
+}
+
+func (x *executionServiceWatchJobConfigsServer) Send(m *WatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ExecutionService_RunJobConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionServiceServer).RunJobConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/furiko.execution.v1alpha1.ExecutionService/RunJobConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionServiceServer).RunJobConfig(ctx, req.(*RunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExecutionService_ServiceDesc is the grpc.ServiceDesc for ExecutionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExecutionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "furiko.execution.v1alpha1.ExecutionService",
+	HandlerType: (*ExecutionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetJob",
+			Handler:    _ExecutionService_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _ExecutionService_ListJobs_Handler,
+		},
+		{
+			MethodName: "KillJob",
+			Handler:    _ExecutionService_KillJob_Handler,
+		},
+		{
+			MethodName: "GetJobConfig",
+			Handler:    _ExecutionService_GetJobConfig_Handler,
+		},
+		{
+			MethodName: "ListJobConfigs",
+			Handler:    _ExecutionService_ListJobConfigs_Handler,
+		},
+		{
+			MethodName: "RunJobConfig",
+			Handler:    _ExecutionService_RunJobConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJobs",
+			Handler:       _ExecutionService_WatchJobs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchJobConfigs",
+			Handler:       _ExecutionService_WatchJobConfigs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/execution/apiserver/executionpb/execution.proto",
+}
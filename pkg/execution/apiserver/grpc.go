@@ -0,0 +1,388 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/klog/v2"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/apiserver/executionpb"
+)
+
+const (
+	grpcShutdownTimeout = 15 * time.Second
+)
+
+var (
+	errMissingName = status.Error(codes.InvalidArgument, "name must be specified")
+)
+
+// GRPCServer serves the same API as the Server as the gRPC service defined in
+// executionpb/execution.proto, on a separate listener from the HTTP server.
+// Calls are authenticated and authorized in the same way as the equivalent REST
+// requests, using the bearer token in the authorization metadata, and objects
+// are returned as JSON.
+type GRPCServer struct {
+	executionpb.UnimplementedExecutionServiceServer
+
+	server     *Server
+	grpcServer *grpc.Server
+}
+
+var _ executionpb.ExecutionServiceServer = (*GRPCServer)(nil)
+
+// NewGRPCServer returns a new GRPCServer which serves the API of the Server.
+func NewGRPCServer(server *Server) *GRPCServer {
+	g := &GRPCServer{
+		server:     server,
+		grpcServer: grpc.NewServer(grpc.MaxRecvMsgSize(maxRequestBodyBytes)),
+	}
+	executionpb.RegisterExecutionServiceServer(g.grpcServer, g)
+	return g
+}
+
+// ListenAndServe listens on the given TCP address and gracefully stops when the
+// given context is canceled.
+func (g *GRPCServer) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	klog.Infof("apiserver: grpc server listening on %v", addr)
+	return g.Serve(ctx, listener)
+}
+
+// Serve accepts connections on the listener and gracefully stops when the given
+// context is canceled. Calls that are still in progress after a timeout, such
+// as watches, are terminated.
+func (g *GRPCServer) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		klog.Infof("apiserver: shutting down grpc server on %v", listener.Addr())
+		stopped := make(chan struct{})
+		go func() {
+			g.grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(grpcShutdownTimeout):
+			g.grpcServer.Stop()
+		}
+		klog.Infof("apiserver: grpc server shut down on %v", listener.Addr())
+	}()
+
+	if err := g.grpcServer.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+func (g *GRPCServer) GetJob(ctx context.Context, req *executionpb.ObjectRequest) (*executionpb.Object, error) {
+	return g.get(ctx, resourceJobs, req, g.server.jobsAccess)
+}
+
+func (g *GRPCServer) ListJobs(ctx context.Context, req *executionpb.ListRequest) (*executionpb.ObjectList, error) {
+	return g.list(ctx, resourceJobs, req, g.server.jobsAccess)
+}
+
+func (g *GRPCServer) WatchJobs(
+	req *executionpb.WatchRequest, stream executionpb.ExecutionService_WatchJobsServer,
+) error {
+	return g.watch(stream, resourceJobs, req, g.server.jobsAccess)
+}
+
+func (g *GRPCServer) KillJob(ctx context.Context, req *executionpb.ObjectRequest) (*executionpb.Object, error) {
+	if req.GetName() == "" {
+		return nil, errMissingName
+	}
+	user, err := g.admit(ctx, http.MethodPost, resourceJobs, req.GetNamespace(), req.GetName(), "kill")
+	if err != nil {
+		return nil, err
+	}
+	rj, err := g.server.requestKill(ctx, user, req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newObject(rj)
+}
+
+func (g *GRPCServer) GetJobConfig(ctx context.Context, req *executionpb.ObjectRequest) (*executionpb.Object, error) {
+	return g.get(ctx, resourceJobConfigs, req, g.server.jobConfigsAccess)
+}
+
+func (g *GRPCServer) ListJobConfigs(
+	ctx context.Context, req *executionpb.ListRequest,
+) (*executionpb.ObjectList, error) {
+	return g.list(ctx, resourceJobConfigs, req, g.server.jobConfigsAccess)
+}
+
+func (g *GRPCServer) WatchJobConfigs(
+	req *executionpb.WatchRequest, stream executionpb.ExecutionService_WatchJobConfigsServer,
+) error {
+	return g.watch(stream, resourceJobConfigs, req, g.server.jobConfigsAccess)
+}
+
+func (g *GRPCServer) RunJobConfig(ctx context.Context, req *executionpb.RunRequest) (*executionpb.Object, error) {
+	if req.GetName() == "" {
+		return nil, errMissingName
+	}
+	user, err := g.admit(ctx, http.MethodPost, resourceJobConfigs, req.GetNamespace(), req.GetName(), "run")
+	if err != nil {
+		return nil, err
+	}
+
+	var runReq RunRequest
+	if req.GetOptionValues() != "" {
+		if err := json.Unmarshal([]byte(req.GetOptionValues()), &runReq.OptionValues); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "cannot decode option_values: "+err.Error())
+		}
+	}
+	if req.GetStartPolicy() != "" {
+		decoder := json.NewDecoder(strings.NewReader(req.GetStartPolicy()))
+		decoder.DisallowUnknownFields()
+		runReq.StartPolicy = &execution.StartPolicySpec{}
+		if err := decoder.Decode(runReq.StartPolicy); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "cannot decode start_policy: "+err.Error())
+		}
+	}
+
+	created, err := g.server.createJob(ctx, user, req.GetNamespace(), req.GetName(), runReq)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return newObject(created)
+}
+
+// admit authenticates and authorizes the call in the same way as the
+// equivalent REST request, which is identified by its HTTP method, resource and
+// action.
+func (g *GRPCServer) admit(
+	ctx context.Context, httpMethod, resource, namespace, name, action string,
+) (authenticationv1.UserInfo, error) {
+	var user authenticationv1.UserInfo
+	if namespace == "" {
+		return user, status.Error(codes.InvalidArgument, "namespace must be specified")
+	}
+
+	route, ok := g.server.route(httpMethod, resource, name, action)
+	if !ok {
+		return user, status.Errorf(codes.Internal, "no route for %v %v", httpMethod, resource)
+	}
+
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+	user, statusErr := g.server.admit(ctx, authorization, route, namespace, name)
+	if statusErr != nil {
+		return user, grpcError(statusErr)
+	}
+	return user, nil
+}
+
+func (g *GRPCServer) get(
+	ctx context.Context,
+	resource string,
+	req *executionpb.ObjectRequest,
+	access func(namespace string) resourceAccess,
+) (*executionpb.Object, error) {
+	if req.GetName() == "" {
+		return nil, errMissingName
+	}
+	if _, err := g.admit(ctx, http.MethodGet, resource, req.GetNamespace(), req.GetName(), ""); err != nil {
+		return nil, err
+	}
+	obj, statusErr := access(req.GetNamespace()).getObject(req.GetName())
+	if statusErr != nil {
+		return nil, grpcError(statusErr)
+	}
+	return newObject(obj)
+}
+
+func (g *GRPCServer) list(
+	ctx context.Context,
+	resource string,
+	req *executionpb.ListRequest,
+	access func(namespace string) resourceAccess,
+) (*executionpb.ObjectList, error) {
+	if _, err := g.admit(ctx, http.MethodGet, resource, req.GetNamespace(), "", ""); err != nil {
+		return nil, err
+	}
+	f, err := newGRPCFilter(req.GetNamespace(), "", req.GetLabelSelector())
+	if err != nil {
+		return nil, err
+	}
+	objs, statusErr := access(req.GetNamespace()).listObjects(f)
+	if statusErr != nil {
+		return nil, grpcError(statusErr)
+	}
+
+	list := &executionpb.ObjectList{Items: make([]*executionpb.Object, 0, len(objs))}
+	for _, obj := range objs {
+		item, err := newObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, nil
+}
+
+// watchStream is implemented by the server streams of all watch methods.
+type watchStream interface {
+	Send(*executionpb.WatchEvent) error
+	grpc.ServerStream
+}
+
+// watch sends the initial objects and subsequent events as WatchEvents until
+// the call is done or the stream is closed.
+func (g *GRPCServer) watch(
+	stream watchStream,
+	resource string,
+	req *executionpb.WatchRequest,
+	access func(namespace string) resourceAccess,
+) error {
+	ctx := stream.Context()
+	if _, err := g.admit(ctx, http.MethodGet, resource, req.GetNamespace(), req.GetName(), ""); err != nil {
+		return err
+	}
+	f, err := newGRPCFilter(req.GetNamespace(), req.GetName(), req.GetLabelSelector())
+	if err != nil {
+		return err
+	}
+	a := access(req.GetNamespace())
+
+	// Start streaming before listing, so that no events are missed in between.
+	st := a.broadcaster.subscribe()
+	defer a.broadcaster.unsubscribe(st)
+
+	objs, statusErr := a.listObjects(f)
+	if statusErr != nil {
+		return grpcError(statusErr)
+	}
+	for _, obj := range objs {
+		if err := sendEvent(stream, watch.Added, obj); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-st.events:
+			if !ok {
+				klog.V(4).InfoS("apiserver: stream was closed")
+				return status.Error(codes.Unavailable, "stream was closed")
+			}
+			eventType, obj, ok := f.apply(e)
+			if !ok {
+				continue
+			}
+			if err := sendEvent(stream, eventType, obj); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newGRPCFilter(namespace, name, labelSelector string) (filter, error) {
+	f := filter{namespace: namespace, name: name}
+	if labelSelector != "" {
+		selector, err := labels.Parse(labelSelector)
+		if err != nil {
+			return f, status.Error(codes.InvalidArgument, "invalid label selector: "+err.Error())
+		}
+		f.selector = selector
+	}
+	return f, nil
+}
+
+func newObject(obj interface{}) (*executionpb.Object, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &executionpb.Object{Json: data}, nil
+}
+
+func sendEvent(stream watchStream, eventType watch.EventType, obj interface{}) error {
+	object, err := newObject(obj)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&executionpb.WatchEvent{Type: string(eventType), Object: object})
+}
+
+// grpcError returns the gRPC status error for an error returned by a method,
+// preserving the status of errors returned by the kube-apiserver.
+func grpcError(err error) error {
+	var statusErr *apierrors.StatusError
+	if errors.As(err, &statusErr) {
+		s := statusErr.Status()
+		return status.Error(grpcCodeForStatus(s), s.Message)
+	}
+	klog.ErrorS(err, "apiserver: cannot handle grpc call")
+	return status.Error(codes.Internal, err.Error())
+}
+
+func grpcCodeForStatus(s metav1.Status) codes.Code {
+	switch s.Code {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		if s.Reason == metav1.StatusReasonAlreadyExists {
+			return codes.AlreadyExists
+		}
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusInternalServerError:
+		return codes.Internal
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	return codes.Unknown
+}
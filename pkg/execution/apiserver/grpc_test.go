@@ -0,0 +1,300 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/apiserver"
+	"github.com/furiko-io/furiko/pkg/execution/apiserver/executionpb"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+// newGRPCClient serves the GRPCServer on an in-memory listener and returns a
+// client connected to it.
+func newGRPCClient(ctx context.Context, t *testing.T, server *apiserver.Server) executionpb.ExecutionServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		if err := apiserver.NewGRPCServer(server).Serve(ctx, listener); err != nil {
+			t.Errorf("cannot serve grpc: %v", err)
+		}
+	}()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("cannot dial grpc server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return executionpb.NewExecutionServiceClient(conn)
+}
+
+// withToken returns a context that passes the token in the authorization
+// metadata.
+func withToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// decodeObjectName returns the name of the object in an Object message.
+func decodeObjectName(t *testing.T, obj *executionpb.Object) string {
+	if obj == nil {
+		t.Fatalf("expected object in message")
+	}
+	var meta metav1.PartialObjectMetadata
+	assert.NoError(t, json.Unmarshal(obj.GetJson(), &meta))
+	return meta.Name
+}
+
+func TestGRPCServer(t *testing.T) {
+	type call func(ctx context.Context, client executionpb.ExecutionServiceClient) (interface{}, error)
+	getJob := func(req *executionpb.ObjectRequest) call {
+		return func(ctx context.Context, client executionpb.ExecutionServiceClient) (interface{}, error) {
+			return client.GetJob(ctx, req)
+		}
+	}
+	listJobs := func(req *executionpb.ListRequest) call {
+		return func(ctx context.Context, client executionpb.ExecutionServiceClient) (interface{}, error) {
+			return client.ListJobs(ctx, req)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		call       call
+		token      string
+		wantCode   codes.Code
+		wantObject string
+		wantList   []string
+	}{
+		{
+			name:       "get job",
+			call:       getJob(&executionpb.ObjectRequest{Namespace: testNamespace, Name: "job1"}),
+			token:      validToken,
+			wantObject: "job1",
+		},
+		{
+			name:     "get job not found",
+			call:     getJob(&executionpb.ObjectRequest{Namespace: testNamespace, Name: "job3"}),
+			token:    validToken,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "get job without name",
+			call:     getJob(&executionpb.ObjectRequest{Namespace: testNamespace}),
+			token:    validToken,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing namespace",
+			call:     listJobs(&executionpb.ListRequest{}),
+			token:    validToken,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing token",
+			call:     listJobs(&executionpb.ListRequest{Namespace: testNamespace}),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			call:     listJobs(&executionpb.ListRequest{Namespace: testNamespace}),
+			token:    "invalid",
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "list jobs",
+			call:     listJobs(&executionpb.ListRequest{Namespace: testNamespace}),
+			token:    validToken,
+			wantList: []string{"job2", "job1"},
+		},
+		{
+			name:     "list jobs with label selector",
+			call:     listJobs(&executionpb.ListRequest{Namespace: testNamespace, LabelSelector: "team=infra"}),
+			token:    validToken,
+			wantList: []string{"job1"},
+		},
+		{
+			name:     "invalid label selector",
+			call:     listJobs(&executionpb.ListRequest{Namespace: testNamespace, LabelSelector: "team in"}),
+			token:    validToken,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "list jobconfigs forbidden",
+			call: func(ctx context.Context, client executionpb.ExecutionServiceClient) (interface{}, error) {
+				return client.ListJobConfigs(ctx, &executionpb.ListRequest{Namespace: testNamespace})
+			},
+			token:    validToken,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "list jobconfigs",
+			call: func(ctx context.Context, client executionpb.ExecutionServiceClient) (interface{}, error) {
+				return client.ListJobConfigs(ctx, &executionpb.ListRequest{Namespace: testNamespace})
+			},
+			token:    adminToken,
+			wantList: []string{"jobconfig1"},
+		},
+		{
+			name: "kill job forbidden",
+			call: func(ctx context.Context, client executionpb.ExecutionServiceClient) (interface{}, error) {
+				return client.KillJob(ctx, &executionpb.ObjectRequest{Namespace: testNamespace, Name: "job1"})
+			},
+			token:    validToken,
+			wantCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			_, server := setUp(ctx, t, nil)
+			client := newGRPCClient(ctx, t, server)
+
+			resp, err := tt.call(withToken(ctx, tt.token), client)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantObject != "" {
+				assert.Equal(t, tt.wantObject, decodeObjectName(t, resp.(*executionpb.Object)))
+			}
+			if tt.wantList != nil {
+				names := make([]string, 0, len(tt.wantList))
+				for _, item := range resp.(*executionpb.ObjectList).GetItems() {
+					names = append(names, decodeObjectName(t, item))
+				}
+				assert.Equal(t, tt.wantList, names)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_KillJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, nil)
+	client := newGRPCClient(ctx, t, server)
+
+	resp, err := client.KillJob(withToken(ctx, adminToken),
+		&executionpb.ObjectRequest{Namespace: testNamespace, Name: "job2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "job2", decodeObjectName(t, resp))
+
+	rj, err := ctrlContext.MockClientsets().FurikoMock().ExecutionV1alpha1().Jobs(testNamespace).
+		Get(ctx, "job2", metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, ok := rj.Annotations[jobutil.AnnotationKeyRequestKill]
+	assert.True(t, ok)
+}
+
+func TestGRPCServer_RunJobConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, server := setUp(ctx, t, nil)
+	client := newGRPCClient(ctx, t, server)
+
+	resp, err := client.RunJobConfig(withToken(ctx, adminToken), &executionpb.RunRequest{
+		Namespace:    testNamespace,
+		Name:         "jobconfig1",
+		OptionValues: `{"env": "prod"}`,
+		StartPolicy:  `{"concurrencyPolicy": "Enqueue"}`,
+	})
+	assert.NoError(t, err)
+
+	var created v1alpha1.Job
+	assert.NoError(t, json.Unmarshal(resp.GetJson(), &created))
+	assert.Equal(t, "jobconfig1-", created.GenerateName)
+	assert.Equal(t, "jobconfig1", created.Spec.ConfigName)
+	assert.JSONEq(t, `{"env": "prod"}`, created.Spec.OptionValues)
+	assert.Equal(t, &v1alpha1.StartPolicySpec{ConcurrencyPolicy: v1alpha1.ConcurrencyPolicyEnqueue},
+		created.Spec.StartPolicy)
+
+	_, err = client.RunJobConfig(withToken(ctx, adminToken), &executionpb.RunRequest{
+		Namespace:   testNamespace,
+		Name:        "jobconfig1",
+		StartPolicy: `{"unknown": true}`,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCServer_WatchJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, nil)
+	client := newGRPCClient(ctx, t, server)
+
+	stream, err := client.WatchJobs(withToken(ctx, validToken), &executionpb.WatchRequest{Namespace: testNamespace})
+	assert.NoError(t, err)
+
+	events := make(chan string, 10)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			events <- event.GetType() + " " + decodeObjectName(t, event.GetObject())
+		}
+	}()
+
+	next := func() string {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+			return ""
+		}
+	}
+
+	assert.Equal(t, "ADDED job2", next())
+	assert.Equal(t, "ADDED job1", next())
+
+	jobClient := ctrlContext.MockClientsets().FurikoMock().ExecutionV1alpha1()
+	_, err = jobClient.Jobs("other").Create(ctx, newJob("job4", "other", "2022-04-01T07:00:00Z", nil),
+		metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = jobClient.Jobs(testNamespace).Create(ctx, newJob("job4", testNamespace, "2022-04-01T07:00:00Z", nil),
+		metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ADDED job4", next())
+
+	assert.NoError(t, jobClient.Jobs(testNamespace).Delete(ctx, "job1", metav1.DeleteOptions{}))
+	assert.Equal(t, "DELETED job1", next())
+}
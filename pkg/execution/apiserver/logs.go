@@ -0,0 +1,176 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// serveLogs writes the logs of a Job's task as plain text. The task can be
+// specified with the task query parameter, and defaults to the most recently
+// created task. Logs are read from the task's Pod if it still exists, and are
+// otherwise fetched from the log archive. Passing archived=true always fetches
+// the logs from the log archive.
+func (s *Server) serveLogs(w http.ResponseWriter, r *http.Request, _ authenticationv1.UserInfo, namespace, name string) {
+	ctx := r.Context()
+	values := r.URL.Query()
+
+	var archived bool
+	if archivedParam := values.Get("archived"); archivedParam != "" {
+		parsed, err := strconv.ParseBool(archivedParam)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest("invalid value for archived: "+archivedParam))
+			return
+		}
+		archived = parsed
+	}
+
+	rj, err := s.ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs().Lister().Jobs(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, apierrors.NewNotFound(
+				schema.GroupResource{Group: executiongroup.GroupName, Resource: resourceJobs}, name))
+			return
+		}
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+
+	task, ok := findTask(rj, values.Get("task"))
+	if !ok {
+		writeError(w, apierrors.NewNotFound(
+			schema.GroupResource{Group: executiongroup.GroupName, Resource: "tasks"}, values.Get("task")))
+		return
+	}
+
+	if !archived {
+		pods := s.ctrlContext.Clientsets().Kubernetes().CoreV1().Pods(namespace)
+		_, err := pods.Get(ctx, task.Name, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			stream, err := pods.GetLogs(task.Name, &corev1.PodLogOptions{Container: values.Get("container")}).Stream(ctx)
+			if err != nil {
+				writeClientError(w, err)
+				return
+			}
+			defer stream.Close()
+			writeLogs(w, stream)
+			return
+		case !apierrors.IsNotFound(err):
+			writeClientError(w, err)
+			return
+		}
+	}
+
+	s.serveArchivedLogs(w, r, task)
+}
+
+// serveArchivedLogs writes the logs of the task from the log archive.
+func (s *Server) serveArchivedLogs(w http.ResponseWriter, r *http.Request, task execution.TaskRef) {
+	archive := task.Status.LogArchive
+	if archive == nil {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{Resource: "pods/log"}, task.Name))
+		return
+	}
+	if s.logArchiveURL == "" {
+		writeError(w, apierrors.NewServiceUnavailable("log archive is not configured"))
+		return
+	}
+
+	archiveURL, err := s.resolveLogArchiveKey(archive.Key)
+	if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, archiveURL, nil)
+	if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		klog.ErrorS(err, "apiserver: cannot fetch archived logs", "task", task.Name)
+		writeError(w, apierrors.NewInternalError(errors.Wrapf(err, "cannot fetch archived logs")))
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{Resource: "pods/log"}, task.Name))
+		return
+	case resp.StatusCode != http.StatusOK:
+		writeError(w, apierrors.NewInternalError(fmt.Errorf("cannot fetch archived logs: %v", resp.Status)))
+		return
+	}
+	writeLogs(w, resp.Body)
+}
+
+// resolveLogArchiveKey returns the URL of the archived logs with the given key.
+// Keys cannot refer to objects outside of the log archive URL.
+func (s *Server) resolveLogArchiveKey(key string) (string, error) {
+	segments := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("invalid log archive key: %v", key)
+		}
+		segments[i] = url.PathEscape(segment)
+	}
+	return s.logArchiveURL + "/" + strings.Join(segments, "/"), nil
+}
+
+// findTask returns the task of the Job with the given name, or the most
+// recently created task if name is empty.
+func findTask(rj *execution.Job, name string) (execution.TaskRef, bool) {
+	var found *execution.TaskRef
+	for i, task := range rj.Status.Tasks {
+		if name != "" && task.Name == name {
+			return task, true
+		}
+		if name == "" && (found == nil || !task.CreationTimestamp.Before(&found.CreationTimestamp)) {
+			found = &rj.Status.Tasks[i]
+		}
+	}
+	if found == nil {
+		return execution.TaskRef{}, false
+	}
+	return *found, true
+}
+
+func writeLogs(w http.ResponseWriter, logs io.Reader) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, logs); err != nil {
+		klog.ErrorS(err, "apiserver: cannot write logs")
+	}
+}
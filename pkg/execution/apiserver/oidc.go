@@ -0,0 +1,443 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	defaultOIDCUsernameClaim = "sub"
+	defaultOIDCGroupsClaim   = "groups"
+	defaultOIDCPrefix        = "oidc:"
+
+	// Minimum interval between fetches of the provider's keys, which are fetched
+	// again when a token is signed with an unknown key.
+	oidcKeysRefreshInterval = time.Minute
+	oidcRequestTimeout      = 10 * time.Second
+)
+
+// oidcVerifier verifies ID tokens issued by an OpenID Connect provider. Only
+// the RS256 and ES256 signing algorithms are supported.
+type oidcVerifier struct {
+	issuerURL      string
+	clientID       string
+	usernameClaim  string
+	usernamePrefix string
+	groupsClaim    string
+	groupsPrefix   string
+	client         *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastFetched time.Time
+	fetchGroup  singleflight.Group
+}
+
+func newOIDCVerifier(cfg *configv1alpha1.OIDCSpec) (*oidcVerifier, error) {
+	if cfg.IssuerURL == "" {
+		return nil, errors.New("issuerURL must be specified")
+	}
+	if cfg.ClientID == "" {
+		return nil, errors.New("clientID must be specified")
+	}
+	v := &oidcVerifier{
+		issuerURL:      cfg.IssuerURL,
+		clientID:       cfg.ClientID,
+		usernameClaim:  cfg.UsernameClaim,
+		usernamePrefix: defaultOIDCPrefix,
+		groupsClaim:    cfg.GroupsClaim,
+		groupsPrefix:   defaultOIDCPrefix,
+		client:         &http.Client{Timeout: oidcRequestTimeout},
+	}
+	if v.usernameClaim == "" {
+		v.usernameClaim = defaultOIDCUsernameClaim
+	}
+	if v.groupsClaim == "" {
+		v.groupsClaim = defaultOIDCGroupsClaim
+	}
+	if cfg.UsernamePrefix != nil {
+		v.usernamePrefix = *cfg.UsernamePrefix
+	}
+	if cfg.GroupsPrefix != nil {
+		v.groupsPrefix = *cfg.GroupsPrefix
+	}
+	return v, nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// isIssuedBy returns true if the token is a JWT issued by the provider. The
+// token is not verified.
+func (v *oidcVerifier) isIssuedBy(token string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return false
+	}
+	return claims.Issuer == v.issuerURL
+}
+
+// verify verifies the ID token and returns the user that it was issued for.
+func (v *oidcVerifier) verify(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return authenticationv1.UserInfo{}, errors.New("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return authenticationv1.UserInfo{}, errors.Wrapf(err, "cannot decode header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return authenticationv1.UserInfo{}, errors.Wrapf(err, "cannot decode signature")
+	}
+
+	key, err := v.getKey(ctx, header.Kid)
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return authenticationv1.UserInfo{}, errors.Wrapf(err, "cannot decode claims")
+	}
+	if err := v.verifyClaims(claims); err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	return v.getUser(claims)
+}
+
+func (v *oidcVerifier) verifyClaims(claims map[string]interface{}) error {
+	if iss, _ := claims["iss"].(string); iss != v.issuerURL {
+		return fmt.Errorf("invalid issuer: %v", iss)
+	}
+
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+	}
+	var validAudience bool
+	for _, aud := range audiences {
+		if aud == v.clientID {
+			validAudience = true
+		}
+	}
+	if !validAudience {
+		return fmt.Errorf("token was not issued for client %v", v.clientID)
+	}
+
+	now := ktime.Now().Time
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("token does not expire")
+	}
+	if !now.Before(time.Unix(int64(exp), 0)) {
+		return errors.New("token is expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Before(time.Unix(int64(nbf), 0)) {
+		return errors.New("token is not yet valid")
+	}
+	return nil
+}
+
+func (v *oidcVerifier) getUser(claims map[string]interface{}) (authenticationv1.UserInfo, error) {
+	username, ok := claims[v.usernameClaim].(string)
+	if !ok || username == "" {
+		return authenticationv1.UserInfo{}, fmt.Errorf("token does not contain claim %v", v.usernameClaim)
+	}
+	if v.usernameClaim == "email" {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return authenticationv1.UserInfo{}, errors.New("email is not verified")
+		}
+	}
+	user := authenticationv1.UserInfo{
+		Username: v.usernamePrefix + username,
+	}
+
+	switch groups := claims[v.groupsClaim].(type) {
+	case string:
+		user.Groups = append(user.Groups, v.groupsPrefix+groups)
+	case []interface{}:
+		for _, group := range groups {
+			if s, ok := group.(string); ok {
+				user.Groups = append(user.Groups, v.groupsPrefix+s)
+			}
+		}
+	}
+	return user, nil
+}
+
+// getKey returns the provider's key with the given ID, fetching the provider's
+// keys if the key is unknown.
+func (v *oidcVerifier) getKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.lookupKeyLocked(kid)
+	fresh := v.isFreshLocked()
+	v.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+	if fresh {
+		return nil, fmt.Errorf("unknown signing key: %v", kid)
+	}
+
+	// Concurrent requests share a single fetch, which continues even if the
+	// request that started it is canceled.
+	select {
+	case res := <-v.fetchGroup.DoChan("", func() (interface{}, error) {
+		return nil, v.refreshKeys()
+	}):
+		if res.Err != nil {
+			return nil, errors.Wrapf(res.Err, "cannot fetch signing keys")
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if key, ok := v.lookupKeyLocked(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key: %v", kid)
+}
+
+// refreshKeys fetches the provider's keys, unless they were fetched within the
+// refresh interval.
+func (v *oidcVerifier) refreshKeys() error {
+	v.mu.RLock()
+	fresh := v.isFreshLocked()
+	v.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcRequestTimeout)
+	defer cancel()
+	now := ktime.Now().Time
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.keys = keys
+	v.lastFetched = now
+	return nil
+}
+
+func (v *oidcVerifier) isFreshLocked() bool {
+	return v.keys != nil && ktime.Now().Time.Before(v.lastFetched.Add(oidcKeysRefreshInterval))
+}
+
+func (v *oidcVerifier) lookupKeyLocked(kid string) (crypto.PublicKey, bool) {
+	// Tokens without a key ID can only be verified if the provider has one key.
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, true
+		}
+	}
+	key, ok := v.keys[kid]
+	return key, ok
+}
+
+func (v *oidcVerifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	discoveryURL := strings.TrimSuffix(v.issuerURL, "/") + "/.well-known/openid-configuration"
+	if err := v.getJSON(ctx, discoveryURL, &discovery); err != nil {
+		return nil, errors.Wrapf(err, "cannot get discovery document")
+	}
+	if discovery.Issuer != v.issuerURL {
+		return nil, fmt.Errorf("discovery document has issuer %v, expected %v", discovery.Issuer, v.issuerURL)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJSON(ctx, discovery.JWKSURI, &jwks); err != nil {
+		return nil, errors.Wrapf(err, "cannot get keys")
+	}
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip unsupported keys, since other keys can still be used.
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (v *oidcVerifier) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// jsonWebKey is a public key in a JSON Web Key Set.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+
+	// RSA keys.
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC keys.
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve: %v", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, errors.New("invalid point")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type: %v", k.Kty)
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, signature []byte) error {
+	digest := sha256.Sum256([]byte(signed))
+	switch alg {
+	case "RS256":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("signing key is not an RSA key")
+		}
+		if err := rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case "ES256":
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("signing key is not an EC key")
+		}
+		if len(signature) != 64 {
+			return errors.New("invalid signature")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported signing algorithm: %v", alg)
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,299 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	ktesting "k8s.io/client-go/testing"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/apiserver"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	testClientID = "dashboard"
+	testKeyID    = "key1"
+)
+
+// oidcProvider is a fake OpenID Connect provider which serves its discovery
+// document and keys, and signs ID tokens.
+type oidcProvider struct {
+	*httptest.Server
+	key        *rsa.PrivateKey
+	keyFetches int32
+}
+
+func newOIDCProvider(t *testing.T) *oidcProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+	p := &oidcProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   p.URL,
+			"jwks_uri": p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&p.keyFetches, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kid": testKeyID,
+					"kty": "RSA",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *oidcProvider) sign(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("cannot marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": "RS256", "kid": testKeyID}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("cannot sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestServer_OIDC(t *testing.T) {
+	now := testutils.Mkmtime("2022-04-01T05:00:00Z")
+	ktime.Clock = clock.NewFakeClock(now.Time)
+
+	provider := newOIDCProvider(t)
+	defer provider.Close()
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+
+	newClaims := func(mutate func(claims map[string]interface{})) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":    provider.URL,
+			"aud":    testClientID,
+			"sub":    "alice",
+			"groups": []string{"eng"},
+			"exp":    now.Add(time.Hour).Unix(),
+		}
+		if mutate != nil {
+			mutate(claims)
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "list jobs",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobs",
+			token:    provider.sign(t, provider.key, newClaims(nil)),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "audience in list",
+			method: http.MethodGet,
+			path:   "/api/v1/namespaces/test/jobs",
+			token: provider.sign(t, provider.key, newClaims(func(claims map[string]interface{}) {
+				claims["aud"] = []string{"other", testClientID}
+			})),
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "forbidden by subjectaccessreview",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobconfigs",
+			token:    provider.sign(t, provider.key, newClaims(nil)),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "cannot make write requests",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/jobs/job1/kill",
+			token:    provider.sign(t, provider.key, newClaims(nil)),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "invalid signature",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobs",
+			token:    provider.sign(t, otherKey, newClaims(nil)),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "invalid audience",
+			method: http.MethodGet,
+			path:   "/api/v1/namespaces/test/jobs",
+			token: provider.sign(t, provider.key, newClaims(func(claims map[string]interface{}) {
+				claims["aud"] = "other"
+			})),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "expired token",
+			method: http.MethodGet,
+			path:   "/api/v1/namespaces/test/jobs",
+			token: provider.sign(t, provider.key, newClaims(func(claims map[string]interface{}) {
+				claims["exp"] = now.Add(-time.Minute).Unix()
+			})),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "missing username claim",
+			method: http.MethodGet,
+			path:   "/api/v1/namespaces/test/jobs",
+			token: provider.sign(t, provider.key, newClaims(func(claims map[string]interface{}) {
+				delete(claims, "sub")
+			})),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "kubernetes token is still accepted",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/jobs/job1/kill",
+			token:    adminToken,
+			wantCode: http.StatusOK,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, &configv1alpha1.APIServerSpec{
+		OIDC: &configv1alpha1.OIDCSpec{
+			IssuerURL: provider.URL,
+			ClientID:  testClientID,
+		},
+	})
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, newRequest(tt.method, tt.path, tt.token, ""))
+			if w.Code != tt.wantCode {
+				t.Fatalf("ServeHTTP() code = %v, want %v, body = %v", w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+
+	// SubjectAccessReviews should use the prefixed username and groups.
+	var found bool
+	for _, action := range ctrlContext.MockClientsets().KubernetesMock().Actions() {
+		createAction, ok := action.(ktesting.CreateAction)
+		if !ok {
+			continue
+		}
+		review, ok := createAction.GetObject().(*authorizationv1.SubjectAccessReview)
+		if !ok || review.Spec.User != "oidc:alice" {
+			continue
+		}
+		found = true
+		assert.Equal(t, []string{"oidc:eng"}, review.Spec.Groups)
+	}
+	assert.True(t, found, "expected subjectaccessreview for oidc user")
+}
+
+func TestServer_OIDCConcurrentRequests(t *testing.T) {
+	now := testutils.Mkmtime("2022-04-01T05:00:00Z")
+	ktime.Clock = clock.NewFakeClock(now.Time)
+
+	provider := newOIDCProvider(t)
+	defer provider.Close()
+	token := provider.sign(t, provider.key, map[string]interface{}{
+		"iss": provider.URL,
+		"aud": testClientID,
+		"sub": "alice",
+		"exp": now.Add(time.Hour).Unix(),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, server := setUp(ctx, t, &configv1alpha1.APIServerSpec{
+		OIDC: &configv1alpha1.OIDCSpec{
+			IssuerURL: provider.URL,
+			ClientID:  testClientID,
+		},
+	})
+
+	var wg sync.WaitGroup
+	codes := make([]int, 10)
+	for i := range codes {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, newRequest(http.MethodGet, "/api/v1/namespaces/test/jobs", token, ""))
+			codes[i] = w.Code
+		}()
+	}
+	wg.Wait()
+
+	for _, code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	// Keys should only be fetched once for all requests.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&provider.keyFetches))
+}
+
+func TestServer_OIDCInvalidConfig(t *testing.T) {
+	_, err := apiserver.NewServer(mock.NewContext(), &configv1alpha1.APIServerSpec{
+		OIDC: &configv1alpha1.OIDCSpec{IssuerURL: "https://issuer.example.com"},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,678 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+)
+
+const (
+	defaultPathPrefix   = "/api"
+	defaultAuthCacheTTL = 10 * time.Second
+	streamPingInterval  = 30 * time.Second
+	maxRequestBodyBytes = 1 << 20
+
+	resourceJobs       = "jobs"
+	resourceJobConfigs = "jobconfigs"
+	resourcePods       = "pods"
+)
+
+// Server serves an authenticated REST API for Jobs and JobConfigs, intended to
+// be used as the backend of web dashboards and chatbots. Requests must contain a
+// bearer token, which is verified using a TokenReview or as an OIDC ID token,
+// and each request is authorized using a SubjectAccessReview for the user.
+// Users authenticated with OIDC can only make read-only requests.
+//
+// The following endpoints are served under the configured path prefix:
+//
+//	GET  /v1/namespaces/{namespace}/jobs: Lists or streams Jobs.
+//	GET  /v1/namespaces/{namespace}/jobs/{name}: Gets or streams a Job.
+//	POST /v1/namespaces/{namespace}/jobs/{name}/kill: Kills a Job.
+//	GET  /v1/namespaces/{namespace}/jobs/{name}/logs: Gets the logs of a Job's task.
+//	GET  /v1/namespaces/{namespace}/jobconfigs: Lists or streams JobConfigs.
+//	GET  /v1/namespaces/{namespace}/jobconfigs/{name}: Gets or streams a JobConfig.
+//	POST /v1/namespaces/{namespace}/jobconfigs/{name}/run: Runs a JobConfig.
+//
+// JobConfigs can also be triggered by external systems without Kubernetes
+// credentials, using a token that is specific to the JobConfig (see
+// serveTrigger).
+//
+// Lists are ordered by creation timestamp from newest to oldest, and can be
+// filtered with the labelSelector query parameter. Passing watch=true streams
+// the objects as server-sent events instead, where each event is named after
+// the watch event type (i.e. ADDED, MODIFIED or DELETED) and contains the
+// object as JSON, starting with ADDED events for all existing objects.
+//
+// The same API can also be served over gRPC on a separate listener using a
+// GRPCServer.
+type Server struct {
+	ctrlContext   controllercontext.Context
+	pathPrefix    string
+	logArchiveURL string
+	auth          *auth
+	jobs          *broadcaster
+	jobConfigs    *broadcaster
+	hasSynced     []cache.InformerSynced
+}
+
+// RunRequest is the request body to run a JobConfig.
+type RunRequest struct {
+	// OptionValues specifies the values for the JobConfig's options.
+	// +optional
+	OptionValues map[string]interface{} `json:"optionValues,omitempty"`
+
+	// StartPolicy specifies how the Job should be started.
+	// +optional
+	StartPolicy *execution.StartPolicySpec `json:"startPolicy,omitempty"`
+}
+
+// NewServer returns a new Server. Must be called before the informers are
+// started, so that no events are missed by streams.
+func NewServer(ctrlContext controllercontext.Context, cfg *configv1alpha1.APIServerSpec) (*Server, error) {
+	if cfg == nil {
+		cfg = &configv1alpha1.APIServerSpec{}
+	}
+
+	pathPrefix := strings.TrimSuffix(cfg.PathPrefix, "/")
+	if pathPrefix == "" {
+		pathPrefix = defaultPathPrefix
+	}
+	ttl := defaultAuthCacheTTL
+	if cfg.AuthCacheTTLSeconds != nil {
+		if *cfg.AuthCacheTTLSeconds < 0 {
+			return nil, fmt.Errorf("invalid authCacheTTLSeconds: %v", *cfg.AuthCacheTTLSeconds)
+		}
+		ttl = time.Duration(*cfg.AuthCacheTTLSeconds) * time.Second
+	}
+
+	var oidc *oidcVerifier
+	if cfg.OIDC != nil {
+		verifier, err := newOIDCVerifier(cfg.OIDC)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid oidc config")
+		}
+		oidc = verifier
+	}
+
+	informers := ctrlContext.Informers().Furiko().Execution().V1alpha1()
+	s := &Server{
+		ctrlContext:   ctrlContext,
+		pathPrefix:    pathPrefix,
+		logArchiveURL: strings.TrimSuffix(cfg.LogArchiveURL, "/"),
+		auth:          newAuth(ctrlContext.Clientsets().Kubernetes(), cfg.Audiences, ttl, oidc),
+		jobs:          newBroadcaster(),
+		jobConfigs:    newBroadcaster(),
+		hasSynced: []cache.InformerSynced{
+			informers.Jobs().Informer().HasSynced,
+			informers.JobConfigs().Informer().HasSynced,
+		},
+	}
+	informers.Jobs().Informer().AddEventHandler(s.jobs)
+	informers.JobConfigs().Informer().AddEventHandler(s.jobConfigs)
+
+	return s, nil
+}
+
+// Pattern returns the pattern that the Server should be registered with.
+func (s *Server) Pattern() string {
+	return s.pathPrefix + "/"
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Expect paths in the form /v1/namespaces/{namespace}/{resource}[/{name}[/{action}]].
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, s.pathPrefix), "/"), "/")
+	if len(parts) < 4 || len(parts) > 6 || parts[0] != "v1" || parts[1] != "namespaces" || parts[2] == "" {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, r.URL.Path))
+		return
+	}
+	namespace, resource := parts[2], parts[3]
+	var name, action string
+	if len(parts) > 4 {
+		name = parts[4]
+	}
+	if len(parts) > 5 {
+		action = parts[5]
+	}
+
+	// Triggers are authenticated with per-JobConfig tokens instead.
+	if resource == resourceTriggers {
+		s.serveTrigger(w, r, namespace, name, action)
+		return
+	}
+
+	route, ok := s.route(r.Method, resource, name, action)
+	if !ok {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, r.URL.Path))
+		return
+	}
+
+	user, statusErr := s.admit(r.Context(), r.Header.Get("Authorization"), route, namespace, name)
+	if statusErr != nil {
+		writeError(w, statusErr)
+		return
+	}
+
+	route.handler(w, r, user, namespace, name)
+}
+
+// admit authenticates the request from its Authorization header and checks that
+// the user is authorized to access the route, returning the authenticated user.
+func (s *Server) admit(
+	ctx context.Context, authorization string, route route, namespace, name string,
+) (authenticationv1.UserInfo, *apierrors.StatusError) {
+	user, readOnly, err := s.auth.authenticate(ctx, authorization)
+	if err != nil {
+		if errors.Is(err, errMissingToken) || errors.Is(err, errInvalidToken) {
+			return user, apierrors.NewUnauthorized(err.Error())
+		}
+		klog.ErrorS(err, "apiserver: cannot authenticate request")
+		return user, apierrors.NewInternalError(err)
+	}
+
+	gr := schema.GroupResource{Group: route.group, Resource: route.resource}
+	if readOnly && route.verb != "get" && route.verb != "list" {
+		return user, apierrors.NewForbidden(gr, name, fmt.Errorf("user %q can only make read-only requests",
+			user.Username))
+	}
+
+	attrs := attributes{
+		verb:        route.verb,
+		group:       route.group,
+		resource:    route.resource,
+		subresource: route.subresource,
+		namespace:   namespace,
+		name:        route.authorizedName(name),
+	}
+	allowed, reason, err := s.auth.authorize(ctx, user, attrs)
+	if err != nil {
+		klog.ErrorS(err, "apiserver: cannot authorize request")
+		return user, apierrors.NewInternalError(err)
+	}
+	if !allowed {
+		return user, apierrors.NewForbidden(gr, attrs.name, fmt.Errorf("user %q cannot %v %v in namespace %q: %v",
+			user.Username, route.verb, route.resource, namespace, reason))
+	}
+
+	for _, hasSynced := range s.hasSynced {
+		if !hasSynced() {
+			return user, apierrors.NewServiceUnavailable("informer cache is not yet synced")
+		}
+	}
+
+	return user, nil
+}
+
+type routeHandler func(w http.ResponseWriter, r *http.Request, user authenticationv1.UserInfo, namespace, name string)
+
+type route struct {
+	verb        string
+	group       string
+	resource    string
+	subresource string
+	handler     routeHandler
+
+	// Whether to authorize the request without a resource name, such as when
+	// creating a Job from a JobConfig, or when the name of the authorized resource
+	// is not known from the path.
+	unnamed bool
+}
+
+func (r route) authorizedName(name string) string {
+	if r.unnamed {
+		return ""
+	}
+	return name
+}
+
+func (s *Server) route(method, resource, name, action string) (route, bool) {
+	switch {
+	case method == http.MethodGet && resource == resourceJobs && action == "":
+		verb := "list"
+		if name != "" {
+			verb = "get"
+		}
+		return route{
+			verb:     verb,
+			group:    executiongroup.GroupName,
+			resource: resourceJobs,
+			handler:  s.serveJobs,
+		}, true
+	case method == http.MethodPost && resource == resourceJobs && name != "" && action == "kill":
+		return route{
+			verb:     "patch",
+			group:    executiongroup.GroupName,
+			resource: resourceJobs,
+			handler:  s.killJob,
+		}, true
+	case method == http.MethodGet && resource == resourceJobs && name != "" && action == "logs":
+		// Task names are not known from the path, so require access to all Pod logs
+		// in the namespace.
+		return route{
+			verb:        "get",
+			resource:    resourcePods,
+			subresource: "log",
+			handler:     s.serveLogs,
+			unnamed:     true,
+		}, true
+	case method == http.MethodGet && resource == resourceJobConfigs && action == "":
+		verb := "list"
+		if name != "" {
+			verb = "get"
+		}
+		return route{
+			verb:     verb,
+			group:    executiongroup.GroupName,
+			resource: resourceJobConfigs,
+			handler:  s.serveJobConfigs,
+		}, true
+	case method == http.MethodPost && resource == resourceJobConfigs && name != "" && action == "run":
+		return route{
+			verb:     "create",
+			group:    executiongroup.GroupName,
+			resource: resourceJobs,
+			handler:  s.runJobConfig,
+			unnamed:  true,
+		}, true
+	}
+	return route{}, false
+}
+
+// resourceAccess reads a resource in a namespace from the informer cache.
+type resourceAccess struct {
+	resource    string
+	broadcaster *broadcaster
+	get         func(name string) (metav1.Object, error)
+	list        func(selector labels.Selector) ([]metav1.Object, error)
+}
+
+func (s *Server) jobsAccess(namespace string) resourceAccess {
+	lister := s.ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs().Lister().Jobs(namespace)
+	return resourceAccess{
+		resource:    resourceJobs,
+		broadcaster: s.jobs,
+		get: func(name string) (metav1.Object, error) {
+			return lister.Get(name)
+		},
+		list: func(selector labels.Selector) ([]metav1.Object, error) {
+			rjs, err := lister.List(selector)
+			if err != nil {
+				return nil, err
+			}
+			objs := make([]metav1.Object, 0, len(rjs))
+			for _, rj := range rjs {
+				objs = append(objs, rj)
+			}
+			return objs, nil
+		},
+	}
+}
+
+func (s *Server) jobConfigsAccess(namespace string) resourceAccess {
+	lister := s.ctrlContext.Informers().Furiko().Execution().V1alpha1().JobConfigs().Lister().JobConfigs(namespace)
+	return resourceAccess{
+		resource:    resourceJobConfigs,
+		broadcaster: s.jobConfigs,
+		get: func(name string) (metav1.Object, error) {
+			return lister.Get(name)
+		},
+		list: func(selector labels.Selector) ([]metav1.Object, error) {
+			rjcs, err := lister.List(selector)
+			if err != nil {
+				return nil, err
+			}
+			objs := make([]metav1.Object, 0, len(rjcs))
+			for _, rjc := range rjcs {
+				objs = append(objs, rjc)
+			}
+			return objs, nil
+		},
+	}
+}
+
+// getObject returns the object with the given name.
+func (a resourceAccess) getObject(name string) (metav1.Object, *apierrors.StatusError) {
+	obj, err := a.get(name)
+	if apierrors.IsNotFound(err) {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Group: executiongroup.GroupName, Resource: a.resource}, name)
+	} else if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	return obj, nil
+}
+
+// listObjects returns all objects that match the filter, ordered by creation
+// timestamp from newest to oldest. If the filter specifies a name, a missing
+// object results in an empty list.
+func (a resourceAccess) listObjects(f filter) ([]metav1.Object, *apierrors.StatusError) {
+	var objs []metav1.Object
+	if f.name != "" {
+		obj, err := a.get(f.name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, apierrors.NewInternalError(err)
+		}
+		if err == nil && f.matches(obj) {
+			objs = append(objs, obj)
+		}
+	} else {
+		selector := f.selector
+		if selector == nil {
+			selector = labels.Everything()
+		}
+		listed, err := a.list(selector)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		objs = listed
+	}
+
+	sort.Slice(objs, func(i, j int) bool {
+		ti, tj := objs[i].GetCreationTimestamp(), objs[j].GetCreationTimestamp()
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return objs[i].GetName() < objs[j].GetName()
+	})
+	return objs, nil
+}
+
+func (s *Server) serveJobs(w http.ResponseWriter, r *http.Request, _ authenticationv1.UserInfo, namespace, name string) {
+	s.serve(w, r, s.jobsAccess(namespace), namespace, name, func(objs []metav1.Object) interface{} {
+		list := &execution.JobList{TypeMeta: metav1.TypeMeta{
+			APIVersion: execution.GroupVersion.String(),
+			Kind:       "JobList",
+		}}
+		for _, obj := range objs {
+			list.Items = append(list.Items, *obj.(*execution.Job))
+		}
+		return list
+	})
+}
+
+func (s *Server) serveJobConfigs(
+	w http.ResponseWriter, r *http.Request, _ authenticationv1.UserInfo, namespace, name string,
+) {
+	s.serve(w, r, s.jobConfigsAccess(namespace), namespace, name, func(objs []metav1.Object) interface{} {
+		list := &execution.JobConfigList{TypeMeta: metav1.TypeMeta{
+			APIVersion: execution.GroupVersion.String(),
+			Kind:       "JobConfigList",
+		}}
+		for _, obj := range objs {
+			list.Items = append(list.Items, *obj.(*execution.JobConfig))
+		}
+		return list
+	})
+}
+
+// serve handles get, list and stream requests for a resource.
+func (s *Server) serve(
+	w http.ResponseWriter,
+	r *http.Request,
+	a resourceAccess,
+	namespace, name string,
+	newList func(objs []metav1.Object) interface{},
+) {
+	values := r.URL.Query()
+	f := filter{namespace: namespace, name: name}
+	if selectorParam := values.Get("labelSelector"); selectorParam != "" {
+		selector, err := labels.Parse(selectorParam)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest("invalid label selector: "+err.Error()))
+			return
+		}
+		f.selector = selector
+	}
+
+	var isWatch bool
+	if watchParam := values.Get("watch"); watchParam != "" {
+		parsed, err := strconv.ParseBool(watchParam)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest("invalid value for watch: "+watchParam))
+			return
+		}
+		isWatch = parsed
+	}
+
+	if !isWatch && name != "" {
+		obj, err := a.getObject(name)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+		return
+	}
+
+	// Start streaming before listing, so that no events are missed in between.
+	var st *stream
+	if isWatch {
+		st = a.broadcaster.subscribe()
+		defer a.broadcaster.unsubscribe(st)
+	}
+
+	objs, err := a.listObjects(f)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !isWatch {
+		writeJSON(w, http.StatusOK, newList(objs))
+		return
+	}
+	s.serveStream(w, r, st, f, objs)
+}
+
+// serveStream writes the initial objects and subsequent events as server-sent
+// events until the request is done or the stream is closed.
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request, st *stream, f filter, objs []metav1.Object) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, apierrors.NewInternalError(errors.New("streaming is not supported")))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	for _, obj := range objs {
+		if err := writeEvent(w, watch.Added, obj); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case e, ok := <-st.events:
+			if !ok {
+				klog.V(4).InfoS("apiserver: stream was closed")
+				return
+			}
+			eventType, obj, ok := f.apply(e)
+			if !ok {
+				continue
+			}
+			if err := writeEvent(w, eventType, obj); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) killJob(w http.ResponseWriter, r *http.Request, user authenticationv1.UserInfo, namespace, name string) {
+	rj, err := s.requestKill(r.Context(), user, namespace, name)
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rj)
+}
+
+// requestKill requests for the Job to be killed on behalf of the user.
+func (s *Server) requestKill(
+	ctx context.Context, user authenticationv1.UserInfo, namespace, name string,
+) (*execution.Job, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				jobutil.AnnotationKeyRequestKill: "",
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rj, err := s.ctrlContext.Clientsets().Furiko().ExecutionV1alpha1().Jobs(namespace).
+		Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	klog.InfoS("apiserver: requested job to be killed", "namespace", namespace, "name", name, "user", user.Username)
+	return rj, nil
+}
+
+func (s *Server) runJobConfig(
+	w http.ResponseWriter, r *http.Request, user authenticationv1.UserInfo, namespace, name string,
+) {
+	var req RunRequest
+	if r.ContentLength != 0 {
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			writeError(w, apierrors.NewBadRequest("cannot decode request body: "+err.Error()))
+			return
+		}
+	}
+
+	created, err := s.createJob(r.Context(), user, namespace, name, req)
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// createJob creates a Job from the JobConfig on behalf of the user.
+func (s *Server) createJob(
+	ctx context.Context, user authenticationv1.UserInfo, namespace, configName string, req RunRequest,
+) (*execution.Job, error) {
+	rj, err := newJob(namespace, configName, req)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	created, err := s.ctrlContext.Clientsets().Furiko().ExecutionV1alpha1().Jobs(namespace).
+		Create(ctx, rj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	klog.InfoS("apiserver: created job from jobconfig", "namespace", namespace, "jobConfig", configName,
+		"name", created.Name, "user", user.Username)
+	return created, nil
+}
+
+// newJob returns a new Job to be created from the JobConfig.
+func newJob(namespace, configName string, req RunRequest) (*execution.Job, error) {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: configName + "-",
+			Namespace:    namespace,
+		},
+		Spec: execution.JobSpec{
+			ConfigName:  configName,
+			StartPolicy: req.StartPolicy,
+		},
+	}
+	if len(req.OptionValues) > 0 {
+		optionValues, err := json.Marshal(req.OptionValues)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal option values")
+		}
+		rj.Spec.OptionValues = string(optionValues)
+	}
+	return rj, nil
+}
+
+func writeEvent(w http.ResponseWriter, eventType watch.EventType, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %v\ndata: %s\n\n", eventType, data)
+	return err
+}
+
+// writeClientError writes an error returned by the kube-apiserver, preserving
+// its status if possible.
+func writeClientError(w http.ResponseWriter, err error) {
+	var statusErr *apierrors.StatusError
+	if errors.As(err, &statusErr) {
+		writeError(w, statusErr)
+		return
+	}
+	writeError(w, apierrors.NewInternalError(err))
+}
+
+func writeError(w http.ResponseWriter, err *apierrors.StatusError) {
+	status := err.Status()
+	status.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}
+	writeJSON(w, int(status.Code), status)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		klog.ErrorS(err, "apiserver: cannot write response")
+	}
+}
@@ -0,0 +1,673 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/apiserver"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	testNamespace = "test"
+	validToken    = "valid-token"
+	adminToken    = "admin-token"
+)
+
+var (
+	jobConfig1 = &v1alpha1.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "jobconfig1",
+			Namespace:         testNamespace,
+			CreationTimestamp: testutils.Mkmtime("2022-04-01T00:00:00Z"),
+		},
+	}
+
+	job1 = newJob("job1", testNamespace, "2022-04-01T04:00:00Z", map[string]string{"team": "infra"})
+	job2 = newJob("job2", testNamespace, "2022-04-01T05:00:00Z", nil)
+	job3 = newJob("job3", "other", "2022-04-01T06:00:00Z", nil)
+	job5 = &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job5",
+			Namespace:         "logs",
+			CreationTimestamp: testutils.Mkmtime("2022-04-01T07:00:00Z"),
+		},
+		Status: v1alpha1.JobStatus{
+			Tasks: []v1alpha1.TaskRef{
+				{
+					Name:              "job5-1",
+					CreationTimestamp: testutils.Mkmtime("2022-04-01T07:00:00Z"),
+					Status: v1alpha1.TaskStatus{
+						LogArchive: &v1alpha1.TaskLogArchive{Key: "logs/job5-1.log"},
+					},
+				},
+				{
+					Name:              "job5-2",
+					CreationTimestamp: testutils.Mkmtime("2022-04-01T07:01:00Z"),
+					Status: v1alpha1.TaskStatus{
+						LogArchive: &v1alpha1.TaskLogArchive{Key: "logs/job5-2.log"},
+					},
+				},
+				{
+					Name:              "job5-3",
+					CreationTimestamp: testutils.Mkmtime("2022-04-01T07:02:00Z"),
+				},
+			},
+		},
+	}
+)
+
+func newJob(name, namespace, createTime string, labels map[string]string) *v1alpha1.Job {
+	return &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			ResourceVersion:   "1",
+			Labels:            labels,
+		},
+	}
+}
+
+// setUp returns a Server where validToken authenticates as a user which can
+// only get and list Jobs, and adminToken authenticates as a user which can
+// perform all actions.
+func setUp(
+	ctx context.Context, t *testing.T, cfg *configv1alpha1.APIServerSpec,
+) (*mock.Context, *apiserver.Server) {
+	ctrlContext := mock.NewContext()
+	client := ctrlContext.MockClientsets().FurikoMock()
+	_, err := client.ExecutionV1alpha1().JobConfigs(testNamespace).Create(ctx, jobConfig1, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	for _, rj := range []*v1alpha1.Job{job1, job2, job3, job5} {
+		_, err := client.ExecutionV1alpha1().Jobs(rj.Namespace).Create(ctx, rj, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	kubeClient := ctrlContext.MockClientsets().KubernetesMock()
+	kubeClient.PrependReactor("create", "tokenreviews",
+		func(action ktesting.Action) (bool, runtime.Object, error) {
+			review := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+			switch review.Spec.Token {
+			case validToken:
+				review.Status.Authenticated = true
+				review.Status.User.Username = "user"
+			case adminToken:
+				review.Status.Authenticated = true
+				review.Status.User.Username = "admin"
+			}
+			return true, review, nil
+		})
+	kubeClient.PrependReactor("create", "subjectaccessreviews",
+		func(action ktesting.Action) (bool, runtime.Object, error) {
+			review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			attrs := review.Spec.ResourceAttributes
+			review.Status.Allowed = review.Spec.User == "admin" ||
+				(attrs.Resource == "jobs" && (attrs.Verb == "get" || attrs.Verb == "list"))
+			return true, review, nil
+		})
+
+	server, err := apiserver.NewServer(ctrlContext, cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, ctrlContext.Start(ctx))
+	informers := ctrlContext.Informers().Furiko().Execution().V1alpha1()
+	if !cache.WaitForCacheSync(ctx.Done(), informers.Jobs().Informer().HasSynced,
+		informers.JobConfigs().Informer().HasSynced) {
+		t.Fatal("cannot sync caches")
+	}
+	return ctrlContext, server
+}
+
+func newRequest(method, path, token, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		token     string
+		body      string
+		wantCode  int
+		wantNames []string
+		wantName  string
+	}{
+		{
+			name:     "missing token",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobs",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobs",
+			token:    "invalid",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:      "list jobs",
+			method:    http.MethodGet,
+			path:      "/api/v1/namespaces/test/jobs",
+			token:     validToken,
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job2", "job1"},
+		},
+		{
+			name:      "list jobs with label selector",
+			method:    http.MethodGet,
+			path:      "/api/v1/namespaces/test/jobs?labelSelector=team%3Dinfra",
+			token:     validToken,
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job1"},
+		},
+		{
+			name:     "invalid label selector",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobs?labelSelector=!!",
+			token:    validToken,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "get job",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobs/job1",
+			token:    validToken,
+			wantCode: http.StatusOK,
+			wantName: "job1",
+		},
+		{
+			name:     "job not found",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/other/jobs/job1",
+			token:    validToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "forbidden to list job configs",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/jobconfigs",
+			token:    validToken,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:      "list job configs",
+			method:    http.MethodGet,
+			path:      "/api/v1/namespaces/test/jobconfigs",
+			token:     adminToken,
+			wantCode:  http.StatusOK,
+			wantNames: []string{"jobconfig1"},
+		},
+		{
+			name:     "forbidden to kill job",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/jobs/job1/kill",
+			token:    validToken,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "kill job",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/jobs/job1/kill",
+			token:    adminToken,
+			wantCode: http.StatusOK,
+			wantName: "job1",
+		},
+		{
+			name:     "kill job not found",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/jobs/job4/kill",
+			token:    adminToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "invalid run request",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/jobconfigs/jobconfig1/run",
+			token:    adminToken,
+			body:     `{"unknown": true}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown path",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/pods",
+			token:    adminToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "unsupported method",
+			method:   http.MethodDelete,
+			path:     "/api/v1/namespaces/test/jobs/job1",
+			token:    adminToken,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, server := setUp(ctx, t, nil)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, newRequest(tt.method, tt.path, tt.token, tt.body))
+			if w.Code != tt.wantCode {
+				t.Fatalf("ServeHTTP() code = %v, want %v, body = %v", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if tt.wantName != "" {
+				var obj metav1.PartialObjectMetadata
+				if err := json.Unmarshal(w.Body.Bytes(), &obj); err != nil {
+					t.Fatalf("cannot unmarshal response: %v", err)
+				}
+				assert.Equal(t, tt.wantName, obj.Name)
+				return
+			}
+			var list struct {
+				Items []metav1.PartialObjectMetadata `json:"items"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatalf("cannot unmarshal response: %v", err)
+			}
+			names := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				names = append(names, item.Name)
+			}
+			if !cmp.Equal(tt.wantNames, names) {
+				t.Errorf("ServeHTTP() names not equal\ndiff = %v", cmp.Diff(tt.wantNames, names))
+			}
+		})
+	}
+}
+
+func TestServer_KillJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, nil)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/namespaces/test/jobs/job2/kill", adminToken, ""))
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	rj, err := ctrlContext.MockClientsets().FurikoMock().ExecutionV1alpha1().Jobs(testNamespace).
+		Get(ctx, "job2", metav1.GetOptions{})
+	assert.NoError(t, err)
+	value, ok := rj.Annotations[jobutil.AnnotationKeyRequestKill]
+	assert.True(t, ok)
+	assert.Equal(t, "", value)
+}
+
+func TestServer_RunJobConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, nil)
+
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/namespaces/test/jobconfigs/jobconfig1/run", adminToken,
+		`{"optionValues": {"env": "prod"}, "startPolicy": {"concurrencyPolicy": "Enqueue"}}`))
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var created v1alpha1.Job
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "jobconfig1-", created.GenerateName)
+	assert.Equal(t, "jobconfig1", created.Spec.ConfigName)
+	assert.JSONEq(t, `{"env": "prod"}`, created.Spec.OptionValues)
+	assert.Equal(t, &v1alpha1.StartPolicySpec{ConcurrencyPolicy: v1alpha1.ConcurrencyPolicyEnqueue},
+		created.Spec.StartPolicy)
+
+	// The SubjectAccessReview should be for creating Jobs in the namespace.
+	var found bool
+	for _, action := range ctrlContext.MockClientsets().KubernetesMock().Actions() {
+		review, ok := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		if !ok {
+			continue
+		}
+		if attrs := review.Spec.ResourceAttributes; attrs.Verb == "create" && attrs.Resource == "jobs" {
+			found = true
+			assert.Equal(t, testNamespace, attrs.Namespace)
+			assert.Equal(t, "", attrs.Name)
+		}
+	}
+	assert.True(t, found, "expected subjectaccessreview for creating jobs")
+}
+
+func TestServer_Stream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, nil)
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		httpServer.URL+"/api/v1/namespaces/test/jobs?watch=true", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+validToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("cannot start stream: %v", err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan string, 10)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		var eventType string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				eventType = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var obj metav1.PartialObjectMetadata
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &obj); err != nil {
+					t.Errorf("cannot unmarshal event: %v", err)
+					return
+				}
+				events <- eventType + " " + obj.Name
+			}
+		}
+	}()
+
+	next := func() string {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for event")
+			return ""
+		}
+	}
+
+	assert.Equal(t, "ADDED job2", next())
+	assert.Equal(t, "ADDED job1", next())
+
+	client := ctrlContext.MockClientsets().FurikoMock().ExecutionV1alpha1()
+	_, err = client.Jobs("other").Create(ctx, newJob("job4", "other", "2022-04-01T07:00:00Z", nil),
+		metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = client.Jobs(testNamespace).Create(ctx, newJob("job4", testNamespace, "2022-04-01T07:00:00Z", nil),
+		metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ADDED job4", next())
+
+	assert.NoError(t, client.Jobs(testNamespace).Delete(ctx, "job1", metav1.DeleteOptions{}))
+	assert.Equal(t, "DELETED job1", next())
+}
+
+func TestServer_Logs(t *testing.T) {
+	archive := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bucket/logs/job5-1.log" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("archived logs"))
+	}))
+	defer archive.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		token    string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "forbidden to get logs",
+			path:     "/api/v1/namespaces/logs/jobs/job5/logs",
+			token:    validToken,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "job not found",
+			path:     "/api/v1/namespaces/logs/jobs/job6/logs",
+			token:    adminToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "task not found",
+			path:     "/api/v1/namespaces/logs/jobs/job5/logs?task=job5-4",
+			token:    adminToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "get logs from pod",
+			path:     "/api/v1/namespaces/logs/jobs/job5/logs?task=job5-2",
+			token:    adminToken,
+			wantCode: http.StatusOK,
+			wantBody: "fake logs",
+		},
+		{
+			name:     "get logs from archive if pod does not exist",
+			path:     "/api/v1/namespaces/logs/jobs/job5/logs?task=job5-1",
+			token:    adminToken,
+			wantCode: http.StatusOK,
+			wantBody: "archived logs",
+		},
+		{
+			name:     "get archived logs even if pod exists",
+			path:     "/api/v1/namespaces/logs/jobs/job5/logs?task=job5-2&archived=true",
+			token:    adminToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "logs were not archived",
+			path:     "/api/v1/namespaces/logs/jobs/job5/logs",
+			token:    adminToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "invalid archived parameter",
+			path:     "/api/v1/namespaces/logs/jobs/job5/logs?archived=maybe",
+			token:    adminToken,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, &configv1alpha1.APIServerSpec{
+		LogArchiveURL: archive.URL + "/bucket/",
+	})
+	_, err := ctrlContext.MockClientsets().KubernetesMock().CoreV1().Pods("logs").Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job5-2",
+			Namespace: "logs",
+		},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, newRequest(http.MethodGet, tt.path, tt.token, ""))
+			if w.Code != tt.wantCode {
+				t.Fatalf("ServeHTTP() code = %v, want %v, body = %v", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode == http.StatusOK {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_Trigger(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, nil)
+
+	const triggerToken = "trigger-token"
+	client := ctrlContext.MockClientsets().FurikoMock().ExecutionV1alpha1()
+	rjc := &v1alpha1.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "triggered",
+			Namespace: testNamespace,
+			UID:       "triggered-uid",
+			Annotations: map[string]string{
+				jobconfig.AnnotationKeyTriggerTokenSecret: "trigger-secret",
+			},
+		},
+	}
+	_, err := client.JobConfigs(testNamespace).Create(ctx, rjc, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	triggeredJob := newJob("triggered-job", testNamespace, "2022-04-01T08:00:00Z",
+		map[string]string{jobconfig.LabelKeyJobConfigUID: "triggered-uid"})
+	_, err = client.Jobs(testNamespace).Create(ctx, triggeredJob, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = ctrlContext.MockClientsets().KubernetesMock().CoreV1().Secrets(testNamespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "trigger-secret", Namespace: testNamespace},
+		Data:       map[string][]byte{jobconfig.TriggerTokenSecretKey: []byte(triggerToken + "\n")},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	informers := ctrlContext.Informers().Furiko().Execution().V1alpha1()
+	assert.Eventually(t, func() bool {
+		_, err1 := informers.JobConfigs().Lister().JobConfigs(testNamespace).Get("triggered")
+		_, err2 := informers.Jobs().Lister().Jobs(testNamespace).Get("triggered-job")
+		return err1 == nil && err2 == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "missing token",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/triggers/triggered",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/triggers/triggered",
+			token:    "invalid",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "kubernetes token is not accepted",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/triggers/triggered",
+			token:    adminToken,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "jobconfig without trigger token",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/triggers/jobconfig1",
+			token:    triggerToken,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "jobconfig not found",
+			method:   http.MethodPost,
+			path:     "/api/v1/namespaces/test/triggers/missing",
+			token:    triggerToken,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "get triggered job",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/triggers/triggered/triggered-job",
+			token:    triggerToken,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "cannot get job of another jobconfig",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/triggers/triggered/job1",
+			token:    triggerToken,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "invalid method",
+			method:   http.MethodGet,
+			path:     "/api/v1/namespaces/test/triggers/triggered",
+			token:    triggerToken,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, newRequest(tt.method, tt.path, tt.token, ""))
+			assert.Equal(t, tt.wantCode, w.Code, w.Body.String())
+		})
+	}
+
+	// Trigger the JobConfig.
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, newRequest(http.MethodPost, "/api/v1/namespaces/test/triggers/triggered", triggerToken,
+		`{"optionValues": {"env": "prod"}}`))
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var resp apiserver.TriggerResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, testNamespace, resp.Namespace)
+	assert.Equal(t, "/api/v1/namespaces/test/triggers/triggered/"+resp.Name, resp.StatusURL)
+
+	jobs, err := client.Jobs(testNamespace).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	var found bool
+	for _, rj := range jobs.Items {
+		if rj.GenerateName == "triggered-" {
+			found = true
+			assert.Equal(t, "triggered", rj.Spec.ConfigName)
+			assert.JSONEq(t, `{"env": "prod"}`, rj.Spec.OptionValues)
+		}
+	}
+	assert.True(t, found, "expected job to be created")
+}
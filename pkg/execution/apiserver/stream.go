@@ -0,0 +1,161 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	streamBufferLength = 100
+)
+
+// event is an informer event. oldObj is nil for additions, and newObj is nil
+// for deletions.
+type event struct {
+	oldObj metav1.Object
+	newObj metav1.Object
+}
+
+// filter describes the objects that a stream is interested in.
+type filter struct {
+	namespace string
+	name      string
+	selector  labels.Selector
+}
+
+func (f filter) matches(obj metav1.Object) bool {
+	if obj.GetNamespace() != f.namespace {
+		return false
+	}
+	if f.name != "" && obj.GetName() != f.name {
+		return false
+	}
+	return f.selector == nil || f.selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// apply returns the watch event type and object for the event as seen by a
+// stream with the given filter. Returns false if the event should not be sent.
+func (f filter) apply(e event) (watch.EventType, metav1.Object, bool) {
+	oldMatches := e.oldObj != nil && f.matches(e.oldObj)
+	newMatches := e.newObj != nil && f.matches(e.newObj)
+	switch {
+	case oldMatches && newMatches:
+		return watch.Modified, e.newObj, true
+	case newMatches:
+		return watch.Added, e.newObj, true
+	case oldMatches:
+		return watch.Deleted, e.oldObj, true
+	}
+	return "", nil, false
+}
+
+type stream struct {
+	events chan event
+}
+
+// broadcaster receives informer events and sends them to all streams. Streams
+// which fall too far behind are closed, and clients are expected to reconnect.
+type broadcaster struct {
+	mu      sync.Mutex
+	streams map[*stream]struct{}
+}
+
+var _ cache.ResourceEventHandler = (*broadcaster)(nil)
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{
+		streams: make(map[*stream]struct{}),
+	}
+}
+
+// subscribe adds a new stream, which must be closed after it is no longer used.
+func (b *broadcaster) subscribe() *stream {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := &stream{
+		events: make(chan event, streamBufferLength),
+	}
+	b.streams[s] = struct{}{}
+	return s
+}
+
+// unsubscribe removes the stream and closes its events channel, if not yet
+// closed.
+func (b *broadcaster) unsubscribe(s *stream) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(s)
+}
+
+func (b *broadcaster) removeLocked(s *stream) {
+	if _, ok := b.streams[s]; ok {
+		delete(b.streams, s)
+		close(s.events)
+	}
+}
+
+func (b *broadcaster) broadcast(e event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.streams {
+		select {
+		case s.events <- e:
+		default:
+			klog.V(2).InfoS("apiserver: closing stream which is too far behind")
+			b.removeLocked(s)
+		}
+	}
+}
+
+func (b *broadcaster) OnAdd(obj interface{}) {
+	if newObj, ok := obj.(metav1.Object); ok {
+		b.broadcast(event{newObj: newObj})
+	}
+}
+
+func (b *broadcaster) OnUpdate(oldObj, newObj interface{}) {
+	oldMeta, ok := oldObj.(metav1.Object)
+	if !ok {
+		return
+	}
+	newMeta, ok := newObj.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	// Skip periodic resyncs, since the object was not modified.
+	if oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+		return
+	}
+	b.broadcast(event{oldObj: oldMeta, newObj: newMeta})
+}
+
+func (b *broadcaster) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if oldObj, ok := obj.(metav1.Object); ok {
+		b.broadcast(event{oldObj: oldObj})
+	}
+}
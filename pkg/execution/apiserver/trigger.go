@@ -0,0 +1,198 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apiserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+)
+
+const (
+	resourceTriggers = "triggers"
+)
+
+// TriggerResponse is the response body after triggering a JobConfig.
+type TriggerResponse struct {
+	// Namespace of the created Job.
+	Namespace string `json:"namespace"`
+
+	// Name of the created Job.
+	Name string `json:"name"`
+
+	// StatusURL is the path that the status of the Job can be retrieved from,
+	// using the same token.
+	StatusURL string `json:"statusURL"`
+}
+
+// serveTrigger serves the trigger endpoints for a JobConfig, which are
+// authenticated with the JobConfig's trigger token instead of a Kubernetes
+// token:
+//
+//	POST /v1/namespaces/{namespace}/triggers/{jobConfig}: Runs the JobConfig.
+//	GET  /v1/namespaces/{namespace}/triggers/{jobConfig}/{job}: Gets the status of a triggered Job.
+//
+// JobConfigs can only be triggered if they have the
+// AnnotationKeyTriggerTokenSecret annotation, which references a Secret
+// containing the token.
+func (s *Server) serveTrigger(w http.ResponseWriter, r *http.Request, namespace, name, jobName string) {
+	switch {
+	case r.Method == http.MethodPost && name != "" && jobName == "":
+	case r.Method == http.MethodGet && name != "" && jobName != "":
+	default:
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{}, r.URL.Path))
+		return
+	}
+
+	for _, hasSynced := range s.hasSynced {
+		if !hasSynced() {
+			writeError(w, apierrors.NewServiceUnavailable("informer cache is not yet synced"))
+			return
+		}
+	}
+
+	// Use the same error for JobConfigs that do not exist or cannot be triggered,
+	// so as to not reveal which JobConfigs exist.
+	unauthorized := apierrors.NewUnauthorized("invalid trigger token")
+
+	rjc, err := s.ctrlContext.Informers().Furiko().Execution().V1alpha1().JobConfigs().Lister().
+		JobConfigs(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		writeError(w, unauthorized)
+		return
+	} else if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+
+	ok, err := s.verifyTriggerToken(r, rjc)
+	if err != nil {
+		klog.ErrorS(err, "apiserver: cannot verify trigger token", "namespace", namespace, "jobConfig", name)
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+	if !ok {
+		writeError(w, unauthorized)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		s.getTriggeredJob(w, rjc, jobName)
+		return
+	}
+
+	var req RunRequest
+	if r.ContentLength != 0 {
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&req); err != nil {
+			writeError(w, apierrors.NewBadRequest("cannot decode request body: "+err.Error()))
+			return
+		}
+	}
+
+	rj, err := newJob(namespace, name, req)
+	if err != nil {
+		writeError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	created, err := s.ctrlContext.Clientsets().Furiko().ExecutionV1alpha1().Jobs(namespace).
+		Create(r.Context(), rj, metav1.CreateOptions{})
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	klog.InfoS("apiserver: created job from trigger", "namespace", namespace, "jobConfig", name,
+		"name", created.Name)
+	writeJSON(w, http.StatusCreated, TriggerResponse{
+		Namespace: created.Namespace,
+		Name:      created.Name,
+		StatusURL: fmt.Sprintf("%v/v1/namespaces/%v/%v/%v/%v", s.pathPrefix, namespace, resourceTriggers,
+			name, created.Name),
+	})
+}
+
+// getTriggeredJob writes a summary of a Job belonging to the JobConfig.
+func (s *Server) getTriggeredJob(w http.ResponseWriter, rjc *execution.JobConfig, name string) {
+	gr := schema.GroupResource{Group: executiongroup.GroupName, Resource: resourceJobs}
+	rj, err := s.ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs().Lister().
+		Jobs(rjc.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		writeError(w, apierrors.NewNotFound(gr, name))
+		return
+	} else if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+
+	// The token only grants access to Jobs of the JobConfig.
+	if !jobconfig.LabelJobsForJobConfig(rjc).AsSelector().Matches(labels.Set(rj.Labels)) {
+		writeError(w, apierrors.NewNotFound(gr, name))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jobconfig.NewJobSummary(rj))
+}
+
+// verifyTriggerToken returns true if the request contains the JobConfig's
+// trigger token.
+func (s *Server) verifyTriggerToken(r *http.Request, rjc *execution.JobConfig) (bool, error) {
+	secretName := rjc.Annotations[jobconfig.AnnotationKeyTriggerTokenSecret]
+	if secretName == "" {
+		return false, nil
+	}
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false, nil
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+	if token == "" {
+		return false, nil
+	}
+
+	secret, err := s.ctrlContext.Clientsets().Kubernetes().CoreV1().Secrets(rjc.Namespace).
+		Get(r.Context(), secretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	// Ignore trailing newlines, which are common when creating the Secret from a file.
+	expected := bytes.TrimSpace(secret.Data[jobconfig.TriggerTokenSecretKey])
+	if len(expected) == 0 {
+		return false, nil
+	}
+
+	// Compare hashes so that the comparison does not leak the token's length.
+	got, want := sha256.Sum256([]byte(token)), sha256.Sum256(expected)
+	return subtle.ConstantTimeCompare(got[:], want[:]) == 1, nil
+}
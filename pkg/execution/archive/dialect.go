@@ -0,0 +1,188 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+)
+
+// columns is the list of columns of the archive table, in the order that they
+// are written and read.
+var columns = []string{
+	"uid",
+	"namespace",
+	"name",
+	"job_config",
+	"type",
+	"phase",
+	"result",
+	"reason",
+	"message",
+	"create_time",
+	"start_time",
+	"finish_time",
+	"created_tasks",
+	"spec",
+	"option_values",
+	"substitutions",
+	"tasks",
+	"outputs",
+	"archive_time",
+}
+
+// dialect contains the differences in SQL syntax between databases.
+type dialect struct {
+	// Column type used for timestamps.
+	timestampType string
+
+	// Column type used for large text values, such as JSON-encoded task results.
+	textType string
+
+	// Whether indexes must be defined in the CREATE TABLE statement, since
+	// CREATE INDEX IF NOT EXISTS is not supported.
+	inlineIndex bool
+
+	// Returns the placeholder for the n-th argument, starting from 1.
+	placeholder func(n int) string
+
+	// Returns the clause that updates all of the given columns on conflict.
+	upsertClause func(columns []string) string
+}
+
+var dialects = map[string]*dialect{
+	DialectPostgres: {
+		timestampType: "TIMESTAMPTZ",
+		textType:      "TEXT",
+		placeholder: func(n int) string {
+			return fmt.Sprintf("$%d", n)
+		},
+		upsertClause: func(columns []string) string {
+			sets := make([]string, 0, len(columns))
+			for _, column := range columns {
+				sets = append(sets, fmt.Sprintf("%v = EXCLUDED.%v", column, column))
+			}
+			return "ON CONFLICT (uid) DO UPDATE SET " + strings.Join(sets, ", ")
+		},
+	},
+	DialectMySQL: {
+		timestampType: "DATETIME(6)",
+		textType:      "MEDIUMTEXT",
+		inlineIndex:   true,
+		placeholder: func(_ int) string {
+			return "?"
+		},
+		upsertClause: func(columns []string) string {
+			sets := make([]string, 0, len(columns))
+			for _, column := range columns {
+				sets = append(sets, fmt.Sprintf("%v = VALUES(%v)", column, column))
+			}
+			return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+		},
+	},
+}
+
+// schema returns the statements that create the table and its indexes if they
+// do not exist.
+func (d *dialect) schema(table string) []string {
+	index := fmt.Sprintf("%v_namespace_job_config_create_time", table)
+	indexColumns := "namespace, job_config, create_time"
+
+	definitions := []string{
+		"uid VARCHAR(36) NOT NULL PRIMARY KEY",
+		"namespace VARCHAR(63) NOT NULL",
+		"name VARCHAR(253) NOT NULL",
+		"job_config VARCHAR(253) NOT NULL",
+		"type VARCHAR(32) NOT NULL",
+		"phase VARCHAR(64) NOT NULL",
+		"result VARCHAR(64) NOT NULL",
+		"reason VARCHAR(255) NOT NULL",
+		"message " + d.textType + " NOT NULL",
+		"create_time " + d.timestampType + " NOT NULL",
+		"start_time " + d.timestampType + " NULL",
+		"finish_time " + d.timestampType + " NULL",
+		"created_tasks BIGINT NOT NULL",
+		"spec " + d.textType + " NOT NULL",
+		"option_values " + d.textType + " NOT NULL",
+		"substitutions " + d.textType + " NOT NULL",
+		"tasks " + d.textType + " NOT NULL",
+		"outputs " + d.textType + " NOT NULL",
+		"archive_time " + d.timestampType + " NOT NULL",
+	}
+	if d.inlineIndex {
+		definitions = append(definitions, fmt.Sprintf("INDEX %v (%v)", index, indexColumns))
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (%v)", table, strings.Join(definitions, ", ")),
+	}
+	if !d.inlineIndex {
+		statements = append(statements,
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS %v ON %v (%v)", index, table, indexColumns))
+	}
+	return statements
+}
+
+// upsert returns the statement that inserts a row, or updates it if a row with
+// the same UID already exists.
+func (d *dialect) upsert(table string) string {
+	placeholders := make([]string, 0, len(columns))
+	for i := range columns {
+		placeholders = append(placeholders, d.placeholder(i+1))
+	}
+	return fmt.Sprintf("INSERT INTO %v (%v) VALUES (%v) %v", table, strings.Join(columns, ", "),
+		strings.Join(placeholders, ", "), d.upsertClause(columns[1:]))
+}
+
+// selectQuery returns the statement that lists rows matching the given query,
+// together with its arguments.
+func (d *dialect) selectQuery(table string, q Query) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(column, op string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf("%v %v %v", column, op, d.placeholder(len(args))))
+	}
+
+	if q.Namespace != "" {
+		addCondition("namespace", "=", q.Namespace)
+	}
+	if q.JobConfig != "" {
+		addCondition("job_config", "=", q.JobConfig)
+	}
+	if q.Name != "" {
+		addCondition("name", "=", q.Name)
+	}
+	if q.Before != nil {
+		addCondition("create_time", "<", q.Before.UTC())
+	}
+
+	stmt := fmt.Sprintf("SELECT %v FROM %v", strings.Join(columns, ", "), table)
+	if len(conditions) > 0 {
+		stmt += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	stmt += " ORDER BY create_time DESC, uid DESC"
+	if q.Limit > 0 {
+		stmt += fmt.Sprintf(" LIMIT %d", q.Limit)
+	}
+	return stmt, args
+}
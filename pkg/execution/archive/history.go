@@ -0,0 +1,153 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+)
+
+const (
+	defaultHistoryPathPrefix = "/history"
+	defaultHistoryLimit      = 100
+	maxHistoryLimit          = 1000
+)
+
+// HistoryServer serves a read-only API to query the history of archived Jobs,
+// including Jobs that have already been deleted from the cluster.
+//
+// The following endpoint is served under the configured path prefix:
+//
+//	GET /v1/jobs: Lists archived Jobs.
+//
+// The namespace, jobConfig and name query parameters filter the list, and
+// limit controls the maximum number of Jobs returned. Jobs are ordered by
+// creation timestamp from newest to oldest. If there may be more results, the
+// continue field in the response can be passed as the before query parameter
+// to fetch the next page.
+type HistoryServer struct {
+	pathPrefix string
+	querier    Querier
+	mux        *http.ServeMux
+}
+
+// HistoryList is the response of the history API.
+type HistoryList struct {
+	Items []*Record `json:"items"`
+
+	// Continue is the creation timestamp of the last item in RFC3339 format,
+	// which is only set if there may be more results.
+	Continue string `json:"continue,omitempty"`
+}
+
+// NewHistoryServer returns a new HistoryServer that lists Records from the
+// given Querier.
+func NewHistoryServer(querier Querier, pathPrefix string) *HistoryServer {
+	if pathPrefix == "" {
+		pathPrefix = defaultHistoryPathPrefix
+	}
+	s := &HistoryServer{
+		pathPrefix: pathPrefix,
+		querier:    querier,
+		mux:        http.NewServeMux(),
+	}
+	s.mux.HandleFunc(s.pathPrefix+"/v1/jobs", s.handleJobs)
+	return s
+}
+
+// Pattern returns the pattern that the HistoryServer should be registered with.
+func (s *HistoryServer) Pattern() string {
+	return s.pathPrefix + "/"
+}
+
+func (s *HistoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *HistoryServer) handleJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, apierrors.NewMethodNotSupported(
+			schema.GroupResource{Group: executiongroup.GroupName, Resource: "jobs"}, r.Method))
+		return
+	}
+
+	values := r.URL.Query()
+	q := Query{
+		Namespace: values.Get("namespace"),
+		JobConfig: values.Get("jobConfig"),
+		Name:      values.Get("name"),
+		Limit:     defaultHistoryLimit,
+	}
+	if limitParam := values.Get("limit"); limitParam != "" {
+		limit, err := strconv.ParseInt(limitParam, 10, 64)
+		if err != nil || limit <= 0 {
+			writeError(w, apierrors.NewBadRequest("invalid value for limit: "+limitParam))
+			return
+		}
+		q.Limit = limit
+		if q.Limit > maxHistoryLimit {
+			q.Limit = maxHistoryLimit
+		}
+	}
+	if beforeParam := values.Get("before"); beforeParam != "" {
+		before, err := time.Parse(time.RFC3339Nano, beforeParam)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest("invalid value for before: "+beforeParam))
+			return
+		}
+		q.Before = &before
+	}
+
+	records, err := s.querier.List(r.Context(), q)
+	if err != nil {
+		klog.ErrorS(err, "archive: cannot list history")
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+
+	list := HistoryList{Items: records}
+	if list.Items == nil {
+		list.Items = []*Record{}
+	}
+	if n := len(records); int64(n) == q.Limit {
+		list.Continue = records[n-1].CreateTime.Format(time.RFC3339Nano)
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func writeError(w http.ResponseWriter, err *apierrors.StatusError) {
+	status := err.Status()
+	status.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}
+	writeJSON(w, int(status.Code), status)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		klog.ErrorS(err, "archive: cannot write response")
+	}
+}
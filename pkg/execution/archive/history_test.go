@@ -0,0 +1,146 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/furiko-io/furiko/pkg/execution/archive"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+type fakeQuerier struct {
+	records []*archive.Record
+	err     error
+	query   archive.Query
+}
+
+func (q *fakeQuerier) List(_ context.Context, query archive.Query) ([]*archive.Record, error) {
+	q.query = query
+	if q.err != nil {
+		return nil, q.err
+	}
+	if query.Limit > 0 && int64(len(q.records)) > query.Limit {
+		return q.records[:query.Limit], nil
+	}
+	return q.records, nil
+}
+
+func TestHistoryServer(t *testing.T) {
+	record1 := newRecord()
+	record2 := newRecord()
+	record2.UID = "uid2"
+	record2.Name = "job2"
+	record2.CreateTime = testutils.Mktime("2022-04-01T03:00:00Z")
+
+	tests := []struct {
+		name         string
+		path         string
+		method       string
+		err          error
+		wantCode     int
+		wantQuery    archive.Query
+		wantNames    []string
+		wantContinue string
+	}{
+		{
+			name:      "list all",
+			path:      "/history/v1/jobs",
+			wantCode:  http.StatusOK,
+			wantQuery: archive.Query{Limit: 100},
+			wantNames: []string{"job", "job2"},
+		},
+		{
+			name:     "filter and paginate",
+			path:     "/history/v1/jobs?namespace=test&jobConfig=jobconfig&limit=1&before=2022-04-02T00:00:00Z",
+			wantCode: http.StatusOK,
+			wantQuery: archive.Query{
+				Namespace: "test",
+				JobConfig: "jobconfig",
+				Before:    testutils.Mktimep("2022-04-02T00:00:00Z"),
+				Limit:     1,
+			},
+			wantNames:    []string{"job"},
+			wantContinue: "2022-04-01T04:00:00Z",
+		},
+		{
+			name:     "invalid limit",
+			path:     "/history/v1/jobs?limit=-1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid before",
+			path:     "/history/v1/jobs?before=yesterday",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "method not allowed",
+			path:     "/history/v1/jobs",
+			method:   http.MethodPost,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:      "query error",
+			path:      "/history/v1/jobs",
+			err:       errors.New("connection refused"),
+			wantCode:  http.StatusInternalServerError,
+			wantQuery: archive.Query{Limit: 100},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &fakeQuerier{
+				records: []*archive.Record{record1, record2},
+				err:     tt.err,
+			}
+			server := archive.NewHistoryServer(querier, "")
+			assert.Equal(t, "/history/", server.Pattern())
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(method, tt.path, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			if !cmp.Equal(tt.wantQuery, querier.query) {
+				t.Errorf("query not equal\ndiff = %v", cmp.Diff(tt.wantQuery, querier.query))
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var list archive.HistoryList
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+			names := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				names = append(names, item.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantContinue, list.Continue)
+		})
+	}
+}
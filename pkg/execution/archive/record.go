@@ -0,0 +1,132 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// Record is the archived record of a finished Job.
+type Record struct {
+	UID       types.UID `json:"uid"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+
+	// Name of the JobConfig that the Job belongs to, if any.
+	JobConfig string `json:"jobConfig,omitempty"`
+
+	Type   execution.JobType   `json:"type"`
+	Phase  execution.JobPhase  `json:"phase"`
+	Result execution.JobResult `json:"result,omitempty"`
+
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	CreateTime time.Time  `json:"createTime"`
+	StartTime  *time.Time `json:"startTime,omitempty"`
+	FinishTime *time.Time `json:"finishTime,omitempty"`
+
+	// Number of tasks that were created for the Job, including retries.
+	CreatedTasks int64 `json:"createdTasks"`
+
+	// Summary of the spec that the Job was executed with.
+	Spec SpecSummary `json:"spec"`
+
+	// Evaluated option values and substitutions of the Job.
+	OptionValues  string            `json:"optionValues,omitempty"`
+	Substitutions map[string]string `json:"substitutions,omitempty"`
+
+	// Results of all tasks that were created for the Job.
+	Tasks []execution.TaskRef `json:"tasks,omitempty"`
+
+	// Outputs that were reported by the Job's tasks.
+	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// Time that the Record was written to the archive, only set for Records that
+	// are read from the archive.
+	ArchiveTime *time.Time `json:"archiveTime,omitempty"`
+}
+
+// SpecSummary summarizes the parts of a Job's spec that are relevant when
+// looking back at its execution.
+type SpecSummary struct {
+	ConcurrencyPolicy execution.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	TemplateRevision  string                      `json:"templateRevision,omitempty"`
+	MaxAttempts       *int32                      `json:"maxAttempts,omitempty"`
+	RetryDelaySeconds *int64                      `json:"retryDelaySeconds,omitempty"`
+	MaxRuntimeSeconds *int64                      `json:"maxRuntimeSeconds,omitempty"`
+	Steps             []string                    `json:"steps,omitempty"`
+}
+
+// NewRecord returns a Record for the given Job.
+func NewRecord(rj *execution.Job) *Record {
+	record := &Record{
+		UID:           rj.GetUID(),
+		Namespace:     rj.GetNamespace(),
+		Name:          rj.GetName(),
+		Type:          rj.Spec.Type,
+		Phase:         rj.Status.Phase,
+		CreateTime:    rj.GetCreationTimestamp().UTC(),
+		StartTime:     toTime(rj.Status.StartTime),
+		CreatedTasks:  rj.Status.CreatedTasks,
+		OptionValues:  rj.Spec.OptionValues,
+		Substitutions: rj.Spec.Substitutions,
+		Tasks:         rj.Status.Tasks,
+		Outputs:       rj.Status.Outputs,
+		Spec: SpecSummary{
+			TemplateRevision: rj.Status.TemplateRevision,
+		},
+	}
+
+	if ref := metav1.GetControllerOf(rj); ref != nil && ref.Kind == execution.KindJobConfig {
+		record.JobConfig = ref.Name
+	}
+
+	if finished := rj.Status.Condition.Finished; finished != nil {
+		record.Result = finished.Result
+		record.Reason = finished.Reason
+		record.Message = finished.Message
+		record.FinishTime = toTime(&finished.FinishedAt)
+	}
+
+	if policy := rj.Spec.StartPolicy; policy != nil {
+		record.Spec.ConcurrencyPolicy = policy.ConcurrencyPolicy
+	}
+	if template := rj.Spec.Template; template != nil {
+		record.Spec.MaxAttempts = template.MaxAttempts
+		record.Spec.RetryDelaySeconds = template.RetryDelaySeconds
+		record.Spec.MaxRuntimeSeconds = template.MaxRuntimeSeconds
+		for _, step := range template.Steps {
+			record.Spec.Steps = append(record.Spec.Steps, step.Name)
+		}
+	}
+
+	return record
+}
+
+func toTime(t *metav1.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	ts := t.UTC()
+	return &ts
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/archive"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestNewRecord(t *testing.T) {
+	record := newRecord()
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job",
+			Namespace:         "test",
+			UID:               "uid",
+			CreationTimestamp: testutils.Mkmtime("2022-04-01T04:00:00Z"),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: execution.GroupVersion.String(),
+					Kind:       execution.KindJobConfig,
+					Name:       "jobconfig",
+					Controller: pointer.Bool(true),
+				},
+			},
+		},
+		Spec: execution.JobSpec{
+			Type: execution.JobTypeScheduled,
+			StartPolicy: &execution.StartPolicySpec{
+				ConcurrencyPolicy: execution.ConcurrencyPolicyForbid,
+			},
+			Template: &execution.JobTemplateSpec{
+				MaxAttempts: pointer.Int32(3),
+			},
+			OptionValues:  record.OptionValues,
+			Substitutions: record.Substitutions,
+		},
+		Status: execution.JobStatus{
+			Phase:        execution.JobSucceeded,
+			StartTime:    testutils.Mkmtimep("2022-04-01T04:00:01Z"),
+			CreatedTasks: 1,
+			Tasks:        record.Tasks,
+			Condition: execution.JobCondition{
+				Finished: &execution.JobConditionFinished{
+					FinishedAt: testutils.Mkmtime("2022-04-01T04:01:00Z"),
+					Result:     execution.JobResultSuccess,
+				},
+			},
+		},
+	}
+
+	got := archive.NewRecord(rj)
+	if !cmp.Equal(record, got) {
+		t.Errorf("NewRecord() not equal\ndiff = %v", cmp.Diff(record, got))
+	}
+}
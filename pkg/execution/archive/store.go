@@ -0,0 +1,293 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	defaultTable   = "furiko_job_history"
+	defaultTimeout = 10 * time.Second
+)
+
+var (
+	validTableName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Archiver writes Records to the archive.
+type Archiver interface {
+	Archive(ctx context.Context, record *Record) error
+}
+
+// Querier lists Records from the archive.
+type Querier interface {
+	List(ctx context.Context, q Query) ([]*Record, error)
+}
+
+// Query filters the Records that are listed from the archive. Empty fields
+// are not used to filter Records.
+type Query struct {
+	Namespace string
+	JobConfig string
+	Name      string
+
+	// Only list Records of Jobs that were created before the given time.
+	Before *time.Time
+
+	// Maximum number of Records to list.
+	Limit int64
+}
+
+// Store is an Archiver and Querier that writes Records to a table in a SQL
+// database. Each Job is stored as a single row keyed by its UID, such that
+// writing the same Job more than once is idempotent.
+type Store struct {
+	db      *sql.DB
+	dialect *dialect
+	table   string
+	timeout time.Duration
+}
+
+var _ Archiver = (*Store)(nil)
+var _ Querier = (*Store)(nil)
+
+// Open opens the database for the given spec and returns a new Store. The
+// database/sql driver must already be registered.
+func Open(spec *configv1alpha1.ArchiveSpec) (*Store, error) {
+	driverName := spec.DriverName
+	if driverName == "" {
+		driverName = spec.Dialect
+	}
+	db, err := sql.Open(driverName, os.ExpandEnv(spec.DSN))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open database")
+	}
+	store, err := NewStore(db, spec)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// NewStore returns a new Store that uses the given database.
+func NewStore(db *sql.DB, spec *configv1alpha1.ArchiveSpec) (*Store, error) {
+	d, ok := dialects[spec.Dialect]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect %v, must be one of: %v, %v",
+			spec.Dialect, DialectPostgres, DialectMySQL)
+	}
+
+	table := spec.Table
+	if table == "" {
+		table = defaultTable
+	}
+	if !validTableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %v", table)
+	}
+
+	timeout := defaultTimeout
+	if spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.TimeoutSeconds) * time.Second
+	}
+
+	return &Store{
+		db:      db,
+		dialect: d,
+		table:   table,
+		timeout: timeout,
+	}, nil
+}
+
+// Init creates the table if it does not exist.
+func (s *Store) Init(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	for _, stmt := range s.dialect.schema(s.table) {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return errors.Wrapf(err, "cannot create table %v", s.table)
+		}
+	}
+	return nil
+}
+
+// Archive writes the Record to the table, replacing any existing row for the
+// same Job.
+func (s *Store) Archive(ctx context.Context, record *Record) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	spec, err := json.Marshal(record.Spec)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal spec")
+	}
+	substitutions, err := json.Marshal(record.Substitutions)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal substitutions")
+	}
+	tasks, err := json.Marshal(record.Tasks)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal tasks")
+	}
+	outputs, err := json.Marshal(record.Outputs)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal outputs")
+	}
+
+	if _, err := s.db.ExecContext(ctx, s.dialect.upsert(s.table),
+		string(record.UID),
+		record.Namespace,
+		record.Name,
+		record.JobConfig,
+		string(record.Type),
+		string(record.Phase),
+		string(record.Result),
+		record.Reason,
+		record.Message,
+		record.CreateTime.UTC(),
+		nullTime(record.StartTime),
+		nullTime(record.FinishTime),
+		record.CreatedTasks,
+		string(spec),
+		record.OptionValues,
+		string(substitutions),
+		string(tasks),
+		string(outputs),
+		ktime.Now().UTC(),
+	); err != nil {
+		return errors.Wrapf(err, "cannot write job %v/%v", record.Namespace, record.Name)
+	}
+
+	return nil
+}
+
+// List returns the Records matching the query, ordered by creation time from
+// newest to oldest.
+func (s *Store) List(ctx context.Context, q Query) ([]*Record, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	stmt, args := s.dialect.selectQuery(s.table, q)
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot query table %v", s.table)
+	}
+	defer rows.Close()
+
+	var records []*Record
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "cannot read rows")
+	}
+
+	return records, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func scanRecord(rows *sql.Rows) (*Record, error) {
+	record := &Record{}
+	var uid, jobType, phase, result, spec, substitutions, tasks, outputs string
+	var startTime, finishTime, archiveTime sql.NullTime
+	if err := rows.Scan(
+		&uid,
+		&record.Namespace,
+		&record.Name,
+		&record.JobConfig,
+		&jobType,
+		&phase,
+		&result,
+		&record.Reason,
+		&record.Message,
+		&record.CreateTime,
+		&startTime,
+		&finishTime,
+		&record.CreatedTasks,
+		&spec,
+		&record.OptionValues,
+		&substitutions,
+		&tasks,
+		&outputs,
+		&archiveTime,
+	); err != nil {
+		return nil, errors.Wrapf(err, "cannot scan row")
+	}
+
+	record.UID = types.UID(uid)
+	record.Type = execution.JobType(jobType)
+	record.Phase = execution.JobPhase(phase)
+	record.Result = execution.JobResult(result)
+	record.CreateTime = record.CreateTime.UTC()
+	record.StartTime = fromNullTime(startTime)
+	record.FinishTime = fromNullTime(finishTime)
+	record.ArchiveTime = fromNullTime(archiveTime)
+
+	for _, field := range []struct {
+		name  string
+		data  string
+		value interface{}
+	}{
+		{name: "spec", data: spec, value: &record.Spec},
+		{name: "substitutions", data: substitutions, value: &record.Substitutions},
+		{name: "tasks", data: tasks, value: &record.Tasks},
+		{name: "outputs", data: outputs, value: &record.Outputs},
+	} {
+		if err := json.Unmarshal([]byte(field.data), field.value); err != nil {
+			return nil, errors.Wrapf(err, "cannot unmarshal %v of job %v", field.name, uid)
+		}
+	}
+
+	return record, nil
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	ts := t.Time.UTC()
+	return &ts
+}
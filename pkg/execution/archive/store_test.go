@@ -0,0 +1,338 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/archive"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	fakeDriverName = "fake-archive"
+	archiveTime    = "2022-04-01T04:05:00Z"
+)
+
+var (
+	fakeDatabases = make(map[string]*fakeDatabase)
+	fakeMu        sync.Mutex
+)
+
+func init() {
+	sql.Register(fakeDriverName, fakeDriver{})
+}
+
+// fakeDatabase records all statements that are executed, and returns the
+// arguments of the last executed statement as the only row of every query.
+type fakeDatabase struct {
+	mu         sync.Mutex
+	statements []string
+	queries    []string
+	queryArgs  [][]driver.Value
+	lastArgs   []driver.Value
+}
+
+func newFakeDatabase(t *testing.T) (*sql.DB, *fakeDatabase) {
+	fakeMu.Lock()
+	defer fakeMu.Unlock()
+	fakeDB := &fakeDatabase{}
+	fakeDatabases[t.Name()] = fakeDB
+	db, err := sql.Open(fakeDriverName, t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, fakeDB
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeMu.Lock()
+	defer fakeMu.Unlock()
+	db, ok := fakeDatabases[name]
+	if !ok {
+		return nil, fmt.Errorf("no such database: %v", name)
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDatabase
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions are not supported")
+}
+
+type fakeStmt struct {
+	db    *fakeDatabase
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.statements = append(s.db.statements, s.query)
+	if len(args) > 0 {
+		s.db.lastArgs = args
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.queries = append(s.db.queries, s.query)
+	s.db.queryArgs = append(s.db.queryArgs, args)
+	rows := &fakeRows{}
+	if s.db.lastArgs != nil {
+		rows.values = [][]driver.Value{s.db.lastArgs}
+	}
+	return rows, nil
+}
+
+type fakeRows struct {
+	values [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.values) == 0 {
+		return nil
+	}
+	columns := make([]string, len(r.values[0]))
+	for i := range columns {
+		columns[i] = fmt.Sprintf("c%d", i)
+	}
+	return columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newRecord() *archive.Record {
+	return &archive.Record{
+		UID:          "uid",
+		Namespace:    "test",
+		Name:         "job",
+		JobConfig:    "jobconfig",
+		Type:         execution.JobTypeScheduled,
+		Phase:        execution.JobSucceeded,
+		Result:       execution.JobResultSuccess,
+		CreateTime:   testutils.Mktime("2022-04-01T04:00:00Z"),
+		StartTime:    testutils.Mktimep("2022-04-01T04:00:01Z"),
+		FinishTime:   testutils.Mktimep("2022-04-01T04:01:00Z"),
+		CreatedTasks: 1,
+		Spec: archive.SpecSummary{
+			ConcurrencyPolicy: execution.ConcurrencyPolicyForbid,
+			MaxAttempts:       pointer.Int32(3),
+		},
+		OptionValues: `{"env":"prod"}`,
+		Substitutions: map[string]string{
+			"option.env": "prod",
+		},
+		Tasks: []execution.TaskRef{
+			{
+				Name:              "job-1",
+				CreationTimestamp: testutils.Mkmtime("2022-04-01T04:00:01Z"),
+				FinishTimestamp:   testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				Status: execution.TaskStatus{
+					State:  execution.TaskSuccess,
+					Result: jobResultPtr(execution.JobResultSuccess),
+				},
+			},
+		},
+	}
+}
+
+func jobResultPtr(result execution.JobResult) *execution.JobResult {
+	return &result
+}
+
+func TestStore(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(testutils.Mktime(archiveTime))
+
+	tests := []struct {
+		name       string
+		spec       *configv1alpha1.ArchiveSpec
+		wantSchema []string
+		wantUpsert string
+		query      archive.Query
+		wantQuery  string
+		wantArgs   []driver.Value
+	}{
+		{
+			name: "postgres",
+			spec: &configv1alpha1.ArchiveSpec{
+				Dialect: archive.DialectPostgres,
+			},
+			wantSchema: []string{
+				"CREATE TABLE IF NOT EXISTS furiko_job_history (",
+				"CREATE INDEX IF NOT EXISTS furiko_job_history_namespace_job_config_create_time " +
+					"ON furiko_job_history (namespace, job_config, create_time)",
+			},
+			wantUpsert: "ON CONFLICT (uid) DO UPDATE SET namespace = EXCLUDED.namespace,",
+			query: archive.Query{
+				Namespace: "test",
+				JobConfig: "jobconfig",
+				Before:    testutils.Mktimep("2022-04-02T00:00:00Z"),
+				Limit:     10,
+			},
+			wantQuery: "FROM furiko_job_history WHERE namespace = $1 AND job_config = $2 AND create_time < $3 " +
+				"ORDER BY create_time DESC, uid DESC LIMIT 10",
+			wantArgs: []driver.Value{"test", "jobconfig", testutils.Mktime("2022-04-02T00:00:00Z")},
+		},
+		{
+			name: "mysql",
+			spec: &configv1alpha1.ArchiveSpec{
+				Dialect: archive.DialectMySQL,
+				Table:   "history",
+			},
+			wantSchema: []string{
+				"INDEX history_namespace_job_config_create_time (namespace, job_config, create_time))",
+			},
+			wantUpsert: "ON DUPLICATE KEY UPDATE namespace = VALUES(namespace),",
+			query: archive.Query{
+				Name: "job",
+			},
+			wantQuery: "FROM history WHERE name = ? ORDER BY create_time DESC, uid DESC",
+			wantArgs:  []driver.Value{"job"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db, fakeDB := newFakeDatabase(t)
+			store, err := archive.NewStore(db, tt.spec)
+			if err != nil {
+				t.Fatalf("NewStore() error = %v", err)
+			}
+			defer store.Close()
+
+			// Create table.
+			assert.NoError(t, store.Init(ctx))
+			if assert.Len(t, fakeDB.statements, len(tt.wantSchema)) {
+				for i, want := range tt.wantSchema {
+					assert.Contains(t, fakeDB.statements[i], want)
+				}
+			}
+
+			// Write record.
+			record := newRecord()
+			assert.NoError(t, store.Archive(ctx, record))
+			assert.Contains(t, fakeDB.statements[len(fakeDB.statements)-1], tt.wantUpsert)
+
+			// Read back the written row.
+			records, err := store.List(ctx, tt.query)
+			assert.NoError(t, err)
+			assert.Len(t, fakeDB.queries, 1)
+			assert.Contains(t, fakeDB.queries[0], tt.wantQuery)
+			assert.Equal(t, tt.wantArgs, fakeDB.queryArgs[0])
+
+			want := newRecord()
+			want.ArchiveTime = testutils.Mktimep(archiveTime)
+			if !cmp.Equal([]*archive.Record{want}, records) {
+				t.Errorf("List() not equal\ndiff = %v", cmp.Diff([]*archive.Record{want}, records))
+			}
+		})
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *configv1alpha1.ArchiveSpec
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: &configv1alpha1.ArchiveSpec{
+				Dialect: archive.DialectPostgres,
+				Table:   "job_history_2022",
+			},
+		},
+		{
+			name: "unsupported dialect",
+			spec: &configv1alpha1.ArchiveSpec{
+				Dialect: "sqlite",
+			},
+			wantErr: "unsupported dialect sqlite, must be one of: postgres, mysql",
+		},
+		{
+			name: "invalid table name",
+			spec: &configv1alpha1.ArchiveSpec{
+				Dialect: archive.DialectMySQL,
+				Table:   "history; DROP TABLE users",
+			},
+			wantErr: "invalid table name: history; DROP TABLE users",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := newFakeDatabase(t)
+			defer db.Close()
+			_, err := archive.NewStore(db, tt.spec)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	// OutputStdout is the output that writes the audit log to stdout.
+	OutputStdout = "stdout"
+)
+
+// Record is a single entry in the audit log, which describes a state transition
+// of a Job or JobConfig.
+type Record struct {
+	// Time at which the transition was observed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Kind of the object, either Job or JobConfig.
+	Kind string `json:"kind"`
+
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       types.UID `json:"uid"`
+
+	// Name of the JobConfig that the Job belongs to, if any.
+	JobConfig string `json:"jobConfig,omitempty"`
+
+	// User or controller that caused the transition.
+	Actor string `json:"actor"`
+
+	// State or phase before and after the transition.
+	From string `json:"from,omitempty"`
+	To   string `json:"to"`
+
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	StartTime         *time.Time `json:"startTime,omitempty"`
+	FinishTime        *time.Time `json:"finishTime,omitempty"`
+}
+
+// Writer is an eventsink.Sink that writes a Record for each state transition
+// of a Job or JobConfig as a single line of JSON. Other Events are ignored.
+type Writer struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+}
+
+var _ eventsink.Sink = (*Writer)(nil)
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{encoder: json.NewEncoder(w)}
+}
+
+// Open returns a Writer for the given output, which is either "stdout" or the
+// path to a file that will be appended to. Defaults to stdout if empty.
+func Open(output string) (*Writer, error) {
+	if output == "" || output == OutputStdout {
+		return NewWriter(os.Stdout), nil
+	}
+	f, err := os.OpenFile(output, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open audit log file")
+	}
+	w := NewWriter(f)
+	w.closer = f
+	return w, nil
+}
+
+func (w *Writer) Name() string {
+	return "auditlog"
+}
+
+func (w *Writer) Write(_ context.Context, events []*eventsink.Event) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, event := range events {
+		record := NewRecord(event)
+		if record == nil {
+			continue
+		}
+		if err := w.encoder.Encode(record); err != nil {
+			return errors.Wrapf(err, "cannot write record for %v %v/%v", record.Kind, record.Namespace, record.Name)
+		}
+	}
+	return nil
+}
+
+// Close closes the underlying file, if any.
+func (w *Writer) Close() error {
+	if w.closer == nil {
+		return nil
+	}
+	return w.closer.Close()
+}
+
+// NewRecord returns a Record for the Event, or nil if it is not a state
+// transition of a Job or JobConfig.
+func NewRecord(event *eventsink.Event) *Record {
+	var record *Record
+	switch obj := event.Object.(type) {
+	case *execution.Job:
+		if event.Kind != eventsink.KindJob {
+			return nil
+		}
+		record = NewJobRecord(obj, execution.JobPhase(event.From), event.Actor)
+	case *execution.JobConfig:
+		if event.Kind != eventsink.KindJobConfig {
+			return nil
+		}
+		record = NewJobConfigRecord(obj, execution.JobConfigState(event.From), event.Actor)
+	default:
+		return nil
+	}
+	record.Timestamp = event.Timestamp
+	return record
+}
+
+// NewJobRecord returns a Record for the transition of a Job from the given
+// phase to its current phase.
+func NewJobRecord(rj *execution.Job, from execution.JobPhase, actor string) *Record {
+	record := &Record{
+		Timestamp:         ktime.Now().Time,
+		Kind:              execution.KindJob,
+		Namespace:         rj.GetNamespace(),
+		Name:              rj.GetName(),
+		UID:               rj.GetUID(),
+		JobConfig:         jobutil.GetJobConfigName(rj),
+		Actor:             actor,
+		From:              string(from),
+		To:                string(rj.Status.Phase),
+		CreationTimestamp: rj.GetCreationTimestamp().Time,
+	}
+	if startTime := rj.Status.StartTime; !startTime.IsZero() {
+		record.StartTime = &startTime.Time
+	}
+
+	condition := rj.Status.Condition
+	switch {
+	case condition.Finished != nil:
+		record.Reason = condition.Finished.Reason
+		if record.Reason == "" {
+			record.Reason = string(condition.Finished.Result)
+		}
+		record.Message = condition.Finished.Message
+		record.FinishTime = &condition.Finished.FinishedAt.Time
+	case condition.Waiting != nil:
+		record.Reason = condition.Waiting.Reason
+		record.Message = condition.Waiting.Message
+	case condition.Queueing != nil:
+		record.Reason = condition.Queueing.Reason
+		record.Message = condition.Queueing.Message
+	}
+
+	return record
+}
+
+// NewJobConfigRecord returns a Record for the transition of a JobConfig from
+// the given state to its current state.
+func NewJobConfigRecord(rjc *execution.JobConfig, from execution.JobConfigState, actor string) *Record {
+	record := &Record{
+		Timestamp:         ktime.Now().Time,
+		Kind:              execution.KindJobConfig,
+		Namespace:         rjc.GetNamespace(),
+		Name:              rjc.GetName(),
+		UID:               rjc.GetUID(),
+		Actor:             actor,
+		From:              string(from),
+		To:                string(rjc.Status.State),
+		CreationTimestamp: rjc.GetCreationTimestamp().Time,
+	}
+
+	// Transitions between Ready states are caused by changes to the schedule,
+	// otherwise they are caused by Jobs being queued, started or finished.
+	conditionType := execution.JobConfigConditionProgressing
+	if isReadyState(from) && isReadyState(rjc.Status.State) {
+		conditionType = execution.JobConfigConditionScheduleActive
+	}
+	if condition := meta.FindStatusCondition(rjc.Status.Conditions, conditionType); condition != nil {
+		record.Reason = condition.Reason
+		record.Message = condition.Message
+	}
+
+	return record
+}
+
+func isReadyState(state execution.JobConfigState) bool {
+	switch state {
+	case execution.JobConfigReady, execution.JobConfigReadyEnabled, execution.JobConfigReadyDisabled:
+		return true
+	}
+	return false
+}
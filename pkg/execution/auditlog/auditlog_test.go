@@ -0,0 +1,287 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auditlog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/auditlog"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	actor = "furiko-controller"
+)
+
+func TestWriter_Job(t *testing.T) {
+	now := testutils.Mkmtime("2022-04-01T04:00:00Z")
+	ktime.Clock = clock.NewFakeClock(now.Time)
+
+	createTime := testutils.Mkmtime("2022-04-01T03:59:00Z")
+	startTime := testutils.Mkmtimep("2022-04-01T03:59:01Z")
+
+	newJob := func(phase execution.JobPhase, condition execution.JobCondition) *execution.Job {
+		rj := &execution.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "job",
+				UID:               "uid",
+				CreationTimestamp: createTime,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: execution.GroupVersion.String(),
+						Kind:       execution.KindJobConfig,
+						Name:       "jobconfig",
+						UID:        "jobconfig-uid",
+						Controller: pointer.Bool(true),
+					},
+				},
+			},
+			Status: execution.JobStatus{
+				Phase:     phase,
+				Condition: condition,
+			},
+		}
+		if phase != execution.JobQueued {
+			rj.Status.StartTime = startTime
+		}
+		return rj
+	}
+
+	queued := newJob(execution.JobQueued, execution.JobCondition{
+		Queueing: &execution.JobConditionQueueing{
+			Reason:  "NotYetDue",
+			Message: "Job is queued",
+		},
+	})
+	createdByUser := queued.DeepCopy()
+	jobutil.SetCreatedBy(createdByUser, "user@example.com")
+	running := newJob(execution.JobRunning, execution.JobCondition{})
+	succeeded := newJob(execution.JobSucceeded, execution.JobCondition{
+		Finished: &execution.JobConditionFinished{
+			FinishedAt: now,
+			Result:     execution.JobResultSuccess,
+		},
+	})
+
+	tests := []struct {
+		name  string
+		rj    *execution.Job
+		newRj *execution.Job
+		want  *auditlog.Record
+	}{
+		{
+			name:  "phase not changed",
+			rj:    running,
+			newRj: running,
+		},
+		{
+			name:  "created without known user",
+			rj:    &execution.Job{},
+			newRj: queued,
+			want: &auditlog.Record{
+				Timestamp:         now.Time,
+				Kind:              "Job",
+				Namespace:         "default",
+				Name:              "job",
+				UID:               "uid",
+				JobConfig:         "jobconfig",
+				Actor:             actor,
+				To:                "Queued",
+				Reason:            "NotYetDue",
+				Message:           "Job is queued",
+				CreationTimestamp: createTime.Time,
+			},
+		},
+		{
+			name:  "created by user",
+			rj:    &execution.Job{},
+			newRj: createdByUser,
+			want: &auditlog.Record{
+				Timestamp:         now.Time,
+				Kind:              "Job",
+				Namespace:         "default",
+				Name:              "job",
+				UID:               "uid",
+				JobConfig:         "jobconfig",
+				Actor:             "user@example.com",
+				To:                "Queued",
+				Reason:            "NotYetDue",
+				Message:           "Job is queued",
+				CreationTimestamp: createTime.Time,
+			},
+		},
+		{
+			name:  "finished",
+			rj:    running,
+			newRj: succeeded,
+			want: &auditlog.Record{
+				Timestamp:         now.Time,
+				Kind:              "Job",
+				Namespace:         "default",
+				Name:              "job",
+				UID:               "uid",
+				JobConfig:         "jobconfig",
+				Actor:             actor,
+				From:              "Running",
+				To:                "Succeeded",
+				Reason:            "Success",
+				CreationTimestamp: createTime.Time,
+				StartTime:         &startTime.Time,
+				FinishTime:        &now.Time,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			events := &eventRecorder{}
+			eventsink.RecordJobTransition(events, tt.rj, tt.newRj, actor)
+			got := writeRecord(t, events.events)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("Write() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestWriter_JobConfig(t *testing.T) {
+	now := testutils.Mkmtime("2022-04-01T04:00:00Z")
+	ktime.Clock = clock.NewFakeClock(now.Time)
+
+	createTime := testutils.Mkmtime("2022-04-01T03:00:00Z")
+	newJobConfig := func(state execution.JobConfigState) *execution.JobConfig {
+		return &execution.JobConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "jobconfig",
+				UID:               "uid",
+				CreationTimestamp: createTime,
+			},
+			Status: execution.JobConfigStatus{
+				State: state,
+				Conditions: []metav1.Condition{
+					{
+						Type:    execution.JobConfigConditionProgressing,
+						Reason:  "NoActiveJobs",
+						Message: "Progressing message",
+					},
+					{
+						Type:   execution.JobConfigConditionScheduleActive,
+						Reason: "ScheduleDisabled",
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		rjc    *execution.JobConfig
+		newRjc *execution.JobConfig
+		want   *auditlog.Record
+	}{
+		{
+			name:   "state not changed",
+			rjc:    newJobConfig(execution.JobConfigReady),
+			newRjc: newJobConfig(execution.JobConfigReady),
+		},
+		{
+			name:   "finished executing",
+			rjc:    newJobConfig(execution.JobConfigExecuting),
+			newRjc: newJobConfig(execution.JobConfigReady),
+			want: &auditlog.Record{
+				Timestamp:         now.Time,
+				Kind:              "JobConfig",
+				Namespace:         "default",
+				Name:              "jobconfig",
+				UID:               "uid",
+				Actor:             actor,
+				From:              "Executing",
+				To:                "Ready",
+				Reason:            "NoActiveJobs",
+				Message:           "Progressing message",
+				CreationTimestamp: createTime.Time,
+			},
+		},
+		{
+			name:   "schedule disabled",
+			rjc:    newJobConfig(execution.JobConfigReadyEnabled),
+			newRjc: newJobConfig(execution.JobConfigReadyDisabled),
+			want: &auditlog.Record{
+				Timestamp:         now.Time,
+				Kind:              "JobConfig",
+				Namespace:         "default",
+				Name:              "jobconfig",
+				UID:               "uid",
+				Actor:             actor,
+				From:              "ReadyEnabled",
+				To:                "ReadyDisabled",
+				Reason:            "ScheduleDisabled",
+				CreationTimestamp: createTime.Time,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			events := &eventRecorder{}
+			eventsink.RecordJobConfigTransition(events, tt.rjc, tt.newRjc, actor)
+			got := writeRecord(t, events.events)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("Write() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+type eventRecorder struct {
+	events []*eventsink.Event
+}
+
+func (r *eventRecorder) Record(event *eventsink.Event) {
+	r.events = append(r.events, event)
+}
+
+// writeRecord writes the events to a Writer and reads back a single record, or
+// nil if nothing was written.
+func writeRecord(t *testing.T, events []*eventsink.Event) *auditlog.Record {
+	buf := &bytes.Buffer{}
+	if err := auditlog.NewWriter(buf).Write(context.Background(), events); err != nil {
+		t.Fatalf("cannot write events: %v", err)
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	record := &auditlog.Record{}
+	if err := json.Unmarshal(buf.Bytes(), record); err != nil {
+		t.Fatalf("cannot unmarshal record: %v", err)
+	}
+	return record
+}
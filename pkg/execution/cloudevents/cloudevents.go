@@ -0,0 +1,207 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	// SpecVersion is the version of the CloudEvents specification that events
+	// conform to.
+	SpecVersion = "1.0"
+
+	// ContentType is the content type of events sent in structured content mode.
+	ContentType = "application/cloudevents+json"
+
+	// DefaultSource is the default value of the source attribute of events.
+	DefaultSource = "furiko.io/execution-controller"
+)
+
+// Event types that are emitted.
+const (
+	// TypeJobStarted is emitted when a Job is started.
+	TypeJobStarted = "io.furiko.execution.job.started"
+
+	// TypeJobFinished is emitted when a Job has finished successfully.
+	TypeJobFinished = "io.furiko.execution.job.finished"
+
+	// TypeJobFailed is emitted when a Job has finished unsuccessfully.
+	TypeJobFailed = "io.furiko.execution.job.failed"
+
+	// TypeScheduleMissed is emitted when a JobConfig's schedule was skipped.
+	TypeScheduleMissed = "io.furiko.execution.jobconfig.schedulemissed"
+)
+
+// Event is a CloudEvent in structured content mode.
+// See https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md.
+type Event struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject,omitempty"`
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// JobData is the data of Job events.
+type JobData struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       types.UID `json:"uid"`
+
+	// Name of the JobConfig that the Job belongs to, if any.
+	JobConfig string `json:"jobConfig,omitempty"`
+
+	Phase   execution.JobPhase  `json:"phase"`
+	Result  execution.JobResult `json:"result,omitempty"`
+	Reason  string              `json:"reason,omitempty"`
+	Message string              `json:"message,omitempty"`
+
+	// Option values that the Job was created with, and the substitutions that
+	// were evaluated from them.
+	OptionValues  json.RawMessage   `json:"optionValues,omitempty"`
+	Substitutions map[string]string `json:"substitutions,omitempty"`
+
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	StartTime         *time.Time `json:"startTime,omitempty"`
+	FinishTime        *time.Time `json:"finishTime,omitempty"`
+}
+
+// ScheduleData is the data of JobConfig schedule events.
+type ScheduleData struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       types.UID `json:"uid"`
+
+	ScheduleTime time.Time `json:"scheduleTime"`
+	Message      string    `json:"message,omitempty"`
+}
+
+// NewEvent returns a new Event with a unique ID.
+func NewEvent(eventType, subject string, data interface{}) *Event {
+	return &Event{
+		SpecVersion:     SpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          DefaultSource,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            ktime.Now().Time,
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// NewEvents returns the Events to be sent for an Event from the event sink.
+// Returns nil if no events should be sent.
+func NewEvents(event *eventsink.Event) []*Event {
+	var events []*Event
+	switch event.Kind {
+	case eventsink.KindJob:
+		if rj, ok := event.Object.(*execution.Job); ok {
+			events = newJobEvents(rj, execution.JobPhase(event.From))
+		}
+	case eventsink.KindScheduleMissed:
+		if rjc, ok := event.Object.(*execution.JobConfig); ok && event.ScheduleTime != nil {
+			events = append(events, NewScheduleMissedEvent(rjc, *event.ScheduleTime, event.Message))
+		}
+	}
+
+	// Use the time that the transition was observed.
+	for _, e := range events {
+		e.Time = event.Timestamp
+	}
+
+	return events
+}
+
+// newJobEvents returns the Events for the transition of a Job from the given
+// phase to its current phase.
+func newJobEvents(rj *execution.Job, from execution.JobPhase) []*Event {
+	var events []*Event
+	subject := fmt.Sprintf("%v/%v", rj.GetNamespace(), rj.GetName())
+
+	if !isStartedPhase(from) && !rj.Status.StartTime.IsZero() {
+		events = append(events, NewEvent(TypeJobStarted, subject, NewJobData(rj)))
+	}
+
+	if finished := rj.Status.Condition.Finished; !from.IsTerminal() && finished != nil {
+		eventType := TypeJobFinished
+		if finished.Result.IsFailed() {
+			eventType = TypeJobFailed
+		}
+		events = append(events, NewEvent(eventType, subject, NewJobData(rj)))
+	}
+
+	return events
+}
+
+// isStartedPhase returns true if a Job in the given phase was already started.
+func isStartedPhase(phase execution.JobPhase) bool {
+	return phase != "" && phase != execution.JobQueued
+}
+
+// NewJobData returns the JobData for a Job.
+func NewJobData(rj *execution.Job) *JobData {
+	data := &JobData{
+		Namespace:         rj.GetNamespace(),
+		Name:              rj.GetName(),
+		UID:               rj.GetUID(),
+		JobConfig:         jobutil.GetJobConfigName(rj),
+		Phase:             rj.Status.Phase,
+		Substitutions:     rj.Spec.Substitutions,
+		CreationTimestamp: rj.GetCreationTimestamp().Time,
+	}
+	if optionValues := rj.Spec.OptionValues; optionValues != "" && json.Valid([]byte(optionValues)) {
+		data.OptionValues = json.RawMessage(optionValues)
+	}
+	if startTime := rj.Status.StartTime; !startTime.IsZero() {
+		data.StartTime = &startTime.Time
+	}
+	if finished := rj.Status.Condition.Finished; finished != nil {
+		data.Result = finished.Result
+		data.Reason = finished.Reason
+		data.Message = finished.Message
+		data.FinishTime = &finished.FinishedAt.Time
+	}
+	return data
+}
+
+// NewScheduleMissedEvent returns an Event for a JobConfig's schedule that was
+// skipped.
+func NewScheduleMissedEvent(rjc *execution.JobConfig, scheduleTime time.Time, message string) *Event {
+	subject := fmt.Sprintf("%v/%v", rjc.GetNamespace(), rjc.GetName())
+	return NewEvent(TypeScheduleMissed, subject, &ScheduleData{
+		Namespace:    rjc.GetNamespace(),
+		Name:         rjc.GetName(),
+		UID:          rjc.GetUID(),
+		ScheduleTime: scheduleTime,
+		Message:      message,
+	})
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloudevents_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/cloudevents"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+var (
+	createTime = testutils.Mkmtime("2022-04-01T03:59:00Z")
+	startTime  = testutils.Mkmtimep("2022-04-01T04:00:00Z")
+	finishTime = testutils.Mkmtime("2022-04-01T04:01:00Z")
+)
+
+func newJob(started bool, result execution.JobResult) *execution.Job {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "job",
+			UID:               "uid",
+			CreationTimestamp: createTime,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: execution.GroupVersion.String(),
+					Kind:       execution.KindJobConfig,
+					Name:       "jobconfig",
+					UID:        "jobconfig-uid",
+					Controller: pointer.Bool(true),
+				},
+			},
+		},
+		Spec: execution.JobSpec{
+			OptionValues:  `{"foo":"bar"}`,
+			Substitutions: map[string]string{"option.foo": "bar"},
+		},
+		Status: execution.JobStatus{
+			Phase: execution.JobQueued,
+		},
+	}
+	if started {
+		rj.Status.Phase = execution.JobRunning
+		rj.Status.StartTime = startTime
+	}
+	if result != "" {
+		rj.Status.Phase = execution.JobSucceeded
+		if result.IsFailed() {
+			rj.Status.Phase = execution.JobRetryLimitExceeded
+		}
+		rj.Status.Condition.Finished = &execution.JobConditionFinished{
+			FinishedAt: finishTime,
+			Result:     result,
+		}
+	}
+	return rj
+}
+
+func TestNewJobEvents(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(finishTime.Time)
+
+	tests := []struct {
+		name      string
+		rj        *execution.Job
+		newRj     *execution.Job
+		wantTypes []string
+	}{
+		{
+			name:  "no transition",
+			rj:    newJob(true, ""),
+			newRj: newJob(true, ""),
+		},
+		{
+			name:      "started",
+			rj:        newJob(false, ""),
+			newRj:     newJob(true, ""),
+			wantTypes: []string{cloudevents.TypeJobStarted},
+		},
+		{
+			name:      "finished",
+			rj:        newJob(true, ""),
+			newRj:     newJob(true, execution.JobResultSuccess),
+			wantTypes: []string{cloudevents.TypeJobFinished},
+		},
+		{
+			name:      "failed",
+			rj:        newJob(true, ""),
+			newRj:     newJob(true, execution.JobResultTaskFailed),
+			wantTypes: []string{cloudevents.TypeJobFailed},
+		},
+		{
+			name:      "started and finished",
+			rj:        newJob(false, ""),
+			newRj:     newJob(true, execution.JobResultSuccess),
+			wantTypes: []string{cloudevents.TypeJobStarted, cloudevents.TypeJobFinished},
+		},
+		{
+			name:  "already finished",
+			rj:    newJob(true, execution.JobResultSuccess),
+			newRj: newJob(true, execution.JobResultSuccess),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			events := newJobEvents(tt.rj, tt.newRj)
+			var types []string
+			for _, event := range events {
+				types = append(types, event.Type)
+				assert.Equal(t, cloudevents.SpecVersion, event.SpecVersion)
+				assert.NotEmpty(t, event.ID)
+				assert.Equal(t, "default/job", event.Subject)
+				assert.Equal(t, finishTime.Time, event.Time)
+			}
+			assert.Equal(t, tt.wantTypes, types)
+		})
+	}
+}
+
+func TestNewEvents_ScheduleMissed(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(finishTime.Time)
+	rjc := &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "jobconfig",
+			UID:       "uid",
+		},
+	}
+	events := cloudevents.NewEvents(eventsink.NewScheduleMissedEvent(rjc, startTime.Time, "message"))
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, cloudevents.TypeScheduleMissed, events[0].Type)
+		assert.Equal(t, "default/jobconfig", events[0].Subject)
+		assert.Equal(t, &cloudevents.ScheduleData{
+			Namespace:    "default",
+			Name:         "jobconfig",
+			UID:          "uid",
+			ScheduleTime: startTime.Time,
+			Message:      "message",
+		}, events[0].Data)
+	}
+
+	// Task transitions do not have any CloudEvents.
+	assert.Empty(t, cloudevents.NewEvents(&eventsink.Event{Kind: eventsink.KindTask}))
+}
+
+func TestNewJobData(t *testing.T) {
+	data := cloudevents.NewJobData(newJob(true, execution.JobResultTaskFailed))
+	assert.Equal(t, &cloudevents.JobData{
+		Namespace:         "default",
+		Name:              "job",
+		UID:               "uid",
+		JobConfig:         "jobconfig",
+		Phase:             execution.JobRetryLimitExceeded,
+		Result:            execution.JobResultTaskFailed,
+		OptionValues:      json.RawMessage(`{"foo":"bar"}`),
+		Substitutions:     map[string]string{"option.foo": "bar"},
+		CreationTimestamp: createTime.Time,
+		StartTime:         &startTime.Time,
+		FinishTime:        &finishTime.Time,
+	}, data)
+}
+
+// newJobEvents returns the CloudEvents for the transition of a Job from rj to
+// newRj.
+func newJobEvents(rj, newRj *execution.Job) []*cloudevents.Event {
+	var events []*cloudevents.Event
+	for _, event := range eventsink.NewJobEvents(rj, newRj) {
+		events = append(events, cloudevents.NewEvents(event)...)
+	}
+	return events
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloudevents
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	promNamespace = "furiko"
+)
+
+const (
+	resultSent   = "sent"
+	resultFailed = "failed"
+)
+
+var (
+	eventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "cloudevents_total",
+			Help:      "Total number of CloudEvents by type and result (sent or failed)",
+		},
+		[]string{"type", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		eventsTotal,
+	)
+}
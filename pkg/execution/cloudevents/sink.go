@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+)
+
+const (
+	// defaultTimeout is the default timeout of each HTTP request.
+	defaultTimeout = 10 * time.Second
+)
+
+// Configs knows how to load the dynamic config for CloudEvents.
+type Configs interface {
+	Jobs() (*configv1alpha1.JobExecutionConfig, error)
+}
+
+// HTTPSink is an eventsink.Sink that sends CloudEvents to the target specified
+// in the dynamic config. No CloudEvents are sent if no target is specified.
+type HTTPSink struct {
+	configs Configs
+	client  *http.Client
+}
+
+var _ eventsink.Sink = (*HTTPSink)(nil)
+
+// NewHTTPSink returns a new HTTPSink.
+func NewHTTPSink(configs Configs) *HTTPSink {
+	return &HTTPSink{
+		configs: configs,
+		client:  &http.Client{},
+	}
+}
+
+func (s *HTTPSink) Name() string {
+	return "cloudevents"
+}
+
+// Write sends the CloudEvents for each of the Events in order.
+func (s *HTTPSink) Write(ctx context.Context, events []*eventsink.Event) error {
+	cfg, err := s.configs.Jobs()
+	if err != nil {
+		return errors.Wrapf(err, "cannot load controller configuration")
+	}
+	spec := cfg.CloudEvents
+	if spec == nil || spec.Target == "" {
+		return nil
+	}
+
+	var errs []error
+	for _, event := range events {
+		for _, cloudEvent := range NewEvents(event) {
+			if !isTypeEnabled(spec, cloudEvent.Type) {
+				continue
+			}
+			if err := s.send(ctx, spec, cloudEvent); err != nil {
+				eventsTotal.WithLabelValues(cloudEvent.Type, resultFailed).Inc()
+				errs = append(errs, errors.Wrapf(err, "cannot send %v event for %v", cloudEvent.Type, cloudEvent.Subject))
+				continue
+			}
+			eventsTotal.WithLabelValues(cloudEvent.Type, resultSent).Inc()
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s *HTTPSink) send(ctx context.Context, spec *configv1alpha1.CloudEventsSpec, event *Event) error {
+	timeout := defaultTimeout
+	if spec.TimeoutSeconds != nil && *spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	newEvent := *event
+	if spec.Source != "" {
+		newEvent.Source = spec.Source
+	}
+	body, err := json.Marshal(newEvent)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spec.Target, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", ContentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+	return nil
+}
+
+func isTypeEnabled(spec *configv1alpha1.CloudEventsSpec, eventType string) bool {
+	if len(spec.Types) == 0 {
+		return true
+	}
+	for _, t := range spec.Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
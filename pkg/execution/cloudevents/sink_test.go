@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloudevents_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/cloudevents"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+)
+
+type fakeConfigs struct {
+	cfg *configv1alpha1.JobExecutionConfig
+}
+
+func (c *fakeConfigs) Jobs() (*configv1alpha1.JobExecutionConfig, error) {
+	return c.cfg, nil
+}
+
+func TestHTTPSink(t *testing.T) {
+	var received []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, cloudevents.ContentType, r.Header.Get("Content-Type"))
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		event := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal(body, &event))
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	sink := cloudevents.NewHTTPSink(&fakeConfigs{
+		cfg: &configv1alpha1.JobExecutionConfig{
+			CloudEvents: &configv1alpha1.CloudEventsSpec{
+				Target: server.URL,
+				Source: "test-source",
+				Types:  []string{cloudevents.TypeJobStarted, cloudevents.TypeJobFailed},
+			},
+		},
+	})
+
+	// Event types which are not enabled should not be sent.
+	var events []*eventsink.Event
+	events = append(events, eventsink.NewJobEvents(newJob(false, ""), newJob(true, execution.JobResultSuccess))...)
+	events = append(events, eventsink.NewJobEvents(newJob(true, ""), newJob(true, execution.JobResultTaskFailed))...)
+	assert.NoError(t, sink.Write(context.Background(), events))
+
+	var types []interface{}
+	for _, event := range received {
+		types = append(types, event["type"])
+		assert.Equal(t, "test-source", event["source"])
+		assert.Equal(t, "1.0", event["specversion"])
+		assert.Equal(t, "default/job", event["subject"])
+	}
+	assert.Equal(t, []interface{}{cloudevents.TypeJobStarted, cloudevents.TypeJobFailed}, types)
+}
+
+func TestHTTPSink_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := cloudevents.NewHTTPSink(&fakeConfigs{
+		cfg: &configv1alpha1.JobExecutionConfig{
+			CloudEvents: &configv1alpha1.CloudEventsSpec{Target: server.URL},
+		},
+	})
+	events := eventsink.NewJobEvents(newJob(true, ""), newJob(true, execution.JobResultTaskFailed))
+	assert.Error(t, sink.Write(context.Background(), events))
+}
+
+func TestHTTPSink_NoTarget(t *testing.T) {
+	sink := cloudevents.NewHTTPSink(&fakeConfigs{cfg: &configv1alpha1.JobExecutionConfig{}})
+	events := eventsink.NewJobEvents(newJob(false, ""), newJob(true, ""))
+	assert.NoError(t, sink.Write(context.Background(), events))
+}
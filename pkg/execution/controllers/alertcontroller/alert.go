@@ -0,0 +1,238 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alertcontroller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	defaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+	defaultOpsgenieAPIURL     = "https://api.opsgenie.com"
+
+	alertSource = "furiko"
+
+	// maxOpsgenieMessageLength is the maximum length of the message of an Opsgenie
+	// alert.
+	maxOpsgenieMessageLength = 130
+)
+
+// Incident describes an incident that is opened for a JobConfig.
+type Incident struct {
+	// DedupKey uniquely identifies the incident, such that subsequent requests
+	// with the same key refer to the same incident.
+	DedupKey string
+
+	// Summary is a short description of the incident.
+	Summary string
+
+	// Details contains additional information about the incident.
+	Details map[string]string
+
+	// URL links to the most recent Job, if any.
+	URL string
+}
+
+// NewIncident returns the Incident for the alert of a JobConfig at the given index.
+func NewIncident(rjc *execution.JobConfig, idx int, jobURLTemplate string) *Incident {
+	incident := &Incident{
+		DedupKey: GetDedupKey(rjc, idx),
+		Summary: fmt.Sprintf("JobConfig %v/%v failed %v consecutive times",
+			rjc.Namespace, rjc.Name, rjc.Status.ConsecutiveFailures),
+		Details: map[string]string{
+			"namespace":           rjc.Namespace,
+			"jobConfig":           rjc.Name,
+			"consecutiveFailures": fmt.Sprintf("%v", rjc.Status.ConsecutiveFailures),
+		},
+	}
+
+	if lastJob := rjc.Status.LastJob; lastJob != nil {
+		incident.Details["lastJob"] = lastJob.Name
+		if lastJob.Result != "" {
+			incident.Details["lastJobResult"] = string(lastJob.Result)
+		}
+		if jobURLTemplate != "" {
+			incident.URL = strings.NewReplacer(
+				"${job.namespace}", rjc.Namespace,
+				"${job.name}", lastJob.Name,
+				"${jobconfig.name}", rjc.Name,
+			).Replace(jobURLTemplate)
+		}
+	}
+
+	return incident
+}
+
+// GetDedupKey returns the deduplication key of the incident for the alert of a
+// JobConfig at the given index.
+func GetDedupKey(rjc *execution.JobConfig, idx int) string {
+	return fmt.Sprintf("%v:%v:%v:%v", alertSource, rjc.Namespace, rjc.Name, idx)
+}
+
+// IncidentClient opens and resolves incidents in an incident management service.
+type IncidentClient interface {
+	// Trigger opens the incident if it is not already open.
+	Trigger(ctx context.Context, incident *Incident) error
+
+	// Resolve resolves the incident with the given deduplication key.
+	Resolve(ctx context.Context, dedupKey string) error
+}
+
+// PagerDutyClient opens incidents using the PagerDuty Events API v2.
+type PagerDutyClient struct {
+	client     *http.Client
+	eventsURL  string
+	routingKey string
+}
+
+var _ IncidentClient = (*PagerDutyClient)(nil)
+
+func NewPagerDutyClient(client *http.Client, eventsURL, routingKey string) *PagerDutyClient {
+	if eventsURL == "" {
+		eventsURL = defaultPagerDutyEventsURL
+	}
+	return &PagerDutyClient{
+		client:     client,
+		eventsURL:  eventsURL,
+		routingKey: routingKey,
+	}
+}
+
+func (c *PagerDutyClient) Trigger(ctx context.Context, incident *Incident) error {
+	event := map[string]interface{}{
+		"routing_key":  c.routingKey,
+		"event_action": "trigger",
+		"dedup_key":    incident.DedupKey,
+		"payload": map[string]interface{}{
+			"summary":        incident.Summary,
+			"source":         alertSource,
+			"severity":       "error",
+			"component":      incident.Details["jobConfig"],
+			"group":          incident.Details["namespace"],
+			"custom_details": incident.Details,
+		},
+	}
+	if incident.URL != "" {
+		event["links"] = []map[string]string{
+			{"href": incident.URL, "text": "View Job"},
+		}
+	}
+	return postJSON(ctx, c.client, c.eventsURL, nil, event)
+}
+
+func (c *PagerDutyClient) Resolve(ctx context.Context, dedupKey string) error {
+	event := map[string]interface{}{
+		"routing_key":  c.routingKey,
+		"event_action": "resolve",
+		"dedup_key":    dedupKey,
+	}
+	return postJSON(ctx, c.client, c.eventsURL, nil, event)
+}
+
+// OpsgenieClient opens alerts using the Opsgenie Alert API.
+type OpsgenieClient struct {
+	client *http.Client
+	apiURL string
+	apiKey string
+}
+
+var _ IncidentClient = (*OpsgenieClient)(nil)
+
+func NewOpsgenieClient(client *http.Client, apiURL, apiKey string) *OpsgenieClient {
+	if apiURL == "" {
+		apiURL = defaultOpsgenieAPIURL
+	}
+	return &OpsgenieClient{
+		client: client,
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		apiKey: apiKey,
+	}
+}
+
+func (c *OpsgenieClient) Trigger(ctx context.Context, incident *Incident) error {
+	message := incident.Summary
+	if len(message) > maxOpsgenieMessageLength {
+		message = message[:maxOpsgenieMessageLength]
+	}
+	alert := map[string]interface{}{
+		"message":     message,
+		"alias":       incident.DedupKey,
+		"description": incident.Summary,
+		"details":     incident.Details,
+		"source":      alertSource,
+	}
+	if incident.URL != "" {
+		alert["description"] = fmt.Sprintf("%v\n\nView Job: %v", incident.Summary, incident.URL)
+	}
+	return postJSON(ctx, c.client, c.apiURL+"/v2/alerts", c.headers(), alert)
+}
+
+func (c *OpsgenieClient) Resolve(ctx context.Context, dedupKey string) error {
+	u := fmt.Sprintf("%v/v2/alerts/%v/close?identifierType=alias", c.apiURL, url.PathEscape(dedupKey))
+	body := map[string]interface{}{
+		"source": alertSource,
+		"note":   "A subsequent Job finished successfully",
+	}
+	return postJSON(ctx, c.client, u, c.headers(), body)
+}
+
+func (c *OpsgenieClient) headers() map[string]string {
+	return map[string]string{
+		"Authorization": "GenieKey " + c.apiKey,
+	}
+}
+
+// postJSON posts the body as JSON to the URL, and returns an error if the
+// response does not have a 2xx status code.
+func postJSON(ctx context.Context, client *http.Client, u string, headers map[string]string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal body")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "cannot send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("request returned status %v: %v", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alertcontroller
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// InformerWorker receives events from the informer and enqueues work to be done
+// for the controller.
+type InformerWorker struct {
+	*Context
+}
+
+func NewInformerWorker(ctrlContext *Context) *InformerWorker {
+	w := &InformerWorker{
+		Context: ctrlContext,
+	}
+
+	// Add event handler for JobConfigs.
+	// The number of consecutive failures is updated in the JobConfig's status.
+	w.jobconfigInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: w.enqueueObject,
+		UpdateFunc: func(_, newObj interface{}) {
+			w.enqueueObject(newObj)
+		},
+	})
+
+	return w
+}
+
+func (w *InformerWorker) WorkerName() string {
+	return fmt.Sprintf("%v.Informer", controllerName)
+}
+
+// enqueueObject enqueues an object to the workqueue.
+func (w *InformerWorker) enqueueObject(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.ErrorS(err, "alertcontroller: keyfunc error", "worker", w.WorkerName(), "obj", obj)
+		return
+	}
+	w.queue.Add(key)
+}
@@ -0,0 +1,271 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alertcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	utiltrace "k8s.io/utils/trace"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+)
+
+type Reconciler struct {
+	*Context
+	concurrency *configv1alpha1.Concurrency
+}
+
+func NewReconciler(ctrlContext *Context, concurrency *configv1alpha1.Concurrency) *Reconciler {
+	return &Reconciler{
+		Context:     ctrlContext,
+		concurrency: concurrency,
+	}
+}
+
+func (w *Reconciler) Name() string {
+	return fmt.Sprintf("%v.Reconciler", controllerName)
+}
+
+func (w *Reconciler) Concurrency() int {
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "alertcontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.Alert
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
+}
+
+func (w *Reconciler) MaxRequeues() int {
+	return -1
+}
+
+func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int) error {
+	trace := utiltrace.New(
+		"alert_sync",
+		utiltrace.Field{Key: "namespace", Value: namespace},
+		utiltrace.Field{Key: "name", Value: name},
+	)
+	defer trace.LogIfLong(500 * time.Millisecond)
+
+	rjc, err := w.jobconfigInformer.Lister().JobConfigs(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get job config")
+	}
+
+	annotation, hasAnnotation := rjc.Annotations[jobconfig.AnnotationKeyAlertsOpen]
+	if len(rjc.Spec.Alerts) == 0 && !hasAnnotation {
+		return nil
+	}
+
+	cfg, err := w.Configs().JobsForNamespace(namespace)
+	if err != nil {
+		return errors.Wrapf(err, "cannot load job execution config")
+	}
+	spec := cfg.Alerting
+	if spec == nil {
+		spec = &configv1alpha1.AlertingSpec{}
+	}
+
+	timeout := defaultTimeout
+	if spec.TimeoutSeconds != nil && *spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+
+	open := parseOpenAlerts(annotation)
+	newOpen := make(map[int]bool, len(open))
+	var errs []error
+
+	for i, alert := range rjc.Spec.Alerts {
+		threshold := alert.ConsecutiveFailures
+		if threshold <= 0 {
+			threshold = 1
+		}
+
+		var action string
+		switch {
+		case !open[i] && rjc.Status.ConsecutiveFailures >= threshold:
+			action = "trigger"
+		case open[i] && rjc.Status.ConsecutiveFailures == 0:
+			action = "resolve"
+		default:
+			newOpen[i] = open[i]
+			continue
+		}
+
+		if err := w.sendAlert(ctx, rjc, i, alert, action, spec, timeout); err != nil {
+			w.recorder.Eventf(rjc, corev1.EventTypeWarning, "AlertFailed",
+				"Cannot %v %v incident: %v", action, alert.Type, err)
+			errs = append(errs, errors.Wrapf(err, "cannot %v alert %v", action, i))
+			newOpen[i] = open[i]
+			continue
+		}
+
+		klog.V(3).InfoS("alertcontroller: sent alert",
+			"worker", w.Name(),
+			"namespace", rjc.GetNamespace(),
+			"name", rjc.GetName(),
+			"type", alert.Type,
+			"action", action,
+		)
+
+		if action == "trigger" {
+			w.recorder.Eventf(rjc, corev1.EventTypeWarning, "AlertTriggered",
+				"Opened %v incident after %v consecutive failures", alert.Type, rjc.Status.ConsecutiveFailures)
+			newOpen[i] = true
+		} else {
+			w.recorder.Eventf(rjc, corev1.EventTypeNormal, "AlertResolved",
+				"Resolved %v incident", alert.Type)
+		}
+	}
+	trace.Step("Send alerts done")
+
+	// Alerts which were removed from the JobConfig are no longer tracked, since
+	// they can no longer be resolved.
+	if newAnnotation := formatOpenAlerts(newOpen); newAnnotation != annotation || hasAnnotation != (newAnnotation != "") {
+		if err := w.recordOpen(ctx, rjc, newAnnotation); err != nil {
+			errs = append(errs, errors.Wrapf(err, "cannot record open alerts"))
+		}
+		trace.Step("Update job config annotations done")
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// sendAlert triggers or resolves the incident for a single alert.
+func (w *Reconciler) sendAlert(
+	ctx context.Context,
+	rjc *execution.JobConfig,
+	idx int,
+	alert execution.AlertSpec,
+	action string,
+	spec *configv1alpha1.AlertingSpec,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	key, err := w.getRoutingKey(ctx, rjc.Namespace, alert.RoutingKeySecretRef)
+	if err != nil {
+		return err
+	}
+
+	var client IncidentClient
+	switch alert.Type {
+	case execution.AlertTypePagerDuty:
+		client = NewPagerDutyClient(w.httpClient, spec.PagerDutyEventsURL, key)
+	case execution.AlertTypeOpsgenie:
+		client = NewOpsgenieClient(w.httpClient, spec.OpsgenieAPIURL, key)
+	default:
+		return fmt.Errorf("unsupported alert type: %v", alert.Type)
+	}
+
+	if action == "trigger" {
+		return client.Trigger(ctx, NewIncident(rjc, idx, spec.JobURLTemplate))
+	}
+	return client.Resolve(ctx, GetDedupKey(rjc, idx))
+}
+
+// getRoutingKey reads the routing key from the referenced Secret key.
+func (w *Reconciler) getRoutingKey(ctx context.Context, namespace string, ref corev1.SecretKeySelector) (string, error) {
+	secret, err := w.Clientsets().Kubernetes().CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot get secret %v", ref.Name)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %v not found in secret %v", ref.Key, ref.Name)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// recordOpen updates the annotation of the JobConfig with the set of alerts
+// that have an open incident, removing the annotation if there are none.
+func (w *Reconciler) recordOpen(ctx context.Context, rjc *execution.JobConfig, value string) error {
+	var annotation interface{}
+	if value != "" {
+		annotation = value
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				jobconfig.AnnotationKeyAlertsOpen: annotation,
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal patch")
+	}
+	if _, err := w.Clientsets().Furiko().ExecutionV1alpha1().JobConfigs(rjc.Namespace).
+		Patch(ctx, rjc.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "cannot update job config")
+	}
+	return nil
+}
+
+// parseOpenAlerts parses the value of AnnotationKeyAlertsOpen.
+func parseOpenAlerts(value string) map[int]bool {
+	open := make(map[int]bool)
+	for _, item := range strings.Split(value, ",") {
+		if idx, err := strconv.Atoi(item); err == nil {
+			open[idx] = true
+		}
+	}
+	return open
+}
+
+// formatOpenAlerts formats a set of alert indexes as the value of
+// AnnotationKeyAlertsOpen.
+func formatOpenAlerts(open map[int]bool) string {
+	idxs := make([]int, 0, len(open))
+	for idx, ok := range open {
+		if ok {
+			idxs = append(idxs, idx)
+		}
+	}
+	sort.Ints(idxs)
+	items := make([]string, 0, len(idxs))
+	for _, idx := range idxs {
+		items = append(items, strconv.Itoa(idx))
+	}
+	return strings.Join(items, ",")
+}
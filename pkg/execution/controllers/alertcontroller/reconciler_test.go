@@ -0,0 +1,338 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alertcontroller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/alertcontroller"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
+)
+
+const (
+	testNamespace  = "test"
+	jobConfigUID   = "5c9e3a1b-8d2f-4e6a-b7c1-0d3e5f7a9b21"
+	annotationOpen = `{"metadata":{"annotations":{"execution.furiko.io/alerts-open":`
+)
+
+var (
+	jobConfig = &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jobconfig",
+			Namespace: testNamespace,
+			UID:       jobConfigUID,
+		},
+		Spec: execution.JobConfigSpec{
+			Alerts: []execution.AlertSpec{
+				{
+					Type: execution.AlertTypePagerDuty,
+					RoutingKeySecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "pagerduty"},
+						Key:                  "key",
+					},
+					ConsecutiveFailures: 2,
+				},
+				{
+					Type: execution.AlertTypeOpsgenie,
+					RoutingKeySecretRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "opsgenie"},
+						Key:                  "key",
+					},
+					ConsecutiveFailures: 3,
+				},
+			},
+		},
+	}
+
+	jobConfigFailedOnce    = withFailures(jobConfig, 1, "")
+	jobConfigFailedTwice   = withFailures(jobConfig, 2, "")
+	jobConfigFailedThrice  = withFailures(jobConfig, 3, "0")
+	jobConfigStillFailing  = withFailures(jobConfig, 4, "0,1")
+	jobConfigRecovered     = withFailures(jobConfig, 0, "0,1")
+	jobConfigWithoutAlerts = func() *execution.JobConfig {
+		newRjc := withFailures(jobConfig, 0, "0")
+		newRjc.Spec.Alerts = nil
+		return newRjc
+	}()
+
+	jobConfigMissingSecret = func() *execution.JobConfig {
+		newRjc := jobConfigFailedTwice.DeepCopy()
+		newRjc.Spec.Alerts[0].RoutingKeySecretRef.Name = "nonexistent"
+		return newRjc
+	}()
+)
+
+type request struct {
+	Path   string
+	Auth   string
+	Action string
+}
+
+func TestReconciler(t *testing.T) {
+	var mu sync.Mutex
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := make(map[string]interface{})
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		req := request{Path: r.URL.Path, Auth: r.Header.Get("Authorization")}
+		if action, ok := payload["event_action"].(string); ok {
+			req.Action = action
+			req.Auth = payload["routing_key"].(string)
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	fixtures := []runtime.Object{
+		newSecret("pagerduty", "routing-key"),
+		newSecret("opsgenie", "api-key"),
+	}
+	configs := controllercontext.ConfigsMap{
+		configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+			Alerting: &configv1alpha1.AlertingSpec{
+				PagerDutyEventsURL: server.URL + "/pagerduty",
+				OpsgenieAPIURL:     server.URL + "/opsgenie",
+			},
+		},
+	}
+
+	// assertRequests asserts the requests received by the server.
+	assertRequests := func(want ...request) func(assert.TestingT, runtimetesting.ReconcilerTestCase,
+		runtimetesting.ControllerContext) {
+		return func(t assert.TestingT, _ runtimetesting.ReconcilerTestCase, _ runtimetesting.ControllerContext) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, want, requests)
+			requests = nil
+		}
+	}
+
+	test := runtimetesting.ReconcilerTest{
+		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
+			return alertcontroller.NewContextWithRecorder(c, recorder, nil)
+		},
+		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
+			return alertcontroller.NewReconciler(
+				c.(*alertcontroller.Context),
+				runtimetesting.ReconcilerDefaultConcurrency,
+			)
+		},
+	}
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name: "no such JobConfig",
+			SyncTarget: &runtimetesting.SyncTarget{
+				Namespace: testNamespace,
+				Name:      "nonexistent-jobconfig",
+			},
+			Assert: assertRequests(),
+		},
+		{
+			Name:     "do not trigger alert below threshold",
+			Target:   jobConfigFailedOnce,
+			Fixtures: fixtures,
+			Configs:  configs,
+			Assert:   assertRequests(),
+		},
+		{
+			Name:     "trigger PagerDuty incident",
+			Target:   jobConfigFailedTwice,
+			Fixtures: fixtures,
+			Configs:  configs,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobConfigAction(testNamespace, jobConfig.Name, types.MergePatchType,
+							[]byte(annotationOpen+`"0"}}}`)),
+					},
+				},
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobConfigUID,
+					Type:    corev1.EventTypeWarning,
+					Reason:  "AlertTriggered",
+					Message: "Opened PagerDuty incident after 2 consecutive failures",
+				},
+			},
+			Assert: assertRequests(
+				request{Path: "/pagerduty", Auth: "routing-key", Action: "trigger"},
+			),
+		},
+		{
+			Name:     "trigger Opsgenie alert",
+			Target:   jobConfigFailedThrice,
+			Fixtures: fixtures,
+			Configs:  configs,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobConfigAction(testNamespace, jobConfig.Name, types.MergePatchType,
+							[]byte(annotationOpen+`"0,1"}}}`)),
+					},
+				},
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobConfigUID,
+					Type:    corev1.EventTypeWarning,
+					Reason:  "AlertTriggered",
+					Message: "Opened Opsgenie incident after 3 consecutive failures",
+				},
+			},
+			Assert: assertRequests(
+				request{Path: "/opsgenie/v2/alerts", Auth: "GenieKey api-key"},
+			),
+		},
+		{
+			Name:     "do not trigger open incidents again",
+			Target:   jobConfigStillFailing,
+			Fixtures: fixtures,
+			Configs:  configs,
+			Assert:   assertRequests(),
+		},
+		{
+			Name:     "resolve incidents after success",
+			Target:   jobConfigRecovered,
+			Fixtures: fixtures,
+			Configs:  configs,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobConfigAction(testNamespace, jobConfig.Name, types.MergePatchType,
+							[]byte(annotationOpen+`null}}}`)),
+					},
+				},
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobConfigUID,
+					Type:    corev1.EventTypeNormal,
+					Reason:  "AlertResolved",
+					Message: "Resolved PagerDuty incident",
+				},
+				{
+					UID:     jobConfigUID,
+					Type:    corev1.EventTypeNormal,
+					Reason:  "AlertResolved",
+					Message: "Resolved Opsgenie incident",
+				},
+			},
+			Assert: assertRequests(
+				request{Path: "/pagerduty", Auth: "routing-key", Action: "resolve"},
+				request{Path: "/opsgenie/v2/alerts/furiko:test:jobconfig:1/close", Auth: "GenieKey api-key"},
+			),
+		},
+		{
+			Name:     "stop tracking incidents of removed alerts",
+			Target:   jobConfigWithoutAlerts,
+			Fixtures: fixtures,
+			Configs:  configs,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobConfigAction(testNamespace, jobConfig.Name, types.MergePatchType,
+							[]byte(annotationOpen+`null}}}`)),
+					},
+				},
+			},
+			Assert: assertRequests(),
+		},
+		{
+			Name:     "missing Secret",
+			Target:   jobConfigMissingSecret,
+			Fixtures: fixtures,
+			Configs:  configs,
+			WantError: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.Error(t, err)
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobConfigUID,
+					Type:    corev1.EventTypeWarning,
+					Reason:  "AlertFailed",
+					Message: `Cannot trigger PagerDuty incident: cannot get secret nonexistent: secrets "nonexistent" not found`,
+				},
+			},
+			Assert: assertRequests(),
+		},
+	})
+}
+
+func TestNewIncident(t *testing.T) {
+	rjc := jobConfigFailedTwice.DeepCopy()
+	rjc.Status.LastJob = &execution.JobSummary{
+		Name:   "jobconfig-1234",
+		Result: execution.JobResultTaskFailed,
+	}
+	incident := alertcontroller.NewIncident(rjc, 1, "https://furiko.example.com/${job.namespace}/${job.name}")
+	assert.Equal(t, &alertcontroller.Incident{
+		DedupKey: "furiko:test:jobconfig:1",
+		Summary:  "JobConfig test/jobconfig failed 2 consecutive times",
+		Details: map[string]string{
+			"namespace":           "test",
+			"jobConfig":           "jobconfig",
+			"consecutiveFailures": "2",
+			"lastJob":             "jobconfig-1234",
+			"lastJobResult":       "TaskFailed",
+		},
+		URL: "https://furiko.example.com/test/jobconfig-1234",
+	}, incident)
+}
+
+func withFailures(rjc *execution.JobConfig, failures int64, open string) *execution.JobConfig {
+	newRjc := rjc.DeepCopy()
+	newRjc.Status.ConsecutiveFailures = failures
+	if open != "" {
+		newRjc.Annotations = map[string]string{
+			jobconfig.AnnotationKeyAlertsOpen: open,
+		}
+	}
+	return newRjc
+}
+
+func newSecret(name, key string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			"key": []byte(key),
+		},
+	}
+}
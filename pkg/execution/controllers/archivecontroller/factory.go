@@ -0,0 +1,69 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archivecontroller
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/archive"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+)
+
+const (
+	controllerName = "ArchiveController"
+	fieldManager   = "furiko-archivecontroller"
+)
+
+type Factory struct {
+	spec *configv1alpha1.ArchiveSpec
+}
+
+// NewFactory returns a new Factory. Finished Jobs are archived to the database
+// in the given spec, which may be nil to disable archival.
+func NewFactory(spec *configv1alpha1.ArchiveSpec) *Factory {
+	return &Factory{spec: spec}
+}
+
+func (f *Factory) Name() string {
+	return controllerName
+}
+
+func (f *Factory) New(
+	ctrlContext controllercontext.Context,
+	concurrencySpec *configv1alpha1.ExecutionControllerConcurrencySpec,
+	rateLimiterSpec *configv1alpha1.ExecutionControllerRateLimitersSpec,
+) (controllermanager.Controller, error) {
+	var archiver archive.Archiver
+	if f.spec != nil {
+		klog.Infof("archivecontroller: archiving finished jobs to %v database", f.spec.Dialect)
+		store, err := archive.Open(f.spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot open job archive")
+		}
+		if err := store.Init(context.Background()); err != nil {
+			_ = store.Close()
+			return nil, errors.Wrapf(err, "cannot initialize job archive")
+		}
+		archiver = store
+	}
+	return NewController(ctrlContext, concurrencySpec.Archive, rateLimiterSpec.Archive, archiver)
+}
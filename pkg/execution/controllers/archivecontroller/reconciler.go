@@ -0,0 +1,178 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archivecontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	utiltrace "k8s.io/utils/trace"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/archive"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/meta"
+)
+
+type Reconciler struct {
+	*Context
+	concurrency *configv1alpha1.Concurrency
+}
+
+func NewReconciler(ctrlContext *Context, concurrency *configv1alpha1.Concurrency) *Reconciler {
+	return &Reconciler{
+		Context:     ctrlContext,
+		concurrency: concurrency,
+	}
+}
+
+func (w *Reconciler) Name() string {
+	return fmt.Sprintf("%v.Reconciler", controllerName)
+}
+
+func (w *Reconciler) Concurrency() int {
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "archivecontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.Archive
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
+}
+
+func (w *Reconciler) MaxRequeues() int {
+	return -1
+}
+
+func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int) error {
+	trace := utiltrace.New(
+		"archive_sync",
+		utiltrace.Field{Key: "namespace", Value: namespace},
+		utiltrace.Field{Key: "name", Value: name},
+	)
+	defer trace.LogIfLong(500 * time.Millisecond)
+
+	rj, err := w.jobInformer.Lister().Jobs(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get job")
+	}
+
+	if !needsSync(rj, w.archiver != nil) {
+		return nil
+	}
+
+	newRj := rj.DeepCopy()
+	switch {
+	case w.archiver == nil:
+		// Archival is disabled, so Jobs which are still held by the finalizer must
+		// be released.
+
+	case isArchived(rj):
+		// Already archived, only need to remove the finalizer.
+
+	case canArchive(rj):
+		if err := w.archiver.Archive(ctx, archive.NewRecord(rj)); err != nil {
+			w.recorder.Eventf(rj, corev1.EventTypeWarning, "ArchiveFailed", "Cannot archive job: %v", err)
+			return errors.Wrapf(err, "cannot archive job")
+		}
+		trace.Step("Archive job done")
+
+		if newRj.Annotations == nil {
+			newRj.Annotations = make(map[string]string)
+		}
+		newRj.Annotations[jobutil.AnnotationKeyArchiveTime] = ktime.Now().Format(time.RFC3339)
+
+		klog.V(3).InfoS("archivecontroller: archived job",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+		)
+
+	case rj.DeletionTimestamp.IsZero():
+		// Hold the Job with a finalizer until it is archived.
+		newRj.Finalizers = meta.MergeFinalizers(newRj.Finalizers, []string{executiongroup.ArchiveFinalizer})
+		return w.updateJob(ctx, rj, newRj)
+
+	default:
+		// Wait for the JobController to finalize the Job before archiving it, so that
+		// the final status of its tasks are recorded.
+		return nil
+	}
+
+	newRj.Finalizers = meta.RemoveFinalizer(newRj.Finalizers, executiongroup.ArchiveFinalizer)
+	if err := w.updateJob(ctx, rj, newRj); err != nil {
+		return err
+	}
+	trace.Step("Update job done")
+
+	return nil
+}
+
+// updateJob updates the Job if its metadata was changed.
+func (w *Reconciler) updateJob(ctx context.Context, rj, newRj *execution.Job) error {
+	if equality.Semantic.DeepEqual(rj.ObjectMeta, newRj.ObjectMeta) {
+		return nil
+	}
+	if _, err := w.Clientsets().Furiko().ExecutionV1alpha1().Jobs(rj.GetNamespace()).
+		Update(ctx, newRj, metav1.UpdateOptions{}); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "cannot update job")
+	}
+	return nil
+}
+
+// needsSync returns true if the Job has yet to be archived, or if it still has
+// the archive finalizer. If archival is disabled, only Jobs with the finalizer
+// need to be synced.
+func needsSync(rj *execution.Job, enabled bool) bool {
+	hasFinalizer := meta.ContainsFinalizer(rj.Finalizers, executiongroup.ArchiveFinalizer)
+	if !enabled {
+		return hasFinalizer
+	}
+	return hasFinalizer || !isArchived(rj)
+}
+
+// isArchived returns true if the Job was already written to the archive.
+func isArchived(rj *execution.Job) bool {
+	_, ok := rj.Annotations[jobutil.AnnotationKeyArchiveTime]
+	return ok
+}
+
+// canArchive returns true if the Job is finished, or if it is being deleted
+// and has already been finalized by the JobController.
+func canArchive(rj *execution.Job) bool {
+	if rj.Status.Condition.Finished != nil {
+		return true
+	}
+	return !rj.DeletionTimestamp.IsZero() &&
+		!meta.ContainsFinalizer(rj.Finalizers, executiongroup.DeleteDependentsFinalizer)
+}
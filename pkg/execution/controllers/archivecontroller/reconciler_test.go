@@ -0,0 +1,246 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archivecontroller_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/archive"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/archivecontroller"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	createTime    = "2022-04-01T04:00:00Z"
+	startTime     = "2022-04-01T04:00:01Z"
+	finishTime    = "2022-04-01T04:01:00Z"
+	deleteTime    = "2022-04-01T04:04:00Z"
+	now           = "2022-04-01T04:05:00Z"
+	testNamespace = "test"
+)
+
+type fakeArchiver struct {
+	mu      sync.Mutex
+	records []*archive.Record
+	err     error
+}
+
+func (a *fakeArchiver) Archive(_ context.Context, record *archive.Record) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.err != nil {
+		return a.err
+	}
+	a.records = append(a.records, record)
+	return nil
+}
+
+// assertArchived asserts the names of the Jobs that were archived.
+func (a *fakeArchiver) assertArchived(want ...string) func(assert.TestingT, runtimetesting.ReconcilerTestCase,
+	runtimetesting.ControllerContext) {
+	return func(t assert.TestingT, _ runtimetesting.ReconcilerTestCase, _ runtimetesting.ControllerContext) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		names := make([]string, 0, len(a.records))
+		for _, record := range a.records {
+			names = append(names, record.Name)
+		}
+		assert.ElementsMatch(t, want, names)
+		a.records = nil
+	}
+}
+
+func newJob(finished bool, finalizers ...string) *execution.Job {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job",
+			Namespace:         testNamespace,
+			UID:               "uid",
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			Finalizers:        finalizers,
+		},
+		Status: execution.JobStatus{
+			Phase:     execution.JobRunning,
+			StartTime: testutils.Mkmtimep(startTime),
+		},
+	}
+	if finished {
+		rj.Status.Phase = execution.JobSucceeded
+		rj.Status.Condition.Finished = &execution.JobConditionFinished{
+			FinishedAt: testutils.Mkmtime(finishTime),
+			Result:     execution.JobResultSuccess,
+		}
+	}
+	return rj
+}
+
+func withArchiveTime(rj *execution.Job) *execution.Job {
+	newRj := rj.DeepCopy()
+	newRj.Annotations = map[string]string{
+		jobutil.AnnotationKeyArchiveTime: now,
+	}
+	return newRj
+}
+
+func withDeletionTimestamp(rj *execution.Job) *execution.Job {
+	newRj := rj.DeepCopy()
+	newRj.DeletionTimestamp = testutils.Mkmtimep(deleteTime)
+	return newRj
+}
+
+func updateJob(rj *execution.Job) runtimetesting.CombinedActions {
+	return runtimetesting.CombinedActions{
+		Furiko: runtimetesting.ActionTest{
+			Actions: []runtimetesting.Action{
+				runtimetesting.NewUpdateJobAction(testNamespace, rj),
+			},
+		},
+	}
+}
+
+func newReconcilerTest(archiver archive.Archiver) runtimetesting.ReconcilerTest {
+	return runtimetesting.ReconcilerTest{
+		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
+			ctrlContext := archivecontroller.NewContextWithRecorder(c, recorder, nil)
+			ctrlContext.SetArchiver(archiver)
+			return ctrlContext
+		},
+		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
+			return archivecontroller.NewReconciler(
+				c.(*archivecontroller.Context),
+				runtimetesting.ReconcilerDefaultConcurrency,
+			)
+		},
+		Now: testutils.Mktime(now),
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	archiver := &fakeArchiver{}
+	test := newReconcilerTest(archiver)
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name: "no such Job",
+			SyncTarget: &runtimetesting.SyncTarget{
+				Namespace: testNamespace,
+				Name:      "nonexistent-job",
+			},
+			Assert: archiver.assertArchived(),
+		},
+		{
+			Name:        "add finalizer to running Job",
+			Target:      newJob(false),
+			WantActions: updateJob(newJob(false, executiongroup.ArchiveFinalizer)),
+			Assert:      archiver.assertArchived(),
+		},
+		{
+			Name:   "running Job already has finalizer",
+			Target: newJob(false, executiongroup.DeleteDependentsFinalizer, executiongroup.ArchiveFinalizer),
+			Assert: archiver.assertArchived(),
+		},
+		{
+			Name:   "archive finished Job and remove finalizer",
+			Target: newJob(true, executiongroup.DeleteDependentsFinalizer, executiongroup.ArchiveFinalizer),
+			WantActions: updateJob(withArchiveTime(
+				newJob(true, executiongroup.DeleteDependentsFinalizer),
+			)),
+			Assert: archiver.assertArchived("job"),
+		},
+		{
+			Name:        "archive finished Job without finalizer",
+			Target:      newJob(true),
+			WantActions: updateJob(withArchiveTime(newJob(true))),
+			Assert:      archiver.assertArchived("job"),
+		},
+		{
+			Name:   "do not archive Job twice",
+			Target: withArchiveTime(newJob(true)),
+			Assert: archiver.assertArchived(),
+		},
+		{
+			Name:        "remove finalizer from archived Job",
+			Target:      withArchiveTime(newJob(true, executiongroup.ArchiveFinalizer)),
+			WantActions: updateJob(withArchiveTime(newJob(true))),
+			Assert:      archiver.assertArchived(),
+		},
+		{
+			Name: "wait for deleted Job to be finalized",
+			Target: withDeletionTimestamp(
+				newJob(false, executiongroup.DeleteDependentsFinalizer, executiongroup.ArchiveFinalizer),
+			),
+			Assert: archiver.assertArchived(),
+		},
+		{
+			Name:        "archive finalized Job",
+			Target:      withDeletionTimestamp(newJob(false, executiongroup.ArchiveFinalizer)),
+			WantActions: updateJob(withArchiveTime(withDeletionTimestamp(newJob(false)))),
+			Assert:      archiver.assertArchived("job"),
+		},
+	})
+}
+
+func TestReconciler_ArchiveFailed(t *testing.T) {
+	archiver := &fakeArchiver{err: errors.New("connection refused")}
+	test := newReconcilerTest(archiver)
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name:   "keep finalizer if Job cannot be archived",
+			Target: newJob(true, executiongroup.ArchiveFinalizer),
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     "uid",
+					Type:    corev1.EventTypeWarning,
+					Reason:  "ArchiveFailed",
+					Message: "Cannot archive job: connection refused",
+				},
+			},
+			WantError: assert.Error,
+		},
+	})
+}
+
+func TestReconciler_Disabled(t *testing.T) {
+	test := newReconcilerTest(nil)
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name:   "do nothing for Job without finalizer",
+			Target: newJob(true),
+		},
+		{
+			Name:        "remove finalizer from Job",
+			Target:      newJob(false, executiongroup.ArchiveFinalizer),
+			WantActions: updateJob(newJob(false)),
+		},
+	})
+}
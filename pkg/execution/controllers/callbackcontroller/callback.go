@@ -0,0 +1,135 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbackcontroller
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	// HeaderSignature is the header that contains the HMAC-SHA256 signature of
+	// the payload.
+	HeaderSignature = "X-Furiko-Signature"
+
+	// HeaderDelivery is the header that contains the UID of the Job, which can be
+	// used by receivers to deduplicate retried deliveries.
+	HeaderDelivery = "X-Furiko-Delivery"
+
+	signaturePrefix = "sha256="
+)
+
+// Payload is the JSON payload of a callback.
+type Payload struct {
+	Namespace     string              `json:"namespace"`
+	Name          string              `json:"name"`
+	UID           types.UID           `json:"uid"`
+	JobConfigName string              `json:"jobConfigName,omitempty"`
+	Phase         execution.JobPhase  `json:"phase"`
+	Result        execution.JobResult `json:"result,omitempty"`
+	Reason        string              `json:"reason,omitempty"`
+	Message       string              `json:"message,omitempty"`
+	Outputs       map[string]string   `json:"outputs,omitempty"`
+	CreateTime    metav1.Time         `json:"createTime"`
+	StartTime     *metav1.Time        `json:"startTime,omitempty"`
+	FinishTime    *metav1.Time        `json:"finishTime,omitempty"`
+
+	// DurationSeconds is the time from when the Job started until it finished.
+	DurationSeconds int64 `json:"durationSeconds"`
+}
+
+// NewPayload returns the callback Payload for a finished Job.
+func NewPayload(rj *execution.Job) *Payload {
+	payload := &Payload{
+		Namespace:  rj.GetNamespace(),
+		Name:       rj.GetName(),
+		UID:        rj.GetUID(),
+		Phase:      rj.Status.Phase,
+		Outputs:    rj.Status.Outputs,
+		CreateTime: rj.GetCreationTimestamp(),
+		StartTime:  rj.Status.StartTime.DeepCopy(),
+	}
+
+	if ref := metav1.GetControllerOf(rj); ref != nil && ref.Kind == execution.KindJobConfig {
+		payload.JobConfigName = ref.Name
+	}
+
+	if finished := rj.Status.Condition.Finished; finished != nil {
+		payload.Result = finished.Result
+		payload.Reason = finished.Reason
+		payload.Message = finished.Message
+		payload.FinishTime = finished.FinishedAt.DeepCopy()
+		if startTime := rj.Status.StartTime; startTime != nil {
+			payload.DurationSeconds = int64(finished.FinishedAt.Sub(startTime.Time).Seconds())
+		}
+	}
+
+	return payload
+}
+
+// Sign returns the value of the signature header for the body.
+func Sign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver sends the signed payload to the URL. Returns the status code of the
+// response if one was received.
+func deliver(
+	ctx context.Context, client *http.Client, url string, key []byte, rj *execution.Job, payload *Payload,
+) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot marshal payload")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSignature, Sign(key, body))
+	req.Header.Set(HeaderDelivery, string(rj.GetUID()))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("callback returned status %v: %v", resp.StatusCode,
+			strings.TrimSpace(string(respBody)))
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbackcontroller
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/furiko-io/furiko/pkg/utils/eventhandler"
+)
+
+// InformerWorker receives events from the informer and enqueues work to be done
+// for the controller.
+type InformerWorker struct {
+	*Context
+}
+
+func NewInformerWorker(ctrlContext *Context) *InformerWorker {
+	w := &InformerWorker{
+		Context: ctrlContext,
+	}
+
+	// Add event handler for Jobs.
+	// Deleted Jobs are not handled since the delivery status cannot be recorded on them.
+	w.jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: w.handleJob,
+		UpdateFunc: func(_, newObj interface{}) {
+			w.handleJob(newObj)
+		},
+	})
+
+	return w
+}
+
+func (w *InformerWorker) WorkerName() string {
+	return fmt.Sprintf("%v.Informer", controllerName)
+}
+
+// handleJob enqueues Jobs that specify a callback.
+func (w *InformerWorker) handleJob(obj interface{}) {
+	rj, err := eventhandler.Executionv1alpha1Job(obj)
+	if err != nil {
+		klog.ErrorS(err, "callbackcontroller: unable to handle event", "worker", w.WorkerName())
+		return
+	}
+
+	if rj.Spec.Template == nil || rj.Spec.Template.Callback == nil {
+		return
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(rj)
+	if err != nil {
+		klog.ErrorS(err, "callbackcontroller: keyfunc error", "worker", w.WorkerName(), "obj", obj)
+		return
+	}
+	w.queue.Add(key)
+}
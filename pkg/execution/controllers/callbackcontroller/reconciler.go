@@ -0,0 +1,246 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbackcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utiltrace "k8s.io/utils/trace"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = 10 * time.Second
+	defaultMaxBackoff  = 10 * time.Minute
+	defaultTimeout     = 10 * time.Second
+)
+
+type Reconciler struct {
+	*Context
+	concurrency *configv1alpha1.Concurrency
+}
+
+func NewReconciler(ctrlContext *Context, concurrency *configv1alpha1.Concurrency) *Reconciler {
+	return &Reconciler{
+		Context:     ctrlContext,
+		concurrency: concurrency,
+	}
+}
+
+func (w *Reconciler) Name() string {
+	return fmt.Sprintf("%v.Reconciler", controllerName)
+}
+
+func (w *Reconciler) Concurrency() int {
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "callbackcontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.Callback
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
+}
+
+func (w *Reconciler) MaxRequeues() int {
+	return -1
+}
+
+func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int) error {
+	trace := utiltrace.New(
+		"callback_sync",
+		utiltrace.Field{Key: "namespace", Value: namespace},
+		utiltrace.Field{Key: "name", Value: name},
+	)
+	defer trace.LogIfLong(500 * time.Millisecond)
+
+	rj, err := w.jobInformer.Lister().Jobs(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get job")
+	}
+
+	// Only deliver callbacks for finished Jobs which have not yet been delivered
+	// or given up on.
+	if rj.Spec.Template == nil || rj.Spec.Template.Callback == nil || rj.Status.Condition.Finished == nil {
+		return nil
+	}
+	status := rj.Status.Callback
+	if status != nil && status.Phase != execution.CallbackPending {
+		return nil
+	}
+	if status == nil {
+		status = &execution.CallbackStatus{Phase: execution.CallbackPending}
+	}
+
+	cfg, err := w.Configs().JobsForNamespace(namespace)
+	if err != nil {
+		return errors.Wrapf(err, "cannot load job execution config")
+	}
+	spec := cfg.Callbacks
+	if spec == nil {
+		spec = &configv1alpha1.CallbacksSpec{}
+	}
+
+	// Wait for the backoff to elapse since the last attempt.
+	now := ktime.Now()
+	if status.LastAttemptTime != nil {
+		nextAttempt := status.LastAttemptTime.Add(getBackoff(spec, status.Attempts))
+		if now.Time.Before(nextAttempt) {
+			w.enqueueAfter(rj, "retry callback", nextAttempt.Sub(now.Time))
+			return nil
+		}
+	}
+
+	code, err := w.deliver(ctx, rj, spec)
+	trace.Step("Deliver callback done")
+
+	newStatus := status.DeepCopy()
+	newStatus.Attempts++
+	newStatus.LastAttemptTime = now
+	newStatus.LastResponseCode = int32(code)
+	newStatus.Message = ""
+
+	switch maxAttempts := getMaxAttempts(spec); {
+	case err == nil:
+		newStatus.Phase = execution.CallbackDelivered
+		w.recorder.Eventf(rj, corev1.EventTypeNormal, "CallbackDelivered",
+			"Delivered callback after %v attempt(s)", newStatus.Attempts)
+	case newStatus.Attempts >= maxAttempts:
+		newStatus.Phase = execution.CallbackFailed
+		newStatus.Message = err.Error()
+		w.recorder.Eventf(rj, corev1.EventTypeWarning, "CallbackFailed",
+			"Cannot deliver callback after %v attempt(s): %v", newStatus.Attempts, err)
+	default:
+		newStatus.Message = err.Error()
+		w.enqueueAfter(rj, "retry callback", getBackoff(spec, newStatus.Attempts))
+	}
+
+	klog.V(3).InfoS("callbackcontroller: attempted callback delivery",
+		"worker", w.Name(),
+		"namespace", rj.GetNamespace(),
+		"name", rj.GetName(),
+		"phase", newStatus.Phase,
+		"attempts", newStatus.Attempts,
+	)
+
+	if err := w.updateStatus(ctx, rj, newStatus); err != nil {
+		return errors.Wrapf(err, "cannot update callback status")
+	}
+	trace.Step("Update job status done")
+
+	return nil
+}
+
+// deliver reads the signing key and delivers the callback for the Job.
+func (w *Reconciler) deliver(ctx context.Context, rj *execution.Job, spec *configv1alpha1.CallbacksSpec) (int, error) {
+	timeout := defaultTimeout
+	if spec.TimeoutSeconds != nil && *spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	callback := rj.Spec.Template.Callback
+	ref := callback.SigningKeySecretRef
+	secret, err := w.Clientsets().Kubernetes().CoreV1().Secrets(rj.GetNamespace()).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot get secret %v", ref.Name)
+	}
+	key, ok := secret.Data[ref.Key]
+	if !ok {
+		return 0, fmt.Errorf("key %v not found in secret %v", ref.Key, ref.Name)
+	}
+
+	return deliver(ctx, w.httpClient, callback.URL, key, rj, NewPayload(rj))
+}
+
+// updateStatus applies the callback status of the Job, which is the only field
+// of the status that is managed by this controller.
+func (w *Reconciler) updateStatus(ctx context.Context, rj *execution.Job, status *execution.CallbackStatus) error {
+	patch, err := controllerutil.NewStatusApplyPatch(execution.GVKJob, rj.GetNamespace(), rj.GetName(),
+		map[string]interface{}{
+			"callback": status,
+		})
+	if err != nil {
+		return errors.Wrapf(err, "cannot create status patch")
+	}
+	if _, err := w.Clientsets().Furiko().ExecutionV1alpha1().Jobs(rj.GetNamespace()).Patch(ctx, rj.GetName(),
+		types.ApplyPatchType, patch, controllerutil.NewApplyPatchOptions(fieldManager), "status",
+	); err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// enqueueAfter will defer a sync after the specified duration, and logs the purpose of deferring
+// the sync for debugging purposes.
+// We enforce a lower bound of 1 second to the next sync, to slow down unwanted bursts of syncs.
+func (w *Reconciler) enqueueAfter(rj *execution.Job, purpose string, duration time.Duration) {
+	duration = timeutil.DurationMax(time.Second, duration)
+	if key, err := cache.MetaNamespaceKeyFunc(rj); err == nil {
+		w.queue.AddAfter(key, duration)
+		klog.V(2).InfoS("callbackcontroller: worker enqueue sync",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"purpose", purpose,
+			"after", duration.String(),
+		)
+	}
+}
+
+func getMaxAttempts(spec *configv1alpha1.CallbacksSpec) int32 {
+	if spec.MaxAttempts != nil && *spec.MaxAttempts > 0 {
+		return int32(*spec.MaxAttempts)
+	}
+	return defaultMaxAttempts
+}
+
+// getBackoff returns the delay before the next attempt after the given number
+// of attempts, which doubles for each attempt up to the maximum backoff.
+func getBackoff(spec *configv1alpha1.CallbacksSpec, attempts int32) time.Duration {
+	backoff, maxBackoff := defaultBackoff, defaultMaxBackoff
+	if spec.BackoffSeconds != nil {
+		backoff = time.Duration(*spec.BackoffSeconds) * time.Second
+	}
+	if spec.MaxBackoffSeconds != nil {
+		maxBackoff = time.Duration(*spec.MaxBackoffSeconds) * time.Second
+	}
+	for i := int32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return timeutil.DurationMin(backoff, maxBackoff)
+}
@@ -0,0 +1,323 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbackcontroller_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/callbackcontroller"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	createTime    = "2022-04-01T04:00:00Z"
+	startTime     = "2022-04-01T04:00:01Z"
+	finishTime    = "2022-04-01T04:01:00Z"
+	now           = "2022-04-01T04:05:00Z"
+	lastAttempt   = "2022-04-01T04:04:55Z"
+	testNamespace = "test"
+	jobUID        = "0f3c2b1a-9d8e-4f7a-b6c5-d4e3f2a1b0c9"
+	signingKey    = "secret-key"
+)
+
+func TestReconciler(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, callbackcontroller.Sign([]byte(signingKey), body), r.Header.Get(callbackcontroller.HeaderSignature))
+		assert.Equal(t, jobUID, r.Header.Get(callbackcontroller.HeaderDelivery))
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("unavailable"))
+		}
+	}))
+	defer server.Close()
+
+	newJob := func(path string, finished bool, status *execution.CallbackStatus) *execution.Job {
+		rj := &execution.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "job",
+				Namespace:         testNamespace,
+				UID:               jobUID,
+				CreationTimestamp: testutils.Mkmtime(createTime),
+			},
+			Spec: execution.JobSpec{
+				Template: &execution.JobTemplateSpec{
+					Callback: &execution.CallbackSpec{
+						URL: server.URL + path,
+						SigningKeySecretRef: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "callback"},
+							Key:                  "key",
+						},
+					},
+				},
+			},
+			Status: execution.JobStatus{
+				Phase:     execution.JobRunning,
+				StartTime: testutils.Mkmtimep(startTime),
+				Callback:  status,
+			},
+		}
+		if finished {
+			rj.Status.Phase = execution.JobSucceeded
+			rj.Status.Condition.Finished = &execution.JobConditionFinished{
+				FinishedAt: testutils.Mkmtime(finishTime),
+				Result:     execution.JobResultSuccess,
+			}
+		}
+		return rj
+	}
+
+	pendingStatus := &execution.CallbackStatus{
+		Phase:            execution.CallbackPending,
+		Attempts:         1,
+		LastAttemptTime:  testutils.Mkmtimep(lastAttempt),
+		LastResponseCode: 503,
+		Message:          "callback returned status 503: unavailable",
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "callback",
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			"key": []byte(signingKey),
+		},
+	}
+	configs := controllercontext.ConfigsMap{
+		configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+			Callbacks: &configv1alpha1.CallbacksSpec{
+				MaxAttempts:    pointer.Int64(2),
+				BackoffSeconds: pointer.Int64(3),
+			},
+		},
+	}
+
+	// assertDeliveries asserts the number of callbacks received by the server.
+	assertDeliveries := func(want int) func(assert.TestingT, runtimetesting.ReconcilerTestCase,
+		runtimetesting.ControllerContext) {
+		return func(t assert.TestingT, _ runtimetesting.ReconcilerTestCase, _ runtimetesting.ControllerContext) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Len(t, bodies, want)
+			bodies = nil
+		}
+	}
+
+	// applyCallbackStatus returns the action that applies the callback status.
+	applyCallbackStatus := func(status *execution.CallbackStatus) runtimetesting.CombinedActions {
+		return runtimetesting.CombinedActions{
+			Furiko: runtimetesting.ActionTest{
+				Actions: []runtimetesting.Action{
+					runtimetesting.NewApplyJobStatusAction(testNamespace, "job", map[string]interface{}{
+						"callback": status,
+					}),
+				},
+			},
+		}
+	}
+
+	test := runtimetesting.ReconcilerTest{
+		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
+			return callbackcontroller.NewContextWithRecorder(c, recorder, nil)
+		},
+		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
+			return callbackcontroller.NewReconciler(
+				c.(*callbackcontroller.Context),
+				runtimetesting.ReconcilerDefaultConcurrency,
+			)
+		},
+		Now: testutils.Mktime(now),
+	}
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name: "no such Job",
+			SyncTarget: &runtimetesting.SyncTarget{
+				Namespace: testNamespace,
+				Name:      "nonexistent-job",
+			},
+			Assert: assertDeliveries(0),
+		},
+		{
+			Name:     "do not deliver callback for running Job",
+			Target:   newJob("/callback", false, nil),
+			Fixtures: []runtime.Object{secret},
+			Assert:   assertDeliveries(0),
+		},
+		{
+			Name:     "deliver callback for finished Job",
+			Target:   newJob("/callback", true, nil),
+			Fixtures: []runtime.Object{secret},
+			WantActions: applyCallbackStatus(&execution.CallbackStatus{
+				Phase:            execution.CallbackDelivered,
+				Attempts:         1,
+				LastAttemptTime:  testutils.Mkmtimep(now),
+				LastResponseCode: 200,
+			}),
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobUID,
+					Type:    corev1.EventTypeNormal,
+					Reason:  "CallbackDelivered",
+					Message: "Delivered callback after 1 attempt(s)",
+				},
+			},
+			Assert: assertDeliveries(1),
+		},
+		{
+			Name:     "do not deliver callback twice",
+			Target:   newJob("/callback", true, &execution.CallbackStatus{Phase: execution.CallbackDelivered, Attempts: 1}),
+			Fixtures: []runtime.Object{secret},
+			Assert:   assertDeliveries(0),
+		},
+		{
+			Name:     "record failed attempt",
+			Target:   newJob("/failing", true, nil),
+			Fixtures: []runtime.Object{secret},
+			Configs:  configs,
+			WantActions: applyCallbackStatus(&execution.CallbackStatus{
+				Phase:            execution.CallbackPending,
+				Attempts:         1,
+				LastAttemptTime:  testutils.Mkmtimep(now),
+				LastResponseCode: 503,
+				Message:          "callback returned status 503: unavailable",
+			}),
+			Assert: assertDeliveries(1),
+		},
+		{
+			Name:     "wait for backoff before retrying",
+			Target:   newJob("/failing", true, pendingStatus),
+			Fixtures: []runtime.Object{secret},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					Callbacks: &configv1alpha1.CallbacksSpec{
+						BackoffSeconds: pointer.Int64(60),
+					},
+				},
+			},
+			Assert: assertDeliveries(0),
+		},
+		{
+			Name:     "give up after max attempts",
+			Target:   newJob("/failing", true, pendingStatus),
+			Fixtures: []runtime.Object{secret},
+			Configs:  configs,
+			WantActions: applyCallbackStatus(&execution.CallbackStatus{
+				Phase:            execution.CallbackFailed,
+				Attempts:         2,
+				LastAttemptTime:  testutils.Mkmtimep(now),
+				LastResponseCode: 503,
+				Message:          "callback returned status 503: unavailable",
+			}),
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobUID,
+					Type:    corev1.EventTypeWarning,
+					Reason:  "CallbackFailed",
+					Message: "Cannot deliver callback after 2 attempt(s): callback returned status 503: unavailable",
+				},
+			},
+			Assert: assertDeliveries(1),
+		},
+		{
+			Name:   "missing Secret",
+			Target: newJob("/callback", true, nil),
+			WantActions: applyCallbackStatus(&execution.CallbackStatus{
+				Phase:           execution.CallbackPending,
+				Attempts:        1,
+				LastAttemptTime: testutils.Mkmtimep(now),
+				Message:         `cannot get secret callback: secrets "callback" not found`,
+			}),
+			Assert: assertDeliveries(0),
+		},
+	})
+}
+
+func TestNewPayload(t *testing.T) {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job",
+			Namespace:         testNamespace,
+			UID:               jobUID,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: execution.GroupVersion.String(),
+					Kind:       execution.KindJobConfig,
+					Name:       "jobconfig",
+					Controller: pointer.Bool(true),
+				},
+			},
+		},
+		Status: execution.JobStatus{
+			Phase:     execution.JobRetryLimitExceeded,
+			StartTime: testutils.Mkmtimep(startTime),
+			Outputs: map[string]string{
+				"rows": "42",
+			},
+			Condition: execution.JobCondition{
+				Finished: &execution.JobConditionFinished{
+					FinishedAt: testutils.Mkmtime(finishTime),
+					Result:     execution.JobResultTaskFailed,
+					Reason:     "Error",
+					Message:    "exit code 1",
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(callbackcontroller.NewPayload(rj))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"namespace": "test",
+		"name": "job",
+		"uid": "0f3c2b1a-9d8e-4f7a-b6c5-d4e3f2a1b0c9",
+		"jobConfigName": "jobconfig",
+		"phase": "RetryLimitExceeded",
+		"result": "TaskFailed",
+		"reason": "Error",
+		"message": "exit code 1",
+		"outputs": {"rows": "42"},
+		"createTime": "2022-04-01T04:00:00Z",
+		"startTime": "2022-04-01T04:00:01Z",
+		"finishTime": "2022-04-01T04:01:00Z",
+		"durationSeconds": 59
+	}`, string(data))
+}
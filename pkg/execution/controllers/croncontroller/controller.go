@@ -23,11 +23,13 @@ import (
 	"github.com/pkg/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	executioninformers "github.com/furiko-io/furiko/pkg/generated/informers/externalversions/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
@@ -62,14 +64,16 @@ type Context struct {
 	HasSynced         []cache.InformerSynced
 	queue             workqueue.RateLimitingInterface
 	updatedConfigs    chan *execution.JobConfig
+	eventBroadcaster  record.EventBroadcaster
+	eventSink         eventsink.Recorder
 }
 
 // NewContext returns a new Context.
-func NewContext(context controllercontext.Context) *Context {
+func NewContext(context controllercontext.Context, rateLimiter *configv1alpha1.RateLimiterSpec) *Context {
 	c := &Context{Context: context}
 
 	// Create workqueue.
-	ratelimiter := workqueue.DefaultControllerRateLimiter()
+	ratelimiter := controllerutil.NewRateLimiter(rateLimiter)
 	c.queue = workqueue.NewNamedRateLimitingQueue(ratelimiter, controllerName)
 
 	// Bind informers.
@@ -82,19 +86,36 @@ func NewContext(context controllercontext.Context) *Context {
 
 	c.updatedConfigs = make(chan *execution.JobConfig, updatedConfigsBufferSize)
 
+	// Create event broadcaster.
+	c.eventBroadcaster = context.NewEventBroadcaster()
+
+	// Discard skipped schedules unless a Recorder is set.
+	c.eventSink = eventsink.NopRecorder{}
+
 	return c
 }
 
+// SetEventSink sets the Recorder that skipped schedules of JobConfigs are
+// recorded to.
+func (c *Context) SetEventSink(recorder eventsink.Recorder) {
+	c.eventSink = recorder
+}
+
 func NewController(
 	ctrlContext controllercontext.Context,
 	concurrency *configv1alpha1.Concurrency,
+	rateLimiter *configv1alpha1.RateLimiterSpec,
+	eventSink eventsink.Recorder,
 ) (*Controller, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ctrl := &Controller{
-		Context:   NewContext(ctrlContext),
+		Context:   NewContext(ctrlContext, rateLimiter),
 		ctx:       ctx,
 		terminate: cancel,
 	}
+	if eventSink != nil {
+		ctrl.SetEventSink(eventSink)
+	}
 
 	ctrl.cronWorker = NewCronWorker(ctrl.Context, newEnqueueHandler(ctrl.Context))
 	ctrl.informerWorker = NewInformerWorker(ctrl.Context, NewUpdateHandler(ctrl.Context))
@@ -138,15 +159,28 @@ func (c *Controller) Run(ctx context.Context) error {
 
 func (c *Controller) Shutdown(ctx context.Context) {
 	klog.InfoS("croncontroller: shutting down")
+
+	// Stop accepting new items and wait for in-flight syncs to complete, before
+	// canceling any syncs that are still running once the deadline is exceeded.
+	if err := c.reconciler.Drain(ctx); err != nil {
+		klog.ErrorS(err, "croncontroller: cannot drain in-flight syncs before deadline")
+	}
 	c.terminate()
-	c.queue.ShutDown()
 	c.reconciler.Wait()
+
+	// Flush any pending events.
+	if c.eventBroadcaster != nil {
+		c.eventBroadcaster.Shutdown()
+	}
 	klog.InfoS("croncontroller: stopped controller")
 }
 
 func (c *Controller) GetHealth() controllermanager.HealthStatus {
 	return controllermanager.HealthStatus{
-		Name:    controllerName,
-		Healthy: atomic.LoadUint64(&c.healthStatus) == 1,
+		Name:               controllerName,
+		Healthy:            atomic.LoadUint64(&c.healthStatus) == 1,
+		CachesSynced:       controllerutil.HasSynced(c.HasSynced...),
+		QueueDepth:         c.reconciler.QueueDepth(),
+		LastSuccessfulSync: c.reconciler.LastSuccessfulSync(),
 	}
 }
@@ -33,6 +33,7 @@ import (
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/core/tzutils"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	"github.com/furiko-io/furiko/pkg/execution/util/cronparser"
 )
 
@@ -108,8 +109,10 @@ func (w *CronWorker) Work() {
 	// Sync each job config.
 	// TODO(irvinlim): Theoretically it is more computationally efficient to use a
 	//  heap instead of iterating all job configs for scheduling.
+	namespaceConfigs := make(map[string]*configv1alpha1.CronExecutionConfig)
 	for _, jobConfig := range jobConfigList {
-		if err := w.syncOne(jobConfig, cfg, parser); err != nil {
+		namespaceCfg := w.getNamespaceConfig(namespaceConfigs, jobConfig.Namespace, cfg)
+		if err := w.syncOne(jobConfig, namespaceCfg, parser); err != nil {
 			klog.ErrorS(err, "croncontroller: sync JobConfig error",
 				"worker", w.WorkerName(),
 				"namespace", jobConfig.GetNamespace(),
@@ -120,6 +123,30 @@ func (w *CronWorker) Work() {
 	trace.Step("Sync all JobConfigs done")
 }
 
+// getNamespaceConfig returns the Cron config for the given namespace, which
+// includes any namespaced overrides. Loaded configs are stored in cache so that
+// each namespace is only loaded once per iteration. Falls back to the global
+// config if the namespaced config cannot be loaded.
+func (w *CronWorker) getNamespaceConfig(
+	cache map[string]*configv1alpha1.CronExecutionConfig,
+	namespace string,
+	cfg *configv1alpha1.CronExecutionConfig,
+) *configv1alpha1.CronExecutionConfig {
+	if namespaceCfg, ok := cache[namespace]; ok {
+		return namespaceCfg
+	}
+	namespaceCfg, err := w.Configs().CronForNamespace(namespace)
+	if err != nil {
+		klog.ErrorS(err, "croncontroller: cannot load namespaced configuration, using global configuration",
+			"worker", w.WorkerName(),
+			"namespace", namespace,
+		)
+		namespaceCfg = cfg
+	}
+	cache[namespace] = namespaceCfg
+	return namespaceCfg
+}
+
 // flushKeys will read all keys to be flushed, and flush it from the nextScheduleTime precomputed map.
 func (w *CronWorker) flushKeys() {
 	flushes := 0
@@ -228,6 +255,7 @@ func (w *CronWorker) syncOne(
 		"name", jobConfig.GetName(),
 	)
 	w.schedule.BumpNextScheduleTime(jobConfig, now, expr)
+	w.eventSink.Record(eventsink.NewScheduleMissedEvent(jobConfig, now, err.Error()))
 
 	return nil
 }
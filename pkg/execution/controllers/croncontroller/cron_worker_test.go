@@ -34,6 +34,7 @@ import (
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/croncontroller"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
 	"github.com/furiko-io/furiko/pkg/utils/testutils"
 )
@@ -100,6 +101,10 @@ func TestCronWorker(t *testing.T) { // nolint:gocognit
 		Update      *execution.JobConfig
 		Delete      *execution.JobConfig
 		WantEnqueue []string
+
+		// WantScheduleMissed is the number of ScheduleMissed events expected to be
+		// recorded to the event sink in this step.
+		WantScheduleMissed int
 	}
 	tests := []struct {
 		name       string
@@ -321,6 +326,7 @@ func TestCronWorker(t *testing.T) { // nolint:gocognit
 						keyFunc(cronWorkerJobConfig, testutils.Mktime("2022-04-01T10:55:00Z")),
 						keyFunc(cronWorkerJobConfig, testutils.Mktime("2022-04-01T10:56:00Z")),
 					},
+					WantScheduleMissed: 1,
 				},
 				{
 					Name: "Enqueue once for next minute",
@@ -369,7 +375,9 @@ func TestCronWorker(t *testing.T) { // nolint:gocognit
 			// Initialize contexts and worker
 			c := mock.NewContext()
 			c.MockConfigs().SetConfigs(tt.configs)
-			ctrlContext := croncontroller.NewContext(c)
+			ctrlContext := croncontroller.NewContext(c, nil)
+			eventSink := &fakeEventSink{}
+			ctrlContext.SetEventSink(eventSink)
 			queue := newEnqueueHandler()
 			worker := croncontroller.NewCronWorker(ctrlContext, queue)
 			executionClient := c.MockClientsets().Furiko().ExecutionV1alpha1()
@@ -443,11 +451,39 @@ func TestCronWorker(t *testing.T) { // nolint:gocognit
 
 				// Queue should now be empty.
 				assert.Equal(t, 0, queue.Len(), msgAndArgs...)
+
+				// Check for missed schedule events.
+				events := eventSink.Flush()
+				assert.Len(t, events, step.WantScheduleMissed, msgAndArgs...)
+				for _, event := range events {
+					assert.Equal(t, eventsink.KindScheduleMissed, event.Kind, msgAndArgs...)
+				}
 			}
 		})
 	}
 }
 
+type fakeEventSink struct {
+	events []*eventsink.Event
+	mu     sync.Mutex
+}
+
+var _ eventsink.Recorder = (*fakeEventSink)(nil)
+
+func (s *fakeEventSink) Record(event *eventsink.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *fakeEventSink) Flush() []*eventsink.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.events
+	s.events = nil
+	return events
+}
+
 type enqueueHandler struct {
 	queue []string
 	mu    sync.RWMutex
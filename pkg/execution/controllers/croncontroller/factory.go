@@ -18,16 +18,21 @@ package croncontroller
 
 import (
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
 )
 
 const controllerName = "CronController"
 
-type Factory struct{}
+type Factory struct {
+	eventSink eventsink.Recorder
+}
 
-func NewFactory() *Factory {
-	return &Factory{}
+// NewFactory returns a new Factory. Skipped schedules of JobConfigs are
+// recorded to the given Recorder, which may be nil to discard them.
+func NewFactory(eventSink eventsink.Recorder) *Factory {
+	return &Factory{eventSink: eventSink}
 }
 
 func (f *Factory) Name() string {
@@ -37,6 +42,7 @@ func (f *Factory) Name() string {
 func (f *Factory) New(
 	ctrlContext controllercontext.Context,
 	concurrencySpec *configv1alpha1.ExecutionControllerConcurrencySpec,
+	rateLimiterSpec *configv1alpha1.ExecutionControllerRateLimitersSpec,
 ) (controllermanager.Controller, error) {
-	return NewController(ctrlContext, concurrencySpec.Cron)
+	return NewController(ctrlContext, concurrencySpec.Cron, rateLimiterSpec.Cron, f.eventSink)
 }
@@ -19,9 +19,40 @@ package croncontroller
 import (
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
 	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
 )
 
+const (
+	promNamespace = "furiko"
+)
+
+var (
+	cronTriggerLag = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: promNamespace,
+			Name:      "cron_trigger_lag_seconds",
+			Help:      "Latency between the scheduled time of a JobConfig and when its Job was created",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 15),
+		},
+		[]string{"namespace"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		cronTriggerLag,
+	)
+}
+
+// ObserveCronTriggerLag records a metric for the latency of creating a Job
+// after its scheduled time.
+func ObserveCronTriggerLag(namespace string, lag time.Duration) {
+	cronTriggerLag.WithLabelValues(namespace).Observe(lag.Seconds())
+}
+
 // instrumentWorkerMetrics wraps a function to instrument start and ends time for the worker.
 func instrumentWorkerMetrics(name string, fn func()) func() {
 	return func() {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
 	utiltrace "k8s.io/utils/trace"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
@@ -30,6 +31,7 @@ import (
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
 )
 
 // Reconciler creates Jobs that are queued to be started from the workqueue,
@@ -65,7 +67,15 @@ func (w *Reconciler) Name() string {
 }
 
 func (w *Reconciler) Concurrency() int {
-	return controllerutil.GetConcurrencyOrDefaultCPUFactor(w.concurrency, 4)
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "croncontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.Cron
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
 }
 
 func (w *Reconciler) MaxRequeues() int {
@@ -150,6 +160,7 @@ func (w *Reconciler) processCronForConfig(ctx context.Context, namespace, name s
 			return errors.Wrapf(err, "could not create new job")
 		}
 		trace.Step("Create job done")
+		ObserveCronTriggerLag(namespace, ktime.Now().Sub(scheduleTime))
 	}
 
 	return nil
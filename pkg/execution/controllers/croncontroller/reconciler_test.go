@@ -214,7 +214,7 @@ func TestReconciler(t *testing.T) {
 
 			c := mock.NewContext()
 			c.MockConfigs().SetConfigs(tt.cfgs)
-			ctrlCtx := croncontroller.NewContext(c)
+			ctrlCtx := croncontroller.NewContext(c, nil)
 			control := tt.control
 			if control == nil {
 				control = &mockControl{}
@@ -22,13 +22,12 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
-	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/scheme"
-	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/utils/logvalues"
 )
 
@@ -45,12 +44,17 @@ type Recorder interface {
 }
 
 type defaultRecorder struct {
-	name     string
-	recorder record.EventRecorder
+	name      string
+	recorder  record.EventRecorder
+	eventSink eventsink.Recorder
 }
 
 func newDefaultRecorder(ctrlContext *Context, name string) *defaultRecorder {
-	return &defaultRecorder{name: name, recorder: newEventRecorder(ctrlContext)}
+	return &defaultRecorder{
+		name:      name,
+		recorder:  newEventRecorder(ctrlContext),
+		eventSink: ctrlContext.eventSink,
+	}
 }
 
 var _ Recorder = (*defaultRecorder)(nil)
@@ -98,13 +102,10 @@ func (r *defaultRecorder) SkippedJobSchedule(
 		"numQueued", jobConfig.Status.Queued,
 	)
 	r.recorder.Event(jobConfig, corev1.EventTypeWarning, "SkippedJobSchedule", message)
+	r.eventSink.Record(eventsink.NewScheduleMissedEvent(jobConfig, scheduleTime, message))
 }
 
 // newEventRecorder returns a new EventRecorder for the controller.
-func newEventRecorder(context controllercontext.Context) record.EventRecorder {
-	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
-		Interface: context.Clientsets().Kubernetes().CoreV1().Events(""),
-	})
-	return eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerName})
+func newEventRecorder(ctrlContext *Context) record.EventRecorder {
+	return ctrlContext.eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerName})
 }
@@ -22,13 +22,13 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	"github.com/furiko-io/furiko/pkg/execution/taskexecutor"
 	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/scheme"
 	executioninformers "github.com/furiko-io/furiko/pkg/generated/informers/externalversions/execution/v1alpha1"
@@ -56,26 +56,29 @@ type Context struct {
 	hasSynced         []cache.InformerSynced
 	queue             workqueue.RateLimitingInterface
 	recorder          record.EventRecorder
+	eventBroadcaster  record.EventBroadcaster
 	tasks             *taskexecutor.Manager
+	eventSink         eventsink.Recorder
 }
 
-func NewContext(context controllercontext.Context) *Context {
-	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
-		Interface: context.Clientsets().Kubernetes().CoreV1().Events(""),
-	})
+func NewContext(context controllercontext.Context, rateLimiter *configv1alpha1.RateLimiterSpec) *Context {
+	eventBroadcaster := context.NewEventBroadcaster()
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerName})
-	return NewContextWithRecorder(context, recorder)
+	c := NewContextWithRecorder(context, recorder, rateLimiter)
+	c.eventBroadcaster = eventBroadcaster
+	return c
 }
 
-func NewContextWithRecorder(context controllercontext.Context, recorder record.EventRecorder) *Context {
+func NewContextWithRecorder(
+	context controllercontext.Context, recorder record.EventRecorder, rateLimiter *configv1alpha1.RateLimiterSpec,
+) *Context {
 	c := &Context{Context: context}
 
 	// Set recorder.
 	c.recorder = recorder
 
 	// Create workqueue.
-	ratelimiter := workqueue.DefaultControllerRateLimiter()
+	ratelimiter := controllerutil.NewRateLimiter(rateLimiter)
 	c.queue = workqueue.NewNamedRateLimitingQueue(ratelimiter, controllerName)
 
 	// Bind informers.
@@ -89,9 +92,18 @@ func NewContextWithRecorder(context controllercontext.Context, recorder record.E
 	// Add task manager
 	c.tasks = taskexecutor.NewManager(context.Clientsets(), context.Informers())
 
+	// Discard state transitions unless a Recorder is set.
+	c.eventSink = eventsink.NopRecorder{}
+
 	return c
 }
 
+// SetEventSink sets the Recorder that state transitions of JobConfigs are
+// recorded to.
+func (c *Context) SetEventSink(recorder eventsink.Recorder) {
+	c.eventSink = recorder
+}
+
 func (c *Context) GetHasSynced() []cache.InformerSynced {
 	return c.hasSynced
 }
@@ -99,13 +111,18 @@ func (c *Context) GetHasSynced() []cache.InformerSynced {
 func NewController(
 	ctrlContext controllercontext.Context,
 	concurrency *configv1alpha1.Concurrency,
+	rateLimiter *configv1alpha1.RateLimiterSpec,
+	eventSink eventsink.Recorder,
 ) (*Controller, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ctrl := &Controller{
-		Context:   NewContext(ctrlContext),
+		Context:   NewContext(ctrlContext, rateLimiter),
 		ctx:       ctx,
 		terminate: cancel,
 	}
+	if eventSink != nil {
+		ctrl.SetEventSink(eventSink)
+	}
 
 	ctrl.informerWorker = NewInformerWorker(ctrl.Context)
 	ctrl.reconciler = reconciler.NewController(NewReconciler(ctrl.Context, concurrency), ctrl.queue)
@@ -132,15 +149,28 @@ func (c *Controller) Run(ctx context.Context) error {
 
 func (c *Controller) Shutdown(ctx context.Context) {
 	klog.InfoS("jobconfigcontroller: shutting down")
+
+	// Stop accepting new items and wait for in-flight syncs to complete, before
+	// canceling any syncs that are still running once the deadline is exceeded.
+	if err := c.reconciler.Drain(ctx); err != nil {
+		klog.ErrorS(err, "jobconfigcontroller: cannot drain in-flight syncs before deadline")
+	}
 	c.terminate()
-	c.queue.ShutDown()
 	c.reconciler.Wait()
+
+	// Flush any pending events.
+	if c.eventBroadcaster != nil {
+		c.eventBroadcaster.Shutdown()
+	}
 	klog.InfoS("jobconfigcontroller: stopped controller")
 }
 
 func (c *Controller) GetHealth() controllermanager.HealthStatus {
 	return controllermanager.HealthStatus{
-		Name:    controllerName,
-		Healthy: atomic.LoadUint64(&c.healthStatus) == 1,
+		Name:               controllerName,
+		Healthy:            atomic.LoadUint64(&c.healthStatus) == 1,
+		CachesSynced:       controllerutil.HasSynced(c.hasSynced...),
+		QueueDepth:         c.reconciler.QueueDepth(),
+		LastSuccessfulSync: c.reconciler.LastSuccessfulSync(),
 	}
 }
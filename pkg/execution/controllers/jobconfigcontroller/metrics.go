@@ -0,0 +1,67 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfigcontroller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	promNamespace = "furiko"
+)
+
+var (
+	jobConfigActiveJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: promNamespace,
+			Name:      "jobconfig_active_jobs",
+			Help:      "Number of active Jobs for each JobConfig",
+		},
+		[]string{"namespace", "jobconfig"},
+	)
+
+	jobConfigQueuedJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: promNamespace,
+			Name:      "jobconfig_queued_jobs",
+			Help:      "Number of queued Jobs for each JobConfig",
+		},
+		[]string{"namespace", "jobconfig"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		jobConfigActiveJobs,
+		jobConfigQueuedJobs,
+	)
+}
+
+// ObserveJobConfigStatus records metrics for the status of a JobConfig.
+func ObserveJobConfigStatus(rjc *execution.JobConfig) {
+	jobConfigActiveJobs.WithLabelValues(rjc.GetNamespace(), rjc.GetName()).Set(float64(rjc.Status.Active))
+	jobConfigQueuedJobs.WithLabelValues(rjc.GetNamespace(), rjc.GetName()).Set(float64(rjc.Status.Queued))
+}
+
+// DeleteJobConfigMetrics removes all metrics for a JobConfig that was deleted.
+func DeleteJobConfigMetrics(namespace, name string) {
+	jobConfigActiveJobs.DeleteLabelValues(namespace, name)
+	jobConfigQueuedJobs.DeleteLabelValues(namespace, name)
+}
@@ -24,13 +24,14 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	utiltrace "k8s.io/utils/trace"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	"github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
@@ -55,7 +56,15 @@ func (w *Reconciler) Name() string {
 }
 
 func (w *Reconciler) Concurrency() int {
-	return controllerutil.GetConcurrencyOrDefaultCPUFactor(w.concurrency, 4)
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "jobconfigcontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.JobConfig
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
 }
 
 func (w *Reconciler) MaxRequeues() int {
@@ -80,6 +89,7 @@ func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int)
 
 	rjc, err := w.jobconfigInformer.Lister().JobConfigs(namespace).Get(name)
 	if kerrors.IsNotFound(err) {
+		DeleteJobConfigMetrics(namespace, name)
 		return nil
 	}
 	if err != nil {
@@ -112,16 +122,41 @@ func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int)
 		newRjc.Status.LastScheduled = ktime.TimeMax(lastScheduleTime, newRjc.Status.LastScheduled)
 	}
 
+	// Update cumulative counters and summary of the last Job.
+	jobconfig.UpdateJobSummary(&newRjc.Status, rjs)
+
+	// Update next schedule time.
+	nextScheduled, scheduleErr := w.getNextScheduleTime(newRjc)
+	if scheduleErr != nil {
+		klog.ErrorS(scheduleErr, "jobconfigcontroller: cannot compute next schedule time",
+			"worker", w.Name(),
+			"namespace", namespace,
+			"name", name,
+		)
+	}
+	newRjc.Status.NextScheduled = nextScheduled
+
 	// Compute final state.
 	newRjc.Status.State = jobconfig.GetState(newRjc)
+	newRjc.Status.Conditions = jobconfig.GetConditions(newRjc, scheduleErr)
+	ObserveJobConfigStatus(newRjc)
 
 	// Update JobConfig status.
 	if isEqual, err := IsJobConfigStatusEqual(rjc, newRjc); err == nil && !isEqual {
-		updatedRjc, err := w.Clientsets().Furiko().ExecutionV1alpha1().JobConfigs(newRjc.GetNamespace()).
-			UpdateStatus(ctx, newRjc, metav1.UpdateOptions{})
+		// The resource usage is managed by the ResourceUsageController, and is
+		// omitted from the applied status to avoid taking ownership of it.
+		status := newRjc.Status.DeepCopy()
+		status.ResourceUsage = nil
+		patch, err := controllerutil.NewStatusApplyPatch(execution.GVKJobConfig, namespace, name, status)
+		if err != nil {
+			return errors.Wrapf(err, "cannot create status patch")
+		}
+		updatedRjc, err := w.Clientsets().Furiko().ExecutionV1alpha1().JobConfigs(namespace).
+			Patch(ctx, name, types.ApplyPatchType, patch, controllerutil.NewApplyPatchOptions(fieldManager), "status")
 		if err != nil {
 			return errors.Wrapf(err, "cannot update job config")
 		}
+		eventsink.RecordJobConfigTransition(w.eventSink, rjc, newRjc, fieldManager)
 		rjc = updatedRjc
 
 		klog.V(3).InfoS("jobconfigcontroller: updated job config", logvalues.
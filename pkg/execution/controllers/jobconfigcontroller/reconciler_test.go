@@ -35,7 +35,6 @@ import (
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
 	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
 	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
-	"github.com/furiko-io/furiko/pkg/utils/ktime"
 	"github.com/furiko-io/furiko/pkg/utils/testutils"
 )
 
@@ -43,6 +42,8 @@ const (
 	createTime1   = "2022-04-01T04:01:00Z"
 	createTime2   = "2022-04-01T04:00:00Z"
 	startTime     = "2022-04-01T04:01:01Z"
+	startTime2    = "2022-04-01T04:01:02Z"
+	nextTime      = "2022-04-01T05:00:00Z"
 	testNamespace = "test"
 	jobConfigUID1 = "0ed1bc76-07ca-4cf7-9a47-a0cc4aec48b9"
 	jobConfigUID2 = "6e08ee33-ccbe-4fc5-9c46-e29c19cc2fcb"
@@ -64,6 +65,21 @@ var (
 		[]*execution.Job{job1Queued}, []*execution.Job{})
 	jobConfig1Executing = makeJobConfig(jobConfig1, execution.JobConfigExecuting,
 		[]*execution.Job{}, []*execution.Job{job1Running})
+	jobConfig1Finished = withJobSummary(makeJobConfig(jobConfig1, execution.JobConfigReady, nil, nil),
+		job1Running, job1Finished)
+	jobConfig1Deleted = withJobSummary(makeJobConfig(jobConfig1, execution.JobConfigReady, nil, nil),
+		job1Running)
+
+	jobConfig1Scheduled = &execution.JobConfig{
+		ObjectMeta: jobConfig1.ObjectMeta,
+		Spec: execution.JobConfigSpec{
+			Schedule: &execution.ScheduleSpec{
+				Cron: &execution.CronSchedule{
+					Expression: "0 5 * * *",
+				},
+			},
+		},
+	}
 
 	jobConfig2 = makeJobConfig(&execution.JobConfig{
 		ObjectMeta: metav1.ObjectMeta{
@@ -112,7 +128,11 @@ var (
 			UID:               jobUID2,
 		},
 	}
-	job2Running = makeJob(job2, execution.JobRunning)
+	job2Running = func() *execution.Job {
+		newJob := makeJob(job2, execution.JobRunning)
+		newJob.Status.StartTime = testutils.Mkmtimep(startTime2)
+		return newJob
+	}()
 
 	scheduledJob1 = makeJob(&execution.Job{
 		ObjectMeta: metav1.ObjectMeta{
@@ -132,7 +152,7 @@ var (
 func TestReconciler(t *testing.T) {
 	test := runtimetesting.ReconcilerTest{
 		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
-			return jobconfigcontroller.NewContextWithRecorder(c, recorder)
+			return jobconfigcontroller.NewContextWithRecorder(c, recorder, nil)
 		},
 		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
 			return jobconfigcontroller.NewReconciler(
@@ -140,6 +160,7 @@ func TestReconciler(t *testing.T) {
 				runtimetesting.ReconcilerDefaultConcurrency,
 			)
 		},
+		Now: testutils.Mktime(startTime),
 		Stores: []mock.StoreFactory{
 			activejobstore.NewFactory(),
 		},
@@ -159,7 +180,7 @@ func TestReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, jobConfig1Ready),
+						newApplyJobConfigStatusAction(jobConfig1Ready),
 					},
 				},
 			},
@@ -175,7 +196,7 @@ func TestReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, jobConfig1JobQueued),
+						newApplyJobConfigStatusAction(jobConfig1JobQueued),
 					},
 				},
 			},
@@ -192,7 +213,7 @@ func TestReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, jobConfig1Executing),
+						newApplyJobConfigStatusAction(jobConfig1Executing),
 					},
 				},
 			},
@@ -204,7 +225,7 @@ func TestReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, jobConfig1Executing),
+						newApplyJobConfigStatusAction(jobConfig1Executing),
 					},
 				},
 			},
@@ -221,7 +242,7 @@ func TestReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, jobConfig1Ready),
+						newApplyJobConfigStatusAction(jobConfig1Finished),
 					},
 				},
 			},
@@ -240,7 +261,7 @@ func TestReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, jobConfig1Ready),
+						newApplyJobConfigStatusAction(jobConfig1Deleted),
 					},
 				},
 			},
@@ -268,7 +289,7 @@ func TestReconciler(t *testing.T) {
 						func() (runtimetesting.Action, error) {
 							newJobConfig := makeJobConfig(jobConfig1, execution.JobConfigExecuting,
 								[]*execution.Job{}, []*execution.Job{job1Running, job2Running})
-							return runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, newJobConfig), nil
+							return newApplyJobConfigStatusAction(newJobConfig), nil
 						},
 					},
 				},
@@ -285,19 +306,48 @@ func TestReconciler(t *testing.T) {
 							newJobConfig := makeJobConfig(jobConfig1, execution.JobConfigExecuting,
 								[]*execution.Job{}, []*execution.Job{scheduledJob1})
 							newJobConfig.Status.LastScheduled = testutils.Mkmtimep(startTime)
-							return runtimetesting.NewUpdateJobConfigStatusAction(testNamespace, newJobConfig), nil
+							return newApplyJobConfigStatusAction(newJobConfig), nil
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "update JobConfig status with NextScheduled",
+			Target: jobConfig1Scheduled,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					ActionGenerators: []runtimetesting.ActionGenerator{
+						func() (runtimetesting.Action, error) {
+							newJobConfig := makeJobConfig(jobConfig1Scheduled, execution.JobConfigReadyEnabled, nil, nil)
+							newJobConfig.Status.NextScheduled = testutils.Mkmtimep(nextTime)
+							return newApplyJobConfigStatusAction(newJobConfig), nil
 						},
 					},
 				},
 			},
 		},
+		{
+			Name: "no NextScheduled for JobConfig with disabled schedule",
+			Target: makeJobConfig(&execution.JobConfig{
+				ObjectMeta: jobConfig1.ObjectMeta,
+				Spec: execution.JobConfigSpec{
+					Schedule: &execution.ScheduleSpec{
+						Cron: &execution.CronSchedule{
+							Expression: "0 5 * * *",
+						},
+						Disabled: true,
+					},
+				},
+			}, execution.JobConfigReadyDisabled, nil, nil),
+		},
 	})
 }
 
 func makeJob(job *execution.Job, phase execution.JobPhase) *execution.Job {
 	newJob := job.DeepCopy()
 	if phase != execution.JobQueued {
-		newJob.Status.StartTime = ktime.Now()
+		newJob.Status.StartTime = testutils.Mkmtimep(startTime)
 	}
 	newJob.Status.Phase = phase
 	return newJob
@@ -315,5 +365,28 @@ func makeJobConfig(
 	newJobConfig.Status.QueuedJobs = jobconfigcontroller.ToJobReferences(queued)
 	newJobConfig.Status.Active = int64(len(active))
 	newJobConfig.Status.ActiveJobs = jobconfigcontroller.ToJobReferences(active)
+	jobconfig.UpdateJobSummary(&newJobConfig.Status, append(append([]*execution.Job{}, queued...), active...))
+	newJobConfig.Status.Conditions = jobconfig.GetConditions(newJobConfig, nil)
+	for i := range newJobConfig.Status.Conditions {
+		newJobConfig.Status.Conditions[i].LastTransitionTime = testutils.Mkmtime(startTime)
+	}
 	return newJobConfig
 }
+
+// withJobSummary returns a new JobConfig after updating its status with the
+// summary of the given Jobs.
+func withJobSummary(jobConfig *execution.JobConfig, jobs ...*execution.Job) *execution.JobConfig {
+	newJobConfig := jobConfig.DeepCopy()
+	for _, rj := range jobs {
+		jobconfig.UpdateJobSummary(&newJobConfig.Status, []*execution.Job{rj})
+	}
+	return newJobConfig
+}
+
+// newApplyJobConfigStatusAction returns the Action that applies the status of
+// the JobConfig, which omits the resource usage.
+func newApplyJobConfigStatusAction(rjc *execution.JobConfig) runtimetesting.Action {
+	status := rjc.Status.DeepCopy()
+	status.ResourceUsage = nil
+	return runtimetesting.NewApplyJobConfigStatusAction(rjc.Namespace, rjc.Name, status)
+}
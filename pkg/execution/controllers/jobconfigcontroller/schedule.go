@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfigcontroller
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/tzutils"
+	"github.com/furiko-io/furiko/pkg/execution/util/cronparser"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	defaultTimezone = "UTC"
+)
+
+// getNextScheduleTime returns the next time that the JobConfig is expected to
+// be scheduled by cron, which is displayed in its status for informational
+// purposes only. Returns nil if the JobConfig will not be scheduled.
+func (w *Reconciler) getNextScheduleTime(rjc *execution.JobConfig) (*metav1.Time, error) {
+	schedule := rjc.Spec.Schedule
+	if schedule == nil || schedule.Disabled || schedule.Cron == nil || len(schedule.Cron.Expression) == 0 {
+		return nil, nil
+	}
+
+	cfg, err := w.Configs().CronForNamespace(rjc.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load controller configuration")
+	}
+
+	namespacedName, err := cache.MetaNamespaceKeyFunc(rjc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get namespaced name")
+	}
+	expr, err := cronparser.NewParser(cfg).Parse(schedule.Cron.Expression, namespacedName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse cron schedule: %v", schedule.Cron.Expression)
+	}
+
+	tzstring := schedule.Cron.Timezone
+	if tzstring == "" {
+		tzstring = defaultTimezone
+		if tz := cfg.DefaultTimezone; tz != nil && len(*tz) > 0 {
+			tzstring = *tz
+		}
+	}
+	timezone, err := tzutils.ParseTimezone(tzstring)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse timezone: %v", tzstring)
+	}
+
+	fromTime := ktime.Now().Time
+	if constraints := schedule.Constraints; constraints != nil {
+		if nbf := constraints.NotBefore; !nbf.IsZero() && fromTime.Before(nbf.Time) {
+			fromTime = nbf.Time.Add(-time.Nanosecond)
+		}
+	}
+
+	next := expr.Next(fromTime.In(timezone))
+	if next.IsZero() {
+		return nil, nil
+	}
+	if constraints := schedule.Constraints; constraints != nil {
+		if naf := constraints.NotAfter; !naf.IsZero() && next.After(naf.Time) {
+			return nil, nil
+		}
+	}
+
+	nextTime := metav1.NewTime(next)
+	return &nextTime, nil
+}
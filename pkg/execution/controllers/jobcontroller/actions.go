@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobcontroller
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// handleRequestKill sets the kill timestamp of the Job from the RequestKill
+// annotation, unless an earlier kill timestamp is already set. The annotation
+// is always cleared once handled.
+func (w *Reconciler) handleRequestKill(rj *execution.Job) *execution.Job {
+	killTimestamp, ok, err := jobutil.GetRequestedKillTimestamp(rj)
+	if !ok {
+		return rj
+	}
+
+	newRj := rj.DeepCopy()
+	delete(newRj.Annotations, jobutil.AnnotationKeyRequestKill)
+
+	if err != nil {
+		w.recorder.Eventf(rj, corev1.EventTypeWarning, "KillRequestInvalid",
+			"Cannot handle kill request: %v", err)
+		return newRj
+	}
+
+	// Nothing to kill if already finished.
+	if rj.Status.Phase.IsTerminal() {
+		return newRj
+	}
+
+	// Existing kill timestamp is earlier, do not update it.
+	if ktime.IsTimeSetAndEarlierThanOrEqualTo(rj.Spec.KillTimestamp, killTimestamp.Time) {
+		return newRj
+	}
+
+	klog.InfoS("jobcontroller: setting kill timestamp from kill request",
+		"worker", w.Name(),
+		"namespace", rj.GetNamespace(),
+		"name", rj.GetName(),
+		"killTimestamp", killTimestamp,
+	)
+
+	w.recorder.Eventf(rj, corev1.EventTypeNormal, "KillRequested",
+		"Job will be killed at %v as requested", killTimestamp.Format(time.RFC3339))
+
+	newRj.Spec.KillTimestamp = &killTimestamp
+	return newRj
+}
+
+// handleRequestRetry creates a new Job to retry the Job if requested via the
+// RequestRetry annotation. The annotation is kept until the Job is finished,
+// and is cleared once the new Job is created.
+func (w *Reconciler) handleRequestRetry(ctx context.Context, rj *execution.Job) (*execution.Job, error) {
+	retryTime, ok, err := jobutil.GetRequestedRetryTimestamp(rj)
+	if !ok || isDeleted(rj) {
+		return rj, nil
+	}
+
+	newRj := rj.DeepCopy()
+	delete(newRj.Annotations, jobutil.AnnotationKeyRequestRetry)
+
+	if err != nil {
+		w.recorder.Eventf(rj, corev1.EventTypeWarning, "RetryRequestInvalid",
+			"Cannot handle retry request: %v", err)
+		return newRj, nil
+	}
+
+	// Wait for the Job to be finished before retrying it.
+	if !rj.Status.Phase.IsTerminal() {
+		return rj, nil
+	}
+
+	retryRj := jobutil.NewRetryJob(rj, retryTime)
+	createdRj, err := w.client.CreateJob(ctx, retryRj)
+
+	// The Job for this request was already created previously.
+	if kerrors.IsAlreadyExists(err) {
+		return newRj, nil
+	}
+
+	// Do not retry invalid requests, and instead store as an event.
+	if kerrors.IsInvalid(err) || kerrors.IsForbidden(err) {
+		w.recorder.Eventf(rj, corev1.EventTypeWarning, "RetryFailed",
+			"Cannot create Job to retry this Job: %v", err)
+		return newRj, nil
+	}
+
+	if err != nil {
+		return rj, errors.Wrapf(err, "cannot create job")
+	}
+
+	w.recorder.Eventf(rj, corev1.EventTypeNormal, "Retried",
+		"Created Job %v to retry this Job", createdRj.Name)
+
+	return newRj, nil
+}
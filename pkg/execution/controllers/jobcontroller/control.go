@@ -23,10 +23,12 @@ import (
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	executionv1alpha1 "github.com/furiko-io/furiko/pkg/generated/clientset/versioned/typed/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
 	"github.com/furiko-io/furiko/pkg/utils/logvalues"
 )
 
@@ -43,6 +45,21 @@ func NewExecutionControl(client executionv1alpha1.ExecutionV1alpha1Interface, na
 	}
 }
 
+func (c *ExecutionControl) CreateJob(ctx context.Context, rj *execution.Job) (*execution.Job, error) {
+	createdRj, err := c.client.Jobs(rj.GetNamespace()).Create(ctx, rj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	klog.V(3).InfoS("jobcontroller: created job", logvalues.
+		Values("worker", c.name, "namespace", createdRj.GetNamespace(), "name", createdRj.GetName()).
+		Level(4, "job", createdRj).
+		Build()...,
+	)
+
+	return createdRj, nil
+}
+
 func (c *ExecutionControl) UpdateJob(ctx context.Context, rj, newRj *execution.Job) (bool, error) {
 	// No need to update if equal.
 	if isEqual, err := IsJobEqual(rj, newRj); err != nil {
@@ -81,7 +98,18 @@ func (c *ExecutionControl) UpdateJobStatus(ctx context.Context, rj, newRj *execu
 		klog.V(5).Infof("jobcontroller: updating job status, diff = %v", cmp.Diff(rj, newRj))
 	}
 
-	updatedRj, err := c.client.Jobs(rj.GetNamespace()).UpdateStatus(ctx, newRj, metav1.UpdateOptions{})
+	// Use server-side apply to avoid conflicts with other controllers that
+	// update a subset of the status, such as the startTime. The callback status
+	// is managed by the CallbackController, and is omitted from the applied
+	// status to avoid taking ownership of it.
+	status := newRj.Status.DeepCopy()
+	status.Callback = nil
+	patch, err := controllerutil.NewStatusApplyPatch(execution.GVKJob, rj.GetNamespace(), rj.GetName(), status)
+	if err != nil {
+		return false, errors.Wrapf(err, "cannot create status patch")
+	}
+	updatedRj, err := c.client.Jobs(rj.GetNamespace()).Patch(ctx, rj.GetName(), types.ApplyPatchType, patch,
+		controllerutil.NewApplyPatchOptions(fieldManager), "status")
 	if err != nil {
 		return false, err
 	}
@@ -93,12 +93,13 @@ func TestExecutionControl(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, updated)
 
-	// Ensure it was updated
+	// Ensure only the status was updated
 	job, err = client.Jobs(fakeJob.Namespace).Get(ctx, fakeJob.Name, metav1.GetOptions{})
 	assert.NoError(t, err)
-	equal, err = cmp.IsJSONEqual(job, newJob)
+	equal, err = cmp.IsJSONEqual(job.Status, newJob.Status)
 	assert.NoError(t, err)
 	assert.True(t, equal)
+	assert.NotNil(t, job.Spec.KillTimestamp)
 
 	// Delete should succeed
 	err = control.DeleteJob(ctx, fakeJob, metav1.DeleteOptions{})
@@ -31,6 +31,7 @@ import (
 	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
 	"github.com/furiko-io/furiko/pkg/execution/tasks"
 	"github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/utils/meta"
 	"github.com/furiko-io/furiko/pkg/utils/testutils"
 )
@@ -38,6 +39,7 @@ import (
 const (
 	jobNamespace = "test"
 	jobName      = "my-sample-job"
+	jobConfigUID = "0ed1bc76-07ca-4cf7-9a47-a0cc4aec48b9"
 
 	createTime = "2021-02-09T04:06:00Z"
 	startTime  = "2021-02-09T04:06:01Z"
@@ -46,6 +48,12 @@ const (
 	now        = "2021-02-09T04:06:05Z"
 	later15m   = "2021-02-09T04:21:00Z"
 	later60m   = "2021-02-09T05:06:00Z"
+
+	// 1 second after killTime.
+	preKillHookTime = "2021-02-09T04:06:11Z"
+
+	// 10 minutes after startTime.
+	maxRuntimeKillTime = "2021-02-09T04:16:01Z"
 )
 
 var (
@@ -92,6 +100,13 @@ var (
 	// Job with pod pending.
 	fakeJobPending = generateJobStatusFromPod(fakeJob, fakePodPending)
 
+	// Job with pod pending, which should fail fast on the pod's waiting reason.
+	fakeJobPendingFailFast = func() *execution.Job {
+		newJob := fakeJobPending.DeepCopy()
+		newJob.Spec.Template.Task.FailFastPendingReasons = []string{"ErrImagePull", "ImagePullBackOff"}
+		return newJob
+	}()
+
 	// Job with kill timestamp.
 	fakeJobWithKillTimestamp = func() *execution.Job {
 		newJob := fakeJobPending.DeepCopy()
@@ -99,6 +114,38 @@ var (
 		return newJob
 	}()
 
+	// Job with pod running and kill timestamp, which specifies a pre-kill hook.
+	fakeJobWithPreKillHook = func() *execution.Job {
+		newJob := fakeJob.DeepCopy()
+		newJob.Spec.KillTimestamp = testutils.Mkmtimep(killTime)
+		newJob.Spec.Template.Task.PreKillHook = &execution.TaskPreKillHook{
+			Command:        []string{"/bin/checkpoint"},
+			TimeoutSeconds: pointer.Int64(60),
+		}
+		return generateJobStatusFromPod(newJob, fakePodRunning)
+	}()
+
+	// Job that is suspended.
+	fakeJobSuspended = func() *execution.Job {
+		newJob := fakeJobPending.DeepCopy()
+		newJob.Spec.Suspend = true
+		return newJob
+	}()
+
+	// Job that is suspended with pod marked to be killed.
+	fakeJobSuspendedPodKilled = func() *execution.Job {
+		newJob := fakeJobSuspended.DeepCopy()
+		newJob.Status.Tasks[0].Suspended = true
+		return generateJobStatusFromPod(newJob, fakePodPending)
+	}()
+
+	// Job that was resumed after its pod was killed due to suspension.
+	fakeJobResumed = func() *execution.Job {
+		newJob := fakeJobSuspendedPodKilled.DeepCopy()
+		newJob.Spec.Suspend = false
+		return generateJobStatusFromPod(newJob, fakePodFinishedSuspended)
+	}()
+
 	// Job with deletion timestamp.
 	fakeJobWithDeletionTimestamp = func() *execution.Job {
 		newJob := fakeJobPending.DeepCopy()
@@ -106,6 +153,21 @@ var (
 		return newJob
 	}()
 
+	// Job with deletion timestamp whose pods should be orphaned.
+	fakeJobWithDeletionTimestampAndOrphanPolicy = func() *execution.Job {
+		newJob := fakeJob.DeepCopy()
+		newJob.DeletionTimestamp = testutils.Mkmtimep(killTime)
+		newJob.Spec.Template.TaskDeletionPolicy = execution.TaskDeletionPolicyOrphan
+		return generateJobStatusFromPod(newJob, fakePodRunning)
+	}()
+
+	// Job with deletion timestamp whose pods were orphaned.
+	fakeJobWithDeletionTimestampAndOrphanedPods = func() *execution.Job {
+		newJob := generateJobStatusFromPod(fakeJobWithDeletionTimestampAndOrphanPolicy, fakePodRunningOrphaned)
+		newJob.Finalizers = meta.RemoveFinalizer(newJob.Finalizers, executiongroup.DeleteDependentsFinalizer)
+		return newJob
+	}()
+
 	// Job with deletion timestamp whose pods are killed.
 	fakeJobWithDeletionTimestampAndKilledPods = func() *execution.Job {
 		newJob := fakeJobWithDeletionTimestamp.DeepCopy()
@@ -149,6 +211,7 @@ var (
 		}
 		newJob.Status.Tasks[0].DeletedStatus = newJob.Status.Tasks[0].Status.DeepCopy()
 		newJob.Status.Tasks[0].FinishTimestamp = testutils.Mkmtimep(killTime)
+		newJob.Status.Conditions = job.GetConditions(newJob)
 		return newJob
 	}()
 
@@ -159,6 +222,51 @@ var (
 		return newJob
 	}()
 
+	// Job with running timeout and pod running.
+	fakeJobWithRunningTimeout = func() *execution.Job {
+		newJob := fakeJob.DeepCopy()
+		newJob.Spec.Template.Task.RunningTimeoutSeconds = pointer.Int64(600)
+		return generateJobStatusFromPod(newJob, fakePodRunning)
+	}()
+
+	// Job with stall detection and pod running.
+	fakeJobWithStallDetection = func() *execution.Job {
+		newJob := fakeJob.DeepCopy()
+		newJob.Spec.Template.Task.StallDetection = &execution.TaskStallDetection{
+			TimeoutSeconds: 600,
+		}
+		return generateJobStatusFromPod(newJob, fakePodRunning)
+	}()
+
+	// Job with stall detection that kills stalled tasks, and pod running.
+	fakeJobWithStallDetectionKill = func() *execution.Job {
+		newJob := fakeJobWithStallDetection.DeepCopy()
+		newJob.Spec.Template.Task.StallDetection.KillStalledTasks = true
+		return newJob
+	}()
+
+	// Job with a maximum runtime.
+	fakeJobWithMaxRuntime = func() *execution.Job {
+		newJob := fakeJob.DeepCopy()
+		newJob.Spec.Template.MaxRuntimeSeconds = pointer.Int64(600)
+		return generateJobStatusFromPod(newJob, fakePodRunning)
+	}()
+
+	// Job with a maximum runtime and kill timestamp set accordingly.
+	fakeJobWithMaxRuntimeKillTimestamp = func() *execution.Job {
+		newJob := fakeJobWithMaxRuntime.DeepCopy()
+		newJob.Spec.KillTimestamp = testutils.Mkmtimep(maxRuntimeKillTime)
+		job.MarkKilledFromMaxRuntime(newJob, *newJob.Spec.KillTimestamp)
+		return newJob
+	}()
+
+	// Job with a maximum runtime that retries failed tasks.
+	fakeJobWithMaxRuntimeRetry = func() *execution.Job {
+		newJob := withMaxRuntime(fakeJob)
+		newJob.Spec.Template.MaxAttempts = pointer.Int32(2)
+		return newJob
+	}()
+
 	// Job with pod being deleted.
 	fakeJobPodDeleting = func() *execution.Job {
 		newJob := generateJobStatusFromPod(fakeJobWithKillTimestamp, fakePodTerminating)
@@ -171,6 +279,20 @@ var (
 		return newJob
 	}()
 
+	// Job with pod being killed and a long kill grace period.
+	fakeJobPodTerminatingWithKillGracePeriod = func() *execution.Job {
+		newJob := generateJobStatusFromPod(fakeJobWithKillTimestamp, fakePodTerminating)
+		newJob.Spec.Template.Task.KillGracePeriodSeconds = pointer.Int64(600)
+		return newJob
+	}()
+
+	// Job with pod being deleted after a long kill grace period.
+	fakeJobPodDeletingWithKillGracePeriod = func() *execution.Job {
+		newJob := fakeJobPodDeleting.DeepCopy()
+		newJob.Spec.Template.Task.KillGracePeriodSeconds = pointer.Int64(600)
+		return newJob
+	}()
+
 	// Job with pod being deleted and force deletion is not allowed.
 	fakeJobPodDeletingForbidForceDeletion = func() *execution.Job {
 		newJob := fakeJobPodDeleting.DeepCopy()
@@ -190,6 +312,21 @@ var (
 		return newJob
 	}()
 
+	// Job with pod whose node was lost.
+	fakeJobNodeLost = generateJobStatusFromPod(fakeJob, fakePodNodeLost)
+
+	// Job with pod being force deleted after its node was lost.
+	fakeJobNodeLostReplacing = func() *execution.Job {
+		newJob := fakeJobNodeLost.DeepCopy()
+		newJob.Status.Tasks[0].DeletedStatus = &execution.TaskStatus{
+			State:   execution.TaskDeletedFinalStateUnknown,
+			Result:  job.GetResultPtr(execution.JobResultFinalStateUnknown),
+			Reason:  job.ReasonNodeLost,
+			Message: "Task was forcefully deleted after its node was lost, and will be replaced",
+		}
+		return newJob
+	}()
+
 	// Job with pod already deleted.
 	fakeJobPodDeleted = func() *execution.Job {
 		newJob := fakeJobPodDeleting.DeepCopy()
@@ -205,12 +342,58 @@ var (
 		}
 		newJob.Status.Tasks[0].Status = *newJob.Status.Tasks[0].DeletedStatus.DeepCopy()
 		newJob.Status.Tasks[0].FinishTimestamp = testutils.Mkmtimep(now)
+		newJob.Status.Conditions = job.GetConditions(newJob)
 		return newJob
 	}()
 
 	// Job that has succeeded.
 	fakeJobFinished = generateJobStatusFromPod(fakeJobResult, fakePodFinished)
 
+	// Job with pod pending and a kill request.
+	fakeJobPendingWithRequestKill = func() *execution.Job {
+		newJob := fakeJobPending.DeepCopy()
+		meta.SetAnnotation(newJob, job.AnnotationKeyRequestKill, killTime)
+		return newJob
+	}()
+
+	// Job that has succeeded and has a retry request.
+	fakeJobFinishedWithRequestRetry = func() *execution.Job {
+		newJob := fakeJobFinished.DeepCopy()
+		meta.SetAnnotation(newJob, job.AnnotationKeyRequestRetry, later15m)
+		return newJob
+	}()
+
+	// Job created to retry fakeJobFinishedWithRequestRetry.
+	fakeJobRetry = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-retry-" + strconv.Itoa(int(testutils.Mktime(later15m).Unix())),
+			Namespace: jobNamespace,
+			Labels:    map[string]string{},
+			Annotations: map[string]string{
+				job.AnnotationKeyRetriedFrom: jobName,
+			},
+		},
+		Spec: fakeJob.Spec,
+	}
+
+	// Job with ephemeral volume claims, that was created with a fully populated
+	// status.
+	fakeJobWithVolumeClaims = func() *execution.Job {
+		newJob := fakeJobResult.DeepCopy()
+		newJob.Spec.Template.Task.VolumeClaimTemplates = []execution.TaskVolumeClaimTemplate{
+			{
+				Name: "scratch",
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				},
+			},
+		}
+		return newJob
+	}()
+
+	// Job with ephemeral volume claims that has succeeded.
+	fakeJobWithVolumeClaimsFinished = generateJobStatusFromPod(fakeJobWithVolumeClaims, fakePodFinished)
+
 	// Job that has succeeded with a custom TTLSecondsAfterFinished.
 	fakeJobFinishedWithTTLAfterFinished = func() *execution.Job {
 		newJob := fakeJobFinished.DeepCopy()
@@ -218,6 +401,110 @@ var (
 		return newJob
 	}()
 
+	// Job that has succeeded with a custom RetainFinishedTasksSeconds.
+	fakeJobFinishedWithRetainFinishedTasks = func() *execution.Job {
+		newJob := fakeJobFinished.DeepCopy()
+		newJob.Spec.RetainFinishedTasksSeconds = pointer.Int64(60)
+		return newJob
+	}()
+
+	// Job that has succeeded, and belongs to a JobConfig.
+	fakeJobFinishedForJobConfig = func() *execution.Job {
+		newJob := fakeJobFinished.DeepCopy()
+		newJob.Labels = map[string]string{
+			jobconfig.LabelKeyJobConfigUID: jobConfigUID,
+		}
+		return newJob
+	}()
+
+	// Job that has succeeded earlier than fakeJobFinishedForJobConfig, and belongs
+	// to the same JobConfig.
+	fakeOlderJobFinishedForJobConfig = func() *execution.Job {
+		newJob := fakeJobFinishedForJobConfig.DeepCopy()
+		newJob.Name = jobName + "-older"
+		newJob.Status.Tasks = nil
+		newJob.Status.Condition.Finished.FinishedAt = testutils.Mkmtime(killTime)
+		return newJob
+	}()
+
+	// Job with steps, where the publish step depends on the prepare step.
+	fakeJobWithSteps = func() *execution.Job {
+		newJob := fakeJob.DeepCopy()
+		taskSpec := newJob.Spec.Template.Task
+		newJob.Spec.Template = &execution.JobTemplateSpec{
+			Steps: []execution.JobStepSpec{
+				{
+					Name: "prepare",
+					Task: taskSpec,
+				},
+				{
+					Name:      "publish",
+					DependsOn: []string{"prepare"},
+					Task:      taskSpec,
+				},
+			},
+		}
+		return newJob
+	}()
+
+	// Pods for each step in various states.
+	fakeStepPodPrepare          = newStepPod(fakeJobWithSteps, "prepare", corev1.PodStatus{})
+	fakeStepPodPrepareSucceeded = newStepPod(fakeJobWithSteps, "prepare", fakePodFinished.Status)
+	fakeStepPodPrepareFailed    = newStepPod(fakeJobWithSteps, "prepare", corev1.PodStatus{
+		Phase:     corev1.PodFailed,
+		StartTime: testutils.Mkmtimep(startTime),
+		ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name: "container",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						StartedAt:  testutils.Mkmtime(startTime),
+						FinishedAt: testutils.Mkmtime(finishTime),
+					},
+				},
+			},
+		},
+	})
+	fakeStepPodPublish = newStepPod(fakeJobWithSteps, "publish", corev1.PodStatus{})
+
+	// Job with steps and a fully populated status.
+	fakeJobWithStepsPrepareCreated   = generateJobStatusFromPods(fakeJobWithSteps, fakeStepPodPrepare)
+	fakeJobWithStepsPrepareSucceeded = generateJobStatusFromPods(fakeJobWithSteps, fakeStepPodPrepareSucceeded)
+	fakeJobWithStepsPrepareFailed    = generateJobStatusFromPods(fakeJobWithSteps, fakeStepPodPrepareFailed)
+	fakeJobWithStepsPublishCreated   = generateJobStatusFromPods(fakeJobWithSteps,
+		fakeStepPodPrepareSucceeded, fakeStepPodPublish)
+
+	// Job with steps that is suspended while its first step is running.
+	fakeStepPodPrepareRunning     = newStepPod(fakeJobWithSteps, "prepare", fakePodRunning.Status)
+	fakeStepPodPrepareTerminating = killPod(fakeStepPodPrepareRunning, testutils.Mktime(killTime))
+	fakeJobWithStepsSuspended     = func() *execution.Job {
+		newJob := fakeJobWithSteps.DeepCopy()
+		newJob.Spec.Suspend = true
+		return generateJobStatusFromPods(newJob, fakeStepPodPrepareRunning)
+	}()
+
+	// Job with steps that is suspended with its running step marked to be killed.
+	fakeJobWithStepsSuspendedPodKilled = func() *execution.Job {
+		newJob := fakeJobWithStepsSuspended.DeepCopy()
+		newJob.Status.Tasks[0].Suspended = true
+		return generateJobStatusFromPods(newJob, fakeStepPodPrepareRunning)
+	}()
+
+	// Job with steps that was resumed after its step was killed due to suspension.
+	fakeStepPodPrepareFinishedSuspended = func() *corev1.Pod {
+		newPod := fakeStepPodPrepareTerminating.DeepCopy()
+		newPod.Status.Phase = corev1.PodFailed
+		newPod.Status.Reason = "DeadlineExceeded"
+		return newPod
+	}()
+	fakeJobWithStepsResumed = func() *execution.Job {
+		newJob := fakeJobWithStepsSuspendedPodKilled.DeepCopy()
+		newJob.Spec.Suspend = false
+		return generateJobStatusFromPods(newJob, fakeStepPodPrepareFinishedSuspended)
+	}()
+	fakeStepPodPrepareRetried = newStepPod(fakeJobWithStepsResumed, "prepare", corev1.PodStatus{})
+
 	// Pod that is to be created.
 	fakePod, _ = podtaskexecutor.NewPod(fakeJob, 1)
 
@@ -228,6 +515,13 @@ var (
 		return newPod
 	}()
 
+	// Pod with the same name as fakePod, but was created for another Job.
+	fakePodForAnotherJob = func() *corev1.Pod {
+		newPod := fakePodResult.DeepCopy()
+		newPod.OwnerReferences[0].UID = "7bb4ccc5-a4e4-4b1b-8a2a-0e3df3dd2a83"
+		return newPod
+	}()
+
 	// Pod that is in Pending state.
 	fakePodPending = func() *corev1.Pod {
 		newPod := fakePodResult.DeepCopy()
@@ -249,6 +543,86 @@ var (
 		return newPod
 	}()
 
+	// Pod that is in Running state.
+	fakePodRunning = func() *corev1.Pod {
+		newPod := fakePodResult.DeepCopy()
+		newPod.Status = corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: testutils.Mkmtimep(startTime),
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "container",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{
+							StartedAt: testutils.Mkmtime(startTime),
+						},
+					},
+				},
+			},
+		}
+		return newPod
+	}()
+
+	// Pod that is in Running state, and was orphaned from its Job.
+	fakePodRunningOrphaned = func() *corev1.Pod {
+		newPod := fakePodRunning.DeepCopy()
+		newPod.OwnerReferences = []metav1.OwnerReference{}
+		newPod.Labels[podtaskexecutor.LabelKeyTaskOrphaned] = "true"
+		return newPod
+	}()
+
+	// Pod that is in Running state, but its node was lost.
+	fakePodNodeLost = func() *corev1.Pod {
+		newPod := fakePodRunning.DeepCopy()
+		newPod.Status.Conditions = []corev1.PodCondition{
+			{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionUnknown,
+				LastTransitionTime: testutils.Mkmtime(killTime),
+			},
+		}
+		return newPod
+	}()
+
+	// Pod that is in Running state and is in the process of being killed by
+	// running timeout.
+	fakePodRunningTimeoutTerminating = func() *corev1.Pod {
+		newPod := killPod(fakePodRunning, testutils.Mktime(later15m))
+		meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyKilledFromRunningTimeout, "1")
+		return newPod
+	}()
+
+	// Pod that is in Running state and has reported its progress.
+	fakePodRunningWithProgress = func() *corev1.Pod {
+		newPod := fakePodRunning.DeepCopy()
+		meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyTaskProgressPercent, "50")
+		return newPod
+	}()
+
+	// Pod that is in Running state and was detected to be stalled.
+	fakePodRunningStalled = func() *corev1.Pod {
+		newPod := fakePodRunning.DeepCopy()
+		meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyTaskStalledTimestamp,
+			strconv.Itoa(int(testutils.Mktime(later15m).Unix())))
+		return newPod
+	}()
+
+	// Pod that is in Running state and is in the process of being killed from
+	// being stalled.
+	fakePodRunningStalledTerminating = func() *corev1.Pod {
+		newPod := killPod(fakePodRunningStalled, testutils.Mktime(later15m))
+		meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyKilledFromStalled, "1")
+		return newPod
+	}()
+
+	// Pod that is in Running state and has started its pre-kill hook.
+	fakePodRunningPreKillHook = func() *corev1.Pod {
+		newPod := fakePodRunning.DeepCopy()
+		meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyTaskPreKillHookTimestamp,
+			strconv.Itoa(int(testutils.Mktime(preKillHookTime).Unix())))
+		return newPod
+	}()
+
 	// Pod that is in Pending state and is in the process of being killed.
 	fakePodTerminating = killPod(fakePodPending, testutils.Mktime(killTime))
 
@@ -269,6 +643,90 @@ var (
 		return newPod
 	}()
 
+	// Pod that was killed due to suspension of the Job.
+	fakePodFinishedSuspended = func() *corev1.Pod {
+		newPod := fakePodTerminating.DeepCopy()
+		newPod.Status.Phase = corev1.PodFailed
+		newPod.Status.Reason = "DeadlineExceeded"
+		return newPod
+	}()
+
+	// Job that retries tasks in-place.
+	fakeJobInPlaceRetry = func() *execution.Job {
+		newJob := fakeJob.DeepCopy()
+		newJob.Spec.Template.MaxAttempts = pointer.Int32(2)
+		newJob.Spec.Template.RetryMode = execution.RetryModeInPlace
+		return newJob
+	}()
+
+	// Pod that failed for a Job that retries tasks in-place.
+	fakePodInPlaceRetryFailed = func() *corev1.Pod {
+		newPod, _ := podtaskexecutor.NewPod(fakeJobInPlaceRetry, 1)
+		newPod.CreationTimestamp = testutils.Mkmtime(createTime)
+		newPod.Status = corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			StartTime: testutils.Mkmtimep(startTime),
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: "container",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:   1,
+							StartedAt:  testutils.Mkmtime(startTime),
+							FinishedAt: testutils.Mkmtime(finishTime),
+						},
+					},
+				},
+			},
+		}
+		return newPod
+	}()
+
+	// Job that retries tasks in-place whose first task has failed.
+	fakeJobInPlaceRetryFailed = generateJobStatusFromPod(fakeJobInPlaceRetry, fakePodInPlaceRetryFailed)
+
+	// Second pod for a Job that retries tasks in-place, which reuses the same name.
+	fakePodInPlaceRetry2, _ = podtaskexecutor.NewPod(fakeJobInPlaceRetry, 2)
+
+	// Second pod that adds CreationTimestamp to mimic mutation on apiserver.
+	fakePodInPlaceRetry2Result = func() *corev1.Pod {
+		newPod := fakePodInPlaceRetry2.DeepCopy()
+		newPod.CreationTimestamp = testutils.Mkmtime(later15m)
+		return newPod
+	}()
+
+	// Pod that failed for a Job with a maximum runtime that retries failed tasks.
+	fakePodWithMaxRuntimeRetryFailed = func() *corev1.Pod {
+		newPod, _ := podtaskexecutor.NewPod(fakeJobWithMaxRuntimeRetry, 1)
+		newPod.CreationTimestamp = testutils.Mkmtime(createTime)
+		newPod.Status = *fakePodInPlaceRetryFailed.Status.DeepCopy()
+		return newPod
+	}()
+
+	// Job with a maximum runtime whose first task has failed.
+	fakeJobWithMaxRuntimeRetryFailed = generateJobStatusFromPod(fakeJobWithMaxRuntimeRetry,
+		fakePodWithMaxRuntimeRetryFailed)
+
+	// Second pod for a Job with a maximum runtime that retries failed tasks.
+	fakePodWithMaxRuntimeRetry2, _ = podtaskexecutor.NewPod(fakeJobWithMaxRuntimeRetry, 2)
+
+	// Second pod that adds CreationTimestamp to mimic mutation on apiserver.
+	fakePodWithMaxRuntimeRetry2Result = func() *corev1.Pod {
+		newPod := fakePodWithMaxRuntimeRetry2.DeepCopy()
+		newPod.CreationTimestamp = testutils.Mkmtime(finishTime)
+		return newPod
+	}()
+
+	// Second pod created after the Job is resumed.
+	fakePod2, _ = podtaskexecutor.NewPod(fakeJob, 2)
+
+	// Second pod that adds CreationTimestamp to mimic mutation on apiserver.
+	fakePod2Result = func() *corev1.Pod {
+		newPod := fakePod2.DeepCopy()
+		newPod.CreationTimestamp = testutils.Mkmtime(finishTime)
+		return newPod
+	}()
+
 	// Pod that is Succeeded.
 	fakePodFinished = func() *corev1.Pod {
 		newPod := fakePodResult.DeepCopy()
@@ -291,6 +749,16 @@ var (
 	}()
 )
 
+// fakeJobAdmissionErrorForExistingPod returns a Job that could not create its
+// task because a Pod with the same name was created for another Job. Must be
+// evaluated after the clock has been faked.
+func fakeJobAdmissionErrorForExistingPod() *execution.Job {
+	newJob := fakeJob.DeepCopy()
+	job.MarkAdmissionError(newJob,
+		"AdmissionRefused - task my-sample-job.1 already exists but was not created for this job")
+	return jobcontroller.UpdateJobStatusFromTaskRefs(job.UpdateJobTaskRefs(newJob, nil))
+}
+
 // generateJobStatusFromPod returns a new Job whose status is reconciled from
 // the Pod.
 //
@@ -298,10 +766,36 @@ var (
 // meant to reduce flaky tests by coupling any changes to internal logic to the
 // fixtures themselves, thus making it suitable for integration tests.
 func generateJobStatusFromPod(rj *execution.Job, pod *corev1.Pod) *execution.Job {
-	newJob := job.UpdateJobTaskRefs(rj, []tasks.Task{podtaskexecutor.NewPodTask(pod, nil)})
+	return generateJobStatusFromPods(rj, pod)
+}
+
+// generateJobStatusFromPods returns a new Job whose status is reconciled from
+// multiple Pods.
+func generateJobStatusFromPods(rj *execution.Job, pods ...*corev1.Pod) *execution.Job {
+	podTasks := make([]tasks.Task, 0, len(pods))
+	for _, pod := range pods {
+		podTasks = append(podTasks, podtaskexecutor.NewPodTask(pod, nil))
+	}
+	newJob := job.UpdateJobTaskRefs(rj, podTasks)
 	return jobcontroller.UpdateJobStatusFromTaskRefs(newJob)
 }
 
+// newStepPod returns a new Pod for the given step, after adding
+// CreationTimestamp to mimic mutation on apiserver.
+func newStepPod(rj *execution.Job, step string, status corev1.PodStatus) *corev1.Pod {
+	pod, _ := podtaskexecutor.NewPodForStep(rj, step)
+	pod.CreationTimestamp = testutils.Mkmtime(createTime)
+	pod.Status = status
+	return pod
+}
+
+// withMaxRuntime returns a copy of the Job with a maximum runtime of 10 minutes.
+func withMaxRuntime(rj *execution.Job) *execution.Job {
+	newJob := rj.DeepCopy()
+	newJob.Spec.Template.MaxRuntimeSeconds = pointer.Int64(600)
+	return newJob
+}
+
 // killPod returns a new Pod after setting the kill timestamp.
 func killPod(pod *corev1.Pod, ts time.Time) *corev1.Pod {
 	newPod := pod.DeepCopy()
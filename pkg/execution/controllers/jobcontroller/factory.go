@@ -18,16 +18,26 @@ package jobcontroller
 
 import (
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
 )
 
-const controllerName = "JobController"
+const (
+	controllerName = "JobController"
 
-type Factory struct{}
+	// fieldManager is the field manager used when applying status updates.
+	fieldManager = "furiko-jobcontroller"
+)
+
+type Factory struct {
+	eventSink eventsink.Recorder
+}
 
-func NewFactory() *Factory {
-	return &Factory{}
+// NewFactory returns a new Factory. State transitions of Jobs are recorded to
+// the given Recorder, which may be nil to discard them.
+func NewFactory(eventSink eventsink.Recorder) *Factory {
+	return &Factory{eventSink: eventSink}
 }
 
 func (f *Factory) Name() string {
@@ -37,6 +47,7 @@ func (f *Factory) Name() string {
 func (f *Factory) New(
 	ctrlContext controllercontext.Context,
 	concurrencySpec *configv1alpha1.ExecutionControllerConcurrencySpec,
+	rateLimiterSpec *configv1alpha1.ExecutionControllerRateLimitersSpec,
 ) (controllermanager.Controller, error) {
-	return NewController(ctrlContext, concurrencySpec.Job)
+	return NewController(ctrlContext, concurrencySpec.Job, rateLimiterSpec.Job, f.eventSink)
 }
@@ -49,6 +49,15 @@ func NewInformerWorker(ctrlContext *Context) *InformerWorker {
 		DeleteFunc: w.handlePod,
 	})
 
+	// Add event handler for ExternalTasks.
+	w.externalTaskInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: w.handleExternalTask,
+		UpdateFunc: func(_, newObj interface{}) {
+			w.handleExternalTask(newObj)
+		},
+		DeleteFunc: w.handleExternalTask,
+	})
+
 	w.jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: w.enqueueObject,
 		UpdateFunc: func(_, newObj interface{}) {
@@ -94,6 +103,22 @@ func (w *InformerWorker) handlePod(obj interface{}) {
 	}
 }
 
+func (w *InformerWorker) handleExternalTask(obj interface{}) {
+	task, err := eventhandler.Executionv1alpha1ExternalTask(obj)
+	if err != nil {
+		klog.ErrorS(err, "jobcontroller: unable to handle event", "worker", w.WorkerName())
+		return
+	}
+
+	if controllerRef := metav1.GetControllerOf(task); controllerRef != nil {
+		rj := w.resolveRefedJob(task.GetNamespace(), controllerRef)
+		if rj != nil {
+			w.enqueueObject(rj)
+			return
+		}
+	}
+}
+
 // resolveRefedJob returns the Job referenced by ControllerRef.
 // It does sanity checks to ensure that we don't return inaccurate objects.
 func (w *InformerWorker) resolveRefedJob(namespace string, ref *metav1.OwnerReference) *execution.Job {
@@ -39,14 +39,50 @@ var (
 		},
 		[]string{"namespace", "job_type"},
 	)
+
+	jobsCreatedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "jobs_created_total",
+			Help:      "Total number of Jobs that were created",
+		},
+		[]string{"namespace", "job_type"},
+	)
+
+	jobsFinishedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "jobs_finished_total",
+			Help:      "Total number of Jobs that have finished, by result",
+		},
+		[]string{"namespace", "job_type", "result"},
+	)
 )
 
 func init() {
 	metrics.Registry.MustRegister(
 		firstTaskCreationLatency,
+		jobsCreatedTotal,
+		jobsFinishedTotal,
 	)
 }
 
+// ObserveJobStatusUpdate records metrics for the transitions between the old
+// and new status of a Job, after the new status was successfully updated.
+func ObserveJobStatusUpdate(rj, newRj *execution.Job) {
+	namespace := newRj.GetNamespace()
+	jobType := string(newRj.Spec.Type)
+
+	// The phase is only empty before the Job is reconciled for the first time.
+	if rj.Status.Phase == "" && newRj.Status.Phase != "" {
+		jobsCreatedTotal.WithLabelValues(namespace, jobType).Inc()
+	}
+
+	if finished := newRj.Status.Condition.Finished; finished != nil && rj.Status.Condition.Finished == nil {
+		jobsFinishedTotal.WithLabelValues(namespace, jobType, string(finished.Result)).Inc()
+	}
+}
+
 // ObserveFirstTaskCreation records a metric for the first task creation time of a Job.
 func ObserveFirstTaskCreation(rj *execution.Job, task tasks.Task) {
 	// There are tasks created prior, do not observe.
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobcontroller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+)
+
+// PreKillHookRunner executes pre-kill hooks asynchronously, and keeps track of
+// the hooks which have finished executing. Since the state is only kept in
+// memory, hooks which were started before the controller restarted will be
+// treated as running until their timeout has elapsed.
+type PreKillHookRunner struct {
+	executor podtaskexecutor.CommandExecutor
+	mu       sync.Mutex
+	finished map[string]bool
+}
+
+func NewPreKillHookRunner(executor podtaskexecutor.CommandExecutor) *PreKillHookRunner {
+	return &PreKillHookRunner{
+		executor: executor,
+		finished: make(map[string]bool),
+	}
+}
+
+// Run executes the hook in the background, and calls onDone once the hook has
+// exited or the timeout has elapsed.
+func (r *PreKillHookRunner) Run(
+	namespace, name string, hook *execution.TaskPreKillHook, timeout time.Duration, onDone func(),
+) {
+	key := preKillHookKey(namespace, name)
+
+	r.mu.Lock()
+	r.finished[key] = false
+	r.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if err := r.executor.Exec(ctx, namespace, name, hook.Container, hook.Command); err != nil {
+			klog.ErrorS(err, "jobcontroller: pre-kill hook failed",
+				"namespace", namespace,
+				"task", name,
+			)
+		}
+
+		r.mu.Lock()
+		r.finished[key] = true
+		r.mu.Unlock()
+
+		onDone()
+	}()
+}
+
+// IsFinished returns true if the hook for the task was started by this runner
+// and has since finished executing.
+func (r *PreKillHookRunner) IsFinished(namespace, name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.finished[preKillHookKey(namespace, name)]
+}
+
+// Forget removes the state of the hook for the task.
+func (r *PreKillHookRunner) Forget(namespace, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.finished, preKillHookKey(namespace, name))
+}
+
+func preKillHookKey(namespace, name string) string {
+	return fmt.Sprintf("%v/%v", namespace, name)
+}
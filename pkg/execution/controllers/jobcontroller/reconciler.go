@@ -19,12 +19,15 @@ package jobcontroller
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	utiltrace "k8s.io/utils/trace"
@@ -33,10 +36,13 @@ import (
 	executiongroup "github.com/furiko-io/furiko/apis/execution"
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	coreerrors "github.com/furiko-io/furiko/pkg/core/errors"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
 	jobtasks "github.com/furiko-io/furiko/pkg/execution/tasks"
 	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/runtime/features"
 	"github.com/furiko-io/furiko/pkg/utils/ktime"
 	"github.com/furiko-io/furiko/pkg/utils/meta"
 	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
@@ -62,7 +68,15 @@ func (w *Reconciler) Name() string {
 }
 
 func (w *Reconciler) Concurrency() int {
-	return controllerutil.GetConcurrencyOrDefaultCPUFactor(w.concurrency, 4)
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "jobcontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.Job
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
 }
 
 func (w *Reconciler) MaxRequeues() int {
@@ -72,7 +86,7 @@ func (w *Reconciler) MaxRequeues() int {
 func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int) error {
 	var err error
 
-	cfg, err := w.Configs().Jobs()
+	cfg, err := w.Configs().JobsForNamespace(namespace)
 	if err != nil {
 		return errors.Wrapf(err, "cannot load controller configuration")
 	}
@@ -98,6 +112,19 @@ func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int)
 	}
 	trace.Step("Lookup job from cache done")
 
+	// Layer the execution overrides of the Job's JobConfig over the config. If the
+	// JobConfig cannot be found (e.g. it was deleted), the config is used as-is.
+	rjc, err := jobconfig.LookupJobOwner(rj, w.jobconfigInformer.Lister().JobConfigs(namespace))
+	if err != nil {
+		klog.V(4).InfoS("jobcontroller: cannot look up job config for execution overrides",
+			"worker", w.Name(),
+			"namespace", namespace,
+			"name", name,
+			"err", err,
+		)
+	}
+	cfg = jobconfig.ApplyExecutionOverrides(cfg, rjc)
+
 	// Perform sync. We assume that the Job is never modified in-place by any downstream function.
 	newRj, syncErr := w.sync(ctx, rj, cfg, trace)
 
@@ -114,9 +141,14 @@ func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int)
 	}
 
 	// Update the JobStatus if different.
-	if _, err := w.client.UpdateJobStatus(ctx, rj, newRj); err != nil {
+	updated, err := w.client.UpdateJobStatus(ctx, rj, newRj)
+	if err != nil {
 		return errors.Wrapf(err, "cannot update job")
 	}
+	if updated {
+		ObserveJobStatusUpdate(rj, newRj)
+		eventsink.RecordJobTransition(w.eventSink, rj, newRj, fieldManager)
+	}
 
 	return syncErr
 }
@@ -126,6 +158,9 @@ func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int)
 func (w *Reconciler) sync(
 	ctx context.Context, rj *execution.Job, cfg *configv1alpha1.JobExecutionConfig, trace *utiltrace.Trace,
 ) (*execution.Job, error) {
+	// Handle kill requests made via annotations.
+	rj = w.handleRequestKill(rj)
+
 	// Main logic: Perform task creation/adoption and reconciliation. If Job is not
 	// started or is being deleted, this is a no-op.
 	if jobutil.IsStarted(rj) && !isDeleted(rj) {
@@ -142,14 +177,28 @@ func (w *Reconciler) sync(
 	//  unfortunately necessary to handle non-started Jobs.
 	rj = w.syncJobStatusFromTaskRefs(rj)
 
+	// Handle retry requests made via annotations once the Job is finished.
+	updatedRj, err := w.handleRequestRetry(ctx, rj)
+	if err != nil {
+		return rj, errors.Wrapf(err, "could not handle retry request")
+	}
+	rj = updatedRj
+	trace.Step("Handle retry request done")
+
 	// Clean up Job if it is finished and beyond its TTL.
 	if err := w.handleTTLAfterFinished(ctx, rj, cfg); err != nil {
 		return rj, errors.Wrapf(err, "could not handle TTLAfterFinished")
 	}
 	trace.Step("Handle TTLAfterFinished done")
 
+	// Clean up older finished Jobs beyond the retention limit.
+	if err := w.handleMaxFinishedJobs(ctx, rj, cfg); err != nil {
+		return rj, errors.Wrapf(err, "could not handle MaxFinishedJobsPerJobConfig")
+	}
+	trace.Step("Handle MaxFinishedJobsPerJobConfig done")
+
 	// Finalize Job if deleting.
-	updatedRj, err := w.handleFinishFinalizer(ctx, rj)
+	updatedRj, err = w.handleFinishFinalizer(ctx, rj)
 	if err != nil {
 		return rj, errors.Wrapf(err, "could not finalize %v", executiongroup.DeleteDependentsFinalizer)
 	}
@@ -168,6 +217,7 @@ func (w *Reconciler) syncJobTasks(
 		return nil, errors.Wrapf(err, "cannot create task manager")
 	}
 
+	origRj := rj
 	maxAllowedTasks := jobutil.GetMaxAllowedTasks(rj)
 
 	// Get all tasks for job from cache.
@@ -179,7 +229,8 @@ func (w *Reconciler) syncJobTasks(
 
 	// Skip adoption step if there is an active task.
 	// We assume there is only 1 task active at a time.
-	if !jobutil.ContainsActiveTask(tasks) {
+	// Jobs with steps do not use retry indexes, so there is nothing to adopt.
+	if !jobutil.ContainsActiveTask(tasks) && !jobutil.HasSteps(rj) {
 		// Get the maximum retry index out of all tasks.
 		// This may not contain all tasks created by the controller, only the non-deleted ones in the cache.
 		maxRetryIndex := jobutil.MaxTaskRetryIndex(tasks)
@@ -195,6 +246,12 @@ func (w *Reconciler) syncJobTasks(
 				return rj, errors.Wrapf(err, "could not fetch task index %v", i)
 			}
 
+			// Do not adopt tasks that were not created for this Job, such as those left
+			// behind by a previous Job with the same name.
+			if !task.IsControlledBy(rj) {
+				break
+			}
+
 			// Found a task, add to our list.
 			tasks = append(tasks, task)
 		}
@@ -219,12 +276,44 @@ func (w *Reconciler) syncJobTasks(
 	}
 	trace.Step("Reap overdue pending tasks done")
 
+	// Check if any tasks exceed running timeout.
+	if err := w.handleRunningTasks(ctx, rj, tasks); err != nil {
+		return rj, errors.Wrapf(err, "could not reap running tasks")
+	}
+	trace.Step("Reap overdue running tasks done")
+
+	// Check if any running tasks are stalled.
+	if err := w.handleStalledTasks(ctx, rj, tasks); err != nil {
+		return rj, errors.Wrapf(err, "could not handle stalled tasks")
+	}
+	trace.Step("Handle stalled tasks done")
+
+	// Replace tasks whose node was lost.
+	newRj, err = w.handleNodeLostTasks(ctx, rj, tasks, cfg)
+	if err != nil {
+		return rj, errors.Wrapf(err, "could not replace node lost tasks")
+	}
+	rj = newRj
+	trace.Step("Replace node lost tasks done")
+
+	// Set kill timestamp if the Job has a maximum runtime.
+	rj = w.handleMaxRuntime(rj, cfg)
+	trace.Step("Handle max runtime done")
+
 	// Handle propagation of kill timestamp to all unfinished tasks
 	if err := w.handleKillJob(ctx, rj, tasks); err != nil {
 		return rj, errors.Wrapf(err, "could not kill job")
 	}
 	trace.Step("Set kill timestamp on tasks done")
 
+	// Kill active tasks if the job is suspended.
+	newRj, err = w.handleSuspendJob(ctx, rj, tasks)
+	if err != nil {
+		return rj, errors.Wrapf(err, "could not suspend job")
+	}
+	rj = newRj
+	trace.Step("Suspend job done")
+
 	// Use deletion of tasks when previous kill is ineffective.
 	newRj, err = w.handleDeleteKillingTasks(ctx, rj, tasks, cfg)
 	if err != nil {
@@ -245,15 +334,66 @@ func (w *Reconciler) syncJobTasks(
 	rj = w.updateTaskRefStatus(rj, tasks)
 	trace.Step("Final update status for tasks done")
 
+	// Clean up resources of newly finished tasks.
+	if err := w.handleFinishedTasks(ctx, origRj, rj, taskMgr.Client()); err != nil {
+		return rj, errors.Wrapf(err, "could not clean up finished tasks")
+	}
+	trace.Step("Clean up finished tasks done")
+
+	// Delete finished tasks beyond their retention period.
+	if err := w.handleRetainFinishedTasks(ctx, origRj, tasks); err != nil {
+		return rj, errors.Wrapf(err, "could not delete retained finished tasks")
+	}
+	trace.Step("Delete retained finished tasks done")
+
 	return rj, nil
 }
 
+// handleFinishedTasks deletes additional resources created for tasks that have
+// become finished since the start of the sync. If deletion fails, the Job
+// status will not be updated, and the cleanup will be retried on the next sync.
+func (w *Reconciler) handleFinishedTasks(
+	ctx context.Context, origRj, rj *execution.Job, client jobtasks.TaskClient,
+) error {
+	type taskKey struct {
+		name       string
+		retryIndex int64
+	}
+	prevFinished := make(map[taskKey]bool, len(origRj.Status.Tasks))
+	for _, taskRef := range origRj.Status.Tasks {
+		prevFinished[taskKey{taskRef.Name, taskRef.RetryIndex}] = !taskRef.FinishTimestamp.IsZero()
+	}
+
+	for _, taskRef := range rj.Status.Tasks {
+		if taskRef.FinishTimestamp.IsZero() || prevFinished[taskKey{taskRef.Name, taskRef.RetryIndex}] {
+			continue
+		}
+		if err := client.DeleteResources(ctx, taskRef); err != nil {
+			return errors.Wrapf(err, "could not delete resources for task %v", taskRef.Name)
+		}
+		klog.V(4).InfoS("jobcontroller: deleted resources for finished task",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"task", taskRef.Name,
+		)
+	}
+
+	return nil
+}
+
 // updateTaskRefStatus will update the CreatedTask fields in the Job's status from a list of tasks.
 // We will always update tasks into Job before computing the rest of the JobStatus.
 func (w *Reconciler) updateTaskRefStatus(rj *execution.Job, tasks []jobtasks.Task) *execution.Job {
 	// Generate new TaskRefs in Job status.
 	updatedRj := jobutil.UpdateJobTaskRefs(rj, tasks)
 
+	// Estimate costs of finished tasks.
+	updatedRj = w.estimateTaskCosts(updatedRj)
+
+	// Compact details of older finished tasks.
+	updatedRj = w.compactTaskRefs(updatedRj)
+
 	// Update Job status with new TaskRefs.
 	return w.syncJobStatusFromTaskRefs(updatedRj)
 }
@@ -281,6 +421,15 @@ func (w *Reconciler) syncJobStatusFromTaskRefs(rj *execution.Job) *execution.Job
 		}
 	}
 
+	// Handle job that is newly suspended or resumed.
+	if rj.Status.Phase != execution.JobSuspended && newRj.Status.Phase == execution.JobSuspended {
+		w.recorder.Eventf(newRj, corev1.EventTypeNormal, "Suspended",
+			"Job was suspended")
+	} else if rj.Status.Phase == execution.JobSuspended && !newRj.Spec.Suspend {
+		w.recorder.Eventf(newRj, corev1.EventTypeNormal, "Resumed",
+			"Job was resumed")
+	}
+
 	// Enqueue work to delete finished Job after TTL.
 	if newRj.Status.Condition.Finished != nil && !isDeleted(newRj) {
 		if newRj.Spec.TTLSecondsAfterFinished != nil {
@@ -323,9 +472,26 @@ func UpdateJobStatusFromTaskRefs(rj *execution.Job) *execution.Job {
 		}
 	}
 
+	// Compute status of each step.
+	newRj.Status.Steps = jobutil.GetStepStatuses(newRj)
+
+	// Aggregate outputs from all tasks.
+	newRj.Status.Outputs = jobutil.GetOutputs(newRj)
+
+	// Aggregate resource usage from all tasks.
+	newRj.Status.ResourceUsage = jobutil.GetResourceUsage(newRj)
+
+	// Persist the queue key so that the start order of queued Jobs is stable.
+	if newRj.Status.QueueKey == "" {
+		newRj.Status.QueueKey = jobutil.ComputeQueueKey(newRj)
+	}
+
 	// Set phase based on computed status so far.
 	newRj.Status.Phase = jobutil.GetPhase(newRj)
 
+	// Compute standard conditions from the consolidated condition and phase.
+	newRj.Status.Conditions = jobutil.GetConditions(newRj)
+
 	return newRj
 }
 
@@ -340,6 +506,11 @@ func (w *Reconciler) syncCreateNewTask(
 ) (*execution.Job, []jobtasks.Task, error) {
 	now := ktime.Now().Time
 
+	// Jobs with steps may create multiple tasks at once.
+	if jobutil.HasSteps(rj) {
+		return w.syncCreateStepTasks(ctx, rj, tasks)
+	}
+
 	// Not allowed to create new task.
 	if !jobutil.AllowedToCreateNewTask(rj) {
 		return rj, tasks, nil
@@ -354,8 +525,81 @@ func (w *Reconciler) syncCreateNewTask(
 		return rj, tasks, nil
 	}
 
+	// Tasks retried in-place reuse the same name, so the previous task has to be
+	// deleted before its replacement can be created. The replacement will be
+	// created once the deletion is observed.
+	if jobutil.IsInPlaceRetry(rj) && len(tasks) > 0 {
+		if err := w.deleteTasks(ctx, rj, tasks, false); err != nil {
+			return rj, tasks, errors.Wrapf(err, "could not delete previous tasks")
+		}
+		return rj, tasks, nil
+	}
+
 	// Create new task.
 	task, err := w.createTask(ctx, rj)
+	newRj, tasks, err := w.handleCreatedTask(rj, tasks, task, err)
+	if err != nil {
+		return rj, tasks, err
+	}
+	rj = newRj
+
+	// Sync Job's status with the new list of tasks before moving on.
+	updatedRj := w.updateTaskRefStatus(rj, tasks)
+
+	return updatedRj, tasks, nil
+}
+
+// syncCreateStepTasks will create tasks for all steps which are ready to be
+// started, and update the list of tasks with the newly created tasks.
+func (w *Reconciler) syncCreateStepTasks(
+	ctx context.Context, rj *execution.Job, tasks []jobtasks.Task,
+) (*execution.Job, []jobtasks.Task, error) {
+	for _, step := range jobutil.GetRunnableSteps(rj) {
+		// Steps that were killed due to suspension reuse the same task name, so the
+		// previous task has to be deleted before the step can be started again.
+		if existing := getTasksForStep(tasks, step.Name); len(existing) > 0 {
+			if err := w.deleteTasks(ctx, rj, existing, false); err != nil {
+				return rj, tasks, err
+			}
+			continue
+		}
+
+		task, err := w.createStepTask(ctx, rj, step)
+		newRj, newTasks, err := w.handleCreatedTask(rj, tasks, task, err)
+		if err != nil {
+			return rj, tasks, err
+		}
+		rj, tasks = newRj, newTasks
+
+		// Sync Job's status with the new list of tasks before moving on.
+		rj = w.updateTaskRefStatus(rj, tasks)
+
+		// No longer allowed to create tasks.
+		if _, ok := jobutil.GetAdmissionErrorMessage(rj); ok {
+			break
+		}
+	}
+
+	return rj, tasks, nil
+}
+
+// getTasksForStep returns the tasks that were created for the given step.
+func getTasksForStep(tasks []jobtasks.Task, step string) []jobtasks.Task {
+	var stepTasks []jobtasks.Task
+	for _, task := range tasks {
+		if name, ok := task.GetStepName(); ok && name == step {
+			stepTasks = append(stepTasks, task)
+		}
+	}
+	return stepTasks
+}
+
+// handleCreatedTask handles the result of creating a new task. If the task
+// could not be created due to an unretryable error, the Job will be marked with
+// an AdmissionError.
+func (w *Reconciler) handleCreatedTask(
+	rj *execution.Job, tasks []jobtasks.Task, task jobtasks.Task, err error,
+) (*execution.Job, []jobtasks.Task, error) {
 	if err != nil {
 		// Handle this as a normal error.
 		rerr := coreerrors.Error(nil)
@@ -377,33 +621,78 @@ func (w *Reconciler) syncCreateNewTask(
 		)
 		w.recorder.Eventf(rj, corev1.EventTypeWarning, "AdmissionError",
 			"Cannot create task: %v", rerr)
-	} else {
-		// Record event.
-		klog.InfoS("jobcontroller: worker created task",
-			"worker", w.Name(),
-			"namespace", rj.GetNamespace(),
-			"name", rj.GetName(),
-			"task", task.GetName(),
-		)
-		w.recorder.Eventf(rj, corev1.EventTypeNormal, "Created",
-			"Created task of kind %v: %v", task.GetKind(), task.GetName())
-
-		// Add to our list of tasks.
-		tasks = append(tasks, task)
+		return rj, tasks, nil
 	}
 
-	// Sync Job's status with the new list of tasks before moving on.
-	updatedRj := w.updateTaskRefStatus(rj, tasks)
+	// Record event.
+	klog.InfoS("jobcontroller: worker created task",
+		"worker", w.Name(),
+		"namespace", rj.GetNamespace(),
+		"name", rj.GetName(),
+		"task", task.GetName(),
+	)
+	w.recorder.Eventf(rj, corev1.EventTypeNormal, "Created",
+		"Created task of kind %v: %v", task.GetKind(), task.GetName())
 
-	return updatedRj, tasks, nil
+	// Add to our list of tasks.
+	tasks = append(tasks, task)
+	return rj, tasks, nil
 }
 
 // createTask will create a new task for the Job.
 // May return AdmissionError if it cannot be created due to an unretryable or irrecoverable error.
 func (w *Reconciler) createTask(ctx context.Context, rj *execution.Job) (jobtasks.Task, error) {
+	// Apply patches whose conditions are satisfied.
+	newRj := rj.DeepCopy()
+	template, err := variablecontext.ApplyPodTemplatePatches(rj, rj.Spec.Template.Task.Template)
+	if err != nil {
+		return nil, coreerrors.NewAdmissionRefusedError(err.Error())
+	}
+	newRj.Spec.Template.Task.Template = w.applyResourceRecommendations(rj, template)
+
+	// Substitute job context variables.
+	newRj.Spec.Template.Task.Template = variablecontext.SubstitutePodTemplateSpecForJob(newRj)
+
+	// Get task manager after substituting variables in Job.
+	taskMgr, err := w.tasks.ForJob(newRj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get task manager")
+	}
+
+	// Create new task.
+	index := rj.Status.CreatedTasks + 1
+	task, err := taskMgr.Client().CreateIndex(ctx, index)
+	if kerrors.IsAlreadyExists(err) {
+		existing, getErr := taskMgr.Client().Index(ctx, index)
+		return w.adoptTask(rj, existing, getErr, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Observe latency for initial task creation.
+	ObserveFirstTaskCreation(rj, task)
+
+	return task, nil
+}
+
+func (w *Reconciler) createStepTask(
+	ctx context.Context, rj *execution.Job, step execution.JobStepSpec,
+) (jobtasks.Task, error) {
+	// Apply patches whose conditions are satisfied.
+	template, err := variablecontext.ApplyPodTemplatePatches(rj, step.Task.Template)
+	if err != nil {
+		return nil, coreerrors.NewAdmissionRefusedError(err.Error())
+	}
+	step.Task.Template = w.applyResourceRecommendations(rj, template)
+
 	// Substitute job context variables.
 	newRj := rj.DeepCopy()
-	newRj.Spec.Template.Task.Template = variablecontext.SubstitutePodTemplateSpecForJob(rj)
+	for i, stepSpec := range newRj.Spec.Template.Steps {
+		if stepSpec.Name == step.Name {
+			newRj.Spec.Template.Steps[i].Task.Template = variablecontext.SubstitutePodTemplateSpecForStep(rj, &step)
+		}
+	}
 
 	// Get task manager after substituting variables in Job.
 	taskMgr, err := w.tasks.ForJob(newRj)
@@ -412,7 +701,11 @@ func (w *Reconciler) createTask(ctx context.Context, rj *execution.Job) (jobtask
 	}
 
 	// Create new task.
-	task, err := taskMgr.Client().CreateIndex(ctx, rj.Status.CreatedTasks+1)
+	task, err := taskMgr.Client().CreateStep(ctx, step.Name)
+	if kerrors.IsAlreadyExists(err) {
+		existing, getErr := taskMgr.Client().Step(ctx, step.Name)
+		return w.adoptTask(rj, existing, getErr, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -423,16 +716,112 @@ func (w *Reconciler) createTask(ctx context.Context, rj *execution.Job) (jobtask
 	return task, nil
 }
 
-// handlePendingTasks looks for pending tasks that have exceeded their pending timeout, and subsequently
-// kill those tasks.
+// applyResourceRecommendations sets the resource requests of the task template
+// based on the resource usage recorded in the JobConfig's status, if the
+// ResourceRecommendations feature gate is enabled. Recommendations are
+// best-effort, and the original template will be used if they cannot be
+// computed.
+func (w *Reconciler) applyResourceRecommendations(
+	rj *execution.Job, template corev1.PodTemplateSpec,
+) corev1.PodTemplateSpec {
+	if !features.Enabled(features.ResourceRecommendations) {
+		return template
+	}
+
+	cfg, err := w.Configs().JobConfigs()
+	if err != nil {
+		klog.ErrorS(err, "jobcontroller: cannot load controller configuration", "worker", w.Name())
+		return template
+	}
+
+	rjc, err := jobconfig.LookupJobOwner(rj, w.jobconfigInformer.Lister().JobConfigs(rj.GetNamespace()))
+	if err != nil {
+		klog.ErrorS(err, "jobcontroller: cannot look up job config for resource recommendations",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+		)
+		return template
+	}
+	if rjc == nil || len(rjc.Status.ResourceUsage) == 0 {
+		return template
+	}
+
+	var marginPercent int64
+	if cfg.ResourceRecommendationMarginPercent != nil {
+		marginPercent = *cfg.ResourceRecommendationMarginPercent
+	}
+
+	return jobconfig.ApplyResourceRecommendations(template, rjc.Status.ResourceUsage, marginPercent)
+}
+
+// estimateTaskCosts computes the estimated cost of finished tasks from their
+// resource usage, using the price table in the controller configuration. Costs
+// are best-effort, and will not be estimated if the price table is not set.
+func (w *Reconciler) estimateTaskCosts(rj *execution.Job) *execution.Job {
+	cfg, err := w.Configs().JobConfigs()
+	if err != nil {
+		klog.ErrorS(err, "jobcontroller: cannot load controller configuration", "worker", w.Name())
+		return rj
+	}
+	if cfg.ResourcePrices == nil {
+		return rj
+	}
+	return jobutil.UpdateTaskRefCosts(rj, cfg.ResourcePrices)
+}
+
+// compactTaskRefs compacts the details of older finished TaskRefs, if enabled in
+// the dynamic config.
+func (w *Reconciler) compactTaskRefs(rj *execution.Job) *execution.Job {
+	cfg, err := w.Configs().JobsForNamespace(rj.GetNamespace())
+	if err != nil {
+		klog.ErrorS(err, "jobcontroller: cannot load controller configuration", "worker", w.Name())
+		return rj
+	}
+	if cfg.MaxDetailedTaskRefs == nil {
+		return rj
+	}
+	return jobutil.CompactTaskRefs(rj, *cfg.MaxDetailedTaskRefs)
+}
+
+// adoptTask adopts an existing task which could not be created because it
+// already exists. This can happen if the task was previously created, but the
+// Job's status lost track of it before it could be updated (e.g. the controller
+// restarted before the status update succeeded). Tasks that were not created for
+// the Job will not be adopted, and will result in an AdmissionError instead.
+func (w *Reconciler) adoptTask(
+	rj *execution.Job, task jobtasks.Task, err error, createErr error,
+) (jobtasks.Task, error) {
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get existing task after create error: %v", createErr)
+	}
+
+	if !task.IsControlledBy(rj) {
+		return nil, coreerrors.NewAdmissionRefusedError(
+			fmt.Sprintf("task %v already exists but was not created for this job", task.GetName()))
+	}
+
+	klog.InfoS("jobcontroller: worker adopted existing task",
+		"worker", w.Name(),
+		"namespace", rj.GetNamespace(),
+		"name", rj.GetName(),
+		"task", task.GetName(),
+	)
+
+	return task, nil
+}
+
+// handlePendingTasks looks for pending tasks that have exceeded their pending timeout, or are pending
+// with one of the fail fast reasons, and subsequently kill those tasks.
 func (w *Reconciler) handlePendingTasks(ctx context.Context, rj *execution.Job, tasks []jobtasks.Task,
 	cfg *configv1alpha1.JobExecutionConfig) error {
 	now := ktime.Now().Time
 
 	pendingTimeout := jobutil.GetPendingTimeout(rj, cfg)
+	failFastReasons := sets.NewString(rj.Spec.Template.Task.FailFastPendingReasons...)
 
-	// Pending timeout is disabled.
-	if pendingTimeout <= 0 {
+	// Pending timeout is disabled and there are no reasons to fail fast on.
+	if pendingTimeout <= 0 && failFastReasons.Len() == 0 {
 		return nil
 	}
 
@@ -451,6 +840,25 @@ func (w *Reconciler) handlePendingTasks(ctx context.Context, rj *execution.Job,
 			continue
 		}
 
+		// Kill immediately if task is pending with a fail fast reason.
+		if reason := ref.Status.Reason; reason != "" && failFastReasons.Has(reason) {
+			needKill = append(needKill, task)
+
+			klog.InfoS("jobcontroller: reaping pending task with fail fast reason",
+				"worker", w.Name(),
+				"namespace", rj.GetNamespace(),
+				"name", rj.GetName(),
+				"task", ref.Name,
+				"reason", reason,
+			)
+			continue
+		}
+
+		// Pending timeout is disabled.
+		if pendingTimeout <= 0 {
+			continue
+		}
+
 		// Skip if task is not yet overdue.
 		if deadline := ref.CreationTimestamp.Add(pendingTimeout); deadline.After(now) {
 			w.enqueueAfter(rj, "task_pending_timeout", time.Until(deadline))
@@ -501,71 +909,462 @@ func (w *Reconciler) handlePendingTasks(ctx context.Context, rj *execution.Job,
 	return nil
 }
 
-// handleKillJob updates kill timestamp of all tasks if spec.killTimestamp is set.
-func (w *Reconciler) handleKillJob(ctx context.Context, rj *execution.Job, tasks []jobtasks.Task) error {
-	// Skip if not killing.
-	if rj.Spec.KillTimestamp == nil || ktime.Now().Before(rj.Spec.KillTimestamp) {
+// handleRunningTasks looks for running tasks that have exceeded their running timeout, and subsequently
+// kill those tasks.
+func (w *Reconciler) handleRunningTasks(ctx context.Context, rj *execution.Job, tasks []jobtasks.Task) error {
+	now := ktime.Now().Time
+
+	runningTimeout := jobutil.GetRunningTimeout(rj)
+
+	// Running timeout is disabled.
+	if runningTimeout <= 0 {
 		return nil
 	}
 
-	// Find all tasks that need update.
-	needUpdate := make([]jobtasks.Task, 0, len(tasks))
+	// Find tasks that need to be killed.
+	needKill := make([]jobtasks.Task, 0, len(tasks))
 	for _, task := range tasks {
-		// Skip finished tasks
-		if jobutil.IsTaskFinished(task) {
-			continue
-		}
+		ref := task.GetTaskRef()
 
-		// Skip if kill timestamp exists, and existing one is earlier than the killTimestamp we requested.
-		if ktime.IsTimeSetAndEarlierThanOrEqualTo(task.GetKillTimestamp(), rj.Spec.KillTimestamp.Time) {
+		// Skip if task already finished.
+		if ts := ref.FinishTimestamp; !ts.IsZero() {
 			continue
 		}
 
-		needUpdate = append(needUpdate, task)
-	}
-
-	if len(needUpdate) == 0 {
-		return nil
-	}
-
-	// Set the kill timestamp for tasks.
-	if err := w.setTasksKillTimestamp(ctx, rj, needUpdate, *rj.Spec.KillTimestamp); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// handleDeleteKillingTasks uses deletion to kill tasks if prior efforts to set kill timestamp on tasks are ineffective.
-func (w *Reconciler) handleDeleteKillingTasks(
-	ctx context.Context, rj *execution.Job, tasks []jobtasks.Task, cfg *configv1alpha1.JobExecutionConfig,
-) (*execution.Job, error) {
-	timeout := jobutil.GetDeleteKillingTimeout(cfg)
-	needDelete := make([]jobtasks.Task, 0, len(tasks))
-	needDeleteMap := make(map[string]jobtasks.Task)
-
-	for _, task := range tasks {
-		// Skip finished tasks.
-		if jobutil.IsTaskFinished(task) {
+		// Skip if task is not yet running.
+		if ts := ref.RunningTimestamp; ts.IsZero() {
 			continue
 		}
 
-		// Skip if task is not scheduled to be killed.
-		killTS := task.GetKillTimestamp()
-		if killTS == nil || killTS.IsZero() {
+		// Skip if task is already being killed.
+		if !task.GetKillTimestamp().IsZero() {
 			continue
 		}
 
-		// Don't need to delete if deletionTimestamp already set and earlier.
-		if ts := task.GetDeletionTimestamp(); ktime.IsTimeSetAndEarlier(ts) {
+		// Skip if task is not yet overdue.
+		if deadline := ref.RunningTimestamp.Add(runningTimeout); deadline.After(now) {
+			w.enqueueAfter(rj, "task_running_timeout", time.Until(deadline))
 			continue
 		}
 
-		// If task is not killable with kill timestamp, or is still alive beyond kill timestamp + timeout,
-		// use deletion to kill the task instead.
-		if !killTS.Add(timeout).After(ktime.Now().Time) || task.RequiresKillWithDeletion() {
-			klog.InfoS("jobcontroller: worker deleting killing task",
-				"worker", w.Name(),
+		// Found task to kill.
+		needKill = append(needKill, task)
+
+		klog.InfoS("jobcontroller: reaping overdue running task",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"task", ref.Name,
+		)
+	}
+
+	if len(needKill) == 0 {
+		return nil
+	}
+
+	// Mark tasks as killed due to running timeout.
+	if err := jobutil.ConcurrentTasks(needKill, func(task jobtasks.Task) error {
+		if task.GetKilledFromRunningTimeoutMarker() {
+			return nil
+		}
+		if err := task.SetKilledFromRunningTimeoutMarker(ctx); err != nil {
+			return err
+		}
+
+		klog.InfoS("jobcontroller: set task as killed from running timeout",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"task", task.GetName(),
+		)
+
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "could not set task(s) as killed from running timeout")
+	}
+
+	// Set kill timestamp on tasks.
+	if err := w.setTasksKillTimestamp(ctx, rj, needKill, *ktime.Now()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleStalledTasks looks for running tasks that have not reported any progress
+// within their stall timeout, and emits an event for each of them. If
+// configured, stalled tasks will also be killed so that they can be retried.
+func (w *Reconciler) handleStalledTasks(ctx context.Context, rj *execution.Job, tasks []jobtasks.Task) error {
+	now := ktime.Now().Time
+
+	// Find tasks that need to be killed.
+	needKill := make([]jobtasks.Task, 0, len(tasks))
+	for _, task := range tasks {
+		step, _ := task.GetStepName()
+		stallDetection := jobutil.GetStallDetection(rj, step)
+		if stallDetection == nil {
+			continue
+		}
+
+		stallTask, ok := task.(jobtasks.StallDetectionTask)
+		if !ok {
+			continue
+		}
+
+		ref := task.GetTaskRef()
+
+		// Skip if task already finished.
+		if ts := ref.FinishTimestamp; !ts.IsZero() {
+			continue
+		}
+
+		// Skip if task is not yet running.
+		if ts := ref.RunningTimestamp; ts.IsZero() {
+			continue
+		}
+
+		// Skip if task is already being killed.
+		if !task.GetKillTimestamp().IsZero() {
+			continue
+		}
+
+		// Use the last time that the task reported progress, which is tracked in the
+		// Job's status.
+		lastActive := ref.RunningTimestamp.Time
+		if taskRef := jobutil.FindTaskRef(rj, task); taskRef != nil {
+			if progress := taskRef.Status.Progress; progress != nil && progress.LastUpdateTime != nil &&
+				progress.LastUpdateTime.After(lastActive) {
+				lastActive = progress.LastUpdateTime.Time
+			}
+		}
+
+		// Skip if task is not yet stalled.
+		timeout := time.Duration(stallDetection.TimeoutSeconds) * time.Second
+		if deadline := lastActive.Add(timeout); deadline.After(now) {
+			w.enqueueAfter(rj, "task_stall_timeout", time.Until(deadline))
+			continue
+		}
+
+		// Emit an event the first time that the task is detected to be stalled.
+		if stallTask.GetStalledTimestamp().IsZero() {
+			if err := stallTask.SetStalledTimestamp(ctx, now); err != nil {
+				return errors.Wrapf(err, "could not set stalled timestamp for task %v", task.GetName())
+			}
+
+			klog.InfoS("jobcontroller: detected stalled task",
+				"worker", w.Name(),
+				"namespace", rj.GetNamespace(),
+				"name", rj.GetName(),
+				"task", ref.Name,
+				"lastActive", lastActive,
+			)
+
+			w.recorder.Eventf(rj, corev1.EventTypeWarning, "TaskStalled",
+				"Task %v has not reported any progress since %v", task.GetName(), lastActive.Format(time.RFC3339))
+		}
+
+		if stallDetection.KillStalledTasks {
+			needKill = append(needKill, task)
+		}
+	}
+
+	if len(needKill) == 0 {
+		return nil
+	}
+
+	// Mark tasks as killed from being stalled.
+	if err := jobutil.ConcurrentTasks(needKill, func(task jobtasks.Task) error {
+		stallTask := task.(jobtasks.StallDetectionTask)
+		if stallTask.GetKilledFromStalledMarker() {
+			return nil
+		}
+		if err := stallTask.SetKilledFromStalledMarker(ctx); err != nil {
+			return err
+		}
+
+		klog.InfoS("jobcontroller: set task as killed from being stalled",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"task", task.GetName(),
+		)
+
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "could not set task(s) as killed from being stalled")
+	}
+
+	// Set kill timestamp on tasks.
+	if err := w.setTasksKillTimestamp(ctx, rj, needKill, *ktime.Now()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleNodeLostTasks force deletes unfinished tasks whose node was lost for
+// longer than the configured timeout. Such tasks are marked with the NodeLost
+// reason, and will be replaced without counting towards the Job's maxAttempts.
+func (w *Reconciler) handleNodeLostTasks(
+	ctx context.Context, rj *execution.Job, tasks []jobtasks.Task, cfg *configv1alpha1.JobExecutionConfig,
+) (*execution.Job, error) {
+	timeout := jobutil.GetNodeLostReplaceTimeout(cfg)
+
+	// Replacement is disabled.
+	if timeout <= 0 {
+		return rj, nil
+	}
+
+	// Do not replace tasks that cannot be force deleted, or if the Job is
+	// already being killed.
+	if rj.Spec.Template.Task.ForbidForceDeletion || !rj.Spec.KillTimestamp.IsZero() {
+		return rj, nil
+	}
+
+	needDelete := make([]jobtasks.Task, 0, len(tasks))
+	needDeleteMap := make(map[string]jobtasks.Task)
+
+	for _, task := range tasks {
+		nodeLostTS := task.GetNodeLostTimestamp()
+		if nodeLostTS.IsZero() {
+			continue
+		}
+
+		if !nodeLostTS.Add(timeout).After(ktime.Now().Time) {
+			klog.InfoS("jobcontroller: worker replacing task whose node was lost",
+				"worker", w.Name(),
+				"namespace", rj.GetNamespace(),
+				"name", rj.GetName(),
+				"task", task.GetName(),
+				"nodeLostTimestamp", nodeLostTS,
+			)
+
+			needDelete = append(needDelete, task)
+			needDeleteMap[task.GetName()] = task
+		} else {
+			// Otherwise, enqueue sync after timeout.
+			w.enqueueAfter(rj, "node_lost_task", time.Until(nodeLostTS.Add(timeout)))
+		}
+	}
+
+	if len(needDelete) == 0 {
+		return rj, nil
+	}
+
+	// Update DeletedStatus, will be updated to TaskRef's status once actually deleted.
+	newRj := rj.DeepCopy()
+	newRefs := make([]execution.TaskRef, 0, len(newRj.Status.Tasks))
+	for _, taskRef := range newRj.Status.Tasks {
+		newRef := taskRef.DeepCopy()
+		if task, ok := needDeleteMap[taskRef.Name]; ok && jobutil.IsTaskRefForTask(taskRef, task) {
+			newRef.DeletedStatus = &execution.TaskStatus{
+				State:   execution.TaskDeletedFinalStateUnknown,
+				Result:  jobutil.GetResultPtr(execution.JobResultFinalStateUnknown),
+				Reason:  jobutil.ReasonNodeLost,
+				Message: "Task was forcefully deleted after its node was lost, and will be replaced",
+			}
+		}
+		newRefs = append(newRefs, *newRef)
+	}
+	newRj.Status.Tasks = newRefs
+
+	// Compute new task status.
+	newRj = jobutil.UpdateJobTaskRefs(newRj, tasks)
+
+	w.recorder.Eventf(rj, corev1.EventTypeWarning, "NodeLost",
+		"Replacing %v task(s) after their node was lost for %v", len(needDelete), timeout)
+
+	// Force delete the tasks.
+	if err := w.deleteTasks(ctx, newRj, needDelete, true); err != nil {
+		return newRj, err
+	}
+
+	return newRj, nil
+}
+
+// handleRetainFinishedTasks deletes finished tasks once they have been retained
+// for longer than spec.retainFinishedTasksSeconds. Only tasks which are already
+// observed to be finished in the Job's status from the start of the sync are
+// deleted, to ensure that their final status is not lost once deleted.
+func (w *Reconciler) handleRetainFinishedTasks(ctx context.Context, rj *execution.Job, tasks []jobtasks.Task) error {
+	retain := jobutil.GetRetainFinishedTasks(rj)
+	if retain == nil {
+		return nil
+	}
+
+	now := ktime.Now().Time
+	needDelete := make([]jobtasks.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		taskRef := jobutil.FindTaskRef(rj, task)
+		if taskRef == nil || taskRef.FinishTimestamp.IsZero() {
+			continue
+		}
+
+		deadline := taskRef.FinishTimestamp.Add(*retain)
+		if deadline.After(now) {
+			w.enqueueAfter(rj, "retain_finished_tasks", time.Until(deadline))
+			continue
+		}
+
+		needDelete = append(needDelete, task)
+	}
+
+	if len(needDelete) == 0 {
+		return nil
+	}
+
+	return w.deleteTasks(ctx, rj, needDelete, false)
+}
+
+// handleMaxRuntime sets the kill timestamp of the Job once it has exceeded its
+// maximum runtime, unless an earlier kill timestamp is already set. The kill
+// timestamp is only set after the deadline has passed, since a future kill
+// timestamp would prevent the Job from retrying, suspending or starting new
+// steps in the meantime.
+func (w *Reconciler) handleMaxRuntime(rj *execution.Job, cfg *configv1alpha1.JobExecutionConfig) *execution.Job {
+	maxRuntime := jobutil.GetMaxRuntime(rj, cfg)
+	if maxRuntime <= 0 || rj.Status.StartTime.IsZero() || rj.Status.Phase.IsTerminal() {
+		return rj
+	}
+
+	deadline := metav1.NewTime(rj.Status.StartTime.Add(maxRuntime))
+	if ktime.IsTimeSetAndEarlierThanOrEqualTo(rj.Spec.KillTimestamp, deadline.Time) {
+		return rj
+	}
+
+	// Sync again once the deadline is reached.
+	if ktime.Now().Before(&deadline) {
+		w.enqueueAfter(rj, "max_runtime", time.Until(deadline.Time))
+		return rj
+	}
+
+	klog.InfoS("jobcontroller: setting kill timestamp from max runtime",
+		"worker", w.Name(),
+		"namespace", rj.GetNamespace(),
+		"name", rj.GetName(),
+		"maxRuntime", maxRuntime,
+		"killTimestamp", deadline,
+	)
+
+	w.recorder.Eventf(rj, corev1.EventTypeNormal, "MaxRuntime",
+		"Killing Job after reaching its maximum runtime of %v", maxRuntime)
+
+	newRj := rj.DeepCopy()
+	newRj.Spec.KillTimestamp = &deadline
+	jobutil.MarkKilledFromMaxRuntime(newRj, deadline)
+	return newRj
+}
+
+// handleKillJob updates kill timestamp of all tasks if spec.killTimestamp is set.
+func (w *Reconciler) handleKillJob(ctx context.Context, rj *execution.Job, tasks []jobtasks.Task) error {
+	// Skip if not killing.
+	if rj.Spec.KillTimestamp == nil || ktime.Now().Before(rj.Spec.KillTimestamp) {
+		return nil
+	}
+
+	// Find all tasks that need update.
+	needUpdate := make([]jobtasks.Task, 0, len(tasks))
+	for _, task := range tasks {
+		// Skip finished tasks
+		if jobutil.IsTaskFinished(task) {
+			continue
+		}
+
+		// Skip if kill timestamp exists, and existing one is earlier than the killTimestamp we requested.
+		if ktime.IsTimeSetAndEarlierThanOrEqualTo(task.GetKillTimestamp(), rj.Spec.KillTimestamp.Time) {
+			continue
+		}
+
+		needUpdate = append(needUpdate, task)
+	}
+
+	if len(needUpdate) == 0 {
+		return nil
+	}
+
+	// Set the kill timestamp for tasks.
+	if err := w.setTasksKillTimestamp(ctx, rj, needUpdate, *rj.Spec.KillTimestamp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleSuspendJob kills all active tasks if the Job is suspended, and marks
+// their TaskRefs as suspended so that they will not be counted towards the
+// maximum attempts. For Jobs with steps, the killed steps will be started again
+// once the Job is resumed.
+func (w *Reconciler) handleSuspendJob(
+	ctx context.Context, rj *execution.Job, tasks []jobtasks.Task,
+) (*execution.Job, error) {
+	if !jobutil.IsSuspended(rj) {
+		return rj, nil
+	}
+
+	// Find all active tasks.
+	needUpdate := make([]jobtasks.Task, 0, len(tasks))
+	needUpdateMap := make(map[string]jobtasks.Task)
+	for _, task := range tasks {
+		if jobutil.IsTaskFinished(task) {
+			continue
+		}
+		needUpdate = append(needUpdate, task)
+		needUpdateMap[task.GetName()] = task
+	}
+
+	if len(needUpdate) == 0 {
+		return rj, nil
+	}
+
+	// Mark TaskRefs as suspended before killing the tasks.
+	newRj := rj.DeepCopy()
+	for i, taskRef := range newRj.Status.Tasks {
+		if task, ok := needUpdateMap[taskRef.Name]; ok && jobutil.IsTaskRefForTask(taskRef, task) {
+			newRj.Status.Tasks[i].Suspended = true
+		}
+	}
+
+	// Kill the tasks immediately.
+	if err := w.setTasksKillTimestamp(ctx, newRj, needUpdate, *ktime.Now()); err != nil {
+		return rj, err
+	}
+
+	return newRj, nil
+}
+
+// handleDeleteKillingTasks uses deletion to kill tasks if prior efforts to set kill timestamp on tasks are ineffective.
+func (w *Reconciler) handleDeleteKillingTasks(
+	ctx context.Context, rj *execution.Job, tasks []jobtasks.Task, cfg *configv1alpha1.JobExecutionConfig,
+) (*execution.Job, error) {
+	timeout := jobutil.GetDeleteKillingTimeout(rj, cfg)
+	needDelete := make([]jobtasks.Task, 0, len(tasks))
+	needDeleteMap := make(map[string]jobtasks.Task)
+
+	for _, task := range tasks {
+		// Skip finished tasks.
+		if jobutil.IsTaskFinished(task) {
+			continue
+		}
+
+		// Skip if task is not scheduled to be killed.
+		killTS := task.GetKillTimestamp()
+		if killTS == nil || killTS.IsZero() {
+			continue
+		}
+
+		// Don't need to delete if deletionTimestamp already set and earlier.
+		if ts := task.GetDeletionTimestamp(); ktime.IsTimeSetAndEarlier(ts) {
+			continue
+		}
+
+		// If task is not killable with kill timestamp, or is still alive beyond kill timestamp + timeout,
+		// use deletion to kill the task instead.
+		if !killTS.Add(timeout).After(ktime.Now().Time) || task.RequiresKillWithDeletion() {
+			klog.InfoS("jobcontroller: worker deleting killing task",
+				"worker", w.Name(),
 				"namespace", rj.GetNamespace(),
 				"name", rj.GetName(),
 				"task", task.GetName(),
@@ -590,7 +1389,7 @@ func (w *Reconciler) handleDeleteKillingTasks(
 	newRefs := make([]execution.TaskRef, 0, len(newRj.Status.Tasks))
 	for _, taskRef := range newRj.Status.Tasks {
 		newRef := taskRef.DeepCopy()
-		if task, ok := needDeleteMap[taskRef.Name]; ok {
+		if task, ok := needDeleteMap[taskRef.Name]; ok && jobutil.IsTaskRefForTask(taskRef, task) {
 			// Assume that task is being killed.
 			newRef.DeletedStatus = &execution.TaskStatus{
 				State:   execution.TaskKilled,
@@ -603,6 +1402,14 @@ func (w *Reconciler) handleDeleteKillingTasks(
 			if killedFromPending := task.GetKilledFromPendingTimeoutMarker(); killedFromPending {
 				newRef.DeletedStatus.Result = jobutil.GetResultPtr(execution.JobResultPendingTimeout)
 			}
+
+			// Set running timeout reason if was marked to be killed by running timeout.
+			if killedFromRunning := task.GetKilledFromRunningTimeoutMarker(); killedFromRunning {
+				newRef.DeletedStatus.State = execution.TaskDeadlineExceeded
+				newRef.DeletedStatus.Result = jobutil.GetResultPtr(execution.JobResultDeadlineExceeded)
+				newRef.DeletedStatus.Reason = "RunningTimeout"
+				newRef.DeletedStatus.Message = "Task exceeded its running timeout and was killed via deletion"
+			}
 		}
 
 		newRefs = append(newRefs, *newRef)
@@ -648,6 +1455,12 @@ func (w *Reconciler) handleForceDeleteKillingTasks(
 			continue
 		}
 
+		// Tasks whose node was lost will be replaced by handleNodeLostTasks instead.
+		if !task.GetNodeLostTimestamp().IsZero() && jobutil.GetNodeLostReplaceTimeout(cfg) > 0 &&
+			rj.Spec.KillTimestamp.IsZero() {
+			continue
+		}
+
 		if !deletionTS.Add(timeout).After(ktime.Now().Time) {
 			klog.InfoS("jobcontroller: worker force deleting killing task",
 				"worker", w.Name(),
@@ -677,7 +1490,7 @@ func (w *Reconciler) handleForceDeleteKillingTasks(
 	newRefs := make([]execution.TaskRef, 0, len(newRj.Status.Tasks))
 	for _, taskRef := range newRj.Status.Tasks {
 		newRef := taskRef.DeepCopy()
-		if task, ok := needDeleteMap[taskRef.Name]; ok {
+		if task, ok := needDeleteMap[taskRef.Name]; ok && jobutil.IsTaskRefForTask(taskRef, task) {
 			newRef.DeletedStatus = &execution.TaskStatus{
 				// TaskUnreachable implies that the node was not reachable.
 				State: execution.TaskKilled, // TODO(irvinlim): Consider adding additional state for this
@@ -692,6 +1505,14 @@ func (w *Reconciler) handleForceDeleteKillingTasks(
 			if killedFromPending := task.GetKilledFromPendingTimeoutMarker(); killedFromPending {
 				newRef.DeletedStatus.Result = jobutil.GetResultPtr(execution.JobResultPendingTimeout)
 			}
+
+			// Set running timeout reason if was marked to be killed by running timeout.
+			if killedFromRunning := task.GetKilledFromRunningTimeoutMarker(); killedFromRunning {
+				newRef.DeletedStatus.State = execution.TaskDeadlineExceeded
+				newRef.DeletedStatus.Result = jobutil.GetResultPtr(execution.JobResultDeadlineExceeded)
+				newRef.DeletedStatus.Reason = "RunningTimeout"
+				newRef.DeletedStatus.Message = "Task exceeded its running timeout and was forcefully deleted, container may still be running"
+			}
 		}
 
 		newRefs = append(newRefs, *newRef)
@@ -732,6 +1553,11 @@ func (w *Reconciler) handleTTLAfterFinished(
 		return nil
 	}
 
+	// Only delete the tasks and retain the job.
+	if cfg.TTLAfterFinishedPolicy == configv1alpha1.TTLAfterFinishedPolicyDeleteTasks {
+		return w.deleteTasksAfterFinished(ctx, rj, ttl)
+	}
+
 	klog.V(2).InfoS("jobcontroller: job ttl expired",
 		"worker", w.Name(),
 		"namespace", rj.GetNamespace(),
@@ -744,6 +1570,108 @@ func (w *Reconciler) handleTTLAfterFinished(
 	return w.client.DeleteJob(ctx, rj, metav1.DeleteOptions{})
 }
 
+// deleteTasksAfterFinished deletes all remaining tasks of a finished Job whose
+// TTL has expired, without deleting the Job itself.
+func (w *Reconciler) deleteTasksAfterFinished(ctx context.Context, rj *execution.Job, ttl time.Duration) error {
+	taskMgr, err := w.tasks.ForJob(rj)
+	if err != nil {
+		return errors.Wrapf(err, "cannot get task manager")
+	}
+
+	tasks, err := taskMgr.Lister().List()
+	if err != nil {
+		return errors.Wrapf(err, "could not list tasks")
+	}
+
+	// All tasks were already deleted.
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	klog.V(2).InfoS("jobcontroller: job ttl expired, deleting tasks",
+		"worker", w.Name(),
+		"namespace", rj.GetNamespace(),
+		"name", rj.GetName(),
+		"ttl", ttl,
+		"ageSinceFinish", time.Since(rj.Status.Condition.Finished.FinishedAt.Time),
+	)
+
+	return w.deleteTasks(ctx, rj, tasks, false)
+}
+
+// handleMaxFinishedJobs deletes the oldest finished Jobs belonging to the same
+// JobConfig as the given Job, if the number of finished Jobs exceeds the
+// configured limit.
+func (w *Reconciler) handleMaxFinishedJobs(
+	ctx context.Context,
+	rj *execution.Job,
+	cfg *configv1alpha1.JobExecutionConfig,
+) error {
+	// Not enabled.
+	limit := cfg.MaxFinishedJobsPerJobConfig
+	if limit == nil || *limit <= 0 {
+		return nil
+	}
+
+	// Skip if not finished or already being deleted.
+	if rj.Status.Condition.Finished == nil || isDeleted(rj) {
+		return nil
+	}
+
+	// Jobs that do not belong to any JobConfig are not affected.
+	jobConfigUID, ok := rj.Labels[jobconfig.LabelKeyJobConfigUID]
+	if !ok || jobConfigUID == "" {
+		return nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{jobconfig.LabelKeyJobConfigUID: jobConfigUID})
+	rjs, err := w.jobInformer.Lister().Jobs(rj.GetNamespace()).List(selector)
+	if err != nil {
+		return errors.Wrapf(err, "cannot list jobs")
+	}
+
+	// Use the latest copy of the Job being reconciled, since it may not yet be
+	// reflected in the cache.
+	finished := make([]*execution.Job, 0, len(rjs))
+	finished = append(finished, rj)
+	for _, other := range rjs {
+		if other.GetName() == rj.GetName() {
+			continue
+		}
+		if other.Status.Condition.Finished != nil && !isDeleted(other) {
+			finished = append(finished, other)
+		}
+	}
+
+	if int64(len(finished)) <= *limit {
+		return nil
+	}
+
+	// Sort by finish time in descending order.
+	sort.Slice(finished, func(i, j int) bool {
+		ti := finished[i].Status.Condition.Finished.FinishedAt
+		tj := finished[j].Status.Condition.Finished.FinishedAt
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return finished[i].GetName() > finished[j].GetName()
+	})
+
+	for _, old := range finished[*limit:] {
+		klog.V(2).InfoS("jobcontroller: deleting job exceeding max finished jobs",
+			"worker", w.Name(),
+			"namespace", old.GetNamespace(),
+			"name", old.GetName(),
+			"limit", *limit,
+		)
+		if err := w.client.DeleteJob(ctx, old, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "cannot delete job %v", old.GetName())
+		}
+	}
+
+	return nil
+}
+
 // handleFinishFinalizer deletes dependent objects if it is due to be deleted.
 // The purpose of this finalizer is to serialize the following:
 // 1. Delete dependent tasks
@@ -789,8 +1717,14 @@ func (w *Reconciler) handleFinishFinalizer(
 		tasks = append(tasks, task)
 	}
 
-	// There are some tasks that are still not deleted, so we need to delete them.
-	if len(tasks) > 0 {
+	// Orphan tasks instead of deleting them if specified by the Job. Orphaned tasks
+	// are no longer owned by the Job, so the Job can be finalized immediately.
+	if jobutil.ShouldOrphanTasks(rj) {
+		if err := w.orphanTasks(ctx, rj, tasks); err != nil {
+			return rj, errors.Wrapf(err, "cannot orphan tasks")
+		}
+	} else if len(tasks) > 0 {
+		// There are some tasks that are still not deleted, so we need to delete them.
 		// Update DeletedStatus for tasks.
 		for _, task := range tasks {
 			rj = jobutil.UpdateTaskRefDeletedStatusIfNotSet(rj, task.GetName(), execution.TaskStatus{
@@ -813,8 +1747,8 @@ func (w *Reconciler) handleFinishFinalizer(
 		return rj, nil
 	}
 
-	// Once at this part, all tasks are guaranteed to have been completely deleted.
-	// Update Job's task ref status first.
+	// Once at this part, all tasks are guaranteed to have been completely deleted
+	// or orphaned. Update Job's task ref status first.
 	rj = w.updateTaskRefStatus(rj, tasks)
 
 	newRj := rj.DeepCopy()
@@ -832,15 +1766,56 @@ func (w *Reconciler) handleFinishFinalizer(
 	return newRj, nil
 }
 
+// orphanTasks will concurrently orphan all tasks for the given Job, so that
+// they are not deleted together with the Job.
+func (w *Reconciler) orphanTasks(ctx context.Context, rj *execution.Job, tasks []jobtasks.Task) error {
+	return jobutil.ConcurrentTasks(tasks, func(task jobtasks.Task) error {
+		orphanTask, ok := task.(jobtasks.OrphanableTask)
+		if !ok {
+			return fmt.Errorf("task %v cannot be orphaned", task.GetName())
+		}
+		if orphanTask.IsOrphaned() {
+			return nil
+		}
+		if err := orphanTask.Orphan(ctx); err != nil {
+			return errors.Wrapf(err, "cannot orphan task %v", task.GetName())
+		}
+
+		klog.InfoS("jobcontroller: orphaned task",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"task", task.GetName(),
+		)
+
+		w.recorder.Eventf(rj, corev1.EventTypeNormal, "Orphaned", "Orphaned task %v", task.GetName())
+		return nil
+	})
+}
+
 func (w *Reconciler) setTasksKillTimestamp(
 	ctx context.Context, rj *execution.Job, tasks []jobtasks.Task, killTimestamp metav1.Time,
 ) error {
+	// Run pre-kill hooks before setting the kill timestamp.
+	tasks, err := w.runPreKillHooks(ctx, rj, tasks)
+	if err != nil {
+		return err
+	}
+
 	if err := jobutil.ConcurrentTasks(tasks, func(task jobtasks.Task) error {
 		if ktime.IsTimeSetAndEarlierThanOrEqualTo(task.GetKillTimestamp(), ktime.Now().Time) {
 			return nil
 		}
 
-		if err := task.SetKillTimestamp(ctx, killTimestamp.Time); err != nil {
+		// Kill escalation should only start once the pre-kill hook is done.
+		ts := killTimestamp.Time
+		if hookTask, ok := task.(jobtasks.PreKillHookTask); ok && !hookTask.GetPreKillHookTimestamp().IsZero() {
+			if now := ktime.Now().Time; now.After(ts) {
+				ts = now
+			}
+		}
+
+		if err := task.SetKillTimestamp(ctx, ts); err != nil {
 			return err
 		}
 
@@ -861,6 +1836,81 @@ func (w *Reconciler) setTasksKillTimestamp(
 	return nil
 }
 
+// runPreKillHooks starts the pre-kill hook for tasks that are about to be
+// killed, and returns the list of tasks which are ready to be killed. Tasks are
+// ready to be killed once their hook has exited or timed out, or if they do not
+// need to run any hook.
+func (w *Reconciler) runPreKillHooks(
+	ctx context.Context, rj *execution.Job, tasks []jobtasks.Task,
+) ([]jobtasks.Task, error) {
+	ready := make([]jobtasks.Task, 0, len(tasks))
+	for _, task := range tasks {
+		isReady, err := w.runPreKillHook(ctx, rj, task)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not run pre-kill hook for task %v", task.GetName())
+		}
+		if isReady {
+			ready = append(ready, task)
+		}
+	}
+	return ready, nil
+}
+
+func (w *Reconciler) runPreKillHook(ctx context.Context, rj *execution.Job, task jobtasks.Task) (bool, error) {
+	step, _ := task.GetStepName()
+	hook := jobutil.GetPreKillHook(rj, step)
+	if hook == nil {
+		return true, nil
+	}
+
+	// Hooks can only be executed in tasks that are running, and are not already
+	// being killed.
+	hookTask, ok := task.(jobtasks.PreKillHookTask)
+	if !ok || task.GetTaskRef().RunningTimestamp.IsZero() || !task.GetKillTimestamp().IsZero() {
+		return true, nil
+	}
+
+	now := ktime.Now()
+	timeout := jobutil.GetPreKillHookTimeout(hook)
+	namespace, name := hookTask.GetNamespace(), hookTask.GetName()
+
+	// Start the hook if it was not yet started.
+	startTime := hookTask.GetPreKillHookTimestamp()
+	if startTime.IsZero() {
+		if err := hookTask.SetPreKillHookTimestamp(ctx, now.Time); err != nil {
+			return false, errors.Wrapf(err, "could not set pre-kill hook timestamp")
+		}
+
+		w.preKillHooks.Run(namespace, name, hook, timeout, func() {
+			w.enqueueAfter(rj, "pre_kill_hook_finished", 0)
+		})
+
+		klog.InfoS("jobcontroller: worker started pre-kill hook for task",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"task", name,
+			"container", hook.Container,
+			"timeout", timeout,
+		)
+
+		w.recorder.Eventf(rj, corev1.EventTypeNormal, "PreKillHook",
+			"Running pre-kill hook for task %v", name)
+		w.enqueueAfter(rj, "pre_kill_hook_timeout", timeout)
+		return false, nil
+	}
+
+	// Wait until the hook has finished or timed out.
+	deadline := startTime.Add(timeout)
+	if !w.preKillHooks.IsFinished(namespace, name) && now.Time.Before(deadline) {
+		w.enqueueAfter(rj, "pre_kill_hook_timeout", deadline.Sub(now.Time))
+		return false, nil
+	}
+
+	w.preKillHooks.Forget(namespace, name)
+	return true, nil
+}
+
 // deleteTasks will concurrently delete all tasks for the given Job.
 // It will also optionally update its status on its TaskRefs to avoid reconciling as TASK_LOST.
 func (w *Reconciler) deleteTasks(
@@ -17,15 +17,19 @@
 package jobcontroller_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/config"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/jobcontroller"
 	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
@@ -39,7 +43,9 @@ import (
 func TestReconciler(t *testing.T) {
 	test := runtimetesting.ReconcilerTest{
 		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
-			return jobcontroller.NewContextWithRecorder(c, recorder)
+			ctrlContext := jobcontroller.NewContextWithRecorder(c, recorder, nil)
+			ctrlContext.SetCommandExecutor(&fakeCommandExecutor{})
+			return ctrlContext
 		},
 		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
 			return jobcontroller.NewReconciler(c.(*jobcontroller.Context), runtimetesting.ReconcilerDefaultConcurrency)
@@ -72,7 +78,69 @@ func TestReconciler(t *testing.T) {
 				},
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace, fakeJobResult),
+						newApplyJobStatusAction(fakeJobResult),
+					},
+				},
+			},
+		},
+		{
+			Name:   "adopt existing pod not found in cache",
+			Target: fakeJob,
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "get",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakePodResult.DeepCopy(), nil
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobResult),
+					},
+				},
+			},
+		},
+		{
+			Name:   "cannot create pod that already exists for another job",
+			Now:    testutils.Mktime(now),
+			Target: fakeJob,
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "get",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakePodForAnotherJob.DeepCopy(), nil
+						},
+					},
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, nil, kerrors.NewAlreadyExists(corev1.Resource("pods"), fakePod.Name)
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, fakePod),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					ActionGenerators: []runtimetesting.ActionGenerator{
+						func() (runtimetesting.Action, error) {
+							return runtimetesting.NewUpdateJobAction(jobNamespace, fakeJobAdmissionErrorForExistingPod()), nil
+						},
+						func() (runtimetesting.Action, error) {
+							return newApplyJobStatusAction(fakeJobAdmissionErrorForExistingPod()), nil
+						},
 					},
 				},
 			},
@@ -91,7 +159,7 @@ func TestReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace, fakeJobPending),
+						newApplyJobStatusAction(fakeJobPending),
 					},
 				},
 			},
@@ -128,7 +196,30 @@ func TestReconciler(t *testing.T) {
 						func() (runtimetesting.Action, error) {
 							// NOTE(irvinlim): Can only generate JobStatus after the clock is mocked
 							object := generateJobStatusFromPod(fakeJobResult, fakePodPendingTimeoutTerminating)
-							return runtimetesting.NewUpdateJobStatusAction(jobNamespace, object), nil
+							return newApplyJobStatusAction(object), nil
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:   "kill pod pending with fail fast reason",
+			Target: fakeJobPendingFailFast,
+			Fixtures: []runtime.Object{
+				fakePodPending,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					ActionGenerators: []runtimetesting.ActionGenerator{
+						func() (runtimetesting.Action, error) {
+							newPod := fakePodPending.DeepCopy()
+							meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyKilledFromPendingTimeout, "1")
+							return runtimetesting.NewUpdatePodAction(jobNamespace, newPod), nil
+						},
+						func() (runtimetesting.Action, error) {
+							newPod := killPod(fakePodPending, testutils.Mktime(now))
+							meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyKilledFromPendingTimeout, "1")
+							return runtimetesting.NewUpdatePodAction(jobNamespace, newPod), nil
 						},
 					},
 				},
@@ -167,217 +258,969 @@ func TestReconciler(t *testing.T) {
 			},
 		},
 		{
-			Name:   "do nothing if kill timestamp is not yet reached",
-			Target: fakeJobWithKillTimestamp,
-			Fixtures: []runtime.Object{
-				fakePodPending,
-			},
-		},
-		{
-			Name:   "kill pod with kill timestamp",
-			Now:    testutils.Mktime(killTime),
-			Target: fakeJobWithKillTimestamp,
+			Name:   "kill pod with running timeout",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobWithRunningTimeout,
 			Fixtures: []runtime.Object{
-				fakePodPending,
+				fakePodRunning,
 			},
 			WantActions: runtimetesting.CombinedActions{
 				Kubernetes: runtimetesting.ActionTest{
-					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdatePodAction(jobNamespace, fakePodTerminating),
+					ActionGenerators: []runtimetesting.ActionGenerator{
+						func() (runtimetesting.Action, error) {
+							newPod := fakePodRunning.DeepCopy()
+							meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyKilledFromRunningTimeout, "1")
+							return runtimetesting.NewUpdatePodAction(jobNamespace, newPod), nil
+						},
+						func() (runtimetesting.Action, error) {
+							return runtimetesting.NewUpdatePodAction(jobNamespace, fakePodRunningTimeoutTerminating), nil
+						},
 					},
 				},
 			},
 		},
 		{
-			Name:   "do nothing with existing kill timestamp",
-			Now:    testutils.Mktime(killTime),
-			Target: fakeJobWithKillTimestamp,
+			Name:   "do nothing if running timeout is not yet reached",
+			Target: fakeJobWithRunningTimeout,
 			Fixtures: []runtime.Object{
-				fakePodTerminating,
+				fakePodRunning,
 			},
 		},
 		{
-			Name: "delete pod with kill timestamp",
-			Now: testutils.Mktime(killTime).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second),
-			Target: fakeJobWithKillTimestamp,
+			Name:   "mark stalled task",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobWithStallDetection,
 			Fixtures: []runtime.Object{
-				fakePodTerminating,
+				fakePodRunning,
 			},
 			WantActions: runtimetesting.CombinedActions{
-				Furiko: runtimetesting.ActionTest{
-					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace, fakeJobPodDeleting),
-					},
-				},
 				Kubernetes: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+						runtimetesting.NewUpdatePodAction(jobNamespace, fakePodRunningStalled),
 					},
 				},
 			},
 		},
 		{
-			Name: "force delete pod with kill timestamp",
-			Now: testutils.Mktime(killTime).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.ForceDeleteKillingTasksTimeoutSeconds) * time.Second),
-			Target: fakeJobPodDeleting,
+			Name:   "do nothing if already marked as stalled",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobWithStallDetection,
 			Fixtures: []runtime.Object{
-				fakePodDeleting,
+				fakePodRunningStalled,
+			},
+		},
+		{
+			Name:   "kill stalled task",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobWithStallDetectionKill,
+			Fixtures: []runtime.Object{
+				fakePodRunning,
 			},
 			WantActions: runtimetesting.CombinedActions{
-				Furiko: runtimetesting.ActionTest{
-					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace, fakeJobPodForceDeleting),
-					},
-				},
 				Kubernetes: runtimetesting.ActionTest{
-					Actions: []runtimetesting.Action{
-						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+					ActionGenerators: []runtimetesting.ActionGenerator{
+						func() (runtimetesting.Action, error) {
+							return runtimetesting.NewUpdatePodAction(jobNamespace, fakePodRunningStalled), nil
+						},
+						func() (runtimetesting.Action, error) {
+							newPod := fakePodRunningStalled.DeepCopy()
+							meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyKilledFromStalled, "1")
+							return runtimetesting.NewUpdatePodAction(jobNamespace, newPod), nil
+						},
+						func() (runtimetesting.Action, error) {
+							return runtimetesting.NewUpdatePodAction(jobNamespace, fakePodRunningStalledTerminating), nil
+						},
 					},
 				},
 			},
 		},
 		{
-			Name: "do not force delete pod if disabled via config",
-			Now: testutils.Mktime(killTime).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.ForceDeleteKillingTasksTimeoutSeconds) * time.Second),
-			Target: fakeJobPodDeleting,
+			Name:   "do nothing if stall timeout is not yet reached",
+			Target: fakeJobWithStallDetectionKill,
 			Fixtures: []runtime.Object{
-				fakePodDeleting,
-			},
-			Configs: controllercontext.ConfigsMap{
-				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
-					ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(0),
-				},
+				fakePodRunning,
 			},
 		},
 		{
-			Name: "do not force delete pod if disabled via JobSpec",
-			Now: testutils.Mktime(killTime).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.ForceDeleteKillingTasksTimeoutSeconds) * time.Second),
-			Target: fakeJobPodDeletingForbidForceDeletion,
+			Name: "do nothing if task recently reported progress",
+			Now:  testutils.Mktime(later15m),
+			TargetGenerator: func() runtime.Object {
+				// NOTE(irvinlim): Can only generate JobStatus after the clock is mocked
+				return generateJobStatusFromPod(fakeJobWithStallDetectionKill, fakePodRunningWithProgress)
+			},
 			Fixtures: []runtime.Object{
-				fakePodDeleting,
+				fakePodRunningWithProgress,
 			},
 		},
 		{
-			Name:   "do nothing with already deleted pod",
-			Now:    testutils.Mktime(killTime).Add(time.Minute * 3),
-			Target: fakeJobPodDeleted,
+			Name:   "do not set kill timestamp before max runtime is reached",
+			Target: fakeJobWithMaxRuntime,
+			Fixtures: []runtime.Object{
+				fakePodRunning,
+			},
 		},
 		{
-			Name:   "finalize job",
-			Target: fakeJobWithDeletionTimestamp,
+			Name:   "retry failed task before max runtime is reached",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobWithMaxRuntimeRetryFailed,
 			Fixtures: []runtime.Object{
-				fakePodTerminating,
+				fakePodWithMaxRuntimeRetryFailed,
 			},
-			WantActions: runtimetesting.CombinedActions{
-				Furiko: runtimetesting.ActionTest{
-					ActionGenerators: []runtimetesting.ActionGenerator{
-						func() (runtimetesting.Action, error) {
-							// NOTE(irvinlim): Can safely ignore the transient status update here
-							action := runtimetesting.NewUpdateJobStatusAction(jobNamespace, fakeJobWithDeletionTimestamp)
-							action.IgnoreObject = true
-							return action, nil
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakePodWithMaxRuntimeRetry2Result.DeepCopy(), nil
 						},
 					},
 				},
+			},
+			WantActions: runtimetesting.CombinedActions{
 				Kubernetes: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+						runtimetesting.NewCreatePodAction(jobNamespace, fakePodWithMaxRuntimeRetry2),
 					},
 				},
-			},
-		},
-		{
-			Name:   "finalize job with already deleted pods",
-			Target: fakeJobWithDeletionTimestampAndKilledPods,
-			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobAction(jobNamespace, fakeJobWithDeletionTimestampAndDeletedPods),
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace, fakeJobWithDeletionTimestampAndDeletedPods),
+						newApplyJobStatusAction(generateJobStatusFromPods(fakeJobWithMaxRuntimeRetryFailed,
+							fakePodWithMaxRuntimeRetryFailed, fakePodWithMaxRuntimeRetry2Result)),
 					},
 				},
 			},
 		},
 		{
-			Name:   "pod succeeded",
-			Now:    testutils.Mktime(later15m),
-			Target: fakeJobResult,
+			Name:   "kill pod when job with max runtime is suspended",
+			Now:    testutils.Mktime(killTime),
+			Target: withMaxRuntime(fakeJobSuspended),
 			Fixtures: []runtime.Object{
-				fakePodFinished,
+				fakePodPending,
 			},
 			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdatePodAction(jobNamespace, fakePodTerminating),
+					},
+				},
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace, fakeJobFinished),
+						newApplyJobStatusAction(withMaxRuntime(fakeJobSuspendedPodKilled)),
 					},
 				},
 			},
 		},
 		{
-			Name:   "don't delete finished job on TTL after created/started",
-			Now:    testutils.Mktime(later60m),
-			Target: fakeJobFinished,
+			Name:   "create pod for next step before max runtime is reached",
+			Target: withMaxRuntime(fakeJobWithStepsPrepareSucceeded),
 			Fixtures: []runtime.Object{
-				fakePodFinished,
+				fakeStepPodPrepareSucceeded,
 			},
-		},
-		{
-			Name: "delete finished job on TTL after finished",
-			Now: testutils.Mktime(finishTime).
-				Add(time.Duration(*config.DefaultJobExecutionConfig.DefaultTTLSecondsAfterFinished) * time.Second),
-			Target: fakeJobFinished,
-			Fixtures: []runtime.Object{
-				fakePodFinished,
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakeStepPodPublish.DeepCopy(), nil
+						},
+					},
+				},
 			},
 			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, newStepPodToCreate(fakeJobWithSteps, "publish")),
+					},
+				},
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewDeleteJobAction(jobNamespace, fakeJob.Name),
+						newApplyJobStatusAction(withMaxRuntime(fakeJobWithStepsPublishCreated)),
 					},
 				},
 			},
 		},
 		{
-			Name:   "delete finished job immediately after finished if set via config",
-			Now:    testutils.Mktime(finishTime),
-			Target: fakeJobFinished,
+			Name:   "kill pod after exceeding max runtime",
+			Now:    testutils.Mktime(maxRuntimeKillTime),
+			Target: fakeJobWithMaxRuntime,
 			Fixtures: []runtime.Object{
-				fakePodFinished,
+				fakePodRunning,
 			},
 			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdatePodAction(jobNamespace,
+							killPod(fakePodRunning, testutils.Mktime(maxRuntimeKillTime))),
+					},
+				},
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewDeleteJobAction(jobNamespace, fakeJob.Name),
+						runtimetesting.NewUpdateJobAction(jobNamespace, fakeJobWithMaxRuntimeKillTimestamp),
 					},
 				},
 			},
-			Configs: controllercontext.ConfigsMap{
-				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
-					DefaultTTLSecondsAfterFinished: pointer.Int64(0),
-				},
+		},
+		{
+			Name:   "do nothing if kill timestamp is not yet reached",
+			Target: fakeJobWithKillTimestamp,
+			Fixtures: []runtime.Object{
+				fakePodPending,
 			},
 		},
 		{
-			Name:   "delete finished job immediately after finished if set via JobSpec",
-			Now:    testutils.Mktime(finishTime),
-			Target: fakeJobFinishedWithTTLAfterFinished,
+			Name:   "kill pod with kill timestamp",
+			Now:    testutils.Mktime(killTime),
+			Target: fakeJobWithKillTimestamp,
 			Fixtures: []runtime.Object{
-				fakePodFinished,
+				fakePodPending,
 			},
 			WantActions: runtimetesting.CombinedActions{
-				Furiko: runtimetesting.ActionTest{
+				Kubernetes: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewDeleteJobAction(jobNamespace, fakeJob.Name),
+						runtimetesting.NewUpdatePodAction(jobNamespace, fakePodTerminating),
 					},
 				},
 			},
 		},
-	})
+		{
+			Name:   "run pre-kill hook before killing pod",
+			Now:    testutils.Mktime(preKillHookTime),
+			Target: fakeJobWithPreKillHook,
+			Fixtures: []runtime.Object{
+				fakePodRunning,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdatePodAction(jobNamespace, fakePodRunningPreKillHook),
+					},
+				},
+			},
+		},
+		{
+			Name:   "do not kill pod while pre-kill hook is running",
+			Now:    testutils.Mktime(preKillHookTime),
+			Target: fakeJobWithPreKillHook,
+			Fixtures: []runtime.Object{
+				fakePodRunningPreKillHook,
+			},
+		},
+		{
+			Name:   "kill pod after pre-kill hook timeout",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobWithPreKillHook,
+			Fixtures: []runtime.Object{
+				fakePodRunningPreKillHook,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdatePodAction(jobNamespace, killPod(fakePodRunningPreKillHook, testutils.Mktime(later15m))),
+					},
+				},
+			},
+		},
+		{
+			Name:   "kill pod when job is suspended",
+			Now:    testutils.Mktime(killTime),
+			Target: fakeJobSuspended,
+			Fixtures: []runtime.Object{
+				fakePodPending,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdatePodAction(jobNamespace, fakePodTerminating),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobSuspendedPodKilled),
+					},
+				},
+			},
+		},
+		{
+			Name:   "do nothing with existing kill timestamp when job is suspended",
+			Now:    testutils.Mktime(killTime),
+			Target: fakeJobSuspendedPodKilled,
+			Fixtures: []runtime.Object{
+				fakePodTerminating,
+			},
+		},
+		{
+			Name:   "delete previous pod to retry in-place",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobInPlaceRetryFailed,
+			Fixtures: []runtime.Object{
+				fakePodInPlaceRetryFailed,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePodInPlaceRetryFailed.Name),
+					},
+				},
+			},
+		},
+		{
+			Name:   "create pod with same name to retry in-place",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobInPlaceRetryFailed,
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakePodInPlaceRetry2Result.DeepCopy(), nil
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, fakePodInPlaceRetry2),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(generateJobStatusFromPods(fakeJobInPlaceRetryFailed, fakePodInPlaceRetry2Result)),
+					},
+				},
+			},
+		},
+		{
+			Name:   "create new pod when job is resumed",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobResumed,
+			Fixtures: []runtime.Object{
+				fakePodFinishedSuspended,
+			},
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakePod2Result.DeepCopy(), nil
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, fakePod2),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(generateJobStatusFromPods(fakeJobResumed, fakePodFinishedSuspended, fakePod2Result)),
+					},
+				},
+			},
+		},
+		{
+			Name:   "do nothing with existing kill timestamp",
+			Now:    testutils.Mktime(killTime),
+			Target: fakeJobWithKillTimestamp,
+			Fixtures: []runtime.Object{
+				fakePodTerminating,
+			},
+		},
+		{
+			Name: "delete pod with kill timestamp",
+			Now: testutils.Mktime(killTime).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second),
+			Target: fakeJobWithKillTimestamp,
+			Fixtures: []runtime.Object{
+				fakePodTerminating,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobPodDeleting),
+					},
+				},
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+					},
+				},
+			},
+		},
+		{
+			Name: "do not delete pod with kill timestamp within kill grace period",
+			Now: testutils.Mktime(killTime).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second),
+			Target: fakeJobPodTerminatingWithKillGracePeriod,
+			Fixtures: []runtime.Object{
+				fakePodTerminating,
+			},
+		},
+		{
+			Name:   "delete pod with kill timestamp after kill grace period",
+			Now:    testutils.Mktime(killTime).Add(time.Minute * 10),
+			Target: fakeJobPodTerminatingWithKillGracePeriod,
+			Fixtures: []runtime.Object{
+				fakePodTerminating,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobPodDeletingWithKillGracePeriod),
+					},
+				},
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+					},
+				},
+			},
+		},
+		{
+			Name: "force delete pod with kill timestamp",
+			Now: testutils.Mktime(killTime).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.ForceDeleteKillingTasksTimeoutSeconds) * time.Second),
+			Target: fakeJobPodDeleting,
+			Fixtures: []runtime.Object{
+				fakePodDeleting,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobPodForceDeleting),
+					},
+				},
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+					},
+				},
+			},
+		},
+		{
+			Name: "do not force delete pod if disabled via config",
+			Now: testutils.Mktime(killTime).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.ForceDeleteKillingTasksTimeoutSeconds) * time.Second),
+			Target: fakeJobPodDeleting,
+			Fixtures: []runtime.Object{
+				fakePodDeleting,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(0),
+				},
+			},
+		},
+		{
+			Name: "do not force delete pod if disabled via JobSpec",
+			Now: testutils.Mktime(killTime).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.DeleteKillingTasksTimeoutSeconds) * time.Second).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.ForceDeleteKillingTasksTimeoutSeconds) * time.Second),
+			Target: fakeJobPodDeletingForbidForceDeletion,
+			Fixtures: []runtime.Object{
+				fakePodDeleting,
+			},
+		},
+		{
+			Name:   "do not replace pod if node was lost recently",
+			Now:    testutils.Mktime(killTime).Add(time.Minute),
+			Target: fakeJobNodeLost,
+			Fixtures: []runtime.Object{
+				fakePodNodeLost,
+			},
+		},
+		{
+			Name: "force delete pod after node was lost",
+			Now: testutils.Mktime(killTime).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.NodeLostReplaceTimeoutSeconds) * time.Second),
+			Target: fakeJobNodeLost,
+			Fixtures: []runtime.Object{
+				fakePodNodeLost,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobNodeLostReplacing),
+					},
+				},
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+					},
+				},
+			},
+		},
+		{
+			Name:   "do nothing with already deleted pod",
+			Now:    testutils.Mktime(killTime).Add(time.Minute * 3),
+			Target: fakeJobPodDeleted,
+		},
+		{
+			Name:   "finalize job",
+			Target: fakeJobWithDeletionTimestamp,
+			Fixtures: []runtime.Object{
+				fakePodTerminating,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					ActionGenerators: []runtimetesting.ActionGenerator{
+						func() (runtimetesting.Action, error) {
+							// NOTE(irvinlim): Can safely ignore the transient status update here
+							action := newApplyJobStatusAction(fakeJobWithDeletionTimestamp)
+							action.IgnoreObject = true
+							return action, nil
+						},
+					},
+				},
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePod.Name),
+					},
+				},
+			},
+		},
+		{
+			Name:   "finalize job with already deleted pods",
+			Target: fakeJobWithDeletionTimestampAndKilledPods,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace, fakeJobWithDeletionTimestampAndDeletedPods),
+						newApplyJobStatusAction(fakeJobWithDeletionTimestampAndDeletedPods),
+					},
+				},
+			},
+		},
+		{
+			Name:   "finalize job by orphaning pods",
+			Target: fakeJobWithDeletionTimestampAndOrphanPolicy,
+			Fixtures: []runtime.Object{
+				fakePodRunning,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace, fakeJobWithDeletionTimestampAndOrphanedPods),
+					},
+				},
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdatePodAction(jobNamespace, fakePodRunningOrphaned),
+					},
+				},
+			},
+		},
+		{
+			Name:   "pod succeeded",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobResult,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobFinished),
+					},
+				},
+			},
+		},
+		{
+			Name:   "delete volume claims when pod succeeded",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobWithVolumeClaims,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeleteAction(
+							corev1.SchemeGroupVersion.WithResource("persistentvolumeclaims"),
+							jobNamespace,
+							podtaskexecutor.GetPodVolumeClaimName(fakePodFinished.Name, "scratch"),
+						),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobWithVolumeClaimsFinished),
+					},
+				},
+			},
+		},
+		{
+			Name:   "retain finished pod within retention period",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobFinishedWithRetainFinishedTasks,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+		},
+		{
+			Name:   "delete finished pod after retention period",
+			Now:    testutils.Mktime(later15m),
+			Target: fakeJobFinishedWithRetainFinishedTasks,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePodFinished.Name),
+					},
+				},
+			},
+		},
+		{
+			Name:   "set kill timestamp from kill request",
+			Target: fakeJobPendingWithRequestKill,
+			Fixtures: []runtime.Object{
+				fakePodPending,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace, fakeJobWithKillTimestamp),
+					},
+				},
+			},
+		},
+		{
+			Name:   "create job from retry request",
+			Target: fakeJobFinishedWithRequestRetry,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreateJobAction(jobNamespace, fakeJobRetry),
+						runtimetesting.NewUpdateJobAction(jobNamespace, fakeJobFinished),
+					},
+				},
+			},
+		},
+		{
+			Name:   "don't delete finished job on TTL after created/started",
+			Now:    testutils.Mktime(later60m),
+			Target: fakeJobFinished,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+		},
+		{
+			Name: "delete finished job on TTL after finished",
+			Now: testutils.Mktime(finishTime).
+				Add(time.Duration(*config.DefaultJobExecutionConfig.DefaultTTLSecondsAfterFinished) * time.Second),
+			Target: fakeJobFinished,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeleteJobAction(jobNamespace, fakeJob.Name),
+					},
+				},
+			},
+		},
+		{
+			Name:   "delete finished job immediately after finished if set via config",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobFinished,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeleteJobAction(jobNamespace, fakeJob.Name),
+					},
+				},
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					DefaultTTLSecondsAfterFinished: pointer.Int64(0),
+				},
+			},
+		},
+		{
+			Name:   "delete finished job immediately after finished if set via JobSpec",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobFinishedWithTTLAfterFinished,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeleteJobAction(jobNamespace, fakeJob.Name),
+					},
+				},
+			},
+		},
+		{
+			Name:   "delete pods but retain finished job on TTL if set via config",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobFinished,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakePodFinished.Name),
+					},
+				},
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					DefaultTTLSecondsAfterFinished: pointer.Int64(0),
+					TTLAfterFinishedPolicy:         configv1alpha1.TTLAfterFinishedPolicyDeleteTasks,
+				},
+			},
+		},
+		{
+			Name:   "do nothing on TTL if pods were already deleted",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobFinished,
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					DefaultTTLSecondsAfterFinished: pointer.Int64(0),
+					TTLAfterFinishedPolicy:         configv1alpha1.TTLAfterFinishedPolicyDeleteTasks,
+				},
+			},
+		},
+		{
+			Name:   "delete oldest finished job exceeding max finished jobs",
+			Target: fakeJobFinishedForJobConfig,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+				fakeOlderJobFinishedForJobConfig,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeleteJobAction(jobNamespace, fakeOlderJobFinishedForJobConfig.Name),
+					},
+				},
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxFinishedJobsPerJobConfig: pointer.Int64(1),
+				},
+			},
+		},
+		{
+			Name:   "do nothing within max finished jobs",
+			Target: fakeJobFinishedForJobConfig,
+			Fixtures: []runtime.Object{
+				fakePodFinished,
+				fakeOlderJobFinishedForJobConfig,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxFinishedJobsPerJobConfig: pointer.Int64(2),
+				},
+			},
+		},
+		{
+			Name:   "create pod for first step",
+			Target: fakeJobWithSteps,
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakeStepPodPrepare.DeepCopy(), nil
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, newStepPodToCreate(fakeJobWithSteps, "prepare")),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobWithStepsPrepareCreated),
+					},
+				},
+			},
+		},
+		{
+			Name:   "adopt existing pod for step that already exists",
+			Target: fakeJobWithSteps,
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, nil, kerrors.NewAlreadyExists(corev1.Resource("pods"), fakeStepPodPrepare.Name)
+						},
+					},
+					{
+						Verb:     "get",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakeStepPodPrepare.DeepCopy(), nil
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, newStepPodToCreate(fakeJobWithSteps, "prepare")),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobWithStepsPrepareCreated),
+					},
+				},
+			},
+		},
+		{
+			Name:     "do nothing while waiting for step dependencies",
+			Target:   fakeJobWithStepsPrepareCreated,
+			Fixtures: []runtime.Object{fakeStepPodPrepare},
+		},
+		{
+			Name:   "create pod for next step after dependency succeeded",
+			Target: fakeJobWithStepsPrepareSucceeded,
+			Fixtures: []runtime.Object{
+				fakeStepPodPrepareSucceeded,
+			},
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakeStepPodPublish.DeepCopy(), nil
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, newStepPodToCreate(fakeJobWithSteps, "publish")),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobWithStepsPublishCreated),
+					},
+				},
+			},
+		},
+		{
+			Name:   "do not create pod for next step after dependency failed",
+			Target: fakeJobWithStepsPrepareFailed,
+			Fixtures: []runtime.Object{
+				fakeStepPodPrepareFailed,
+			},
+		},
+		{
+			Name:   "kill running step when job with steps is suspended",
+			Now:    testutils.Mktime(killTime),
+			Target: fakeJobWithStepsSuspended,
+			Fixtures: []runtime.Object{
+				fakeStepPodPrepareRunning,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdatePodAction(jobNamespace, fakeStepPodPrepareTerminating),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(fakeJobWithStepsSuspendedPodKilled),
+					},
+				},
+			},
+		},
+		{
+			Name:   "do not start next step while job with steps is suspended",
+			Now:    testutils.Mktime(finishTime),
+			Target: generateJobStatusFromPods(fakeJobWithStepsSuspendedPodKilled, fakeStepPodPrepareFinishedSuspended),
+			Fixtures: []runtime.Object{
+				fakeStepPodPrepareFinishedSuspended,
+			},
+		},
+		{
+			Name:   "delete killed step when job with steps is resumed",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobWithStepsResumed,
+			Fixtures: []runtime.Object{
+				fakeStepPodPrepareFinishedSuspended,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeletePodAction(jobNamespace, fakeStepPodPrepareFinishedSuspended.Name),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start killed step again after job with steps is resumed",
+			Now:    testutils.Mktime(finishTime),
+			Target: fakeJobWithStepsResumed,
+			Reactors: runtimetesting.CombinedReactors{
+				Kubernetes: []*ktesting.SimpleReactor{
+					{
+						Verb:     "create",
+						Resource: "pods",
+						Reaction: func(action ktesting.Action) (bool, runtime.Object, error) {
+							return true, fakeStepPodPrepareRetried.DeepCopy(), nil
+						},
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Kubernetes: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewCreatePodAction(jobNamespace, newStepPodToCreate(fakeJobWithStepsResumed, "prepare")),
+					},
+				},
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobStatusAction(generateJobStatusFromPods(fakeJobWithStepsResumed, fakeStepPodPrepareRetried)),
+					},
+				},
+			},
+		},
+	})
+}
+
+func newStepPodToCreate(rj *execution.Job, step string) *corev1.Pod {
+	pod, _ := podtaskexecutor.NewPodForStep(rj, step)
+	return pod
+}
+
+type fakeCommandExecutor struct{}
+
+func (e *fakeCommandExecutor) Exec(_ context.Context, _, _, _ string, _ []string) error {
+	return nil
+}
+
+func newApplyJobStatusAction(rj *execution.Job) runtimetesting.Action {
+	return runtimetesting.NewApplyJobStatusAction(rj.Namespace, rj.Name, rj.Status)
 }
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobgroupcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utiltrace "k8s.io/utils/trace"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobgroup"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/utils/cmp"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/logvalues"
+	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
+)
+
+type Reconciler struct {
+	*Context
+	concurrency *configv1alpha1.Concurrency
+}
+
+func NewReconciler(ctrlContext *Context, concurrency *configv1alpha1.Concurrency) *Reconciler {
+	return &Reconciler{
+		Context:     ctrlContext,
+		concurrency: concurrency,
+	}
+}
+
+func (w *Reconciler) Name() string {
+	return fmt.Sprintf("%v.Reconciler", controllerName)
+}
+
+func (w *Reconciler) Concurrency() int {
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "jobgroupcontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.JobGroup
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
+}
+
+func (w *Reconciler) MaxRequeues() int {
+	return -1
+}
+
+func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int) error {
+	trace := utiltrace.New(
+		"jobgroup_sync",
+		utiltrace.Field{Key: "namespace", Value: namespace},
+		utiltrace.Field{Key: "name", Value: name},
+	)
+	defer trace.LogIfLong(500 * time.Millisecond)
+
+	klog.V(2).InfoS("jobgroupcontroller: syncing job group",
+		"worker", w.Name(),
+		"namespace", namespace,
+		"name", name,
+	)
+
+	rjg, err := w.jobgroupInformer.Lister().JobGroups(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get job group")
+	}
+	trace.Step("Lookup job group from cache done")
+
+	// List all member Jobs from cache.
+	rjs, err := w.jobInformer.Lister().Jobs(namespace).List(labels.SelectorFromSet(jobgroup.LabelJobsForJobGroup(rjg)))
+	if err != nil {
+		return errors.Wrapf(err, "cannot list jobs")
+	}
+	sort.Slice(rjs, func(i, j int) bool {
+		return rjs[i].Name < rjs[j].Name
+	})
+	trace.Step("List jobs from cache done")
+
+	// Propagate the kill timestamp to member Jobs.
+	if err := w.killJobs(ctx, rjg, rjs); err != nil {
+		return errors.Wrapf(err, "cannot kill jobs")
+	}
+	trace.Step("Kill jobs done")
+
+	// Update JobGroup status.
+	status := jobgroup.GetStatus(rjg, rjs)
+	if isEqual, err := cmp.IsJSONEqual(rjg.Status, status); err == nil && !isEqual {
+		patch, err := controllerutil.NewStatusApplyPatch(execution.GVKJobGroup, namespace, name, status)
+		if err != nil {
+			return errors.Wrapf(err, "cannot create status patch")
+		}
+		updatedRjg, err := w.Clientsets().Furiko().ExecutionV1alpha1().JobGroups(namespace).
+			Patch(ctx, name, types.ApplyPatchType, patch, controllerutil.NewApplyPatchOptions(fieldManager), "status")
+		if err != nil {
+			return errors.Wrapf(err, "cannot update job group")
+		}
+
+		klog.V(3).InfoS("jobgroupcontroller: updated job group", logvalues.
+			Values("worker", w.Name(), "namespace", namespace, "name", name).
+			Level(4, "job_group", updatedRjg).
+			Build()...,
+		)
+
+		if !rjg.Status.Phase.IsTerminal() && status.Phase.IsTerminal() {
+			w.recorder.Eventf(updatedRjg, corev1.EventTypeNormal, string(status.Phase),
+				"All %v Jobs in the JobGroup have finished", status.Total)
+		}
+
+		rjg = updatedRjg
+		trace.Step("Update job group done")
+	}
+
+	// Delete the JobGroup and its member Jobs once the TTL has expired.
+	if err := w.handleTTL(ctx, rjg, rjs); err != nil {
+		return errors.Wrapf(err, "cannot handle ttl")
+	}
+
+	return nil
+}
+
+// killJobs propagates the JobGroup's kill timestamp to all unfinished member Jobs.
+func (w *Reconciler) killJobs(ctx context.Context, rjg *execution.JobGroup, rjs []*execution.Job) error {
+	var killed int
+	for _, rj := range rjs {
+		if !jobgroup.ShouldKillJob(rjg, rj) {
+			continue
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"killTimestamp": rjg.Spec.KillTimestamp,
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "cannot marshal patch")
+		}
+		if _, err := w.Clientsets().Furiko().ExecutionV1alpha1().Jobs(rj.Namespace).
+			Patch(ctx, rj.Name, types.MergePatchType, patch, metav1.PatchOptions{}); kerrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "cannot update job %v", rj.Name)
+		}
+
+		klog.V(3).InfoS("jobgroupcontroller: set kill timestamp for job",
+			"worker", w.Name(),
+			"namespace", rjg.GetNamespace(),
+			"name", rjg.GetName(),
+			"job", rj.GetName(),
+			"killTimestamp", rjg.Spec.KillTimestamp,
+		)
+		killed++
+	}
+
+	if killed > 0 {
+		w.recorder.Eventf(rjg, corev1.EventTypeNormal, "Killing",
+			"Set kill timestamp for %v Jobs in the JobGroup", killed)
+	}
+
+	return nil
+}
+
+// handleTTL deletes the JobGroup and all of its member Jobs if its TTL after it
+// has finished has expired, otherwise enqueues the JobGroup to be synced when
+// the TTL expires.
+func (w *Reconciler) handleTTL(ctx context.Context, rjg *execution.JobGroup, rjs []*execution.Job) error {
+	expiry, ok := jobgroup.GetTTLExpiry(rjg)
+	if !ok {
+		return nil
+	}
+	if now := ktime.Now(); now.Time.Before(expiry) {
+		w.enqueueAfter(rjg, "ttl", expiry.Sub(now.Time))
+		return nil
+	}
+
+	client := w.Clientsets().Furiko().ExecutionV1alpha1()
+	propagation := metav1.DeletePropagationBackground
+	options := metav1.DeleteOptions{PropagationPolicy: &propagation}
+	for _, rj := range rjs {
+		if err := client.Jobs(rj.Namespace).Delete(ctx, rj.Name, options); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "cannot delete job %v", rj.Name)
+		}
+	}
+	if err := client.JobGroups(rjg.Namespace).Delete(ctx, rjg.Name, options); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "cannot delete job group")
+	}
+
+	klog.V(3).InfoS("jobgroupcontroller: deleted job group after ttl expired",
+		"worker", w.Name(),
+		"namespace", rjg.GetNamespace(),
+		"name", rjg.GetName(),
+		"jobs", len(rjs),
+	)
+
+	return nil
+}
+
+// enqueueAfter will defer a sync after the specified duration, and logs the purpose of deferring
+// the sync for debugging purposes.
+// We enforce a lower bound of 1 second to the next sync, to slow down unwanted bursts of syncs.
+func (w *Reconciler) enqueueAfter(rjg *execution.JobGroup, purpose string, duration time.Duration) {
+	duration = timeutil.DurationMax(time.Second, duration)
+	if key, err := cache.MetaNamespaceKeyFunc(rjg); err == nil {
+		w.queue.AddAfter(key, duration)
+		klog.V(2).InfoS("jobgroupcontroller: worker enqueue sync",
+			"worker", w.Name(),
+			"namespace", rjg.GetNamespace(),
+			"name", rjg.GetName(),
+			"purpose", purpose,
+			"after", duration.String(),
+		)
+	}
+}
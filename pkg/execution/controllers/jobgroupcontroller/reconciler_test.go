@@ -0,0 +1,238 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobgroupcontroller_test
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/jobgroupcontroller"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobgroup"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	startTime     = "2022-04-01T04:00:00Z"
+	finishTime    = "2022-04-01T04:01:00Z"
+	now           = "2022-04-01T04:05:00Z"
+	killTime      = "2022-04-01T04:10:00Z"
+	testNamespace = "test"
+	jobGroupUID   = "0ed1bc76-07ca-4cf7-9a47-a0cc4aec48b9"
+)
+
+var (
+	jobGroup = &execution.JobGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-group",
+			Namespace: testNamespace,
+			UID:       jobGroupUID,
+		},
+	}
+
+	jobGroupKilled = func() *execution.JobGroup {
+		newJobGroup := jobGroup.DeepCopy()
+		newJobGroup.Spec.KillTimestamp = testutils.Mkmtimep(killTime)
+		return newJobGroup
+	}()
+
+	jobGroupWithTTL = func() *execution.JobGroup {
+		newJobGroup := jobGroup.DeepCopy()
+		newJobGroup.Spec.TTLSecondsAfterFinished = pointer.Int64(60)
+		return newJobGroup
+	}()
+
+	job1 = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-1",
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				jobgroup.LabelKeyJobGroup: jobGroup.Name,
+			},
+		},
+	}
+	job1Running  = makeJob(job1, execution.JobRunning)
+	job1Finished = makeJob(job1, execution.JobSucceeded)
+
+	job2 = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-2",
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				jobgroup.LabelKeyJobGroup: jobGroup.Name,
+			},
+		},
+	}
+	job2Queued   = makeJob(job2, execution.JobQueued)
+	job2Finished = makeJob(job2, execution.JobSucceeded)
+
+	otherJob = makeJob(&execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-job",
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				jobgroup.LabelKeyJobGroup: "other-job-group",
+			},
+		},
+	}, execution.JobRunning)
+)
+
+func TestReconciler(t *testing.T) {
+	test := runtimetesting.ReconcilerTest{
+		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
+			return jobgroupcontroller.NewContextWithRecorder(c, recorder, nil)
+		},
+		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
+			return jobgroupcontroller.NewReconciler(
+				c.(*jobgroupcontroller.Context),
+				runtimetesting.ReconcilerDefaultConcurrency,
+			)
+		},
+		Now: testutils.Mktime(now),
+	}
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name: "no such JobGroup",
+			SyncTarget: &runtimetesting.SyncTarget{
+				Namespace: testNamespace,
+				Name:      "nonexistent-job-group",
+			},
+		},
+		{
+			Name:   "update status for empty JobGroup",
+			Target: jobGroup,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobGroupStatusAction(makeJobGroup(jobGroup)),
+					},
+				},
+			},
+		},
+		{
+			Name:     "up-to-date status for JobGroup",
+			Target:   makeJobGroup(jobGroup, job1Running, job2Queued),
+			Fixtures: []runtime.Object{job1Running, job2Queued, otherJob},
+		},
+		{
+			Name:     "update status for running JobGroup",
+			Target:   jobGroup,
+			Fixtures: []runtime.Object{job1Running, job2Queued, otherJob},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobGroupStatusAction(makeJobGroup(jobGroup, job1Running, job2Queued)),
+					},
+				},
+			},
+		},
+		{
+			Name:     "update status for finished JobGroup",
+			Target:   makeJobGroup(jobGroup, job1Running, job2Queued),
+			Fixtures: []runtime.Object{job1Finished, job2Finished},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newApplyJobGroupStatusAction(makeJobGroup(jobGroup, job1Finished, job2Finished)),
+					},
+				},
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobGroupUID,
+					Type:    v1.EventTypeNormal,
+					Reason:  string(execution.JobGroupSucceeded),
+					Message: "All 2 Jobs in the JobGroup have finished",
+				},
+			},
+		},
+		{
+			Name:     "kill unfinished Jobs in JobGroup",
+			Target:   makeJobGroup(jobGroupKilled, job1Finished, job2Queued),
+			Fixtures: []runtime.Object{job1Finished, job2Queued},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobAction(testNamespace, job2.Name, types.MergePatchType,
+							[]byte(`{"spec":{"killTimestamp":"`+killTime+`"}}`)),
+					},
+				},
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobGroupUID,
+					Type:    v1.EventTypeNormal,
+					Reason:  "Killing",
+					Message: "Set kill timestamp for 1 Jobs in the JobGroup",
+				},
+			},
+		},
+		{
+			Name:     "do not delete JobGroup before TTL",
+			Target:   makeJobGroup(jobGroupWithTTL, job1Running),
+			Fixtures: []runtime.Object{job1Running},
+		},
+		{
+			Name:     "delete JobGroup after TTL",
+			Target:   makeJobGroup(jobGroupWithTTL, job1Finished, job2Finished),
+			Fixtures: []runtime.Object{job1Finished, job2Finished},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewDeleteJobAction(testNamespace, job1.Name),
+						runtimetesting.NewDeleteJobAction(testNamespace, job2.Name),
+						runtimetesting.NewDeleteJobGroupAction(testNamespace, jobGroup.Name),
+					},
+				},
+			},
+		},
+	})
+}
+
+func makeJob(job *execution.Job, phase execution.JobPhase) *execution.Job {
+	newJob := job.DeepCopy()
+	if phase != execution.JobQueued {
+		newJob.Status.StartTime = testutils.Mkmtimep(startTime)
+	}
+	if phase.IsTerminal() {
+		newJob.Status.Condition.Finished = &execution.JobConditionFinished{
+			FinishedAt: testutils.Mkmtime(finishTime),
+		}
+	}
+	newJob.Status.Phase = phase
+	return newJob
+}
+
+func makeJobGroup(jobGroup *execution.JobGroup, jobs ...*execution.Job) *execution.JobGroup {
+	newJobGroup := jobGroup.DeepCopy()
+	newJobGroup.Status = jobgroup.GetStatus(newJobGroup, jobs)
+	return newJobGroup
+}
+
+func newApplyJobGroupStatusAction(rjg *execution.JobGroup) runtimetesting.Action {
+	return runtimetesting.NewApplyJobGroupStatusAction(rjg.Namespace, rjg.Name, rjg.Status)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+)
+
+// getConcurrencyGroupKey returns the key used to identify the concurrency group
+// in the limiter. Concurrency groups are scoped to a single namespace.
+func getConcurrencyGroupKey(namespace, group string) string {
+	return fmt.Sprintf("%v/%v", namespace, group)
+}
+
+// listJobConfigsInConcurrencyGroup returns all JobConfigs in the namespace that
+// belong to the given concurrency group.
+func (c *Context) listJobConfigsInConcurrencyGroup(namespace, group string) ([]*execution.JobConfig, error) {
+	rjcs, err := c.jobconfigInformer.Lister().JobConfigs(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list jobconfigs")
+	}
+
+	members := make([]*execution.JobConfig, 0, len(rjcs))
+	for _, rjc := range rjcs {
+		if spec := rjc.Spec.Concurrency.Group; spec != nil && spec.Name == group {
+			members = append(members, rjc)
+		}
+	}
+	return members, nil
+}
+
+// listJobsInConcurrencyGroup returns all Jobs belonging to JobConfigs in the
+// given concurrency group.
+func (c *Context) listJobsInConcurrencyGroup(namespace, group string) ([]*execution.Job, error) {
+	rjcs, err := c.listJobConfigsInConcurrencyGroup(namespace, group)
+	if err != nil {
+		return nil, err
+	}
+
+	var rjs []*execution.Job
+	for _, rjc := range rjcs {
+		selector := labels.SelectorFromSet(jobconfig.LabelJobsForJobConfig(rjc))
+		jobs, err := c.jobInformer.Lister().Jobs(namespace).List(selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot list jobs")
+		}
+		rjs = append(rjs, jobs...)
+	}
+	return rjs, nil
+}
+
+// acquireConcurrencyGroupSlot returns true if the Job can be started without
+// exceeding the maximum concurrency of the JobConfig's concurrency group,
+// reserving a slot for the Job if so. Otherwise, the Job will be marked as being
+// held back by the concurrency group.
+func (c *Context) acquireConcurrencyGroupSlot(
+	ctx context.Context,
+	client JobControlInterface,
+	rjc *execution.JobConfig,
+	rj *execution.Job,
+) (bool, error) {
+	group := rjc.Spec.Concurrency.Group
+	if group == nil {
+		return true, nil
+	}
+	limit := int64(1)
+	if group.MaxConcurrency != nil {
+		limit = *group.MaxConcurrency
+	}
+
+	rjs, err := c.listJobsInConcurrencyGroup(rj.Namespace, group.Name)
+	if err != nil {
+		return false, err
+	}
+
+	key := getConcurrencyGroupKey(rj.Namespace, group.Name)
+	if c.groupLimiter.TryAcquire(key, rj, rjs, limit, nil) {
+		return true, nil
+	}
+
+	if err := client.MarkConcurrencyGroupLimited(ctx, rj, group.Name); err != nil {
+		return false, errors.Wrapf(err, "cannot mark job as held by concurrency group")
+	}
+	return false, nil
+}
+
+// releaseConcurrencyGroupSlot releases the slot previously reserved for the Job
+// in the JobConfig's concurrency group, if any.
+func (c *Context) releaseConcurrencyGroupSlot(rjc *execution.JobConfig, rj *execution.Job) {
+	if group := rjc.Spec.Concurrency.Group; group != nil {
+		c.groupLimiter.Release(getConcurrencyGroupKey(rj.Namespace, group.Name), rj)
+	}
+}
@@ -22,20 +22,28 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	executionv1alpha1 "github.com/furiko-io/furiko/pkg/generated/clientset/versioned/typed/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
 	"github.com/furiko-io/furiko/pkg/utils/ktime"
 	"github.com/furiko-io/furiko/pkg/utils/logvalues"
+	"github.com/furiko-io/furiko/pkg/utils/meta"
 )
 
 // JobControlInterface is the client interface for the Controller.
 type JobControlInterface interface {
 	StartJob(ctx context.Context, rj *execution.Job) error
 	RejectJob(ctx context.Context, rj *execution.Job, msg string) error
+	ExpireJob(ctx context.Context, rj *execution.Job, msg string) error
+	RefreshJobTemplate(ctx context.Context, rj *execution.Job, rjc *execution.JobConfig) (*execution.Job, error)
+	MarkNamespaceConcurrencyLimited(ctx context.Context, rj *execution.Job, limit int64) error
+	MarkConcurrencyGroupLimited(ctx context.Context, rj *execution.Job, group string) error
 }
 
 // JobControl is the default implementation of JobControlInterface.
@@ -59,11 +67,23 @@ func NewJobControl(
 	}
 }
 
-// StartJob sets the startTime of the Job to the current time.
+// StartJob sets the startTime of the Job to the current time, and records the
+// template revision that the Job was started with.
 func (c *JobControl) StartJob(ctx context.Context, rj *execution.Job) error {
-	newRj := rj.DeepCopy()
-	newRj.Status.StartTime = ktime.Now()
-	updatedRj, err := c.client.Jobs(rj.GetNamespace()).UpdateStatus(ctx, newRj, metav1.UpdateOptions{})
+	// Only apply the fields of the status that are managed by this controller,
+	// so that we do not conflict with the JobController.
+	status := map[string]interface{}{
+		"startTime": ktime.Now(),
+	}
+	if revision := rj.Annotations[jobconfig.AnnotationKeyTemplateRevision]; revision != "" {
+		status["templateRevision"] = revision
+	}
+	patch, err := controllerutil.NewStatusApplyPatch(execution.GVKJob, rj.GetNamespace(), rj.GetName(), status)
+	if err != nil {
+		return errors.Wrapf(err, "cannot create status patch")
+	}
+	updatedRj, err := c.client.Jobs(rj.GetNamespace()).Patch(ctx, rj.GetName(), types.ApplyPatchType, patch,
+		controllerutil.NewApplyPatchOptions(fieldManager), "status")
 	if err != nil {
 		return errors.Wrapf(err, "cannot update job status")
 	}
@@ -74,10 +94,46 @@ func (c *JobControl) StartJob(ctx context.Context, rj *execution.Job) error {
 		Build()...,
 	)
 
+	ObserveJobStarted(updatedRj)
 	c.recorder.Eventf(rj, corev1.EventTypeNormal, "Started", "Started job successfully")
 	return nil
 }
 
+// RefreshJobTemplate replaces the template of the Job with the latest template
+// of the JobConfig, if the template revision has changed since the Job was
+// created. Returns the updated Job.
+func (c *JobControl) RefreshJobTemplate(
+	ctx context.Context, rj *execution.Job, rjc *execution.JobConfig,
+) (*execution.Job, error) {
+	revision, err := jobconfig.GetTemplateRevision(rjc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot compute template revision")
+	}
+	if rj.Annotations[jobconfig.AnnotationKeyTemplateRevision] == revision {
+		return rj, nil
+	}
+
+	newRj := rj.DeepCopy()
+	newRj.Spec.Template = rjc.Spec.Template.Spec.DeepCopy()
+	meta.SetAnnotation(newRj, jobconfig.AnnotationKeyTemplateRevision, revision)
+
+	updatedRj, err := c.client.Jobs(rj.GetNamespace()).Update(ctx, newRj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot update job")
+	}
+
+	klog.V(3).InfoS("jobqueuecontroller: refreshed job template", logvalues.
+		Values("worker", c.name, "namespace", updatedRj.GetNamespace(), "name", updatedRj.GetName(),
+			"revision", revision).
+		Level(4, "job", updatedRj).
+		Build()...,
+	)
+
+	c.recorder.Eventf(rj, corev1.EventTypeNormal, "TemplateRefreshed",
+		"Refreshed job template from %v to revision %v", rjc.GetName(), revision)
+	return updatedRj, nil
+}
+
 func (c *JobControl) RejectJob(ctx context.Context, rj *execution.Job, msg string) error {
 	newRj := rj.DeepCopy()
 	job.MarkAdmissionError(newRj, msg)
@@ -96,3 +152,83 @@ func (c *JobControl) RejectJob(ctx context.Context, rj *execution.Job, msg strin
 	c.recorder.Eventf(rj, corev1.EventTypeWarning, "AdmissionRefused", msg)
 	return nil
 }
+
+// ExpireJob marks a queued Job as expired, so that it will never be started.
+func (c *JobControl) ExpireJob(ctx context.Context, rj *execution.Job, msg string) error {
+	newRj := rj.DeepCopy()
+	job.MarkStartExpired(newRj, msg)
+
+	updatedRj, err := c.client.Jobs(rj.GetNamespace()).Update(ctx, newRj, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "cannot update job")
+	}
+
+	klog.V(3).InfoS("jobqueuecontroller: expired job", logvalues.
+		Values("worker", c.name, "namespace", updatedRj.GetNamespace(), "name", updatedRj.GetName()).
+		Level(4, "job", updatedRj).
+		Build()...,
+	)
+
+	c.recorder.Eventf(rj, corev1.EventTypeWarning, "Expired", msg)
+	return nil
+}
+
+// MarkNamespaceConcurrencyLimited marks a queued Job as being held back by the
+// concurrency limit of its namespace, so that the reason is reflected in its
+// status. Does nothing if the Job was already marked with the same limit.
+func (c *JobControl) MarkNamespaceConcurrencyLimited(ctx context.Context, rj *execution.Job, limit int64) error {
+	if current, ok := job.GetNamespaceConcurrencyLimit(rj); ok && current == limit {
+		if _, ok := job.GetConcurrencyGroupLimit(rj); !ok {
+			return nil
+		}
+	}
+
+	newRj := rj.DeepCopy()
+	job.MarkNamespaceConcurrencyLimited(newRj, limit)
+
+	updatedRj, err := c.client.Jobs(rj.GetNamespace()).Update(ctx, newRj, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "cannot update job")
+	}
+
+	klog.V(3).InfoS("jobqueuecontroller: job held by namespace concurrency limit", logvalues.
+		Values("worker", c.name, "namespace", updatedRj.GetNamespace(), "name", updatedRj.GetName(),
+			"limit", limit).
+		Level(4, "job", updatedRj).
+		Build()...,
+	)
+
+	c.recorder.Eventf(rj, corev1.EventTypeNormal, "NamespaceConcurrencyLimit",
+		"Job is queued as namespace %v has reached its limit of %v concurrent Jobs", rj.GetNamespace(), limit)
+	return nil
+}
+
+// MarkConcurrencyGroupLimited marks a queued Job as being held back by its
+// concurrency group, so that the reason is reflected in its status. Does nothing
+// if the Job was already marked with the same group.
+func (c *JobControl) MarkConcurrencyGroupLimited(ctx context.Context, rj *execution.Job, group string) error {
+	if current, ok := job.GetConcurrencyGroupLimit(rj); ok && current == group {
+		if _, ok := job.GetNamespaceConcurrencyLimit(rj); !ok {
+			return nil
+		}
+	}
+
+	newRj := rj.DeepCopy()
+	job.MarkConcurrencyGroupLimited(newRj, group)
+
+	updatedRj, err := c.client.Jobs(rj.GetNamespace()).Update(ctx, newRj, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "cannot update job")
+	}
+
+	klog.V(3).InfoS("jobqueuecontroller: job held by concurrency group", logvalues.
+		Values("worker", c.name, "namespace", updatedRj.GetNamespace(), "name", updatedRj.GetName(),
+			"group", group).
+		Level(4, "job", updatedRj).
+		Build()...,
+	)
+
+	c.recorder.Eventf(rj, corev1.EventTypeNormal, "ConcurrencyGroupLimit",
+		"Job is queued as concurrency group %v has reached its maximum number of active Jobs", group)
+	return nil
+}
@@ -22,7 +22,6 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -59,21 +58,26 @@ type Context struct {
 	jobConfigQueue    workqueue.RateLimitingInterface
 	independentQueue  workqueue.RateLimitingInterface
 	recorder          record.EventRecorder
+	eventBroadcaster  record.EventBroadcaster
+	namespaceLimiter  *activeJobLimiter
+	groupLimiter      *activeJobLimiter
+	startRateLimiter  *startRateLimiter
 }
 
 // NewContext returns a new Context.
-func NewContext(context controllercontext.Context) *Context {
-	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
-		Interface: context.Clientsets().Kubernetes().CoreV1().Events(""),
-	})
+func NewContext(context controllercontext.Context, rateLimiter *configv1alpha1.RateLimiterSpec) *Context {
+	eventBroadcaster := context.NewEventBroadcaster()
 	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerName})
 
-	return NewContextWithRecorder(context, recorder)
+	c := NewContextWithRecorder(context, recorder, rateLimiter)
+	c.eventBroadcaster = eventBroadcaster
+	return c
 }
 
 // NewContextWithRecorder returns a new Context with a custom EventRecorder.
-func NewContextWithRecorder(context controllercontext.Context, recorder record.EventRecorder) *Context {
+func NewContextWithRecorder(
+	context controllercontext.Context, recorder record.EventRecorder, rateLimiter *configv1alpha1.RateLimiterSpec,
+) *Context {
 	c := &Context{Context: context}
 
 	// Set recorder
@@ -86,11 +90,14 @@ func NewContextWithRecorder(context controllercontext.Context, recorder record.E
 		c.jobInformer.Informer().HasSynced,
 		c.jobconfigInformer.Informer().HasSynced,
 	}
+	c.namespaceLimiter = newActiveJobLimiter()
+	c.groupLimiter = newActiveJobLimiter()
+	c.startRateLimiter = newStartRateLimiter()
 
 	// Create workqueues.
-	c.jobConfigQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(),
+	c.jobConfigQueue = workqueue.NewNamedRateLimitingQueue(controllerutil.NewRateLimiter(rateLimiter),
 		(&PerConfigReconciler{}).Name())
-	c.independentQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(),
+	c.independentQueue = workqueue.NewNamedRateLimitingQueue(controllerutil.NewRateLimiter(rateLimiter),
 		(&IndependentReconciler{}).Name())
 
 	return c
@@ -103,10 +110,11 @@ func (c *Context) GetHasSynced() []cache.InformerSynced {
 func NewController(
 	ctrlContext controllercontext.Context,
 	concurrency *configv1alpha1.Concurrency,
+	rateLimiter *configv1alpha1.RateLimiterSpec,
 ) (*Controller, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	ctrl := &Controller{
-		Context:   NewContext(ctrlContext),
+		Context:   NewContext(ctrlContext, rateLimiter),
 		ctx:       ctx,
 		terminate: cancel,
 	}
@@ -145,16 +153,37 @@ func (c *Controller) Run(ctx context.Context) error {
 
 func (c *Controller) Shutdown(ctx context.Context) {
 	klog.InfoS("jobqueuecontroller: shutting down")
+
+	// Stop accepting new items and wait for in-flight syncs to complete, before
+	// canceling any syncs that are still running once the deadline is exceeded.
+	for _, recon := range []*reconciler.Controller{c.perConfigReconciler, c.independentReconciler} {
+		if err := recon.Drain(ctx); err != nil {
+			klog.ErrorS(err, "jobqueuecontroller: cannot drain in-flight syncs before deadline")
+		}
+	}
 	c.terminate()
-	c.jobConfigQueue.ShutDown()
-	c.independentQueue.ShutDown()
 	c.perConfigReconciler.Wait()
+	c.independentReconciler.Wait()
+
+	// Flush any pending events.
+	if c.eventBroadcaster != nil {
+		c.eventBroadcaster.Shutdown()
+	}
 	klog.InfoS("jobqueuecontroller: stopped controller")
 }
 
 func (c *Controller) GetHealth() controllermanager.HealthStatus {
+	lastSuccessfulSync := c.perConfigReconciler.LastSuccessfulSync()
+	if ts := c.independentReconciler.LastSuccessfulSync(); ts != nil &&
+		(lastSuccessfulSync == nil || ts.After(*lastSuccessfulSync)) {
+		lastSuccessfulSync = ts
+	}
+
 	return controllermanager.HealthStatus{
-		Name:    controllerName,
-		Healthy: atomic.LoadUint64(&c.healthStatus) == 1,
+		Name:               controllerName,
+		Healthy:            atomic.LoadUint64(&c.healthStatus) == 1,
+		CachesSynced:       controllerutil.HasSynced(c.hasSynced...),
+		QueueDepth:         c.perConfigReconciler.QueueDepth() + c.independentReconciler.QueueDepth(),
+		LastSuccessfulSync: lastSuccessfulSync,
 	}
 }
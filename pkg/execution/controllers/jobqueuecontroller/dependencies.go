@@ -0,0 +1,194 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/tzutils"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	// dependencyRecheckInterval is the interval to re-evaluate dependencies of a
+	// Job that is waiting for its dependencies to be satisfied.
+	dependencyRecheckInterval = 15 * time.Second
+)
+
+// dependencyCheckResult contains the result of evaluating the dependencies of a Job.
+type dependencyCheckResult struct {
+	// Satisfied is true if all dependencies are satisfied and the Job can be started.
+	Satisfied bool
+
+	// RejectMessage is non-empty if the Job can never be started, and should be rejected.
+	RejectMessage string
+
+	// RecheckAfter is the duration after which the dependencies should be re-evaluated.
+	RecheckAfter time.Duration
+}
+
+// checkDependencies evaluates the dependencies of a Job specified in its StartPolicy.
+func (c *Context) checkDependencies(rj *execution.Job) (dependencyCheckResult, error) {
+	var result dependencyCheckResult
+
+	spec := rj.Spec.StartPolicy
+	if spec == nil || len(spec.DependsOn) == 0 {
+		result.Satisfied = true
+		return result, nil
+	}
+
+	now := ktime.Now().Time
+	pending := make([]string, 0, len(spec.DependsOn))
+	for _, dependency := range spec.DependsOn {
+		satisfied, rejectMsg, err := c.checkDependency(rj, dependency, now)
+		if err != nil {
+			return result, errors.Wrapf(err, "cannot check dependency")
+		}
+		if rejectMsg != "" {
+			result.RejectMessage = rejectMsg
+			return result, nil
+		}
+		if !satisfied {
+			pending = append(pending, formatDependency(dependency))
+		}
+	}
+
+	if len(pending) == 0 {
+		result.Satisfied = true
+		return result, nil
+	}
+
+	// Reject the Job if the timeout has been exceeded.
+	result.RecheckAfter = dependencyRecheckInterval
+	if timeoutSeconds := spec.DependsOnTimeoutSeconds; timeoutSeconds != nil {
+		deadline := rj.CreationTimestamp.Add(time.Duration(*timeoutSeconds) * time.Second)
+		if !now.Before(deadline) {
+			result.RejectMessage = fmt.Sprintf("Timed out after %v waiting for dependencies: %v",
+				time.Duration(*timeoutSeconds)*time.Second, strings.Join(pending, ", "))
+			return result, nil
+		}
+		if untilDeadline := deadline.Sub(now); untilDeadline < result.RecheckAfter {
+			result.RecheckAfter = untilDeadline
+		}
+	}
+
+	return result, nil
+}
+
+// checkDependency evaluates a single JobDependency. Returns a non-empty
+// rejectMsg if the dependency can never be satisfied.
+func (c *Context) checkDependency(
+	rj *execution.Job,
+	dependency execution.JobDependency,
+	now time.Time,
+) (satisfied bool, rejectMsg string, err error) {
+	switch {
+	case dependency.JobName != "":
+		depJob, err := c.jobInformer.Lister().Jobs(rj.Namespace).Get(dependency.JobName)
+		if kerrors.IsNotFound(err) {
+			return false, "", nil
+		}
+		if err != nil {
+			return false, "", errors.Wrapf(err, "cannot get job %v", dependency.JobName)
+		}
+		if depJob.Status.Phase == execution.JobSucceeded {
+			return true, "", nil
+		}
+		if depJob.Status.Phase.IsTerminal() {
+			return false, fmt.Sprintf("Dependency %v finished with phase %v", formatDependency(dependency),
+				depJob.Status.Phase), nil
+		}
+		return false, "", nil
+
+	case dependency.JobConfigName != "":
+		rjc, err := c.jobconfigInformer.Lister().JobConfigs(rj.Namespace).Get(dependency.JobConfigName)
+		if kerrors.IsNotFound(err) {
+			return false, "", nil
+		}
+		if err != nil {
+			return false, "", errors.Wrapf(err, "cannot get job config %v", dependency.JobConfigName)
+		}
+		timezone, err := tzutils.ParseTimezone(dependency.Timezone)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "cannot parse timezone %v", dependency.Timezone)
+		}
+		latest, err := c.getLatestFinishedJobForJobConfig(rjc, rj)
+		if err != nil {
+			return false, "", err
+		}
+		if latest == nil || latest.Status.Phase != execution.JobSucceeded {
+			return false, "", nil
+		}
+		finishedAt := latest.Status.Condition.Finished.FinishedAt
+		return !finishedAt.Time.Before(startOfDay(now, timezone)), "", nil
+	}
+
+	return false, fmt.Sprintf("Invalid dependency %v", formatDependency(dependency)), nil
+}
+
+// getLatestFinishedJobForJobConfig returns the Job for the JobConfig that
+// finished most recently, excluding the given Job. Jobs which have not yet
+// finished are ignored, so that newer Jobs which are still queued or running do
+// not hide the result of an earlier run.
+func (c *Context) getLatestFinishedJobForJobConfig(
+	rjc *execution.JobConfig, exclude *execution.Job,
+) (*execution.Job, error) {
+	selector := labels.SelectorFromSet(jobconfig.LabelJobsForJobConfig(rjc))
+	rjs, err := c.jobInformer.Lister().Jobs(rjc.Namespace).List(selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list jobs for job config %v", rjc.Name)
+	}
+
+	var latest *execution.Job
+	for _, rj := range rjs {
+		if rj.UID == exclude.UID && rj.Name == exclude.Name {
+			continue
+		}
+		finished := rj.Status.Condition.Finished
+		if finished == nil {
+			continue
+		}
+		if latest == nil || latest.Status.Condition.Finished.FinishedAt.Before(&finished.FinishedAt) {
+			latest = rj
+		}
+	}
+
+	return latest, nil
+}
+
+func formatDependency(dependency execution.JobDependency) string {
+	if dependency.JobName != "" {
+		return fmt.Sprintf("Job %v", dependency.JobName)
+	}
+	if dependency.JobConfigName != "" {
+		return fmt.Sprintf("JobConfig %v", dependency.JobConfigName)
+	}
+	return "<empty>"
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
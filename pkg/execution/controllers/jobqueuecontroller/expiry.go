@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// getStartDeadline returns the time after which a queued Job should no longer be
+// started, together with its expiry window. Returns false if the Job may remain
+// queued indefinitely. The JobConfig may be nil for independent Jobs.
+func getStartDeadline(rj *execution.Job, rjc *execution.JobConfig) (time.Time, time.Duration, bool) {
+	var expireAfter *int64
+	if spec := rj.Spec.StartPolicy; spec != nil {
+		expireAfter = spec.ExpireAfterSeconds
+	}
+	if expireAfter == nil && rjc != nil {
+		expireAfter = rjc.Spec.Concurrency.ExpireAfterSeconds
+	}
+	if expireAfter == nil || *expireAfter <= 0 {
+		return time.Time{}, 0, false
+	}
+
+	// The Job is due to start at its queue time, or at startAfter if it is later.
+	dueTime := getQueueTime(rj).Time
+	if spec := rj.Spec.StartPolicy; spec != nil && spec.StartAfter != nil && spec.StartAfter.After(dueTime) {
+		dueTime = spec.StartAfter.Time
+	}
+
+	window := time.Duration(*expireAfter) * time.Second
+	return dueTime.Add(window), window, true
+}
+
+// checkStartDeadline marks the Job as expired if it has exceeded its start
+// deadline, and returns true if so. Otherwise, returns the duration until the
+// Job will expire, or 0 if the Job has no start deadline.
+func (c *Context) checkStartDeadline(
+	ctx context.Context,
+	client JobControlInterface,
+	rj *execution.Job,
+	rjc *execution.JobConfig,
+) (bool, time.Duration, error) {
+	// Already marked as expired previously.
+	if _, ok := job.GetStartExpiredMessage(rj); ok {
+		return true, 0, nil
+	}
+
+	deadline, window, ok := getStartDeadline(rj, rjc)
+	if !ok {
+		return false, 0, nil
+	}
+
+	if untilDeadline := deadline.Sub(ktime.Now().Time); untilDeadline > 0 {
+		return false, untilDeadline, nil
+	}
+
+	msg := fmt.Sprintf("Job was not started within %v after it was due to start", window)
+	if err := client.ExpireJob(ctx, rj, msg); err != nil {
+		return false, 0, errors.Wrapf(err, "cannot expire job")
+	}
+	return true, 0, nil
+}
@@ -22,7 +22,12 @@ import (
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
 )
 
-const controllerName = "JobQueueController"
+const (
+	controllerName = "JobQueueController"
+
+	// fieldManager is the field manager used when applying status updates.
+	fieldManager = "furiko-jobqueuecontroller"
+)
 
 type Factory struct{}
 
@@ -37,6 +42,7 @@ func (f *Factory) Name() string {
 func (f *Factory) New(
 	ctrlContext controllercontext.Context,
 	concurrencySpec *configv1alpha1.ExecutionControllerConcurrencySpec,
+	rateLimiterSpec *configv1alpha1.ExecutionControllerRateLimitersSpec,
 ) (controllermanager.Controller, error) {
-	return NewController(ctrlContext, concurrencySpec.JobQueue)
+	return NewController(ctrlContext, concurrencySpec.JobQueue, rateLimiterSpec.JobQueue)
 }
@@ -23,6 +23,8 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/utils/eventhandler"
 )
@@ -42,7 +44,10 @@ func NewInformerWorker(ctrlContext *Context) *InformerWorker {
 	// We will sync their parent JobConfigs.
 	w.jobInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: w.handleJob,
-		UpdateFunc: func(_, newObj interface{}) {
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if w.isDeferredResync(oldObj, newObj) {
+				return
+			}
 			w.handleJob(newObj)
 		},
 		DeleteFunc: w.handleJob,
@@ -69,6 +74,25 @@ func (w *InformerWorker) enqueueObject(obj interface{}, queue workqueue.Interfac
 	queue.Add(key)
 }
 
+// isDeferredResync returns true if the update is a periodic resync of a Job that
+// is waiting for its startAfter time. Such Jobs already have a sync scheduled at
+// exactly their startAfter time, so the resync can be skipped.
+func (w *InformerWorker) isDeferredResync(oldObj, newObj interface{}) bool {
+	oldRj, err := eventhandler.Executionv1alpha1Job(oldObj)
+	if err != nil {
+		return false
+	}
+	newRj, err := eventhandler.Executionv1alpha1Job(newObj)
+	if err != nil {
+		return false
+	}
+	if oldRj.ResourceVersion != newRj.ResourceVersion {
+		return false
+	}
+	_, deferred := getStartAfterDelay(newRj)
+	return deferred && job.IsQueued(newRj)
+}
+
 func (w *InformerWorker) handleJob(obj interface{}) {
 	rj, err := eventhandler.Executionv1alpha1Job(obj)
 	if err != nil {
@@ -89,9 +113,36 @@ func (w *InformerWorker) handleJob(obj interface{}) {
 	// Enqueue JobConfig to be reconciled.
 	if rjc != nil {
 		w.enqueueObject(rjc, w.jobConfigQueue)
+		w.enqueueConcurrencyGroup(rjc, rj)
 		return
 	}
 
 	// Otherwise, enqueue the Job to be reconciled independently.
 	w.enqueueObject(rj, w.independentQueue)
 }
+
+// enqueueConcurrencyGroup enqueues all other JobConfigs in the same concurrency
+// group as the given JobConfig once the Job is finished, since their queued Jobs
+// may be waiting for it to be finished.
+func (w *InformerWorker) enqueueConcurrencyGroup(rjc *execution.JobConfig, rj *execution.Job) {
+	group := rjc.Spec.Concurrency.Group
+	if group == nil || job.IsQueued(rj) || job.IsActive(rj) {
+		return
+	}
+
+	rjcs, err := w.listJobConfigsInConcurrencyGroup(rjc.Namespace, group.Name)
+	if err != nil {
+		klog.ErrorS(err, "jobqueuecontroller: cannot list jobconfigs in concurrency group",
+			"worker", w.WorkerName(),
+			"namespace", rjc.GetNamespace(),
+			"group", group.Name,
+		)
+		return
+	}
+
+	for _, member := range rjcs {
+		if member.UID != rjc.UID {
+			w.enqueueObject(member, w.jobConfigQueue)
+		}
+	}
+}
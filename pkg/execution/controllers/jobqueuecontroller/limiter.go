@@ -0,0 +1,132 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+// activeJobLimiter guards against starting more than the maximum number of
+// concurrently active Jobs in a pool of Jobs, such as all Jobs in a namespace.
+// Each pool is identified by a unique key.
+//
+// Since Jobs in the same pool may be started concurrently by multiple reconciler
+// goroutines, and the informer cache may not yet reflect Jobs that were just
+// started, the limiter also keeps track of Jobs that it has admitted but which
+// are not yet observed to be started in the cache.
+type activeJobLimiter struct {
+	mu      sync.Mutex
+	pending map[string]map[types.UID]struct{}
+}
+
+func newActiveJobLimiter() *activeJobLimiter {
+	return &activeJobLimiter{
+		pending: make(map[string]map[types.UID]struct{}),
+	}
+}
+
+// TryAcquire returns true if the target Job can be started without exceeding
+// limit active Jobs in the pool, and reserves a slot for the Job if so. The slot
+// should be released with Release if the Job could not be started.
+//
+// The given list of Jobs should contain all Jobs in the pool. If isAhead is not
+// nil, queued Jobs for which it returns true will also occupy a slot, so that
+// they are not starved by the target Job.
+func (l *activeJobLimiter) TryAcquire(
+	key string,
+	target *execution.Job,
+	rjs []*execution.Job,
+	limit int64,
+	isAhead func(rj *execution.Job) bool,
+) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.count(key, target, rjs, isAhead) >= limit {
+		return false
+	}
+
+	pending, ok := l.pending[key]
+	if !ok {
+		pending = make(map[types.UID]struct{})
+		l.pending[key] = pending
+	}
+	pending[target.UID] = struct{}{}
+
+	return true
+}
+
+// Release releases the slot previously reserved for the Job in the pool.
+func (l *activeJobLimiter) Release(key string, rj *execution.Job) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.forget(key, rj.UID)
+}
+
+// count returns the number of slots in the pool that are not available to the
+// target Job. This includes active Jobs, Jobs that were admitted but not yet
+// observed to be started, as well as queued Jobs that are ahead of the target
+// Job. Must be called with the lock held.
+func (l *activeJobLimiter) count(
+	key string,
+	target *execution.Job,
+	rjs []*execution.Job,
+	isAhead func(rj *execution.Job) bool,
+) int64 {
+	var count int64
+	observed := make(map[types.UID]*execution.Job, len(rjs))
+	for _, rj := range rjs {
+		observed[rj.UID] = rj
+		if job.IsActive(rj) {
+			count++
+			continue
+		}
+		if _, pending := l.pending[key][rj.UID]; !pending && rj.UID != target.UID &&
+			isAhead != nil && job.IsQueued(rj) && isAhead(rj) {
+			count++
+		}
+	}
+
+	// Jobs which are already started in the cache have been counted above, and
+	// Jobs which no longer exist do not need to be counted.
+	for uid := range l.pending[key] {
+		rj, ok := observed[uid]
+		if !ok || job.IsStarted(rj) {
+			l.forget(key, uid)
+			continue
+		}
+		count++
+	}
+
+	return count
+}
+
+func (l *activeJobLimiter) forget(key string, uid types.UID) {
+	pending, ok := l.pending[key]
+	if !ok {
+		return
+	}
+	delete(pending, uid)
+	if len(pending) == 0 {
+		delete(l.pending, key)
+	}
+}
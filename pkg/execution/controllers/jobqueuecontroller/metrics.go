@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	promNamespace = "furiko"
+)
+
+var (
+	jobsStartedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "jobs_started_total",
+			Help:      "Total number of Jobs that were started after being admitted from the queue",
+		},
+		[]string{"namespace", "job_type"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		jobsStartedTotal,
+	)
+}
+
+// ObserveJobStarted records a metric for a Job that was started.
+func ObserveJobStarted(rj *execution.Job) {
+	jobsStartedTotal.WithLabelValues(rj.GetNamespace(), string(rj.Spec.Type)).Inc()
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+const (
+	// namespaceLimitRecheckInterval is the interval to re-evaluate a Job that is
+	// held back by the concurrency limit of its namespace.
+	namespaceLimitRecheckInterval = 15 * time.Second
+)
+
+// isHeldByNamespaceLimit returns true if the Job is queued and was previously
+// held back by the namespace concurrency limit.
+func isHeldByNamespaceLimit(rj *execution.Job) bool {
+	if !job.IsQueued(rj) || job.IsSuspended(rj) {
+		return false
+	}
+	_, ok := job.GetNamespaceConcurrencyLimit(rj)
+	return ok
+}
+
+// getNamespaceConcurrencyLimit returns the maximum number of concurrently
+// active Jobs in the given namespace. Returns 0 if there is no limit.
+func (c *Context) getNamespaceConcurrencyLimit(namespace string) (int64, error) {
+	cfg, err := c.Configs().JobsForNamespace(namespace)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot load controller configuration")
+	}
+	if limit, ok := cfg.NamespaceMaxConcurrentJobs[namespace]; ok {
+		return limit, nil
+	}
+	if cfg.MaxConcurrentJobsPerNamespace != nil {
+		return *cfg.MaxConcurrentJobsPerNamespace, nil
+	}
+	return 0, nil
+}
+
+// acquireNamespaceSlot returns true if the Job can be started without exceeding
+// the concurrency limit of its namespace, reserving a slot for the Job if so.
+// Otherwise, the Job will be marked as being held back by the limit.
+//
+// Slots are also reserved for other Jobs held back by the limit which are ahead
+// of the Job in the queue, so that they are not starved by lower priority Jobs.
+func (c *Context) acquireNamespaceSlot(
+	ctx context.Context,
+	client JobControlInterface,
+	rj *execution.Job,
+) (bool, error) {
+	limit, err := c.getNamespaceConcurrencyLimit(rj.Namespace)
+	if err != nil {
+		return false, err
+	}
+	if limit <= 0 {
+		return true, nil
+	}
+
+	rjs, err := c.jobInformer.Lister().Jobs(rj.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, errors.Wrapf(err, "cannot list jobs")
+	}
+
+	isAhead := func(other *execution.Job) bool {
+		return isHeldByNamespaceLimit(other) && isQueuedBefore(other, rj)
+	}
+	if c.namespaceLimiter.TryAcquire(rj.Namespace, rj, rjs, limit, isAhead) {
+		return true, nil
+	}
+
+	if err := client.MarkNamespaceConcurrencyLimited(ctx, rj, limit); err != nil {
+		return false, errors.Wrapf(err, "cannot mark job as held by namespace concurrency limit")
+	}
+	return false, nil
+}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// namespacePausedRecheckInterval is the interval to re-evaluate queued Jobs in
+	// a paused namespace, since changes to the dynamic configuration do not trigger
+	// any reconciliation.
+	namespacePausedRecheckInterval = 15 * time.Second
+)
+
+// isNamespacePaused returns true if no new Jobs should be started in the given
+// namespace.
+func (c *Context) isNamespacePaused(namespace string) (bool, error) {
+	cfg, err := c.Configs().Jobs()
+	if err != nil {
+		return false, errors.Wrapf(err, "cannot load controller configuration")
+	}
+	for _, paused := range cfg.PausedNamespaces {
+		if paused == namespace {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// startRateLimiter limits the rate at which Jobs are started, both globally and
+// per namespace. Since the rate limits are read from dynamic configuration, the
+// token buckets are recreated whenever their configuration changes.
+type startRateLimiter struct {
+	mu         sync.Mutex
+	global     *tokenBucket
+	namespaces map[string]*tokenBucket
+}
+
+func newStartRateLimiter() *startRateLimiter {
+	return &startRateLimiter{
+		namespaces: make(map[string]*tokenBucket),
+	}
+}
+
+// TryAccept consumes a token from both the global and namespace token buckets
+// if both of them have a token available. Otherwise, no tokens are consumed, and
+// the duration to wait until tokens will be available is returned.
+func (l *startRateLimiter) TryAccept(
+	namespace string,
+	globalSpec, namespaceSpec *configv1alpha1.RateLimitSpec,
+) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := ktime.Now().Time
+	buckets := make([]*tokenBucket, 0, 2)
+	if bucket := getOrCreateBucket(l.global, globalSpec, now); bucket != nil {
+		l.global = bucket
+		buckets = append(buckets, bucket)
+	} else {
+		l.global = nil
+	}
+	if bucket := getOrCreateBucket(l.namespaces[namespace], namespaceSpec, now); bucket != nil {
+		l.namespaces[namespace] = bucket
+		buckets = append(buckets, bucket)
+	} else {
+		delete(l.namespaces, namespace)
+	}
+
+	var wait time.Duration
+	for _, bucket := range buckets {
+		if d := bucket.Wait(now); d > wait {
+			wait = d
+		}
+	}
+	if wait > 0 {
+		return false, wait
+	}
+
+	for _, bucket := range buckets {
+		bucket.Take()
+	}
+	return true, 0
+}
+
+// getOrCreateBucket returns the existing bucket if it matches the spec, or
+// creates a new one otherwise. Returns nil if the rate limit is disabled.
+func getOrCreateBucket(bucket *tokenBucket, spec *configv1alpha1.RateLimitSpec, now time.Time) *tokenBucket {
+	if spec == nil || spec.QPS <= 0 {
+		return nil
+	}
+	if bucket != nil && bucket.spec == *spec {
+		return bucket
+	}
+	return newTokenBucket(*spec, now)
+}
+
+// tokenBucket is a simple token bucket that is refilled lazily.
+type tokenBucket struct {
+	spec   configv1alpha1.RateLimitSpec
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(spec configv1alpha1.RateLimitSpec, now time.Time) *tokenBucket {
+	burst := float64(spec.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(spec.QPS))
+	}
+	return &tokenBucket{
+		spec:   spec,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// Wait refills the bucket and returns the duration until a token is available.
+func (b *tokenBucket) Wait(now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.spec.QPS)
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.spec.QPS * float64(time.Second))
+}
+
+// Take consumes a single token from the bucket.
+func (b *tokenBucket) Take() {
+	b.tokens--
+}
+
+// checkStartRateLimit returns true if a Job in the given namespace can be
+// started without exceeding the configured start rate limits, consuming a token
+// if so. Otherwise, returns the duration to wait before trying again.
+func (c *Context) checkStartRateLimit(namespace string) (bool, time.Duration, error) {
+	cfg, err := c.Configs().Jobs()
+	if err != nil {
+		return false, 0, errors.Wrapf(err, "cannot load controller configuration")
+	}
+	ok, wait := c.startRateLimiter.TryAccept(namespace, cfg.GlobalStartRateLimit, cfg.NamespaceStartRateLimit)
+	return ok, wait, nil
+}
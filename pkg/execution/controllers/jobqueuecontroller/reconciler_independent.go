@@ -31,7 +31,6 @@ import (
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
-	"github.com/furiko-io/furiko/pkg/utils/ktime"
 	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
 )
 
@@ -60,7 +59,15 @@ func (r *IndependentReconciler) Name() string {
 }
 
 func (r *IndependentReconciler) Concurrency() int {
-	return controllerutil.GetConcurrencyOrDefaultCPUFactor(r.concurrency, 4)
+	cfg, err := r.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "jobqueuecontroller: cannot load controller configuration", "worker", r.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, r.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.JobQueue
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
 }
 
 func (r *IndependentReconciler) MaxRequeues() int {
@@ -96,14 +103,83 @@ func (r *IndependentReconciler) SyncOne(ctx context.Context, namespace, name str
 		return nil
 	}
 
-	if spec := rj.Spec.StartPolicy; spec != nil {
-		if ktime.IsTimeSetAndLater(spec.StartAfter) {
-			r.enqueueAfter(rj, "job_start_after", time.Until(spec.StartAfter.Time))
-			return nil
+	// Do not start jobs that have exceeded their start deadline.
+	expired, untilDeadline, err := r.checkStartDeadline(ctx, r.client, rj, nil)
+	if err != nil {
+		return errors.Wrapf(err, "cannot check start deadline")
+	}
+	if expired {
+		return nil
+	}
+	if untilDeadline > 0 {
+		r.enqueueAfter(rj, "job_start_deadline", untilDeadline)
+	}
+
+	// Do not start suspended jobs until they are resumed.
+	if job.IsSuspended(rj) {
+		return nil
+	}
+
+	// Wake up exactly when startAfter is reached, instead of waiting for the next
+	// periodic sync.
+	if delay, ok := getStartAfterDelay(rj); ok {
+		r.enqueueAfterPrecise(rj, "job_start_after", delay)
+		return nil
+	}
+
+	// Wait for dependencies to be satisfied.
+	result, err := r.checkDependencies(rj)
+	if err != nil {
+		return errors.Wrapf(err, "cannot check dependencies")
+	}
+	if result.RejectMessage != "" {
+		if err := r.client.RejectJob(ctx, rj, result.RejectMessage); err != nil {
+			return errors.Wrapf(err, "cannot reject job")
 		}
+		return nil
+	}
+	if !result.Satisfied {
+		r.enqueueAfter(rj, "job_dependencies", result.RecheckAfter)
+		return nil
+	}
+	trace.Step("Check dependencies done")
+
+	// Hold the Job in the queue while its namespace is paused.
+	paused, err := r.isNamespacePaused(rj.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "cannot check if namespace is paused")
+	}
+	if paused {
+		r.enqueueAfter(rj, "namespace_paused", namespacePausedRecheckInterval)
+		return nil
+	}
+
+	// Hold the Job in the queue if its namespace has too many active Jobs.
+	ok, err := r.acquireNamespaceSlot(ctx, r.client, rj)
+	if err != nil {
+		return errors.Wrapf(err, "cannot check namespace concurrency limit")
 	}
+	if !ok {
+		r.enqueueAfter(rj, "namespace_concurrency_limit", namespaceLimitRecheckInterval)
+		return nil
+	}
+	trace.Step("Check namespace concurrency limit done")
+
+	// Defer starting the Job if we exceed the start rate limit.
+	ok, wait, err := r.checkStartRateLimit(rj.Namespace)
+	if err != nil {
+		r.namespaceLimiter.Release(rj.Namespace, rj)
+		return errors.Wrapf(err, "cannot check start rate limit")
+	}
+	if !ok {
+		r.namespaceLimiter.Release(rj.Namespace, rj)
+		r.enqueueAfter(rj, "start_rate_limit", wait)
+		return nil
+	}
+	trace.Step("Check start rate limit done")
 
 	if err := r.client.StartJob(ctx, rj); err != nil {
+		r.namespaceLimiter.Release(rj.Namespace, rj)
 		return errors.Wrapf(err, "cannot start job")
 	}
 	trace.Step("Start job done")
@@ -115,7 +191,13 @@ func (r *IndependentReconciler) SyncOne(ctx context.Context, namespace, name str
 // the sync for debugging purposes.
 // We enforce a lower bound of 1 second to the next sync, to slow down unwanted bursts of syncs.
 func (r *IndependentReconciler) enqueueAfter(rj *execution.Job, purpose string, duration time.Duration) {
-	duration = timeutil.DurationMax(time.Second, duration)
+	r.enqueueAfterPrecise(rj, purpose, timeutil.DurationMax(time.Second, duration))
+}
+
+// enqueueAfterPrecise will defer a sync after exactly the specified duration,
+// without enforcing any lower bound. Should only be used when the sync is known
+// to be needed at a specific time.
+func (r *IndependentReconciler) enqueueAfterPrecise(rj *execution.Job, purpose string, duration time.Duration) {
 	if key, err := cache.MetaNamespaceKeyFunc(rj); err == nil {
 		r.independentQueue.AddAfter(key, duration)
 		klog.V(2).InfoS("jobqueuecontroller: worker enqueue sync",
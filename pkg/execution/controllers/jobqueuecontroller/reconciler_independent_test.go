@@ -19,8 +19,12 @@ package jobqueuecontroller_test
 import (
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/jobqueuecontroller"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
@@ -31,7 +35,7 @@ import (
 func TestIndependentReconciler(t *testing.T) {
 	test := runtimetesting.ReconcilerTest{
 		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
-			return jobqueuecontroller.NewContextWithRecorder(c, recorder)
+			return jobqueuecontroller.NewContextWithRecorder(c, recorder, nil)
 		},
 		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
 			return jobqueuecontroller.NewIndependentReconciler(
@@ -49,7 +53,7 @@ func TestIndependentReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace, startJob(jobToBeStarted, timeNow)),
+						newStartJobAction(jobToBeStarted, timeNow),
 					},
 				},
 			},
@@ -58,6 +62,10 @@ func TestIndependentReconciler(t *testing.T) {
 			Name:   "job already started",
 			Target: startJob(jobToBeStarted, timeNow),
 		},
+		{
+			Name:   "don't start suspended job",
+			Target: suspendJob(jobToBeStarted),
+		},
 		{
 			Name:   "don't start job with future startAfter",
 			Target: jobWithStartAfter,
@@ -69,8 +77,270 @@ func TestIndependentReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace,
-							startJob(jobWithStartAfter, testutils.Mkmtimep(startAfter))),
+						newStartJobAction(jobWithStartAfter, testutils.Mkmtimep(startAfter)),
+					},
+				},
+			},
+		},
+		{
+			Name:   "don't start job in paused namespace",
+			Target: jobToBeStarted,
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					PausedNamespaces: []string{jobNamespace},
+				},
+			},
+		},
+		{
+			Name:   "start job if other namespace is paused",
+			Target: jobToBeStarted,
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					PausedNamespaces: []string{"other-namespace"},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "hold job exceeding namespace concurrency limit",
+			Target: jobToBeStarted,
+			Fixtures: []runtime.Object{
+				runningJob,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxConcurrentJobsPerNamespace: pointer.Int64(1),
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace, markNamespaceConcurrencyLimited(jobToBeStarted, 1)),
+					},
+				},
+			},
+		},
+		{
+			Name:   "don't update job already held by namespace concurrency limit",
+			Target: markNamespaceConcurrencyLimited(jobToBeStarted, 1),
+			Fixtures: []runtime.Object{
+				runningJob,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxConcurrentJobsPerNamespace: pointer.Int64(1),
+				},
+			},
+		},
+		{
+			Name:   "start job within namespace concurrency limit",
+			Target: jobToBeStarted,
+			Fixtures: []runtime.Object{
+				runningJob,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxConcurrentJobsPerNamespace: pointer.Int64(2),
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job with namespace concurrency limit disabled for namespace",
+			Target: jobToBeStarted,
+			Fixtures: []runtime.Object{
+				runningJob,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxConcurrentJobsPerNamespace: pointer.Int64(1),
+					NamespaceMaxConcurrentJobs: map[string]int64{
+						jobNamespace: 0,
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "hold job behind higher priority job held by namespace concurrency limit",
+			Target: withUID(jobToBeStarted, uid4),
+			Fixtures: []runtime.Object{
+				runningJob,
+				markNamespaceConcurrencyLimited(jobWithHighPriority, 2),
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxConcurrentJobsPerNamespace: pointer.Int64(2),
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace,
+							markNamespaceConcurrencyLimited(withUID(jobToBeStarted, uid4), 2)),
+					},
+				},
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     uid4,
+					Type:    corev1.EventTypeNormal,
+					Reason:  "NamespaceConcurrencyLimit",
+					Message: "Job is queued as namespace test has reached its limit of 2 concurrent Jobs",
+				},
+			},
+		},
+		{
+			Name:   "start higher priority job held by namespace concurrency limit",
+			Target: markNamespaceConcurrencyLimited(jobWithHighPriority, 2),
+			Fixtures: []runtime.Object{
+				runningJob,
+				markNamespaceConcurrencyLimited(withUID(jobToBeStarted, uid4), 2),
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxConcurrentJobsPerNamespace: pointer.Int64(2),
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(markNamespaceConcurrencyLimited(jobWithHighPriority, 2), timeNow),
+					},
+				},
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     uid3,
+					Type:    corev1.EventTypeNormal,
+					Reason:  "Started",
+					Message: "Started job successfully",
+				},
+			},
+		},
+		{
+			Name:   "expire job not started within expiry window",
+			Target: jobWithExpireAfter,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace, expireJob(jobWithExpireAfter,
+							"Job was not started within 3s after it was due to start")),
+					},
+				},
+			},
+		},
+		{
+			Name:   "don't update job that is already expired",
+			Target: expireJob(jobWithExpireAfter, "Job was not started within 3s after it was due to start"),
+		},
+		{
+			Name:   "start job with startAfter within expiry window",
+			Now:    testutils.Mktime(startAfter),
+			Target: jobWithStartAfterAndExpireAfter,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobWithStartAfterAndExpireAfter, testutils.Mkmtimep(startAfter)),
+					},
+				},
+			},
+		},
+		{
+			Name:   "don't start job with pending dependency",
+			Target: jobWithDependency,
+			Fixtures: []runtime.Object{
+				dependencyJobRunning,
+			},
+		},
+		{
+			Name:   "don't start job with missing dependency",
+			Target: jobWithDependency,
+		},
+		{
+			Name:   "start job with satisfied dependency",
+			Target: jobWithDependency,
+			Fixtures: []runtime.Object{
+				dependencyJobSucceeded,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobWithDependency, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "reject job with failed dependency",
+			Target: jobWithDependency,
+			Fixtures: []runtime.Object{
+				dependencyJobFailed,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace, rejectJob(jobWithDependency,
+							"Dependency Job dependency-job finished with phase RetryLimitExceeded")),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job with satisfied jobconfig dependency and newer queued job",
+			Target: jobWithJobConfigDependency,
+			Fixtures: []runtime.Object{
+				jobConfig1,
+				jobForConfig1Succeeded,
+				jobForConfig1QueuedLater,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobWithJobConfigDependency, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "don't start job with jobconfig dependency that failed most recently",
+			Target: jobWithJobConfigDependency,
+			Fixtures: []runtime.Object{
+				jobConfig1,
+				jobForConfig1Succeeded,
+				jobForConfig1FailedLater,
+			},
+		},
+		{
+			Name:   "reject job after dependency timeout",
+			Now:    testutils.Mktime(depsTimeout),
+			Target: jobWithDependency,
+			Fixtures: []runtime.Object{
+				dependencyJobRunning,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobNamespace, rejectJob(jobWithDependency,
+							"Timed out after 1h0m0s waiting for dependencies: Job dependency-job")),
 					},
 				},
 			},
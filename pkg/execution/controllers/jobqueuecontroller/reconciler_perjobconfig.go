@@ -24,6 +24,7 @@ import (
 
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
@@ -35,7 +36,6 @@ import (
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
-	"github.com/furiko-io/furiko/pkg/utils/ktime"
 	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
 )
 
@@ -66,7 +66,15 @@ func (w *PerConfigReconciler) Name() string {
 }
 
 func (w *PerConfigReconciler) Concurrency() int {
-	return controllerutil.GetConcurrencyOrDefaultCPUFactor(w.concurrency, 4)
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "jobqueuecontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.JobQueue
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
 }
 
 func (w *PerConfigReconciler) MaxRequeues() int {
@@ -98,7 +106,7 @@ func (w *PerConfigReconciler) SyncOne(ctx context.Context, namespace, name strin
 	}
 	trace.Step("Lookup job config from cache done")
 
-	// List queued Jobs for JobConfig in order of creation time.
+	// List queued Jobs for JobConfig in order of priority and queue time.
 	rjs, err := w.listQueuedJobsForJobConfig(rjc)
 	if err != nil {
 		return errors.Wrapf(err, "cannot list jobs")
@@ -127,7 +135,54 @@ func (w *PerConfigReconciler) SyncOne(ctx context.Context, namespace, name strin
 		if !ok {
 			continue
 		}
+
+		// Stop starting Jobs while the namespace is paused.
+		paused, err := w.isNamespacePaused(rj.Namespace)
+		if err != nil {
+			return errors.Wrapf(err, "cannot check if namespace is paused")
+		}
+		if paused {
+			w.enqueueAfter(rjc, "namespace_paused", namespacePausedRecheckInterval)
+			break
+		}
+
+		// Hold the Job in the queue if its concurrency group has too many active
+		// Jobs. The JobConfig will be enqueued again once any Job in the group is
+		// finished.
+		ok, err = w.acquireConcurrencyGroupSlot(ctx, w.client, rjc, rj)
+		if err != nil {
+			return errors.Wrapf(err, "cannot check concurrency group")
+		}
+		if !ok {
+			continue
+		}
+
+		// Hold the Job in the queue if its namespace has too many active Jobs.
+		ok, err = w.acquireNamespaceSlot(ctx, w.client, rj)
+		if err != nil {
+			w.releaseSlots(rjc, rj)
+			return errors.Wrapf(err, "cannot check namespace concurrency limit")
+		}
+		if !ok {
+			w.releaseSlots(rjc, rj)
+			w.enqueueAfter(rjc, "namespace_concurrency_limit", namespaceLimitRecheckInterval)
+			continue
+		}
+
+		// Stop starting Jobs once we exceed the start rate limit.
+		ok, wait, err := w.checkStartRateLimit(rj.Namespace)
+		if err != nil {
+			w.releaseSlots(rjc, rj)
+			return errors.Wrapf(err, "cannot check start rate limit")
+		}
+		if !ok {
+			w.releaseSlots(rjc, rj)
+			w.enqueueAfter(rjc, "start_rate_limit", wait)
+			break
+		}
+
 		if err := w.startJob(ctx, rjc, rj, store, activeCount); err != nil {
+			w.releaseSlots(rjc, rj)
 			return errors.Wrapf(err, "cannot start job")
 		}
 
@@ -154,12 +209,35 @@ func (w *PerConfigReconciler) listQueuedJobsForJobConfig(
 		}
 	}
 
-	// Sort by creation timestamp.
-	sort.Slice(rjobs, func(i, j int) bool {
-		return rjobs[i].CreationTimestamp.Before(&rjobs[j].CreationTimestamp)
+	sortQueuedJobs(rjobs)
+	return rjobs, nil
+}
+
+// sortQueuedJobs sorts queued Jobs in the order that they should be started.
+// Jobs with a higher priority are started first, followed by Jobs with a
+// smaller queue key, i.e. Jobs that were scheduled (or created, if not
+// scheduled) earlier.
+func sortQueuedJobs(rjobs []*execution.Job) {
+	sort.SliceStable(rjobs, func(i, j int) bool {
+		return isQueuedBefore(rjobs[i], rjobs[j])
 	})
+}
 
-	return rjobs, nil
+// isQueuedBefore returns true if the Job a should be started before b.
+func isQueuedBefore(a, b *execution.Job) bool {
+	if pa, pb := job.GetPriority(a), job.GetPriority(b); pa != pb {
+		return pa > pb
+	}
+	return job.GetQueueKey(a) < job.GetQueueKey(b)
+}
+
+// getQueueTime returns the schedule time of the Job if it was scheduled,
+// otherwise its creation time.
+func getQueueTime(rj *execution.Job) *metav1.Time {
+	if t := jobconfig.GetLabelScheduleTime(rj); t != nil {
+		return t
+	}
+	return &rj.CreationTimestamp
 }
 
 func (w *PerConfigReconciler) canStartJob(
@@ -168,10 +246,55 @@ func (w *PerConfigReconciler) canStartJob(
 	rj *execution.Job,
 	activeCount int64,
 ) (bool, error) {
+	// Do not start jobs that have exceeded their start deadline.
+	expired, untilDeadline, err := w.checkStartDeadline(ctx, w.client, rj, rjc)
+	if err != nil {
+		return false, errors.Wrapf(err, "cannot check start deadline")
+	}
+	if expired {
+		klog.InfoS("jobqueuecontroller: job expired before it could be started",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+		)
+		return false, nil
+	}
+	if untilDeadline > 0 {
+		w.enqueueAfter(rjc, "job_start_deadline", untilDeadline)
+	}
+
+	// Do not start suspended jobs until they are resumed.
+	if job.IsSuspended(rj) {
+		return false, nil
+	}
+
+	// Cannot start yet, wake up exactly when startAfter is reached instead of
+	// waiting for the next periodic sync.
+	if delay, ok := getStartAfterDelay(rj); ok {
+		w.enqueueAfterPrecise(rjc, "job_start_after", delay)
+		return false, nil
+	}
+
 	if spec := rj.Spec.StartPolicy; spec != nil {
-		// Cannot start yet.
-		if ktime.IsTimeSetAndLater(spec.StartAfter) {
-			w.enqueueAfter(rjc, "job_start_after", time.Until(spec.StartAfter.Time))
+		// Wait for dependencies to be satisfied.
+		result, err := w.checkDependencies(rj)
+		if err != nil {
+			return false, errors.Wrapf(err, "cannot check dependencies")
+		}
+		if result.RejectMessage != "" {
+			if err := w.client.RejectJob(ctx, rj, result.RejectMessage); err != nil {
+				return false, errors.Wrapf(err, "failed to reject job")
+			}
+			klog.InfoS("jobqueuecontroller: job rejected due to dependencies",
+				"worker", w.Name(),
+				"namespace", rj.GetNamespace(),
+				"name", rj.GetName(),
+				"message", result.RejectMessage,
+			)
+			return false, nil
+		}
+		if !result.Satisfied {
+			w.enqueueAfter(rjc, "job_dependencies", result.RecheckAfter)
 			return false, nil
 		}
 
@@ -219,14 +342,35 @@ func (w *PerConfigReconciler) startJob(
 		}
 	}()
 
+	// Refresh the template from the JobConfig before starting the Job.
+	if rjc.Spec.TemplatePolicy == execution.TemplatePolicyReference {
+		rj, err = w.client.RefreshJobTemplate(ctx, rj, rjc)
+		if err != nil {
+			return errors.Wrapf(err, "cannot refresh job template")
+		}
+	}
+
 	return w.client.StartJob(ctx, rj)
 }
 
+// releaseSlots releases all concurrency slots that were reserved for a Job which
+// could not be started.
+func (w *PerConfigReconciler) releaseSlots(rjc *execution.JobConfig, rj *execution.Job) {
+	w.releaseConcurrencyGroupSlot(rjc, rj)
+	w.namespaceLimiter.Release(rj.Namespace, rj)
+}
+
 // enqueueAfter will defer a sync after the specified duration, and logs the purpose of deferring
 // the sync for debugging purposes.
 // We enforce a lower bound of 1 second to the next sync, to slow down unwanted bursts of syncs.
 func (w *PerConfigReconciler) enqueueAfter(rjc *execution.JobConfig, purpose string, duration time.Duration) {
-	duration = timeutil.DurationMax(time.Second, duration)
+	w.enqueueAfterPrecise(rjc, purpose, timeutil.DurationMax(time.Second, duration))
+}
+
+// enqueueAfterPrecise will defer a sync after exactly the specified duration,
+// without enforcing any lower bound. Should only be used when the sync is known
+// to be needed at a specific time.
+func (w *PerConfigReconciler) enqueueAfterPrecise(rjc *execution.JobConfig, purpose string, duration time.Duration) {
 	if key, err := cache.MetaNamespaceKeyFunc(rjc); err == nil {
 		w.jobConfigQueue.AddAfter(key, duration)
 		klog.V(2).InfoS("jobqueuecontroller: worker enqueue sync",
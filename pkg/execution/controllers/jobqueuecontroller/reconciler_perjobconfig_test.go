@@ -21,7 +21,9 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/controllers/jobqueuecontroller"
 	"github.com/furiko-io/furiko/pkg/execution/stores/activejobstore"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
@@ -34,7 +36,7 @@ import (
 func TestPerJobConfigReconciler(t *testing.T) {
 	test := runtimetesting.ReconcilerTest{
 		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
-			return jobqueuecontroller.NewContextWithRecorder(c, recorder)
+			return jobqueuecontroller.NewContextWithRecorder(c, recorder, nil)
 		},
 		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
 			return jobqueuecontroller.NewPerConfigReconciler(
@@ -62,8 +64,254 @@ func TestPerJobConfigReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobConfig1.Namespace,
-							startJob(jobForConfig1ToBeStarted, timeNow)),
+						newStartJobAction(jobForConfig1ToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "don't start job in paused namespace",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1ToBeStarted,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					PausedNamespaces: []string{jobConfig1.Namespace},
+				},
+			},
+		},
+		{
+			Name:   "hold job exceeding namespace concurrency limit",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1ToBeStarted,
+				runningJob,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					MaxConcurrentJobsPerNamespace: pointer.Int64(1),
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobConfig1.Namespace,
+							markNamespaceConcurrencyLimited(jobForConfig1ToBeStarted, 1)),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job with higher priority first",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1Enqueued,
+				jobForConfig1WithHighPriority,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1WithHighPriority, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job with same queue time in order of name",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1EnqueuedSameTime,
+				jobForConfig1Enqueued,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1Enqueued, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job in order of persisted queue key",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1Enqueued,
+				jobForConfig1WithPersistedQueueKey,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1WithPersistedQueueKey, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start multiple jobs without rate limit",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1ToBeStarted,
+				jobForConfig1CreatedLater,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1ToBeStarted, timeNow),
+						newStartJobAction(jobForConfig1CreatedLater, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "defer starting jobs exceeding global start rate limit",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1ToBeStarted,
+				jobForConfig1CreatedLater,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					GlobalStartRateLimit: &configv1alpha1.RateLimitSpec{
+						QPS: 0.5,
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1ToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "defer starting jobs exceeding namespace start rate limit",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1ToBeStarted,
+				jobForConfig1CreatedLater,
+			},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					GlobalStartRateLimit: &configv1alpha1.RateLimitSpec{
+						QPS: 10,
+					},
+					NamespaceStartRateLimit: &configv1alpha1.RateLimitSpec{
+						QPS: 1,
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1ToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "expire job using job config default expiry window",
+			Target: jobConfig1WithExpireAfter,
+			Fixtures: []runtime.Object{
+				jobForConfig1ToBeStarted,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobConfig1.Namespace, expireJob(jobForConfig1ToBeStarted,
+							"Job was not started within 3s after it was due to start")),
+					},
+				},
+			},
+		},
+		{
+			Name:   "hold job exceeding concurrency group limit",
+			Target: jobConfig1InGroup,
+			Fixtures: []runtime.Object{
+				jobConfig2InGroup,
+				jobForConfig2Running,
+				jobForConfig1ToBeStarted,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobConfig1.Namespace,
+							markConcurrencyGroupLimited(jobForConfig1ToBeStarted, concurrencyGroup.Name)),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job within concurrency group limit",
+			Target: jobConfig1InGroupWithMaxConcurrency,
+			Fixtures: []runtime.Object{
+				jobConfig2InGroup,
+				jobForConfig2Running,
+				jobForConfig1ToBeStarted,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1ToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job in concurrency group ignoring jobs in other groups",
+			Target: jobConfig1InGroup,
+			Fixtures: []runtime.Object{
+				jobForConfig2Running,
+				jobForConfig1ToBeStarted,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1ToBeStarted, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job without refreshing template",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				jobForConfig1WithOutdatedTemplate,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1WithOutdatedTemplate, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "refresh job template before starting job",
+			Target: jobConfig1WithTemplatePolicyReference,
+			Fixtures: []runtime.Object{
+				jobForConfig1WithOutdatedTemplate,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewUpdateJobAction(jobConfig1.Namespace, jobForConfig1WithRefreshedTemplate),
+						newStartJobAction(jobForConfig1WithRefreshedTemplate, timeNow),
+					},
+				},
+			},
+		},
+		{
+			Name:   "start job with up-to-date template",
+			Target: jobConfig1WithTemplatePolicyReference,
+			Fixtures: []runtime.Object{
+				jobForConfig1WithRefreshedTemplate,
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						newStartJobAction(jobForConfig1WithRefreshedTemplate, timeNow),
 					},
 				},
 			},
@@ -75,6 +323,13 @@ func TestPerJobConfigReconciler(t *testing.T) {
 				startJob(jobForConfig1ToBeStarted, timeNow),
 			},
 		},
+		{
+			Name:   "job config with suspended job",
+			Target: jobConfig1,
+			Fixtures: []runtime.Object{
+				suspendJob(jobForConfig1ToBeStarted),
+			},
+		},
 		{
 			Name:   "job config with unstarted job for another job config",
 			Target: jobConfig1,
@@ -106,8 +361,7 @@ func TestPerJobConfigReconciler(t *testing.T) {
 			WantActions: runtimetesting.CombinedActions{
 				Furiko: runtimetesting.ActionTest{
 					Actions: []runtimetesting.Action{
-						runtimetesting.NewUpdateJobStatusAction(jobNamespace,
-							startJob(jobForConfig1WithStartAfter, testutils.Mkmtimep(startAfter))),
+						newStartJobAction(jobForConfig1WithStartAfter, testutils.Mkmtimep(startAfter)),
 					},
 				},
 			},
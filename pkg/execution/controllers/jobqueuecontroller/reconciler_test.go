@@ -18,10 +18,14 @@ package jobqueuecontroller_test
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 
 	executiongroup "github.com/furiko-io/furiko/apis/execution"
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
 	"github.com/furiko-io/furiko/pkg/utils/testutils"
 )
 
@@ -30,8 +34,12 @@ const (
 	createTime   = "2021-02-09T04:06:00Z"
 	now          = "2021-02-09T04:06:05Z"
 	startAfter   = "2021-02-09T05:00:00Z"
+	depsTimeout  = "2021-02-09T05:06:00Z"
+	todayEarlier = "2021-02-09T01:00:00Z"
 	uid1         = "0ed1bc76-07ca-4cf7-9a47-a0cc4aec48b9"
 	uid2         = "6e08ee33-ccbe-4fc5-9c46-e29c19cc2fcb"
+	uid3         = "b1f2c4a8-3d5e-4f60-8a71-92c3d4e5f607"
+	uid4         = "c7d8e9f0-1a2b-4c3d-9e4f-5a6b7c8d9e0f"
 )
 
 var (
@@ -66,6 +74,94 @@ var (
 		},
 	}
 
+	jobWithDependency = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job-with-dependency",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			Finalizers: []string{
+				executiongroup.DeleteDependentsFinalizer,
+			},
+		},
+		Spec: execution.JobSpec{
+			StartPolicy: &execution.StartPolicySpec{
+				DependsOn: []execution.JobDependency{
+					{JobName: "dependency-job"},
+				},
+				DependsOnTimeoutSeconds: pointer.Int64(3600),
+			},
+		},
+	}
+
+	dependencyJobRunning = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "dependency-job",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+		},
+		Status: execution.JobStatus{
+			Phase:     execution.JobRunning,
+			StartTime: testutils.Mkmtimep(createTime),
+		},
+	}
+
+	runningJob = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "running-job",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+		},
+		Status: execution.JobStatus{
+			Phase:     execution.JobRunning,
+			StartTime: testutils.Mkmtimep(createTime),
+		},
+	}
+
+	jobWithExpireAfter = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job-with-expire-after",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			Finalizers: []string{
+				executiongroup.DeleteDependentsFinalizer,
+			},
+		},
+		Spec: execution.JobSpec{
+			StartPolicy: &execution.StartPolicySpec{
+				ConcurrencyPolicy:  execution.ConcurrencyPolicyAllow,
+				ExpireAfterSeconds: pointer.Int64(3),
+			},
+		},
+	}
+
+	jobWithStartAfterAndExpireAfter = func() *execution.Job {
+		newJob := jobWithExpireAfter.DeepCopy()
+		newJob.Spec.StartPolicy.StartAfter = testutils.Mkmtimep(startAfter)
+		return newJob
+	}()
+
+	jobWithHighPriority = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job-with-high-priority",
+			Namespace:         jobNamespace,
+			UID:               uid3,
+			CreationTimestamp: testutils.Mkmtime(now),
+			Finalizers: []string{
+				executiongroup.DeleteDependentsFinalizer,
+			},
+		},
+		Spec: execution.JobSpec{
+			StartPolicy: &execution.StartPolicySpec{
+				ConcurrencyPolicy: execution.ConcurrencyPolicyEnqueue,
+				Priority:          10,
+			},
+		},
+	}
+
+	dependencyJobSucceeded = withJobPhase(dependencyJobRunning, execution.JobSucceeded)
+
+	dependencyJobFailed = withJobPhase(dependencyJobRunning, execution.JobRetryLimitExceeded)
+
 	jobConfig1 = &execution.JobConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			UID:       uid1,
@@ -88,6 +184,173 @@ var (
 		},
 	}
 
+	jobWithJobConfigDependency = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job-with-jobconfig-dependency",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			Finalizers: []string{
+				executiongroup.DeleteDependentsFinalizer,
+			},
+		},
+		Spec: execution.JobSpec{
+			StartPolicy: &execution.StartPolicySpec{
+				DependsOn: []execution.JobDependency{
+					{JobConfigName: "job-config-1"},
+				},
+			},
+		},
+	}
+
+	jobForConfig1Succeeded = withJobFinished(&execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job-for-config-1-succeeded",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(todayEarlier),
+			Labels: map[string]string{
+				jobconfig.LabelKeyJobConfigUID: uid1,
+			},
+		},
+	}, execution.JobSucceeded, execution.JobResultSuccess, todayEarlier)
+
+	jobForConfig1FailedLater = func() *execution.Job {
+		newJob := withJobFinished(jobForConfig1Succeeded, execution.JobRetryLimitExceeded,
+			execution.JobResultTaskFailed, createTime)
+		newJob.Name = "job-for-config-1-failed-later"
+		return newJob
+	}()
+
+	jobForConfig1QueuedLater = func() *execution.Job {
+		newJob := jobForConfig1ToBeStarted.DeepCopy()
+		newJob.Name = "job-for-config-1-queued-later"
+		newJob.CreationTimestamp = testutils.Mkmtime(createTime)
+		return newJob
+	}()
+
+	jobConfig1WithTemplatePolicyReference = func() *execution.JobConfig {
+		newRjc := jobConfig1.DeepCopy()
+		newRjc.Spec.TemplatePolicy = execution.TemplatePolicyReference
+		newRjc.Spec.Template.Spec.MaxAttempts = pointer.Int32(3)
+		return newRjc
+	}()
+
+	jobForConfig1WithOutdatedTemplate = func() *execution.Job {
+		newJob := jobForConfig1ToBeStarted.DeepCopy()
+		newJob.Annotations = map[string]string{
+			jobconfig.AnnotationKeyTemplateRevision: "outdated",
+		}
+		newJob.Spec.Template = &execution.JobTemplateSpec{
+			MaxAttempts: pointer.Int32(1),
+		}
+		return newJob
+	}()
+
+	jobForConfig1WithRefreshedTemplate = func() *execution.Job {
+		newJob := jobForConfig1WithOutdatedTemplate.DeepCopy()
+		revision, _ := jobconfig.GetTemplateRevision(jobConfig1WithTemplatePolicyReference)
+		newJob.Annotations[jobconfig.AnnotationKeyTemplateRevision] = revision
+		newJob.Spec.Template = jobConfig1WithTemplatePolicyReference.Spec.Template.Spec.DeepCopy()
+		return newJob
+	}()
+
+	jobForConfig1CreatedLater = func() *execution.Job {
+		newJob := jobForConfig1ToBeStarted.DeepCopy()
+		newJob.Name = "job-for-config-1-created-later"
+		newJob.CreationTimestamp = testutils.Mkmtime(now)
+		return newJob
+	}()
+
+	jobForConfig1Enqueued = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job-for-config-1-enqueued",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			Finalizers: []string{
+				executiongroup.DeleteDependentsFinalizer,
+			},
+			Labels: map[string]string{
+				jobconfig.LabelKeyJobConfigUID: uid1,
+			},
+		},
+		Spec: execution.JobSpec{
+			StartPolicy: &execution.StartPolicySpec{
+				ConcurrencyPolicy: execution.ConcurrencyPolicyEnqueue,
+			},
+		},
+	}
+
+	jobForConfig1WithHighPriority = func() *execution.Job {
+		newJob := jobForConfig1Enqueued.DeepCopy()
+		newJob.Name = "job-for-config-1-with-high-priority"
+		newJob.CreationTimestamp = testutils.Mkmtime(now)
+		newJob.Spec.StartPolicy.Priority = 10
+		return newJob
+	}()
+
+	jobForConfig1EnqueuedSameTime = func() *execution.Job {
+		newJob := jobForConfig1Enqueued.DeepCopy()
+		newJob.Name = "job-for-config-1-enqueued-same-time"
+		return newJob
+	}()
+
+	jobForConfig1WithPersistedQueueKey = func() *execution.Job {
+		newJob := jobForConfig1Enqueued.DeepCopy()
+		newJob.Name = "job-for-config-1-with-persisted-queue-key"
+		newJob.CreationTimestamp = testutils.Mkmtime(now)
+		newJob.Status.QueueKey = "0"
+		return newJob
+	}()
+
+	jobConfig1WithExpireAfter = func() *execution.JobConfig {
+		newRjc := jobConfig1.DeepCopy()
+		newRjc.Spec.Concurrency.ExpireAfterSeconds = pointer.Int64(3)
+		return newRjc
+	}()
+
+	concurrencyGroup = &execution.ConcurrencyGroupSpec{
+		Name: "database",
+	}
+
+	jobConfig1InGroup = func() *execution.JobConfig {
+		newRjc := jobConfig1.DeepCopy()
+		newRjc.Spec.Concurrency.Group = concurrencyGroup.DeepCopy()
+		return newRjc
+	}()
+
+	jobConfig1InGroupWithMaxConcurrency = func() *execution.JobConfig {
+		newRjc := jobConfig1InGroup.DeepCopy()
+		newRjc.Spec.Concurrency.Group.MaxConcurrency = pointer.Int64(2)
+		return newRjc
+	}()
+
+	jobConfig2InGroup = &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uid2,
+			Namespace: jobNamespace,
+			Name:      "job-config-2",
+		},
+		Spec: execution.JobConfigSpec{
+			Concurrency: execution.ConcurrencySpec{
+				Group: concurrencyGroup.DeepCopy(),
+			},
+		},
+	}
+
+	jobForConfig2Running = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job-for-config-2-running",
+			Namespace:         jobNamespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			Labels: map[string]string{
+				jobconfig.LabelKeyJobConfigUID: uid2,
+			},
+		},
+		Status: execution.JobStatus{
+			Phase:     execution.JobRunning,
+			StartTime: testutils.Mkmtimep(createTime),
+		},
+	}
+
 	jobForConfig2ToBeStarted = &execution.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              "job-for-config-2-to-be-started",
@@ -120,8 +383,74 @@ var (
 	}
 )
 
+func suspendJob(rj *execution.Job) *execution.Job {
+	newJob := rj.DeepCopy()
+	newJob.Spec.Suspend = true
+	return newJob
+}
+
+func withJobPhase(rj *execution.Job, phase execution.JobPhase) *execution.Job {
+	newJob := rj.DeepCopy()
+	newJob.Status.Phase = phase
+	return newJob
+}
+
+func withJobFinished(
+	rj *execution.Job, phase execution.JobPhase, result execution.JobResult, finishTime string,
+) *execution.Job {
+	newJob := withJobPhase(rj, phase)
+	newJob.Status.Condition.Finished = &execution.JobConditionFinished{
+		FinishedAt: testutils.Mkmtime(finishTime),
+		Result:     result,
+	}
+	return newJob
+}
+
+func rejectJob(rj *execution.Job, msg string) *execution.Job {
+	newJob := rj.DeepCopy()
+	job.MarkAdmissionError(newJob, msg)
+	return newJob
+}
+
+func markNamespaceConcurrencyLimited(rj *execution.Job, limit int64) *execution.Job {
+	newJob := rj.DeepCopy()
+	job.MarkNamespaceConcurrencyLimited(newJob, limit)
+	return newJob
+}
+
+func withUID(rj *execution.Job, uid types.UID) *execution.Job {
+	newJob := rj.DeepCopy()
+	newJob.UID = uid
+	return newJob
+}
+
+func expireJob(rj *execution.Job, msg string) *execution.Job {
+	newJob := rj.DeepCopy()
+	job.MarkStartExpired(newJob, msg)
+	return newJob
+}
+
+func markConcurrencyGroupLimited(rj *execution.Job, group string) *execution.Job {
+	newJob := rj.DeepCopy()
+	job.MarkConcurrencyGroupLimited(newJob, group)
+	return newJob
+}
+
 func startJob(job *execution.Job, now *metav1.Time) *execution.Job {
 	newJob := job.DeepCopy()
 	newJob.Status.StartTime = now
+	newJob.Status.TemplateRevision = job.Annotations[jobconfig.AnnotationKeyTemplateRevision]
 	return newJob
 }
+
+// newStartJobAction returns the Action that applies the status fields of a
+// started Job.
+func newStartJobAction(job *execution.Job, now *metav1.Time) runtimetesting.Action {
+	status := map[string]interface{}{
+		"startTime": now,
+	}
+	if revision := job.Annotations[jobconfig.AnnotationKeyTemplateRevision]; revision != "" {
+		status["templateRevision"] = revision
+	}
+	return runtimetesting.NewApplyJobStatusAction(job.Namespace, job.Name, status)
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobqueuecontroller
+
+import (
+	"time"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// getStartAfterDelay returns the duration until the Job is allowed to start
+// according to its startAfter time. Returns false if the Job does not need to
+// wait for startAfter.
+func getStartAfterDelay(rj *execution.Job) (time.Duration, bool) {
+	spec := rj.Spec.StartPolicy
+	if spec == nil || !ktime.IsTimeSetAndLater(spec.StartAfter) {
+		return 0, false
+	}
+	return spec.StartAfter.Time.Sub(ktime.Now().Time), true
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationcontroller
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/scheme"
+	executioninformers "github.com/furiko-io/furiko/pkg/generated/informers/externalversions/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+)
+
+// Controller is responsible for sending the notifications that are configured
+// on JobConfigs when their Jobs finish or run for too long.
+type Controller struct {
+	*Context
+	ctx            context.Context
+	terminate      context.CancelFunc
+	healthStatus   uint64
+	informerWorker *InformerWorker
+	reconciler     *reconciler.Controller
+}
+
+// Context extends the common controllercontext.Context.
+type Context struct {
+	controllercontext.Context
+	jobInformer       executioninformers.JobInformer
+	jobconfigInformer executioninformers.JobConfigInformer
+	hasSynced         []cache.InformerSynced
+	queue             workqueue.RateLimitingInterface
+	recorder          record.EventRecorder
+	eventBroadcaster  record.EventBroadcaster
+	httpClient        *http.Client
+}
+
+func NewContext(context controllercontext.Context, rateLimiter *configv1alpha1.RateLimiterSpec) *Context {
+	eventBroadcaster := context.NewEventBroadcaster()
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: controllerName})
+	c := NewContextWithRecorder(context, recorder, rateLimiter)
+	c.eventBroadcaster = eventBroadcaster
+	return c
+}
+
+func NewContextWithRecorder(
+	context controllercontext.Context, recorder record.EventRecorder, rateLimiter *configv1alpha1.RateLimiterSpec,
+) *Context {
+	c := &Context{Context: context}
+
+	// Set recorder.
+	c.recorder = recorder
+
+	// Set HTTP client. Timeouts are applied to each request from the dynamic config.
+	c.httpClient = &http.Client{}
+
+	// Create workqueue.
+	ratelimiter := controllerutil.NewRateLimiter(rateLimiter)
+	c.queue = workqueue.NewNamedRateLimitingQueue(ratelimiter, controllerName)
+
+	// Bind informers.
+	c.jobInformer = c.Informers().Furiko().Execution().V1alpha1().Jobs()
+	c.jobconfigInformer = c.Informers().Furiko().Execution().V1alpha1().JobConfigs()
+	c.hasSynced = []cache.InformerSynced{
+		c.jobInformer.Informer().HasSynced,
+		c.jobconfigInformer.Informer().HasSynced,
+	}
+
+	return c
+}
+
+func (c *Context) GetHasSynced() []cache.InformerSynced {
+	return c.hasSynced
+}
+
+func NewController(
+	ctrlContext controllercontext.Context,
+	concurrency *configv1alpha1.Concurrency,
+	rateLimiter *configv1alpha1.RateLimiterSpec,
+) (*Controller, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrl := &Controller{
+		Context:   NewContext(ctrlContext, rateLimiter),
+		ctx:       ctx,
+		terminate: cancel,
+	}
+
+	ctrl.informerWorker = NewInformerWorker(ctrl.Context)
+	ctrl.reconciler = reconciler.NewController(NewReconciler(ctrl.Context, concurrency), ctrl.queue)
+
+	return ctrl, nil
+}
+
+func (c *Controller) Run(ctx context.Context) error {
+	defer utilruntime.HandleCrash()
+	klog.InfoS("notificationcontroller: starting controller")
+
+	if ok := cache.WaitForNamedCacheSync(controllerName, ctx.Done(), c.hasSynced...); !ok {
+		klog.Error("notificationcontroller: cache sync timeout")
+		return controllerutil.ErrWaitForCacheSyncTimeout
+	}
+
+	c.reconciler.Start(c.ctx)
+
+	atomic.StoreUint64(&c.healthStatus, 1)
+	klog.InfoS("notificationcontroller: started controller")
+
+	return nil
+}
+
+func (c *Controller) Shutdown(ctx context.Context) {
+	klog.InfoS("notificationcontroller: shutting down")
+
+	// Stop accepting new items and wait for in-flight syncs to complete, before
+	// canceling any syncs that are still running once the deadline is exceeded.
+	if err := c.reconciler.Drain(ctx); err != nil {
+		klog.ErrorS(err, "notificationcontroller: cannot drain in-flight syncs before deadline")
+	}
+	c.terminate()
+	c.reconciler.Wait()
+
+	// Flush any pending events.
+	if c.eventBroadcaster != nil {
+		c.eventBroadcaster.Shutdown()
+	}
+	klog.InfoS("notificationcontroller: stopped controller")
+}
+
+func (c *Controller) GetHealth() controllermanager.HealthStatus {
+	return controllermanager.HealthStatus{
+		Name:               controllerName,
+		Healthy:            atomic.LoadUint64(&c.healthStatus) == 1,
+		CachesSynced:       controllerutil.HasSynced(c.hasSynced...),
+		QueueDepth:         c.reconciler.QueueDepth(),
+		LastSuccessfulSync: c.reconciler.LastSuccessfulSync(),
+	}
+}
@@ -0,0 +1,240 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationcontroller
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	defaultSMTPPort = 587
+
+	// DefaultEmailSubjectTemplate is the default template for the subject of email
+	// notifications.
+	DefaultEmailSubjectTemplate = `[Furiko] {{ .Title }}`
+
+	// DefaultEmailBodyTemplate is the default template for the body of email
+	// notifications.
+	DefaultEmailBodyTemplate = `{{ .Title }}
+
+JobConfig: {{ .JobConfigName }}
+{{- if .Result }}
+Result: {{ .Result }}
+{{- end }}
+{{- if .Reason }}
+Reason: {{ .Reason }}
+{{- end }}
+{{- if .Message }}
+Message: {{ .Message }}
+{{- end }}
+Duration: {{ .Duration }}
+{{- if .URL }}
+
+View Job: {{ .URL }}
+{{- end }}
+`
+)
+
+// EmailTemplateData contains the fields that can be used in the subject and body
+// templates of email notifications.
+type EmailTemplateData struct {
+	Title         string
+	Namespace     string
+	JobName       string
+	JobConfigName string
+	Trigger       string
+	Result        string
+	Reason        string
+	Message       string
+	Duration      string
+	URL           string
+}
+
+// NewEmailTemplateData returns the EmailTemplateData for a notification of the
+// Job with the given trigger.
+func NewEmailTemplateData(
+	rjc *execution.JobConfig,
+	rj *execution.Job,
+	trigger execution.NotificationTrigger,
+	jobURLTemplate string,
+	now time.Time,
+) *EmailTemplateData {
+	msg := NewMessage(rjc, rj, trigger, jobURLTemplate, now)
+	data := &EmailTemplateData{
+		Title:         msg.Title,
+		Namespace:     rj.Namespace,
+		JobName:       rj.Name,
+		JobConfigName: rjc.Name,
+		Trigger:       string(trigger),
+		Duration:      formatDuration(getDuration(rj, trigger, now)),
+		URL:           msg.URL,
+	}
+	if finished := rj.Status.Condition.Finished; finished != nil {
+		data.Result = string(finished.Result)
+		data.Reason = finished.Reason
+		data.Message = finished.Message
+	}
+	return data
+}
+
+// RenderEmail renders the subject and body of an email notification.
+func RenderEmail(spec *configv1alpha1.EmailNotificationsSpec, data *EmailTemplateData) (string, string, error) {
+	subjectTemplate := spec.SubjectTemplate
+	if subjectTemplate == "" {
+		subjectTemplate = DefaultEmailSubjectTemplate
+	}
+	bodyTemplate := spec.BodyTemplate
+	if bodyTemplate == "" {
+		bodyTemplate = DefaultEmailBodyTemplate
+	}
+
+	subject, err := executeTemplate("subject", subjectTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := executeTemplate("body", bodyTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Subjects cannot span multiple lines.
+	subject = strings.Join(strings.Fields(subject), " ")
+
+	return subject, body, nil
+}
+
+func executeTemplate(name, text string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot parse %v template", name)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "cannot execute %v template", name)
+	}
+	return buf.String(), nil
+}
+
+// buildEmail returns the RFC 5322 message for an email with a plain text body.
+func buildEmail(from string, to, cc []string, subject, body string, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	if len(cc) > 0 {
+		buf.WriteString("Cc: " + strings.Join(cc, ", ") + "\r\n")
+	}
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	buf.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sendEmail sends the message to all recipients using the SMTP server. The
+// context's deadline is applied to the entire SMTP session.
+func sendEmail(
+	ctx context.Context,
+	spec *configv1alpha1.EmailNotificationsSpec,
+	recipients []string,
+	msg []byte,
+) error {
+	port := spec.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	addr := net.JoinHostPort(spec.Host, strconv.FormatInt(port, 10))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "cannot connect to smtp server")
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return errors.Wrapf(err, "cannot set deadline")
+		}
+	}
+
+	client, err := smtp.NewClient(conn, spec.Host)
+	if err != nil {
+		return errors.Wrapf(err, "cannot create smtp client")
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: spec.Host}); err != nil {
+			return errors.Wrapf(err, "cannot start tls")
+		}
+	}
+	if spec.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", spec.Username, spec.Password, spec.Host)); err != nil {
+			return errors.Wrapf(err, "cannot authenticate")
+		}
+	}
+
+	if err := client.Mail(spec.From); err != nil {
+		return errors.Wrapf(err, "cannot set sender")
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return errors.Wrapf(err, "cannot add recipient %v", recipient)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return errors.Wrapf(err, "cannot start data")
+	}
+	if _, err := w.Write(msg); err != nil {
+		return errors.Wrapf(err, "cannot write data")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrapf(err, "cannot send data")
+	}
+
+	if err := client.Quit(); err != nil {
+		return errors.Wrapf(err, "cannot quit")
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationcontroller_test
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/notificationcontroller"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestRenderEmail(t *testing.T) {
+	rj := jobFailed.DeepCopy()
+	rj.Status.Condition.Finished.Reason = "ContainerFailed"
+	rj.Status.Condition.Finished.Message = "exit code 1"
+	data := notificationcontroller.NewEmailTemplateData(jobConfig, rj, execution.NotificationTriggerFailure,
+		"https://furiko.example.com/${job.namespace}/${job.name}", testutils.Mktime(now))
+
+	tests := []struct {
+		name        string
+		spec        *configv1alpha1.EmailNotificationsSpec
+		wantSubject string
+		wantBody    string
+		wantErr     bool
+	}{
+		{
+			name:        "default templates",
+			spec:        &configv1alpha1.EmailNotificationsSpec{},
+			wantSubject: "[Furiko] Job test/job failed",
+			wantBody: `Job test/job failed
+
+JobConfig: jobconfig
+Result: TaskFailed
+Reason: ContainerFailed
+Message: exit code 1
+Duration: 59s
+
+View Job: https://furiko.example.com/test/job
+`,
+		},
+		{
+			name: "custom templates",
+			spec: &configv1alpha1.EmailNotificationsSpec{
+				SubjectTemplate: "{{ .JobConfigName }}:\n{{ .Result }}",
+				BodyTemplate:    "{{ .Namespace }}/{{ .JobName }} {{ .Trigger }}: {{ .Reason }}",
+			},
+			wantSubject: "jobconfig: TaskFailed",
+			wantBody:    "test/job Failure: ContainerFailed",
+		},
+		{
+			name: "invalid template",
+			spec: &configv1alpha1.EmailNotificationsSpec{
+				BodyTemplate: "{{ .Unknown }}",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			subject, body, err := notificationcontroller.RenderEmail(tt.spec, data)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSubject, subject)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestReconciler_Email(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	defer server.Close()
+
+	emailJobConfig := jobConfig.DeepCopy()
+	emailJobConfig.Spec.Notifications[0] = execution.NotificationSpec{
+		Type: execution.NotificationTypeEmail,
+		Email: &execution.EmailNotificationSpec{
+			To: []string{"team@example.com"},
+			Cc: []string{"oncall@example.com"},
+		},
+		On: []execution.NotificationTrigger{execution.NotificationTriggerFailure},
+	}
+
+	host, port, err := net.SplitHostPort(server.Addr().String())
+	assert.NoError(t, err)
+	portNum, err := strconv.ParseInt(port, 10, 64)
+	assert.NoError(t, err)
+	configs := controllercontext.ConfigsMap{
+		configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+			Notifications: &configv1alpha1.NotificationsSpec{
+				Email: &configv1alpha1.EmailNotificationsSpec{
+					Host: host,
+					Port: portNum,
+					From: "furiko@example.com",
+				},
+			},
+		},
+	}
+
+	test := runtimetesting.ReconcilerTest{
+		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
+			return notificationcontroller.NewContextWithRecorder(c, recorder, nil)
+		},
+		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
+			return notificationcontroller.NewReconciler(
+				c.(*notificationcontroller.Context),
+				runtimetesting.ReconcilerDefaultConcurrency,
+			)
+		},
+		Now: testutils.Mktime(now),
+	}
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name:     "send email notification",
+			Target:   jobFailed,
+			Fixtures: []runtime.Object{emailJobConfig},
+			Configs:  configs,
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobAction(testNamespace, job.Name, types.MergePatchType,
+							[]byte(annotationSent+`"Failure/0"}}}`)),
+					},
+				},
+			},
+			Assert: func(t assert.TestingT, _ runtimetesting.ReconcilerTestCase, _ runtimetesting.ControllerContext) {
+				mails := server.Mails()
+				if assert.Len(t, mails, 1) {
+					assert.Equal(t, "furiko@example.com", mails[0].From)
+					assert.Equal(t, []string{"team@example.com", "oncall@example.com"}, mails[0].To)
+					assert.Contains(t, mails[0].Data, "Subject: [Furiko] Job test/job failed\r\n")
+					assert.Contains(t, mails[0].Data, "Cc: oncall@example.com\r\n")
+					assert.Contains(t, mails[0].Data, "Result: TaskFailed")
+				}
+			},
+		},
+		{
+			Name:     "email notifications not configured",
+			Target:   jobFailed,
+			Fixtures: []runtime.Object{emailJobConfig},
+			WantError: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.Error(t, err)
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobUID,
+					Type:    "Warning",
+					Reason:  "NotificationFailed",
+					Message: "Cannot send Email notification for Failure: email notifications are not configured",
+				},
+			},
+		},
+	})
+}
+
+type fakeMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// fakeSMTPServer is a minimal SMTP server that accepts all mails.
+type fakeSMTPServer struct {
+	net.Listener
+	mails chan fakeMail
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("cannot listen: %v", err)
+	}
+	server := &fakeSMTPServer{
+		Listener: listener,
+		mails:    make(chan fakeMail, 10),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.handle(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	var mail fakeMail
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch command {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			mail.From = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			reply("250 OK")
+		case "RCPT":
+			mail.To = append(mail.To, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			reply("250 OK")
+		case "DATA":
+			reply("354 Start mail input")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			mail.Data = data.String()
+			s.mails <- mail
+			mail = fakeMail{}
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+// Mails returns all mails that were received so far.
+func (s *fakeSMTPServer) Mails() []fakeMail {
+	var mails []fakeMail
+	for {
+		select {
+		case mail := <-s.mails:
+			mails = append(mails, mail)
+		default:
+			return mails
+		}
+	}
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationcontroller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	// maxNotifyDelay is the maximum duration after a Job has finished, within which
+	// its Success and Failure notifications can still be sent. This prevents
+	// notifications from being sent for Jobs which finished long ago, such as when
+	// notifications are first added to a JobConfig.
+	maxNotifyDelay = time.Hour
+
+	colorSuccess = "2EB67D"
+	colorFailure = "E01E5A"
+	colorWarning = "ECB22E"
+)
+
+// getNotifyTime returns the time at which the trigger should fire for the Job,
+// or false if it will never fire.
+func getNotifyTime(
+	spec execution.NotificationSpec,
+	trigger execution.NotificationTrigger,
+	rj *execution.Job,
+) (time.Time, bool) {
+	finished := rj.Status.Condition.Finished
+	switch trigger {
+	case execution.NotificationTriggerSuccess:
+		if finished != nil && finished.Result == execution.JobResultSuccess {
+			return finished.FinishedAt.Time, true
+		}
+	case execution.NotificationTriggerFailure:
+		if finished != nil && finished.Result.IsFailed() {
+			return finished.FinishedAt.Time, true
+		}
+	case execution.NotificationTriggerLongRunning:
+		if finished == nil && rj.Status.StartTime != nil && spec.LongRunningThresholdSeconds != nil {
+			threshold := time.Duration(*spec.LongRunningThresholdSeconds) * time.Second
+			return rj.Status.StartTime.Add(threshold), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// notificationKey is a key that identifies a notification that was sent for a
+// Job, consisting of the trigger and the index of the notification in the
+// JobConfig.
+func notificationKey(trigger execution.NotificationTrigger, idx int) string {
+	return fmt.Sprintf("%v/%v", trigger, idx)
+}
+
+// parseSentNotifications parses the value of AnnotationKeyNotificationsSent.
+func parseSentNotifications(value string) map[string]bool {
+	sent := make(map[string]bool)
+	for _, key := range strings.Split(value, ",") {
+		if key != "" {
+			sent[key] = true
+		}
+	}
+	return sent
+}
+
+// formatSentNotifications formats a set of notification keys as the value of
+// AnnotationKeyNotificationsSent.
+func formatSentNotifications(sent map[string]bool) string {
+	keys := make([]string, 0, len(sent))
+	for key := range sent {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// Message is a chat service-agnostic notification message.
+type Message struct {
+	Title string
+	Color string
+	Facts []Fact
+	URL   string
+}
+
+// Fact is a key-value pair that is displayed in a Message.
+type Fact struct {
+	Name  string
+	Value string
+}
+
+// NewMessage returns the Message for a notification of the Job with the given
+// trigger. If jobURLTemplate is not empty, it will be used to link to the Job.
+func NewMessage(
+	rjc *execution.JobConfig,
+	rj *execution.Job,
+	trigger execution.NotificationTrigger,
+	jobURLTemplate string,
+	now time.Time,
+) *Message {
+	msg := &Message{
+		Facts: []Fact{
+			{Name: "JobConfig", Value: rjc.Name},
+		},
+	}
+
+	name := rj.Namespace + "/" + rj.Name
+	duration := formatDuration(getDuration(rj, trigger, now))
+
+	switch trigger {
+	case execution.NotificationTriggerLongRunning:
+		msg.Title = fmt.Sprintf("Job %v is still running", name)
+		msg.Color = colorWarning
+		msg.Facts = append(msg.Facts, Fact{Name: "Running for", Value: duration})
+	default:
+		msg.Title = fmt.Sprintf("Job %v succeeded", name)
+		msg.Color = colorSuccess
+		if trigger == execution.NotificationTriggerFailure {
+			msg.Title = fmt.Sprintf("Job %v failed", name)
+			msg.Color = colorFailure
+		}
+		if finished := rj.Status.Condition.Finished; finished != nil {
+			msg.Facts = append(msg.Facts,
+				Fact{Name: "Result", Value: string(finished.Result)},
+				Fact{Name: "Duration", Value: duration},
+			)
+			if finished.Reason != "" {
+				msg.Facts = append(msg.Facts, Fact{Name: "Reason", Value: finished.Reason})
+			}
+			if finished.Message != "" {
+				msg.Facts = append(msg.Facts, Fact{Name: "Message", Value: finished.Message})
+			}
+		}
+	}
+
+	if jobURLTemplate != "" {
+		msg.URL = strings.NewReplacer(
+			"${job.namespace}", rj.Namespace,
+			"${job.name}", rj.Name,
+			"${jobconfig.name}", rjc.Name,
+		).Replace(jobURLTemplate)
+	}
+
+	return msg
+}
+
+// Payload returns the JSON payload of the Message for the given type of chat
+// service.
+func (m *Message) Payload(notificationType execution.NotificationType) (interface{}, error) {
+	switch notificationType {
+	case execution.NotificationTypeSlack:
+		return m.slackPayload(), nil
+	case execution.NotificationTypeTeams:
+		return m.teamsPayload(), nil
+	}
+	return nil, fmt.Errorf("unsupported notification type: %v", notificationType)
+}
+
+func (m *Message) slackPayload() interface{} {
+	title := m.Title
+	if m.URL != "" {
+		title = fmt.Sprintf("<%v|%v>", m.URL, m.Title)
+	}
+	lines := []string{"*" + title + "*"}
+	for _, fact := range m.Facts {
+		lines = append(lines, fmt.Sprintf("*%v:* %v", fact.Name, fact.Value))
+	}
+	return map[string]interface{}{
+		"text": m.Title,
+		"attachments": []map[string]interface{}{
+			{
+				"color": "#" + m.Color,
+				"text":  strings.Join(lines, "\n"),
+			},
+		},
+	}
+}
+
+func (m *Message) teamsPayload() interface{} {
+	facts := make([]map[string]string, 0, len(m.Facts))
+	for _, fact := range m.Facts {
+		facts = append(facts, map[string]string{"name": fact.Name, "value": fact.Value})
+	}
+	payload := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    m.Title,
+		"themeColor": m.Color,
+		"title":      m.Title,
+		"sections": []map[string]interface{}{
+			{"facts": facts},
+		},
+	}
+	if m.URL != "" {
+		payload["potentialAction"] = []map[string]interface{}{
+			{
+				"@type": "OpenUri",
+				"name":  "View Job",
+				"targets": []map[string]string{
+					{"os": "default", "uri": m.URL},
+				},
+			},
+		}
+	}
+	return payload
+}
+
+// send posts the payload as JSON to the webhook URL.
+func send(ctx context.Context, client *http.Client, webhookURL string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal payload")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "cannot send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned status %v: %v", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// getDuration returns the duration that the Job ran for until it finished, or
+// until now if it has not yet finished.
+func getDuration(rj *execution.Job, trigger execution.NotificationTrigger, now time.Time) time.Duration {
+	startTime := rj.GetCreationTimestamp().Time
+	if rj.Status.StartTime != nil {
+		startTime = rj.Status.StartTime.Time
+	}
+	if finished := rj.Status.Condition.Finished; finished != nil && trigger != execution.NotificationTriggerLongRunning {
+		return finished.FinishedAt.Sub(startTime)
+	}
+	return now.Sub(startTime)
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
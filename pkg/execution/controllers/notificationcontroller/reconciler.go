@@ -0,0 +1,294 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationcontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	utiltrace "k8s.io/utils/trace"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+)
+
+type Reconciler struct {
+	*Context
+	concurrency *configv1alpha1.Concurrency
+}
+
+func NewReconciler(ctrlContext *Context, concurrency *configv1alpha1.Concurrency) *Reconciler {
+	return &Reconciler{
+		Context:     ctrlContext,
+		concurrency: concurrency,
+	}
+}
+
+func (w *Reconciler) Name() string {
+	return fmt.Sprintf("%v.Reconciler", controllerName)
+}
+
+func (w *Reconciler) Concurrency() int {
+	cfg, err := w.Configs().Controllers()
+	if err != nil {
+		klog.ErrorS(err, "notificationcontroller: cannot load controller configuration", "worker", w.Name())
+	}
+	concurrency := controllerutil.GetDynamicConcurrency(cfg, w.concurrency,
+		func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency {
+			return spec.Notification
+		})
+	return controllerutil.GetConcurrencyOrDefaultCPUFactor(concurrency, 4)
+}
+
+func (w *Reconciler) MaxRequeues() int {
+	return -1
+}
+
+func (w *Reconciler) SyncOne(ctx context.Context, namespace, name string, _ int) error {
+	trace := utiltrace.New(
+		"notification_sync",
+		utiltrace.Field{Key: "namespace", Value: namespace},
+		utiltrace.Field{Key: "name", Value: name},
+	)
+	defer trace.LogIfLong(500 * time.Millisecond)
+
+	rj, err := w.jobInformer.Lister().Jobs(namespace).Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get job")
+	}
+
+	// Look up the owner JobConfig, which may have been deleted or recreated.
+	ref := metav1.GetControllerOf(rj)
+	if ref == nil || ref.Kind != execution.KindJobConfig {
+		return nil
+	}
+	rjc, err := w.jobconfigInformer.Lister().JobConfigs(namespace).Get(ref.Name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get job config")
+	}
+	if rjc.UID != ref.UID || len(rjc.Spec.Notifications) == 0 {
+		return nil
+	}
+	trace.Step("Lookup job and job config from cache done")
+
+	cfg, err := w.Configs().JobsForNamespace(namespace)
+	if err != nil {
+		return errors.Wrapf(err, "cannot load job execution config")
+	}
+	spec := cfg.Notifications
+	if spec == nil {
+		spec = &configv1alpha1.NotificationsSpec{}
+	}
+
+	now := ktime.Now().Time
+	sent := parseSentNotifications(rj.Annotations[jobutil.AnnotationKeyNotificationsSent])
+	var numSent int
+	var errs []error
+
+	for i, notification := range rjc.Spec.Notifications {
+		for _, trigger := range notification.On {
+			key := notificationKey(trigger, i)
+			if sent[key] {
+				continue
+			}
+
+			notifyTime, ok := getNotifyTime(notification, trigger, rj)
+			if !ok {
+				continue
+			}
+			if now.Before(notifyTime) {
+				w.enqueueAfter(rj, string(trigger), notifyTime.Sub(now))
+				continue
+			}
+			if trigger != execution.NotificationTriggerLongRunning && now.Sub(notifyTime) > maxNotifyDelay {
+				continue
+			}
+
+			if err := w.notify(ctx, rjc, rj, notification, trigger, spec, now); err != nil {
+				w.recorder.Eventf(rj, corev1.EventTypeWarning, "NotificationFailed",
+					"Cannot send %v notification for %v: %v", notification.Type, trigger, err)
+				errs = append(errs, errors.Wrapf(err, "cannot send notification %v", key))
+				continue
+			}
+
+			klog.V(3).InfoS("notificationcontroller: sent notification",
+				"worker", w.Name(),
+				"namespace", rj.GetNamespace(),
+				"name", rj.GetName(),
+				"type", notification.Type,
+				"trigger", trigger,
+			)
+
+			sent[key] = true
+			numSent++
+		}
+	}
+	trace.Step("Send notifications done")
+
+	// Record the notifications that were sent, even if other notifications failed.
+	if numSent > 0 {
+		if err := w.recordSent(ctx, rj, sent); err != nil {
+			errs = append(errs, errors.Wrapf(err, "cannot record sent notifications"))
+		}
+		trace.Step("Update job annotations done")
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// notify sends a single notification for the Job.
+func (w *Reconciler) notify(
+	ctx context.Context,
+	rjc *execution.JobConfig,
+	rj *execution.Job,
+	notification execution.NotificationSpec,
+	trigger execution.NotificationTrigger,
+	spec *configv1alpha1.NotificationsSpec,
+	now time.Time,
+) error {
+	timeout := defaultTimeout
+	if spec.TimeoutSeconds != nil && *spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(*spec.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if notification.Type == execution.NotificationTypeEmail {
+		return w.notifyEmail(ctx, rjc, rj, notification, trigger, spec, now)
+	}
+
+	if notification.WebhookURLSecretRef == nil {
+		return errors.New("webhookURLSecretRef is not specified")
+	}
+	webhookURL, err := w.getWebhookURL(ctx, rj.Namespace, *notification.WebhookURLSecretRef)
+	if err != nil {
+		return err
+	}
+
+	payload, err := NewMessage(rjc, rj, trigger, spec.JobURLTemplate, now).Payload(notification.Type)
+	if err != nil {
+		return err
+	}
+
+	return send(ctx, w.httpClient, webhookURL, payload)
+}
+
+// notifyEmail sends a single email notification for the Job.
+func (w *Reconciler) notifyEmail(
+	ctx context.Context,
+	rjc *execution.JobConfig,
+	rj *execution.Job,
+	notification execution.NotificationSpec,
+	trigger execution.NotificationTrigger,
+	spec *configv1alpha1.NotificationsSpec,
+	now time.Time,
+) error {
+	if spec.Email == nil {
+		return errors.New("email notifications are not configured")
+	}
+	if notification.Email == nil || len(notification.Email.To) == 0 {
+		return errors.New("email recipients are not specified")
+	}
+
+	data := NewEmailTemplateData(rjc, rj, trigger, spec.JobURLTemplate, now)
+	subject, body, err := RenderEmail(spec.Email, data)
+	if err != nil {
+		return err
+	}
+	msg, err := buildEmail(spec.Email.From, notification.Email.To, notification.Email.Cc, subject, body, now)
+	if err != nil {
+		return errors.Wrapf(err, "cannot build email")
+	}
+
+	recipients := append(append([]string{}, notification.Email.To...), notification.Email.Cc...)
+	return sendEmail(ctx, spec.Email, recipients, msg)
+}
+
+// getWebhookURL reads the webhook URL from the referenced Secret key.
+func (w *Reconciler) getWebhookURL(ctx context.Context, namespace string, ref corev1.SecretKeySelector) (string, error) {
+	secret, err := w.Clientsets().Kubernetes().CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot get secret %v", ref.Name)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %v not found in secret %v", ref.Key, ref.Name)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// recordSent updates the annotation of the Job with the set of notifications
+// that were sent.
+func (w *Reconciler) recordSent(ctx context.Context, rj *execution.Job, sent map[string]bool) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				jobutil.AnnotationKeyNotificationsSent: formatSentNotifications(sent),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal patch")
+	}
+	if _, err := w.Clientsets().Furiko().ExecutionV1alpha1().Jobs(rj.Namespace).
+		Patch(ctx, rj.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "cannot update job")
+	}
+	return nil
+}
+
+// enqueueAfter will defer a sync after the specified duration, and logs the purpose of deferring
+// the sync for debugging purposes.
+// We enforce a lower bound of 1 second to the next sync, to slow down unwanted bursts of syncs.
+func (w *Reconciler) enqueueAfter(rj *execution.Job, purpose string, duration time.Duration) {
+	duration = timeutil.DurationMax(time.Second, duration)
+	if key, err := cache.MetaNamespaceKeyFunc(rj); err == nil {
+		w.queue.AddAfter(key, duration)
+		klog.V(2).InfoS("notificationcontroller: worker enqueue sync",
+			"worker", w.Name(),
+			"namespace", rj.GetNamespace(),
+			"name", rj.GetName(),
+			"purpose", purpose,
+			"after", duration.String(),
+		)
+	}
+}
@@ -0,0 +1,390 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notificationcontroller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/notificationcontroller"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+	runtimetesting "github.com/furiko-io/furiko/pkg/runtime/testing"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	createTime     = "2022-04-01T04:00:00Z"
+	startTime      = "2022-04-01T04:00:01Z"
+	finishTime     = "2022-04-01T04:01:00Z"
+	now            = "2022-04-01T04:05:00Z"
+	muchLater      = "2022-04-01T08:00:00Z"
+	testNamespace  = "test"
+	jobConfigUID   = "7a1d9b2c-5d1a-4b8e-9a3c-2f6e3f1c8d10"
+	jobUID         = "e3f0c6a4-1b2d-4c5e-8f9a-0b1c2d3e4f50"
+	annotationSent = `{"metadata":{"annotations":{"execution.furiko.io/notifications-sent":`
+)
+
+var (
+	jobConfig = &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jobconfig",
+			Namespace: testNamespace,
+			UID:       jobConfigUID,
+		},
+		Spec: execution.JobConfigSpec{
+			Notifications: []execution.NotificationSpec{
+				{
+					Type: execution.NotificationTypeSlack,
+					WebhookURLSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "slack"},
+						Key:                  "url",
+					},
+					On: []execution.NotificationTrigger{
+						execution.NotificationTriggerFailure,
+						execution.NotificationTriggerLongRunning,
+					},
+					LongRunningThresholdSeconds: pointer.Int64(3600),
+				},
+			},
+		},
+	}
+
+	jobConfigFailingWebhook = func() *execution.JobConfig {
+		newRjc := jobConfig.DeepCopy()
+		newRjc.Spec.Notifications[0].WebhookURLSecretRef.Name = "failing"
+		return newRjc
+	}()
+
+	jobConfigWithoutNotifications = func() *execution.JobConfig {
+		newRjc := jobConfig.DeepCopy()
+		newRjc.Spec.Notifications = nil
+		return newRjc
+	}()
+
+	job = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "job",
+			Namespace:         testNamespace,
+			UID:               jobUID,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			Labels: map[string]string{
+				jobconfig.LabelKeyJobConfigUID: jobConfigUID,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(jobConfig, execution.GVKJobConfig),
+			},
+		},
+	}
+
+	jobRunning   = makeJob(job, "")
+	jobSucceeded = makeJob(job, execution.JobResultSuccess)
+	jobFailed    = makeJob(job, execution.JobResultTaskFailed)
+
+	jobFailedNotified = func() *execution.Job {
+		newRj := jobFailed.DeepCopy()
+		newRj.Annotations = map[string]string{
+			jobutil.AnnotationKeyNotificationsSent: "Failure/0",
+		}
+		return newRj
+	}()
+)
+
+func TestReconciler(t *testing.T) {
+	var mu sync.Mutex
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/failing" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("internal error"))
+			return
+		}
+		payload := make(map[string]interface{})
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	secrets := []runtime.Object{
+		newSecret("slack", server.URL+"/slack"),
+		newSecret("failing", server.URL+"/failing"),
+	}
+
+	// assertPayloads asserts the titles of the payloads received by the server.
+	assertPayloads := func(want ...string) func(assert.TestingT, runtimetesting.ReconcilerTestCase,
+		runtimetesting.ControllerContext) {
+		return func(t assert.TestingT, _ runtimetesting.ReconcilerTestCase, _ runtimetesting.ControllerContext) {
+			mu.Lock()
+			defer mu.Unlock()
+			var titles []string
+			for _, payload := range payloads {
+				titles = append(titles, payload["text"].(string))
+			}
+			assert.Equal(t, want, titles)
+			payloads = nil
+		}
+	}
+
+	test := runtimetesting.ReconcilerTest{
+		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
+			return notificationcontroller.NewContextWithRecorder(c, recorder, nil)
+		},
+		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
+			return notificationcontroller.NewReconciler(
+				c.(*notificationcontroller.Context),
+				runtimetesting.ReconcilerDefaultConcurrency,
+			)
+		},
+		Now: testutils.Mktime(now),
+	}
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name: "no such Job",
+			SyncTarget: &runtimetesting.SyncTarget{
+				Namespace: testNamespace,
+				Name:      "nonexistent-job",
+			},
+			Assert: assertPayloads(),
+		},
+		{
+			Name:     "JobConfig without notifications",
+			Target:   jobFailed,
+			Fixtures: append([]runtime.Object{jobConfigWithoutNotifications}, secrets...),
+			Assert:   assertPayloads(),
+		},
+		{
+			Name:     "send notification for failed Job",
+			Target:   jobFailed,
+			Fixtures: append([]runtime.Object{jobConfig}, secrets...),
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobAction(testNamespace, job.Name, types.MergePatchType,
+							[]byte(annotationSent+`"Failure/0"}}}`)),
+					},
+				},
+			},
+			Assert: assertPayloads("Job test/job failed"),
+		},
+		{
+			Name:     "do not send notification twice",
+			Target:   jobFailedNotified,
+			Fixtures: append([]runtime.Object{jobConfig}, secrets...),
+			Assert:   assertPayloads(),
+		},
+		{
+			Name:     "do not send notification for untriggered result",
+			Target:   jobSucceeded,
+			Fixtures: append([]runtime.Object{jobConfig}, secrets...),
+			Assert:   assertPayloads(),
+		},
+		{
+			Name:     "do not send notification for Job that finished long ago",
+			Target:   jobFailed,
+			Fixtures: append([]runtime.Object{jobConfig}, secrets...),
+			Now:      testutils.Mktime(muchLater),
+			Assert:   assertPayloads(),
+		},
+		{
+			Name:     "do not send LongRunning notification before threshold",
+			Target:   jobRunning,
+			Fixtures: append([]runtime.Object{jobConfig}, secrets...),
+			Assert:   assertPayloads(),
+		},
+		{
+			Name:     "send LongRunning notification after threshold",
+			Target:   jobRunning,
+			Fixtures: append([]runtime.Object{jobConfig}, secrets...),
+			Now:      testutils.Mktime(muchLater),
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobAction(testNamespace, job.Name, types.MergePatchType,
+							[]byte(annotationSent+`"LongRunning/0"}}}`)),
+					},
+				},
+			},
+			Assert: assertPayloads("Job test/job is still running"),
+		},
+		{
+			Name:     "record event if webhook failed",
+			Target:   jobFailed,
+			Fixtures: append([]runtime.Object{jobConfigFailingWebhook}, secrets...),
+			WantError: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.Error(t, err)
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobUID,
+					Type:    corev1.EventTypeWarning,
+					Reason:  "NotificationFailed",
+					Message: "Cannot send Slack notification for Failure: webhook returned status 500: internal error",
+				},
+			},
+			Assert: assertPayloads(),
+		},
+		{
+			Name:     "missing Secret",
+			Target:   jobFailed,
+			Fixtures: []runtime.Object{jobConfig},
+			WantError: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.Error(t, err)
+			},
+			WantEvents: []runtimetesting.Event{
+				{
+					UID:     jobUID,
+					Type:    corev1.EventTypeWarning,
+					Reason:  "NotificationFailed",
+					Message: `Cannot send Slack notification for Failure: cannot get secret slack: secrets "slack" not found`,
+				},
+			},
+			Assert: assertPayloads(),
+		},
+	})
+}
+
+func TestNewMessage(t *testing.T) {
+	msg := notificationcontroller.NewMessage(jobConfig, jobFailed, execution.NotificationTriggerFailure,
+		"https://furiko.example.com/${job.namespace}/${jobconfig.name}/${job.name}", testutils.Mktime(now))
+	assert.Equal(t, &notificationcontroller.Message{
+		Title: "Job test/job failed",
+		Color: "E01E5A",
+		Facts: []notificationcontroller.Fact{
+			{Name: "JobConfig", Value: "jobconfig"},
+			{Name: "Result", Value: "TaskFailed"},
+			{Name: "Duration", Value: "59s"},
+		},
+		URL: "https://furiko.example.com/test/jobconfig/job",
+	}, msg)
+
+	for _, notificationType := range []execution.NotificationType{
+		execution.NotificationTypeSlack,
+		execution.NotificationTypeTeams,
+	} {
+		_, err := msg.Payload(notificationType)
+		assert.NoError(t, err)
+	}
+	_, err := msg.Payload(execution.NotificationTypeEmail)
+	assert.Error(t, err)
+}
+
+func TestReconciler_JobURLTemplate(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+	}))
+	defer server.Close()
+
+	teamsJobConfig := jobConfig.DeepCopy()
+	teamsJobConfig.Spec.Notifications[0].Type = execution.NotificationTypeTeams
+
+	test := runtimetesting.ReconcilerTest{
+		ContextFunc: func(c controllercontext.Context, recorder record.EventRecorder) runtimetesting.ControllerContext {
+			return notificationcontroller.NewContextWithRecorder(c, recorder, nil)
+		},
+		ReconcilerFunc: func(c runtimetesting.ControllerContext) reconciler.Reconciler {
+			return notificationcontroller.NewReconciler(
+				c.(*notificationcontroller.Context),
+				runtimetesting.ReconcilerDefaultConcurrency,
+			)
+		},
+		Now: testutils.Mktime(now),
+	}
+
+	test.Run(t, []runtimetesting.ReconcilerTestCase{
+		{
+			Name:     "send Teams notification with link",
+			Target:   jobFailed,
+			Fixtures: []runtime.Object{teamsJobConfig, newSecret("slack", server.URL)},
+			Configs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					Notifications: &configv1alpha1.NotificationsSpec{
+						JobURLTemplate: "https://furiko.example.com/${job.namespace}/${job.name}",
+					},
+				},
+			},
+			WantActions: runtimetesting.CombinedActions{
+				Furiko: runtimetesting.ActionTest{
+					Actions: []runtimetesting.Action{
+						runtimetesting.NewPatchJobAction(testNamespace, job.Name, types.MergePatchType,
+							[]byte(annotationSent+`"Failure/0"}}}`)),
+					},
+				},
+			},
+			Assert: func(t assert.TestingT, _ runtimetesting.ReconcilerTestCase, _ runtimetesting.ControllerContext) {
+				assert.Equal(t, "MessageCard", payload["@type"])
+				assert.Equal(t, "Job test/job failed", payload["title"])
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{
+						"@type": "OpenUri",
+						"name":  "View Job",
+						"targets": []interface{}{
+							map[string]interface{}{"os": "default", "uri": "https://furiko.example.com/test/job"},
+						},
+					},
+				}, payload["potentialAction"])
+			},
+		},
+	})
+}
+
+func makeJob(job *execution.Job, result execution.JobResult) *execution.Job {
+	newJob := job.DeepCopy()
+	newJob.Status.Phase = execution.JobRunning
+	newJob.Status.StartTime = testutils.Mkmtimep(startTime)
+	if result != "" {
+		newJob.Status.Phase = execution.JobSucceeded
+		if result.IsFailed() {
+			newJob.Status.Phase = execution.JobRetryLimitExceeded
+		}
+		newJob.Status.Condition.Finished = &execution.JobConditionFinished{
+			FinishedAt: testutils.Mkmtime(finishTime),
+			Result:     result,
+		}
+	}
+	return newJob
+}
+
+func newSecret(name, url string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Data: map[string][]byte{
+			"url": []byte(url),
+		},
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourceusagecontroller
+
+import (
+	"context"
+	"sync/atomic"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	executioninformers "github.com/furiko-io/furiko/pkg/generated/informers/externalversions/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+)
+
+// Controller is responsible for sampling the resource usage of running tasks,
+// recording the resource usage of each task on its Pod, and recording the peak
+// usage in the status of their JobConfigs.
+type Controller struct {
+	*Context
+	ctx          context.Context
+	terminate    context.CancelFunc
+	healthStatus uint64
+	sampler      *Sampler
+}
+
+// Context extends the common controllercontext.Context.
+type Context struct {
+	controllercontext.Context
+	podInformer       coreinformers.PodInformer
+	jobInformer       executioninformers.JobInformer
+	jobconfigInformer executioninformers.JobConfigInformer
+	HasSynced         []cache.InformerSynced
+	metrics           PodMetricsGetter
+}
+
+// NewContext returns a new Context.
+func NewContext(context controllercontext.Context, metrics PodMetricsGetter) *Context {
+	c := &Context{Context: context, metrics: metrics}
+
+	// Bind informers.
+	c.podInformer = c.Informers().Kubernetes().Core().V1().Pods()
+	c.jobInformer = c.Informers().Furiko().Execution().V1alpha1().Jobs()
+	c.jobconfigInformer = c.Informers().Furiko().Execution().V1alpha1().JobConfigs()
+	c.HasSynced = []cache.InformerSynced{
+		c.podInformer.Informer().HasSynced,
+		c.jobInformer.Informer().HasSynced,
+		c.jobconfigInformer.Informer().HasSynced,
+	}
+
+	return c
+}
+
+func NewController(ctrlContext controllercontext.Context, metrics PodMetricsGetter) *Controller {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrl := &Controller{
+		Context:   NewContext(ctrlContext, metrics),
+		ctx:       ctx,
+		terminate: cancel,
+	}
+
+	ctrl.sampler = NewSampler(ctrl.Context)
+
+	return ctrl
+}
+
+func (c *Controller) Run(ctx context.Context) error {
+	defer utilruntime.HandleCrash()
+	klog.InfoS("resourceusagecontroller: starting controller")
+
+	if ok := cache.WaitForNamedCacheSync(controllerName, ctx.Done(), c.HasSynced...); !ok {
+		klog.Error("resourceusagecontroller: cache sync timeout")
+		return controllerutil.ErrWaitForCacheSyncTimeout
+	}
+
+	c.sampler.Start(c.ctx)
+
+	atomic.StoreUint64(&c.healthStatus, 1)
+	klog.InfoS("resourceusagecontroller: started controller")
+
+	return nil
+}
+
+func (c *Controller) Shutdown(ctx context.Context) {
+	klog.InfoS("resourceusagecontroller: shutting down")
+	c.terminate()
+	klog.InfoS("resourceusagecontroller: stopped controller")
+}
+
+func (c *Controller) GetHealth() controllermanager.HealthStatus {
+	return controllermanager.HealthStatus{
+		Name:         controllerName,
+		Healthy:      atomic.LoadUint64(&c.healthStatus) == 1,
+		CachesSynced: controllerutil.HasSynced(c.HasSynced...),
+	}
+}
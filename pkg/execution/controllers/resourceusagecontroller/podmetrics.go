@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourceusagecontroller
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// metricsAPIPath is the path to the resource metrics API, which is typically
+	// served by metrics-server.
+	metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+)
+
+// PodMetrics contains the resource usage of a single Pod's containers.
+// This mirrors the PodMetrics type in the metrics.k8s.io API group.
+type PodMetrics struct {
+	Containers []ContainerMetrics `json:"containers"`
+}
+
+// ContainerMetrics contains the resource usage of a single container.
+type ContainerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// PodMetricsGetter knows how to get the current resource usage of a Pod.
+type PodMetricsGetter interface {
+	GetPodMetrics(ctx context.Context, namespace, name string) (*PodMetrics, error)
+}
+
+// PodMetricsClient gets PodMetrics from the metrics API using a REST client.
+type PodMetricsClient struct {
+	client rest.Interface
+}
+
+var _ PodMetricsGetter = (*PodMetricsClient)(nil)
+
+func NewPodMetricsClient(client rest.Interface) *PodMetricsClient {
+	return &PodMetricsClient{client: client}
+}
+
+func (c *PodMetricsClient) GetPodMetrics(ctx context.Context, namespace, name string) (*PodMetrics, error) {
+	body, err := c.client.Get().
+		AbsPath(metricsAPIPath, "namespaces", namespace, "pods", name).
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &PodMetrics{}
+	if err := json.Unmarshal(body, metrics); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal pod metrics")
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourceusagecontroller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/meta"
+)
+
+const (
+	// disabledRecheckInterval is the interval to check if sampling has been
+	// enabled in the dynamic configuration.
+	disabledRecheckInterval = time.Minute
+)
+
+// Sampler periodically samples the resource usage of all running tasks, and
+// records the peak usage of each container in the status of their JobConfigs.
+// The total CPU time and peak memory of each task is also accumulated in
+// annotations on the task's Pod, which will be reflected in the TaskStatus.
+type Sampler struct {
+	*Context
+	mu sync.Mutex
+}
+
+func NewSampler(ctrlContext *Context) *Sampler {
+	return &Sampler{
+		Context: ctrlContext,
+	}
+}
+
+func (w *Sampler) WorkerName() string {
+	return fmt.Sprintf("%v.Sampler", controllerName)
+}
+
+func (w *Sampler) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+func (w *Sampler) run(ctx context.Context) {
+	for {
+		interval, err := w.getSampleInterval()
+		if err != nil {
+			klog.ErrorS(err, "resourceusagecontroller: cannot load controller configuration",
+				"worker", w.WorkerName(),
+			)
+		}
+
+		if interval > 0 {
+			if err := w.Sample(ctx); err != nil {
+				klog.ErrorS(err, "resourceusagecontroller: cannot sample resource usage",
+					"worker", w.WorkerName(),
+				)
+			}
+		} else {
+			interval = disabledRecheckInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Sample runs a single iteration of sampling the resource usage of all running
+// tasks.
+func (w *Sampler) Sample(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	rjcs, err := w.jobconfigInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return errors.Wrapf(err, "cannot list job configs")
+	}
+
+	for _, rjc := range rjcs {
+		if err := w.sampleJobConfig(ctx, rjc); err != nil {
+			klog.ErrorS(err, "resourceusagecontroller: cannot sample resource usage for job config",
+				"worker", w.WorkerName(),
+				"namespace", rjc.GetNamespace(),
+				"name", rjc.GetName(),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (w *Sampler) sampleJobConfig(ctx context.Context, rjc *execution.JobConfig) error {
+	selector := labels.SelectorFromSet(jobconfig.LabelJobsForJobConfig(rjc))
+	rjs, err := w.jobInformer.Lister().Jobs(rjc.GetNamespace()).List(selector)
+	if err != nil {
+		return errors.Wrapf(err, "cannot list jobs")
+	}
+
+	// Compute the peak usage of each container across all running tasks.
+	observed := make(map[string]corev1.ResourceList)
+	for _, rj := range rjs {
+		for _, taskRef := range rj.Status.Tasks {
+			if taskRef.RunningTimestamp.IsZero() || !taskRef.FinishTimestamp.IsZero() {
+				continue
+			}
+			metrics, err := w.metrics.GetPodMetrics(ctx, rj.GetNamespace(), taskRef.Name)
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return errors.Wrapf(err, "cannot get metrics for task %v", taskRef.Name)
+			}
+			for _, container := range metrics.Containers {
+				observed[container.Name] = maxResourceList(observed[container.Name], container.Usage)
+			}
+			if err := w.recordTaskUsage(ctx, rj, taskRef, metrics); err != nil {
+				klog.ErrorS(err, "resourceusagecontroller: cannot record resource usage for task",
+					"worker", w.WorkerName(),
+					"namespace", rj.GetNamespace(),
+					"name", rj.GetName(),
+					"task", taskRef.Name,
+				)
+			}
+		}
+	}
+
+	if len(observed) == 0 {
+		return nil
+	}
+
+	// Only apply the resource usage, which is the only field of the status that
+	// is managed by this controller.
+	status := map[string]interface{}{
+		"resourceUsage": jobconfig.MergeResourceUsage(rjc.Status.ResourceUsage, observed, *ktime.Now()),
+	}
+	patch, err := controllerutil.NewStatusApplyPatch(execution.GVKJobConfig, rjc.GetNamespace(), rjc.GetName(), status)
+	if err != nil {
+		return errors.Wrapf(err, "cannot create status patch")
+	}
+	if _, err := w.Clientsets().Furiko().ExecutionV1alpha1().JobConfigs(rjc.GetNamespace()).Patch(ctx,
+		rjc.GetName(), types.ApplyPatchType, patch, controllerutil.NewApplyPatchOptions(fieldManager), "status",
+	); err != nil {
+		return errors.Wrapf(err, "cannot update job config")
+	}
+
+	klog.V(3).InfoS("resourceusagecontroller: updated resource usage for job config",
+		"worker", w.WorkerName(),
+		"namespace", rjc.GetNamespace(),
+		"name", rjc.GetName(),
+	)
+
+	return nil
+}
+
+// recordTaskUsage accumulates the sampled resource usage of a task into
+// annotations on its Pod. CPU time is integrated over the duration since the
+// previous sample, or since the task started running if it was never sampled.
+func (w *Sampler) recordTaskUsage(
+	ctx context.Context, rj *execution.Job, taskRef execution.TaskRef, metrics *PodMetrics,
+) error {
+	pod, err := w.podInformer.Lister().Pods(rj.GetNamespace()).Get(taskRef.Name)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot get pod")
+	}
+
+	now := ktime.Now()
+	lastSampled := taskRef.RunningTimestamp.Time
+	if val, ok := pod.Annotations[podtaskexecutor.LabelKeyTaskResourceUsageSampleTime]; ok {
+		if ts, err := time.Parse(time.RFC3339, val); err == nil {
+			lastSampled = ts
+		}
+	}
+	elapsed := now.Sub(lastSampled)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	total := sumContainerUsage(metrics.Containers)
+
+	cpuMilliSeconds := total.Cpu().MilliValue() * elapsed.Milliseconds() / 1000
+	if val, ok := pod.Annotations[podtaskexecutor.LabelKeyTaskCPUSeconds]; ok {
+		if quantity, err := resource.ParseQuantity(val); err == nil {
+			cpuMilliSeconds += quantity.MilliValue()
+		}
+	}
+
+	peakMemory := total.Memory().DeepCopy()
+	if val, ok := pod.Annotations[podtaskexecutor.LabelKeyTaskPeakMemory]; ok {
+		if quantity, err := resource.ParseQuantity(val); err == nil && quantity.Cmp(peakMemory) > 0 {
+			peakMemory = quantity
+		}
+	}
+
+	newPod := pod.DeepCopy()
+	meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyTaskCPUSeconds,
+		resource.NewMilliQuantity(cpuMilliSeconds, resource.DecimalSI).String())
+	meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyTaskPeakMemory, peakMemory.String())
+	meta.SetAnnotation(newPod, podtaskexecutor.LabelKeyTaskResourceUsageSampleTime, now.Format(time.RFC3339))
+	if _, err := w.Clientsets().Kubernetes().CoreV1().Pods(newPod.GetNamespace()).
+		Update(ctx, newPod, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "cannot update pod")
+	}
+
+	return nil
+}
+
+func (w *Sampler) getSampleInterval() (time.Duration, error) {
+	cfg, err := w.Configs().JobConfigs()
+	if err != nil {
+		return 0, err
+	}
+	if cfg.ResourceUsageSampleIntervalSeconds == nil {
+		return 0, nil
+	}
+	return time.Duration(*cfg.ResourceUsageSampleIntervalSeconds) * time.Second, nil
+}
+
+func sumContainerUsage(containers []ContainerMetrics) corev1.ResourceList {
+	total := corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewMilliQuantity(0, resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(0, resource.BinarySI),
+	}
+	for _, container := range containers {
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if quantity, ok := container.Usage[name]; ok {
+				sum := total[name]
+				sum.Add(quantity)
+				total[name] = sum
+			}
+		}
+	}
+	return total
+}
+
+func maxResourceList(a, b corev1.ResourceList) corev1.ResourceList {
+	result := a.DeepCopy()
+	if result == nil {
+		result = corev1.ResourceList{}
+	}
+	for name, quantity := range b {
+		if existing, ok := result[name]; !ok || quantity.Cmp(existing) > 0 {
+			result[name] = quantity.DeepCopy()
+		}
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resourceusagecontroller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/resourceusagecontroller"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	namespace    = "test"
+	jobConfigUID = "0ed1bc76-07ca-4cf7-9a47-a0cc4aec48b9"
+)
+
+var (
+	fakeJobConfig = &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-config",
+			Namespace: namespace,
+			UID:       jobConfigUID,
+		},
+		Status: execution.JobConfigStatus{
+			ResourceUsage: []execution.ContainerResourceUsage{
+				{
+					Name:    "container",
+					CPU:     quantityPtr("500m"),
+					Memory:  quantityPtr("64Mi"),
+					Samples: 1,
+				},
+			},
+		},
+	}
+
+	fakeJob = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job",
+			Namespace: namespace,
+			Labels: map[string]string{
+				jobconfig.LabelKeyJobConfigUID: jobConfigUID,
+			},
+		},
+		Status: execution.JobStatus{
+			Tasks: []execution.TaskRef{
+				{
+					Name:             "job.1",
+					RunningTimestamp: testutils.Mkmtimep("2021-02-09T04:06:01Z"),
+					FinishTimestamp:  testutils.Mkmtimep("2021-02-09T04:06:18Z"),
+				},
+				{
+					Name:             "job.2",
+					RunningTimestamp: testutils.Mkmtimep("2021-02-09T04:07:01Z"),
+				},
+				{
+					Name:             "job.3",
+					RunningTimestamp: testutils.Mkmtimep("2021-02-09T04:08:01Z"),
+				},
+			},
+		},
+	}
+
+	fakePod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job.2",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskPeakMemory: "64Mi",
+			},
+		},
+	}
+)
+
+type fakeMetricsGetter map[string]*resourceusagecontroller.PodMetrics
+
+func (f fakeMetricsGetter) GetPodMetrics(
+	_ context.Context, _, name string,
+) (*resourceusagecontroller.PodMetrics, error) {
+	metrics, ok := f[name]
+	if !ok {
+		return nil, kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, name)
+	}
+	return metrics, nil
+}
+
+func TestSampler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	metrics := fakeMetricsGetter{
+		"job.1": {
+			Containers: []resourceusagecontroller.ContainerMetrics{
+				{Name: "container", Usage: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+			},
+		},
+		"job.2": {
+			Containers: []resourceusagecontroller.ContainerMetrics{
+				{
+					Name: "container",
+					Usage: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	c := mock.NewContext()
+	ctrlContext := resourceusagecontroller.NewContext(c, metrics)
+	sampler := resourceusagecontroller.NewSampler(ctrlContext)
+	client := c.MockClientsets().Furiko().ExecutionV1alpha1()
+
+	assert.NoError(t, c.Start(ctx))
+	_, err := client.JobConfigs(namespace).Create(ctx, fakeJobConfig, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = client.Jobs(namespace).Create(ctx, fakeJob, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = c.MockClientsets().Kubernetes().CoreV1().Pods(namespace).Create(ctx, fakePod, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	if !cache.WaitForCacheSync(ctx.Done(), ctrlContext.HasSynced...) {
+		assert.FailNow(t, "caches not synced")
+	}
+
+	// Wait for objects to be observed in the cache.
+	assert.Eventually(t, func() bool {
+		rjcs, _ := c.Informers().Furiko().Execution().V1alpha1().JobConfigs().Lister().List(labels.Everything())
+		rjs, _ := c.Informers().Furiko().Execution().V1alpha1().Jobs().Lister().List(labels.Everything())
+		pods, _ := c.Informers().Kubernetes().Core().V1().Pods().Lister().List(labels.Everything())
+		return len(rjcs) == 1 && len(rjs) == 1 && len(pods) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	ktime.Clock = clock.NewFakeClock(testutils.Mktime("2021-02-09T04:08:01Z"))
+	assert.NoError(t, sampler.Sample(ctx))
+
+	rjc, err := client.JobConfigs(namespace).Get(ctx, fakeJobConfig.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, rjc.Status.ResourceUsage, 1) {
+		usage := rjc.Status.ResourceUsage[0]
+		assert.Equal(t, "container", usage.Name)
+		assert.Equal(t, "500m", usage.CPU.String())
+		assert.Equal(t, "128Mi", usage.Memory.String())
+		assert.Equal(t, int64(2), usage.Samples)
+	}
+
+	// Resource usage of the task should be recorded on the Pod.
+	pod, err := c.MockClientsets().Kubernetes().CoreV1().Pods(namespace).Get(ctx, fakePod.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "6", pod.Annotations[podtaskexecutor.LabelKeyTaskCPUSeconds])
+	assert.Equal(t, "128Mi", pod.Annotations[podtaskexecutor.LabelKeyTaskPeakMemory])
+	assert.Equal(t, "2021-02-09T04:08:01Z", pod.Annotations[podtaskexecutor.LabelKeyTaskResourceUsageSampleTime])
+}
+
+func quantityPtr(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+	return &quantity
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triggercontroller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+)
+
+var (
+	// ReceiveErrorBackoff is the duration to wait after failing to receive
+	// messages before trying again.
+	ReceiveErrorBackoff = 5 * time.Second
+)
+
+// Consumer receives messages from a single trigger and creates Jobs from them.
+// Messages are only deleted after a Job is successfully created, which gives
+// at-least-once semantics. Since the Job's name is derived from the message ID,
+// a message that is received more than once still creates a single Job.
+type Consumer struct {
+	controllercontext.Context
+	trigger *trigger.Trigger
+}
+
+func NewConsumer(ctrlContext controllercontext.Context, t *trigger.Trigger) *Consumer {
+	return &Consumer{
+		Context: ctrlContext,
+		trigger: t,
+	}
+}
+
+// Run receives and processes messages until the context is canceled.
+func (c *Consumer) Run(ctx context.Context) {
+	klog.InfoS("triggercontroller: starting consumer", "trigger", c.trigger.Name)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for ctx.Err() == nil {
+			if err := c.Poll(ctx); err != nil {
+				if ctx.Err() == nil {
+					klog.ErrorS(err, "triggercontroller: cannot receive messages", "trigger", c.trigger.Name)
+				}
+				return
+			}
+		}
+	}, ReceiveErrorBackoff)
+	klog.InfoS("triggercontroller: stopped consumer", "trigger", c.trigger.Name)
+}
+
+// Poll receives a single batch of messages and processes them.
+func (c *Consumer) Poll(ctx context.Context) error {
+	msgs, err := c.trigger.Source.Receive(ctx)
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		c.process(ctx, msg)
+	}
+	return nil
+}
+
+func (c *Consumer) process(ctx context.Context, msg *trigger.Message) {
+	name, source := c.trigger.Name, c.trigger.Source
+
+	err := c.createJob(ctx, msg)
+	switch {
+	case err == nil:
+		if err := source.Delete(ctx, msg); err != nil {
+			klog.ErrorS(err, "triggercontroller: cannot delete message", "trigger", name, "message", msg.ID)
+		}
+
+	case isPermanentError(err) || msg.ReceiveCount >= c.trigger.MaxReceiveCount:
+		klog.ErrorS(err, "triggercontroller: cannot create job, moving message to dead-letter queue",
+			"trigger", name,
+			"message", msg.ID,
+			"receiveCount", msg.ReceiveCount,
+		)
+		if err := source.DeadLetter(ctx, msg); err != nil {
+			klog.ErrorS(err, "triggercontroller: cannot dead-letter message", "trigger", name, "message", msg.ID)
+		}
+
+	default:
+		// Leave the message in the queue to be received again.
+		klog.ErrorS(err, "triggercontroller: cannot create job, will retry",
+			"trigger", name,
+			"message", msg.ID,
+			"receiveCount", msg.ReceiveCount,
+		)
+	}
+}
+
+func (c *Consumer) createJob(ctx context.Context, msg *trigger.Message) error {
+	rj, err := c.trigger.NewJob(msg)
+	if err != nil {
+		return err
+	}
+
+	created, err := c.Clientsets().Furiko().ExecutionV1alpha1().Jobs(rj.Namespace).
+		Create(ctx, rj, metav1.CreateOptions{})
+	if kerrors.IsAlreadyExists(err) {
+		// The message was already processed before, but was not deleted.
+		klog.V(4).InfoS("triggercontroller: job already exists",
+			"trigger", c.trigger.Name,
+			"message", msg.ID,
+			"namespace", rj.Namespace,
+			"name", rj.Name,
+		)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	klog.InfoS("triggercontroller: created job",
+		"trigger", c.trigger.Name,
+		"message", msg.ID,
+		"namespace", created.Namespace,
+		"name", created.Name,
+	)
+	return nil
+}
+
+// isPermanentError returns true if creating the Job will never succeed for the
+// message.
+func isPermanentError(err error) bool {
+	var invalidErr *trigger.InvalidMessageError
+	return errors.As(err, &invalidErr) ||
+		kerrors.IsInvalid(err) ||
+		kerrors.IsBadRequest(err) ||
+		kerrors.IsForbidden(err)
+}
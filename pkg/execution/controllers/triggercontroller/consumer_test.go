@@ -0,0 +1,160 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triggercontroller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/furiko-io/furiko/pkg/execution/controllers/triggercontroller"
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+)
+
+type fakeSource struct {
+	msgs       []*trigger.Message
+	err        error
+	deleted    []string
+	deadLetter []string
+}
+
+func (s *fakeSource) Receive(_ context.Context) ([]*trigger.Message, error) {
+	msgs := s.msgs
+	s.msgs = nil
+	return msgs, s.err
+}
+
+func (s *fakeSource) Delete(_ context.Context, msg *trigger.Message) error {
+	s.deleted = append(s.deleted, msg.ID)
+	return nil
+}
+
+func (s *fakeSource) DeadLetter(_ context.Context, msg *trigger.Message) error {
+	s.deadLetter = append(s.deadLetter, msg.ID)
+	return nil
+}
+
+func TestConsumer_Poll(t *testing.T) {
+	tests := []struct {
+		name           string
+		msgs           []*trigger.Message
+		createErr      error
+		wantJobs       int
+		wantDeleted    []string
+		wantDeadLetter []string
+	}{
+		{
+			name: "create jobs and delete messages",
+			msgs: []*trigger.Message{
+				{ID: "msg1", Body: `{"region": "us-east-1"}`, ReceiveCount: 1},
+				{ID: "msg2", Body: `{"region": "us-west-2"}`, ReceiveCount: 1},
+			},
+			wantJobs:    2,
+			wantDeleted: []string{"msg1", "msg2"},
+		},
+		{
+			name: "duplicate message creates single job",
+			msgs: []*trigger.Message{
+				{ID: "msg1", Body: `{}`, ReceiveCount: 1},
+				{ID: "msg1", Body: `{}`, ReceiveCount: 2},
+			},
+			wantJobs:    1,
+			wantDeleted: []string{"msg1", "msg1"},
+		},
+		{
+			name: "dead-letter invalid message",
+			msgs: []*trigger.Message{
+				{ID: "msg1", Body: "not json", ReceiveCount: 1},
+			},
+			wantDeadLetter: []string{"msg1"},
+		},
+		{
+			name: "dead-letter rejected job",
+			msgs: []*trigger.Message{
+				{ID: "msg1", Body: `{}`, ReceiveCount: 1},
+			},
+			createErr:      kerrors.NewBadRequest("rejected"),
+			wantDeadLetter: []string{"msg1"},
+		},
+		{
+			name: "retry transient error",
+			msgs: []*trigger.Message{
+				{ID: "msg1", Body: `{}`, ReceiveCount: 1},
+			},
+			createErr: kerrors.NewServiceUnavailable("unavailable"),
+		},
+		{
+			name: "dead-letter after max receive count",
+			msgs: []*trigger.Message{
+				{ID: "msg1", Body: `{}`, ReceiveCount: 3},
+			},
+			createErr:      kerrors.NewServiceUnavailable("unavailable"),
+			wantDeadLetter: []string{"msg1"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+			defer cancel()
+
+			c := mock.NewContext()
+			client := c.MockClientsets().FurikoMock()
+			if tt.createErr != nil {
+				client.PrependReactor("create", "jobs",
+					func(action ktesting.Action) (bool, runtime.Object, error) {
+						return true, nil, tt.createErr
+					})
+			}
+			assert.NoError(t, c.Start(ctx))
+
+			source := &fakeSource{msgs: tt.msgs}
+			consumer := triggercontroller.NewConsumer(c, &trigger.Trigger{
+				Name:            "trigger",
+				Namespace:       "test",
+				JobConfigName:   "jobconfig",
+				OptionValues:    map[string]string{"region": "region"},
+				MaxReceiveCount: 3,
+				Source:          source,
+			})
+			assert.NoError(t, consumer.Poll(ctx))
+
+			jobs, err := client.ExecutionV1alpha1().Jobs("test").List(ctx, metav1.ListOptions{})
+			assert.NoError(t, err)
+			assert.Len(t, jobs.Items, tt.wantJobs)
+			assert.Equal(t, tt.wantDeleted, source.deleted)
+			assert.Equal(t, tt.wantDeadLetter, source.deadLetter)
+		})
+	}
+}
+
+func TestConsumer_Poll_ReceiveError(t *testing.T) {
+	c := mock.NewContext()
+	source := &fakeSource{err: kerrors.NewNotFound(schema.GroupResource{Resource: "queue"}, "queue")}
+	consumer := triggercontroller.NewConsumer(c, &trigger.Trigger{Name: "trigger", Source: source})
+	err := consumer.Poll(context.Background())
+	assert.True(t, errors.Is(err, source.err))
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triggercontroller
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+)
+
+// Controller is responsible for consuming messages from the configured
+// triggers and creating a Job for each message.
+type Controller struct {
+	controllercontext.Context
+	ctx          context.Context
+	terminate    context.CancelFunc
+	healthStatus uint64
+	consumers    []*Consumer
+	wg           sync.WaitGroup
+}
+
+func NewController(ctrlContext controllercontext.Context, triggers []*trigger.Trigger) (*Controller, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrl := &Controller{
+		Context:   ctrlContext,
+		ctx:       ctx,
+		terminate: cancel,
+	}
+
+	for _, t := range triggers {
+		ctrl.consumers = append(ctrl.consumers, NewConsumer(ctrlContext, t))
+	}
+
+	return ctrl, nil
+}
+
+func (c *Controller) Run(ctx context.Context) error {
+	defer utilruntime.HandleCrash()
+	klog.InfoS("triggercontroller: starting controller", "triggers", len(c.consumers))
+
+	for _, consumer := range c.consumers {
+		consumer := consumer
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			consumer.Run(c.ctx)
+		}()
+	}
+
+	atomic.StoreUint64(&c.healthStatus, 1)
+	klog.InfoS("triggercontroller: started controller")
+
+	return nil
+}
+
+func (c *Controller) Shutdown(ctx context.Context) {
+	klog.InfoS("triggercontroller: shutting down")
+
+	// Messages that are being processed when the controller is terminated are not
+	// deleted, and will be received again after their visibility timeout.
+	c.terminate()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		klog.ErrorS(ctx.Err(), "triggercontroller: cannot stop consumers before deadline")
+	}
+
+	// Close sources that hold connections open.
+	for _, consumer := range c.consumers {
+		if closer, ok := consumer.trigger.Source.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				klog.ErrorS(err, "triggercontroller: cannot close source", "trigger", consumer.trigger.Name)
+			}
+		}
+	}
+
+	klog.InfoS("triggercontroller: stopped controller")
+}
+
+func (c *Controller) GetHealth() controllermanager.HealthStatus {
+	return controllermanager.HealthStatus{
+		Name:         controllerName,
+		Healthy:      atomic.LoadUint64(&c.healthStatus) == 1,
+		CachesSynced: true,
+	}
+}
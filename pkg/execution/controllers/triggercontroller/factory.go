@@ -0,0 +1,56 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triggercontroller
+
+import (
+	"github.com/pkg/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+)
+
+const (
+	controllerName = "TriggerController"
+)
+
+type Factory struct {
+	specs []configv1alpha1.TriggerSpec
+}
+
+// NewFactory returns a new Factory that consumes messages from the triggers in
+// the given specs.
+func NewFactory(specs []configv1alpha1.TriggerSpec) *Factory {
+	return &Factory{specs: specs}
+}
+
+func (f *Factory) Name() string {
+	return controllerName
+}
+
+func (f *Factory) New(
+	ctrlContext controllercontext.Context,
+	_ *configv1alpha1.ExecutionControllerConcurrencySpec,
+	_ *configv1alpha1.ExecutionControllerRateLimitersSpec,
+) (controllermanager.Controller, error) {
+	triggers, err := trigger.NewTriggers(f.specs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot set up triggers")
+	}
+	return NewController(ctrlContext, triggers)
+}
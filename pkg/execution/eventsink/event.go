@@ -0,0 +1,212 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	// KindJob is the kind of Events for state transitions of Jobs.
+	KindJob = execution.KindJob
+
+	// KindTask is the kind of Events for state transitions of a Job's tasks.
+	KindTask = "Task"
+
+	// KindJobConfig is the kind of Events for state transitions of JobConfigs.
+	KindJobConfig = execution.KindJobConfig
+
+	// KindScheduleMissed is the kind of Events for a JobConfig's schedule that was
+	// skipped.
+	KindScheduleMissed = "ScheduleMissed"
+)
+
+// Event describes a single state transition of a Job, one of its tasks, or a
+// JobConfig.
+type Event struct {
+	// Time at which the transition was observed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Kind of the transition, such as Job or Task.
+	Kind string `json:"kind"`
+
+	Namespace string    `json:"namespace"`
+	JobName   string    `json:"jobName"`
+	JobUID    types.UID `json:"jobUID"`
+
+	// Name of the JobConfig that the Job belongs to, if any.
+	JobConfig string `json:"jobConfig,omitempty"`
+
+	// Labels of the Job, only set for Job transitions.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Name and retry index of the task, only set for Task transitions.
+	TaskName   string `json:"taskName,omitempty"`
+	RetryIndex int64  `json:"retryIndex,omitempty"`
+
+	// User or controller that caused the transition, if known.
+	Actor string `json:"actor,omitempty"`
+
+	// Phase of the Job or state of the task or JobConfig before and after the
+	// transition.
+	From string `json:"from,omitempty"`
+	To   string `json:"to"`
+
+	Result  string `json:"result,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+
+	CreationTimestamp time.Time  `json:"creationTimestamp"`
+	StartTime         *time.Time `json:"startTime,omitempty"`
+	FinishTime        *time.Time `json:"finishTime,omitempty"`
+
+	// Number of tasks created for the Job so far, only set for Job transitions.
+	CreatedTasks int64 `json:"createdTasks,omitempty"`
+
+	// Time of the schedule that was skipped, only set for ScheduleMissed Events.
+	ScheduleTime *time.Time `json:"scheduleTime,omitempty"`
+
+	// Object is the Job or JobConfig after the transition, which allows sinks to
+	// read fields that are not part of the Event. It is not serialized.
+	Object runtime.Object `json:"-"`
+}
+
+// Key returns the partitioning key of the Event, which is the namespaced name
+// of the JobConfig, or the namespaced name of the Job if it does not belong to
+// any JobConfig.
+func (e *Event) Key() string {
+	if e.JobConfig != "" {
+		return fmt.Sprintf("%v/%v", e.Namespace, e.JobConfig)
+	}
+	return fmt.Sprintf("%v/%v", e.Namespace, e.JobName)
+}
+
+// NewJobEvents returns Events for all state transitions of the Job and its
+// tasks from rj to newRj.
+func NewJobEvents(rj, newRj *execution.Job) []*Event {
+	var events []*Event
+	now := ktime.Now().Time
+
+	if rj.Status.Phase != newRj.Status.Phase {
+		event := newEvent(newRj, KindJob, now)
+		event.From = string(rj.Status.Phase)
+		event.To = string(newRj.Status.Phase)
+		event.Labels = newRj.GetLabels()
+		event.CreatedTasks = newRj.Status.CreatedTasks
+		if startTime := newRj.Status.StartTime; !startTime.IsZero() {
+			event.StartTime = &startTime.Time
+		}
+		if finished := newRj.Status.Condition.Finished; finished != nil {
+			event.Result = string(finished.Result)
+			event.Reason = finished.Reason
+			event.Message = finished.Message
+			event.FinishTime = &finished.FinishedAt.Time
+		}
+		events = append(events, event)
+	}
+
+	type taskKey struct {
+		name       string
+		retryIndex int64
+	}
+	prevStates := make(map[taskKey]execution.TaskState, len(rj.Status.Tasks))
+	for _, taskRef := range rj.Status.Tasks {
+		prevStates[taskKey{taskRef.Name, taskRef.RetryIndex}] = taskRef.Status.State
+	}
+	for _, taskRef := range newRj.Status.Tasks {
+		from := prevStates[taskKey{taskRef.Name, taskRef.RetryIndex}]
+		if from == taskRef.Status.State {
+			continue
+		}
+		event := newEvent(newRj, KindTask, now)
+		event.TaskName = taskRef.Name
+		event.RetryIndex = taskRef.RetryIndex
+		event.From = string(from)
+		event.To = string(taskRef.Status.State)
+		event.Reason = taskRef.Status.Reason
+		event.Message = taskRef.Status.Message
+		event.CreationTimestamp = taskRef.CreationTimestamp.Time
+		if result := taskRef.Status.Result; result != nil {
+			event.Result = string(*result)
+		}
+		if runningTime := taskRef.RunningTimestamp; !runningTime.IsZero() {
+			event.StartTime = &runningTime.Time
+		}
+		if finishTime := taskRef.FinishTimestamp; !finishTime.IsZero() {
+			event.FinishTime = &finishTime.Time
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// NewJobConfigEvents returns Events for the state transition of the JobConfig
+// from rjc to newRjc, if any.
+func NewJobConfigEvents(rjc, newRjc *execution.JobConfig) []*Event {
+	if rjc.Status.State == newRjc.Status.State {
+		return nil
+	}
+	return []*Event{
+		{
+			Timestamp:         ktime.Now().Time,
+			Kind:              KindJobConfig,
+			Namespace:         newRjc.GetNamespace(),
+			JobConfig:         newRjc.GetName(),
+			From:              string(rjc.Status.State),
+			To:                string(newRjc.Status.State),
+			CreationTimestamp: newRjc.GetCreationTimestamp().Time,
+			Object:            newRjc,
+		},
+	}
+}
+
+// NewScheduleMissedEvent returns an Event for a JobConfig's schedule that was
+// skipped.
+func NewScheduleMissedEvent(rjc *execution.JobConfig, scheduleTime time.Time, message string) *Event {
+	return &Event{
+		Timestamp:         ktime.Now().Time,
+		Kind:              KindScheduleMissed,
+		Namespace:         rjc.GetNamespace(),
+		JobConfig:         rjc.GetName(),
+		Message:           message,
+		CreationTimestamp: rjc.GetCreationTimestamp().Time,
+		ScheduleTime:      &scheduleTime,
+		Object:            rjc,
+	}
+}
+
+func newEvent(rj *execution.Job, kind string, now time.Time) *Event {
+	return &Event{
+		Timestamp:         now,
+		Kind:              kind,
+		Namespace:         rj.GetNamespace(),
+		JobName:           rj.GetName(),
+		JobUID:            rj.GetUID(),
+		JobConfig:         jobutil.GetJobConfigName(rj),
+		CreationTimestamp: rj.GetCreationTimestamp().Time,
+		Object:            rj,
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+var (
+	now        = testutils.Mkmtime("2022-04-01T04:02:00Z")
+	createTime = testutils.Mkmtime("2022-04-01T04:00:00Z")
+	startTime  = testutils.Mkmtimep("2022-04-01T04:00:01Z")
+	finishTime = testutils.Mkmtimep("2022-04-01T04:01:00Z")
+)
+
+func newJob(phase execution.JobPhase, tasks ...execution.TaskRef) *execution.Job {
+	return &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "job",
+			UID:               "uid",
+			CreationTimestamp: createTime,
+			Labels: map[string]string{
+				"team": "data",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: execution.GroupVersion.String(),
+					Kind:       execution.KindJobConfig,
+					Name:       "jobconfig",
+					Controller: pointer.Bool(true),
+				},
+			},
+		},
+		Status: execution.JobStatus{
+			Phase:        phase,
+			Tasks:        tasks,
+			CreatedTasks: int64(len(tasks)),
+		},
+	}
+}
+
+func newTaskRef(state execution.TaskState) execution.TaskRef {
+	taskRef := execution.TaskRef{
+		Name:              "job.1",
+		RetryIndex:        1,
+		CreationTimestamp: createTime,
+		Status: execution.TaskStatus{
+			State: state,
+		},
+	}
+	if state == execution.TaskRunning || state == execution.TaskSuccess {
+		taskRef.RunningTimestamp = startTime
+	}
+	if state == execution.TaskSuccess {
+		taskRef.FinishTimestamp = finishTime
+		taskRef.Status.Result = jobutil.GetResultPtr(execution.JobResultSuccess)
+	}
+	return taskRef
+}
+
+func TestNewJobEvents(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(now.Time)
+
+	tests := []struct {
+		name  string
+		rj    *execution.Job
+		newRj *execution.Job
+		want  []*eventsink.Event
+	}{
+		{
+			name:  "no transitions",
+			rj:    newJob(execution.JobRunning, newTaskRef(execution.TaskRunning)),
+			newRj: newJob(execution.JobRunning, newTaskRef(execution.TaskRunning)),
+		},
+		{
+			name:  "job and new task",
+			rj:    newJob(execution.JobStarting),
+			newRj: newJob(execution.JobPending, newTaskRef(execution.TaskStarting)),
+			want: []*eventsink.Event{
+				{
+					Timestamp:         now.Time,
+					Kind:              eventsink.KindJob,
+					Namespace:         "default",
+					JobName:           "job",
+					JobUID:            "uid",
+					JobConfig:         "jobconfig",
+					Labels:            map[string]string{"team": "data"},
+					From:              string(execution.JobStarting),
+					To:                string(execution.JobPending),
+					CreationTimestamp: createTime.Time,
+					CreatedTasks:      1,
+				},
+				{
+					Timestamp:         now.Time,
+					Kind:              eventsink.KindTask,
+					Namespace:         "default",
+					JobName:           "job",
+					JobUID:            "uid",
+					JobConfig:         "jobconfig",
+					TaskName:          "job.1",
+					RetryIndex:        1,
+					To:                string(execution.TaskStarting),
+					CreationTimestamp: createTime.Time,
+				},
+			},
+		},
+		{
+			name:  "task finished",
+			rj:    newJob(execution.JobRunning, newTaskRef(execution.TaskRunning)),
+			newRj: newJob(execution.JobRunning, newTaskRef(execution.TaskSuccess)),
+			want: []*eventsink.Event{
+				{
+					Timestamp:         now.Time,
+					Kind:              eventsink.KindTask,
+					Namespace:         "default",
+					JobName:           "job",
+					JobUID:            "uid",
+					JobConfig:         "jobconfig",
+					TaskName:          "job.1",
+					RetryIndex:        1,
+					From:              string(execution.TaskRunning),
+					To:                string(execution.TaskSuccess),
+					Result:            string(execution.JobResultSuccess),
+					CreationTimestamp: createTime.Time,
+					StartTime:         &startTime.Time,
+					FinishTime:        &finishTime.Time,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := eventsink.NewJobEvents(tt.rj, tt.newRj)
+			for _, event := range got {
+				if event.Object != tt.newRj {
+					t.Errorf("NewJobEvents() did not set Object to the new Job")
+				}
+			}
+			opts := cmpopts.IgnoreFields(eventsink.Event{}, "Object")
+			if !cmp.Equal(tt.want, got, opts) {
+				t.Errorf("NewJobEvents() not equal\ndiff = %v", cmp.Diff(tt.want, got, opts))
+			}
+		})
+	}
+}
+
+func TestEvent_Key(t *testing.T) {
+	event := &eventsink.Event{Namespace: "default", JobName: "job", JobConfig: "jobconfig"}
+	if key := event.Key(); key != "default/jobconfig" {
+		t.Errorf("Key() = %v, want default/jobconfig", key)
+	}
+	event.JobConfig = ""
+	if key := event.Key(); key != "default/job" {
+		t.Errorf("Key() = %v, want default/job", key)
+	}
+}
+
+func TestNewScheduleMissedEvent(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(now.Time)
+	rjc := &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "jobconfig",
+			CreationTimestamp: createTime,
+		},
+	}
+	event := eventsink.NewScheduleMissedEvent(rjc, startTime.Time, "missed too many schedules")
+	want := &eventsink.Event{
+		Timestamp:         now.Time,
+		Kind:              eventsink.KindScheduleMissed,
+		Namespace:         "default",
+		JobConfig:         "jobconfig",
+		Message:           "missed too many schedules",
+		CreationTimestamp: createTime.Time,
+		ScheduleTime:      &startTime.Time,
+		Object:            rjc,
+	}
+	if !cmp.Equal(want, event) {
+		t.Errorf("NewScheduleMissedEvent() not equal\ndiff = %v", cmp.Diff(want, event))
+	}
+	if key := event.Key(); key != "default/jobconfig" {
+		t.Errorf("Key() = %v, want %v", key, "default/jobconfig")
+	}
+}
+
+func TestNewJobConfigEvents(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(now.Time)
+	newJobConfig := func(state execution.JobConfigState) *execution.JobConfig {
+		return &execution.JobConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              "jobconfig",
+				CreationTimestamp: createTime,
+			},
+			Status: execution.JobConfigStatus{
+				State: state,
+			},
+		}
+	}
+
+	ready := newJobConfig(execution.JobConfigReady)
+	executing := newJobConfig(execution.JobConfigExecuting)
+	if events := eventsink.NewJobConfigEvents(ready, ready); len(events) != 0 {
+		t.Errorf("NewJobConfigEvents() = %v, want no events", events)
+	}
+
+	events := eventsink.NewJobConfigEvents(ready, executing)
+	want := []*eventsink.Event{
+		{
+			Timestamp:         now.Time,
+			Kind:              eventsink.KindJobConfig,
+			Namespace:         "default",
+			JobConfig:         "jobconfig",
+			From:              "Ready",
+			To:                "Executing",
+			CreationTimestamp: createTime.Time,
+			Object:            executing,
+		},
+	}
+	if !cmp.Equal(want, events) {
+		t.Errorf("NewJobConfigEvents() not equal\ndiff = %v", cmp.Diff(want, events))
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	grafanaSinkName = "Grafana"
+
+	defaultGrafanaTimeout = 10 * time.Second
+
+	grafanaTag = "furiko"
+)
+
+// GrafanaSink is a Sink that posts annotations to Grafana when Jobs start
+// running and when they finish. All other Events are ignored.
+type GrafanaSink struct {
+	url          string
+	apiTokenFile string
+	dashboardUID string
+	tags         []string
+	timeout      time.Duration
+	client       *http.Client
+}
+
+var _ Sink = (*GrafanaSink)(nil)
+
+// GrafanaAnnotation is the request body to create an annotation using the
+// Grafana HTTP API.
+type GrafanaAnnotation struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	Time         int64    `json:"time"`
+	TimeEnd      int64    `json:"timeEnd,omitempty"`
+	Tags         []string `json:"tags"`
+	Text         string   `json:"text"`
+}
+
+// NewGrafanaSink returns a new GrafanaSink for the given spec.
+func NewGrafanaSink(spec *configv1alpha1.GrafanaSinkSpec) (*GrafanaSink, error) {
+	if spec.URL == "" {
+		return nil, errors.New("url must be specified")
+	}
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid url, must be a http or https URL: %v", spec.URL)
+	}
+
+	timeout := defaultGrafanaTimeout
+	if spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.TimeoutSeconds) * time.Second
+	}
+
+	return &GrafanaSink{
+		url:          strings.TrimSuffix(spec.URL, "/") + "/api/annotations",
+		apiTokenFile: spec.APITokenFile,
+		dashboardUID: spec.DashboardUID,
+		tags:         spec.Tags,
+		timeout:      timeout,
+		client:       &http.Client{},
+	}, nil
+}
+
+func (s *GrafanaSink) Name() string {
+	return grafanaSinkName
+}
+
+func (s *GrafanaSink) Write(ctx context.Context, events []*Event) error {
+	var errs []error
+	for _, event := range events {
+		annotation := s.newAnnotation(event)
+		if annotation == nil {
+			continue
+		}
+		if err := s.post(ctx, annotation); err != nil {
+			errs = append(errs, errors.Wrapf(err, "cannot post annotation for job %v/%v", event.Namespace, event.JobName))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// newAnnotation returns the annotation for the Event, or nil if the Event is not
+// a Job starting or finishing.
+func (s *GrafanaSink) newAnnotation(event *Event) *GrafanaAnnotation {
+	if event.Kind != KindJob {
+		return nil
+	}
+
+	tags := append([]string{grafanaTag, "namespace:" + event.Namespace}, s.tags...)
+	if event.JobConfig != "" {
+		tags = append(tags, "jobconfig:"+event.JobConfig)
+	}
+	annotation := &GrafanaAnnotation{
+		DashboardUID: s.dashboardUID,
+		Tags:         tags,
+	}
+
+	switch {
+	case event.FinishTime != nil:
+		// Jobs which were never started are annotated at the time they finished.
+		annotation.Time = event.FinishTime.UnixMilli()
+		if event.StartTime != nil {
+			annotation.Time = event.StartTime.UnixMilli()
+			annotation.TimeEnd = event.FinishTime.UnixMilli()
+		}
+		annotation.Tags = append(annotation.Tags, "finished", "result:"+event.Result)
+		annotation.Text = fmt.Sprintf("Job %v/%v finished with result %v", event.Namespace, event.JobName,
+			event.Result)
+		if event.Message != "" {
+			annotation.Text += ": " + event.Message
+		}
+
+	// Only annotate the first time that the Job starts running, and not after
+	// each retry.
+	case event.To == string(execution.JobRunning) && event.StartTime != nil && event.CreatedTasks <= 1:
+		annotation.Time = event.StartTime.UnixMilli()
+		annotation.Tags = append(annotation.Tags, "started")
+		annotation.Text = fmt.Sprintf("Job %v/%v started", event.Namespace, event.JobName)
+
+	default:
+		return nil
+	}
+
+	return annotation
+}
+
+func (s *GrafanaSink) post(ctx context.Context, annotation *GrafanaAnnotation) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(annotation)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal annotation")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiTokenFile != "" {
+		token, err := os.ReadFile(s.apiTokenFile)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read api token file")
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("request failed with status %v: %v", resp.StatusCode, string(data))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+const (
+	kafkaSinkName = "Kafka"
+
+	// Content types of the Kafka REST Proxy v2 API.
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAcceptType  = "application/vnd.kafka.v2+json"
+
+	defaultKafkaTimeout = 10 * time.Second
+)
+
+// KafkaSink is a Sink that produces Events to a Kafka topic using the Kafka REST
+// Proxy, keyed by Event.Key.
+type KafkaSink struct {
+	endpoint string
+	timeout  time.Duration
+	client   *http.Client
+}
+
+var _ Sink = (*KafkaSink)(nil)
+
+// NewKafkaSink returns a new KafkaSink for the given spec.
+func NewKafkaSink(spec *configv1alpha1.KafkaSinkSpec) (*KafkaSink, error) {
+	if spec.RESTProxyURL == "" {
+		return nil, errors.New("restProxyURL must be specified")
+	}
+	if spec.Topic == "" {
+		return nil, errors.New("topic must be specified")
+	}
+	base, err := url.Parse(spec.RESTProxyURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid restProxyURL")
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid restProxyURL, must be a http or https URL: %v", spec.RESTProxyURL)
+	}
+
+	timeout := defaultKafkaTimeout
+	if spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.TimeoutSeconds) * time.Second
+	}
+
+	return &KafkaSink{
+		endpoint: strings.TrimSuffix(base.String(), "/") + "/topics/" + url.PathEscape(spec.Topic),
+		timeout:  timeout,
+		client:   &http.Client{},
+	}, nil
+}
+
+func (s *KafkaSink) Name() string {
+	return kafkaSinkName
+}
+
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value *Event `json:"value"`
+}
+
+type kafkaProduceResponse struct {
+	Offsets []kafkaOffset `json:"offsets"`
+}
+
+type kafkaOffset struct {
+	ErrorCode *int   `json:"error_code"`
+	Error     string `json:"error"`
+}
+
+func (s *KafkaSink) Write(ctx context.Context, events []*Event) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	produce := kafkaProduceRequest{Records: make([]kafkaRecord, 0, len(events))}
+	for _, event := range events {
+		produce.Records = append(produce.Records, kafkaRecord{Key: event.Key(), Value: event})
+	}
+	body, err := json.Marshal(produce)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal records")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaAcceptType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+
+	// Records may fail to be produced individually.
+	var produceResp kafkaProduceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&produceResp); err != nil {
+		return errors.Wrapf(err, "cannot decode response")
+	}
+	for _, offset := range produceResp.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("cannot produce record, error code %v: %v", *offset.ErrorCode, offset.Error)
+		}
+	}
+
+	return nil
+}
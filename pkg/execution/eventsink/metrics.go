@@ -0,0 +1,49 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	promNamespace = "furiko"
+)
+
+const (
+	resultSent    = "sent"
+	resultFailed  = "failed"
+	resultDropped = "dropped"
+)
+
+var (
+	eventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "event_sink_events_total",
+			Help:      "Total number of state transition events by sink and result (sent, failed or dropped)",
+		},
+		[]string{"sink", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		eventsTotal,
+	)
+}
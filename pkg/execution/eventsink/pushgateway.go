@@ -0,0 +1,198 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	pushgatewaySinkName = "Pushgateway"
+
+	defaultPushgatewayTimeout = 10 * time.Second
+
+	pushgatewayMetricPrefix = "furiko_job_"
+)
+
+var (
+	invalidLabelNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+// PushgatewaySink is a Sink that pushes metrics of finished Jobs to a
+// Prometheus Pushgateway. All other Events are ignored.
+//
+// Metrics are pushed using POST, which only replaces metrics with the same
+// names in the group, so that the last success timestamp is retained across
+// failed runs.
+type PushgatewaySink struct {
+	url       string
+	labelKeys []string
+	timeout   time.Duration
+	client    *http.Client
+}
+
+var _ Sink = (*PushgatewaySink)(nil)
+
+// NewPushgatewaySink returns a new PushgatewaySink for the given spec.
+func NewPushgatewaySink(spec *configv1alpha1.PushgatewaySinkSpec) (*PushgatewaySink, error) {
+	if spec.URL == "" {
+		return nil, errors.New("url must be specified")
+	}
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid url, must be a http or https URL: %v", spec.URL)
+	}
+
+	timeout := defaultPushgatewayTimeout
+	if spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.TimeoutSeconds) * time.Second
+	}
+
+	return &PushgatewaySink{
+		url:       spec.URL,
+		labelKeys: spec.LabelKeys,
+		timeout:   timeout,
+		client:    &http.Client{},
+	}, nil
+}
+
+func (s *PushgatewaySink) Name() string {
+	return pushgatewaySinkName
+}
+
+func (s *PushgatewaySink) Write(ctx context.Context, events []*Event) error {
+	var errs []error
+	for _, event := range events {
+		if event.Kind != KindJob || event.FinishTime == nil {
+			continue
+		}
+		if err := s.push(ctx, event); err != nil {
+			errs = append(errs, errors.Wrapf(err, "cannot push metrics for job %v/%v", event.Namespace, event.JobName))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s *PushgatewaySink) push(ctx context.Context, event *Event) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	job := event.JobConfig
+	if job == "" {
+		job = event.JobName
+	}
+	pusher := push.New(s.url, job).
+		Gatherer(NewJobMetrics(event)).
+		Client(doerFunc(func(req *http.Request) (*http.Response, error) {
+			return s.client.Do(req.WithContext(ctx))
+		})).
+		Grouping("namespace", event.Namespace)
+	for _, key := range s.labelKeys {
+		if value, ok := event.Labels[key]; ok {
+			pusher = pusher.Grouping(SanitizeLabelName(key), value)
+		}
+	}
+
+	return pusher.Add()
+}
+
+// NewJobMetrics returns a Gatherer for the metrics of a finished Job.
+func NewJobMetrics(event *Event) prometheus.Gatherer {
+	registry := prometheus.NewRegistry()
+	gauge := func(name, help string, value float64, labels prometheus.Labels) {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        pushgatewayMetricPrefix + name,
+			Help:        help,
+			ConstLabels: labels,
+		})
+		g.Set(value)
+		registry.MustRegister(g)
+	}
+
+	succeeded := event.Result == string(execution.JobResultSuccess)
+	gauge("last_completion_timestamp_seconds", "Unix timestamp at which the last Job finished.",
+		timestamp(*event.FinishTime), nil)
+	if succeeded {
+		gauge("last_success_timestamp_seconds", "Unix timestamp at which the last Job succeeded.",
+			timestamp(*event.FinishTime), nil)
+	}
+	gauge("succeeded", "Whether the last Job succeeded.", boolToFloat(succeeded), nil)
+	gauge("result", "Result of the last Job.", 1, prometheus.Labels{"result": event.Result})
+
+	// Jobs which were never started have a duration of zero, and waited in the
+	// queue until they finished.
+	startTime, duration := *event.FinishTime, 0.0
+	if event.StartTime != nil {
+		startTime = *event.StartTime
+		duration = event.FinishTime.Sub(startTime).Seconds()
+	}
+	gauge("duration_seconds", "Duration that the last Job ran for.", duration, nil)
+	gauge("queue_wait_seconds", "Duration that the last Job waited before it was started.",
+		startTime.Sub(event.CreationTimestamp).Seconds(), nil)
+
+	var retries int64
+	if event.CreatedTasks > 1 {
+		retries = event.CreatedTasks - 1
+	}
+	gauge("retries", "Number of times the last Job was retried.", float64(retries), nil)
+
+	return registry
+}
+
+// SanitizeLabelName replaces characters which are not allowed in Prometheus
+// label names with underscores.
+func SanitizeLabelName(name string) string {
+	name = invalidLabelNameChars.ReplaceAllString(name, "_")
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+func timestamp(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// doerFunc implements push.HTTPDoer using a function.
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
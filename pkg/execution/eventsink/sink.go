@@ -0,0 +1,165 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+const (
+	defaultBufferSize    = 1000
+	defaultMaxBatchSize  = 100
+	defaultFlushInterval = time.Second
+)
+
+// Sink knows how to write a batch of Events to an external system.
+type Sink interface {
+	// Name returns the name of the Sink.
+	Name() string
+
+	// Write writes the batch of Events in order.
+	Write(ctx context.Context, events []*Event) error
+}
+
+// Recorder records Events to be sent to sinks.
+type Recorder interface {
+	Record(event *Event)
+}
+
+// NopRecorder is a Recorder that discards all Events.
+type NopRecorder struct{}
+
+var _ Recorder = NopRecorder{}
+
+func (NopRecorder) Record(_ *Event) {}
+
+// RecordJobTransition records Events for all state transitions of the Job and
+// its tasks from rj to newRj using the given Recorder. The actor of the initial
+// transition is the user who created the Job if it is known.
+func RecordJobTransition(recorder Recorder, rj, newRj *execution.Job, actor string) {
+	for _, event := range NewJobEvents(rj, newRj) {
+		event.Actor = actor
+		if createdBy := jobutil.GetCreatedBy(newRj); event.Kind == KindJob && event.From == "" && createdBy != "" {
+			event.Actor = createdBy
+		}
+		recorder.Record(event)
+	}
+}
+
+// RecordJobConfigTransition records an Event for the state transition of the
+// JobConfig from rjc to newRjc using the given Recorder.
+func RecordJobConfigTransition(recorder Recorder, rjc, newRjc *execution.JobConfig, actor string) {
+	for _, event := range NewJobConfigEvents(rjc, newRjc) {
+		event.Actor = actor
+		recorder.Record(event)
+	}
+}
+
+// Dispatcher is a Recorder that buffers Events and writes them to all sinks in
+// batches in the background. Events are delivered on a best-effort basis, and
+// are dropped if the buffer is full or if a sink fails to write them.
+type Dispatcher struct {
+	sinks         []Sink
+	queue         chan *Event
+	maxBatchSize  int
+	flushInterval time.Duration
+}
+
+var _ Recorder = (*Dispatcher)(nil)
+
+// NewDispatcher returns a new Dispatcher for the given sinks. Non-positive
+// values will use the defaults. Start must be called to begin writing Events.
+func NewDispatcher(bufferSize, maxBatchSize int, flushInterval time.Duration, sinks ...Sink) *Dispatcher {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	if maxBatchSize <= 0 {
+		maxBatchSize = defaultMaxBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	return &Dispatcher{
+		sinks:         sinks,
+		queue:         make(chan *Event, bufferSize),
+		maxBatchSize:  maxBatchSize,
+		flushInterval: flushInterval,
+	}
+}
+
+// Record enqueues the Event to be written, without blocking.
+func (d *Dispatcher) Record(event *Event) {
+	select {
+	case d.queue <- event:
+	default:
+		for _, sink := range d.sinks {
+			eventsTotal.WithLabelValues(sink.Name(), resultDropped).Inc()
+		}
+		klog.V(4).InfoS("eventsink: dropped event due to full buffer", "kind", event.Kind,
+			"namespace", event.Namespace, "name", event.JobName)
+	}
+}
+
+// Start writes Events in the background until the context is canceled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	go d.run(ctx)
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(d.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Event, 0, d.maxBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		d.write(ctx, batch)
+		batch = make([]*Event, 0, d.maxBatchSize)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-d.queue:
+			batch = append(batch, event)
+			if len(batch) >= d.maxBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (d *Dispatcher) write(ctx context.Context, events []*Event) {
+	for _, sink := range d.sinks {
+		if err := sink.Write(ctx, events); err != nil {
+			klog.ErrorS(err, "eventsink: cannot write events", "sink", sink.Name(), "count", len(events))
+			eventsTotal.WithLabelValues(sink.Name(), resultFailed).Add(float64(len(events)))
+			continue
+		}
+		eventsTotal.WithLabelValues(sink.Name(), resultSent).Add(float64(len(events)))
+	}
+}
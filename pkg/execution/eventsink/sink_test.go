@@ -0,0 +1,319 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventsink_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/eventsink"
+)
+
+func TestKafkaSink(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/job-history", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received["records"].([]interface{})[0].(map[string]interface{})["key"] == "default/failing" {
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50003,"error":"error"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1}]}`))
+	}))
+	defer server.Close()
+
+	sink, err := eventsink.NewKafkaSink(&configv1alpha1.KafkaSinkSpec{
+		RESTProxyURL: server.URL + "/",
+		Topic:        "job-history",
+	})
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, sink.Write(ctx, []*eventsink.Event{
+		{Namespace: "default", JobName: "job", JobConfig: "jobconfig", To: "Running"},
+	}))
+	assert.Equal(t, map[string]interface{}{
+		"records": []interface{}{
+			map[string]interface{}{
+				"key": "default/jobconfig",
+				"value": map[string]interface{}{
+					"timestamp":         "0001-01-01T00:00:00Z",
+					"kind":              "",
+					"namespace":         "default",
+					"jobName":           "job",
+					"jobUID":            "",
+					"jobConfig":         "jobconfig",
+					"to":                "Running",
+					"creationTimestamp": "0001-01-01T00:00:00Z",
+				},
+			},
+		},
+	}, received)
+
+	// Should return error if any record failed.
+	assert.Error(t, sink.Write(ctx, []*eventsink.Event{
+		{Namespace: "default", JobName: "failing", To: "Running"},
+	}))
+}
+
+func TestNewKafkaSink_Invalid(t *testing.T) {
+	_, err := eventsink.NewKafkaSink(&configv1alpha1.KafkaSinkSpec{Topic: "topic"})
+	assert.Error(t, err)
+	_, err = eventsink.NewKafkaSink(&configv1alpha1.KafkaSinkSpec{RESTProxyURL: "kafka:8082", Topic: "topic"})
+	assert.Error(t, err)
+	_, err = eventsink.NewKafkaSink(&configv1alpha1.KafkaSinkSpec{RESTProxyURL: "http://kafka:8082"})
+	assert.Error(t, err)
+}
+
+func TestPushgatewaySink(t *testing.T) {
+	var path string
+	metrics := make(map[string]float64)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		path = r.URL.Path
+		decoder := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+		for {
+			family := &dto.MetricFamily{}
+			if err := decoder.Decode(family); err != nil {
+				assert.ErrorIs(t, err, io.EOF)
+				break
+			}
+			for _, metric := range family.GetMetric() {
+				name := family.GetName()
+				for _, label := range metric.GetLabel() {
+					name += "/" + label.GetName() + "=" + label.GetValue()
+				}
+				metrics[name] = metric.GetGauge().GetValue()
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink, err := eventsink.NewPushgatewaySink(&configv1alpha1.PushgatewaySinkSpec{
+		URL:       server.URL,
+		LabelKeys: []string{"app.kubernetes.io/team", "missing"},
+	})
+	assert.NoError(t, err)
+
+	createTime := time.Unix(1648785600, 0)
+	startTime := createTime.Add(5 * time.Second)
+	finishTime := startTime.Add(time.Minute)
+	ctx := context.Background()
+	assert.NoError(t, sink.Write(ctx, []*eventsink.Event{
+		// Should ignore unfinished Jobs and tasks.
+		{Kind: eventsink.KindJob, Namespace: "default", JobName: "running", To: "Running"},
+		{Kind: eventsink.KindTask, Namespace: "default", JobName: "running", FinishTime: &finishTime},
+		{
+			Kind:              eventsink.KindJob,
+			Namespace:         "default",
+			JobName:           "job",
+			JobConfig:         "jobconfig",
+			Labels:            map[string]string{"app.kubernetes.io/team": "data"},
+			To:                "Succeeded",
+			Result:            "Success",
+			CreationTimestamp: createTime,
+			StartTime:         &startTime,
+			FinishTime:        &finishTime,
+			CreatedTasks:      3,
+		},
+	}))
+	// The order of the grouping labels in the path is not deterministic.
+	assert.True(t, strings.HasPrefix(path, "/metrics/job/jobconfig/"), path)
+	grouping := make(map[string]string)
+	parts := strings.Split(strings.TrimPrefix(path, "/metrics/job/jobconfig/"), "/")
+	for i := 0; i+1 < len(parts); i += 2 {
+		grouping[parts[i]] = parts[i+1]
+	}
+	assert.Equal(t, map[string]string{
+		"namespace":              "default",
+		"app_kubernetes_io_team": "data",
+	}, grouping)
+	assert.Equal(t, map[string]float64{
+		"furiko_job_last_completion_timestamp_seconds": 1648785665,
+		"furiko_job_last_success_timestamp_seconds":    1648785665,
+		"furiko_job_succeeded":                         1,
+		"furiko_job_result/result=Success":             1,
+		"furiko_job_duration_seconds":                  60,
+		"furiko_job_queue_wait_seconds":                5,
+		"furiko_job_retries":                           2,
+	}, metrics)
+}
+
+func TestNewPushgatewaySink_Invalid(t *testing.T) {
+	_, err := eventsink.NewPushgatewaySink(&configv1alpha1.PushgatewaySinkSpec{})
+	assert.Error(t, err)
+	_, err = eventsink.NewPushgatewaySink(&configv1alpha1.PushgatewaySinkSpec{URL: "pushgateway:9091"})
+	assert.Error(t, err)
+}
+
+func TestGrafanaSink(t *testing.T) {
+	var received []eventsink.GrafanaAnnotation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/grafana/api/annotations", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		var annotation eventsink.GrafanaAnnotation
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&annotation))
+		received = append(received, annotation)
+		if annotation.Text == "Job default/failing started" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"message":"Annotation added"}`))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("token\n"), 0600))
+	sink, err := eventsink.NewGrafanaSink(&configv1alpha1.GrafanaSinkSpec{
+		URL:          server.URL + "/grafana/",
+		APITokenFile: tokenFile,
+		DashboardUID: "dashboard",
+		Tags:         []string{"cluster:prod"},
+	})
+	assert.NoError(t, err)
+
+	createTime := time.Unix(1648785600, 0)
+	startTime := createTime.Add(5 * time.Second)
+	finishTime := startTime.Add(time.Minute)
+	ctx := context.Background()
+	assert.NoError(t, sink.Write(ctx, []*eventsink.Event{
+		// Should ignore tasks, other phases and retries.
+		{Kind: eventsink.KindTask, Namespace: "default", JobName: "job", To: "Running", StartTime: &startTime},
+		{Kind: eventsink.KindJob, Namespace: "default", JobName: "job", To: "Queued"},
+		{
+			Kind:         eventsink.KindJob,
+			Namespace:    "default",
+			JobName:      "retried",
+			To:           "Running",
+			StartTime:    &startTime,
+			CreatedTasks: 2,
+		},
+		{
+			Kind:         eventsink.KindJob,
+			Namespace:    "default",
+			JobName:      "job",
+			JobConfig:    "jobconfig",
+			To:           "Running",
+			StartTime:    &startTime,
+			CreatedTasks: 1,
+		},
+		{
+			Kind:       eventsink.KindJob,
+			Namespace:  "default",
+			JobName:    "job",
+			JobConfig:  "jobconfig",
+			To:         "RetryLimitExceeded",
+			Result:     "TaskFailed",
+			Message:    "exit code 1",
+			StartTime:  &startTime,
+			FinishTime: &finishTime,
+		},
+	}))
+	assert.Equal(t, []eventsink.GrafanaAnnotation{
+		{
+			DashboardUID: "dashboard",
+			Time:         1648785605000,
+			Tags:         []string{"furiko", "namespace:default", "cluster:prod", "jobconfig:jobconfig", "started"},
+			Text:         "Job default/job started",
+		},
+		{
+			DashboardUID: "dashboard",
+			Time:         1648785605000,
+			TimeEnd:      1648785665000,
+			Tags: []string{
+				"furiko", "namespace:default", "cluster:prod", "jobconfig:jobconfig", "finished", "result:TaskFailed",
+			},
+			Text: "Job default/job finished with result TaskFailed: exit code 1",
+		},
+	}, received)
+
+	// Should return error if the request failed.
+	assert.Error(t, sink.Write(ctx, []*eventsink.Event{
+		{Kind: eventsink.KindJob, Namespace: "default", JobName: "failing", To: "Running", StartTime: &startTime},
+	}))
+}
+
+func TestNewGrafanaSink_Invalid(t *testing.T) {
+	_, err := eventsink.NewGrafanaSink(&configv1alpha1.GrafanaSinkSpec{})
+	assert.Error(t, err)
+	_, err = eventsink.NewGrafanaSink(&configv1alpha1.GrafanaSinkSpec{URL: "grafana:3000"})
+	assert.Error(t, err)
+}
+
+func TestSanitizeLabelName(t *testing.T) {
+	assert.Equal(t, "app_kubernetes_io_name", eventsink.SanitizeLabelName("app.kubernetes.io/name"))
+	assert.Equal(t, "_1team", eventsink.SanitizeLabelName("1team"))
+}
+
+type fakeSink struct {
+	batches chan []*eventsink.Event
+}
+
+func (s *fakeSink) Name() string {
+	return "Fake"
+}
+
+func (s *fakeSink) Write(_ context.Context, events []*eventsink.Event) error {
+	s.batches <- events
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sink := &fakeSink{batches: make(chan []*eventsink.Event, 10)}
+	dispatcher := eventsink.NewDispatcher(10, 2, 50*time.Millisecond, sink)
+	dispatcher.Start(ctx)
+
+	for _, name := range []string{"job1", "job2", "job3"} {
+		dispatcher.Record(&eventsink.Event{JobName: name})
+	}
+
+	// First batch is flushed once the max batch size is reached, and the
+	// remaining event is flushed after the flush interval.
+	var names [][]string
+	for i := 0; i < 2; i++ {
+		select {
+		case batch := <-sink.batches:
+			var batchNames []string
+			for _, event := range batch {
+				batchNames = append(batchNames, event.JobName)
+			}
+			names = append(names, batchNames)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for batch")
+		}
+	}
+	assert.Equal(t, [][]string{{"job1", "job2"}, {"job3"}}, names)
+}
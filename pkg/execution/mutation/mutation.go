@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +37,7 @@ import (
 	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/core/options"
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/execution/util/tasktemplate"
 	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
 	executionlister "github.com/furiko-io/furiko/pkg/generated/listers/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
@@ -67,6 +69,19 @@ func (m *Mutator) MutateJobConfig(rjc *v1alpha1.JobConfig) *webhook.Result {
 		rjc.Spec.Option = options.DefaultOptionSpec(spec)
 	}
 
+	// Store the JSON Schema of the OptionSpec, so that external tools can render
+	// option inputs without having to understand the OptionSpec.
+	if spec := rjc.Spec.Option; spec != nil && len(spec.Options) > 0 {
+		if schema, err := json.Marshal(options.GenerateJSONSchema(spec)); err != nil {
+			warning := fmt.Sprintf("failed to store option json schema in annotations: %v", err)
+			result.Warnings = append(result.Warnings, warning)
+		} else {
+			meta.SetAnnotation(rjc, jobconfig.AnnotationKeyOptionJSONSchema, string(schema))
+		}
+	} else {
+		delete(rjc.Annotations, jobconfig.AnnotationKeyOptionJSONSchema)
+	}
+
 	return result
 }
 
@@ -83,6 +98,10 @@ func (m *Mutator) MutateCreateJobConfig(rjc *v1alpha1.JobConfig) *webhook.Result
 		}
 	}
 
+	// Merge the namespace's default task template values.
+	result.Merge(m.applyTaskTemplateDefaults(rjc.Namespace, &rjc.Spec.Template.Spec,
+		field.NewPath("spec", "template", "spec")))
+
 	return result
 }
 
@@ -111,7 +130,7 @@ func (m *Mutator) MutateUpdateJobConfig(oldRjc, rjc *v1alpha1.JobConfig) *webhoo
 func (m *Mutator) MutateJob(rj *v1alpha1.Job) *webhook.Result {
 	result := webhook.NewResult()
 
-	cfg, err := m.ctrlContext.Configs().Jobs()
+	cfg, err := m.ctrlContext.Configs().JobsForNamespace(rj.Namespace)
 	if err != nil {
 		result.Errors = append(result.Errors, field.InternalError(field.NewPath(""), err))
 		return result
@@ -122,13 +141,29 @@ func (m *Mutator) MutateJob(rj *v1alpha1.Job) *webhook.Result {
 		rj.Spec.Type = v1alpha1.JobTypeAdhoc
 	}
 	if rj.Spec.TTLSecondsAfterFinished == nil {
-		rj.Spec.TTLSecondsAfterFinished = cfg.DefaultTTLSecondsAfterFinished
+		// Use the JobConfig's execution overrides if any. Errors are ignored here,
+		// since the JobConfig owner is already validated on creation.
+		rjc, _ := jobconfig.LookupJobOwner(rj, m.getJobConfigLister(rj.Namespace))
+		rj.Spec.TTLSecondsAfterFinished = jobconfig.ApplyExecutionOverrides(cfg, rjc).DefaultTTLSecondsAfterFinished
 	}
 
 	// Specify default values for JobTemplateSpec.
 	if rj.Spec.Template == nil {
 		rj.Spec.Template = &v1alpha1.JobTemplateSpec{}
 	}
+
+	// Resolve references to TaskTemplates, which may come from the JobConfig when
+	// the Job is created or when the template of a queued Job is refreshed. The
+	// namespace's default task template values are only merged once resolved.
+	if tasktemplate.HasReferences(rj.Spec.Template) {
+		fldPath := field.NewPath("spec", "template")
+		lister := m.getTaskTemplateLister(rj.Namespace)
+		if errs := tasktemplate.ResolveJobTemplateSpec(rj.Spec.Template, lister, fldPath); len(errs) > 0 {
+			result.Errors = append(result.Errors, errs...)
+			return result
+		}
+		result.Merge(m.applyTaskTemplateDefaults(rj.Namespace, rj.Spec.Template, fldPath))
+	}
 	result.Merge(m.MutateJobTemplateSpec(rj.Spec.Template, field.NewPath("spec", "template")))
 
 	return result
@@ -147,6 +182,11 @@ func (m *Mutator) MutateCreateJob(rj *v1alpha1.Job) *webhook.Result {
 	// Evaluate configName and populate fields from the JobConfig.
 	result.Merge(m.evaluateConfigName(rj, rj.Spec.ConfigName, field.NewPath("spec").Child("configName")))
 
+	// Merge the namespace's default task template values.
+	if rj.Spec.Template != nil {
+		result.Merge(m.applyTaskTemplateDefaults(rj.Namespace, rj.Spec.Template, field.NewPath("spec", "template")))
+	}
+
 	// Look up the JobConfig or fail validation if we retrieved an invalid JobConfig owner.
 	rjc, errs := jobconfig.ValidateLookupJobOwner(rj, m.getJobConfigLister(rj.Namespace))
 	if errs != nil {
@@ -168,6 +208,43 @@ func (m *Mutator) MutateCreateJob(rj *v1alpha1.Job) *webhook.Result {
 	return result
 }
 
+// RenderJobTemplate mutates a v1alpha1.Job in-place by applying template patches
+// and substituting job context variables into each task template, in the same
+// way that the controller does when creating tasks. This is only used for
+// dry-run requests, so that users can preview the evaluated task templates
+// without creating the Job.
+func (m *Mutator) RenderJobTemplate(rj *v1alpha1.Job) *webhook.Result {
+	result := webhook.NewResult()
+	if rj.Spec.Template == nil {
+		return result
+	}
+	fldPath := field.NewPath("spec", "template")
+
+	if len(rj.Spec.Template.Steps) == 0 {
+		template, err := variablecontext.ApplyPodTemplatePatches(rj, rj.Spec.Template.Task.Template)
+		if err != nil {
+			result.Errors = append(result.Errors, field.Invalid(fldPath.Child("patches"), rj.Spec.Template.Patches, err.Error()))
+			return result
+		}
+		rj.Spec.Template.Task.Template = template
+		rj.Spec.Template.Task.Template = variablecontext.SubstitutePodTemplateSpecForJob(rj)
+		return result
+	}
+
+	for i := range rj.Spec.Template.Steps {
+		step := &rj.Spec.Template.Steps[i]
+		template, err := variablecontext.ApplyPodTemplatePatches(rj, step.Task.Template)
+		if err != nil {
+			result.Errors = append(result.Errors, field.Invalid(fldPath.Child("patches"), rj.Spec.Template.Patches, err.Error()))
+			return result
+		}
+		step.Task.Template = template
+		step.Task.Template = variablecontext.SubstitutePodTemplateSpecForStep(rj, step)
+	}
+
+	return result
+}
+
 // evaluateOptionValues mutates the v1alpha1.Job in-place after evaluating job
 // options, and returns any validation errors encountered.
 func (m *Mutator) evaluateConfigName(rj *v1alpha1.Job, rjcName string, fldPath *field.Path) *webhook.Result {
@@ -255,6 +332,10 @@ func (m *Mutator) evaluateOptionValues(rj *v1alpha1.Job, rjc *v1alpha1.JobConfig
 			return result
 		}
 
+		// Convert any values using deprecated formats, which will be stored in their
+		// replacement format below.
+		result.Warnings = append(result.Warnings, options.ConvertDeprecatedOptionValues(optionValues, rjc.Spec.Option, fldPath)...)
+
 		// Re-marshal into JSON to standardize the output value (mostly useful to avoid
 		// corrupting `kubectl describe`).
 		jsonValues, err := json.Marshal(optionValues)
@@ -277,7 +358,7 @@ func (m *Mutator) evaluateOptionValues(rj *v1alpha1.Job, rjc *v1alpha1.JobConfig
 	}
 
 	// Evaluate the option values. If any required option is empty, an error will be raised.
-	// TODO(irvinlim): Need to fail admission if OptionValues contains a non-existent option name.
+	// Option names which do not exist in the OptionSpec are rejected by the validating webhook.
 	evaluated, errs := options.EvaluateOptions(optionValues, rjc.Spec.Option, fldPath)
 	if len(errs) > 0 {
 		result.Errors = append(result.Errors, errs...)
@@ -300,6 +381,14 @@ func (m *Mutator) MutateJobTemplateSpec(spec *v1alpha1.JobTemplateSpec, fldPath
 		spec.MaxAttempts = pointer.Int32(1)
 	}
 
+	// Mutate each step's PodTemplateSpec if steps are specified.
+	if len(spec.Steps) > 0 {
+		for i := range spec.Steps {
+			m.MutatePodTemplateSpec(&spec.Steps[i].Task.Template, fldPath.Child("steps").Index(i).Child("task", "template"))
+		}
+		return result
+	}
+
 	// Mutate task's PodTemplateSpec.
 	m.MutatePodTemplateSpec(&spec.Task.Template, fldPath.Child("task", "template"))
 
@@ -317,7 +406,94 @@ func (m *Mutator) MutatePodTemplateSpec(spec *corev1.PodTemplateSpec, fldPath *f
 	return result
 }
 
+// applyTaskTemplateDefaults merges the TaskTemplateDefaults from all
+// ExecutionConfigs in the namespace into each task's PodTemplateSpec in-place.
+// External tasks are skipped since they are not created as Pods, and so are
+// tasks that reference a TaskTemplate until the reference is resolved.
+func (m *Mutator) applyTaskTemplateDefaults(namespace string, spec *v1alpha1.JobTemplateSpec, fldPath *field.Path) *webhook.Result {
+	result := webhook.NewResult()
+
+	defaults, err := m.getTaskTemplateDefaults(namespace)
+	if err != nil {
+		result.Errors = append(result.Errors, field.InternalError(fldPath, err))
+		return result
+	}
+	if defaults == nil {
+		return result
+	}
+
+	if len(spec.Steps) > 0 {
+		for i := range spec.Steps {
+			if spec.Steps[i].Task.TemplateRef == nil {
+				applyPodTemplateDefaults(&spec.Steps[i].Task.Template, defaults)
+			}
+		}
+		return result
+	}
+
+	if spec.Task.External == nil && spec.Task.TemplateRef == nil {
+		applyPodTemplateDefaults(&spec.Task.Template, defaults)
+	}
+
+	return result
+}
+
+// getTaskTemplateDefaults returns the TaskTemplateDefaults for the namespace,
+// merged from all ExecutionConfigs in lexicographical order of their names.
+// Returns nil if no ExecutionConfig in the namespace specifies any defaults.
+func (m *Mutator) getTaskTemplateDefaults(namespace string) (*v1alpha1.TaskTemplateDefaults, error) {
+	executionConfigs, err := m.ctrlContext.Informers().Furiko().Execution().V1alpha1().ExecutionConfigs().Lister().
+		ExecutionConfigs(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list executionconfigs")
+	}
+	sort.Slice(executionConfigs, func(i, j int) bool {
+		return executionConfigs[i].Name < executionConfigs[j].Name
+	})
+
+	var res *v1alpha1.TaskTemplateDefaults
+	for _, executionConfig := range executionConfigs {
+		defaults := executionConfig.Spec.TaskTemplateDefaults
+		if defaults == nil {
+			continue
+		}
+		if res == nil {
+			res = &v1alpha1.TaskTemplateDefaults{}
+		}
+		if len(defaults.Labels) > 0 {
+			res.Labels = labels.Merge(res.Labels, defaults.Labels)
+		}
+		if defaults.ServiceAccountName != "" {
+			res.ServiceAccountName = defaults.ServiceAccountName
+		}
+		if defaults.SecurityContext != nil {
+			res.SecurityContext = defaults.SecurityContext.DeepCopy()
+		}
+	}
+
+	return res, nil
+}
+
+// applyPodTemplateDefaults merges TaskTemplateDefaults into a PodTemplateSpec
+// in-place, with values already specified in the template taking precedence.
+func applyPodTemplateDefaults(template *corev1.PodTemplateSpec, defaults *v1alpha1.TaskTemplateDefaults) {
+	if len(defaults.Labels) > 0 {
+		template.Labels = labels.Merge(defaults.Labels, template.Labels)
+	}
+	if template.Spec.ServiceAccountName == "" {
+		template.Spec.ServiceAccountName = defaults.ServiceAccountName
+	}
+	if template.Spec.SecurityContext == nil && defaults.SecurityContext != nil {
+		template.Spec.SecurityContext = defaults.SecurityContext.DeepCopy()
+	}
+}
+
 func (m *Mutator) getJobConfigLister(namespace string) executionlister.JobConfigNamespaceLister {
 	return m.ctrlContext.Informers().Furiko().Execution().V1alpha1().JobConfigs().Lister().
 		JobConfigs(namespace)
 }
+
+func (m *Mutator) getTaskTemplateLister(namespace string) executionlister.TaskTemplateNamespaceLister {
+	return m.ctrlContext.Informers().Furiko().Execution().V1alpha1().TaskTemplates().Lister().
+		TaskTemplates(namespace)
+}
@@ -66,6 +66,16 @@ var (
 		UID:       "7172ef3a-7754-4e73-a99a-d7e8a8e9fe5b",
 	}
 
+	objectMetaJobConfigWithOptionSchema = metav1.ObjectMeta{
+		Namespace: objectMetaJobConfig.Namespace,
+		Name:      objectMetaJobConfig.Name,
+		UID:       objectMetaJobConfig.UID,
+		Annotations: map[string]string{
+			jobconfig.AnnotationKeyOptionJSONSchema: `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object",` +
+				`"properties":{"opt":{"type":"boolean","default":false}},"additionalProperties":false}`,
+		},
+	}
+
 	ownerReferences = []metav1.OwnerReference{
 		{
 			APIVersion:         v1alpha1.GroupVersion.String(),
@@ -109,6 +119,29 @@ var (
 		},
 	}
 
+	objectMetaJobFromConfigName = metav1.ObjectMeta{
+		Namespace:       objectMetaJobWithAllReferences.Namespace,
+		Name:            objectMetaJobWithAllReferences.Name,
+		Labels:          objectMetaJobWithAllReferences.Labels,
+		OwnerReferences: objectMetaJobWithAllReferences.OwnerReferences,
+		Finalizers:      objectMetaJobWithAllReferences.Finalizers,
+		Annotations: map[string]string{
+			jobconfig.AnnotationKeyTemplateRevision: templateRevision,
+		},
+	}
+
+	objectMetaJobFromConfigNameWithOptionsHash = metav1.ObjectMeta{
+		Namespace:       objectMetaJobWithAllReferences.Namespace,
+		Name:            objectMetaJobWithAllReferences.Name,
+		Labels:          objectMetaJobWithAllReferences.Labels,
+		OwnerReferences: objectMetaJobWithAllReferences.OwnerReferences,
+		Finalizers:      objectMetaJobWithAllReferences.Finalizers,
+		Annotations: map[string]string{
+			jobconfig.AnnotationKeyOptionSpecHash:   optionSpecHash,
+			jobconfig.AnnotationKeyTemplateRevision: templateRevision,
+		},
+	}
+
 	podTemplateSpecBare = corev1.PodTemplateSpec{
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
@@ -209,6 +242,26 @@ var (
 	}
 
 	optionSpecHash, _ = options.HashOptionSpec(&optionSpecThree)
+
+	optionSpecDate = v1alpha1.OptionSpec{
+		Options: []v1alpha1.Option{
+			{
+				Type: v1alpha1.OptionTypeDate,
+				Name: "date",
+				Date: &v1alpha1.DateOptionConfig{
+					Format: "YYYY-MM-DD",
+				},
+			},
+		},
+	}
+
+	optionSpecDateHash, _ = options.HashOptionSpec(&optionSpecDate)
+
+	templateRevision, _ = jobconfig.GetTemplateRevision(&v1alpha1.JobConfig{
+		Spec: v1alpha1.JobConfigSpec{
+			Template: jobTemplateSpecBasic,
+		},
+	})
 )
 
 func TestMutator_MutateJobConfig(t *testing.T) {
@@ -260,7 +313,7 @@ func TestMutator_MutateJobConfig(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.JobConfig{
-				ObjectMeta: objectMetaJobConfig,
+				ObjectMeta: objectMetaJobConfigWithOptionSchema,
 				Spec: v1alpha1.JobConfigSpec{
 					Template: jobTemplateSpecBasic,
 					Option: &v1alpha1.OptionSpec{
@@ -277,6 +330,23 @@ func TestMutator_MutateJobConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "remove option json schema without options",
+			rjc: &v1alpha1.JobConfig{
+				ObjectMeta: objectMetaJobConfigWithOptionSchema,
+				Spec: v1alpha1.JobConfigSpec{
+					Template: jobTemplateSpecBasic,
+					Option:   &v1alpha1.OptionSpec{},
+				},
+			},
+			want: &v1alpha1.JobConfig{
+				ObjectMeta: objectMetaJobConfig,
+				Spec: v1alpha1.JobConfigSpec{
+					Template: jobTemplateSpecBasic,
+					Option:   &v1alpha1.OptionSpec{},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -619,6 +689,7 @@ func TestMutator_MutateJob(t *testing.T) {
 		name         string
 		cfgs         map[configv1alpha1.ConfigName]runtime.Object
 		rj           *v1alpha1.Job
+		rjcs         []*v1alpha1.JobConfig
 		want         *v1alpha1.Job
 		wantErrors   string
 		wantWarnings []string
@@ -649,6 +720,44 @@ func TestMutator_MutateJob(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "use TTL from JobConfig execution overrides",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						MaxAttempts: pointer.Int32(1),
+					},
+				},
+			},
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						ExecutionOverrides: &v1alpha1.JobExecutionOverrides{
+							TTLSecondsAfterFinished: pointer.Int64(60),
+						},
+					},
+				},
+			},
+			want: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						MaxAttempts: pointer.Int32(1),
+					},
+					TTLSecondsAfterFinished: pointer.Int64(60),
+				},
+			},
+		},
 		{
 			name: "no change expected",
 			rj: &v1alpha1.Job{
@@ -669,7 +778,7 @@ func TestMutator_MutateJob(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			mutator := setup(t, tt.cfgs, nil)
+			mutator := setup(t, tt.cfgs, tt.rjcs)
 			newRj := tt.rj.DeepCopy()
 			resp := mutator.MutateJob(newRj)
 
@@ -918,6 +1027,55 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 			},
 			wantErrors: "spec.optionValues[option2]: Invalid value: 2: expected string, got float64",
 		},
+		{
+			name: "convert deprecated optionValues",
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						Template:    jobTemplateSpecBasic,
+						Concurrency: concurrencySpecBasic,
+						Option:      &optionSpecDate,
+					},
+				},
+			},
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Template:    &jobTemplateSpecBasic.Spec,
+					StartPolicy: &startPolicyBasic,
+					OptionValues: optionValues(map[string]interface{}{
+						"date": 1612843569,
+					}),
+				},
+			},
+			want: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       objectMetaJobWithAllReferences.Namespace,
+					Name:            objectMetaJobWithAllReferences.Name,
+					Labels:          objectMetaJobWithAllReferences.Labels,
+					OwnerReferences: objectMetaJobWithAllReferences.OwnerReferences,
+					Finalizers:      objectMetaJobWithAllReferences.Finalizers,
+					Annotations: map[string]string{
+						jobconfig.AnnotationKeyOptionSpecHash: optionSpecDateHash,
+					},
+				},
+				Spec: v1alpha1.JobSpec{
+					Template:    &jobTemplateSpecBasic.Spec,
+					StartPolicy: &startPolicyBasic,
+					OptionValues: optionValues(map[string]interface{}{
+						"date": "2021-02-09T04:06:09Z",
+					}),
+					Substitutions: map[string]string{
+						"option.date": "2021-02-09",
+					},
+				},
+			},
+			wantWarnings: []string{
+				`spec.optionValues[date]: numeric Unix timestamps for date options are deprecated, ` +
+					`use an RFC3339 string instead (converted 1612843569 to "2021-02-09T04:06:09Z")`,
+			},
+		},
 		{
 			name: "mutate ConfigName",
 			rjcs: []*v1alpha1.JobConfig{
@@ -936,7 +1094,7 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.Job{
-				ObjectMeta: objectMetaJobWithAllReferences,
+				ObjectMeta: objectMetaJobFromConfigName,
 				Spec: v1alpha1.JobSpec{
 					Template:    &jobTemplateSpecBasic.Spec,
 					StartPolicy: &startPolicyBasic,
@@ -962,7 +1120,7 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.Job{
-				ObjectMeta: objectMetaJobWithAllReferences,
+				ObjectMeta: objectMetaJobFromConfigName,
 				Spec: v1alpha1.JobSpec{
 					Template:    &jobTemplateSpecBasic.Spec,
 					StartPolicy: &startPolicyOverride,
@@ -995,7 +1153,7 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.Job{
-				ObjectMeta: objectMetaJobWithOptionsHash,
+				ObjectMeta: objectMetaJobFromConfigNameWithOptionsHash,
 				Spec: v1alpha1.JobSpec{
 					Template:    &jobTemplateSpecBasic.Spec,
 					StartPolicy: &startPolicyBasic,
@@ -1034,7 +1192,7 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.Job{
-				ObjectMeta: objectMetaJobWithOptionsHash,
+				ObjectMeta: objectMetaJobFromConfigNameWithOptionsHash,
 				Spec: v1alpha1.JobSpec{
 					Template:    &jobTemplateSpecBasic.Spec,
 					StartPolicy: &startPolicyBasic,
@@ -1077,7 +1235,7 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.Job{
-				ObjectMeta: objectMetaJobWithOptionsHash,
+				ObjectMeta: objectMetaJobFromConfigNameWithOptionsHash,
 				Spec: v1alpha1.JobSpec{
 					Template:    &jobTemplateSpecBasic.Spec,
 					StartPolicy: &startPolicyBasic,
@@ -1117,7 +1275,7 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.Job{
-				ObjectMeta: objectMetaJobWithAllReferences,
+				ObjectMeta: objectMetaJobFromConfigName,
 				Spec: v1alpha1.JobSpec{
 					Template:    &jobTemplateSpecBasic.Spec,
 					StartPolicy: &startPolicyBasic,
@@ -1210,6 +1368,443 @@ func TestMutator_MutateCreateJob(t *testing.T) {
 	}
 }
 
+func TestMutator_RenderJobTemplate(t *testing.T) {
+	podTemplateSpecWithOption := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:    "container",
+					Image:   "alpine:${option.tag}",
+					Command: []string{"echo", "${option.message}", "${task.retry_index}"},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+	podTemplateSpecRendered := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:    "container",
+					Image:   "alpine:3.16",
+					Command: []string{"echo", "Hello World", "${task.retry_index}"},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+	substitutions := map[string]string{
+		"option.tag":     "3.16",
+		"option.message": "Hello World",
+	}
+
+	tests := []struct {
+		name       string
+		rj         *v1alpha1.Job
+		want       *v1alpha1.Job
+		wantErrors string
+	}{
+		{
+			name: "no template",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+			},
+		},
+		{
+			name: "render task template",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecWithOption,
+						},
+					},
+					Substitutions: substitutions,
+				},
+			},
+			want: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecRendered,
+						},
+					},
+					Substitutions: substitutions,
+				},
+			},
+		},
+		{
+			name: "render step templates",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{
+								Name: "step",
+								Task: v1alpha1.JobTaskSpec{
+									Template: podTemplateSpecWithOption,
+								},
+							},
+						},
+					},
+					Substitutions: substitutions,
+				},
+			},
+			want: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{
+								Name: "step",
+								Task: v1alpha1.JobTaskSpec{
+									Template: podTemplateSpecRendered,
+								},
+							},
+						},
+					},
+					Substitutions: substitutions,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mutator := setup(t, nil, nil)
+			newRj := tt.rj.DeepCopy()
+			resp := mutator.RenderJobTemplate(newRj)
+			if err := checkResult(resp, tt.wantErrors, nil); err != "" {
+				t.Errorf("RenderJobTemplate() %v", err)
+			}
+			if tt.wantErrors != "" {
+				return
+			}
+
+			opts := []cmp.Option{cmpopts.EquateEmpty()}
+			if tt.want == nil {
+				if !cmp.Equal(newRj, tt.rj, opts...) {
+					t.Errorf("RenderJobTemplate() expected no change\ndiff = %v", cmp.Diff(tt.rj, newRj, opts...))
+				}
+			} else if !cmp.Equal(newRj, tt.want, opts...) {
+				t.Errorf("RenderJobTemplate() not equal\ndiff = %v", cmp.Diff(tt.want, newRj, opts...))
+			}
+		})
+	}
+}
+
+func TestMutator_TaskTemplateDefaults(t *testing.T) {
+	securityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot: pointer.Bool(true),
+	}
+	executionConfigs := []*v1alpha1.ExecutionConfig{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "defaults-b",
+			},
+			Spec: v1alpha1.ExecutionConfigSpec{
+				TaskTemplateDefaults: &v1alpha1.TaskTemplateDefaults{
+					Labels: map[string]string{
+						"team": "team-b",
+					},
+					ServiceAccountName: "tenant-sa",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "defaults-a",
+			},
+			Spec: v1alpha1.ExecutionConfigSpec{
+				TaskTemplateDefaults: &v1alpha1.TaskTemplateDefaults{
+					Labels: map[string]string{
+						"team": "team-a",
+						"tier": "batch",
+					},
+					ServiceAccountName: "default-sa",
+					SecurityContext:    securityContext,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "other-namespace",
+				Name:      "defaults",
+			},
+			Spec: v1alpha1.ExecutionConfigSpec{
+				TaskTemplateDefaults: &v1alpha1.TaskTemplateDefaults{
+					ServiceAccountName: "other-sa",
+				},
+			},
+		},
+	}
+
+	withDefaults := podTemplateSpecBasic.DeepCopy()
+	withDefaults.Labels = map[string]string{
+		"team": "team-b",
+		"tier": "batch",
+	}
+	withDefaults.Spec.ServiceAccountName = "tenant-sa"
+	withDefaults.Spec.SecurityContext = securityContext
+
+	withOverrides := podTemplateSpecBasic.DeepCopy()
+	withOverrides.Labels = map[string]string{
+		"team": "my-team",
+	}
+	withOverrides.Spec.ServiceAccountName = "my-sa"
+
+	withMergedOverrides := withOverrides.DeepCopy()
+	withMergedOverrides.Labels["tier"] = "batch"
+	withMergedOverrides.Spec.SecurityContext = securityContext
+
+	tests := []struct {
+		name      string
+		namespace string
+		template  corev1.PodTemplateSpec
+		want      corev1.PodTemplateSpec
+	}{
+		{
+			name:      "no ExecutionConfigs in namespace",
+			namespace: "empty-namespace",
+			template:  podTemplateSpecBasic,
+			want:      podTemplateSpecBasic,
+		},
+		{
+			name:      "merge defaults in name order",
+			namespace: metav1.NamespaceDefault,
+			template:  podTemplateSpecBasic,
+			want:      *withDefaults,
+		},
+		{
+			name:      "do not override specified values",
+			namespace: metav1.NamespaceDefault,
+			template:  *withOverrides,
+			want:      *withMergedOverrides,
+		},
+	}
+
+	ctx := context.Background()
+	ctrlContext := mock.NewContext()
+	hasSynced := ctrlContext.Informers().Furiko().Execution().V1alpha1().ExecutionConfigs().Informer().HasSynced
+	if err := ctrlContext.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, executionConfig := range executionConfigs {
+		_, err := ctrlContext.Clientsets().Furiko().ExecutionV1alpha1().ExecutionConfigs(executionConfig.Namespace).
+			Create(ctx, executionConfig, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("cannot create ExecutionConfig: %v", err)
+		}
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), hasSynced) {
+		t.Fatalf("cannot sync caches")
+	}
+	mutator := mutation.NewMutator(ctrlContext)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []cmp.Option{cmpopts.EquateEmpty()}
+
+			rjc := &v1alpha1.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tt.namespace,
+					Name:      "jobconfig",
+				},
+				Spec: v1alpha1.JobConfigSpec{
+					Template: v1alpha1.JobTemplate{
+						Spec: v1alpha1.JobTemplateSpec{
+							Task: v1alpha1.JobTaskSpec{
+								Template: *tt.template.DeepCopy(),
+							},
+						},
+					},
+				},
+			}
+			if err := checkResult(mutator.MutateCreateJobConfig(rjc), "", nil); err != "" {
+				t.Errorf("MutateCreateJobConfig() %v", err)
+			}
+			if got := rjc.Spec.Template.Spec.Task.Template; !cmp.Equal(tt.want, got, opts...) {
+				t.Errorf("MutateCreateJobConfig() not equal\ndiff = %v", cmp.Diff(tt.want, got, opts...))
+			}
+
+			rj := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  tt.namespace,
+					Name:       "job",
+					Finalizers: []string{execution.DeleteDependentsFinalizer},
+				},
+				Spec: v1alpha1.JobSpec{
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{
+								Name: "step",
+								Task: v1alpha1.JobTaskSpec{
+									Template: *tt.template.DeepCopy(),
+								},
+							},
+						},
+					},
+				},
+			}
+			if err := checkResult(mutator.MutateCreateJob(rj), "", nil); err != "" {
+				t.Errorf("MutateCreateJob() %v", err)
+			}
+			if got := rj.Spec.Template.Steps[0].Task.Template; !cmp.Equal(tt.want, got, opts...) {
+				t.Errorf("MutateCreateJob() not equal\ndiff = %v", cmp.Diff(tt.want, got, opts...))
+			}
+		})
+	}
+}
+
+func TestMutator_TaskTemplateRef(t *testing.T) {
+	taskTemplate := &v1alpha1.TaskTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "tasktemplate",
+		},
+		Spec: v1alpha1.TaskTemplateSpec{
+			Task: v1alpha1.JobTaskSpec{
+				Template:              podTemplateSpecBasic,
+				PendingTimeoutSeconds: pointer.Int64(300),
+			},
+		},
+	}
+	executionConfig := &v1alpha1.ExecutionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "defaults",
+		},
+		Spec: v1alpha1.ExecutionConfigSpec{
+			TaskTemplateDefaults: &v1alpha1.TaskTemplateDefaults{
+				ServiceAccountName: "default-sa",
+			},
+		},
+	}
+
+	withOverrides := podTemplateSpecBasic.DeepCopy()
+	withOverrides.Spec.ServiceAccountName = "my-sa"
+	withOverrides.Spec.Containers[0].Command = []string{"echo", "Hello Override"}
+
+	withDefaults := podTemplateSpecBasic.DeepCopy()
+	withDefaults.Spec.ServiceAccountName = "default-sa"
+
+	tests := []struct {
+		name       string
+		task       v1alpha1.JobTaskSpec
+		want       v1alpha1.JobTaskSpec
+		wantErrors string
+	}{
+		{
+			name: "use TaskTemplate as base",
+			task: v1alpha1.JobTaskSpec{
+				TemplateRef: &v1alpha1.TaskTemplateReference{Name: "tasktemplate"},
+			},
+			want: v1alpha1.JobTaskSpec{
+				Template:              *withDefaults,
+				PendingTimeoutSeconds: pointer.Int64(300),
+			},
+		},
+		{
+			name: "merge local overrides",
+			task: v1alpha1.JobTaskSpec{
+				TemplateRef: &v1alpha1.TaskTemplateReference{Name: "tasktemplate"},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "my-sa",
+						Containers: []corev1.Container{
+							{
+								Name:    "container",
+								Command: []string{"echo", "Hello Override"},
+							},
+						},
+					},
+				},
+			},
+			want: v1alpha1.JobTaskSpec{
+				Template:              *withOverrides,
+				PendingTimeoutSeconds: pointer.Int64(300),
+			},
+		},
+		{
+			name: "TaskTemplate not found",
+			task: v1alpha1.JobTaskSpec{
+				TemplateRef: &v1alpha1.TaskTemplateReference{Name: "missing"},
+			},
+			wantErrors: `spec.template.steps[0].task.templateRef.name: Not found: "missing"`,
+		},
+		{
+			name: "empty TaskTemplate name",
+			task: v1alpha1.JobTaskSpec{
+				TemplateRef: &v1alpha1.TaskTemplateReference{},
+			},
+			wantErrors: "spec.template.steps[0].task.templateRef.name: Required value",
+		},
+	}
+
+	ctx := context.Background()
+	ctrlContext := mock.NewContext()
+	informers := ctrlContext.Informers().Furiko().Execution().V1alpha1()
+	hasSynced := []cache.InformerSynced{
+		informers.TaskTemplates().Informer().HasSynced,
+		informers.ExecutionConfigs().Informer().HasSynced,
+	}
+	if err := ctrlContext.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	client := ctrlContext.Clientsets().Furiko().ExecutionV1alpha1()
+	if _, err := client.TaskTemplates(taskTemplate.Namespace).Create(ctx, taskTemplate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("cannot create TaskTemplate: %v", err)
+	}
+	if _, err := client.ExecutionConfigs(executionConfig.Namespace).
+		Create(ctx, executionConfig, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("cannot create ExecutionConfig: %v", err)
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), hasSynced...) {
+		t.Fatalf("cannot sync caches")
+	}
+	mutator := mutation.NewMutator(ctrlContext)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []cmp.Option{cmpopts.EquateEmpty()}
+			rj := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:  metav1.NamespaceDefault,
+					Name:       "job",
+					Finalizers: []string{execution.DeleteDependentsFinalizer},
+				},
+				Spec: v1alpha1.JobSpec{
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{
+								Name: "step",
+								Task: *tt.task.DeepCopy(),
+							},
+						},
+					},
+				},
+			}
+			if err := checkResult(mutator.MutateJob(rj), tt.wantErrors, nil); err != "" {
+				t.Errorf("MutateJob() %v", err)
+				return
+			}
+			if tt.wantErrors != "" {
+				return
+			}
+			if got := rj.Spec.Template.Steps[0].Task; !cmp.Equal(tt.want, got, opts...) {
+				t.Errorf("MutateJob() not equal\ndiff = %v", cmp.Diff(tt.want, got, opts...))
+			}
+		})
+	}
+}
+
 type noopProvider struct{}
 
 func (n *noopProvider) GetAllPrefixes() []string {
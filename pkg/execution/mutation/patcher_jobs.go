@@ -47,6 +47,12 @@ func (p *JobPatcher) Patch(operation admissionv1.Operation, oldRj, rj *execution
 	return webhook.NewResult()
 }
 
+// Render evaluates the task templates of a Job that was already patched for
+// creation. It should only be used for dry-run requests.
+func (p *JobPatcher) Render(rj *execution.Job) *webhook.Result {
+	return p.mutator.RenderJobTemplate(rj)
+}
+
 func (p *JobPatcher) patchCreate(rj *execution.Job) *webhook.Result {
 	result := webhook.NewResult()
 	result.Merge(p.mutator.MutateCreateJob(rj))
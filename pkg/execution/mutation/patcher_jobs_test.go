@@ -84,7 +84,7 @@ func TestNewJobPatcher(t *testing.T) {
 				},
 			},
 			want: &v1alpha1.Job{
-				ObjectMeta: objectMetaJobWithAllReferences,
+				ObjectMeta: objectMetaJobFromConfigName,
 				Spec: v1alpha1.JobSpec{
 					Type: v1alpha1.JobTypeAdhoc,
 					Template: &v1alpha1.JobTemplateSpec{
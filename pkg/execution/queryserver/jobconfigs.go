@@ -0,0 +1,136 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver
+
+import (
+	"fmt"
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	indexJobConfigState = "queryserver:state"
+)
+
+func newJobConfigResource(informer cache.SharedIndexInformer) *resource {
+	return &resource{
+		name:     "jobconfigs",
+		informer: informer,
+		indexers: cache.Indexers{
+			indexJobConfigState: indexJobConfigsByState,
+		},
+		watchers:   newBroadcaster(watcherBufferLength),
+		parseQuery: parseJobConfigQuery,
+		toItem: func(obj metav1.Object) interface{} {
+			rjc := *obj.(*execution.JobConfig)
+			rjc.TypeMeta = metav1.TypeMeta{
+				APIVersion: execution.GroupVersion.String(),
+				Kind:       execution.KindJobConfig,
+			}
+			return &rjc
+		},
+		newList: func(items []interface{}, listMeta metav1.ListMeta) interface{} {
+			list := &execution.JobConfigList{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: execution.GroupVersion.String(),
+					Kind:       "JobConfigList",
+				},
+				ListMeta: listMeta,
+				Items:    make([]execution.JobConfig, 0, len(items)),
+			}
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*execution.JobConfig))
+			}
+			return list
+		},
+	}
+}
+
+// indexJobConfigsByState indexes JobConfigs by their state.
+func indexJobConfigsByState(obj interface{}) ([]string, error) {
+	rjc, ok := obj.(*execution.JobConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected *execution.JobConfig, got %T", obj)
+	}
+	return []string{string(rjc.Status.State)}, nil
+}
+
+// jobConfigQuery is a query for JobConfigs, which is parsed from the following
+// query parameters:
+//
+//	namespace: Only return JobConfigs in the given namespace.
+//	state: Only return JobConfigs in any of the given states. Can be comma-separated or repeated.
+//	labelSelector: Only return JobConfigs matching the given label selector.
+type jobConfigQuery struct {
+	namespace string
+	states    sets.String
+	selector  labels.Selector
+}
+
+var _ query = (*jobConfigQuery)(nil)
+
+func parseJobConfigQuery(values url.Values) (query, error) {
+	q := &jobConfigQuery{
+		namespace: values.Get("namespace"),
+		states:    parseList(values["state"]),
+	}
+
+	var err error
+	if q.selector, err = parseSelector(values); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (q *jobConfigQuery) candidates(indexer cache.Indexer) ([]interface{}, error) {
+	switch {
+	case q.states.Len() > 0:
+		var candidates []interface{}
+		for _, state := range q.states.List() {
+			objs, err := indexer.ByIndex(indexJobConfigState, state)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, objs...)
+		}
+		return candidates, nil
+	case q.namespace != "":
+		return indexer.ByIndex(cache.NamespaceIndex, q.namespace)
+	}
+	return indexer.List(), nil
+}
+
+func (q *jobConfigQuery) matches(obj metav1.Object) bool {
+	rjc, ok := obj.(*execution.JobConfig)
+	if !ok {
+		return false
+	}
+	if q.namespace != "" && rjc.Namespace != q.namespace {
+		return false
+	}
+	if q.states.Len() > 0 && !q.states.Has(string(rjc.Status.State)) {
+		return false
+	}
+	return q.selector.Matches(labels.Set(rjc.Labels))
+}
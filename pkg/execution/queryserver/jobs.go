@@ -0,0 +1,204 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/options"
+)
+
+const (
+	indexJobConfig = "queryserver:jobConfig"
+	indexJobPhase  = "queryserver:phase"
+
+	optionParamPrefix = "option."
+)
+
+func newJobResource(informer cache.SharedIndexInformer) *resource {
+	return &resource{
+		name:     "jobs",
+		informer: informer,
+		indexers: cache.Indexers{
+			indexJobConfig: indexJobsByJobConfig,
+			indexJobPhase:  indexJobsByPhase,
+		},
+		watchers:   newBroadcaster(watcherBufferLength),
+		parseQuery: parseJobQuery,
+		toItem: func(obj metav1.Object) interface{} {
+			rj := *obj.(*execution.Job)
+			rj.TypeMeta = metav1.TypeMeta{
+				APIVersion: execution.GroupVersion.String(),
+				Kind:       execution.KindJob,
+			}
+			return &rj
+		},
+		newList: func(items []interface{}, listMeta metav1.ListMeta) interface{} {
+			list := &execution.JobList{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: execution.GroupVersion.String(),
+					Kind:       "JobList",
+				},
+				ListMeta: listMeta,
+				Items:    make([]execution.Job, 0, len(items)),
+			}
+			for _, item := range items {
+				list.Items = append(list.Items, *item.(*execution.Job))
+			}
+			return list
+		},
+	}
+}
+
+// indexJobsByJobConfig indexes Jobs by the namespaced name of their owner
+// JobConfig.
+func indexJobsByJobConfig(obj interface{}) ([]string, error) {
+	rj, ok := obj.(*execution.Job)
+	if !ok {
+		return nil, fmt.Errorf("expected *execution.Job, got %T", obj)
+	}
+	ref := metav1.GetControllerOf(rj)
+	if ref == nil || ref.Kind != execution.KindJobConfig {
+		return nil, nil
+	}
+	return []string{rj.Namespace + "/" + ref.Name}, nil
+}
+
+// indexJobsByPhase indexes Jobs by their phase.
+func indexJobsByPhase(obj interface{}) ([]string, error) {
+	rj, ok := obj.(*execution.Job)
+	if !ok {
+		return nil, fmt.Errorf("expected *execution.Job, got %T", obj)
+	}
+	return []string{string(rj.Status.Phase)}, nil
+}
+
+// jobQuery is a query for Jobs, which is parsed from the following query
+// parameters:
+//
+//	namespace: Only return Jobs in the given namespace.
+//	jobConfig: Only return Jobs belonging to the given JobConfig. Requires namespace to be specified.
+//	phase: Only return Jobs in any of the given phases. Can be comma-separated or repeated.
+//	createdAfter: Only return Jobs created at or after the given RFC3339 timestamp.
+//	createdBefore: Only return Jobs created before the given RFC3339 timestamp.
+//	labelSelector: Only return Jobs matching the given label selector.
+//	option.<name>: Only return Jobs whose evaluated option value for <name> is equal to the given value.
+type jobQuery struct {
+	namespace     string
+	jobConfig     string
+	phases        sets.String
+	createdAfter  *time.Time
+	createdBefore *time.Time
+	selector      labels.Selector
+	options       map[string]string
+}
+
+var _ query = (*jobQuery)(nil)
+
+func parseJobQuery(values url.Values) (query, error) {
+	q := &jobQuery{
+		namespace: values.Get("namespace"),
+		jobConfig: values.Get("jobConfig"),
+		phases:    parseList(values["phase"]),
+		options:   make(map[string]string),
+	}
+
+	if q.jobConfig != "" && q.namespace == "" {
+		return nil, errors.New("namespace must be specified together with jobConfig")
+	}
+
+	var err error
+	if q.createdAfter, err = parseTime(values, "createdAfter"); err != nil {
+		return nil, err
+	}
+	if q.createdBefore, err = parseTime(values, "createdBefore"); err != nil {
+		return nil, err
+	}
+	if q.selector, err = parseSelector(values); err != nil {
+		return nil, err
+	}
+
+	for key := range values {
+		if name := strings.TrimPrefix(key, optionParamPrefix); name != key {
+			q.options[name] = values.Get(key)
+		}
+	}
+
+	return q, nil
+}
+
+func (q *jobQuery) candidates(indexer cache.Indexer) ([]interface{}, error) {
+	switch {
+	case q.jobConfig != "":
+		return indexer.ByIndex(indexJobConfig, q.namespace+"/"+q.jobConfig)
+	case q.phases.Len() > 0:
+		var candidates []interface{}
+		for _, phase := range q.phases.List() {
+			objs, err := indexer.ByIndex(indexJobPhase, phase)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, objs...)
+		}
+		return candidates, nil
+	case q.namespace != "":
+		return indexer.ByIndex(cache.NamespaceIndex, q.namespace)
+	}
+	return indexer.List(), nil
+}
+
+func (q *jobQuery) matches(obj metav1.Object) bool {
+	rj, ok := obj.(*execution.Job)
+	if !ok {
+		return false
+	}
+	if q.namespace != "" && rj.Namespace != q.namespace {
+		return false
+	}
+	if q.jobConfig != "" {
+		if ref := metav1.GetControllerOf(rj); ref == nil || ref.Kind != execution.KindJobConfig ||
+			ref.Name != q.jobConfig {
+			return false
+		}
+	}
+	if q.phases.Len() > 0 && !q.phases.Has(string(rj.Status.Phase)) {
+		return false
+	}
+	if !matchesCreationTime(rj, q.createdAfter, q.createdBefore) {
+		return false
+	}
+	if !q.selector.Matches(labels.Set(rj.Labels)) {
+		return false
+	}
+	for name, value := range q.options {
+		key := options.MakeOptionVariableName(execution.Option{Name: name})
+		if actual, ok := rj.Spec.Substitutions[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
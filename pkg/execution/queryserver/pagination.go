@@ -0,0 +1,112 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// continueToken marks the position of the last object returned in a page.
+// Since the token refers to a position in the sort order rather than an offset,
+// pages remain consistent even if objects are created or deleted in between
+// requests.
+type continueToken struct {
+	CreationTimestamp metav1.Time `json:"t"`
+	Key               string      `json:"k"`
+}
+
+func newContinueToken(obj metav1.Object) *continueToken {
+	return &continueToken{
+		CreationTimestamp: obj.GetCreationTimestamp(),
+		Key:               objectKey(obj),
+	}
+}
+
+func (t *continueToken) encode() (string, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeContinueToken(value string) (*continueToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid continue token")
+	}
+	token := &continueToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, errors.Wrapf(err, "invalid continue token")
+	}
+	return token, nil
+}
+
+// paginate sorts the objects and returns a single page of at most limit objects
+// that come after the continue token, if specified.
+func paginate(objs []metav1.Object, limit int64, token *continueToken) ([]metav1.Object, metav1.ListMeta) {
+	sortObjects(objs)
+
+	var start int
+	if token != nil {
+		start = sort.Search(len(objs), func(i int) bool {
+			return comesBefore(token.CreationTimestamp, token.Key, objs[i])
+		})
+	}
+	end := len(objs)
+	if int64(end-start) > limit {
+		end = start + int(limit)
+	}
+
+	page := objs[start:end]
+	var listMeta metav1.ListMeta
+	if remaining := int64(len(objs) - end); remaining > 0 && len(page) > 0 {
+		if value, err := newContinueToken(page[len(page)-1]).encode(); err == nil {
+			listMeta.Continue = value
+			listMeta.RemainingItemCount = &remaining
+		}
+	}
+
+	return page, listMeta
+}
+
+// sortObjects sorts objects by creation timestamp from newest to oldest, using
+// the namespaced name to break ties.
+func sortObjects(objs []metav1.Object) {
+	sort.Slice(objs, func(i, j int) bool {
+		return comesBefore(objs[i].GetCreationTimestamp(), objectKey(objs[i]), objs[j])
+	})
+}
+
+// comesBefore returns true if an object with the given creation timestamp and
+// key is sorted before obj.
+func comesBefore(createTime metav1.Time, key string, obj metav1.Object) bool {
+	objCreateTime := obj.GetCreationTimestamp()
+	if !createTime.Equal(&objCreateTime) {
+		return objCreateTime.Before(&createTime)
+	}
+	return key < objectKey(obj)
+}
+
+func objectKey(obj metav1.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
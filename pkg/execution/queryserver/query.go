@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// parseList parses a list of values which may be repeated or comma-separated.
+func parseList(values []string) sets.String {
+	result := sets.NewString()
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result.Insert(item)
+			}
+		}
+	}
+	return result
+}
+
+// parseTime parses an optional RFC3339 timestamp from the given query parameter.
+func parseTime(values url.Values, key string) (*time.Time, error) {
+	value := values.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %v: %v", key, err)
+	}
+	return &t, nil
+}
+
+// parseSelector parses an optional label selector from the query parameters.
+func parseSelector(values url.Values) (labels.Selector, error) {
+	selector, err := labels.Parse(values.Get("labelSelector"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for labelSelector: %v", err)
+	}
+	return selector, nil
+}
+
+// matchesCreationTime returns true if the object was created within the
+// interval [after, before). Either of the bounds may be nil.
+func matchesCreationTime(obj metav1.Object, after, before *time.Time) bool {
+	createTime := obj.GetCreationTimestamp().Time
+	if after != nil && createTime.Before(*after) {
+		return false
+	}
+	if before != nil && !createTime.Before(*before) {
+		return false
+	}
+	return true
+}
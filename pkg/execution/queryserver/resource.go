@@ -0,0 +1,60 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver
+
+import (
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// resource defines how queries are served for a single resource type.
+type resource struct {
+	// Name of the resource in the URL path, which is also the plural resource name.
+	name string
+
+	informer cache.SharedIndexInformer
+	indexers cache.Indexers
+	watchers *broadcaster
+
+	// parseQuery parses a query from the URL query parameters.
+	parseQuery func(values url.Values) (query, error)
+
+	// toItem returns a copy of the object with its TypeMeta populated.
+	toItem func(obj metav1.Object) interface{}
+
+	// newList returns a list object containing the given items.
+	newList func(items []interface{}, listMeta metav1.ListMeta) interface{}
+}
+
+func (r *resource) groupResource() schema.GroupResource {
+	return execution.GroupVersion.WithResource(r.name).GroupResource()
+}
+
+// query is a parsed query for objects of a single resource type.
+type query interface {
+	// candidates returns a superset of all objects that match the query, using an
+	// index where possible to avoid iterating through all objects.
+	candidates(indexer cache.Indexer) ([]interface{}, error)
+
+	// matches returns true if the object matches the query.
+	matches(obj metav1.Object) bool
+}
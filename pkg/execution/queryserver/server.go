@@ -0,0 +1,267 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+)
+
+const (
+	defaultPathPrefix   = "/query"
+	defaultLimit        = 100
+	defaultMaxLimit     = 1000
+	watcherBufferLength = 1000
+)
+
+// Server serves a read-only query API for Jobs and JobConfigs from the informer
+// caches of the execution controller. It supports filtered and paginated list
+// queries using additional cache indexes, as well as filtered watch streams.
+//
+// The following endpoints are served under the configured path prefix:
+//
+//	GET /v1/jobs: Lists or watches Jobs.
+//	GET /v1/jobconfigs: Lists or watches JobConfigs.
+//
+// List responses are returned as a JobList or JobConfigList, ordered by
+// creation timestamp from newest to oldest. If there are more results, the
+// continue field in the list metadata can be passed as the continue query
+// parameter to fetch the next page. Watch requests (i.e. watch=true) return a
+// stream of newline-delimited watch events, starting with ADDED events for all
+// existing objects that match the query.
+type Server struct {
+	pathPrefix   string
+	defaultLimit int64
+	maxLimit     int64
+	mux          *http.ServeMux
+}
+
+// NewServer returns a new Server. Must be called before the informers are
+// started, since additional indexes have to be added to the informers.
+func NewServer(ctrlContext controllercontext.Context, cfg *configv1alpha1.QueryServerSpec) (*Server, error) {
+	if cfg == nil {
+		cfg = &configv1alpha1.QueryServerSpec{}
+	}
+
+	s := &Server{
+		pathPrefix:   cfg.PathPrefix,
+		defaultLimit: cfg.DefaultLimit,
+		maxLimit:     cfg.MaxLimit,
+		mux:          http.NewServeMux(),
+	}
+	if s.pathPrefix == "" {
+		s.pathPrefix = defaultPathPrefix
+	}
+	if s.maxLimit <= 0 {
+		s.maxLimit = defaultMaxLimit
+	}
+	if s.defaultLimit <= 0 {
+		s.defaultLimit = defaultLimit
+	}
+	if s.defaultLimit > s.maxLimit {
+		s.defaultLimit = s.maxLimit
+	}
+
+	informers := ctrlContext.Informers().Furiko().Execution().V1alpha1()
+	resources := []*resource{
+		newJobResource(informers.Jobs().Informer()),
+		newJobConfigResource(informers.JobConfigs().Informer()),
+	}
+	for _, res := range resources {
+		if err := res.informer.AddIndexers(res.indexers); err != nil {
+			return nil, errors.Wrapf(err, "cannot add indexers for %v", res.name)
+		}
+		res.informer.AddEventHandler(res.watchers)
+		s.mux.HandleFunc(s.pathPrefix+"/v1/"+res.name, s.handle(res))
+	}
+
+	return s, nil
+}
+
+// Pattern returns the pattern that the Server should be registered with.
+func (s *Server) Pattern() string {
+	return s.pathPrefix + "/"
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handle(res *resource) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, apierrors.NewMethodNotSupported(res.groupResource(), r.Method))
+			return
+		}
+		if !res.informer.HasSynced() {
+			writeError(w, apierrors.NewServiceUnavailable("informer cache is not yet synced"))
+			return
+		}
+
+		values := r.URL.Query()
+		q, err := res.parseQuery(values)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest(err.Error()))
+			return
+		}
+
+		if watchParam := values.Get("watch"); watchParam != "" {
+			isWatch, err := strconv.ParseBool(watchParam)
+			if err != nil {
+				writeError(w, apierrors.NewBadRequest("invalid value for watch: "+watchParam))
+				return
+			}
+			if isWatch {
+				s.serveWatch(w, r, res, q)
+				return
+			}
+		}
+
+		s.serveList(w, res, q, values)
+	}
+}
+
+func (s *Server) serveList(w http.ResponseWriter, res *resource, q query, values url.Values) {
+	limit := s.defaultLimit
+	if limitParam := values.Get("limit"); limitParam != "" {
+		parsed, err := strconv.ParseInt(limitParam, 10, 64)
+		if err != nil || parsed <= 0 {
+			writeError(w, apierrors.NewBadRequest("invalid value for limit: "+limitParam))
+			return
+		}
+		limit = parsed
+		if limit > s.maxLimit {
+			limit = s.maxLimit
+		}
+	}
+
+	var token *continueToken
+	if continueParam := values.Get("continue"); continueParam != "" {
+		parsed, err := decodeContinueToken(continueParam)
+		if err != nil {
+			writeError(w, apierrors.NewBadRequest(err.Error()))
+			return
+		}
+		token = parsed
+	}
+
+	objs, err := list(res.informer.GetIndexer(), q)
+	if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+
+	page, listMeta := paginate(objs, limit, token)
+	items := make([]interface{}, 0, len(page))
+	for _, obj := range page {
+		items = append(items, res.toItem(obj))
+	}
+	writeJSON(w, http.StatusOK, res.newList(items, listMeta))
+}
+
+func (s *Server) serveWatch(w http.ResponseWriter, r *http.Request, res *resource, q query) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, apierrors.NewInternalError(errors.New("streaming is not supported")))
+		return
+	}
+
+	// Start watching before listing, so that no events are missed in between.
+	watcher := res.watchers.watch()
+	defer res.watchers.stop(watcher)
+
+	objs, err := list(res.informer.GetIndexer(), q)
+	if err != nil {
+		writeError(w, apierrors.NewInternalError(err))
+		return
+	}
+	sortObjects(objs)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, obj := range objs {
+		if err := encoder.Encode(newWatchEvent(watch.Added, res.toItem(obj))); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-watcher.events:
+			if !ok {
+				klog.V(4).InfoS("queryserver: watcher was closed", "resource", res.name)
+				return
+			}
+			eventType, obj, ok := e.filter(q)
+			if !ok {
+				continue
+			}
+			if err := encoder.Encode(newWatchEvent(eventType, res.toItem(obj))); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// list returns all objects matching the query, using an index to look up
+// candidate objects if possible.
+func list(indexer cache.Indexer, q query) ([]metav1.Object, error) {
+	candidates, err := q.candidates(indexer)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]metav1.Object, 0, len(candidates))
+	for _, candidate := range candidates {
+		obj, ok := candidate.(metav1.Object)
+		if !ok || !q.matches(obj) {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func writeError(w http.ResponseWriter, err *apierrors.StatusError) {
+	status := err.Status()
+	status.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Status"}
+	writeJSON(w, int(status.Code), status)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		klog.ErrorS(err, "queryserver: cannot write response")
+	}
+}
@@ -0,0 +1,374 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/queryserver"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	testNamespace = "test"
+)
+
+var (
+	jobConfig1 = &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "jobconfig1",
+			Namespace:         testNamespace,
+			UID:               "uid1",
+			CreationTimestamp: testutils.Mkmtime("2022-04-01T00:00:00Z"),
+		},
+		Status: execution.JobConfigStatus{
+			State: execution.JobConfigExecuting,
+		},
+	}
+
+	jobConfig2 = &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "jobconfig2",
+			Namespace:         testNamespace,
+			UID:               "uid2",
+			CreationTimestamp: testutils.Mkmtime("2022-04-02T00:00:00Z"),
+			Labels: map[string]string{
+				"team": "infra",
+			},
+		},
+		Status: execution.JobConfigStatus{
+			State: execution.JobConfigReady,
+		},
+	}
+
+	job1 = newJob("job1", testNamespace, jobConfig1, "2022-04-01T04:00:00Z", execution.JobSucceeded,
+		map[string]string{"option.env": "prod"})
+	job2 = newJob("job2", testNamespace, jobConfig1, "2022-04-01T05:00:00Z", execution.JobRetryLimitExceeded,
+		map[string]string{"option.env": "staging"})
+	job3 = newJob("job3", testNamespace, jobConfig2, "2022-04-01T05:00:00Z", execution.JobRunning, nil)
+	job4 = newJob("job4", "other", nil, "2022-04-01T06:00:00Z", execution.JobQueued, nil)
+)
+
+func newJob(
+	name, namespace string,
+	rjc *execution.JobConfig,
+	createTime string,
+	phase execution.JobPhase,
+	substitutions map[string]string,
+) *execution.Job {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: testutils.Mkmtime(createTime),
+			ResourceVersion:   "1",
+		},
+		Spec: execution.JobSpec{
+			Substitutions: substitutions,
+		},
+		Status: execution.JobStatus{
+			Phase: phase,
+		},
+	}
+	if rjc != nil {
+		rj.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(rjc, execution.GVKJobConfig),
+		}
+	}
+	return rj
+}
+
+func setUp(ctx context.Context, t *testing.T, cfg *configv1alpha1.QueryServerSpec) (*mock.Context, *queryserver.Server) {
+	ctrlContext := mock.NewContext()
+	client := ctrlContext.MockClientsets().FurikoMock()
+	for _, rjc := range []*execution.JobConfig{jobConfig1, jobConfig2} {
+		_, err := client.ExecutionV1alpha1().JobConfigs(rjc.Namespace).Create(ctx, rjc, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	for _, rj := range []*execution.Job{job1, job2, job3, job4} {
+		_, err := client.ExecutionV1alpha1().Jobs(rj.Namespace).Create(ctx, rj, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	server, err := queryserver.NewServer(ctrlContext, cfg)
+	assert.NoError(t, err)
+	assert.NoError(t, ctrlContext.Start(ctx))
+	informers := ctrlContext.Informers().Furiko().Execution().V1alpha1()
+	if !cache.WaitForCacheSync(ctx.Done(), informers.Jobs().Informer().HasSynced,
+		informers.JobConfigs().Informer().HasSynced) {
+		t.Fatal("cannot sync caches")
+	}
+	return ctrlContext, server
+}
+
+func TestServer_List(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		wantCode  int
+		wantNames []string
+	}{
+		{
+			name:      "list all jobs",
+			path:      "/query/v1/jobs",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job4", "job2", "job3", "job1"},
+		},
+		{
+			name:      "filter by namespace",
+			path:      "/query/v1/jobs?namespace=test",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job2", "job3", "job1"},
+		},
+		{
+			name:      "filter by job config",
+			path:      "/query/v1/jobs?namespace=test&jobConfig=jobconfig1",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job2", "job1"},
+		},
+		{
+			name:     "job config without namespace",
+			path:     "/query/v1/jobs?jobConfig=jobconfig1",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "filter by phases",
+			path:      "/query/v1/jobs?phase=Succeeded,Running&phase=Queued",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job4", "job3", "job1"},
+		},
+		{
+			name:      "filter by time range",
+			path:      "/query/v1/jobs?createdAfter=2022-04-01T05:00:00Z&createdBefore=2022-04-01T06:00:00Z",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job2", "job3"},
+		},
+		{
+			name:     "invalid time",
+			path:     "/query/v1/jobs?createdAfter=yesterday",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "filter by option value",
+			path:      "/query/v1/jobs?option.env=staging",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job2"},
+		},
+		{
+			name:      "filter by phase and job config",
+			path:      "/query/v1/jobs?namespace=test&jobConfig=jobconfig1&phase=Succeeded",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"job1"},
+		},
+		{
+			name:      "list job configs",
+			path:      "/query/v1/jobconfigs",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"jobconfig2", "jobconfig1"},
+		},
+		{
+			name:      "filter job configs by state",
+			path:      "/query/v1/jobconfigs?state=Executing",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"jobconfig1"},
+		},
+		{
+			name:      "filter job configs by label selector",
+			path:      "/query/v1/jobconfigs?labelSelector=team%3Dinfra",
+			wantCode:  http.StatusOK,
+			wantNames: []string{"jobconfig2"},
+		},
+		{
+			name:     "invalid label selector",
+			path:     "/query/v1/jobconfigs?labelSelector=!!",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown resource",
+			path:     "/query/v1/pods",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, server := setUp(ctx, t, nil)
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantCode {
+				t.Fatalf("ServeHTTP() code = %v, want %v, body = %v", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var list struct {
+				Items []metav1.PartialObjectMetadata `json:"items"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+				t.Fatalf("cannot unmarshal response: %v", err)
+			}
+			names := make([]string, 0, len(list.Items))
+			for _, item := range list.Items {
+				names = append(names, item.Name)
+			}
+			if !cmp.Equal(tt.wantNames, names) {
+				t.Errorf("ServeHTTP() names not equal\ndiff = %v", cmp.Diff(tt.wantNames, names))
+			}
+		})
+	}
+}
+
+func TestServer_Paginate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, server := setUp(ctx, t, &configv1alpha1.QueryServerSpec{
+		PathPrefix: "/api",
+		MaxLimit:   3,
+	})
+
+	var names []string
+	var pages int
+	path := "/api/v1/jobs?limit=2"
+	for {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("ServeHTTP() code = %v, body = %v", w.Code, w.Body.String())
+		}
+		var list execution.JobList
+		if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+			t.Fatalf("cannot unmarshal response: %v", err)
+		}
+		pages++
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+		if list.Continue == "" {
+			break
+		}
+		path = "/api/v1/jobs?limit=2&continue=" + list.Continue
+	}
+
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, []string{"job4", "job2", "job3", "job1"}, names)
+
+	// Limit is capped to the maximum limit.
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs?limit=100", nil))
+	var list execution.JobList
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Items, 3)
+	if assert.NotNil(t, list.RemainingItemCount) {
+		assert.Equal(t, int64(1), *list.RemainingItemCount)
+	}
+
+	// Invalid continue token.
+	w = httptest.NewRecorder()
+	server.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs?continue=invalid", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestServer_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctrlContext, server := setUp(ctx, t, nil)
+	client := ctrlContext.MockClientsets().FurikoMock()
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, time.Second*10)
+	defer reqCancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet,
+		httpServer.URL+"/query/v1/jobs?namespace=test&phase=Running,Queued&watch=true", nil)
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("cannot watch: %v", err)
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	type event struct {
+		Type   string                       `json:"type"`
+		Object metav1.PartialObjectMetadata `json:"object"`
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	nextEvent := func() string {
+		if !scanner.Scan() {
+			t.Fatalf("cannot read event: %v", scanner.Err())
+		}
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("cannot unmarshal event: %v", err)
+		}
+		return e.Type + " " + e.Object.Name
+	}
+
+	// Existing objects are sent first.
+	assert.Equal(t, "ADDED job3", nextEvent())
+
+	// New Job that matches the query.
+	job5 := newJob("job5", testNamespace, jobConfig2, "2022-04-01T07:00:00Z", execution.JobQueued, nil)
+	_, err = client.ExecutionV1alpha1().Jobs(testNamespace).Create(ctx, job5, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "ADDED job5", nextEvent())
+
+	// New Job that does not match the query is not sent.
+	job6 := newJob("job6", "other", nil, "2022-04-01T07:00:00Z", execution.JobQueued, nil)
+	_, err = client.ExecutionV1alpha1().Jobs("other").Create(ctx, job6, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	// Job is modified but still matches the query.
+	job5 = job5.DeepCopy()
+	job5.ResourceVersion = "2"
+	job5.Status.Phase = execution.JobRunning
+	_, err = client.ExecutionV1alpha1().Jobs(testNamespace).Update(ctx, job5, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "MODIFIED job5", nextEvent())
+
+	// Job no longer matches the query.
+	job3 := job3.DeepCopy()
+	job3.ResourceVersion = "2"
+	job3.Status.Phase = execution.JobSucceeded
+	_, err = client.ExecutionV1alpha1().Jobs(testNamespace).Update(ctx, job3, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETED job3", nextEvent())
+
+	// Job is deleted.
+	err = client.ExecutionV1alpha1().Jobs(testNamespace).Delete(ctx, job5.Name, metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "DELETED job5", nextEvent())
+}
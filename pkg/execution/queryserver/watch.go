@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package queryserver
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// watchEvent is a single event in a watch stream, which follows the format of
+// watch events returned by the kube-apiserver.
+type watchEvent struct {
+	Type   watch.EventType `json:"type"`
+	Object interface{}     `json:"object"`
+}
+
+func newWatchEvent(eventType watch.EventType, obj interface{}) watchEvent {
+	return watchEvent{
+		Type:   eventType,
+		Object: obj,
+	}
+}
+
+// event is an informer event. oldObj is nil for additions, and newObj is nil
+// for deletions.
+type event struct {
+	oldObj metav1.Object
+	newObj metav1.Object
+}
+
+// filter returns the watch event type and object for the event as seen by a
+// watcher with the given query. Objects which start or stop matching the query
+// are sent as ADDED and DELETED events respectively. Returns false if the event
+// should not be sent.
+func (e event) filter(q query) (watch.EventType, metav1.Object, bool) {
+	oldMatches := e.oldObj != nil && q.matches(e.oldObj)
+	newMatches := e.newObj != nil && q.matches(e.newObj)
+	switch {
+	case oldMatches && newMatches:
+		return watch.Modified, e.newObj, true
+	case newMatches:
+		return watch.Added, e.newObj, true
+	case oldMatches:
+		return watch.Deleted, e.oldObj, true
+	}
+	return "", nil, false
+}
+
+type watcher struct {
+	events chan event
+}
+
+// broadcaster receives informer events and sends them to all watchers. Sending
+// never blocks the informer, so watchers which fall too far behind are closed
+// and are expected to watch again.
+type broadcaster struct {
+	mu           sync.Mutex
+	watchers     map[*watcher]struct{}
+	bufferLength int
+}
+
+var _ cache.ResourceEventHandler = (*broadcaster)(nil)
+
+func newBroadcaster(bufferLength int) *broadcaster {
+	return &broadcaster{
+		watchers:     make(map[*watcher]struct{}),
+		bufferLength: bufferLength,
+	}
+}
+
+// watch adds a new watcher, which must be stopped after it is no longer used.
+func (b *broadcaster) watch() *watcher {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	w := &watcher{
+		events: make(chan event, b.bufferLength),
+	}
+	b.watchers[w] = struct{}{}
+	return w
+}
+
+// stop removes the watcher and closes its events channel, if not yet closed.
+func (b *broadcaster) stop(w *watcher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(w)
+}
+
+func (b *broadcaster) removeLocked(w *watcher) {
+	if _, ok := b.watchers[w]; ok {
+		delete(b.watchers, w)
+		close(w.events)
+	}
+}
+
+func (b *broadcaster) broadcast(e event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for w := range b.watchers {
+		select {
+		case w.events <- e:
+		default:
+			klog.V(2).InfoS("queryserver: closing watcher which is too far behind",
+				"bufferLength", b.bufferLength)
+			b.removeLocked(w)
+		}
+	}
+}
+
+func (b *broadcaster) OnAdd(obj interface{}) {
+	if newObj, ok := obj.(metav1.Object); ok {
+		b.broadcast(event{newObj: newObj})
+	}
+}
+
+func (b *broadcaster) OnUpdate(oldObj, newObj interface{}) {
+	oldMeta, ok := oldObj.(metav1.Object)
+	if !ok {
+		return
+	}
+	newMeta, ok := newObj.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	// Skip periodic resyncs, since the object was not modified.
+	if oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+		return
+	}
+	b.broadcast(event{oldObj: oldMeta, newObj: newMeta})
+}
+
+func (b *broadcaster) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if oldObj, ok := obj.(metav1.Object); ok {
+		b.broadcast(event{oldObj: oldObj})
+	}
+}
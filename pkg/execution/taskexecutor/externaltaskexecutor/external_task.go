@@ -0,0 +1,69 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package externaltaskexecutor
+
+import (
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/tasks"
+	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
+)
+
+// NewExternalTask creates a new ExternalTask object for the given Job and
+// index. ExternalTasks share the same labels and naming scheme as Pod tasks.
+func NewExternalTask(rj *execution.Job, index int64) (*execution.ExternalTask, error) {
+	var taskSpec execution.JobTaskSpec
+	if jobTemplate := rj.Spec.Template; jobTemplate != nil {
+		taskSpec = jobTemplate.Task
+	}
+	if taskSpec.External == nil {
+		return nil, fmt.Errorf("job does not specify an external task template")
+	}
+
+	taskTemplate := &tasks.TaskTemplate{
+		Name:       podtaskexecutor.GetPodIndexedNameForJob(rj, index),
+		RetryIndex: index,
+	}
+
+	task := &execution.ExternalTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rj.GetNamespace(),
+			Name:      taskTemplate.Name,
+			Labels: labels.Set{
+				podtaskexecutor.LabelKeyJobUID:         string(rj.GetUID()),
+				podtaskexecutor.LabelKeyTaskRetryIndex: strconv.Itoa(int(taskTemplate.RetryIndex)),
+			},
+		},
+		Spec: execution.ExternalTaskSpec{
+			ExternalTaskTemplate: variablecontext.SubstituteExternalTaskTemplateForTask(
+				rj, taskTemplate, *taskSpec.External,
+			),
+		},
+	}
+
+	// Add OwnerReference back to Job
+	controllerRef := metav1.NewControllerRef(rj, execution.GVKJob)
+	task.OwnerReferences = append(task.OwnerReferences, *controllerRef)
+
+	return task, nil
+}
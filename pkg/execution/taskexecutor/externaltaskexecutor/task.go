@@ -0,0 +1,271 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package externaltaskexecutor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+	executionv1alpha1 "github.com/furiko-io/furiko/pkg/generated/clientset/versioned/typed/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/meta"
+)
+
+const (
+	reasonError          = "Error"
+	reasonRunningTimeout = "RunningTimeout"
+
+	messageRunningTimeout = "Task was killed after exceeding its running timeout"
+)
+
+// Task is a wrapper around ExternalTask that fulfils tasks.Task.
+type Task struct {
+	*execution.ExternalTask
+	client executionv1alpha1.ExternalTaskInterface
+}
+
+func NewTask(task *execution.ExternalTask, client executionv1alpha1.ExternalTaskInterface) *Task {
+	return &Task{ExternalTask: task, client: client}
+}
+
+func (t *Task) GetTaskRef() execution.TaskRef {
+	reason, message := t.GetReasonMessage()
+	task := execution.TaskRef{
+		Name:              t.GetName(),
+		CreationTimestamp: t.GetCreationTimestamp(),
+		Status: execution.TaskStatus{
+			State:   t.GetState(),
+			Result:  t.GetResult(),
+			Reason:  reason,
+			Message: message,
+			Outputs: t.Status.Outputs,
+		},
+	}
+
+	if exitCode := t.Status.ExitCode; exitCode != nil {
+		task.ContainerStates = []execution.TaskContainerState{
+			{
+				ExitCode: *exitCode,
+				Reason:   t.Status.Reason,
+				Message:  t.Status.Message,
+			},
+		}
+	}
+	if index, ok := t.GetRetryIndex(); ok {
+		task.RetryIndex = index
+	}
+	if ts := t.Status.StartTime; !ts.IsZero() {
+		task.RunningTimestamp = ts.DeepCopy()
+	}
+	if ts := t.GetFinishTimestamp(); !ts.IsZero() {
+		task.FinishTimestamp = &ts
+	}
+
+	return task
+}
+
+func (t *Task) GetKind() string {
+	return execution.KindExternalTask
+}
+
+func (t *Task) GetRetryIndex() (int64, bool) {
+	val, ok := t.Labels[podtaskexecutor.LabelKeyTaskRetryIndex]
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false
+	}
+	return int64(i), true
+}
+
+// GetStepName always returns false, since ExternalTasks cannot be created for
+// Jobs with steps.
+func (t *Task) GetStepName() (string, bool) {
+	return "", false
+}
+
+// IsControlledBy returns true if the ExternalTask's controller reference points
+// to the Job. If it does not have a controller reference, the Job UID label
+// will be used instead.
+func (t *Task) IsControlledBy(rj *execution.Job) bool {
+	if ref := metav1.GetControllerOf(t.ExternalTask); ref != nil {
+		return ref.UID == rj.GetUID()
+	}
+	return t.Labels[podtaskexecutor.LabelKeyJobUID] == string(rj.GetUID())
+}
+
+// RequiresKillWithDeletion returns true if the ExternalTask has not yet been
+// started by any runner, since there is no runner to observe the kill
+// timestamp.
+func (t *Task) RequiresKillWithDeletion() bool {
+	return t.Status.Phase == "" || t.Status.Phase == execution.ExternalTaskPending
+}
+
+func (t *Task) GetKillTimestamp() *metav1.Time {
+	return t.Spec.KillTimestamp
+}
+
+func (t *Task) SetKillTimestamp(ctx context.Context, ts time.Time) error {
+	newTask := t.ExternalTask.DeepCopy()
+
+	// Cannot increase kill timestamp further than it was before.
+	if ktime.IsTimeSetAndEarlierThan(t.GetKillTimestamp(), ts) {
+		ts = t.GetKillTimestamp().Time
+	}
+
+	killTimestamp := metav1.NewTime(ts)
+	newTask.Spec.KillTimestamp = &killTimestamp
+	return t.update(ctx, newTask)
+}
+
+func (t *Task) GetKilledFromPendingTimeoutMarker() bool {
+	_, ok := t.Annotations[podtaskexecutor.LabelKeyKilledFromPendingTimeout]
+	return ok
+}
+
+func (t *Task) SetKilledFromPendingTimeoutMarker(ctx context.Context) error {
+	newTask := t.ExternalTask.DeepCopy()
+	meta.SetAnnotation(newTask, podtaskexecutor.LabelKeyKilledFromPendingTimeout, "1")
+	return t.update(ctx, newTask)
+}
+
+func (t *Task) GetKilledFromRunningTimeoutMarker() bool {
+	_, ok := t.Annotations[podtaskexecutor.LabelKeyKilledFromRunningTimeout]
+	return ok
+}
+
+func (t *Task) SetKilledFromRunningTimeoutMarker(ctx context.Context) error {
+	newTask := t.ExternalTask.DeepCopy()
+	meta.SetAnnotation(newTask, podtaskexecutor.LabelKeyKilledFromRunningTimeout, "1")
+	return t.update(ctx, newTask)
+}
+
+// GetNodeLostTimestamp always returns nil, since ExternalTasks do not run on
+// any node in the cluster.
+func (t *Task) GetNodeLostTimestamp() *metav1.Time {
+	return nil
+}
+
+func (t *Task) update(ctx context.Context, newTask *execution.ExternalTask) error {
+	updatedTask, err := t.client.Update(ctx, newTask, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not update externaltask")
+	}
+
+	t.ExternalTask = updatedTask
+	return nil
+}
+
+func (t *Task) GetState() execution.TaskState {
+	switch {
+	case t.isKilledFromRunningTimeout():
+		return execution.TaskDeadlineExceeded
+	case t.isKilledFromPendingTimeout():
+		return execution.TaskPendingTimeout
+	case ktime.IsTimeSetAndEarlier(t.GetKillTimestamp()):
+		if !t.IsFinished() {
+			return execution.TaskKilling
+		}
+		if t.Status.Phase == execution.ExternalTaskFailed {
+			return execution.TaskKilled
+		}
+	}
+
+	switch t.Status.Phase {
+	case execution.ExternalTaskRunning:
+		return execution.TaskRunning
+	case execution.ExternalTaskSucceeded:
+		return execution.TaskSuccess
+	case execution.ExternalTaskFailed:
+		return execution.TaskFailed
+	case execution.ExternalTaskPending:
+	}
+
+	return execution.TaskStaging
+}
+
+func (t *Task) GetResult() *execution.JobResult {
+	switch {
+	case t.isKilledFromPendingTimeout():
+		return job.GetResultPtr(execution.JobResultPendingTimeout)
+	case t.isKilledFromRunningTimeout():
+		return job.GetResultPtr(execution.JobResultDeadlineExceeded)
+	}
+
+	switch t.Status.Phase {
+	case execution.ExternalTaskSucceeded:
+		return job.GetResultPtr(execution.JobResultSuccess)
+	case execution.ExternalTaskFailed:
+		if !t.GetKillTimestamp().IsZero() {
+			return job.GetResultPtr(execution.JobResultKilled)
+		}
+		return job.GetResultPtr(execution.JobResultTaskFailed)
+	case execution.ExternalTaskPending, execution.ExternalTaskRunning:
+	}
+
+	return nil
+}
+
+func (t *Task) GetReasonMessage() (string, string) {
+	if t.isKilledFromRunningTimeout() {
+		return reasonRunningTimeout, messageRunningTimeout
+	}
+	if t.Status.Reason != "" && t.Status.Message != "" {
+		return t.Status.Reason, t.Status.Message
+	}
+	if t.Status.Phase == execution.ExternalTaskFailed && t.Status.ExitCode != nil && *t.Status.ExitCode != 0 {
+		return reasonError, fmt.Sprintf("Command exited with status %v", *t.Status.ExitCode)
+	}
+	return "", ""
+}
+
+// GetFinishTimestamp returns the time that the ExternalTask finished, falling
+// back to its start time or creation time if the runner did not report it.
+func (t *Task) GetFinishTimestamp() metav1.Time {
+	if !t.IsFinished() {
+		return metav1.Time{}
+	}
+	if ts := t.Status.FinishTime; !ts.IsZero() {
+		return *ts
+	}
+	if ts := t.Status.StartTime; !ts.IsZero() {
+		return *ts
+	}
+	return t.GetCreationTimestamp()
+}
+
+func (t *Task) IsFinished() bool {
+	return t.Status.Phase.IsTerminal()
+}
+
+func (t *Task) isKilledFromPendingTimeout() bool {
+	return t.Status.Phase == execution.ExternalTaskFailed && t.GetKilledFromPendingTimeoutMarker()
+}
+
+func (t *Task) isKilledFromRunningTimeout() bool {
+	return t.Status.Phase == execution.ExternalTaskFailed && t.GetKilledFromRunningTimeoutMarker()
+}
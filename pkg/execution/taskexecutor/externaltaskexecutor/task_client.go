@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package externaltaskexecutor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	coreerrors "github.com/furiko-io/furiko/pkg/core/errors"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/tasks"
+	executionv1alpha1 "github.com/furiko-io/furiko/pkg/generated/clientset/versioned/typed/execution/v1alpha1"
+)
+
+// TaskClient operates on ExternalTask tasks.
+type TaskClient struct {
+	client executionv1alpha1.ExternalTaskInterface
+	rj     *execution.Job
+}
+
+func NewTaskClient(client executionv1alpha1.ExternalTaskInterface, rj *execution.Job) *TaskClient {
+	return &TaskClient{
+		client: client,
+		rj:     rj,
+	}
+}
+
+func (c *TaskClient) Get(ctx context.Context, name string) (tasks.Task, error) {
+	task, err := c.client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get externaltask")
+	}
+	return c.new(task), nil
+}
+
+func (c *TaskClient) Index(ctx context.Context, index int64) (tasks.Task, error) {
+	task, err := c.Get(ctx, podtaskexecutor.GetPodIndexedNameForJob(c.rj, index))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRetryIndex(task, index); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (c *TaskClient) Step(_ context.Context, step string) (tasks.Task, error) {
+	return nil, fmt.Errorf("externaltasks cannot be created for step %v", step)
+}
+
+func (c *TaskClient) CreateIndex(ctx context.Context, index int64) (tasks.Task, error) {
+	newTask, err := NewExternalTask(c.rj, index)
+	if err != nil {
+		return nil, coreerrors.NewAdmissionRefusedError(err.Error())
+	}
+
+	task, err := c.client.Create(ctx, newTask, metav1.CreateOptions{})
+
+	// Rejected by apiserver, do not attempt to retry and raise an
+	// AdmissionRefusedError instead.
+	if kerrors.IsInvalid(err) {
+		return nil, coreerrors.NewAdmissionRefusedError(err.Error())
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create externaltask")
+	}
+
+	return c.new(task), nil
+}
+
+func (c *TaskClient) CreateStep(_ context.Context, step string) (tasks.Task, error) {
+	return nil, coreerrors.NewAdmissionRefusedError(fmt.Sprintf("externaltasks cannot be created for step %v", step))
+}
+
+func (c *TaskClient) Delete(ctx context.Context, name string, force bool) error {
+	opts := metav1.DeleteOptions{}
+
+	// Force delete using grace period set as 0.
+	if force {
+		var grace int64
+		opts.GracePeriodSeconds = &grace
+	}
+
+	if err := c.client.Delete(ctx, name, opts); err != nil {
+		return errors.Wrapf(err, "could not delete externaltask")
+	}
+
+	return nil
+}
+
+// DeleteResources is a no-op, since ExternalTasks do not create any additional
+// resources.
+func (c *TaskClient) DeleteResources(_ context.Context, _ execution.TaskRef) error {
+	return nil
+}
+
+// checkRetryIndex returns a NotFound error if the task does not have the given
+// retry index. Tasks that were retried in-place share the same name, so the
+// task found with the name may be for a different retry index.
+func checkRetryIndex(task tasks.Task, index int64) error {
+	if taskIndex, ok := task.GetRetryIndex(); ok && taskIndex != index {
+		return errors.Wrapf(kerrors.NewNotFound(execution.Resource("externaltasks"), task.GetName()),
+			"task has retry index %v instead of %v", taskIndex, index)
+	}
+	return nil
+}
+
+func (c *TaskClient) new(task *execution.ExternalTask) tasks.Task {
+	return NewTask(task, c.client)
+}
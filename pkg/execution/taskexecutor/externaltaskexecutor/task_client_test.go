@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package externaltaskexecutor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/externaltaskexecutor"
+	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/fake"
+)
+
+const (
+	jobNamespace = "test"
+	jobName      = "my-job"
+	jobUID       = "e2ea7b6b-8c0a-4a6a-86cd-b2bc20d28f2a"
+)
+
+var (
+	fakeJob = &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: jobNamespace,
+			UID:       jobUID,
+		},
+		Spec: execution.JobSpec{
+			Template: &execution.JobTemplateSpec{
+				Task: execution.JobTaskSpec{
+					External: &execution.ExternalTaskTemplate{
+						RunnerName: "runner",
+						Command:    []string{"echo", "${job.name}", "${task.retry_index}"},
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestNewTaskClient(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientset := fake.NewSimpleClientset()
+	client := externaltaskexecutor.NewTaskClient(clientset.ExecutionV1alpha1().ExternalTasks(jobNamespace), fakeJob)
+
+	// Create new index
+	newTask, err := client.CreateIndex(ctx, 1)
+	assert.NoError(t, err)
+	index, ok := newTask.GetRetryIndex()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), index)
+	assert.True(t, newTask.IsControlledBy(fakeJob))
+	assert.Equal(t, execution.KindExternalTask, newTask.GetKind())
+
+	// Command should be substituted
+	created, err := clientset.ExecutionV1alpha1().ExternalTasks(jobNamespace).
+		Get(ctx, newTask.GetName(), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "runner", created.Spec.RunnerName)
+	assert.Equal(t, []string{"echo", jobName, "1"}, created.Spec.Command)
+
+	// Should be able to get task by index
+	task, err := client.Index(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, newTask.GetName(), task.GetName())
+
+	// Returns NotFound error when index not found
+	_, err = client.Index(ctx, 2)
+	assert.True(t, kerrors.IsNotFound(err))
+
+	// Set kill timestamp
+	assert.NoError(t, task.SetKillTimestamp(ctx, metav1.Now().Time))
+	updated, err := clientset.ExecutionV1alpha1().ExternalTasks(jobNamespace).
+		Get(ctx, newTask.GetName(), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotNil(t, updated.Spec.KillTimestamp)
+
+	// Delete newly created task
+	err = client.Delete(ctx, newTask.GetName(), false)
+	assert.NoError(t, err)
+
+	// Not idempotent
+	err = client.Delete(ctx, newTask.GetName(), false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package externaltaskexecutor
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	jobtasks "github.com/furiko-io/furiko/pkg/execution/tasks"
+	executionv1alpha1 "github.com/furiko-io/furiko/pkg/generated/clientset/versioned/typed/execution/v1alpha1"
+	executionlister "github.com/furiko-io/furiko/pkg/generated/listers/execution/v1alpha1"
+)
+
+// TaskLister lists ExternalTask tasks.
+type TaskLister struct {
+	lister executionlister.ExternalTaskLister
+	client executionv1alpha1.ExternalTaskInterface
+	rj     *execution.Job
+}
+
+func NewTaskLister(
+	lister executionlister.ExternalTaskLister, client executionv1alpha1.ExternalTaskInterface, rj *execution.Job,
+) *TaskLister {
+	return &TaskLister{
+		lister: lister,
+		client: client,
+		rj:     rj,
+	}
+}
+
+func (l *TaskLister) Get(name string) (jobtasks.Task, error) {
+	task, err := l.lister.ExternalTasks(l.rj.GetNamespace()).Get(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get task")
+	}
+	return l.new(task), nil
+}
+
+func (l *TaskLister) Index(index int64) (jobtasks.Task, error) {
+	task, err := l.Get(podtaskexecutor.GetPodIndexedNameForJob(l.rj, index))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRetryIndex(task, index); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (l *TaskLister) List() ([]jobtasks.Task, error) {
+	selector := labels.SelectorFromSet(podtaskexecutor.LabelPodsForJob(l.rj))
+	externalTasks, err := l.lister.ExternalTasks(l.rj.GetNamespace()).List(selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list externaltasks")
+	}
+	tasks := make([]jobtasks.Task, 0, len(externalTasks))
+	for _, task := range externalTasks {
+		tasks = append(tasks, l.new(task))
+	}
+	return tasks, nil
+}
+
+func (l *TaskLister) new(task *execution.ExternalTask) jobtasks.Task {
+	return NewTask(task, l.client)
+}
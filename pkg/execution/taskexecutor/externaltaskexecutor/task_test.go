@@ -0,0 +1,229 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package externaltaskexecutor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/externaltaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+var (
+	startTime = metav1.NewTime(time.Now().Add(-time.Minute))
+	killTime  = metav1.NewTime(time.Now().Add(-time.Second))
+
+	taskPending = &execution.ExternalTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "job.1",
+		},
+	}
+
+	taskRunning = &execution.ExternalTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "job.1",
+		},
+		Status: execution.ExternalTaskStatus{
+			Phase:     execution.ExternalTaskRunning,
+			StartTime: &startTime,
+		},
+	}
+
+	taskSucceeded = &execution.ExternalTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "job.1",
+		},
+		Status: execution.ExternalTaskStatus{
+			Phase:      execution.ExternalTaskSucceeded,
+			StartTime:  &startTime,
+			FinishTime: &killTime,
+			ExitCode:   pointer.Int32(0),
+		},
+	}
+
+	taskFailed = &execution.ExternalTask{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "job.1",
+		},
+		Status: execution.ExternalTaskStatus{
+			Phase:      execution.ExternalTaskFailed,
+			StartTime:  &startTime,
+			FinishTime: &killTime,
+			ExitCode:   pointer.Int32(1),
+		},
+	}
+
+	taskKilling = func() *execution.ExternalTask {
+		newTask := taskRunning.DeepCopy()
+		newTask.Spec.KillTimestamp = &killTime
+		return newTask
+	}()
+
+	taskKilled = func() *execution.ExternalTask {
+		newTask := taskFailed.DeepCopy()
+		newTask.Spec.KillTimestamp = &killTime
+		return newTask
+	}()
+
+	taskKilledByRunningTimeout = func() *execution.ExternalTask {
+		newTask := taskKilled.DeepCopy()
+		newTask.Annotations = map[string]string{
+			podtaskexecutor.LabelKeyKilledFromRunningTimeout: "1",
+		}
+		return newTask
+	}()
+)
+
+func TestTask_GetState(t *testing.T) {
+	tests := []struct {
+		name string
+		task *execution.ExternalTask
+		want execution.TaskState
+	}{
+		{
+			name: "task pending",
+			task: taskPending,
+			want: execution.TaskStaging,
+		},
+		{
+			name: "task running",
+			task: taskRunning,
+			want: execution.TaskRunning,
+		},
+		{
+			name: "task succeeded",
+			task: taskSucceeded,
+			want: execution.TaskSuccess,
+		},
+		{
+			name: "task failed",
+			task: taskFailed,
+			want: execution.TaskFailed,
+		},
+		{
+			name: "task killing",
+			task: taskKilling,
+			want: execution.TaskKilling,
+		},
+		{
+			name: "task killed",
+			task: taskKilled,
+			want: execution.TaskKilled,
+		},
+		{
+			name: "task killed from running timeout",
+			task: taskKilledByRunningTimeout,
+			want: execution.TaskDeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			task := externaltaskexecutor.NewTask(tt.task, nil)
+			if got := task.GetState(); got != tt.want {
+				t.Errorf("GetState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_GetResult(t *testing.T) {
+	tests := []struct {
+		name string
+		task *execution.ExternalTask
+		want *execution.JobResult
+	}{
+		{
+			name: "task pending",
+			task: taskPending,
+		},
+		{
+			name: "task running",
+			task: taskRunning,
+		},
+		{
+			name: "task succeeded",
+			task: taskSucceeded,
+			want: job.GetResultPtr(execution.JobResultSuccess),
+		},
+		{
+			name: "task failed",
+			task: taskFailed,
+			want: job.GetResultPtr(execution.JobResultTaskFailed),
+		},
+		{
+			name: "task killing",
+			task: taskKilling,
+		},
+		{
+			name: "task killed",
+			task: taskKilled,
+			want: job.GetResultPtr(execution.JobResultKilled),
+		},
+		{
+			name: "task killed from running timeout",
+			task: taskKilledByRunningTimeout,
+			want: job.GetResultPtr(execution.JobResultDeadlineExceeded),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			task := externaltaskexecutor.NewTask(tt.task, nil)
+			if got := task.GetResult(); !cmp.Equal(got, tt.want) {
+				t.Errorf("GetResult() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_GetTaskRef(t *testing.T) {
+	task := externaltaskexecutor.NewTask(taskFailed, nil)
+	want := execution.TaskRef{
+		Name:             "job.1",
+		RunningTimestamp: &startTime,
+		FinishTimestamp:  &killTime,
+		Status: execution.TaskStatus{
+			State:   execution.TaskFailed,
+			Result:  job.GetResultPtr(execution.JobResultTaskFailed),
+			Reason:  "Error",
+			Message: "Command exited with status 1",
+		},
+		ContainerStates: []execution.TaskContainerState{
+			{ExitCode: 1},
+		},
+	}
+	if got := task.GetTaskRef(); !cmp.Equal(got, want) {
+		t.Errorf("GetTaskRef() not equal\ndiff = %v", cmp.Diff(want, got))
+	}
+}
+
+func TestTask_RequiresKillWithDeletion(t *testing.T) {
+	if !externaltaskexecutor.NewTask(taskPending, nil).RequiresKillWithDeletion() {
+		t.Errorf("RequiresKillWithDeletion() = false for pending task, want true")
+	}
+	if externaltaskexecutor.NewTask(taskRunning, nil).RequiresKillWithDeletion() {
+		t.Errorf("RequiresKillWithDeletion() = true for running task, want false")
+	}
+}
@@ -17,23 +17,45 @@
 package taskexecutor
 
 import (
+	"fmt"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/externaltaskexecutor"
 	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
 	"github.com/furiko-io/furiko/pkg/execution/tasks"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/features"
 )
 
 // Manager is a task executor manager. It holds references to task executor
 // factories to create task executors on demand.
 type Manager struct {
-	tasks.ExecutorFactory
+	pod      tasks.ExecutorFactory
+	external tasks.ExecutorFactory
 }
 
-// NewManager returns a task executor manager that currently always returns the
-// Pod task executor.
+var _ tasks.ExecutorFactory = (*Manager)(nil)
+
+// NewManager returns a task executor manager that returns the ExternalTask
+// executor for Jobs that specify an external task template, and the Pod task
+// executor otherwise.
 func NewManager(
 	clientsets controllercontext.Clientsets, informers controllercontext.Informers,
 ) *Manager {
 	return &Manager{
-		ExecutorFactory: podtaskexecutor.NewFactory(clientsets, informers),
+		pod:      podtaskexecutor.NewFactory(clientsets, informers),
+		external: externaltaskexecutor.NewFactory(clientsets, informers),
+	}
+}
+
+func (m *Manager) ForJob(rj *execution.Job) (tasks.Executor, error) {
+	if job.IsExternal(rj) {
+		if !features.Enabled(features.ExternalTaskExecutor) {
+			return nil, fmt.Errorf("cannot use external task executor, feature gate %v is disabled",
+				features.ExternalTaskExecutor)
+		}
+		return m.external.ForJob(rj)
 	}
+	return m.pod.ForJob(rj)
 }
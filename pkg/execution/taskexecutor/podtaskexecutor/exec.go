@@ -0,0 +1,100 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podtaskexecutor
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// CommandExecutor knows how to execute a command in a container of a Pod.
+type CommandExecutor interface {
+	// Exec executes the command in the given container, and blocks until the
+	// command exits or the context is done.
+	Exec(ctx context.Context, namespace, name, container string, command []string) error
+}
+
+// RemoteCommandExecutor executes commands in containers using the exec
+// subresource of Pods.
+type RemoteCommandExecutor struct {
+	config *rest.Config
+	client kubernetes.Interface
+}
+
+var _ CommandExecutor = (*RemoteCommandExecutor)(nil)
+
+func NewRemoteCommandExecutor(config *rest.Config, client kubernetes.Interface) *RemoteCommandExecutor {
+	return &RemoteCommandExecutor{config: config, client: client}
+}
+
+func (e *RemoteCommandExecutor) Exec(
+	ctx context.Context, namespace, name, container string, command []string,
+) error {
+	if e.config == nil {
+		return errors.New("cannot exec without rest config")
+	}
+
+	req := e.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return errors.Wrapf(err, "cannot create executor")
+	}
+
+	// NOTE(irvinlim): Stream does not accept a context, so we stop waiting once
+	// the context is done. The stream will be closed once the command exits.
+	var stderr bytes.Buffer
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- executor.Stream(remotecommand.StreamOptions{
+			Stdout: &bytes.Buffer{},
+			Stderr: &stderr,
+		})
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return errors.Wrapf(err, "command failed: %v", msg)
+			}
+			return errors.Wrapf(err, "command failed")
+		}
+	}
+
+	return nil
+}
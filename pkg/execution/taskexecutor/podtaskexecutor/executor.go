@@ -48,7 +48,11 @@ func (e *executor) Lister() tasks.TaskLister {
 }
 
 func (e *executor) Client() tasks.TaskClient {
-	return NewPodTaskClient(e.client.Pods(e.rj.GetNamespace()), e.rj)
+	return NewPodTaskClient(
+		e.client.Pods(e.rj.GetNamespace()),
+		e.client.PersistentVolumeClaims(e.rj.GetNamespace()),
+		e.rj,
+	)
 }
 
 type factory struct {
@@ -31,6 +31,14 @@ var (
 	// the task.
 	LabelKeyTaskRetryIndex = executiongroup.AddGroupToLabel("task-retry-index")
 
+	// LabelKeyTaskStep label is added on Pods to indicate the name of the step
+	// that the task was created for.
+	LabelKeyTaskStep = executiongroup.AddGroupToLabel("task-step")
+
+	// LabelKeyTaskOrphaned label is added on Pods that were orphaned from their
+	// Job when the Job was deleted, so that they can be cleaned up externally.
+	LabelKeyTaskOrphaned = executiongroup.AddGroupToLabel("task-orphaned")
+
 	// LabelKeyTaskKillTimestamp annotation will be added on Pods as the
 	// authoritative time we requested to kill the Task.
 	LabelKeyTaskKillTimestamp = executiongroup.AddGroupToLabel("task-kill-timestamp")
@@ -38,6 +46,55 @@ var (
 	// LabelKeyKilledFromPendingTimeout annotation will be added on Pods that they
 	// are killed from pending timeout.
 	LabelKeyKilledFromPendingTimeout = executiongroup.AddGroupToLabel("task-killed-from-pending-timeout")
+
+	// LabelKeyKilledFromRunningTimeout annotation will be added on Pods that they
+	// are killed from running timeout.
+	LabelKeyKilledFromRunningTimeout = executiongroup.AddGroupToLabel("task-killed-from-running-timeout")
+
+	// LabelKeyKilledFromStalled annotation will be added on Pods that they are
+	// killed from being stalled.
+	LabelKeyKilledFromStalled = executiongroup.AddGroupToLabel("task-killed-from-stalled")
+
+	// LabelKeyTaskStalledTimestamp annotation will be added on Pods to store the
+	// time that the task was first detected to be stalled, in unix seconds.
+	LabelKeyTaskStalledTimestamp = executiongroup.AddGroupToLabel("task-stalled-timestamp")
+
+	// LabelKeyTaskPreKillHookTimestamp annotation will be added on Pods to store
+	// the time that the pre-kill hook was started, in unix seconds.
+	LabelKeyTaskPreKillHookTimestamp = executiongroup.AddGroupToLabel("task-pre-kill-hook-timestamp")
+
+	// LabelKeyTaskRequiredContainers annotation will be added on Pods to store the
+	// comma-separated list of containers that must succeed for the task to be
+	// considered successful.
+	LabelKeyTaskRequiredContainers = executiongroup.AddGroupToLabel("task-required-containers")
+
+	// LabelKeyTaskProgressPercent annotation can be added on Pods by the task
+	// itself to report the percentage of the task that is completed.
+	LabelKeyTaskProgressPercent = executiongroup.AddGroupToLabel("task-progress-percent")
+
+	// LabelKeyTaskProgressMessage annotation can be added on Pods by the task
+	// itself to report a descriptive message of its progress.
+	LabelKeyTaskProgressMessage = executiongroup.AddGroupToLabel("task-progress-message")
+
+	// LabelKeyTaskOutputs annotation can be added on Pods by the task itself to
+	// emit key/value outputs, specified as a JSON object.
+	LabelKeyTaskOutputs = executiongroup.AddGroupToLabel("task-outputs")
+
+	// LabelKeyTaskCPUSeconds annotation will be added on Pods to store the total
+	// CPU time consumed by the task so far, in CPU-seconds.
+	LabelKeyTaskCPUSeconds = executiongroup.AddGroupToLabel("task-cpu-seconds")
+
+	// LabelKeyTaskPeakMemory annotation will be added on Pods to store the peak
+	// memory usage of the task observed so far.
+	LabelKeyTaskPeakMemory = executiongroup.AddGroupToLabel("task-peak-memory")
+
+	// LabelKeyTaskResourceUsageSampleTime annotation will be added on Pods to
+	// store the time that the resource usage of the task was last sampled.
+	LabelKeyTaskResourceUsageSampleTime = executiongroup.AddGroupToLabel("task-resource-usage-sample-time")
+
+	// LabelKeyTaskLogArchiveKey annotation can be added on Pods by a log archiver
+	// to store the key that the task's logs were archived to.
+	LabelKeyTaskLogArchiveKey = executiongroup.AddGroupToLabel("task-log-archive-key")
 )
 
 // LabelPodsForJob returns a labels.Set that labels all Pods for a Job.
@@ -18,6 +18,28 @@ package podtaskexecutor
 
 import (
 	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/options"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+const (
+	// maxPodNameLength is the maximum length of a Pod name generated from a
+	// TaskNameTemplate. This is further restricted from the maximum length of a
+	// DNS subdomain to ensure that it can still be used as the Pod's hostname.
+	maxPodNameLength = validation.DNS1123LabelMaxLength
+)
+
+var (
+	invalidPodNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+	invalidPodNameDots  = regexp.MustCompile(`[.-]*\.[.-]*`)
 )
 
 // GetPodIndexedName returns a name for the pod with a given index.
@@ -25,3 +47,112 @@ import (
 func GetPodIndexedName(name string, index int64) string {
 	return fmt.Sprintf("%v.%v", name, index)
 }
+
+// GetPodStepName returns a name for the pod for a given step.
+// Step names cannot consist of only digits, so they will never conflict with
+// names returned by GetPodIndexedName.
+func GetPodStepName(name string, step string) string {
+	return fmt.Sprintf("%v.%v", name, step)
+}
+
+// GetPodVolumeClaimName returns a name for the PersistentVolumeClaim created for
+// the given volume of a pod.
+func GetPodVolumeClaimName(podName string, volumeName string) string {
+	return fmt.Sprintf("%v-%v", podName, volumeName)
+}
+
+// GetPodIndexedNameForJob returns a name for the pod with a given index for the
+// Job. If the Job specifies a TaskNameTemplate, it will be used to generate the
+// name, otherwise it falls back to GetPodIndexedName. If the Job uses the
+// InPlace retry mode, all retries share the name of the first task.
+func GetPodIndexedNameForJob(rj *execution.Job, index int64) string {
+	if job.IsInPlaceRetry(rj) {
+		index = 1
+	}
+	identity := strconv.Itoa(int(index))
+	vars := map[string]string{
+		"task.retry_index": identity,
+	}
+	if name, ok := renderPodName(rj, identity, vars); ok {
+		return name
+	}
+	return GetPodIndexedName(rj.GetName(), index)
+}
+
+// GetPodStepNameForJob returns a name for the pod for a given step for the Job.
+// If the Job specifies a TaskNameTemplate, it will be used to generate the
+// name, otherwise it falls back to GetPodStepName.
+func GetPodStepNameForJob(rj *execution.Job, step string) string {
+	vars := map[string]string{
+		"task.retry_index": "1",
+		"task.step":        step,
+	}
+	if name, ok := renderPodName(rj, step, vars); ok {
+		return name
+	}
+	return GetPodStepName(rj.GetName(), step)
+}
+
+// renderPodName renders the TaskNameTemplate of the Job, where identity uniquely
+// identifies the task among all tasks of the Job. Returns false if the Job does
+// not specify a TaskNameTemplate, or if the rendered name is empty.
+//
+// The rendered name is guaranteed to be unique among all tasks in the
+// namespace: a short hash computed from the Job's UID and the task's identity
+// is appended if the template does not already reference the hash, or does not
+// reference both the Job's name and the task's identity. Names that exceed
+// maxPodNameLength will be truncated and suffixed with the hash.
+func renderPodName(rj *execution.Job, identity string, vars map[string]string) (string, bool) {
+	if rj.Spec.Template == nil || rj.Spec.Template.TaskNameTemplate == "" {
+		return "", false
+	}
+	tmpl := rj.Spec.Template.TaskNameTemplate
+	hash := computeTaskHash(rj, identity)
+
+	identityVar := "${task.retry_index}"
+	if _, ok := vars["task.step"]; ok {
+		identityVar = "${task.step}"
+	}
+	needHash := !strings.Contains(tmpl, "${task.hash}") &&
+		!(strings.Contains(tmpl, "${job.name}") && strings.Contains(tmpl, identityVar))
+
+	subMaps := make([]map[string]string, 0, 3)
+	if len(rj.Spec.Substitutions) > 0 {
+		subMaps = append(subMaps, rj.Spec.Substitutions)
+	}
+	subMaps = append(subMaps, vars, map[string]string{
+		"job.name":  rj.GetName(),
+		"task.hash": hash,
+	})
+	name := options.SubstituteVariableMaps(tmpl, subMaps, []string{"jobconfig.", "job.", "task.", "option."})
+	name = sanitizePodName(name)
+	if name == "" {
+		return "", false
+	}
+
+	if needHash || len(name) > maxPodNameLength {
+		maxLength := maxPodNameLength - len(hash) - 1
+		if len(name) > maxLength {
+			name = sanitizePodName(name[:maxLength])
+		}
+		name = fmt.Sprintf("%v-%v", name, hash)
+	}
+
+	return name, true
+}
+
+// sanitizePodName replaces all characters that are not allowed in a Pod name
+// with dashes, collapses dots that are adjacent to other dots or dashes, and
+// trims any leading or trailing non-alphanumeric characters.
+func sanitizePodName(name string) string {
+	name = invalidPodNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	name = invalidPodNameDots.ReplaceAllString(name, ".")
+	return strings.Trim(name, ".-")
+}
+
+// computeTaskHash returns a short hash that uniquely identifies a task.
+func computeTaskHash(rj *execution.Job, identity string) string {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(string(rj.GetUID()) + "/" + rj.GetName() + "/" + identity))
+	return fmt.Sprintf("%08x", hasher.Sum32())
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podtaskexecutor_test
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+)
+
+func TestGetPodIndexedNameForJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		jobName    string
+		template   string
+		index      int64
+		want       string
+		wantHashed bool
+	}{
+		{
+			name:    "no template",
+			jobName: "my-sample-job",
+			index:   1,
+			want:    "my-sample-job.1",
+		},
+		{
+			name:     "template with job name and retry index",
+			jobName:  "my-sample-job",
+			template: "${job.name}-attempt-${task.retry_index}",
+			index:    2,
+			want:     "my-sample-job-attempt-2",
+		},
+		{
+			name:     "template with hash",
+			jobName:  "my-sample-job",
+			template: "etl-${task.hash}",
+			index:    1,
+			want:     "etl-",
+		},
+		{
+			name:       "append hash if not unique",
+			jobName:    "my-sample-job",
+			template:   "${job.name}",
+			index:      1,
+			want:       "my-sample-job-",
+			wantHashed: true,
+		},
+		{
+			name:     "sanitize invalid characters",
+			jobName:  "my-sample-job",
+			template: "${job.name}_Retry..${task.retry_index}.",
+			index:    3,
+			want:     "my-sample-job-retry.3",
+		},
+		{
+			name:       "truncate long names",
+			jobName:    strings.Repeat("a", 70),
+			template:   "${job.name}.${task.retry_index}",
+			index:      1,
+			want:       strings.Repeat("a", 54) + "-",
+			wantHashed: true,
+		},
+		{
+			name:     "fallback if template renders empty name",
+			jobName:  "my-sample-job",
+			template: "${job.unknown}",
+			index:    1,
+			want:     "my-sample-job.1",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tt.jobName,
+					UID:  jobUID,
+				},
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						TaskNameTemplate: tt.template,
+					},
+				},
+			}
+			got := podtaskexecutor.GetPodIndexedNameForJob(rj, tt.index)
+			if !strings.HasPrefix(got, tt.want) {
+				t.Errorf("GetPodIndexedNameForJob() = %v, want prefix %v", got, tt.want)
+			}
+			if tt.wantHashed && len(got) != len(tt.want)+8 {
+				t.Errorf("GetPodIndexedNameForJob() = %v, want hash suffix", got)
+			}
+			if errs := validation.IsDNS1123Subdomain(got); len(errs) > 0 {
+				t.Errorf("GetPodIndexedNameForJob() = %v, not a valid name: %v", got, errs)
+			}
+			if len(got) > validation.DNS1123LabelMaxLength {
+				t.Errorf("GetPodIndexedNameForJob() = %v, exceeds maximum length", got)
+			}
+		})
+	}
+}
+
+func TestGetPodStepNameForJob(t *testing.T) {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-sample-job",
+			UID:  jobUID,
+		},
+		Spec: execution.JobSpec{
+			Template: &execution.JobTemplateSpec{
+				TaskNameTemplate: "${job.name}-${task.retry_index}",
+			},
+		},
+	}
+
+	// Step names must be part of the name, otherwise a hash is appended to
+	// ensure uniqueness.
+	prepare := podtaskexecutor.GetPodStepNameForJob(rj, "prepare")
+	publish := podtaskexecutor.GetPodStepNameForJob(rj, "publish")
+	if prepare == publish {
+		t.Errorf("GetPodStepNameForJob() returned duplicate names: %v", prepare)
+	}
+	if !strings.HasPrefix(prepare, "my-sample-job-1-") {
+		t.Errorf("GetPodStepNameForJob() = %v, want prefix %v", prepare, "my-sample-job-1-")
+	}
+
+	rj.Spec.Template.TaskNameTemplate = "${job.name}-${task.step}"
+	if got, want := podtaskexecutor.GetPodStepNameForJob(rj, "prepare"), "my-sample-job-prepare"; got != want {
+		t.Errorf("GetPodStepNameForJob() = %v, want %v", got, want)
+	}
+}
@@ -17,7 +17,9 @@
 package podtaskexecutor
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,24 +27,61 @@ import (
 
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/tasks"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
+	"github.com/furiko-io/furiko/pkg/utils/meta"
 )
 
 // NewPod creates a new Pod object for the given Job and index.
 func NewPod(rj *execution.Job, index int64) (*corev1.Pod, error) {
-	var template corev1.PodTemplateSpec
+	var taskSpec execution.JobTaskSpec
 	if jobTemplate := rj.Spec.Template; jobTemplate != nil {
-		template = jobTemplate.Task.Template
+		taskSpec = jobTemplate.Task
 	}
 
 	// Generate name for pod.
-	podName := GetPodIndexedName(rj.Name, index)
+	podName := GetPodIndexedNameForJob(rj, index)
 	taskTemplate := &tasks.TaskTemplate{
 		Name:       podName,
 		RetryIndex: index,
-		PodSpec:    template.Spec,
+		PodSpec:    taskSpec.Template.Spec,
 	}
 
+	return newPod(rj, taskTemplate, taskSpec), nil
+}
+
+// NewPodForStep creates a new Pod object for the given Job and step.
+func NewPodForStep(rj *execution.Job, step string) (*corev1.Pod, error) {
+	var taskSpec execution.JobTaskSpec
+	var found bool
+	if jobTemplate := rj.Spec.Template; jobTemplate != nil {
+		for _, stepSpec := range jobTemplate.Steps {
+			if stepSpec.Name == step {
+				taskSpec = stepSpec.Task
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("step %v not found in job", step)
+	}
+
+	// Generate name for pod.
+	podName := GetPodStepNameForJob(rj, step)
+	taskTemplate := &tasks.TaskTemplate{
+		Name:       podName,
+		RetryIndex: job.GetStepRetryIndex(rj, step),
+		Step:       step,
+		PodSpec:    taskSpec.Template.Spec,
+	}
+
+	return newPod(rj, taskTemplate, taskSpec), nil
+}
+
+func newPod(rj *execution.Job, taskTemplate *tasks.TaskTemplate, taskSpec execution.JobTaskSpec) *corev1.Pod {
+	template := taskSpec.Template
+
 	// Generate pod spec.
 	// TODO(irvinlim): This need to be moved out into the controller if we want to
 	//  make the task executor generic.
@@ -51,23 +90,47 @@ func NewPod(rj *execution.Job, index int64) (*corev1.Pod, error) {
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:   rj.GetNamespace(),
-			Name:        podName,
-			Labels:      makeLabels(rj, index, template),
-			Annotations: makeAnnotations(rj, index, template),
-			Finalizers:  makeFinalizers(rj, index, template),
+			Name:        taskTemplate.Name,
+			Labels:      makeLabels(rj, taskTemplate, template),
+			Annotations: makeAnnotations(rj, taskTemplate.RetryIndex, template),
+			Finalizers:  makeFinalizers(rj, taskTemplate.RetryIndex, template),
 		},
 		Spec: podSpec,
 	}
 
+	// Override termination grace period if specified.
+	if grace := taskSpec.KillGracePeriodSeconds; grace != nil {
+		gracePeriod := *grace
+		pod.Spec.TerminationGracePeriodSeconds = &gracePeriod
+	}
+
+	// Store list of required containers, since it is needed to determine the
+	// result of the task.
+	if len(taskSpec.RequiredContainers) > 0 {
+		meta.SetAnnotation(pod, LabelKeyTaskRequiredContainers, strings.Join(taskSpec.RequiredContainers, ","))
+	}
+
+	// Add volumes for ephemeral volume claims.
+	for _, claimTemplate := range taskSpec.VolumeClaimTemplates {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: claimTemplate.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: GetPodVolumeClaimName(pod.Name, claimTemplate.Name),
+				},
+			},
+		})
+	}
+
 	// Add OwnerReference back to Job
 	controllerRef := metav1.NewControllerRef(rj, execution.GVKJob)
 	pod.OwnerReferences = append(pod.OwnerReferences, *controllerRef)
 
-	return pod, nil
+	return pod
 }
 
-func makeLabels(rj *execution.Job, index int64, template corev1.PodTemplateSpec) labels.Set {
-	desiredLabels := make(labels.Set, len(template.Labels)+2)
+func makeLabels(rj *execution.Job, taskTemplate *tasks.TaskTemplate, template corev1.PodTemplateSpec) labels.Set {
+	desiredLabels := make(labels.Set, len(template.Labels)+3)
 	for k, v := range template.Labels {
 		desiredLabels[k] = v
 	}
@@ -75,7 +138,10 @@ func makeLabels(rj *execution.Job, index int64, template corev1.PodTemplateSpec)
 	// Append additional labels.
 	additionalLabels := map[string]string{
 		LabelKeyJobUID:         string(rj.GetUID()),
-		LabelKeyTaskRetryIndex: strconv.Itoa(int(index)),
+		LabelKeyTaskRetryIndex: strconv.Itoa(int(taskTemplate.RetryIndex)),
+	}
+	if taskTemplate.Step != "" {
+		additionalLabels[LabelKeyTaskStep] = taskTemplate.Step
 	}
 	for k, v := range additionalLabels {
 		desiredLabels[k] = v
@@ -84,6 +150,48 @@ func makeLabels(rj *execution.Job, index int64, template corev1.PodTemplateSpec)
 	return desiredLabels
 }
 
+// NewPodVolumeClaims creates the PersistentVolumeClaim objects for the given
+// Pod, which are owned by the Pod.
+func NewPodVolumeClaims(
+	pod *corev1.Pod, claimTemplates []execution.TaskVolumeClaimTemplate,
+) []*corev1.PersistentVolumeClaim {
+	claims := make([]*corev1.PersistentVolumeClaim, 0, len(claimTemplates))
+	for _, claimTemplate := range claimTemplates {
+		claim := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: pod.GetNamespace(),
+				Name:      GetPodVolumeClaimName(pod.GetName(), claimTemplate.Name),
+				Labels: map[string]string{
+					LabelKeyJobUID: pod.Labels[LabelKeyJobUID],
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(pod, corev1.SchemeGroupVersion.WithKind("Pod")),
+				},
+			},
+			Spec: *claimTemplate.Spec.DeepCopy(),
+		}
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// getVolumeClaimTemplates returns the list of volume claim templates for the
+// task with the given step name, or the Job's task if the step is empty.
+func getVolumeClaimTemplates(rj *execution.Job, step string) []execution.TaskVolumeClaimTemplate {
+	if rj.Spec.Template == nil {
+		return nil
+	}
+	if step == "" {
+		return rj.Spec.Template.Task.VolumeClaimTemplates
+	}
+	for _, stepSpec := range rj.Spec.Template.Steps {
+		if stepSpec.Name == step {
+			return stepSpec.Task.VolumeClaimTemplates
+		}
+	}
+	return nil
+}
+
 func makeAnnotations(_ *execution.Job, _ int64, template corev1.PodTemplateSpec) labels.Set {
 	desiredAnnotations := make(labels.Set, len(template.Annotations))
 	for k, v := range template.Annotations {
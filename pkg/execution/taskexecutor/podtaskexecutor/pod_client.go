@@ -28,18 +28,23 @@ import (
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	coreerrors "github.com/furiko-io/furiko/pkg/core/errors"
 	"github.com/furiko-io/furiko/pkg/execution/tasks"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
 )
 
 // PodTaskClient operates on Pod tasks.
 type PodTaskClient struct {
-	client v1.PodInterface
-	rj     *execution.Job
+	client    v1.PodInterface
+	pvcClient v1.PersistentVolumeClaimInterface
+	rj        *execution.Job
 }
 
-func NewPodTaskClient(client v1.PodInterface, rj *execution.Job) *PodTaskClient {
+func NewPodTaskClient(
+	client v1.PodInterface, pvcClient v1.PersistentVolumeClaimInterface, rj *execution.Job,
+) *PodTaskClient {
 	return &PodTaskClient{
-		client: client,
-		rj:     rj,
+		client:    client,
+		pvcClient: pvcClient,
+		rj:        rj,
 	}
 }
 
@@ -52,7 +57,25 @@ func (p *PodTaskClient) Get(ctx context.Context, name string) (tasks.Task, error
 }
 
 func (p *PodTaskClient) Index(ctx context.Context, index int64) (tasks.Task, error) {
-	return p.Get(ctx, GetPodIndexedName(p.rj.GetName(), index))
+	task, err := p.Get(ctx, GetPodIndexedNameForJob(p.rj, index))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRetryIndex(task, index); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (p *PodTaskClient) Step(ctx context.Context, step string) (tasks.Task, error) {
+	task, err := p.Get(ctx, GetPodStepNameForJob(p.rj, step))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRetryIndex(task, job.GetStepRetryIndex(p.rj, step)); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
 func (p *PodTaskClient) CreateIndex(ctx context.Context, index int64) (tasks.Task, error) {
@@ -62,6 +85,22 @@ func (p *PodTaskClient) CreateIndex(ctx context.Context, index int64) (tasks.Tas
 		return nil, err
 	}
 
+	return p.create(ctx, newPod, getVolumeClaimTemplates(p.rj, ""))
+}
+
+func (p *PodTaskClient) CreateStep(ctx context.Context, step string) (tasks.Task, error) {
+	// Create pod object
+	newPod, err := NewPodForStep(p.rj, step)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.create(ctx, newPod, getVolumeClaimTemplates(p.rj, step))
+}
+
+func (p *PodTaskClient) create(
+	ctx context.Context, newPod *corev1.Pod, claimTemplates []execution.TaskVolumeClaimTemplate,
+) (tasks.Task, error) {
 	// Create resource
 	pod, err := p.client.Create(ctx, newPod, metav1.CreateOptions{})
 
@@ -75,9 +114,33 @@ func (p *PodTaskClient) CreateIndex(ctx context.Context, index int64) (tasks.Tas
 		return nil, errors.Wrapf(err, "could not create pod")
 	}
 
+	// Create ephemeral volume claims, which are owned by the pod.
+	if err := p.createVolumeClaims(ctx, pod, claimTemplates); err != nil {
+		// Clean up the pod on a best-effort basis, so that it can be recreated again.
+		_ = p.Delete(ctx, pod.GetName(), true)
+		return nil, err
+	}
+
 	return p.new(pod), nil
 }
 
+func (p *PodTaskClient) createVolumeClaims(
+	ctx context.Context, pod *corev1.Pod, claimTemplates []execution.TaskVolumeClaimTemplate,
+) error {
+	for _, claim := range NewPodVolumeClaims(pod, claimTemplates) {
+		if _, err := p.pvcClient.Create(ctx, claim, metav1.CreateOptions{}); err != nil {
+			if kerrors.IsAlreadyExists(err) {
+				continue
+			}
+			if kerrors.IsInvalid(err) {
+				return coreerrors.NewAdmissionRefusedError(err.Error())
+			}
+			return errors.Wrapf(err, "could not create persistentvolumeclaim %v", claim.GetName())
+		}
+	}
+	return nil
+}
+
 func (p *PodTaskClient) Delete(ctx context.Context, name string, force bool) error {
 	opts := metav1.DeleteOptions{}
 
@@ -94,6 +157,27 @@ func (p *PodTaskClient) Delete(ctx context.Context, name string, force bool) err
 	return nil
 }
 
+func (p *PodTaskClient) DeleteResources(ctx context.Context, taskRef execution.TaskRef) error {
+	for _, claimTemplate := range getVolumeClaimTemplates(p.rj, taskRef.Step) {
+		name := GetPodVolumeClaimName(taskRef.Name, claimTemplate.Name)
+		if err := p.pvcClient.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "could not delete persistentvolumeclaim %v", name)
+		}
+	}
+	return nil
+}
+
+// checkRetryIndex returns a NotFound error if the task does not have the given
+// retry index. Tasks that were retried in-place share the same name, so the
+// task found with the name may be for a different retry index.
+func checkRetryIndex(task tasks.Task, index int64) error {
+	if taskIndex, ok := task.GetRetryIndex(); ok && taskIndex != index {
+		return errors.Wrapf(kerrors.NewNotFound(corev1.Resource("pods"), task.GetName()),
+			"task has retry index %v instead of %v", taskIndex, index)
+	}
+	return nil
+}
+
 func (p *PodTaskClient) new(pod *corev1.Pod) tasks.Task {
 	return NewPodTask(pod, p.client)
 }
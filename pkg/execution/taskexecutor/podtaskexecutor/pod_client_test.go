@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
 )
 
@@ -34,7 +35,11 @@ func TestNewPodTaskClient(t *testing.T) {
 	defer cancel()
 
 	clientset := fake.NewSimpleClientset()
-	client := podtaskexecutor.NewPodTaskClient(clientset.CoreV1().Pods(jobNamespace), fakeJob)
+	client := podtaskexecutor.NewPodTaskClient(
+		clientset.CoreV1().Pods(jobNamespace),
+		clientset.CoreV1().PersistentVolumeClaims(jobNamespace),
+		fakeJob,
+	)
 
 	// Populate Pods
 	createdPods := make([]*corev1.Pod, 0, len(fakePods))
@@ -95,3 +100,52 @@ func TestNewPodTaskClient(t *testing.T) {
 	err = client.Delete(ctx, newTask.GetName(), false)
 	assert.Error(t, err)
 }
+
+func TestPodTaskClient_VolumeClaimTemplates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rj := fakeJob.DeepCopy()
+	rj.Spec.Template = &execution.JobTemplateSpec{
+		Task: execution.JobTaskSpec{
+			VolumeClaimTemplates: []execution.TaskVolumeClaimTemplate{
+				{
+					Name: "scratch",
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					},
+				},
+			},
+		},
+	}
+
+	clientset := fake.NewSimpleClientset()
+	pvcs := clientset.CoreV1().PersistentVolumeClaims(jobNamespace)
+	client := podtaskexecutor.NewPodTaskClient(clientset.CoreV1().Pods(jobNamespace), pvcs, rj)
+
+	// Create new index
+	newTask, err := client.CreateIndex(ctx, 1)
+	assert.NoError(t, err)
+	claimName := podtaskexecutor.GetPodVolumeClaimName(newTask.GetName(), "scratch")
+
+	// Pod should reference the volume claim
+	pod, err := clientset.CoreV1().Pods(jobNamespace).Get(ctx, newTask.GetName(), metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, "scratch", pod.Spec.Volumes[0].Name)
+	assert.Equal(t, claimName, pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	// Volume claim should be created and owned by the pod
+	pvc, err := pvcs.Get(ctx, claimName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, metav1.IsControlledBy(pvc, pod))
+
+	// Delete resources for the task
+	taskRef := execution.TaskRef{Name: newTask.GetName()}
+	assert.NoError(t, client.DeleteResources(ctx, taskRef))
+	_, err = pvcs.Get(ctx, claimName, metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+
+	// Idempotent
+	assert.NoError(t, client.DeleteResources(ctx, taskRef))
+}
@@ -53,7 +53,14 @@ func (p *PodTaskLister) Get(name string) (jobtasks.Task, error) {
 }
 
 func (p *PodTaskLister) Index(index int64) (jobtasks.Task, error) {
-	return p.Get(GetPodIndexedName(p.rj.GetName(), index))
+	task, err := p.Get(GetPodIndexedNameForJob(p.rj, index))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRetryIndex(task, index); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
 
 func (p *PodTaskLister) List() ([]jobtasks.Task, error) {
@@ -18,12 +18,16 @@ package podtaskexecutor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
@@ -37,6 +41,20 @@ const (
 	reasonOOMKilled        = "OOMKilled"
 	reasonError            = "Error"
 	reasonDeadlineExceeded = "DeadlineExceeded"
+	reasonRunningTimeout   = "RunningTimeout"
+	reasonNodeLost         = "NodeLost"
+	reasonStalled          = "Stalled"
+
+	// conditionDisruptionTarget is added by newer versions of Kubernetes when a Pod
+	// is about to be deleted due to a disruption, such as a NoExecute taint.
+	conditionDisruptionTarget    corev1.PodConditionType = "DisruptionTarget"
+	reasonDeletionByTaintManager                         = "DeletionByTaintManager"
+	reasonPreemptionByScheduler                          = "PreemptionByScheduler"
+	reasonEvictionByEvictionAPI                          = "EvictionByEvictionAPI"
+	reasonTerminationByKubelet                           = "TerminationByKubelet"
+
+	messageRunningTimeout = "Task was killed after exceeding its running timeout"
+	messageStalled        = "Task was killed after not reporting any progress within its stall timeout"
 )
 
 // PodTask is a wrapper around Pod that fulfils Task.
@@ -53,17 +71,25 @@ func (p *PodTask) GetTaskRef() execution.TaskRef {
 	reason, message := p.GetReasonMessage()
 	task := execution.TaskRef{
 		Name:              p.GetName(),
+		Step:              p.Pod.Labels[LabelKeyTaskStep],
 		CreationTimestamp: p.GetCreationTimestamp(),
 		Status: execution.TaskStatus{
-			State:   p.GetState(),
-			Result:  p.GetResult(),
-			Reason:  reason,
-			Message: message,
+			State:         p.GetState(),
+			Result:        p.GetResult(),
+			Reason:        reason,
+			Message:       message,
+			Progress:      p.GetProgress(),
+			Outputs:       p.GetOutputs(),
+			ResourceUsage: p.GetResourceUsage(),
+			LogArchive:    p.GetLogArchive(),
 		},
 		NodeName:        p.Spec.NodeName,
 		ContainerStates: p.GetContainerStates(),
 	}
 
+	if index, ok := p.GetRetryIndex(); ok {
+		task.RetryIndex = index
+	}
 	if t := p.GetRunningTimestamp(); !t.IsZero() {
 		task.RunningTimestamp = &t
 	}
@@ -74,6 +100,93 @@ func (p *PodTask) GetTaskRef() execution.TaskRef {
 	return task
 }
 
+// GetProgress returns the progress reported by the task via annotations on the
+// Pod. Returns nil if no progress was reported. Invalid percentages are
+// ignored, and valid ones are clamped between 0 and 100.
+func (p *PodTask) GetProgress() *execution.TaskProgress {
+	progress := &execution.TaskProgress{
+		Message: p.Pod.Annotations[LabelKeyTaskProgressMessage],
+	}
+	if val, ok := p.Pod.Annotations[LabelKeyTaskProgressPercent]; ok {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(val), "%"), 64)
+		if err == nil && !math.IsNaN(percent) {
+			rounded := int32(math.Max(0, math.Min(100, percent)))
+			progress.Percent = &rounded
+		}
+	}
+	if progress.Percent == nil && progress.Message == "" {
+		return nil
+	}
+	return progress
+}
+
+// GetResourceUsage returns the resource usage of the task recorded via
+// annotations on the Pod. Returns nil if no resource usage was recorded, and
+// values that cannot be parsed are ignored.
+func (p *PodTask) GetResourceUsage() *execution.TaskResourceUsage {
+	usage := &execution.TaskResourceUsage{}
+	if val, ok := p.Pod.Annotations[LabelKeyTaskCPUSeconds]; ok {
+		if quantity, err := resource.ParseQuantity(val); err == nil {
+			usage.CPUSeconds = &quantity
+		}
+	}
+	if val, ok := p.Pod.Annotations[LabelKeyTaskPeakMemory]; ok {
+		if quantity, err := resource.ParseQuantity(val); err == nil {
+			usage.PeakMemory = &quantity
+		}
+	}
+	if usage.CPUSeconds == nil && usage.PeakMemory == nil {
+		return nil
+	}
+	return usage
+}
+
+// GetLogArchive returns the location of the task's archived logs, recorded via
+// annotations on the Pod. Returns nil if the logs were not archived.
+func (p *PodTask) GetLogArchive() *execution.TaskLogArchive {
+	key := strings.TrimSpace(p.Pod.Annotations[LabelKeyTaskLogArchiveKey])
+	if key == "" {
+		return nil
+	}
+	return &execution.TaskLogArchive{Key: key}
+}
+
+// GetOutputs returns the key/value outputs emitted by the task, from the
+// termination messages of its containers and the task outputs annotation on the
+// Pod. Values that cannot be parsed as a JSON object are ignored.
+func (p *PodTask) GetOutputs() map[string]string {
+	outputs := make(map[string]string)
+	for _, status := range p.Pod.Status.ContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil {
+			parseOutputs(terminated.Message, outputs)
+		}
+	}
+	if val, ok := p.Pod.Annotations[LabelKeyTaskOutputs]; ok {
+		parseOutputs(val, outputs)
+	}
+	if len(outputs) == 0 {
+		return nil
+	}
+	return outputs
+}
+
+// parseOutputs parses a JSON object and adds its values into outputs. Non-string
+// values are stored using their JSON representation.
+func parseOutputs(data string, outputs map[string]string) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &values); err != nil {
+		return
+	}
+	for key, raw := range values {
+		var str string
+		if err := json.Unmarshal(raw, &str); err == nil {
+			outputs[key] = str
+			continue
+		}
+		outputs[key] = string(raw)
+	}
+}
+
 func (p *PodTask) GetKind() string {
 	return "Pod"
 }
@@ -90,6 +203,53 @@ func (p *PodTask) GetRetryIndex() (int64, bool) {
 	return int64(i), true
 }
 
+func (p *PodTask) GetStepName() (string, bool) {
+	val, ok := p.Pod.Labels[LabelKeyTaskStep]
+	return val, ok && val != ""
+}
+
+// IsControlledBy returns true if the Pod's controller reference points to the
+// Job. If the Pod does not have a controller reference, the Job UID label will
+// be used instead.
+func (p *PodTask) IsControlledBy(rj *execution.Job) bool {
+	if ref := metav1.GetControllerOf(p.Pod); ref != nil {
+		return ref.UID == rj.GetUID()
+	}
+	return p.Pod.Labels[LabelKeyJobUID] == string(rj.GetUID())
+}
+
+// IsOrphaned returns true if the Pod was orphaned from its Job.
+func (p *PodTask) IsOrphaned() bool {
+	_, ok := p.Pod.Labels[LabelKeyTaskOrphaned]
+	return ok
+}
+
+// Orphan removes the owner reference to the Job from the Pod, and labels it as
+// orphaned so that it will not be garbage collected together with the Job.
+func (p *PodTask) Orphan(ctx context.Context) error {
+	newPod := p.Pod.DeepCopy()
+	ownerRefs := make([]metav1.OwnerReference, 0, len(newPod.OwnerReferences))
+	for _, ref := range newPod.OwnerReferences {
+		if ref.APIVersion == execution.GVKJob.GroupVersion().String() && ref.Kind == execution.GVKJob.Kind {
+			continue
+		}
+		ownerRefs = append(ownerRefs, ref)
+	}
+	newPod.OwnerReferences = ownerRefs
+	if newPod.Labels == nil {
+		newPod.Labels = make(map[string]string)
+	}
+	newPod.Labels[LabelKeyTaskOrphaned] = "true"
+
+	updatedPod, err := p.client.Update(ctx, newPod, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not update pod")
+	}
+
+	p.Pod = updatedPod
+	return nil
+}
+
 // RequiresKillWithDeletion returns true if the Task should be killed with
 // deletion instead of active deadline. Currently, we only enforce deletion if
 // the Pod is not yet scheduled, otherwise we should always use kill timestamp
@@ -180,7 +340,103 @@ func (p *PodTask) SetKilledFromPendingTimeoutMarker(ctx context.Context) error {
 	return nil
 }
 
+func (p *PodTask) GetKilledFromRunningTimeoutMarker() bool {
+	_, ok := p.Pod.Annotations[LabelKeyKilledFromRunningTimeout]
+	return ok
+}
+
+func (p *PodTask) SetKilledFromRunningTimeoutMarker(ctx context.Context) error {
+	newPod := p.Pod.DeepCopy()
+	meta.SetAnnotation(newPod, LabelKeyKilledFromRunningTimeout, "1")
+
+	updatedPod, err := p.client.Update(ctx, newPod, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not update pod")
+	}
+
+	p.Pod = updatedPod
+	return nil
+}
+
+func (p *PodTask) GetKilledFromStalledMarker() bool {
+	_, ok := p.Pod.Annotations[LabelKeyKilledFromStalled]
+	return ok
+}
+
+func (p *PodTask) SetKilledFromStalledMarker(ctx context.Context) error {
+	newPod := p.Pod.DeepCopy()
+	meta.SetAnnotation(newPod, LabelKeyKilledFromStalled, "1")
+
+	updatedPod, err := p.client.Update(ctx, newPod, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not update pod")
+	}
+
+	p.Pod = updatedPod
+	return nil
+}
+
+// GetStalledTimestamp returns the time that the task was first detected to be
+// stalled, or nil if it was never stalled.
+func (p *PodTask) GetStalledTimestamp() *metav1.Time {
+	if val, ok := p.Pod.Annotations[LabelKeyTaskStalledTimestamp]; ok {
+		if unix, err := strconv.Atoi(val); err == nil {
+			t := metav1.Unix(int64(unix), 0)
+			return &t
+		}
+	}
+	return nil
+}
+
+func (p *PodTask) SetStalledTimestamp(ctx context.Context, ts time.Time) error {
+	newPod := p.Pod.DeepCopy()
+	meta.SetAnnotation(newPod, LabelKeyTaskStalledTimestamp, strconv.Itoa(int(ts.Unix())))
+
+	updatedPod, err := p.client.Update(ctx, newPod, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not update pod")
+	}
+
+	p.Pod = updatedPod
+	return nil
+}
+
+// GetPreKillHookTimestamp returns the time that the pre-kill hook was started
+// for the task, or nil if it was never started.
+func (p *PodTask) GetPreKillHookTimestamp() *metav1.Time {
+	if val, ok := p.Pod.Annotations[LabelKeyTaskPreKillHookTimestamp]; ok {
+		if unix, err := strconv.Atoi(val); err == nil {
+			t := metav1.Unix(int64(unix), 0)
+			return &t
+		}
+	}
+	return nil
+}
+
+func (p *PodTask) SetPreKillHookTimestamp(ctx context.Context, ts time.Time) error {
+	newPod := p.Pod.DeepCopy()
+	meta.SetAnnotation(newPod, LabelKeyTaskPreKillHookTimestamp, strconv.Itoa(int(ts.Unix())))
+
+	updatedPod, err := p.client.Update(ctx, newPod, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not update pod")
+	}
+
+	p.Pod = updatedPod
+	return nil
+}
+
 func (p *PodTask) GetState() execution.TaskState {
+	// Pod was killed by running timeout.
+	if p.IsKilledFromRunningTimeout() {
+		return execution.TaskDeadlineExceeded
+	}
+
+	// Pod was killed from being stalled.
+	if p.IsKilledFromStalled() {
+		return execution.TaskFailed
+	}
+
 	// Pod has a kill timestamp in the past.
 	if ktime.IsTimeSetAndEarlier(p.GetKillTimestamp()) {
 		if !p.IsFinished() {
@@ -221,6 +477,9 @@ func (p *PodTask) GetState() execution.TaskState {
 	case corev1.PodSucceeded:
 		return execution.TaskSuccess
 	case corev1.PodFailed:
+		if p.IsRequiredContainersSucceeded() {
+			return execution.TaskSuccess
+		}
 		return execution.TaskFailed
 	}
 
@@ -238,6 +497,16 @@ func (p *PodTask) GetResult() *execution.JobResult {
 		return job.GetResultPtr(execution.JobResultPendingTimeout)
 	}
 
+	// Pod was killed by running timeout.
+	if p.IsKilledFromRunningTimeout() {
+		return job.GetResultPtr(execution.JobResultDeadlineExceeded)
+	}
+
+	// Pod was killed from being stalled.
+	if p.IsKilledFromStalled() {
+		return job.GetResultPtr(execution.JobResultTaskFailed)
+	}
+
 	// Killed pod using active deadline.
 	if p.IsDeadlineExceeded() {
 		// Kill timestamp was set but not from pending timeout.
@@ -252,6 +521,9 @@ func (p *PodTask) GetResult() *execution.JobResult {
 	case corev1.PodSucceeded:
 		return job.GetResultPtr(execution.JobResultSuccess)
 	case corev1.PodFailed:
+		if p.IsRequiredContainersSucceeded() {
+			return job.GetResultPtr(execution.JobResultSuccess)
+		}
 		return job.GetResultPtr(execution.JobResultTaskFailed)
 	case corev1.PodPending, corev1.PodRunning:
 	}
@@ -309,6 +581,67 @@ func (p *PodTask) IsKilledFromPendingTimeout() bool {
 	return p.GetKilledFromPendingTimeoutMarker()
 }
 
+// IsEvicted returns true if the Pod was evicted, either by the kubelet due to
+// node pressure, or via the Eviction API or preemption.
+func (p *PodTask) IsEvicted() bool {
+	if p.Status.Reason == job.ReasonEvicted {
+		return true
+	}
+	if condition := GetPodConditionDisruptionTarget(p.Pod); condition != nil &&
+		condition.Status == corev1.ConditionTrue {
+		switch condition.Reason {
+		case reasonPreemptionByScheduler, reasonEvictionByEvictionAPI, reasonTerminationByKubelet:
+			return true
+		}
+	}
+	return false
+}
+
+// GetNodeLostTimestamp returns the time that the Pod's node was observed to be
+// lost, based on its status as updated by the node lifecycle controller.
+func (p *PodTask) GetNodeLostTimestamp() *metav1.Time {
+	if p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed {
+		return nil
+	}
+
+	for _, condition := range p.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionUnknown {
+			return condition.LastTransitionTime.DeepCopy()
+		}
+	}
+	if condition := GetPodConditionDisruptionTarget(p.Pod); condition != nil &&
+		condition.Status == corev1.ConditionTrue && condition.Reason == reasonDeletionByTaintManager {
+		return condition.LastTransitionTime.DeepCopy()
+	}
+
+	// Pods may also be marked with an Unknown phase or NodeLost reason, in which
+	// case we use the deletion timestamp if any.
+	if p.Status.Phase == corev1.PodUnknown || p.Status.Reason == reasonNodeLost {
+		if ts := p.GetDeletionTimestamp(); ts != nil {
+			return ts.DeepCopy()
+		}
+		return ktime.Now()
+	}
+
+	return nil
+}
+
+func (p *PodTask) IsKilledFromRunningTimeout() bool {
+	// Pod is not yet in failed state. It may be possible for a Pod to race between
+	// succeeding and being killed by running timeout.
+	if p.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	return p.GetKilledFromRunningTimeoutMarker()
+}
+
+func (p *PodTask) IsKilledFromStalled() bool {
+	if p.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	return p.GetKilledFromStalledMarker()
+}
+
 func (p *PodTask) IsDeadlineExceeded() bool {
 	return p.Status.Reason == reasonDeadlineExceeded
 }
@@ -316,6 +649,9 @@ func (p *PodTask) IsDeadlineExceeded() bool {
 func (p *PodTask) IsOOMKilled() bool {
 	for _, container := range p.Status.ContainerStatuses {
 		container := container
+		if !p.IsRequiredContainer(container.Name) {
+			continue
+		}
 		if status := GetTerminationStatus(&container); status != nil && status.Reason == reasonOOMKilled {
 			return true
 		}
@@ -323,6 +659,71 @@ func (p *PodTask) IsOOMKilled() bool {
 	return false
 }
 
+// GetFailedInitContainer returns the name and termination status of the first
+// init container that has exited with a non-zero code, and is not currently
+// running again. Returns a nil status if no init container has failed.
+func (p *PodTask) GetFailedInitContainer() (string, *corev1.ContainerStateTerminated) {
+	for _, container := range p.Status.InitContainerStatuses {
+		container := container
+		if container.State.Running != nil {
+			continue
+		}
+		if status := GetTerminationStatus(&container); status != nil && status.ExitCode != 0 {
+			return container.Name, status
+		}
+	}
+	return "", nil
+}
+
+// GetRequiredContainers returns the list of containers that must succeed for
+// the task to be considered successful. Returns nil if all containers must
+// succeed.
+func (p *PodTask) GetRequiredContainers() []string {
+	value, ok := p.Pod.Annotations[LabelKeyTaskRequiredContainers]
+	if !ok || value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// IsRequiredContainer returns true if the container with the given name must
+// succeed for the task to be considered successful.
+func (p *PodTask) IsRequiredContainer(name string) bool {
+	required := p.GetRequiredContainers()
+	if len(required) == 0 {
+		return true
+	}
+	for _, container := range required {
+		if container == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRequiredContainersSucceeded returns true if the Pod specifies a list of
+// required containers, and all of them have terminated successfully. This
+// allows failures of other containers to be ignored.
+func (p *PodTask) IsRequiredContainersSucceeded() bool {
+	required := p.GetRequiredContainers()
+	if len(required) == 0 {
+		return false
+	}
+	succeeded := make(map[string]bool, len(required))
+	for _, container := range p.Status.ContainerStatuses {
+		if terminated := container.State.Terminated; terminated != nil && terminated.ExitCode == 0 &&
+			terminated.Reason != reasonOOMKilled {
+			succeeded[container.Name] = true
+		}
+	}
+	for _, name := range required {
+		if !succeeded[name] {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *PodTask) GetContainerStates() []execution.TaskContainerState {
 	states := make([]execution.TaskContainerState, 0, len(p.Status.ContainerStatuses))
 	for _, container := range p.Status.ContainerStatuses {
@@ -340,17 +741,41 @@ func (p *PodTask) GetContainerStates() []execution.TaskContainerState {
 }
 
 func (p *PodTask) GetReasonMessage() (string, string) { // nolint:gocognit
+	// Pod was killed by running timeout.
+	if p.IsKilledFromRunningTimeout() {
+		return reasonRunningTimeout, messageRunningTimeout
+	}
+
+	// Pod was killed from being stalled.
+	if p.IsKilledFromStalled() {
+		return reasonStalled, messageStalled
+	}
+
 	// Take from Pod if exists.
 	if p.Status.Reason != "" && p.Status.Message != "" {
 		return p.Status.Reason, p.Status.Message
 	}
 
+	// Pod was evicted, such as due to preemption or a node drain.
+	if p.IsEvicted() {
+		message := "Task was evicted"
+		if condition := GetPodConditionDisruptionTarget(p.Pod); condition != nil && condition.Message != "" {
+			message = condition.Message
+		}
+		return job.ReasonEvicted, message
+	}
+
 	// Get failed scheduling reason.
 	if condition := GetPodConditionScheduled(p.Pod); condition != nil &&
 		condition.Status == corev1.ConditionFalse && hasReasonMessage(condition.Reason, condition.Message) {
 		return condition.Reason, condition.Message
 	}
 
+	// Get failed init container reason.
+	if name, status := p.GetFailedInitContainer(); status != nil {
+		return job.ReasonInitFailed, fmt.Sprintf("Init container %v exited with status %v", name, status.ExitCode)
+	}
+
 	// Get failed pod initialization reason.
 	if condition := GetPodConditionInitialized(p.Pod); condition != nil &&
 		condition.Status == corev1.ConditionFalse && hasReasonMessage(condition.Reason, condition.Message) {
@@ -373,6 +798,11 @@ func (p *PodTask) GetReasonMessage() (string, string) { // nolint:gocognit
 		}
 	}
 
+	// Failures of containers that are not required should be ignored.
+	if p.IsRequiredContainersSucceeded() {
+		return "", ""
+	}
+
 	// Take from container Terminated state.
 	for _, statuses := range containerStatusLists {
 		for _, container := range statuses {
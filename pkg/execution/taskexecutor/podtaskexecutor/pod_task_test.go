@@ -18,9 +18,13 @@ package podtaskexecutor_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
@@ -68,6 +72,16 @@ func TestPodTask_GetState(t *testing.T) {
 			Pod:  podOOMKilled,
 			want: execution.TaskFailed,
 		},
+		{
+			name: "pod sidecar error",
+			Pod:  podSidecarError,
+			want: execution.TaskFailed,
+		},
+		{
+			name: "pod sidecar error with required containers succeeded",
+			Pod:  podSidecarErrorWithRequiredContainers,
+			want: execution.TaskSuccess,
+		},
 		{
 			name: "pod killing",
 			Pod:  podKilling,
@@ -83,6 +97,16 @@ func TestPodTask_GetState(t *testing.T) {
 			Pod:  podKilledByPendingTimeout,
 			want: execution.TaskKilled,
 		},
+		{
+			name: "pod killed from running timeout",
+			Pod:  podKilledByRunningTimeout,
+			want: execution.TaskDeadlineExceeded,
+		},
+		{
+			name: "pod killed from being stalled",
+			Pod:  podKilledFromStalled,
+			want: execution.TaskFailed,
+		},
 		{
 			name: "pod DeadlineExceeded",
 			Pod:  podDeadlineExceeded,
@@ -131,6 +155,16 @@ func TestPodTask_GetResult(t *testing.T) {
 			Pod:  podError,
 			want: job.GetResultPtr(execution.JobResultTaskFailed),
 		},
+		{
+			name: "Sidecar error",
+			Pod:  podSidecarError,
+			want: job.GetResultPtr(execution.JobResultTaskFailed),
+		},
+		{
+			name: "Sidecar error with required containers succeeded",
+			Pod:  podSidecarErrorWithRequiredContainers,
+			want: job.GetResultPtr(execution.JobResultSuccess),
+		},
 		{
 			name: "OOMKilled - Container exited with status 0",
 			Pod:  podOOMKilledWithExitCode0,
@@ -161,6 +195,16 @@ func TestPodTask_GetResult(t *testing.T) {
 			Pod:  podKilledByPendingTimeout,
 			want: job.GetResultPtr(execution.JobResultPendingTimeout),
 		},
+		{
+			name: "RunningTimeout",
+			Pod:  podKilledByRunningTimeout,
+			want: job.GetResultPtr(execution.JobResultDeadlineExceeded),
+		},
+		{
+			name: "Stalled",
+			Pod:  podKilledFromStalled,
+			want: job.GetResultPtr(execution.JobResultTaskFailed),
+		},
 		{
 			name: "Killed by pending timeout, still running",
 			Pod:  podKillingByPendingTimeout,
@@ -357,6 +401,374 @@ func TestPodTask_RequiresKillWithDeletion(t *testing.T) {
 	}
 }
 
+func TestPodTask_GetNodeLostTimestamp(t *testing.T) {
+	nodeLostTime := metav1.NewTime(containerStartTime.Add(time.Minute))
+	podNodeLost := podRunning.DeepCopy()
+	podNodeLost.Status.Conditions = append(podNodeLost.Status.Conditions, corev1.PodCondition{
+		Type:               corev1.PodReady,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: nodeLostTime,
+	})
+	podNodeLostTerminating := podRunning.DeepCopy()
+	podNodeLostTerminating.DeletionTimestamp = &nodeLostTime
+	podNodeLostTerminating.Status.Reason = "NodeLost"
+	podCompletedNodeLost := podCompleted.DeepCopy()
+	podCompletedNodeLost.Status.Conditions = podNodeLost.Status.Conditions
+
+	tests := []struct {
+		name string
+		Pod  *corev1.Pod
+		want *metav1.Time
+	}{
+		{
+			name: "pod running",
+			Pod:  &podRunning,
+		},
+		{
+			name: "ready condition unknown",
+			Pod:  podNodeLost,
+			want: &nodeLostTime,
+		},
+		{
+			name: "terminating with NodeLost reason",
+			Pod:  podNodeLostTerminating,
+			want: &nodeLostTime,
+		},
+		{
+			name: "pod completed",
+			Pod:  podCompletedNodeLost,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p := podtaskexecutor.NewPodTask(tt.Pod, nil)
+			if got := p.GetNodeLostTimestamp(); !cmp.Equal(got, tt.want) {
+				t.Errorf("GetNodeLostTimestamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodTask_IsControlledBy(t *testing.T) {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "job",
+			UID:  "f3a5c2d8-3a9b-4a33-9d68-0c0c3a7a5e0b",
+		},
+	}
+	tests := []struct {
+		name string
+		Pod  corev1.Pod
+		want bool
+	}{
+		{
+			name: "controlled by job",
+			Pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rj, execution.GVKJob)},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "controlled by another job",
+			Pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&execution.Job{
+						ObjectMeta: metav1.ObjectMeta{Name: "job", UID: "6e08ee33-ccbe-4fc5-9c46-e29c19cc2fcb"},
+					}, execution.GVKJob)},
+					Labels: map[string]string{
+						podtaskexecutor.LabelKeyJobUID: string(rj.UID),
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "no controller, matching label",
+			Pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						podtaskexecutor.LabelKeyJobUID: string(rj.UID),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "no controller, no label",
+			Pod:  corev1.Pod{},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			p := podtaskexecutor.NewPodTask(&tt.Pod, nil)
+			if got := p.IsControlledBy(rj); got != tt.want {
+				t.Errorf("IsControlledBy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodTask_GetProgress(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *execution.TaskProgress
+	}{
+		{
+			name: "no progress reported",
+		},
+		{
+			name: "percent and message",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskProgressPercent: "42",
+				podtaskexecutor.LabelKeyTaskProgressMessage: "Processed 42 out of 100 records",
+			},
+			want: &execution.TaskProgress{
+				Percent: pointer.Int32(42),
+				Message: "Processed 42 out of 100 records",
+			},
+		},
+		{
+			name: "percent with percent sign and decimals",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskProgressPercent: "66.7%",
+			},
+			want: &execution.TaskProgress{
+				Percent: pointer.Int32(66),
+			},
+		},
+		{
+			name: "percent out of range",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskProgressPercent: "150",
+			},
+			want: &execution.TaskProgress{
+				Percent: pointer.Int32(100),
+			},
+		},
+		{
+			name: "invalid percent with message",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskProgressPercent: "half",
+				podtaskexecutor.LabelKeyTaskProgressMessage: "Halfway there",
+			},
+			want: &execution.TaskProgress{
+				Message: "Halfway there",
+			},
+		},
+		{
+			name: "invalid percent only",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskProgressPercent: "half",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			p := podtaskexecutor.NewPodTask(pod, nil)
+			if diff := cmp.Diff(tt.want, p.GetProgress()); diff != "" {
+				t.Errorf("GetProgress() not equal:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestPodTask_GetResourceUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		wantNil        bool
+		wantCPUSeconds string
+		wantPeakMemory string
+	}{
+		{
+			name:    "no resource usage recorded",
+			wantNil: true,
+		},
+		{
+			name: "cpu seconds and peak memory",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskCPUSeconds: "12500m",
+				podtaskexecutor.LabelKeyTaskPeakMemory: "128Mi",
+			},
+			wantCPUSeconds: "12500m",
+			wantPeakMemory: "128Mi",
+		},
+		{
+			name: "invalid cpu seconds",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskCPUSeconds: "invalid",
+				podtaskexecutor.LabelKeyTaskPeakMemory: "1Gi",
+			},
+			wantPeakMemory: "1Gi",
+		},
+		{
+			name: "all invalid",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskCPUSeconds: "invalid",
+				podtaskexecutor.LabelKeyTaskPeakMemory: "invalid",
+			},
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			p := podtaskexecutor.NewPodTask(pod, nil)
+			got := p.GetResourceUsage()
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("GetResourceUsage() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("GetResourceUsage() = nil, want non-nil")
+			}
+			if got := quantityString(got.CPUSeconds); got != tt.wantCPUSeconds {
+				t.Errorf("GetResourceUsage() CPUSeconds = %v, want %v", got, tt.wantCPUSeconds)
+			}
+			if got := quantityString(got.PeakMemory); got != tt.wantPeakMemory {
+				t.Errorf("GetResourceUsage() PeakMemory = %v, want %v", got, tt.wantPeakMemory)
+			}
+		})
+	}
+}
+
+func TestPodTask_GetLogArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *execution.TaskLogArchive
+	}{
+		{
+			name: "logs not archived",
+		},
+		{
+			name: "empty key",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskLogArchiveKey: " ",
+			},
+		},
+		{
+			name: "archived logs",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskLogArchiveKey: "default/job-1/job-1-0.log",
+			},
+			want: &execution.TaskLogArchive{
+				Key: "default/job-1/job-1-0.log",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			p := podtaskexecutor.NewPodTask(pod, nil)
+			if diff := cmp.Diff(tt.want, p.GetLogArchive()); diff != "" {
+				t.Errorf("GetLogArchive() not equal:\n%v", diff)
+			}
+		})
+	}
+}
+
+func quantityString(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+	return quantity.String()
+}
+
+func TestPodTask_GetOutputs(t *testing.T) {
+	terminated := func(name, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name: name,
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Message: message,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		statuses    []corev1.ContainerStatus
+		want        map[string]string
+	}{
+		{
+			name: "no outputs",
+		},
+		{
+			name: "termination message",
+			statuses: []corev1.ContainerStatus{
+				terminated("container", `{"records": 42, "path": "s3://bucket/output"}`),
+			},
+			want: map[string]string{
+				"records": "42",
+				"path":    "s3://bucket/output",
+			},
+		},
+		{
+			name: "ignore invalid termination message",
+			statuses: []corev1.ContainerStatus{
+				terminated("container", "Completed successfully"),
+			},
+		},
+		{
+			name: "annotation takes precedence",
+			annotations: map[string]string{
+				podtaskexecutor.LabelKeyTaskOutputs: `{"path": "s3://bucket/final"}`,
+			},
+			statuses: []corev1.ContainerStatus{
+				terminated("container", `{"records": 42, "path": "s3://bucket/output"}`),
+			},
+			want: map[string]string{
+				"records": "42",
+				"path":    "s3://bucket/final",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: tt.statuses,
+				},
+			}
+			p := podtaskexecutor.NewPodTask(pod, nil)
+			if diff := cmp.Diff(tt.want, p.GetOutputs()); diff != "" {
+				t.Errorf("GetOutputs() not equal:\n%v", diff)
+			}
+		})
+	}
+}
+
 func TestPodTask_GetReasonMessage(t *testing.T) {
 	type reasonMessage struct {
 		reason  string
@@ -424,6 +836,51 @@ func TestPodTask_GetReasonMessage(t *testing.T) {
 				message: "Pod was active on the node longer than the specified deadline",
 			},
 		},
+		{
+			name: "Evicted by kubelet",
+			Pod:  podEvicted,
+			want: reasonMessage{
+				reason:  "Evicted",
+				message: "The node was low on resource: memory.",
+			},
+		},
+		{
+			name: "Preempted",
+			Pod:  podPreempted,
+			want: reasonMessage{
+				reason:  "Evicted",
+				message: "Preempted in order to admit critical pod",
+			},
+		},
+		{
+			name: "Sidecar error",
+			Pod:  podSidecarError,
+			want: reasonMessage{
+				reason:  "Error",
+				message: "Container exited with status 1",
+			},
+		},
+		{
+			name: "Sidecar error with required containers succeeded",
+			Pod:  podSidecarErrorWithRequiredContainers,
+			want: reasonMessage{},
+		},
+		{
+			name: "RunningTimeout",
+			Pod:  podKilledByRunningTimeout,
+			want: reasonMessage{
+				reason:  "RunningTimeout",
+				message: "Task was killed after exceeding its running timeout",
+			},
+		},
+		{
+			name: "Stalled",
+			Pod:  podKilledFromStalled,
+			want: reasonMessage{
+				reason:  "Stalled",
+				message: "Task was killed after not reporting any progress within its stall timeout",
+			},
+		},
 		{
 			name: "Unschedulable",
 			Pod:  podPendingUnschedulable,
@@ -433,11 +890,11 @@ func TestPodTask_GetReasonMessage(t *testing.T) {
 			},
 		},
 		{
-			name: "ContainersNotInitialized",
+			name: "InitFailed",
 			Pod:  podConditionInitializedFalse,
 			want: reasonMessage{
-				reason:  "ContainersNotInitialized",
-				message: "containers with incomplete status: [init-container]",
+				reason:  "InitFailed",
+				message: "Init container init-container exited with status 1",
 			},
 		},
 	}
@@ -303,6 +303,97 @@ var (
 		},
 	}
 
+	podEvicted = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: createTime,
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			Message:   "The node was low on resource: memory.",
+			StartTime: &startTime,
+		},
+	}
+
+	podPreempted = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: createTime,
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			StartTime: &startTime,
+			Conditions: append([]corev1.PodCondition{
+				{
+					Type:    "DisruptionTarget",
+					Status:  corev1.ConditionTrue,
+					Reason:  "PreemptionByScheduler",
+					Message: "Preempted in order to admit critical pod",
+				},
+			}, conditionsPodScheduledAndInit...),
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  containerName,
+					Image: image,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ContainerID: containerID,
+							ExitCode:    137,
+							FinishedAt:  containerFinishTime,
+							Reason:      "Error",
+							StartedAt:   containerStartTime,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	podSidecarError = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: createTime,
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodFailed,
+			StartTime:  &startTime,
+			Conditions: conditionsPodScheduledAndInit,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  containerName,
+					Image: image,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ContainerID: containerID,
+							FinishedAt:  containerFinishTime,
+							Reason:      "Completed",
+							StartedAt:   containerStartTime,
+						},
+					},
+				},
+				{
+					Name:  "sidecar",
+					Image: image,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ContainerID: containerID,
+							ExitCode:    1,
+							FinishedAt:  containerFinishTime,
+							Reason:      "Error",
+							StartedAt:   containerStartTime,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	podSidecarErrorWithRequiredContainers = func() corev1.Pod {
+		newPod := podSidecarError.DeepCopy()
+		newPod.Annotations = map[string]string{
+			podtaskexecutor.LabelKeyTaskRequiredContainers: containerName,
+		}
+		return *newPod
+	}()
+
 	podOOMKilled = corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			CreationTimestamp: createTime,
@@ -506,6 +597,68 @@ var (
 		},
 	}
 
+	podKilledByRunningTimeout = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: createTime,
+			Annotations: map[string]string{
+				podtaskexecutor.LabelKeyKilledFromRunningTimeout: "1",
+				podtaskexecutor.LabelKeyTaskKillTimestamp:        strconv.Itoa(int(startTime.Unix())),
+			},
+		},
+		Spec: corev1.PodSpec{
+			ActiveDeadlineSeconds: &activeDeadline,
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodFailed,
+			StartTime:  &startTime,
+			Reason:     "DeadlineExceeded",
+			Message:    "Pod was active on the node longer than the specified deadline",
+			Conditions: conditionsPodScheduledAndInit,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  containerName,
+					Image: image,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{
+							StartedAt: containerStartTime,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	podKilledFromStalled = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: createTime,
+			Annotations: map[string]string{
+				podtaskexecutor.LabelKeyKilledFromStalled: "1",
+				podtaskexecutor.LabelKeyTaskKillTimestamp: strconv.Itoa(int(startTime.Unix())),
+			},
+		},
+		Spec: corev1.PodSpec{
+			ActiveDeadlineSeconds: &activeDeadline,
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodFailed,
+			StartTime:  &startTime,
+			Reason:     "DeadlineExceeded",
+			Message:    "Pod was active on the node longer than the specified deadline",
+			Conditions: conditionsPodScheduledAndInit,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  containerName,
+					Image: image,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{
+							StartedAt: containerStartTime,
+						},
+					},
+				},
+			},
+		},
+	}
+
 	podKillingByPendingTimeout = corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			CreationTimestamp: createTime,
@@ -53,6 +53,16 @@ func GetPodConditionInitialized(pod *corev1.Pod) *corev1.PodCondition {
 	return nil
 }
 
+// GetPodConditionDisruptionTarget returns the DisruptionTarget pod condition.
+func GetPodConditionDisruptionTarget(pod *corev1.Pod) *corev1.PodCondition {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionDisruptionTarget {
+			return &condition
+		}
+	}
+	return nil
+}
+
 // GetTerminationStatus returns either the current container's Terminated or LastTerminateState.
 func GetTerminationStatus(container *corev1.ContainerStatus) *corev1.ContainerStateTerminated {
 	if container.State.Terminated != nil {
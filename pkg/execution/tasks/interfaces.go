@@ -42,6 +42,13 @@ type Task interface {
 	// All tasks should be numbered sequentially starting from 1 for a single job.
 	GetRetryIndex() (int64, bool)
 
+	// GetStepName returns the name of the step that the task was created for, if
+	// the Job specifies steps.
+	GetStepName() (string, bool)
+
+	// IsControlledBy returns true if the task was created for the given Job.
+	IsControlledBy(rj *execution.Job) bool
+
 	// RequiresKillWithDeletion returns true if the task cannot be killed via kill
 	// timestamp and needs deletion instead. If the task is already finished, this
 	// should always return false (i.e. cannot/should not kill finished tasks).
@@ -59,14 +66,70 @@ type Task interface {
 	// SetKilledFromPendingTimeoutMarker marks the task as killed from a pending timeout.
 	SetKilledFromPendingTimeoutMarker(ctx context.Context) error
 
+	// GetKilledFromRunningTimeoutMarker returns true if the task was marked as killed from a running timeout.
+	GetKilledFromRunningTimeoutMarker() bool
+
+	// SetKilledFromRunningTimeoutMarker marks the task as killed from a running timeout.
+	SetKilledFromRunningTimeoutMarker(ctx context.Context) error
+
 	// GetDeletionTimestamp returns the timestamp that the task was requested to be deleted.
 	GetDeletionTimestamp() *metav1.Time
+
+	// GetNodeLostTimestamp returns the timestamp that the task was first observed
+	// to have lost its node, or nil if the task is finished or its node was not lost.
+	GetNodeLostTimestamp() *metav1.Time
+}
+
+// PreKillHookTask is implemented by Tasks that support executing a pre-kill
+// hook in the task before it is killed.
+type PreKillHookTask interface {
+	Task
+
+	// GetNamespace returns the Task's namespace.
+	GetNamespace() string
+
+	// GetPreKillHookTimestamp returns the time that the pre-kill hook was started.
+	GetPreKillHookTimestamp() *metav1.Time
+
+	// SetPreKillHookTimestamp marks the time that the pre-kill hook was started.
+	SetPreKillHookTimestamp(ctx context.Context, ts time.Time) error
+}
+
+// StallDetectionTask is implemented by Tasks that support being marked as
+// stalled when they stop reporting progress.
+type StallDetectionTask interface {
+	Task
+
+	// GetStalledTimestamp returns the time that the task was first detected to be stalled.
+	GetStalledTimestamp() *metav1.Time
+
+	// SetStalledTimestamp marks the time that the task was first detected to be stalled.
+	SetStalledTimestamp(ctx context.Context, ts time.Time) error
+
+	// GetKilledFromStalledMarker returns true if the task was marked as killed from being stalled.
+	GetKilledFromStalledMarker() bool
+
+	// SetKilledFromStalledMarker marks the task as killed from being stalled.
+	SetKilledFromStalledMarker(ctx context.Context) error
+}
+
+// OrphanableTask is implemented by Tasks that can be orphaned from their Job,
+// such that they are not deleted together with the Job.
+type OrphanableTask interface {
+	Task
+
+	// IsOrphaned returns true if the task was orphaned from its Job.
+	IsOrphaned() bool
+
+	// Orphan removes the Job's ownership of the task and marks it as orphaned.
+	Orphan(ctx context.Context) error
 }
 
 // TaskTemplate defines how to create a Task.
 type TaskTemplate struct {
 	Name       string
 	RetryIndex int64
+	Step       string
 	PodSpec    corev1.PodSpec
 }
 
@@ -82,14 +145,25 @@ type TaskClient interface {
 	// CreateIndex creates a new Task with the given index.
 	CreateIndex(ctx context.Context, index int64) (task Task, err error)
 
+	// CreateStep creates a new Task for the given step.
+	CreateStep(ctx context.Context, step string) (task Task, err error)
+
 	// Get returns a single Task from apiserver.
 	Get(ctx context.Context, name string) (Task, error)
 
 	// Index returns a single Task for the given index from apiserver.
 	Index(ctx context.Context, index int64) (Task, error)
 
+	// Step returns a single Task for the given step from apiserver.
+	Step(ctx context.Context, step string) (Task, error)
+
 	// Delete will delete the Task with the given name.
 	Delete(ctx context.Context, name string, force bool) error
+
+	// DeleteResources will delete any additional resources that were created
+	// together with the Task, such as ephemeral volumes. This is called once the
+	// Task is finished, and must be idempotent.
+	DeleteResources(ctx context.Context, taskRef execution.TaskRef) error
 }
 
 // Executor is a task executor interface.
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+const (
+	natsClientName     = "furiko-execution-controller"
+	natsRequestTimeout = 10 * time.Second
+
+	// natsMinWaitTime is the minimum duration that pull requests are held open
+	// for, since JetStream pull requests always expire after some time.
+	natsMinWaitTime = time.Second
+)
+
+// NATSSource is a Source that consumes messages from a NATS JetStream pull
+// consumer. The connection is established when messages are first received,
+// and is re-established if the client gives up reconnecting to the server.
+type NATSSource struct {
+	url               string
+	stream            string
+	consumer          string
+	deadLetterSubject string
+	waitTime          time.Duration
+	maxMessages       int64
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	sub  *nats.Subscription
+}
+
+var _ Source = (*NATSSource)(nil)
+
+// NewNATSSource returns a new NATSSource for the given spec. Environment
+// variables in the URL are expanded.
+func NewNATSSource(spec *configv1alpha1.NATSTriggerSpec) (*NATSSource, error) {
+	rawURL := os.ExpandEnv(spec.URL)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse url")
+	}
+	switch u.Scheme {
+	case "nats", "tls":
+	default:
+		return nil, fmt.Errorf("invalid url scheme %v, must be one of: nats, tls", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("url must specify a host")
+	}
+	if spec.Stream == "" {
+		return nil, errors.New("stream must be specified")
+	}
+	if spec.Consumer == "" {
+		return nil, errors.New("consumer must be specified")
+	}
+
+	source := &NATSSource{
+		url:               rawURL,
+		stream:            spec.Stream,
+		consumer:          spec.Consumer,
+		deadLetterSubject: spec.DeadLetterSubject,
+		waitTime:          defaultWaitTimeSeconds * time.Second,
+		maxMessages:       spec.MaxMessages,
+	}
+	if spec.WaitTimeSeconds != nil {
+		source.waitTime = time.Duration(*spec.WaitTimeSeconds) * time.Second
+	}
+	if source.waitTime < natsMinWaitTime {
+		source.waitTime = natsMinWaitTime
+	}
+	if source.maxMessages <= 0 {
+		source.maxMessages = defaultMaxMessages
+	}
+
+	return source, nil
+}
+
+func (s *NATSSource) Receive(ctx context.Context) ([]*Message, error) {
+	sub, err := s.subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, s.waitTime)
+	defer cancel()
+	msgs, err := sub.Fetch(int(s.maxMessages), nats.Context(fetchCtx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// No messages were received before the pull request expired.
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrTimeout) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "cannot fetch messages")
+	}
+
+	result := make([]*Message, 0, len(msgs))
+	for _, msg := range msgs {
+		meta, err := msg.Metadata()
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get metadata of message")
+		}
+		result = append(result, &Message{
+			ID:            strconv.FormatUint(meta.Sequence.Stream, 10),
+			Body:          string(msg.Data),
+			ReceiveCount:  int64(meta.NumDelivered),
+			ReceiptHandle: msg.Reply,
+		})
+	}
+	return result, nil
+}
+
+func (s *NATSSource) Delete(ctx context.Context, msg *Message) error {
+	sub, err := s.subscribe()
+	if err != nil {
+		return err
+	}
+
+	// The receipt handle is the ack subject of the message, which remains valid
+	// even if the subscription has since been recreated.
+	ctx, cancel := context.WithTimeout(ctx, natsRequestTimeout)
+	defer cancel()
+	natsMsg := &nats.Msg{Reply: msg.ReceiptHandle, Sub: sub}
+	if err := natsMsg.AckSync(nats.Context(ctx)); err != nil {
+		return errors.Wrapf(err, "cannot acknowledge message")
+	}
+	return nil
+}
+
+func (s *NATSSource) DeadLetter(ctx context.Context, msg *Message) error {
+	if s.deadLetterSubject != "" {
+		if _, err := s.subscribe(); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+		if err := conn.Publish(s.deadLetterSubject, []byte(msg.Body)); err != nil {
+			return errors.Wrapf(err, "cannot publish message to dead-letter subject")
+		}
+	}
+	return s.Delete(ctx, msg)
+}
+
+// Close closes the connection to the server, if any.
+func (s *NATSSource) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.conn = nil
+	s.sub = nil
+	return nil
+}
+
+// subscribe returns a pull subscription that is bound to the consumer,
+// connecting to the server if there is no open connection.
+func (s *NATSSource) subscribe() (*nats.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil && !s.conn.IsClosed() {
+		return s.sub, nil
+	}
+
+	conn, err := nats.Connect(s.url, nats.Name(natsClientName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot connect to server")
+	}
+	js, err := conn.JetStream(nats.MaxWait(natsRequestTimeout))
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "cannot create jetstream context")
+	}
+
+	// The subject must match the filter subject of the consumer to bind to it.
+	info, err := js.ConsumerInfo(s.stream, s.consumer)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "cannot get consumer %v of stream %v", s.consumer, s.stream)
+	}
+	sub, err := js.PullSubscribe(info.Config.FilterSubject, s.consumer, nats.Bind(s.stream, s.consumer))
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "cannot subscribe to consumer %v of stream %v", s.consumer, s.stream)
+	}
+
+	s.conn = conn
+	s.sub = sub
+	return sub, nil
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+)
+
+const (
+	natsToken = "token"
+)
+
+// newNATSServer starts a NATS server with JetStream enabled, which has a stream
+// with a pull consumer for the "jobs" subject, and another stream for the
+// "dlq" subject. Returns the server and a JetStream client for it.
+func newNATSServer(t *testing.T) (*server.Server, nats.JetStreamContext) {
+	srv, err := server.NewServer(&server.Options{
+		Host:          "127.0.0.1",
+		Port:          -1,
+		JetStream:     true,
+		StoreDir:      t.TempDir(),
+		Authorization: natsToken,
+	})
+	if err != nil {
+		t.Fatalf("cannot create server: %v", err)
+	}
+	go srv.Start()
+	t.Cleanup(srv.Shutdown)
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Fatalf("server is not ready for connections")
+	}
+
+	conn, err := nats.Connect(srv.ClientURL(), nats.Token(natsToken))
+	if err != nil {
+		t.Fatalf("cannot connect: %v", err)
+	}
+	t.Cleanup(conn.Close)
+	js, err := conn.JetStream()
+	if err != nil {
+		t.Fatalf("cannot create jetstream context: %v", err)
+	}
+	for name, subject := range map[string]string{"stream": "jobs", "dlq": "dlq"} {
+		if _, err := js.AddStream(&nats.StreamConfig{
+			Name:     name,
+			Subjects: []string{subject},
+			Storage:  nats.MemoryStorage,
+		}); err != nil {
+			t.Fatalf("cannot add stream: %v", err)
+		}
+	}
+	if _, err := js.AddConsumer("stream", &nats.ConsumerConfig{
+		Durable:       "consumer",
+		AckPolicy:     nats.AckExplicitPolicy,
+		FilterSubject: "jobs",
+	}); err != nil {
+		t.Fatalf("cannot add consumer: %v", err)
+	}
+
+	return srv, js
+}
+
+// natsURL returns the URL of the server with the given userinfo.
+func natsURL(srv *server.Server, userinfo string) string {
+	return strings.Replace(srv.ClientURL(), "nats://", "nats://"+userinfo, 1)
+}
+
+func TestNATSSource(t *testing.T) {
+	ctx := context.Background()
+	srv, js := newNATSServer(t)
+	if _, err := js.Publish("jobs", []byte("{}")); err != nil {
+		t.Fatalf("cannot publish: %v", err)
+	}
+
+	waitTime := int64(1)
+	source, err := trigger.NewNATSSource(&configv1alpha1.NATSTriggerSpec{
+		URL:               natsURL(srv, natsToken+"@"),
+		Stream:            "stream",
+		Consumer:          "consumer",
+		DeadLetterSubject: "dlq",
+		WaitTimeSeconds:   &waitTime,
+	})
+	assert.NoError(t, err)
+	defer source.Close()
+
+	msgs, err := source.Receive(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, msgs, 1) {
+		assert.Equal(t, "1", msgs[0].ID)
+		assert.Equal(t, "{}", msgs[0].Body)
+		assert.Equal(t, int64(1), msgs[0].ReceiveCount)
+		assert.True(t, strings.HasPrefix(msgs[0].ReceiptHandle, "$JS.ACK.stream.consumer."))
+		assert.NoError(t, source.DeadLetter(ctx, msgs[0]))
+	}
+
+	// The message should not be received again once it is acknowledged.
+	msgs, err = source.Receive(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 0)
+
+	info, err := js.ConsumerInfo("stream", "consumer")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, info.NumAckPending)
+	assert.Equal(t, uint64(1), info.AckFloor.Stream)
+	dlq, err := js.GetMsg("dlq", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(dlq.Data))
+}
+
+func TestNATSSource_Unauthorized(t *testing.T) {
+	srv, _ := newNATSServer(t)
+	source, err := trigger.NewNATSSource(&configv1alpha1.NATSTriggerSpec{
+		URL:      natsURL(srv, "invalid@"),
+		Stream:   "stream",
+		Consumer: "consumer",
+	})
+	assert.NoError(t, err)
+	defer source.Close()
+
+	_, err = source.Receive(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNATSSource_ContextCanceled(t *testing.T) {
+	srv, _ := newNATSServer(t)
+	source, err := trigger.NewNATSSource(&configv1alpha1.NATSTriggerSpec{
+		URL:      natsURL(srv, natsToken+"@"),
+		Stream:   "stream",
+		Consumer: "consumer",
+	})
+	assert.NoError(t, err)
+	defer source.Close()
+
+	// No messages are published, so the pull request is held open until the
+	// context is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = source.Receive(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewNATSSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *configv1alpha1.NATSTriggerSpec
+		wantErr bool
+	}{
+		{
+			name: "valid spec",
+			spec: &configv1alpha1.NATSTriggerSpec{
+				URL:      "nats://localhost:4222",
+				Stream:   "stream",
+				Consumer: "consumer",
+			},
+		},
+		{
+			name: "invalid scheme",
+			spec: &configv1alpha1.NATSTriggerSpec{
+				URL:      "http://localhost:4222",
+				Stream:   "stream",
+				Consumer: "consumer",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing stream",
+			spec: &configv1alpha1.NATSTriggerSpec{
+				URL:      "nats://localhost:4222",
+				Consumer: "consumer",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing consumer",
+			spec: &configv1alpha1.NATSTriggerSpec{
+				URL:    "nats://localhost:4222",
+				Stream: "stream",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := trigger.NewNATSSource(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewNATSSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+const (
+	pubsubScope           = "https://www.googleapis.com/auth/pubsub"
+	defaultPubSubEndpoint = "https://pubsub.googleapis.com"
+
+	// pubsubRequestTimeout is the timeout of each request, which must be longer
+	// than the duration that the server holds a pull request open for.
+	pubsubRequestTimeout = 2 * time.Minute
+)
+
+var (
+	pubsubSubscriptionRegexp = regexp.MustCompile(`^projects/[^/]+/subscriptions/[^/]+$`)
+	pubsubTopicRegexp        = regexp.MustCompile(`^projects/[^/]+/topics/[^/]+$`)
+)
+
+// PubSubSource is a Source that consumes messages from a Google Cloud Pub/Sub
+// subscription using the REST API.
+type PubSubSource struct {
+	endpoint        string
+	subscription    string
+	deadLetterTopic string
+	maxMessages     int64
+	client          *http.Client
+}
+
+var _ Source = (*PubSubSource)(nil)
+
+// NewPubSubSource returns a new PubSubSource for the given spec.
+func NewPubSubSource(spec *configv1alpha1.PubSubTriggerSpec) (*PubSubSource, error) {
+	if !pubsubSubscriptionRegexp.MatchString(spec.Subscription) {
+		return nil, fmt.Errorf("invalid subscription %v, must be in the format "+
+			"projects/<project>/subscriptions/<subscription>", spec.Subscription)
+	}
+	if spec.DeadLetterTopic != "" && !pubsubTopicRegexp.MatchString(spec.DeadLetterTopic) {
+		return nil, fmt.Errorf("invalid dead-letter topic %v, must be in the format "+
+			"projects/<project>/topics/<topic>", spec.DeadLetterTopic)
+	}
+
+	tokenSource, err := newPubSubTokenSource(spec.CredentialsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load credentials")
+	}
+
+	source := &PubSubSource{
+		endpoint:        strings.TrimSuffix(spec.Endpoint, "/"),
+		subscription:    spec.Subscription,
+		deadLetterTopic: spec.DeadLetterTopic,
+		maxMessages:     spec.MaxMessages,
+		client: &http.Client{
+			Transport: &oauth2.Transport{Source: tokenSource},
+			Timeout:   pubsubRequestTimeout,
+		},
+	}
+	if source.endpoint == "" {
+		source.endpoint = defaultPubSubEndpoint
+	}
+	if source.maxMessages <= 0 {
+		source.maxMessages = defaultMaxMessages
+	}
+
+	return source, nil
+}
+
+// newPubSubTokenSource returns a TokenSource using the credentials in
+// credentialsFile, or Application Default Credentials if it is empty.
+func newPubSubTokenSource(credentialsFile string) (oauth2.TokenSource, error) {
+	ctx := context.Background()
+	if credentialsFile == "" {
+		creds, err := google.FindDefaultCredentials(ctx, pubsubScope)
+		if err != nil {
+			return nil, err
+		}
+		return creds.TokenSource, nil
+	}
+
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read credentials file")
+	}
+	creds, err := google.CredentialsFromJSON(ctx, data, pubsubScope)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse credentials file")
+	}
+	return creds.TokenSource, nil
+}
+
+type pubsubMessage struct {
+	Data      string `json:"data"`
+	MessageID string `json:"messageId,omitempty"`
+}
+
+type pubsubReceivedMessage struct {
+	AckID           string        `json:"ackId"`
+	Message         pubsubMessage `json:"message"`
+	DeliveryAttempt int64         `json:"deliveryAttempt"`
+}
+
+type pubsubPullResponse struct {
+	ReceivedMessages []pubsubReceivedMessage `json:"receivedMessages"`
+}
+
+func (s *PubSubSource) Receive(ctx context.Context) ([]*Message, error) {
+	var resp pubsubPullResponse
+	if err := s.do(ctx, s.subscription, "pull", map[string]interface{}{
+		"maxMessages": s.maxMessages,
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*Message, 0, len(resp.ReceivedMessages))
+	for _, m := range resp.ReceivedMessages {
+		body, err := base64.StdEncoding.DecodeString(m.Message.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode data of message %v", m.Message.MessageID)
+		}
+
+		// The delivery attempt is only set if the subscription has a dead-letter
+		// policy.
+		msg := &Message{
+			ID:            m.Message.MessageID,
+			Body:          string(body),
+			ReceiptHandle: m.AckID,
+			ReceiveCount:  m.DeliveryAttempt,
+		}
+		if msg.ReceiveCount <= 0 {
+			msg.ReceiveCount = 1
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func (s *PubSubSource) Delete(ctx context.Context, msg *Message) error {
+	return s.do(ctx, s.subscription, "acknowledge", map[string]interface{}{
+		"ackIds": []string{msg.ReceiptHandle},
+	}, nil)
+}
+
+func (s *PubSubSource) DeadLetter(ctx context.Context, msg *Message) error {
+	if s.deadLetterTopic != "" {
+		if err := s.do(ctx, s.deadLetterTopic, "publish", map[string]interface{}{
+			"messages": []pubsubMessage{
+				{Data: base64.StdEncoding.EncodeToString([]byte(msg.Body))},
+			},
+		}, nil); err != nil {
+			return errors.Wrapf(err, "cannot publish message to dead-letter topic")
+		}
+	}
+	return s.Delete(ctx, msg)
+}
+
+type pubsubErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	} `json:"error"`
+}
+
+// do sends a request for the given method of the resource, and decodes the
+// response into out if it is not nil.
+func (s *PubSubSource) do(ctx context.Context, resource, method string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal request")
+	}
+	u := fmt.Sprintf("%v/v1/%v:%v", s.endpoint, resource, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%v request failed", method)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		var errResp pubsubErrorResponse
+		if err := json.Unmarshal(data, &errResp); err == nil && errResp.Error.Status != "" {
+			return fmt.Errorf("%v request failed with status %v: %v: %v",
+				method, resp.StatusCode, errResp.Error.Status, errResp.Error.Message)
+		}
+		return fmt.Errorf("%v request failed with status %v: %v", method, resp.StatusCode, string(data))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return errors.Wrapf(err, "cannot decode %v response", method)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+)
+
+const (
+	pubsubAccessToken = "access-token"
+)
+
+type fakePubSub struct {
+	mu       sync.Mutex
+	requests map[string][]map[string]interface{}
+}
+
+func (f *fakePubSub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Serve tokens for the service account.
+	if r.URL.Path == "/token" {
+		if err := r.ParseForm(); err != nil || r.Form.Get("assertion") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "` + pubsubAccessToken + `", "token_type": "Bearer", "expires_in": 3600}`))
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+pubsubAccessToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var req map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	f.requests[path] = append(f.requests[path], req)
+
+	switch path {
+	case "projects/project/subscriptions/subscription:pull":
+		// The data is base64-encoded "{}".
+		_, _ = w.Write([]byte(`{"receivedMessages": [{"ackId": "ack1", ` +
+			`"message": {"data": "e30=", "messageId": "msg1"}, "deliveryAttempt": 2}]}`))
+	case "projects/project/subscriptions/subscription:acknowledge",
+		"projects/project/topics/dlq:publish":
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`))
+	}
+}
+
+func TestPubSubSource(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakePubSub{requests: make(map[string][]map[string]interface{})}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	source, err := trigger.NewPubSubSource(&configv1alpha1.PubSubTriggerSpec{
+		Subscription:    "projects/project/subscriptions/subscription",
+		DeadLetterTopic: "projects/project/topics/dlq",
+		CredentialsFile: writeServiceAccountKey(t, server.URL+"/token"),
+		Endpoint:        server.URL,
+	})
+	assert.NoError(t, err)
+
+	msgs, err := source.Receive(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*trigger.Message{
+		{ID: "msg1", Body: "{}", ReceiptHandle: "ack1", ReceiveCount: 2},
+	}, msgs)
+
+	assert.NoError(t, source.Delete(ctx, msgs[0]))
+	assert.NoError(t, source.DeadLetter(ctx, msgs[0]))
+
+	pulls := fake.requests["projects/project/subscriptions/subscription:pull"]
+	assert.Len(t, pulls, 1)
+	assert.Equal(t, float64(10), pulls[0]["maxMessages"])
+	publishes := fake.requests["projects/project/topics/dlq:publish"]
+	assert.Len(t, publishes, 1)
+	assert.Equal(t, []interface{}{map[string]interface{}{"data": "e30="}}, publishes[0]["messages"])
+	acks := fake.requests["projects/project/subscriptions/subscription:acknowledge"]
+	assert.Len(t, acks, 2)
+	assert.Equal(t, []interface{}{"ack1"}, acks[0]["ackIds"])
+}
+
+func TestPubSubSource_Error(t *testing.T) {
+	fake := &fakePubSub{requests: make(map[string][]map[string]interface{})}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	source, err := trigger.NewPubSubSource(&configv1alpha1.PubSubTriggerSpec{
+		Subscription:    "projects/project/subscriptions/nonexistent",
+		CredentialsFile: writeServiceAccountKey(t, server.URL+"/token"),
+		Endpoint:        server.URL,
+	})
+	assert.NoError(t, err)
+
+	_, err = source.Receive(context.Background())
+	assert.EqualError(t, err, "pull request failed with status 404: NOT_FOUND: not found")
+}
+
+func TestNewPubSubSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *configv1alpha1.PubSubTriggerSpec
+		wantErr bool
+	}{
+		{
+			name: "invalid subscription",
+			spec: &configv1alpha1.PubSubTriggerSpec{
+				Subscription: "subscription",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid dead-letter topic",
+			spec: &configv1alpha1.PubSubTriggerSpec{
+				Subscription:    "projects/project/subscriptions/subscription",
+				DeadLetterTopic: "projects/project/subscriptions/dlq",
+			},
+			wantErr: true,
+		},
+		{
+			name: "credentials file does not exist",
+			spec: &configv1alpha1.PubSubTriggerSpec{
+				Subscription:    "projects/project/subscriptions/subscription",
+				CredentialsFile: filepath.Join(t.TempDir(), "nonexistent.json"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "use default credentials",
+			spec: &configv1alpha1.PubSubTriggerSpec{
+				Subscription: "projects/project/subscriptions/subscription",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Fall back to the metadata server without checking whether it is
+			// reachable.
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+			t.Setenv("GCE_METADATA_HOST", "127.0.0.1:1")
+			t.Setenv("HOME", t.TempDir())
+			_, err := trigger.NewPubSubSource(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPubSubSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// writeServiceAccountKey writes a service account key file with a new private
+// key that uses the given token URL, and returns its path.
+func writeServiceAccountKey(t *testing.T, tokenURL string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("cannot generate key: %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "furiko@project.iam.gserviceaccount.com",
+		"private_key":    string(privateKey),
+		"private_key_id": "key-id",
+		"token_uri":      tokenURL,
+	})
+	if err != nil {
+		t.Fatalf("cannot marshal key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("cannot write key: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,238 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	sqsService             = "sqs"
+	sqsContentType         = "application/x-amz-json-1.0"
+	sqsTargetPrefix        = "AmazonSQS."
+	defaultWaitTimeSeconds = 20
+	defaultMaxMessages     = 10
+	maxErrorBodySize       = 4096
+)
+
+// SQSSource is a Source that consumes messages from an Amazon SQS queue using
+// the JSON protocol.
+type SQSSource struct {
+	queueURL          string
+	endpoint          string
+	region            string
+	deadLetterURL     string
+	waitTimeSeconds   int64
+	maxMessages       int64
+	visibilityTimeout *int64
+	credentials       aws.CredentialsProvider
+	signer            *v4.Signer
+	client            *http.Client
+}
+
+var _ Source = (*SQSSource)(nil)
+
+// NewSQSSource returns a new SQSSource for the given spec, using credentials
+// from the default credential chain of the AWS SDK.
+func NewSQSSource(spec *configv1alpha1.SQSTriggerSpec) (*SQSSource, error) {
+	u, err := url.Parse(spec.QueueURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse queue url")
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid queue url: %v", spec.QueueURL)
+	}
+
+	source := &SQSSource{
+		queueURL:          spec.QueueURL,
+		endpoint:          u.Scheme + "://" + u.Host + "/",
+		region:            spec.Region,
+		deadLetterURL:     spec.DeadLetterQueueURL,
+		waitTimeSeconds:   defaultWaitTimeSeconds,
+		maxMessages:       spec.MaxMessages,
+		visibilityTimeout: spec.VisibilityTimeoutSeconds,
+		signer:            v4.NewSigner(),
+		client:            &http.Client{},
+	}
+	if spec.WaitTimeSeconds != nil {
+		source.waitTimeSeconds = *spec.WaitTimeSeconds
+	}
+	if source.maxMessages <= 0 || source.maxMessages > defaultMaxMessages {
+		source.maxMessages = defaultMaxMessages
+	}
+	if source.region == "" {
+		source.region = parseSQSRegion(u.Host)
+	}
+	if source.region == "" {
+		return nil, fmt.Errorf("cannot determine region from queue url: %v", spec.QueueURL)
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(source.region))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load aws config")
+	}
+	source.credentials = awsConfig.Credentials
+
+	// Ensure that long polling does not exceed the client timeout.
+	source.client.Timeout = time.Duration(source.waitTimeSeconds)*time.Second + time.Minute
+
+	return source, nil
+}
+
+// parseSQSRegion returns the region from a host such as
+// sqs.us-east-1.amazonaws.com.
+func parseSQSRegion(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) >= 4 && parts[0] == sqsService {
+		return parts[1]
+	}
+	return ""
+}
+
+type sqsMessage struct {
+	MessageID     string            `json:"MessageId"`
+	ReceiptHandle string            `json:"ReceiptHandle"`
+	Body          string            `json:"Body"`
+	Attributes    map[string]string `json:"Attributes"`
+}
+
+type sqsReceiveMessageResponse struct {
+	Messages []sqsMessage `json:"Messages"`
+}
+
+func (s *SQSSource) Receive(ctx context.Context) ([]*Message, error) {
+	req := map[string]interface{}{
+		"QueueUrl":                    s.queueURL,
+		"MaxNumberOfMessages":         s.maxMessages,
+		"WaitTimeSeconds":             s.waitTimeSeconds,
+		"MessageSystemAttributeNames": []string{"ApproximateReceiveCount"},
+		"AttributeNames":              []string{"ApproximateReceiveCount"},
+	}
+	if s.visibilityTimeout != nil {
+		req["VisibilityTimeout"] = *s.visibilityTimeout
+	}
+
+	var resp sqsReceiveMessageResponse
+	if err := s.do(ctx, "ReceiveMessage", req, &resp); err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*Message, 0, len(resp.Messages))
+	for _, m := range resp.Messages {
+		msg := &Message{
+			ID:            m.MessageID,
+			Body:          m.Body,
+			ReceiptHandle: m.ReceiptHandle,
+			ReceiveCount:  1,
+		}
+		if count, err := strconv.ParseInt(m.Attributes["ApproximateReceiveCount"], 10, 64); err == nil {
+			msg.ReceiveCount = count
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func (s *SQSSource) Delete(ctx context.Context, msg *Message) error {
+	return s.do(ctx, "DeleteMessage", map[string]interface{}{
+		"QueueUrl":      s.queueURL,
+		"ReceiptHandle": msg.ReceiptHandle,
+	}, nil)
+}
+
+func (s *SQSSource) DeadLetter(ctx context.Context, msg *Message) error {
+	if s.deadLetterURL != "" {
+		if err := s.do(ctx, "SendMessage", map[string]interface{}{
+			"QueueUrl":    s.deadLetterURL,
+			"MessageBody": msg.Body,
+		}, nil); err != nil {
+			return errors.Wrapf(err, "cannot send message to dead-letter queue")
+		}
+	}
+	return s.Delete(ctx, msg)
+}
+
+type sqsErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// do sends a signed request for the given action, and decodes the response into
+// out if it is not nil.
+func (s *SQSSource) do(ctx context.Context, action string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "cannot create request")
+	}
+	req.Header.Set("Content-Type", sqsContentType)
+	req.Header.Set("X-Amz-Target", sqsTargetPrefix+action)
+	creds, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "cannot retrieve credentials")
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := s.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), sqsService, s.region,
+		ktime.Now().Time); err != nil {
+		return errors.Wrapf(err, "cannot sign request")
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%v request failed", action)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		var errResp sqsErrorResponse
+		if err := json.Unmarshal(data, &errResp); err == nil && errResp.Type != "" {
+			return fmt.Errorf("%v request failed with status %v: %v: %v",
+				action, resp.StatusCode, errResp.Type, errResp.Message)
+		}
+		return fmt.Errorf("%v request failed with status %v: %v", action, resp.StatusCode, string(data))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return errors.Wrapf(err, "cannot decode %v response", action)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+)
+
+type fakeSQS struct {
+	mu       sync.Mutex
+	requests map[string][]map[string]interface{}
+}
+
+func (f *fakeSQS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonSQS.")
+	var req map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.requests[action] = append(f.requests[action], req)
+
+	switch action {
+	case "ReceiveMessage":
+		_, _ = w.Write([]byte(`{"Messages": [{"MessageId": "msg1", "ReceiptHandle": "handle1", ` +
+			`"Body": "{}", "Attributes": {"ApproximateReceiveCount": "2"}}]}`))
+	case "DeleteMessage", "SendMessage":
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"__type": "InvalidAction", "message": "invalid action"}`))
+	}
+}
+
+func TestSQSSource(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeSQS{requests: make(map[string][]map[string]interface{})}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	waitTime := int64(0)
+	source, err := trigger.NewSQSSource(&configv1alpha1.SQSTriggerSpec{
+		QueueURL:           server.URL + "/123456789012/queue",
+		Region:             "us-east-1",
+		DeadLetterQueueURL: server.URL + "/123456789012/dlq",
+		WaitTimeSeconds:    &waitTime,
+	})
+	assert.NoError(t, err)
+
+	msgs, err := source.Receive(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []*trigger.Message{
+		{ID: "msg1", Body: "{}", ReceiptHandle: "handle1", ReceiveCount: 2},
+	}, msgs)
+
+	assert.NoError(t, source.Delete(ctx, msgs[0]))
+	assert.NoError(t, source.DeadLetter(ctx, msgs[0]))
+
+	assert.Len(t, fake.requests["ReceiveMessage"], 1)
+	assert.Equal(t, float64(10), fake.requests["ReceiveMessage"][0]["MaxNumberOfMessages"])
+	assert.Len(t, fake.requests["SendMessage"], 1)
+	assert.Equal(t, server.URL+"/123456789012/dlq", fake.requests["SendMessage"][0]["QueueUrl"])
+	assert.Len(t, fake.requests["DeleteMessage"], 2)
+	assert.Equal(t, "handle1", fake.requests["DeleteMessage"][0]["ReceiptHandle"])
+}
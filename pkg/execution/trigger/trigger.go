@@ -0,0 +1,248 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+const (
+	defaultMaxReceiveCount = 5
+	defaultNamespace       = "default"
+	jobNameHashLength      = 10
+)
+
+// Message is a message received from a Source.
+type Message struct {
+	// ID uniquely identifies the message in the queue.
+	ID string
+
+	// Body of the message.
+	Body string
+
+	// ReceiveCount is the number of times that the message has been received,
+	// including the current receive.
+	ReceiveCount int64
+
+	// ReceiptHandle is used by the Source to delete the message.
+	ReceiptHandle string
+}
+
+// Source is a message queue that Jobs are created from. Messages that are
+// received but not deleted are expected to be received again later.
+type Source interface {
+	// Receive returns the next batch of messages, blocking until messages are
+	// available or a source-specific timeout elapses.
+	Receive(ctx context.Context) ([]*Message, error)
+
+	// Delete deletes the message from the queue once it has been processed.
+	Delete(ctx context.Context, msg *Message) error
+
+	// DeadLetter moves the message to the dead-letter queue, or deletes it if
+	// there is none.
+	DeadLetter(ctx context.Context, msg *Message) error
+}
+
+// Trigger creates Jobs from the messages of a Source.
+type Trigger struct {
+	Name            string
+	Namespace       string
+	JobConfigName   string
+	OptionValues    map[string]string
+	MaxReceiveCount int64
+	Source          Source
+}
+
+// NewTriggers returns the Triggers for the given specs.
+func NewTriggers(specs []configv1alpha1.TriggerSpec) ([]*Trigger, error) {
+	triggers := make([]*Trigger, 0, len(specs))
+	names := make(map[string]struct{}, len(specs))
+	for _, spec := range specs {
+		if spec.Name == "" {
+			return nil, errors.New("name must be specified")
+		}
+		if _, ok := names[spec.Name]; ok {
+			return nil, fmt.Errorf("duplicate trigger name: %v", spec.Name)
+		}
+		names[spec.Name] = struct{}{}
+
+		trigger, err := NewTrigger(spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid trigger %v", spec.Name)
+		}
+		triggers = append(triggers, trigger)
+	}
+	return triggers, nil
+}
+
+// NewTrigger returns a Trigger for the given spec.
+func NewTrigger(spec configv1alpha1.TriggerSpec) (*Trigger, error) {
+	if spec.JobConfig.Name == "" {
+		return nil, errors.New("jobConfig.name must be specified")
+	}
+	for option, path := range spec.OptionValues {
+		if path == "" {
+			return nil, fmt.Errorf("field for option %v must be specified", option)
+		}
+	}
+
+	trigger := &Trigger{
+		Name:            spec.Name,
+		Namespace:       spec.JobConfig.Namespace,
+		JobConfigName:   spec.JobConfig.Name,
+		OptionValues:    spec.OptionValues,
+		MaxReceiveCount: spec.MaxReceiveCount,
+	}
+	if trigger.Namespace == "" {
+		trigger.Namespace = defaultNamespace
+	}
+	if trigger.MaxReceiveCount <= 0 {
+		trigger.MaxReceiveCount = defaultMaxReceiveCount
+	}
+
+	var numSources int
+	for _, specified := range []bool{spec.SQS != nil, spec.PubSub != nil, spec.NATS != nil} {
+		if specified {
+			numSources++
+		}
+	}
+	if numSources > 1 {
+		return nil, errors.New("only one source can be specified")
+	}
+
+	switch {
+	case spec.SQS != nil:
+		source, err := NewSQSSource(spec.SQS)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid sqs source")
+		}
+		trigger.Source = source
+	case spec.PubSub != nil:
+		source, err := NewPubSubSource(spec.PubSub)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pubsub source")
+		}
+		trigger.Source = source
+	case spec.NATS != nil:
+		source, err := NewNATSSource(spec.NATS)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid nats source")
+		}
+		trigger.Source = source
+	default:
+		return nil, errors.New("no source specified")
+	}
+
+	return trigger, nil
+}
+
+// InvalidMessageError is returned if a Job cannot be created from a message,
+// regardless of how many times it is received.
+type InvalidMessageError struct {
+	err error
+}
+
+func (e *InvalidMessageError) Error() string {
+	return fmt.Sprintf("invalid message: %v", e.err)
+}
+
+func (e *InvalidMessageError) Unwrap() error {
+	return e.err
+}
+
+// NewJob returns the Job to be created for the message. The name of the Job is
+// derived from the message ID, such that each message creates at most one Job.
+func (t *Trigger) NewJob(msg *Message) (*execution.Job, error) {
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.jobName(msg),
+			Namespace: t.Namespace,
+			Annotations: map[string]string{
+				jobutil.AnnotationKeyTriggerMessage: t.Name + "/" + msg.ID,
+			},
+		},
+		Spec: execution.JobSpec{
+			ConfigName: t.JobConfigName,
+		},
+	}
+
+	if len(t.OptionValues) > 0 {
+		optionValues, err := t.evaluateOptionValues(msg.Body)
+		if err != nil {
+			return nil, &InvalidMessageError{err: err}
+		}
+		if len(optionValues) > 0 {
+			marshaled, err := json.Marshal(optionValues)
+			if err != nil {
+				return nil, &InvalidMessageError{err: err}
+			}
+			rj.Spec.OptionValues = string(marshaled)
+		}
+	}
+
+	return rj, nil
+}
+
+func (t *Trigger) jobName(msg *Message) string {
+	hash := sha256.Sum256([]byte(t.Name + "/" + msg.ID))
+	return t.JobConfigName + "-" + hex.EncodeToString(hash[:])[:jobNameHashLength]
+}
+
+// evaluateOptionValues returns the option values from the fields of the message
+// body.
+func (t *Trigger) evaluateOptionValues(body string) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal message body as json object")
+	}
+
+	optionValues := make(map[string]interface{}, len(t.OptionValues))
+	for option, path := range t.OptionValues {
+		if value, ok := lookupField(data, path); ok {
+			optionValues[option] = value
+		}
+	}
+	return optionValues, nil
+}
+
+// lookupField returns the value at the dot-separated path in the object.
+func lookupField(data map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value, ok = obj[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
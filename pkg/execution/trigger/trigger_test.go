@@ -0,0 +1,238 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trigger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/trigger"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+)
+
+func TestNewTriggers(t *testing.T) {
+	sqs := &configv1alpha1.SQSTriggerSpec{
+		QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/queue",
+	}
+	tests := []struct {
+		name    string
+		specs   []configv1alpha1.TriggerSpec
+		wantErr bool
+	}{
+		{
+			name: "valid trigger",
+			specs: []configv1alpha1.TriggerSpec{
+				{Name: "trigger", JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"}, SQS: sqs},
+			},
+		},
+		{
+			name: "valid pubsub trigger",
+			specs: []configv1alpha1.TriggerSpec{
+				{
+					Name:      "trigger",
+					JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"},
+					PubSub: &configv1alpha1.PubSubTriggerSpec{
+						Subscription: "projects/project/subscriptions/subscription",
+					},
+				},
+			},
+		},
+		{
+			name: "valid nats trigger",
+			specs: []configv1alpha1.TriggerSpec{
+				{
+					Name:      "trigger",
+					JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"},
+					NATS: &configv1alpha1.NATSTriggerSpec{
+						URL:      "nats://localhost:4222",
+						Stream:   "stream",
+						Consumer: "consumer",
+					},
+				},
+			},
+		},
+		{
+			name: "missing name",
+			specs: []configv1alpha1.TriggerSpec{
+				{JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"}, SQS: sqs},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			specs: []configv1alpha1.TriggerSpec{
+				{Name: "trigger", JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"}, SQS: sqs},
+				{Name: "trigger", JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"}, SQS: sqs},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing jobconfig",
+			specs: []configv1alpha1.TriggerSpec{
+				{Name: "trigger", SQS: sqs},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing source",
+			specs: []configv1alpha1.TriggerSpec{
+				{Name: "trigger", JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple sources",
+			specs: []configv1alpha1.TriggerSpec{
+				{
+					Name:      "trigger",
+					JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"},
+					SQS:       sqs,
+					PubSub: &configv1alpha1.PubSubTriggerSpec{
+						Subscription: "projects/project/subscriptions/subscription",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cannot determine region",
+			specs: []configv1alpha1.TriggerSpec{
+				{
+					Name:      "trigger",
+					JobConfig: configv1alpha1.ObjectReference{Name: "jobconfig"},
+					SQS:       &configv1alpha1.SQSTriggerSpec{QueueURL: "http://localhost:9324/queue"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "")
+			_, err := trigger.NewTriggers(tt.specs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTriggers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrigger_NewJob(t *testing.T) {
+	tr := &trigger.Trigger{
+		Name:          "trigger",
+		Namespace:     "test",
+		JobConfigName: "jobconfig",
+		OptionValues: map[string]string{
+			"region": "data.region",
+			"count":  "count",
+			"absent": "data.absent",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		msg     *trigger.Message
+		want    *execution.Job
+		wantErr bool
+	}{
+		{
+			name: "map option values",
+			msg: &trigger.Message{
+				ID:   "msg1",
+				Body: `{"count": 3, "data": {"region": "us-east-1"}}`,
+			},
+			want: &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jobconfig-cd4dd21748",
+					Namespace: "test",
+					Annotations: map[string]string{
+						jobutil.AnnotationKeyTriggerMessage: "trigger/msg1",
+					},
+				},
+				Spec: execution.JobSpec{
+					ConfigName:   "jobconfig",
+					OptionValues: `{"count":3,"region":"us-east-1"}`,
+				},
+			},
+		},
+		{
+			name: "no fields present",
+			msg: &trigger.Message{
+				ID:   "msg1",
+				Body: `{"data": "us-east-1"}`,
+			},
+			want: &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jobconfig-cd4dd21748",
+					Namespace: "test",
+					Annotations: map[string]string{
+						jobutil.AnnotationKeyTriggerMessage: "trigger/msg1",
+					},
+				},
+				Spec: execution.JobSpec{
+					ConfigName: "jobconfig",
+				},
+			},
+		},
+		{
+			name: "invalid body",
+			msg: &trigger.Message{
+				ID:   "msg1",
+				Body: "not json",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tr.NewJob(tt.msg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var invalidErr *trigger.InvalidMessageError
+				assert.True(t, errors.As(err, &invalidErr))
+				return
+			}
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("NewJob() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestTrigger_NewJob_Idempotent(t *testing.T) {
+	tr := &trigger.Trigger{Name: "trigger", Namespace: "test", JobConfigName: "jobconfig"}
+	msg := &trigger.Message{ID: "msg1", Body: "{}"}
+	rj1, err := tr.NewJob(msg)
+	assert.NoError(t, err)
+	rj2, err := tr.NewJob(msg)
+	assert.NoError(t, err)
+	assert.Equal(t, rj1.Name, rj2.Name)
+
+	other, err := tr.NewJob(&trigger.Message{ID: "msg2", Body: "{}"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, rj1.Name, other.Name)
+}
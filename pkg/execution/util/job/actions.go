@@ -0,0 +1,118 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// retryDropAnnotations is the list of annotations which are not copied over to
+// a Job created from a retry request, since they describe the state of the
+// original Job.
+var retryDropAnnotations = []string{
+	LabelKeyAdmissionErrorMessage,
+	LabelKeyStartExpiredMessage,
+	LabelKeyKilledFromMaxRuntime,
+	LabelKeyNamespaceConcurrencyLimit,
+	LabelKeyConcurrencyGroupLimit,
+	AnnotationKeyRequestKill,
+	AnnotationKeyRequestRetry,
+	jobconfig.AnnotationKeyScheduleTime,
+}
+
+// GetRequestedKillTimestamp returns the kill timestamp that was requested via
+// the RequestKill annotation, and whether the annotation exists. An empty value
+// requests the Job to be killed immediately.
+func GetRequestedKillTimestamp(rj *execution.Job) (metav1.Time, bool, error) {
+	val, ok := rj.GetAnnotations()[AnnotationKeyRequestKill]
+	if !ok {
+		return metav1.Time{}, false, nil
+	}
+	ts, err := parseRequestTimestamp(val)
+	return ts, true, err
+}
+
+// GetRequestedRetryTimestamp returns the timestamp of the retry that was
+// requested via the RequestRetry annotation, and whether the annotation exists.
+// An empty value uses the current time.
+func GetRequestedRetryTimestamp(rj *execution.Job) (metav1.Time, bool, error) {
+	val, ok := rj.GetAnnotations()[AnnotationKeyRequestRetry]
+	if !ok {
+		return metav1.Time{}, false, nil
+	}
+	ts, err := parseRequestTimestamp(val)
+	return ts, true, err
+}
+
+// parseRequestTimestamp parses a timestamp from an annotation value, which may
+// be either in RFC3339 format or a Unix timestamp in seconds.
+func parseRequestTimestamp(val string) (metav1.Time, error) {
+	if val == "" {
+		return *ktime.Now(), nil
+	}
+	if ts, err := time.Parse(time.RFC3339, val); err == nil {
+		return metav1.NewTime(ts), nil
+	}
+	if unix, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return metav1.NewTime(time.Unix(unix, 0)), nil
+	}
+	return metav1.Time{}, fmt.Errorf("invalid timestamp %v, must be RFC3339 or Unix timestamp", val)
+}
+
+// NewRetryJob returns a new Job that retries the given Job with the same spec.
+// The name of the new Job is derived from the retry timestamp, such that
+// repeated attempts to create the Job for the same request are idempotent.
+func NewRetryJob(rj *execution.Job, retryTime metav1.Time) *execution.Job {
+	annotations := make(map[string]string, len(rj.Annotations)+1)
+	for k, v := range rj.Annotations {
+		annotations[k] = v
+	}
+	for _, key := range retryDropAnnotations {
+		delete(annotations, key)
+	}
+	annotations[AnnotationKeyRetriedFrom] = rj.Name
+
+	labels := make(map[string]string, len(rj.Labels))
+	for k, v := range rj.Labels {
+		labels[k] = v
+	}
+
+	spec := rj.Spec.DeepCopy()
+	spec.KillTimestamp = nil
+	if spec.StartPolicy != nil {
+		spec.StartPolicy.StartAfter = nil
+	}
+
+	return &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%v-retry-%v", rj.Name, retryTime.Unix()),
+			Namespace:       rj.Namespace,
+			Labels:          labels,
+			Annotations:     annotations,
+			OwnerReferences: rj.DeepCopy().OwnerReferences,
+		},
+		Spec: *spec,
+	}
+}
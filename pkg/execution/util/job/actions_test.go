@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestGetRequestedKillTimestamp(t *testing.T) {
+	mockNow := testutils.Mkmtime("2022-03-01T10:00:00Z")
+	ktime.Clock = clock.NewFakeClock(mockNow.Time)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        metav1.Time
+		wantOk      bool
+		wantErr     bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name: "empty value",
+			annotations: map[string]string{
+				jobutil.AnnotationKeyRequestKill: "",
+			},
+			want:   mockNow,
+			wantOk: true,
+		},
+		{
+			name: "RFC3339 value",
+			annotations: map[string]string{
+				jobutil.AnnotationKeyRequestKill: "2022-03-01T10:05:00Z",
+			},
+			want:   testutils.Mkmtime("2022-03-01T10:05:00Z"),
+			wantOk: true,
+		},
+		{
+			name: "Unix timestamp value",
+			annotations: map[string]string{
+				jobutil.AnnotationKeyRequestKill: "1646129100",
+			},
+			want:   metav1.NewTime(time.Unix(1646129100, 0)),
+			wantOk: true,
+		},
+		{
+			name: "invalid value",
+			annotations: map[string]string{
+				jobutil.AnnotationKeyRequestKill: "now",
+			},
+			wantOk:  true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			got, ok, err := jobutil.GetRequestedKillTimestamp(rj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRequestedKillTimestamp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("GetRequestedKillTimestamp() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !got.Equal(&tt.want) {
+				t.Errorf("GetRequestedKillTimestamp() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRetryJob(t *testing.T) {
+	retryTime := testutils.Mkmtime("2022-03-01T10:05:00Z")
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-1",
+			Namespace: "test",
+			Labels: map[string]string{
+				jobconfig.LabelKeyJobConfigUID: "uid",
+			},
+			Annotations: map[string]string{
+				"custom":                            "value",
+				jobutil.AnnotationKeyRequestRetry:   "2022-03-01T10:05:00Z",
+				jobutil.LabelKeyStartExpiredMessage: "expired",
+				jobconfig.AnnotationKeyScheduleTime: "1646128800",
+			},
+		},
+		Spec: execution.JobSpec{
+			Type:          execution.JobTypeScheduled,
+			OptionValues:  `{"foo":"bar"}`,
+			KillTimestamp: &retryTime,
+			StartPolicy: &execution.StartPolicySpec{
+				ConcurrencyPolicy: execution.ConcurrencyPolicyAllow,
+				StartAfter:        &retryTime,
+			},
+		},
+		Status: execution.JobStatus{
+			Phase: execution.JobRetryLimitExceeded,
+		},
+	}
+
+	want := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-1-retry-1646129100",
+			Namespace: "test",
+			Labels: map[string]string{
+				jobconfig.LabelKeyJobConfigUID: "uid",
+			},
+			Annotations: map[string]string{
+				"custom":                         "value",
+				jobutil.AnnotationKeyRetriedFrom: "job-1",
+			},
+		},
+		Spec: execution.JobSpec{
+			Type:         execution.JobTypeScheduled,
+			OptionValues: `{"foo":"bar"}`,
+			StartPolicy: &execution.StartPolicySpec{
+				ConcurrencyPolicy: execution.ConcurrencyPolicyAllow,
+			},
+		},
+	}
+
+	got := jobutil.NewRetryJob(rj, retryTime)
+	if !cmp.Equal(want, got) {
+		t.Errorf("NewRetryJob() not equal\ndiff = %v", cmp.Diff(want, got))
+	}
+}
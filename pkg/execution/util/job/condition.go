@@ -19,12 +19,30 @@ package job
 import (
 	"fmt"
 
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/utils/ktime"
 )
 
 // GetCondition returns a consolidated JobCondition computed from TaskRefs.
 func GetCondition(rj *execution.Job) execution.JobCondition {
+	state := getCondition(rj)
+
+	// Jobs that were killed after reaching their maximum runtime should be
+	// distinguished from Jobs that were killed externally.
+	if finished := state.Finished; finished != nil && finished.Result == execution.JobResultKilled &&
+		IsKilledFromMaxRuntime(rj) {
+		finished.Result = execution.JobResultDeadlineExceeded
+		finished.Reason = "MaxRuntimeExceeded"
+		finished.Message = "Job exceeded its maximum runtime and was killed"
+	}
+
+	return state
+}
+
+func getCondition(rj *execution.Job) execution.JobCondition {
 	state := execution.JobCondition{}
 
 	// We cannot create tasks due to a user error.
@@ -45,16 +63,47 @@ func GetCondition(rj *execution.Job) execution.JobCondition {
 		return state
 	}
 
+	// The Job was not started before its start deadline.
+	if message, ok := GetStartExpiredMessage(rj); ok && rj.Status.StartTime.IsZero() {
+		newStatus := &execution.JobConditionFinished{
+			FinishedAt: *ktime.Now(),
+			Result:     execution.JobResultExpired,
+			Reason:     "Expired",
+			Message:    message,
+		}
+
+		// Use old FinishedAt if previously set.
+		if oldStatus := rj.Status.Condition.Finished; oldStatus != nil && !oldStatus.FinishedAt.IsZero() {
+			newStatus.FinishedAt = oldStatus.FinishedAt
+		}
+
+		state.Finished = newStatus
+		return state
+	}
+
 	// Not yet started.
 	if rj.Status.StartTime.IsZero() {
 		var reason, message string
 
 		// The job is explicitly enqueued to start later. If it is nil, it will be
 		// started almost immediately so no need to set any special Reason/Message.
-		if spec := rj.Spec.StartPolicy; spec != nil {
+		if IsSuspended(rj) {
+			reason = "Suspended"
+			message = "Job is suspended and will not be started until it is resumed"
+		} else if limit, ok := GetNamespaceConcurrencyLimit(rj); ok {
+			reason = "NamespaceConcurrencyLimit"
+			message = fmt.Sprintf("Job is queued until fewer than %v Jobs are running in namespace %v",
+				limit, rj.Namespace)
+		} else if group, ok := GetConcurrencyGroupLimit(rj); ok {
+			reason = "ConcurrencyGroupLimit"
+			message = fmt.Sprintf("Job is queued pending other Jobs in concurrency group %v to be finished", group)
+		} else if spec := rj.Spec.StartPolicy; spec != nil {
 			if !spec.StartAfter.IsZero() {
 				reason = "NotYetDue"
 				message = fmt.Sprintf("Job is queued to start no earlier than %v", spec.StartAfter)
+			} else if len(spec.DependsOn) > 0 {
+				reason = "WaitingForDependencies"
+				message = "Job is queued and pending its dependencies to be satisfied"
 			} else if spec.ConcurrencyPolicy == execution.ConcurrencyPolicyEnqueue {
 				reason = "Queued"
 				message = "Job is queued and may be pending other concurrent jobs to be finished"
@@ -82,14 +131,43 @@ func GetCondition(rj *execution.Job) execution.JobCondition {
 
 		// Otherwise, the job is waiting for tasks to be created.
 		state.Waiting = &execution.JobConditionWaiting{}
+		if IsSuspended(rj) {
+			state.Waiting.Reason = "Suspended"
+			state.Waiting.Message = "Job is suspended and will not create tasks until it is resumed"
+		}
 		return state
 	}
 
+	// Steps may be executed in parallel, so we cannot use the latest task ref.
+	if HasSteps(rj) {
+		return getStepsCondition(rj)
+	}
+
 	// Get latest task ref.
 	latestTask := taskRefs[len(taskRefs)-1]
 
 	// Latest task is finished.
 	if finishTime := latestTask.FinishTimestamp; !finishTime.IsZero() {
+		// The job is suspended, and will create a new task once it is resumed.
+		if IsSuspended(rj) && canCreateNewTask(rj) {
+			state.Waiting = &execution.JobConditionWaiting{
+				CreatedAt: &latestTask.CreationTimestamp,
+				Reason:    "Suspended",
+				Message:   "Job is suspended and will not create tasks until it is resumed",
+			}
+			return state
+		}
+
+		// The job was resumed after its task was killed due to suspension.
+		if latestTask.Suspended && AllowedToCreateNewTask(rj) {
+			state.Waiting = &execution.JobConditionWaiting{
+				CreatedAt: &latestTask.CreationTimestamp,
+				Reason:    "Resuming",
+				Message:   "Waiting to create new task after being resumed",
+			}
+			return state
+		}
+
 		// The task did not succeed, still got more retries.
 		if AllowedToCreateNewTask(rj) {
 			state.Waiting = &execution.JobConditionWaiting{
@@ -136,3 +214,64 @@ func GetCondition(rj *execution.Job) execution.JobCondition {
 	}
 	return state
 }
+
+// GetConditions returns the standard conditions of a Job, computed from its
+// JobCondition and JobPhase. The Job's existing conditions are updated such
+// that the transition time is only changed when the status of a condition
+// changes. Since the Job is progressing from the time it is created until it
+// is finished, these are used as the transition times.
+func GetConditions(rj *execution.Job) []metav1.Condition {
+	conditions := make([]metav1.Condition, len(rj.Status.Conditions))
+	copy(conditions, rj.Status.Conditions)
+
+	phase := string(rj.Status.Phase)
+	transitionTime := rj.CreationTimestamp
+	if transitionTime.IsZero() {
+		transitionTime = *ktime.Now()
+	}
+
+	progressing := metav1.Condition{
+		Type:               execution.JobConditionTypeProgressing,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: rj.Generation,
+		LastTransitionTime: transitionTime,
+		Reason:             phase,
+	}
+	finished := metav1.Condition{
+		Type:               execution.JobConditionTypeFinished,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: rj.Generation,
+		LastTransitionTime: transitionTime,
+		Reason:             phase,
+	}
+
+	condition := rj.Status.Condition
+	switch {
+	case condition.Finished != nil:
+		progressing.Status = metav1.ConditionFalse
+		progressing.LastTransitionTime = condition.Finished.FinishedAt
+		progressing.Message = "Job is finished"
+		finished.Status = metav1.ConditionTrue
+		finished.LastTransitionTime = condition.Finished.FinishedAt
+		finished.Reason = string(condition.Finished.Result)
+		finished.Message = condition.Finished.Message
+	case condition.Queueing != nil:
+		progressing.Message = condition.Queueing.Message
+	case condition.Waiting != nil:
+		progressing.Message = condition.Waiting.Message
+	}
+
+	// Reason is required for all conditions.
+	for _, c := range []*metav1.Condition{&progressing, &finished} {
+		if c.Reason == "" {
+			c.Reason = "Unknown"
+		}
+		if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = transitionTime
+		}
+	}
+
+	apimeta.SetStatusCondition(&conditions, progressing)
+	apimeta.SetStatusCondition(&conditions, finished)
+	return conditions
+}
@@ -129,6 +129,74 @@ func TestGetCondition(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Held by namespace concurrency limit",
+			args: args{
+				rj: &execution.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Annotations: map[string]string{
+							jobutil.LabelKeyNamespaceConcurrencyLimit: "5",
+						},
+					},
+					Spec: execution.JobSpec{
+						StartPolicy: &execution.StartPolicySpec{
+							ConcurrencyPolicy: execution.ConcurrencyPolicyAllow,
+						},
+					},
+				},
+				tasks:      []tasks.Task{},
+				notStarted: true,
+			},
+			want: execution.JobCondition{
+				Queueing: &execution.JobConditionQueueing{
+					Reason:  "NamespaceConcurrencyLimit",
+					Message: "Job is queued until fewer than 5 Jobs are running in namespace test",
+				},
+			},
+		},
+		{
+			name: "Held by concurrency group",
+			args: args{
+				rj: &execution.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							jobutil.LabelKeyConcurrencyGroupLimit: "database",
+						},
+					},
+				},
+				tasks:      []tasks.Task{},
+				notStarted: true,
+			},
+			want: execution.JobCondition{
+				Queueing: &execution.JobConditionQueueing{
+					Reason:  "ConcurrencyGroupLimit",
+					Message: "Job is queued pending other Jobs in concurrency group database to be finished",
+				},
+			},
+		},
+		{
+			name: "Waiting for dependencies",
+			args: args{
+				rj: &execution.Job{
+					Spec: execution.JobSpec{
+						StartPolicy: &execution.StartPolicySpec{
+							DependsOn: []execution.JobDependency{
+								{JobName: "other-job"},
+							},
+						},
+					},
+				},
+				tasks:      []tasks.Task{},
+				notStarted: true,
+			},
+			want: execution.JobCondition{
+				Queueing: &execution.JobConditionQueueing{
+					Reason:  "WaitingForDependencies",
+					Message: "Job is queued and pending its dependencies to be satisfied",
+				},
+			},
+		},
 		{
 			name: "Start later at a specific time with Enqueued",
 			args: args{
@@ -172,6 +240,28 @@ func TestGetCondition(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Expired without StartTime",
+			args: args{
+				rj: &execution.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							jobutil.LabelKeyStartExpiredMessage: "expired message",
+						},
+					},
+				},
+				tasks:      []tasks.Task{},
+				notStarted: true,
+			},
+			want: execution.JobCondition{
+				Finished: &execution.JobConditionFinished{
+					FinishedAt: metav1.NewTime(timeNow),
+					Result:     execution.JobResultExpired,
+					Reason:     "Expired",
+					Message:    "expired message",
+				},
+			},
+		},
 		{
 			name: "No tasks created yet",
 			args: args{
@@ -199,6 +289,50 @@ func TestGetCondition(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Job killed from max runtime with no task created",
+			args: args{
+				rj: func() *execution.Job {
+					rj := &execution.Job{
+						Spec: execution.JobSpec{
+							KillTimestamp: &killTime,
+						},
+					}
+					jobutil.MarkKilledFromMaxRuntime(rj, killTime)
+					return rj
+				}(),
+				tasks: []tasks.Task{},
+			},
+			want: execution.JobCondition{
+				Finished: &execution.JobConditionFinished{
+					FinishedAt: killTime,
+					Result:     execution.JobResultDeadlineExceeded,
+					Reason:     "MaxRuntimeExceeded",
+					Message:    "Job exceeded its maximum runtime and was killed",
+				},
+			},
+		},
+		{
+			name: "Job killed with earlier kill timestamp than max runtime",
+			args: args{
+				rj: func() *execution.Job {
+					rj := &execution.Job{
+						Spec: execution.JobSpec{
+							KillTimestamp: &killTime,
+						},
+					}
+					jobutil.MarkKilledFromMaxRuntime(rj, metav1.NewTime(killTime.Add(time.Hour)))
+					return rj
+				}(),
+				tasks: []tasks.Task{},
+			},
+			want: execution.JobCondition{
+				Finished: &execution.JobConditionFinished{
+					FinishedAt: killTime,
+					Result:     execution.JobResultKilled,
+				},
+			},
+		},
 		{
 			name: "Task is not yet running",
 			args: args{
@@ -789,6 +923,122 @@ func TestGetCondition(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Not started and suspended",
+			args: args{
+				rj: &execution.Job{
+					Spec: execution.JobSpec{
+						Suspend: true,
+						StartPolicy: &execution.StartPolicySpec{
+							StartAfter: &startTime,
+						},
+					},
+				},
+				tasks:      []tasks.Task{},
+				notStarted: true,
+			},
+			want: execution.JobCondition{
+				Queueing: &execution.JobConditionQueueing{
+					Reason:  "Suspended",
+					Message: "Job is suspended and will not be started until it is resumed",
+				},
+			},
+		},
+		{
+			name: "Task killed due to suspension",
+			args: args{
+				rj: &execution.Job{
+					Spec: execution.JobSpec{
+						Suspend: true,
+					},
+					Status: createSuspendedTaskRefsStatus("task1"),
+				},
+				tasks: []tasks.Task{
+					&stubTask{
+						taskRef: execution.TaskRef{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							RunningTimestamp:  &startTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskKilled,
+								Result: jobutil.GetResultPtr(execution.JobResultKilled),
+							},
+						},
+					},
+				},
+			},
+			want: execution.JobCondition{
+				Waiting: &execution.JobConditionWaiting{
+					CreatedAt: &createTime,
+					Reason:    "Suspended",
+					Message:   "Job is suspended and will not create tasks until it is resumed",
+				},
+			},
+		},
+		{
+			name: "Task killed due to suspension, then resumed",
+			args: args{
+				rj: &execution.Job{
+					Status: createSuspendedTaskRefsStatus("task1"),
+				},
+				tasks: []tasks.Task{
+					&stubTask{
+						taskRef: execution.TaskRef{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							RunningTimestamp:  &startTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskKilled,
+								Result: jobutil.GetResultPtr(execution.JobResultKilled),
+							},
+						},
+					},
+				},
+			},
+			want: execution.JobCondition{
+				Waiting: &execution.JobConditionWaiting{
+					CreatedAt: &createTime,
+					Reason:    "Resuming",
+					Message:   "Waiting to create new task after being resumed",
+				},
+			},
+		},
+		{
+			name: "Task killed due to suspension, then killed",
+			args: args{
+				rj: &execution.Job{
+					Spec: execution.JobSpec{
+						Suspend:       true,
+						KillTimestamp: &killTime,
+					},
+					Status: createSuspendedTaskRefsStatus("task1"),
+				},
+				tasks: []tasks.Task{
+					&stubTask{
+						taskRef: execution.TaskRef{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							RunningTimestamp:  &startTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskKilled,
+								Result: jobutil.GetResultPtr(execution.JobResultKilled),
+							},
+						},
+					},
+				},
+			},
+			want: execution.JobCondition{
+				Finished: &execution.JobConditionFinished{
+					CreatedAt:  &createTime,
+					StartedAt:  &startTime,
+					FinishedAt: finishTime,
+					Result:     execution.JobResultKilled,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -827,3 +1077,112 @@ func createTaskRefsStatus(taskNames ...string) execution.JobStatus {
 		Tasks:        refs,
 	}
 }
+
+func createSuspendedTaskRefsStatus(taskNames ...string) execution.JobStatus {
+	status := createTaskRefsStatus(taskNames...)
+	for i := range status.Tasks {
+		status.Tasks[i].Suspended = true
+	}
+	return status
+}
+
+func TestGetConditions(t *testing.T) {
+	tests := []struct {
+		name string
+		rj   *execution.Job
+		want []metav1.Condition
+	}{
+		{
+			name: "Queued",
+			rj: &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: createTime,
+				},
+				Status: execution.JobStatus{
+					Phase: execution.JobQueued,
+					Condition: execution.JobCondition{
+						Queueing: &execution.JobConditionQueueing{
+							Reason:  "NotYetDue",
+							Message: "Job is queued to start later",
+						},
+					},
+				},
+			},
+			want: []metav1.Condition{
+				{
+					Type:               execution.JobConditionTypeProgressing,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: createTime,
+					Reason:             string(execution.JobQueued),
+					Message:            "Job is queued to start later",
+				},
+				{
+					Type:               execution.JobConditionTypeFinished,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: createTime,
+					Reason:             string(execution.JobQueued),
+				},
+			},
+		},
+		{
+			name: "Finished with existing conditions",
+			rj: &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: createTime,
+					Generation:        2,
+				},
+				Status: execution.JobStatus{
+					Phase: execution.JobRetryLimitExceeded,
+					Condition: execution.JobCondition{
+						Finished: &execution.JobConditionFinished{
+							FinishedAt: finishTime,
+							Result:     execution.JobResultTaskFailed,
+							Message:    "Task failed",
+						},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:               execution.JobConditionTypeProgressing,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: createTime,
+							Reason:             string(execution.JobRunning),
+						},
+						{
+							Type:               execution.JobConditionTypeFinished,
+							Status:             metav1.ConditionFalse,
+							LastTransitionTime: createTime,
+							Reason:             string(execution.JobRunning),
+						},
+					},
+				},
+			},
+			want: []metav1.Condition{
+				{
+					Type:               execution.JobConditionTypeProgressing,
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 2,
+					LastTransitionTime: finishTime,
+					Reason:             string(execution.JobRetryLimitExceeded),
+					Message:            "Job is finished",
+				},
+				{
+					Type:               execution.JobConditionTypeFinished,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 2,
+					LastTransitionTime: finishTime,
+					Reason:             string(execution.JobResultTaskFailed),
+					Message:            "Task failed",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := jobutil.GetConditions(tt.rj)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("GetConditions() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	"k8s.io/apimachinery/pkg/fields"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// Field paths which can be registered as selectable fields on the Job CRD, and
+// used in field selectors for server-side filtered List/Watch requests. This
+// requires Kubernetes v1.30+, see config/crd/patches/selectablefields_in_jobs.yaml.
+//
+// To select Jobs created from a JobConfig, use a label selector for
+// jobconfig.LabelJobsForJobConfig instead, since spec.configName is cleared
+// when the Job is admitted.
+const (
+	FieldPathType  = "spec.type"
+	FieldPathPhase = "status.phase"
+)
+
+// FieldSelectorForPhase returns a field selector that selects all Jobs in the
+// given phase.
+func FieldSelectorForPhase(phase execution.JobPhase) fields.Selector {
+	return fields.OneTermEqualSelector(FieldPathPhase, string(phase))
+}
+
+// GetSelectableFields returns the values of all selectable fields of the Job,
+// which can be used to match a field selector against a Job on the client.
+func GetSelectableFields(rj *execution.Job) fields.Set {
+	return fields.Set{
+		FieldPathType:  string(rj.Spec.Type),
+		FieldPathPhase: string(rj.Status.Phase),
+	}
+}
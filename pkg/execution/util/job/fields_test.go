@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job_test
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+)
+
+func TestFieldSelectors(t *testing.T) {
+	rjc := &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jobconfig-sample",
+			Namespace: "default",
+			UID:       "0ed1bc76-07ca-4cf7-9a47-a0cc4aec48b9",
+		},
+	}
+	otherRjc := &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jobconfig-other",
+			Namespace: "default",
+			UID:       "6e08ee33-ccb4-4e2b-a2a5-2b3a2d6b2d2a",
+		},
+	}
+
+	// Job as it looks after admission, where spec.configName has been cleared.
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job-sample",
+			Namespace: "default",
+			Labels:    jobconfig.LabelJobsForJobConfig(rjc),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(rjc, execution.GVKJobConfig),
+			},
+		},
+		Spec: execution.JobSpec{
+			Type: execution.JobTypeAdhoc,
+		},
+		Status: execution.JobStatus{
+			Phase: execution.JobRunning,
+		},
+	}
+	fields := jobutil.GetSelectableFields(rj)
+
+	if !labels.SelectorFromSet(jobconfig.LabelJobsForJobConfig(rjc)).Matches(labels.Set(rj.Labels)) {
+		t.Errorf("LabelJobsForJobConfig() did not match Job")
+	}
+	if labels.SelectorFromSet(jobconfig.LabelJobsForJobConfig(otherRjc)).Matches(labels.Set(rj.Labels)) {
+		t.Errorf("LabelJobsForJobConfig() matched Job with different JobConfig")
+	}
+	if !jobutil.FieldSelectorForPhase(execution.JobRunning).Matches(fields) {
+		t.Errorf("FieldSelectorForPhase() did not match Job")
+	}
+	if jobutil.FieldSelectorForPhase(execution.JobSucceeded).Matches(fields) {
+		t.Errorf("FieldSelectorForPhase() matched Job with different phase")
+	}
+}
@@ -17,7 +17,13 @@
 package job
 
 import (
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/utils/meta"
 )
 
@@ -27,8 +33,24 @@ import (
 //
 // It assumes that it should only have at most 1 task running at a time, does
 // not create more tasks on success, and stops creating tasks once KillTimestamp
-// is set (even if it is in the future).
+// is set (even if it is in the future). Jobs with steps create tasks using
+// GetRunnableSteps instead, so this always returns false for such Jobs.
 func AllowedToCreateNewTask(rj *execution.Job) bool {
+	if HasSteps(rj) {
+		return false
+	}
+
+	// Job is suspended, wait until it is resumed.
+	if IsSuspended(rj) {
+		return false
+	}
+
+	return canCreateNewTask(rj)
+}
+
+// canCreateNewTask returns whether a Job is allowed to create more tasks,
+// without taking into account whether the Job is suspended.
+func canCreateNewTask(rj *execution.Job) bool {
 	// Previously determined cannot create task, so give up.
 	if _, ok := GetAdmissionErrorMessage(rj); ok {
 		return false
@@ -52,6 +74,12 @@ func AllowedToCreateNewTask(rj *execution.Job) bool {
 	return true
 }
 
+// IsSuspended returns true if the Job is suspended. A Job that is being killed
+// is not considered to be suspended.
+func IsSuspended(rj *execution.Job) bool {
+	return rj.Spec.Suspend && rj.Spec.KillTimestamp == nil
+}
+
 // HasActiveTask returns true if any of the TaskRefs is still active.
 // Active means that the task is currently active (pending/running).
 func HasActiveTask(rj *execution.Job) bool {
@@ -73,6 +101,72 @@ func HasSuccessTask(rj *execution.Job) bool {
 	return false
 }
 
+// IsInPlaceRetry returns true if retries of the Job's tasks reuse the same name.
+func IsInPlaceRetry(rj *execution.Job) bool {
+	return rj.Spec.Template != nil && rj.Spec.Template.RetryMode == execution.RetryModeInPlace && !HasSteps(rj)
+}
+
+// IsExternal returns true if the Job's tasks are run on an external runner
+// instead of as Pods.
+func IsExternal(rj *execution.Job) bool {
+	return rj.Spec.Template != nil && rj.Spec.Template.Task.External != nil && !HasSteps(rj)
+}
+
+// ShouldOrphanTasks returns true if the tasks of the Job should be orphaned
+// instead of deleted when the Job is deleted.
+func ShouldOrphanTasks(rj *execution.Job) bool {
+	return rj.Spec.Template != nil && rj.Spec.Template.TaskDeletionPolicy == execution.TaskDeletionPolicyOrphan &&
+		!IsExternal(rj)
+}
+
+// GetPreKillHook returns the pre-kill hook for tasks of the Job, or for tasks of
+// the given step if it is not empty. The container of the returned hook will be
+// defaulted to the first container of the task template. Returns nil if no
+// pre-kill hook is specified.
+func GetPreKillHook(rj *execution.Job, step string) *execution.TaskPreKillHook {
+	taskSpec := getTaskSpec(rj, step)
+	if taskSpec == nil || taskSpec.PreKillHook == nil || taskSpec.External != nil {
+		return nil
+	}
+
+	hook := taskSpec.PreKillHook.DeepCopy()
+	if hook.Container == "" && len(taskSpec.Template.Spec.Containers) > 0 {
+		hook.Container = taskSpec.Template.Spec.Containers[0].Name
+	}
+	return hook
+}
+
+// GetStallDetection returns the stall detection settings for tasks of the
+// given step, or the Job's task template if step is empty. Returns nil if stall
+// detection is disabled.
+func GetStallDetection(rj *execution.Job, step string) *execution.TaskStallDetection {
+	taskSpec := getTaskSpec(rj, step)
+	if taskSpec == nil || taskSpec.StallDetection == nil || taskSpec.External != nil {
+		return nil
+	}
+	if taskSpec.StallDetection.TimeoutSeconds <= 0 {
+		return nil
+	}
+	return taskSpec.StallDetection.DeepCopy()
+}
+
+// getTaskSpec returns the JobTaskSpec for the given step, or the Job's task
+// template if step is empty.
+func getTaskSpec(rj *execution.Job, step string) *execution.JobTaskSpec {
+	if rj.Spec.Template == nil {
+		return nil
+	}
+	if step == "" {
+		return &rj.Spec.Template.Task
+	}
+	for i, stepSpec := range rj.Spec.Template.Steps {
+		if stepSpec.Name == step {
+			return &rj.Spec.Template.Steps[i].Task
+		}
+	}
+	return nil
+}
+
 // MarkAdmissionError updates a Job to add the AdmissionError annotation.
 func MarkAdmissionError(rj *execution.Job, msg string) {
 	meta.SetAnnotation(rj, LabelKeyAdmissionErrorMessage, msg)
@@ -84,3 +178,145 @@ func GetAdmissionErrorMessage(rj *execution.Job) (string, bool) {
 	val, ok := rj.GetAnnotations()[LabelKeyAdmissionErrorMessage]
 	return val, ok
 }
+
+// MarkStartExpired updates a Job to add the StartExpired annotation.
+func MarkStartExpired(rj *execution.Job, msg string) {
+	meta.SetAnnotation(rj, LabelKeyStartExpiredMessage, msg)
+}
+
+// GetStartExpiredMessage returns the message if the Job contains the
+// StartExpired annotation.
+func GetStartExpiredMessage(rj *execution.Job) (string, bool) {
+	val, ok := rj.GetAnnotations()[LabelKeyStartExpiredMessage]
+	return val, ok
+}
+
+// IsImmutableUpdateAllowed returns true if the Job has the AllowImmutableUpdate
+// annotation set to "true".
+func IsImmutableUpdateAllowed(rj *execution.Job) bool {
+	return rj.GetAnnotations()[AnnotationKeyAllowImmutableUpdate] == "true"
+}
+
+// SetCreatedBy updates a Job to store the username of the user who created it.
+// Any existing value will be removed if the username is empty.
+func SetCreatedBy(rj *execution.Job, username string) {
+	if username == "" {
+		delete(rj.Annotations, AnnotationKeyCreatedBy)
+		return
+	}
+	meta.SetAnnotation(rj, AnnotationKeyCreatedBy, username)
+}
+
+// GetCreatedBy returns the username of the user who created the Job, or an
+// empty string if it is not known.
+func GetCreatedBy(rj *execution.Job) string {
+	return rj.GetAnnotations()[AnnotationKeyCreatedBy]
+}
+
+// GetJobConfigName returns the name of the JobConfig that the Job was created
+// from, or an empty string if it was not created from a JobConfig. Since
+// spec.configName is cleared when the Job is admitted, the name is looked up
+// from the controller reference instead.
+func GetJobConfigName(rj *execution.Job) string {
+	if ref := metav1.GetControllerOf(rj); ref != nil && ref.Kind == execution.KindJobConfig {
+		return ref.Name
+	}
+	return ""
+}
+
+// MarkKilledFromMaxRuntime updates a Job to add the KilledFromMaxRuntime
+// annotation for the given kill timestamp.
+func MarkKilledFromMaxRuntime(rj *execution.Job, killTimestamp metav1.Time) {
+	meta.SetAnnotation(rj, LabelKeyKilledFromMaxRuntime, strconv.FormatInt(killTimestamp.Unix(), 10))
+}
+
+// IsKilledFromMaxRuntime returns true if the Job's kill timestamp was set after
+// the Job reached its maximum runtime. If the kill timestamp has since been
+// changed, the Job is no longer considered to be killed from max runtime.
+func IsKilledFromMaxRuntime(rj *execution.Job) bool {
+	val, ok := rj.GetAnnotations()[LabelKeyKilledFromMaxRuntime]
+	if !ok || rj.Spec.KillTimestamp.IsZero() {
+		return false
+	}
+	return val == strconv.FormatInt(rj.Spec.KillTimestamp.Unix(), 10)
+}
+
+// MarkNamespaceConcurrencyLimited updates a Job to add the
+// NamespaceConcurrencyLimit annotation for the given limit. Since a Job is only
+// held back for a single reason at a time, any ConcurrencyGroupLimit annotation
+// is removed.
+func MarkNamespaceConcurrencyLimited(rj *execution.Job, limit int64) {
+	meta.SetAnnotation(rj, LabelKeyNamespaceConcurrencyLimit, strconv.FormatInt(limit, 10))
+	delete(rj.Annotations, LabelKeyConcurrencyGroupLimit)
+}
+
+// GetNamespaceConcurrencyLimit returns the per-namespace concurrency limit that
+// prevented the Job from being started, if any.
+func GetNamespaceConcurrencyLimit(rj *execution.Job) (int64, bool) {
+	val, ok := rj.GetAnnotations()[LabelKeyNamespaceConcurrencyLimit]
+	if !ok {
+		return 0, false
+	}
+	limit, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return limit, true
+}
+
+// GetPriority returns the start priority of the Job. Jobs with a higher priority
+// should be started before other queued Jobs.
+func GetPriority(rj *execution.Job) int32 {
+	if spec := rj.Spec.StartPolicy; spec != nil {
+		return spec.Priority
+	}
+	return 0
+}
+
+// GetQueueKey returns the key used to order queued Jobs with the same priority,
+// where Jobs with a smaller key should be started first. Returns the persisted
+// key in the JobStatus if it was already set.
+func GetQueueKey(rj *execution.Job) string {
+	if rj.Status.QueueKey != "" {
+		return rj.Status.QueueKey
+	}
+	return ComputeQueueKey(rj)
+}
+
+// ComputeQueueKey computes the queue key for a Job, which orders Jobs by their
+// schedule time (or creation time if the Job was not scheduled). Ties are broken
+// using the creation time and name of the Job, so that the order is
+// deterministic.
+func ComputeQueueKey(rj *execution.Job) string {
+	queueTime := rj.CreationTimestamp
+	if ts := jobconfig.GetLabelScheduleTime(rj); ts != nil {
+		queueTime = *ts
+	}
+	return fmt.Sprintf("%v.%v.%v", formatQueueKeyTime(queueTime), formatQueueKeyTime(rj.CreationTimestamp), rj.Name)
+}
+
+// formatQueueKeyTime formats a timestamp as a fixed-width string which sorts
+// lexicographically in chronological order.
+func formatQueueKeyTime(ts metav1.Time) string {
+	var unix int64
+	if !ts.IsZero() {
+		unix = ts.Unix()
+	}
+	return fmt.Sprintf("%012d", unix)
+}
+
+// MarkConcurrencyGroupLimited updates a Job to add the ConcurrencyGroupLimit
+// annotation for the given concurrency group. Since a Job is only held back for
+// a single reason at a time, any NamespaceConcurrencyLimit annotation is
+// removed.
+func MarkConcurrencyGroupLimited(rj *execution.Job, group string) {
+	meta.SetAnnotation(rj, LabelKeyConcurrencyGroupLimit, group)
+	delete(rj.Annotations, LabelKeyNamespaceConcurrencyLimit)
+}
+
+// GetConcurrencyGroupLimit returns the name of the concurrency group that
+// prevented the Job from being started, if any.
+func GetConcurrencyGroupLimit(rj *execution.Job) (string, bool) {
+	val, ok := rj.GetAnnotations()[LabelKeyConcurrencyGroupLimit]
+	return val, ok
+}
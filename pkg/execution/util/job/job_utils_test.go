@@ -19,6 +19,11 @@ package job_test
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	jobtasks "github.com/furiko-io/furiko/pkg/execution/tasks"
 	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
@@ -42,6 +47,95 @@ func TestAllowedToCreateNewTask(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "Suspended with no tasks created yet",
+			args: args{
+				rj: &execution.Job{
+					Spec: execution.JobSpec{
+						Suspend: true,
+					},
+				},
+				tasks: []jobtasks.Task{},
+			},
+			want: false,
+		},
+		{
+			name: "Task killed due to suspension without retry",
+			args: args{
+				rj: &execution.Job{
+					Status: createSuspendedTaskRefsStatus("task1"),
+				},
+				tasks: []jobtasks.Task{
+					&stubTask{
+						taskRef: execution.TaskRef{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							RunningTimestamp:  &startTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskKilled,
+								Result: jobutil.GetResultPtr(execution.JobResultKilled),
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Task failed due to init container failure without retries",
+			args: args{
+				rj: &execution.Job{
+					Spec: execution.JobSpec{
+						Template: &execution.JobTemplateSpec{},
+					},
+					Status: createTaskRefsStatus("task1"),
+				},
+				tasks: []jobtasks.Task{
+					&stubTask{
+						taskRef: execution.TaskRef{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskFailed,
+								Result: jobutil.GetResultPtr(execution.JobResultTaskFailed),
+								Reason: jobutil.ReasonInitFailed,
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "Task failed due to init container failure with retries",
+			args: args{
+				rj: &execution.Job{
+					Spec: execution.JobSpec{
+						Template: &execution.JobTemplateSpec{
+							MaxInitFailureRetries: &two,
+						},
+					},
+					Status: createTaskRefsStatus("task1"),
+				},
+				tasks: []jobtasks.Task{
+					&stubTask{
+						taskRef: execution.TaskRef{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskFailed,
+								Result: jobutil.GetResultPtr(execution.JobResultTaskFailed),
+								Reason: jobutil.ReasonInitFailed,
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
 		{
 			name: "Task is not yet running",
 			args: args{
@@ -250,3 +344,148 @@ func TestAllowedToCreateNewTask(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPreKillHook(t *testing.T) {
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "main"},
+				{Name: "sidecar"},
+			},
+		},
+	}
+	tests := []struct {
+		name string
+		rj   *execution.Job
+		step string
+		want *execution.TaskPreKillHook
+	}{
+		{
+			name: "no template",
+			rj:   &execution.Job{},
+		},
+		{
+			name: "no pre-kill hook",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						Task: execution.JobTaskSpec{
+							Template: template,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "default to first container",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						Task: execution.JobTaskSpec{
+							Template: template,
+							PreKillHook: &execution.TaskPreKillHook{
+								Command: []string{"/bin/checkpoint"},
+							},
+						},
+					},
+				},
+			},
+			want: &execution.TaskPreKillHook{
+				Container: "main",
+				Command:   []string{"/bin/checkpoint"},
+			},
+		},
+		{
+			name: "hook for step",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						Steps: []execution.JobStepSpec{
+							{
+								Name: "step1",
+								Task: execution.JobTaskSpec{
+									Template: template,
+									PreKillHook: &execution.TaskPreKillHook{
+										Container: "sidecar",
+										Command:   []string{"/bin/checkpoint"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			step: "step1",
+			want: &execution.TaskPreKillHook{
+				Container: "sidecar",
+				Command:   []string{"/bin/checkpoint"},
+			},
+		},
+		{
+			name: "step not found",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						Task: execution.JobTaskSpec{
+							Template: template,
+							PreKillHook: &execution.TaskPreKillHook{
+								Command: []string{"/bin/checkpoint"},
+							},
+						},
+					},
+				},
+			},
+			step: "step1",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobutil.GetPreKillHook(tt.rj, tt.step); !cmp.Equal(got, tt.want) {
+				t.Errorf("GetPreKillHook() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestGetJobConfigName(t *testing.T) {
+	tests := []struct {
+		name string
+		refs []metav1.OwnerReference
+		want string
+	}{
+		{
+			name: "no owner references",
+		},
+		{
+			name: "controlled by jobconfig",
+			refs: []metav1.OwnerReference{
+				{Kind: execution.KindJobConfig, Name: "jobconfig", Controller: pointer.Bool(true)},
+			},
+			want: "jobconfig",
+		},
+		{
+			name: "not controller",
+			refs: []metav1.OwnerReference{
+				{Kind: execution.KindJobConfig, Name: "jobconfig"},
+			},
+		},
+		{
+			name: "controlled by other kind",
+			refs: []metav1.OwnerReference{
+				{Kind: "CronJob", Name: "cronjob", Controller: pointer.Bool(true)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{OwnerReferences: tt.refs},
+				Spec:       execution.JobSpec{ConfigName: "ignored"},
+			}
+			if got := jobutil.GetJobConfigName(rj); got != tt.want {
+				t.Errorf("GetJobConfigName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -25,4 +25,65 @@ var (
 	// tasks. This usually implies a misconfiguration that we cannot retry further,
 	// hence the Job should transit into a terminal state.
 	LabelKeyAdmissionErrorMessage = executiongroup.AddGroupToLabel("admission-error")
+
+	// LabelKeyStartExpiredMessage stores the message explaining why a queued Job
+	// was not started before its start deadline. Once set, the Job will transit
+	// into a terminal state without being started.
+	LabelKeyStartExpiredMessage = executiongroup.AddGroupToLabel("start-expired")
+
+	// LabelKeyKilledFromMaxRuntime stores the kill timestamp (in Unix seconds) that
+	// was set on the Job after it reached its maximum runtime. This is used to
+	// distinguish timeouts from kills that were requested externally.
+	LabelKeyKilledFromMaxRuntime = executiongroup.AddGroupToLabel("killed-from-max-runtime")
+
+	// LabelKeyNamespaceConcurrencyLimit stores the per-namespace concurrency limit
+	// that prevented a queued Job from being started. This is used to surface the
+	// reason why the Job remains queued.
+	LabelKeyNamespaceConcurrencyLimit = executiongroup.AddGroupToLabel("namespace-concurrency-limit")
+
+	// LabelKeyConcurrencyGroupLimit stores the name of the concurrency group that
+	// prevented a queued Job from being started. This is used to surface the
+	// reason why the Job remains queued.
+	LabelKeyConcurrencyGroupLimit = executiongroup.AddGroupToLabel("concurrency-group-limit")
+
+	// AnnotationKeyRequestKill requests the Job to be killed at the given
+	// timestamp, or immediately if empty. This allows users who can only annotate
+	// Jobs to kill them, and is cleared once the kill timestamp has been set.
+	AnnotationKeyRequestKill = executiongroup.AddGroupToLabel("request-kill")
+
+	// AnnotationKeyRequestRetry requests a finished Job to be retried by creating
+	// a new Job with the same spec. The value should be a timestamp, which
+	// uniquely identifies the retry request. The annotation is kept until the Job
+	// is finished, and cleared once the new Job has been created.
+	AnnotationKeyRequestRetry = executiongroup.AddGroupToLabel("request-retry")
+
+	// AnnotationKeyRetriedFrom stores the name of the Job that a Job was created
+	// to retry via AnnotationKeyRequestRetry.
+	AnnotationKeyRetriedFrom = executiongroup.AddGroupToLabel("retried-from")
+
+	// AnnotationKeyTriggerMessage stores the name of the trigger and the ID of the
+	// message that the Job was created from, in the form <trigger>/<messageID>.
+	AnnotationKeyTriggerMessage = executiongroup.AddGroupToLabel("trigger-message")
+
+	// AnnotationKeyAllowImmutableUpdate, when set to "true" on a Job in an update
+	// request, allows the immutable fields of the Job's spec (e.g. type and
+	// template) to be updated. This is only meant as an escape hatch for
+	// break-glass edits, and should be removed once the edit is done. Changes to
+	// the JobConfig UID label and a kill timestamp that has passed are still not
+	// allowed.
+	AnnotationKeyAllowImmutableUpdate = executiongroup.AddGroupToLabel("allow-immutable-update")
+
+	// AnnotationKeyCreatedBy stores the username of the user who created the Job,
+	// and is set by the mutating webhook from the admission request. This is used
+	// to enforce per-user quotas on ad-hoc Jobs.
+	AnnotationKeyCreatedBy = executiongroup.AddGroupToLabel("created-by")
+
+	// AnnotationKeyNotificationsSent stores a comma-separated list of the
+	// JobConfig notifications that were sent for the Job, each in the form of
+	// <trigger>/<index>. This ensures that each notification is sent at most once.
+	AnnotationKeyNotificationsSent = executiongroup.AddGroupToLabel("notifications-sent")
+
+	// AnnotationKeyArchiveTime stores the time (in RFC3339 format) that the Job
+	// was written to the archive, so that it is only archived once.
+	AnnotationKeyArchiveTime = executiongroup.AddGroupToLabel("archive-time")
 )
@@ -39,6 +39,8 @@ func GetPhase(rj *v1alpha1.Job) v1alpha1.JobPhase {
 			return v1alpha1.JobDeadlineExceeded
 		case v1alpha1.JobResultAdmissionError:
 			return v1alpha1.JobAdmissionError
+		case v1alpha1.JobResultExpired:
+			return v1alpha1.JobExpired
 		case v1alpha1.JobResultFinalStateUnknown:
 			fallthrough
 		default:
@@ -51,6 +53,11 @@ func GetPhase(rj *v1alpha1.Job) v1alpha1.JobPhase {
 		return v1alpha1.JobKilling
 	}
 
+	// Use JobSuspended if the job is suspended.
+	if IsSuspended(rj) {
+		return v1alpha1.JobSuspended
+	}
+
 	// Handle JobConditionRunning.
 	if rj.Status.Condition.Running != nil {
 		return v1alpha1.JobRunning
@@ -63,6 +70,11 @@ func GetPhase(rj *v1alpha1.Job) v1alpha1.JobPhase {
 			return v1alpha1.JobStarting
 		}
 
+		// Steps are never retried, so the Job is simply pending other steps.
+		if HasSteps(rj) {
+			return v1alpha1.JobPending
+		}
+
 		latestTask := rj.Status.Tasks[len(rj.Status.Tasks)-1]
 
 		// The latest task has a finish timestamp and it is waiting, which implies that
@@ -48,6 +48,24 @@ func TestGetPhase(t *testing.T) {
 			},
 			want: execution.JobStarting,
 		},
+		{
+			name: "Suspended",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Suspend: true,
+				},
+				Status: execution.JobStatus{
+					StartTime: &startTime,
+					Condition: execution.JobCondition{
+						Waiting: &execution.JobConditionWaiting{
+							CreatedAt: &createTime,
+							Reason:    "Suspended",
+						},
+					},
+				},
+			},
+			want: execution.JobSuspended,
+		},
 		{
 			name: "Pending",
 			rj: &execution.Job{
@@ -282,6 +300,20 @@ func TestGetPhase(t *testing.T) {
 			},
 			want: execution.JobAdmissionError,
 		},
+		{
+			name: "Expired",
+			rj: &execution.Job{
+				Status: execution.JobStatus{
+					Condition: execution.JobCondition{
+						Finished: &execution.JobConditionFinished{
+							FinishedAt: finishTime,
+							Result:     execution.JobResultExpired,
+						},
+					},
+				},
+			},
+			want: execution.JobExpired,
+		},
 		{
 			name: "Killing job in Waiting",
 			rj: &execution.Job{
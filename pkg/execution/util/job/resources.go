@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	"math"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	// costPrecision is the number of decimal places that estimated costs are
+	// rounded to.
+	costPrecision = 6
+
+	bytesPerGiB = 1 << 30
+)
+
+// UpdateTaskRefCosts returns a new copy of the Job with the estimated cost of
+// each finished TaskRef computed from its resource usage, using the given price
+// table. Costs of tasks which have not yet finished will not be estimated.
+func UpdateTaskRefCosts(rj *execution.Job, prices *configv1alpha1.ResourcePrices) *execution.Job {
+	newRj := rj.DeepCopy()
+	if prices == nil {
+		return newRj
+	}
+
+	for i, taskRef := range newRj.Status.Tasks {
+		if taskRef.FinishTimestamp.IsZero() || taskRef.RunningTimestamp.IsZero() {
+			continue
+		}
+		runningSeconds := taskRef.FinishTimestamp.Sub(taskRef.RunningTimestamp.Time).Seconds()
+		if usage := newRj.Status.Tasks[i].Status.ResourceUsage; usage != nil {
+			usage.EstimatedCost = EstimateCost(usage, runningSeconds, prices)
+		}
+		if deleted := newRj.Status.Tasks[i].DeletedStatus; deleted != nil && deleted.ResourceUsage != nil {
+			deleted.ResourceUsage.EstimatedCost = EstimateCost(deleted.ResourceUsage, runningSeconds, prices)
+		}
+	}
+
+	return newRj
+}
+
+// EstimateCost returns the estimated cost of a task as a decimal string, given
+// its resource usage and the number of seconds that it was running for. CPU is
+// charged using the total CPU time consumed, and memory is charged using the
+// peak memory usage over the entire running duration. Returns an empty string if
+// the cost cannot be estimated.
+func EstimateCost(
+	usage *execution.TaskResourceUsage, runningSeconds float64, prices *configv1alpha1.ResourcePrices,
+) string {
+	if usage == nil || prices == nil {
+		return ""
+	}
+
+	var cost float64
+	var estimated bool
+	if usage.CPUSeconds != nil && prices.CPUCoreHour != nil {
+		cost += usage.CPUSeconds.AsApproximateFloat64() / 3600 * prices.CPUCoreHour.AsApproximateFloat64()
+		estimated = true
+	}
+	if usage.PeakMemory != nil && prices.MemoryGiBHour != nil && runningSeconds > 0 {
+		gib := usage.PeakMemory.AsApproximateFloat64() / bytesPerGiB
+		cost += gib * runningSeconds / 3600 * prices.MemoryGiBHour.AsApproximateFloat64()
+		estimated = true
+	}
+	if !estimated {
+		return ""
+	}
+
+	return formatCost(cost)
+}
+
+// GetResourceUsage aggregates the resource usage of all TaskRefs of the Job.
+// CPU time and estimated costs are summed, while the peak memory is the maximum
+// across all tasks. Returns nil if no resource usage was recorded.
+func GetResourceUsage(rj *execution.Job) *execution.TaskResourceUsage {
+	var total *execution.TaskResourceUsage
+	var totalCost float64
+	var hasCost bool
+
+	for _, taskRef := range rj.Status.Tasks {
+		usage := taskRef.Status.ResourceUsage
+		if usage == nil {
+			continue
+		}
+		if total == nil {
+			total = &execution.TaskResourceUsage{}
+		}
+		if usage.CPUSeconds != nil {
+			if total.CPUSeconds == nil {
+				total.CPUSeconds = resource.NewMilliQuantity(0, resource.DecimalSI)
+			}
+			total.CPUSeconds.Add(*usage.CPUSeconds)
+		}
+		if usage.PeakMemory != nil {
+			if total.PeakMemory == nil || usage.PeakMemory.Cmp(*total.PeakMemory) > 0 {
+				peak := usage.PeakMemory.DeepCopy()
+				total.PeakMemory = &peak
+			}
+		}
+		if usage.EstimatedCost != "" {
+			if cost, err := strconv.ParseFloat(usage.EstimatedCost, 64); err == nil {
+				totalCost += cost
+				hasCost = true
+			}
+		}
+	}
+
+	if total != nil && hasCost {
+		total.EstimatedCost = formatCost(totalCost)
+	}
+
+	return total
+}
+
+func formatCost(cost float64) string {
+	scale := math.Pow(10, costPrecision)
+	return strconv.FormatFloat(math.Round(cost*scale)/scale, 'f', -1, 64)
+}
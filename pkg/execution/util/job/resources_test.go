@@ -0,0 +1,261 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+var (
+	fakePrices = &configv1alpha1.ResourcePrices{
+		CPUCoreHour:   quantityPtr("0.036"),
+		MemoryGiBHour: quantityPtr("0.004"),
+	}
+)
+
+func TestEstimateCost(t *testing.T) {
+	tests := []struct {
+		name           string
+		usage          *execution.TaskResourceUsage
+		runningSeconds float64
+		prices         *configv1alpha1.ResourcePrices
+		want           string
+	}{
+		{
+			name:   "no usage",
+			prices: fakePrices,
+		},
+		{
+			name: "no prices",
+			usage: &execution.TaskResourceUsage{
+				CPUSeconds: quantityPtr("3600"),
+			},
+		},
+		{
+			name: "cpu only",
+			usage: &execution.TaskResourceUsage{
+				CPUSeconds: quantityPtr("1800"),
+			},
+			runningSeconds: 3600,
+			prices:         fakePrices,
+			want:           "0.018",
+		},
+		{
+			name: "cpu and memory",
+			usage: &execution.TaskResourceUsage{
+				CPUSeconds: quantityPtr("1800"),
+				PeakMemory: quantityPtr("2Gi"),
+			},
+			runningSeconds: 1800,
+			prices:         fakePrices,
+			want:           "0.022",
+		},
+		{
+			name: "memory price only",
+			usage: &execution.TaskResourceUsage{
+				CPUSeconds: quantityPtr("1800"),
+				PeakMemory: quantityPtr("1Gi"),
+			},
+			runningSeconds: 3600,
+			prices: &configv1alpha1.ResourcePrices{
+				MemoryGiBHour: quantityPtr("0.004"),
+			},
+			want: "0.004",
+		},
+		{
+			name: "rounded to precision",
+			usage: &execution.TaskResourceUsage{
+				CPUSeconds: quantityPtr("1"),
+			},
+			prices: fakePrices,
+			want:   "0.00001",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobutil.EstimateCost(tt.usage, tt.runningSeconds, tt.prices); got != tt.want {
+				t.Errorf("EstimateCost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskRefCosts(t *testing.T) {
+	rj := &execution.Job{
+		Status: execution.JobStatus{
+			Tasks: []execution.TaskRef{
+				{
+					Name:             "job.1",
+					RunningTimestamp: testutils.Mkmtimep("2021-02-09T04:00:00Z"),
+					FinishTimestamp:  testutils.Mkmtimep("2021-02-09T04:30:00Z"),
+					Status: execution.TaskStatus{
+						ResourceUsage: &execution.TaskResourceUsage{
+							CPUSeconds: quantityPtr("1800"),
+							PeakMemory: quantityPtr("2Gi"),
+						},
+					},
+					DeletedStatus: &execution.TaskStatus{
+						ResourceUsage: &execution.TaskResourceUsage{
+							CPUSeconds: quantityPtr("1800"),
+							PeakMemory: quantityPtr("2Gi"),
+						},
+					},
+				},
+				{
+					Name:             "job.2",
+					RunningTimestamp: testutils.Mkmtimep("2021-02-09T04:30:00Z"),
+					Status: execution.TaskStatus{
+						ResourceUsage: &execution.TaskResourceUsage{
+							CPUSeconds: quantityPtr("1800"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	newRj := jobutil.UpdateTaskRefCosts(rj, fakePrices)
+	if got := newRj.Status.Tasks[0].Status.ResourceUsage.EstimatedCost; got != "0.022" {
+		t.Errorf("Status.EstimatedCost = %v, want %v", got, "0.022")
+	}
+	if got := newRj.Status.Tasks[0].DeletedStatus.ResourceUsage.EstimatedCost; got != "0.022" {
+		t.Errorf("DeletedStatus.EstimatedCost = %v, want %v", got, "0.022")
+	}
+	if got := newRj.Status.Tasks[1].Status.ResourceUsage.EstimatedCost; got != "" {
+		t.Errorf("EstimatedCost for running task = %v, want empty", got)
+	}
+	if rj.Status.Tasks[0].Status.ResourceUsage.EstimatedCost != "" {
+		t.Errorf("original Job was mutated")
+	}
+}
+
+func TestGetResourceUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		tasks          []execution.TaskRef
+		wantNil        bool
+		wantCPUSeconds string
+		wantPeakMemory string
+		wantCost       string
+	}{
+		{
+			name:    "no tasks",
+			wantNil: true,
+		},
+		{
+			name: "no resource usage",
+			tasks: []execution.TaskRef{
+				{Name: "job.1"},
+			},
+			wantNil: true,
+		},
+		{
+			name: "aggregate multiple tasks",
+			tasks: []execution.TaskRef{
+				{
+					Name: "job.1",
+					Status: execution.TaskStatus{
+						ResourceUsage: &execution.TaskResourceUsage{
+							CPUSeconds:    quantityPtr("1500m"),
+							PeakMemory:    quantityPtr("128Mi"),
+							EstimatedCost: "0.01",
+						},
+					},
+				},
+				{
+					Name: "job.2",
+					Status: execution.TaskStatus{
+						ResourceUsage: &execution.TaskResourceUsage{
+							CPUSeconds:    quantityPtr("2"),
+							PeakMemory:    quantityPtr("256Mi"),
+							EstimatedCost: "0.02",
+						},
+					},
+				},
+				{
+					Name: "job.3",
+				},
+			},
+			wantCPUSeconds: "3500m",
+			wantPeakMemory: "256Mi",
+			wantCost:       "0.03",
+		},
+		{
+			name: "no costs",
+			tasks: []execution.TaskRef{
+				{
+					Name: "job.1",
+					Status: execution.TaskStatus{
+						ResourceUsage: &execution.TaskResourceUsage{
+							PeakMemory: quantityPtr("1Gi"),
+						},
+					},
+				},
+			},
+			wantPeakMemory: "1Gi",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				Status: execution.JobStatus{
+					Tasks: tt.tasks,
+				},
+			}
+			got := jobutil.GetResourceUsage(rj)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("GetResourceUsage() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("GetResourceUsage() = nil, want non-nil")
+			}
+			if got := quantityString(got.CPUSeconds); got != tt.wantCPUSeconds {
+				t.Errorf("GetResourceUsage() CPUSeconds = %v, want %v", got, tt.wantCPUSeconds)
+			}
+			if got := quantityString(got.PeakMemory); got != tt.wantPeakMemory {
+				t.Errorf("GetResourceUsage() PeakMemory = %v, want %v", got, tt.wantPeakMemory)
+			}
+			if got.EstimatedCost != tt.wantCost {
+				t.Errorf("GetResourceUsage() EstimatedCost = %v, want %v", got.EstimatedCost, tt.wantCost)
+			}
+		})
+	}
+}
+
+func quantityPtr(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+	return &quantity
+}
+
+func quantityString(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+	return quantity.String()
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// HasSteps returns true if the Job specifies steps in its template.
+func HasSteps(rj *execution.Job) bool {
+	return rj.Spec.Template != nil && len(rj.Spec.Template.Steps) > 0
+}
+
+// GetStepStatuses computes the status of each step in the Job from its TaskRefs.
+// Returns nil if the Job does not specify any steps.
+func GetStepStatuses(rj *execution.Job) []execution.JobStepStatus {
+	if !HasSteps(rj) {
+		return nil
+	}
+
+	taskRefs := getStepTaskRefs(rj)
+	halted := isStepExecutionHalted(rj, taskRefs)
+	statuses := make([]execution.JobStepStatus, 0, len(rj.Spec.Template.Steps))
+	for _, step := range rj.Spec.Template.Steps {
+		status := execution.JobStepStatus{
+			Name:  step.Name,
+			Phase: execution.JobStepPending,
+		}
+		if taskRef, ok := taskRefs[step.Name]; ok {
+			status.TaskName = taskRef.Name
+			status.Phase = getStepPhaseFromTaskRef(taskRef)
+		} else if halted {
+			status.Phase = execution.JobStepSkipped
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// GetRunnableSteps returns the list of steps whose tasks are not yet created,
+// and whose dependencies have all succeeded. Returns an empty list if no more
+// steps should be started.
+func GetRunnableSteps(rj *execution.Job) []execution.JobStepSpec {
+	if !HasSteps(rj) {
+		return nil
+	}
+
+	// Do not start new steps while suspended.
+	if IsSuspended(rj) {
+		return nil
+	}
+
+	taskRefs := getStepTaskRefs(rj)
+	if isStepExecutionHalted(rj, taskRefs) {
+		return nil
+	}
+
+	runnable := make([]execution.JobStepSpec, 0, len(rj.Spec.Template.Steps))
+	for _, step := range rj.Spec.Template.Steps {
+		if _, ok := taskRefs[step.Name]; ok {
+			continue
+		}
+		satisfied := true
+		for _, dependency := range step.DependsOn {
+			taskRef, ok := taskRefs[dependency]
+			if !ok || getStepPhaseFromTaskRef(taskRef) != execution.JobStepSucceeded {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			runnable = append(runnable, step)
+		}
+	}
+
+	return runnable
+}
+
+// getStepsCondition computes the JobCondition for a Job with steps, assuming
+// that at least one task has already been created.
+func getStepsCondition(rj *execution.Job) execution.JobCondition {
+	state := execution.JobCondition{}
+	taskRefs := rj.Status.Tasks
+	createdAt := taskRefs[0].CreationTimestamp
+
+	var startedAt, finishedAt *metav1.Time
+	var pendingTask *execution.TaskRef
+	var running bool
+	for i, taskRef := range taskRefs {
+		if !taskRef.RunningTimestamp.IsZero() &&
+			(startedAt == nil || taskRef.RunningTimestamp.Before(startedAt)) {
+			startedAt = taskRef.RunningTimestamp
+		}
+		finishedAt = ktime.TimeMax(finishedAt, taskRef.FinishTimestamp)
+		if taskRef.FinishTimestamp.IsZero() {
+			if !taskRef.RunningTimestamp.IsZero() {
+				running = true
+			} else if pendingTask == nil {
+				pendingTask = &taskRefs[i]
+			}
+		}
+	}
+
+	statuses := GetStepStatuses(rj)
+	allTerminal := true
+	for _, status := range statuses {
+		if !status.Phase.IsTerminal() {
+			allTerminal = false
+			break
+		}
+	}
+
+	// All steps are finished, or will never be started.
+	if allTerminal {
+		finished := &execution.JobConditionFinished{
+			CreatedAt: &createdAt,
+			StartedAt: startedAt,
+			Result:    execution.JobResultSuccess,
+		}
+		if finishedAt != nil {
+			finished.FinishedAt = *finishedAt
+		}
+
+		stepTaskRefs := getStepTaskRefs(rj)
+		for _, status := range statuses {
+			if status.Phase == execution.JobStepSucceeded {
+				continue
+			}
+			if status.Phase == execution.JobStepSkipped {
+				// Steps were skipped without any failure, which means the Job was killed.
+				finished.Result = execution.JobResultKilled
+				if ts := rj.Spec.KillTimestamp; !ts.IsZero() && finished.FinishedAt.Before(ts) {
+					finished.FinishedAt = *ts
+				}
+				continue
+			}
+
+			// Use the first failed step to determine the result.
+			taskRef := stepTaskRefs[status.Name]
+			finished.Result = execution.JobResultTaskFailed
+			if result := taskRef.Status.Result; result != nil {
+				finished.Result = *result
+			}
+			finished.Reason = taskRef.Status.Reason
+			finished.Message = fmt.Sprintf("Step %v failed", status.Name)
+			if taskRef.Status.Message != "" {
+				finished.Message = fmt.Sprintf("Step %v failed: %v", status.Name, taskRef.Status.Message)
+			}
+			break
+		}
+
+		state.Finished = finished
+		return state
+	}
+
+	// At least one step is running.
+	if running {
+		state.Running = &execution.JobConditionRunning{
+			CreatedAt: createdAt,
+			StartedAt: *startedAt,
+		}
+		return state
+	}
+
+	// Otherwise, the Job is waiting for steps to be started.
+	state.Waiting = &execution.JobConditionWaiting{
+		CreatedAt: &createdAt,
+	}
+	if pendingTask != nil {
+		state.Waiting.Reason = pendingTask.Status.Reason
+		state.Waiting.Message = pendingTask.Status.Message
+	} else if IsSuspended(rj) {
+		state.Waiting.Reason = "Suspended"
+		state.Waiting.Message = "Job is suspended and will not start new steps until it is resumed"
+	}
+	return state
+}
+
+// GetStepRetryIndex returns the retry index to use for the next task created
+// for the given step. Steps are usually created once, but a step whose task was
+// killed by suspending the Job will be started again once it is resumed.
+func GetStepRetryIndex(rj *execution.Job, step string) int64 {
+	index := int64(1)
+	for _, taskRef := range rj.Status.Tasks {
+		if taskRef.Step == step {
+			index++
+		}
+	}
+	return index
+}
+
+// getStepTaskRefs returns a map of step name to TaskRef. TaskRefs that were
+// killed due to suspension are excluded, since the step has to be started again.
+func getStepTaskRefs(rj *execution.Job) map[string]execution.TaskRef {
+	taskRefs := make(map[string]execution.TaskRef, len(rj.Status.Tasks))
+	for _, taskRef := range rj.Status.Tasks {
+		if taskRef.Step != "" && !taskRef.Suspended {
+			taskRefs[taskRef.Step] = taskRef
+		}
+	}
+	return taskRefs
+}
+
+// isStepExecutionHalted returns true if no further steps should be started.
+func isStepExecutionHalted(rj *execution.Job, taskRefs map[string]execution.TaskRef) bool {
+	if _, ok := GetAdmissionErrorMessage(rj); ok {
+		return true
+	}
+	if rj.Spec.KillTimestamp != nil && !ktime.Now().Before(rj.Spec.KillTimestamp) {
+		return true
+	}
+	for _, taskRef := range taskRefs {
+		if getStepPhaseFromTaskRef(taskRef) == execution.JobStepFailed {
+			return true
+		}
+	}
+	return false
+}
+
+func getStepPhaseFromTaskRef(taskRef execution.TaskRef) execution.JobStepPhase {
+	if !taskRef.FinishTimestamp.IsZero() {
+		if result := taskRef.Status.Result; result != nil && *result == execution.JobResultSuccess {
+			return execution.JobStepSucceeded
+		}
+		return execution.JobStepFailed
+	}
+	if !taskRef.RunningTimestamp.IsZero() {
+		return execution.JobStepRunning
+	}
+	return execution.JobStepPending
+}
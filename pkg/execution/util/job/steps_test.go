@@ -0,0 +1,273 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+var (
+	stepsTemplate = &v1alpha1.JobTemplateSpec{
+		Steps: []v1alpha1.JobStepSpec{
+			{Name: "prepare"},
+			{Name: "lint"},
+			{Name: "publish", DependsOn: []string{"prepare", "lint"}},
+		},
+	}
+	futureKillTime = metav1.NewTime(stdKillTime.Add(time.Hour))
+)
+
+func TestGetStepStatuses(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(stdKillTime)
+
+	tests := []struct {
+		name          string
+		status        v1alpha1.JobStatus
+		killTimestamp *metav1.Time
+		want          []v1alpha1.JobStepStatus
+	}{
+		{
+			name: "no tasks",
+			want: []v1alpha1.JobStepStatus{
+				{Name: "prepare", Phase: v1alpha1.JobStepPending},
+				{Name: "lint", Phase: v1alpha1.JobStepPending},
+				{Name: "publish", Phase: v1alpha1.JobStepPending},
+			},
+		},
+		{
+			name: "one running, one succeeded",
+			status: v1alpha1.JobStatus{
+				Tasks: []v1alpha1.TaskRef{
+					stepTaskRef("prepare", v1alpha1.JobResultSuccess),
+					{Name: "job.lint", Step: "lint", CreationTimestamp: createTime, RunningTimestamp: &startTime},
+				},
+			},
+			want: []v1alpha1.JobStepStatus{
+				{Name: "prepare", Phase: v1alpha1.JobStepSucceeded, TaskName: "job.prepare"},
+				{Name: "lint", Phase: v1alpha1.JobStepRunning, TaskName: "job.lint"},
+				{Name: "publish", Phase: v1alpha1.JobStepPending},
+			},
+		},
+		{
+			name: "step failed",
+			status: v1alpha1.JobStatus{
+				Tasks: []v1alpha1.TaskRef{
+					stepTaskRef("prepare", v1alpha1.JobResultSuccess),
+					stepTaskRef("lint", v1alpha1.JobResultTaskFailed),
+				},
+			},
+			want: []v1alpha1.JobStepStatus{
+				{Name: "prepare", Phase: v1alpha1.JobStepSucceeded, TaskName: "job.prepare"},
+				{Name: "lint", Phase: v1alpha1.JobStepFailed, TaskName: "job.lint"},
+				{Name: "publish", Phase: v1alpha1.JobStepSkipped},
+			},
+		},
+		{
+			name:          "killed",
+			killTimestamp: &killTime,
+			want: []v1alpha1.JobStepStatus{
+				{Name: "prepare", Phase: v1alpha1.JobStepSkipped},
+				{Name: "lint", Phase: v1alpha1.JobStepSkipped},
+				{Name: "publish", Phase: v1alpha1.JobStepSkipped},
+			},
+		},
+		{
+			name:          "kill timestamp in the future",
+			killTimestamp: &futureKillTime,
+			want: []v1alpha1.JobStepStatus{
+				{Name: "prepare", Phase: v1alpha1.JobStepPending},
+				{Name: "lint", Phase: v1alpha1.JobStepPending},
+				{Name: "publish", Phase: v1alpha1.JobStepPending},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					Template: stepsTemplate,
+				},
+				Status: tt.status,
+			}
+			rj.Spec.KillTimestamp = tt.killTimestamp
+			if diff := cmp.Diff(tt.want, job.GetStepStatuses(rj)); diff != "" {
+				t.Errorf("GetStepStatuses() not equal:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestGetRunnableSteps(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(stdKillTime)
+
+	tests := []struct {
+		name          string
+		status        v1alpha1.JobStatus
+		killTimestamp *metav1.Time
+		want          []string
+	}{
+		{
+			name: "no tasks",
+			want: []string{"prepare", "lint"},
+		},
+		{
+			name: "partial dependencies succeeded",
+			status: v1alpha1.JobStatus{
+				Tasks: []v1alpha1.TaskRef{
+					stepTaskRef("prepare", v1alpha1.JobResultSuccess),
+				},
+			},
+			want: []string{"lint"},
+		},
+		{
+			name: "all dependencies succeeded",
+			status: v1alpha1.JobStatus{
+				Tasks: []v1alpha1.TaskRef{
+					stepTaskRef("prepare", v1alpha1.JobResultSuccess),
+					stepTaskRef("lint", v1alpha1.JobResultSuccess),
+				},
+			},
+			want: []string{"publish"},
+		},
+		{
+			name: "dependency failed",
+			status: v1alpha1.JobStatus{
+				Tasks: []v1alpha1.TaskRef{
+					stepTaskRef("prepare", v1alpha1.JobResultTaskFailed),
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "step killed due to suspension",
+			status: v1alpha1.JobStatus{
+				Tasks: []v1alpha1.TaskRef{
+					suspendedStepTaskRef("prepare"),
+					stepTaskRef("lint", v1alpha1.JobResultSuccess),
+				},
+			},
+			want: []string{"prepare"},
+		},
+		{
+			name:          "killed",
+			killTimestamp: &killTime,
+			want:          []string{},
+		},
+		{
+			name:          "kill timestamp in the future",
+			killTimestamp: &futureKillTime,
+			want:          []string{"prepare", "lint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					Template:      stepsTemplate,
+					KillTimestamp: tt.killTimestamp,
+				},
+				Status: tt.status,
+			}
+			got := make([]string, 0)
+			for _, step := range job.GetRunnableSteps(rj) {
+				got = append(got, step.Name)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetRunnableSteps() not equal:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestGetStepRetryIndex(t *testing.T) {
+	rj := &v1alpha1.Job{
+		Spec: v1alpha1.JobSpec{
+			Template: stepsTemplate,
+		},
+		Status: v1alpha1.JobStatus{
+			Tasks: []v1alpha1.TaskRef{
+				suspendedStepTaskRef("prepare"),
+				stepTaskRef("lint", v1alpha1.JobResultSuccess),
+			},
+		},
+	}
+	for step, want := range map[string]int64{
+		"prepare": 2,
+		"lint":    2,
+		"publish": 1,
+	} {
+		if got := job.GetStepRetryIndex(rj, step); got != want {
+			t.Errorf("GetStepRetryIndex(%v) = %v, want %v", step, got, want)
+		}
+	}
+}
+
+func TestGetCondition_Steps(t *testing.T) {
+	rj := &v1alpha1.Job{
+		Spec: v1alpha1.JobSpec{
+			Template: stepsTemplate,
+		},
+		Status: v1alpha1.JobStatus{
+			StartTime: &startTime,
+			Tasks: []v1alpha1.TaskRef{
+				stepTaskRef("prepare", v1alpha1.JobResultSuccess),
+				stepTaskRef("lint", v1alpha1.JobResultTaskFailed),
+			},
+		},
+	}
+	want := v1alpha1.JobCondition{
+		Finished: &v1alpha1.JobConditionFinished{
+			CreatedAt:  &createTime,
+			StartedAt:  &startTime,
+			FinishedAt: finishTime,
+			Result:     v1alpha1.JobResultTaskFailed,
+			Message:    "Step lint failed",
+		},
+	}
+	if diff := cmp.Diff(want, job.GetCondition(rj)); diff != "" {
+		t.Errorf("GetCondition() not equal:\n%v", diff)
+	}
+}
+
+func stepTaskRef(step string, result v1alpha1.JobResult) v1alpha1.TaskRef {
+	return v1alpha1.TaskRef{
+		Name:              "job." + step,
+		Step:              step,
+		CreationTimestamp: createTime,
+		RunningTimestamp:  &startTime,
+		FinishTimestamp:   &finishTime,
+		Status: v1alpha1.TaskStatus{
+			State:  v1alpha1.TaskSuccess,
+			Result: job.GetResultPtr(result),
+		},
+	}
+}
+
+func suspendedStepTaskRef(step string) v1alpha1.TaskRef {
+	taskRef := stepTaskRef(step, v1alpha1.JobResultKilled)
+	taskRef.Suspended = true
+	return taskRef
+}
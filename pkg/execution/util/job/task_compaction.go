@@ -0,0 +1,64 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job
+
+import (
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// CompactTaskRefs returns a new copy of the Job with all but the latest
+// maxDetailed finished TaskRefs compacted. Compacted TaskRefs are retained in
+// the Job's status, since they are needed to count the Job's attempts and to
+// guard against recreating tasks, but their container states, messages and
+// progress are dropped. Unfinished TaskRefs are never compacted. Does nothing if
+// maxDetailed is not positive.
+func CompactTaskRefs(rj *execution.Job, maxDetailed int64) *execution.Job {
+	newRj := rj.DeepCopy()
+	if maxDetailed <= 0 {
+		return newRj
+	}
+
+	// TaskRefs are sorted by creation, so we iterate from the latest TaskRef.
+	var finished int64
+	for i := len(newRj.Status.Tasks) - 1; i >= 0; i-- {
+		taskRef := &newRj.Status.Tasks[i]
+		if taskRef.FinishTimestamp.IsZero() {
+			continue
+		}
+		if finished++; finished > maxDetailed {
+			compactTaskRef(taskRef)
+		}
+	}
+
+	return newRj
+}
+
+// compactTaskRef drops the details of a TaskRef in place. Outputs and resource
+// usage are retained, since they are aggregated into the Job's status.
+func compactTaskRef(taskRef *execution.TaskRef) {
+	taskRef.Compacted = true
+	taskRef.ContainerStates = nil
+	compactTaskStatus(&taskRef.Status)
+	if taskRef.DeletedStatus != nil {
+		compactTaskStatus(taskRef.DeletedStatus)
+	}
+}
+
+func compactTaskStatus(status *execution.TaskStatus) {
+	status.Message = ""
+	status.Progress = nil
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package job_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestCompactTaskRefs(t *testing.T) {
+	newTaskRef := func(name string, finished bool) execution.TaskRef {
+		status := execution.TaskStatus{
+			State:   execution.TaskRunning,
+			Message: "task message",
+			Progress: &execution.TaskProgress{
+				Message: "progress message",
+			},
+			Outputs: map[string]string{"name": name},
+		}
+		taskRef := execution.TaskRef{
+			Name:              name,
+			CreationTimestamp: testutils.Mkmtime("2022-03-01T10:00:00Z"),
+			Status:            status,
+			ContainerStates: []execution.TaskContainerState{
+				{
+					ExitCode: 1,
+					Message:  "container message",
+				},
+			},
+		}
+		if finished {
+			taskRef.FinishTimestamp = testutils.Mkmtimep("2022-03-01T10:05:00Z")
+			taskRef.Status.State = execution.TaskFailed
+			taskRef.DeletedStatus = taskRef.Status.DeepCopy()
+		}
+		return taskRef
+	}
+	compacted := func(taskRef execution.TaskRef) execution.TaskRef {
+		newTaskRef := taskRef.DeepCopy()
+		newTaskRef.Compacted = true
+		newTaskRef.ContainerStates = nil
+		newTaskRef.Status.Message = ""
+		newTaskRef.Status.Progress = nil
+		if newTaskRef.DeletedStatus != nil {
+			newTaskRef.DeletedStatus.Message = ""
+			newTaskRef.DeletedStatus.Progress = nil
+		}
+		return *newTaskRef
+	}
+
+	task1 := newTaskRef("task1", true)
+	task2 := newTaskRef("task2", true)
+	task3 := newTaskRef("task3", false)
+	task4 := newTaskRef("task4", true)
+
+	tests := []struct {
+		name        string
+		tasks       []execution.TaskRef
+		maxDetailed int64
+		want        []execution.TaskRef
+	}{
+		{
+			name:        "disabled",
+			tasks:       []execution.TaskRef{task1, task2, task3, task4},
+			maxDetailed: 0,
+			want:        []execution.TaskRef{task1, task2, task3, task4},
+		},
+		{
+			name:        "no tasks",
+			maxDetailed: 1,
+		},
+		{
+			name:        "not exceeded",
+			tasks:       []execution.TaskRef{task1, task2, task3, task4},
+			maxDetailed: 3,
+			want:        []execution.TaskRef{task1, task2, task3, task4},
+		},
+		{
+			name:        "compact older finished tasks",
+			tasks:       []execution.TaskRef{task1, task2, task3, task4},
+			maxDetailed: 1,
+			want:        []execution.TaskRef{compacted(task1), compacted(task2), task3, task4},
+		},
+		{
+			name:        "do not compact unfinished tasks",
+			tasks:       []execution.TaskRef{task3, task1, task2},
+			maxDetailed: 1,
+			want:        []execution.TaskRef{task3, compacted(task1), task2},
+		},
+		{
+			name:        "already compacted",
+			tasks:       []execution.TaskRef{compacted(task1), task2, task4},
+			maxDetailed: 1,
+			want:        []execution.TaskRef{compacted(task1), compacted(task2), task4},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "job"},
+				Status: execution.JobStatus{
+					Tasks: tt.tasks,
+				},
+			}
+			original := rj.DeepCopy()
+			got := jobutil.CompactTaskRefs(rj, tt.maxDetailed)
+			if !cmp.Equal(tt.want, got.Status.Tasks) {
+				t.Errorf("CompactTaskRefs() not equal\ndiff = %v", cmp.Diff(tt.want, got.Status.Tasks))
+			}
+			if !cmp.Equal(original, rj) {
+				t.Errorf("CompactTaskRefs() mutated input\ndiff = %v", cmp.Diff(original, rj))
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ func GetTaskRef(existing *execution.TaskRef, task tasks.Task) execution.TaskRef
 	if existing != nil {
 		// Don't clear fields which are set rather than derived.
 		newTaskRef.DeletedStatus = existing.DeletedStatus.DeepCopy()
+		newTaskRef.Suspended = existing.Suspended
 
 		// Don't clear running or finish timestamps, which could be lost between task updates.
 		// NOTE(irvinlim): Our assumption is that once we observe a FinishTimestamp for a task,
@@ -46,6 +47,19 @@ func GetTaskRef(existing *execution.TaskRef, task tasks.Task) execution.TaskRef
 		}
 	}
 
+	// Track the last time that the progress changed, which is used for stall detection.
+	if progress := newTaskRef.Status.Progress; progress != nil {
+		var existingProgress *execution.TaskProgress
+		if existing != nil {
+			existingProgress = existing.Status.Progress
+		}
+		if existingProgress != nil && isProgressEqual(existingProgress, progress) {
+			progress.LastUpdateTime = existingProgress.LastUpdateTime.DeepCopy()
+		} else {
+			progress.LastUpdateTime = ktime.Now()
+		}
+	}
+
 	// TODO(irvinlim): Move this handling into PodTask
 	// if taskRef.CannotForceDelete {
 	// 	// Overwrite the message if the task is set to forbid force deletion.
@@ -62,6 +76,17 @@ func GetTaskRef(existing *execution.TaskRef, task tasks.Task) execution.TaskRef
 	return *newTaskRef
 }
 
+// isProgressEqual returns true if both TaskProgress report the same progress.
+func isProgressEqual(a, b *execution.TaskProgress) bool {
+	if a.Message != b.Message {
+		return false
+	}
+	if a.Percent == nil || b.Percent == nil {
+		return a.Percent == b.Percent
+	}
+	return *a.Percent == *b.Percent
+}
+
 // UpdateJobTaskRefs will update the TaskRefs in the Job's status given a list of tasks.
 // Will return a new copy of the Job whose fields are updated.
 func UpdateJobTaskRefs(rj *execution.Job, tasks []tasks.Task) *execution.Job {
@@ -93,28 +118,36 @@ func UpdateTaskRefDeletedStatusIfNotSet(
 // If any task is no longer present, it will transition to TaskDeletedFinalStateUnknown.
 func GenerateTaskRefs(existing []execution.TaskRef, tasks []tasks.Task) []execution.TaskRef {
 	newRefs := make([]execution.TaskRef, 0, len(tasks)+len(existing))
-	newRefNames := make(map[string]struct{}, len(tasks))
-	existingRefs := make(map[string]execution.TaskRef, len(existing))
+	newRefKeys := make(map[taskRefKey]struct{}, len(tasks))
+	existingRefs := make(map[taskRefKey]execution.TaskRef, len(existing))
 
 	for _, ref := range existing {
-		existingRefs[ref.Name] = ref
+		existingRefs[getTaskRefKey(ref)] = ref
 	}
 
 	// Convert tasks to TaskRefs
 	for _, task := range tasks {
 		var existingRef *execution.TaskRef
-		if ref, ok := existingRefs[task.GetName()]; ok {
+		key := getTaskKey(task)
+		ref, ok := existingRefs[key]
+		if !ok {
+			// TaskRefs may not have been populated with their retry index previously.
+			key = taskRefKey{name: task.GetName()}
+			ref, ok = existingRefs[key]
+		}
+		if ok {
 			existingRef = &ref
 		}
-		ref := GetTaskRef(existingRef, task)
-		newRefNames[task.GetName()] = struct{}{}
-		newRefs = append(newRefs, ref)
+		newRef := GetTaskRef(existingRef, task)
+		newRefKeys[key] = struct{}{}
+		newRefKeys[getTaskRefKey(newRef)] = struct{}{}
+		newRefs = append(newRefs, newRef)
 	}
 
 	// Find TaskRefs that were existing before but no longer present in the current list.
 	// These are considered to be lost.
 	for _, existingRef := range existing {
-		if _, ok := newRefNames[existingRef.Name]; !ok {
+		if _, ok := newRefKeys[getTaskRefKey(existingRef)]; !ok {
 			newRef := existingRef.DeepCopy()
 
 			// Use current time as finish time if not set.
@@ -126,6 +159,12 @@ func GenerateTaskRefs(existing []execution.TaskRef, tasks []tasks.Task) []execut
 			// Otherwise, use lost state.
 			if existingRef.DeletedStatus != nil {
 				newRef.Status = *existingRef.DeletedStatus
+
+				// Retain the location of archived logs, which may not have been known when
+				// DeletedStatus was set.
+				if newRef.Status.LogArchive == nil {
+					newRef.Status.LogArchive = existingRef.Status.LogArchive
+				}
 			} else {
 				newRef.Status.State = execution.TaskDeletedFinalStateUnknown
 				if newRef.Status.Result == nil {
@@ -154,12 +193,58 @@ func SortTaskRefs(taskRefs []execution.TaskRef) {
 	})
 }
 
-// FindTaskRef returns the Task that matches the given Task's name.
+// FindTaskRef returns the TaskRef that matches the given Task.
 func FindTaskRef(rj *execution.Job, task tasks.Task) *execution.TaskRef {
 	for _, taskRef := range rj.Status.Tasks {
-		if taskRef.Name == task.GetName() {
+		if IsTaskRefForTask(taskRef, task) {
 			return &taskRef
 		}
 	}
 	return nil
 }
+
+// IsTaskRefForTask returns true if the TaskRef refers to the given Task. Tasks
+// with the same name are further distinguished by their retry index, if known.
+func IsTaskRefForTask(taskRef execution.TaskRef, task tasks.Task) bool {
+	if taskRef.Name != task.GetName() {
+		return false
+	}
+	if index, ok := task.GetRetryIndex(); ok && taskRef.RetryIndex > 0 {
+		return taskRef.RetryIndex == index
+	}
+	return true
+}
+
+// taskRefKey uniquely identifies a task of a Job.
+type taskRefKey struct {
+	name       string
+	retryIndex int64
+}
+
+func getTaskRefKey(taskRef execution.TaskRef) taskRefKey {
+	return taskRefKey{name: taskRef.Name, retryIndex: taskRef.RetryIndex}
+}
+
+func getTaskKey(task tasks.Task) taskRefKey {
+	key := taskRefKey{name: task.GetName()}
+	if index, ok := task.GetRetryIndex(); ok {
+		key.retryIndex = index
+	}
+	return key
+}
+
+// GetOutputs aggregates the outputs of all TaskRefs of the Job. TaskRefs are
+// ordered by creation, so outputs of later tasks take precedence. Returns nil if
+// no outputs were emitted.
+func GetOutputs(rj *execution.Job) map[string]string {
+	var outputs map[string]string
+	for _, taskRef := range rj.Status.Tasks {
+		for key, value := range taskRef.Status.Outputs {
+			if outputs == nil {
+				outputs = make(map[string]string)
+			}
+			outputs[key] = value
+		}
+	}
+	return outputs
+}
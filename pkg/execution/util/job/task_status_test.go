@@ -21,13 +21,20 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
 
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/tasks"
 	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
 )
 
 func TestGenerateTaskRefs(t *testing.T) {
+	timeNow := metav1.Now().Rfc3339Copy()
+	ktime.Clock = clock.NewFakeClock(timeNow.Time)
+
 	tests := []struct {
 		name     string
 		existing []execution.TaskRef
@@ -230,6 +237,143 @@ func TestGenerateTaskRefs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "set progress update time for new progress",
+			existing: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+				},
+			},
+			tasks: []tasks.Task{
+				&stubTask{
+					taskRef: execution.TaskRef{
+						Name:              "task1",
+						CreationTimestamp: createTime,
+						RunningTimestamp:  &startTime,
+						Status: execution.TaskStatus{
+							Progress: &execution.TaskProgress{Percent: pointer.Int32(10)},
+						},
+					},
+				},
+			},
+			want: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+					Status: execution.TaskStatus{
+						Progress: &execution.TaskProgress{Percent: pointer.Int32(10), LastUpdateTime: &timeNow},
+					},
+				},
+			},
+		},
+		{
+			name: "retain progress update time if progress is unchanged",
+			existing: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+					Status: execution.TaskStatus{
+						Progress: &execution.TaskProgress{Percent: pointer.Int32(10), LastUpdateTime: &startTime},
+					},
+				},
+			},
+			tasks: []tasks.Task{
+				&stubTask{
+					taskRef: execution.TaskRef{
+						Name:              "task1",
+						CreationTimestamp: createTime,
+						RunningTimestamp:  &startTime,
+						Status: execution.TaskStatus{
+							Progress: &execution.TaskProgress{Percent: pointer.Int32(10)},
+						},
+					},
+				},
+			},
+			want: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+					Status: execution.TaskStatus{
+						Progress: &execution.TaskProgress{Percent: pointer.Int32(10), LastUpdateTime: &startTime},
+					},
+				},
+			},
+		},
+		{
+			name: "update progress update time if progress is changed",
+			existing: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+					Status: execution.TaskStatus{
+						Progress: &execution.TaskProgress{Percent: pointer.Int32(10), LastUpdateTime: &startTime},
+					},
+				},
+			},
+			tasks: []tasks.Task{
+				&stubTask{
+					taskRef: execution.TaskRef{
+						Name:              "task1",
+						CreationTimestamp: createTime,
+						RunningTimestamp:  &startTime,
+						Status: execution.TaskStatus{
+							Progress: &execution.TaskProgress{Percent: pointer.Int32(20)},
+						},
+					},
+				},
+			},
+			want: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+					Status: execution.TaskStatus{
+						Progress: &execution.TaskProgress{Percent: pointer.Int32(20), LastUpdateTime: &timeNow},
+					},
+				},
+			},
+		},
+		{
+			name: "retain log archive of deleted task",
+			existing: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+					Status: execution.TaskStatus{
+						State:      execution.TaskRunning,
+						LogArchive: &execution.TaskLogArchive{Key: "task1.log"},
+					},
+					DeletedStatus: &execution.TaskStatus{
+						State:  execution.TaskKilled,
+						Result: jobutil.GetResultPtr(execution.JobResultKilled),
+					},
+				},
+			},
+			want: []execution.TaskRef{
+				{
+					Name:              "task1",
+					CreationTimestamp: createTime,
+					RunningTimestamp:  &startTime,
+					FinishTimestamp:   &timeNow,
+					Status: execution.TaskStatus{
+						State:      execution.TaskKilled,
+						Result:     jobutil.GetResultPtr(execution.JobResultKilled),
+						LogArchive: &execution.TaskLogArchive{Key: "task1.log"},
+					},
+					DeletedStatus: &execution.TaskStatus{
+						State:  execution.TaskKilled,
+						Result: jobutil.GetResultPtr(execution.JobResultKilled),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -352,3 +496,51 @@ func TestUpdateTaskRefDeletedStatusIfNotSet(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOutputs(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks []execution.TaskRef
+		want  map[string]string
+	}{
+		{
+			name: "no tasks",
+		},
+		{
+			name: "no outputs",
+			tasks: []execution.TaskRef{
+				{Name: "task1"},
+			},
+		},
+		{
+			name: "later tasks take precedence",
+			tasks: []execution.TaskRef{
+				{
+					Name: "task1",
+					Status: execution.TaskStatus{
+						Outputs: map[string]string{"attempt": "1", "first": "true"},
+					},
+				},
+				{
+					Name: "task2",
+					Status: execution.TaskStatus{
+						Outputs: map[string]string{"attempt": "2"},
+					},
+				},
+			},
+			want: map[string]string{"attempt": "2", "first": "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				Status: execution.JobStatus{
+					Tasks: tt.tasks,
+				},
+			}
+			if diff := cmp.Diff(tt.want, jobutil.GetOutputs(rj)); diff != "" {
+				t.Errorf("GetOutputs() not equal:\n%v", diff)
+			}
+		})
+	}
+}
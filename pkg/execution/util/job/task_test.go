@@ -51,8 +51,10 @@ type stubTask struct {
 	taskRef                        v1alpha1.TaskRef
 	killTimestamp                  *metav1.Time
 	deletionTimestamp              *metav1.Time
+	nodeLostTimestamp              *metav1.Time
 	retryIndex                     int64
 	killedFromPendingTimeoutMarker bool
+	killedFromRunningTimeoutMarker bool
 	killable                       bool
 }
 
@@ -73,6 +75,15 @@ func (t *stubTask) SetKilledFromPendingTimeoutMarker(ctx context.Context) error
 	return nil
 }
 
+func (t *stubTask) GetKilledFromRunningTimeoutMarker() bool {
+	return t.killedFromRunningTimeoutMarker
+}
+
+func (t *stubTask) SetKilledFromRunningTimeoutMarker(ctx context.Context) error {
+	t.killedFromRunningTimeoutMarker = true
+	return nil
+}
+
 func (t *stubTask) GetKind() string {
 	return "Stub"
 }
@@ -81,6 +92,14 @@ func (t *stubTask) GetRetryIndex() (int64, bool) {
 	return t.retryIndex, true
 }
 
+func (t *stubTask) GetStepName() (string, bool) {
+	return t.taskRef.Step, t.taskRef.Step != ""
+}
+
+func (t *stubTask) IsControlledBy(_ *v1alpha1.Job) bool {
+	return true
+}
+
 func (t *stubTask) RequiresKillWithDeletion() bool {
 	return t.killable
 }
@@ -89,6 +108,10 @@ func (t *stubTask) GetDeletionTimestamp() *metav1.Time {
 	return t.deletionTimestamp
 }
 
+func (t *stubTask) GetNodeLostTimestamp() *metav1.Time {
+	return t.nodeLostTimestamp
+}
+
 func (t *stubTask) GetKillTimestamp() *metav1.Time {
 	return t.killTimestamp
 }
@@ -24,6 +24,20 @@ import (
 	jobtasks "github.com/furiko-io/furiko/pkg/execution/tasks"
 )
 
+const (
+	// ReasonInitFailed is the reason used in the TaskStatus of tasks that failed
+	// due to an init container exiting with a non-zero code.
+	ReasonInitFailed = "InitFailed"
+
+	// ReasonNodeLost is the reason used in the TaskStatus of tasks that were
+	// replaced after their node was lost.
+	ReasonNodeLost = "NodeLost"
+
+	// ReasonEvicted is the reason used in the TaskStatus of tasks that were
+	// evicted, such as due to preemption or node pressure.
+	ReasonEvicted = "Evicted"
+)
+
 // ContainsActiveTask tests a list of tasks if there are any active tasks. An active
 // task is one that is not finished, and is not waiting to be deleted.
 func ContainsActiveTask(tasks []jobtasks.Task) bool {
@@ -48,14 +62,77 @@ func IsTaskFinished(task jobtasks.Task) bool {
 }
 
 // GetMaxAllowedTasks returns the maximum number of allowed tasks that a Job can have.
+// Tasks that were killed due to the Job being suspended are not counted towards
+// the maximum attempts, and neither are tasks that were replaced after their
+// node was lost. If MaxInitFailureRetries is specified, tasks that
+// failed due to init container failures are also not counted towards the
+// maximum attempts, up to MaxInitFailureRetries. Likewise for tasks that were
+// evicted, up to MaxEvictionRetries.
 func GetMaxAllowedTasks(rj *execution.Job) int64 {
-	var maxAttempts int64 = 1
+	var maxAttempts, maxInitFailureRetries int64 = 1, 0
 	if template := rj.Spec.Template; template != nil {
 		if rj.Spec.Template.MaxAttempts != nil {
 			maxAttempts = int64(*rj.Spec.Template.MaxAttempts)
 		}
+		if rj.Spec.Template.MaxInitFailureRetries != nil {
+			maxInitFailureRetries = int64(*rj.Spec.Template.MaxInitFailureRetries)
+		}
+	}
+	var initFailures int64
+	for _, taskRef := range rj.Status.Tasks {
+		if taskRef.Suspended || IsTaskRefNodeLost(taskRef) {
+			maxAttempts++
+		} else if IsTaskRefInitFailed(taskRef) {
+			initFailures++
+		}
+	}
+	if initFailures > maxInitFailureRetries {
+		initFailures = maxInitFailureRetries
+	}
+	evictions := countEvictedTaskRefs(rj)
+	if maxEvictionRetries := getMaxEvictionRetries(rj); evictions > maxEvictionRetries {
+		evictions = maxEvictionRetries
+	}
+	return maxAttempts + initFailures + evictions
+}
+
+// IsTaskRefEvicted returns true if the TaskRef is finished and had failed due to
+// being evicted.
+func IsTaskRefEvicted(taskRef execution.TaskRef) bool {
+	return !taskRef.FinishTimestamp.IsZero() && taskRef.Status.Reason == ReasonEvicted
+}
+
+func countEvictedTaskRefs(rj *execution.Job) int64 {
+	var evictions int64
+	for _, taskRef := range rj.Status.Tasks {
+		if !taskRef.Suspended && IsTaskRefEvicted(taskRef) {
+			evictions++
+		}
 	}
-	return maxAttempts
+	return evictions
+}
+
+func getMaxEvictionRetries(rj *execution.Job) int64 {
+	if template := rj.Spec.Template; template != nil && template.MaxEvictionRetries != nil {
+		return int64(*template.MaxEvictionRetries)
+	}
+	return 0
+}
+
+// IsTaskRefInitFailed returns true if the TaskRef is finished and had failed due
+// to an init container failure.
+func IsTaskRefInitFailed(taskRef execution.TaskRef) bool {
+	if taskRef.FinishTimestamp.IsZero() || taskRef.Status.Reason != ReasonInitFailed {
+		return false
+	}
+	result := taskRef.Status.Result
+	return result != nil && *result == execution.JobResultTaskFailed
+}
+
+// IsTaskRefNodeLost returns true if the TaskRef is finished and was replaced
+// after its node was lost.
+func IsTaskRefNodeLost(taskRef execution.TaskRef) bool {
+	return !taskRef.FinishTimestamp.IsZero() && taskRef.Status.Reason == ReasonNodeLost
 }
 
 // GetNextAllowedRetry checks if we can create a new task, and if so, returns
@@ -94,6 +171,18 @@ func GetNextAllowedRetry(rj *execution.Job) (time.Time, error) {
 		return nextRetry, fmt.Errorf("last task is not yet finished")
 	}
 
+	// The last task was killed due to suspension or replaced after its node was
+	// lost, can resume immediately.
+	if lastTask.Suspended || IsTaskRefNodeLost(lastTask) {
+		return nextRetry, nil
+	}
+
+	// The last task was evicted and can be recreated immediately, as long as it
+	// is still within the maximum eviction retries.
+	if IsTaskRefEvicted(lastTask) && countEvictedTaskRefs(rj) <= getMaxEvictionRetries(rj) {
+		return nextRetry, nil
+	}
+
 	// Compute next allowed time based on last task's finish timestamp.
 	return finishTime.Add(retryDelay), nil
 }
@@ -250,6 +250,115 @@ func TestGetNextAllowedRetry(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "last task was replaced after node was lost",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						MaxAttempts:       &two,
+						RetryDelaySeconds: pointer.Int64(30),
+					},
+				},
+				Status: execution.JobStatus{
+					CreatedTasks: 2,
+					Tasks: []execution.TaskRef{
+						{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskFailed,
+								Result: jobutil.GetResultPtr(execution.JobResultTaskFailed),
+							},
+						},
+						{
+							Name:              "task2",
+							CreationTimestamp: createTime2,
+							FinishTimestamp:   &finishTime2,
+							Status: execution.TaskStatus{
+								State:  execution.TaskDeletedFinalStateUnknown,
+								Result: jobutil.GetResultPtr(execution.JobResultFinalStateUnknown),
+								Reason: jobutil.ReasonNodeLost,
+							},
+						},
+					},
+				},
+			},
+			want: time.Time{},
+		},
+		{
+			name: "last task was evicted within max eviction retries",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						MaxAttempts:        &two,
+						MaxEvictionRetries: pointer.Int32(1),
+						RetryDelaySeconds:  pointer.Int64(30),
+					},
+				},
+				Status: execution.JobStatus{
+					CreatedTasks: 2,
+					Tasks: []execution.TaskRef{
+						{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskFailed,
+								Result: jobutil.GetResultPtr(execution.JobResultTaskFailed),
+							},
+						},
+						{
+							Name:              "task2",
+							CreationTimestamp: createTime2,
+							FinishTimestamp:   &finishTime2,
+							Status: execution.TaskStatus{
+								State:  execution.TaskFailed,
+								Result: jobutil.GetResultPtr(execution.JobResultTaskFailed),
+								Reason: jobutil.ReasonEvicted,
+							},
+						},
+					},
+				},
+			},
+			want: time.Time{},
+		},
+		{
+			name: "last task was evicted without max eviction retries",
+			rj: &execution.Job{
+				Spec: execution.JobSpec{
+					Template: &execution.JobTemplateSpec{
+						MaxAttempts:       &two,
+						RetryDelaySeconds: pointer.Int64(30),
+					},
+				},
+				Status: execution.JobStatus{
+					CreatedTasks: 2,
+					Tasks: []execution.TaskRef{
+						{
+							Name:              "task1",
+							CreationTimestamp: createTime,
+							FinishTimestamp:   &finishTime,
+							Status: execution.TaskStatus{
+								State:  execution.TaskFailed,
+								Result: jobutil.GetResultPtr(execution.JobResultTaskFailed),
+							},
+						},
+						{
+							Name:              "task2",
+							CreationTimestamp: createTime2,
+							FinishTimestamp:   &finishTime2,
+							Status: execution.TaskStatus{
+								State:  execution.TaskFailed,
+								Result: jobutil.GetResultPtr(execution.JobResultTaskFailed),
+								Reason: jobutil.ReasonEvicted,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
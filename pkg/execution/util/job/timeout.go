@@ -23,6 +23,10 @@ import (
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 )
 
+const (
+	defaultPreKillHookTimeoutSeconds int64 = 30
+)
+
 // GetPendingTimeout returns the pending timeout for the given Job.
 func GetPendingTimeout(rj *execution.Job, cfg *configv1alpha1.JobExecutionConfig) time.Duration {
 	var sec int64
@@ -35,12 +39,62 @@ func GetPendingTimeout(rj *execution.Job, cfg *configv1alpha1.JobExecutionConfig
 	return time.Duration(sec) * time.Second
 }
 
+// GetRunningTimeout returns the running timeout for the given Job. Returns 0 if
+// the running timeout is disabled.
+func GetRunningTimeout(rj *execution.Job) time.Duration {
+	var sec int64
+	if taskRt := rj.Spec.Template.Task.RunningTimeoutSeconds; taskRt != nil && *taskRt >= 0 {
+		sec = *taskRt
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// GetMaxRuntime returns the maximum runtime for the given Job, measured from its
+// start time. Returns 0 if the maximum runtime is disabled.
+func GetMaxRuntime(rj *execution.Job, cfg *configv1alpha1.JobExecutionConfig) time.Duration {
+	var sec int64
+	if spec := cfg.DefaultMaxRuntimeSeconds; spec != nil {
+		sec = *spec
+	}
+	if rj.Spec.Template != nil {
+		if maxRuntime := rj.Spec.Template.MaxRuntimeSeconds; maxRuntime != nil && *maxRuntime >= 0 {
+			sec = *maxRuntime
+		}
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// GetRetainFinishedTasks returns the duration to retain finished tasks for the
+// given Job. Returns nil if finished tasks should be retained until the Job is
+// deleted.
+func GetRetainFinishedTasks(rj *execution.Job) *time.Duration {
+	sec := rj.Spec.RetainFinishedTasksSeconds
+	if sec == nil || *sec < 0 {
+		return nil
+	}
+	retain := time.Duration(*sec) * time.Second
+	return &retain
+}
+
 // GetDeleteKillingTimeout returns the timeout before the controller starts killing tasks with deletion.
-func GetDeleteKillingTimeout(cfg *configv1alpha1.JobExecutionConfig) time.Duration {
+func GetDeleteKillingTimeout(rj *execution.Job, cfg *configv1alpha1.JobExecutionConfig) time.Duration {
 	var sec int64
 	if spec := cfg.DeleteKillingTasksTimeoutSeconds; spec != nil {
 		sec = *spec
 	}
+	if grace := rj.Spec.Template.Task.KillGracePeriodSeconds; grace != nil && *grace >= 0 {
+		sec = *grace
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// GetPreKillHookTimeout returns the duration to wait for a pre-kill hook to
+// exit before the task is killed regardless.
+func GetPreKillHookTimeout(hook *execution.TaskPreKillHook) time.Duration {
+	sec := defaultPreKillHookTimeoutSeconds
+	if timeout := hook.TimeoutSeconds; timeout != nil && *timeout >= 0 {
+		sec = *timeout
+	}
 	return time.Duration(sec) * time.Second
 }
 
@@ -53,6 +107,16 @@ func GetForceDeleteKillingTimeout(cfg *configv1alpha1.JobExecutionConfig) time.D
 	return time.Duration(sec) * time.Second
 }
 
+// GetNodeLostReplaceTimeout returns the timeout before tasks whose node was
+// lost are replaced. Returns 0 if disabled.
+func GetNodeLostReplaceTimeout(cfg *configv1alpha1.JobExecutionConfig) time.Duration {
+	var sec int64
+	if spec := cfg.NodeLostReplaceTimeoutSeconds; spec != nil {
+		sec = *spec
+	}
+	return time.Duration(sec) * time.Second
+}
+
 // GetTTLAfterFinished returns the TTL after a Job is finished.
 func GetTTLAfterFinished(rj *execution.Job, cfg *configv1alpha1.JobExecutionConfig) time.Duration {
 	var sec int64
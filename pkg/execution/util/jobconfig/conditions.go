@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig
+
+import (
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// GetConditions returns the standard conditions of a JobConfig, computed from
+// its spec and status. If scheduleErr is not nil, the JobConfig is not
+// considered to be Ready since it cannot be scheduled. The JobConfig's existing
+// conditions are updated such that the transition time is only changed when the
+// status of a condition changes.
+func GetConditions(rjc *execution.JobConfig, scheduleErr error) []metav1.Condition {
+	conditions := make([]metav1.Condition, len(rjc.Status.Conditions))
+	copy(conditions, rjc.Status.Conditions)
+	now := *ktime.Now()
+
+	ready := metav1.Condition{
+		Type:    execution.JobConfigConditionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Ready",
+		Message: "JobConfig is ready to create Jobs",
+	}
+	if scheduleErr != nil {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "InvalidSchedule"
+		ready.Message = scheduleErr.Error()
+	}
+
+	progressing := metav1.Condition{
+		Type:   execution.JobConfigConditionProgressing,
+		Status: metav1.ConditionFalse,
+		Reason: "NoActiveJobs",
+	}
+	if active, queued := rjc.Status.Active, rjc.Status.Queued; active > 0 || queued > 0 {
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = string(GetState(rjc))
+		progressing.Message = fmt.Sprintf("JobConfig has %v active and %v queued Jobs", active, queued)
+	}
+
+	scheduleActive := metav1.Condition{
+		Type:   execution.JobConfigConditionScheduleActive,
+		Status: metav1.ConditionFalse,
+		Reason: "NoSchedule",
+	}
+	if spec := rjc.Spec.Schedule; spec != nil && spec.Cron != nil {
+		scheduleActive.Reason = "ScheduleDisabled"
+		if !spec.Disabled {
+			scheduleActive.Status = metav1.ConditionTrue
+			scheduleActive.Reason = "ScheduleEnabled"
+		}
+	}
+
+	for _, condition := range []metav1.Condition{ready, progressing, scheduleActive} {
+		condition.ObservedGeneration = rjc.Generation
+		condition.LastTransitionTime = now
+		apimeta.SetStatusCondition(&conditions, condition)
+	}
+
+	return conditions
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestGetConditions(t *testing.T) {
+	earlier := testutils.Mkmtime("2022-04-01T04:00:00Z")
+	now := testutils.Mkmtime("2022-04-01T05:00:00Z")
+	ktime.Clock = clock.NewFakeClock(now.Time)
+
+	tests := []struct {
+		name        string
+		rjc         *execution.JobConfig
+		scheduleErr error
+		want        []metav1.Condition
+	}{
+		{
+			name: "Ready without schedule",
+			rjc:  &execution.JobConfig{},
+			want: []metav1.Condition{
+				{
+					Type:               execution.JobConfigConditionReady,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             "Ready",
+					Message:            "JobConfig is ready to create Jobs",
+				},
+				{
+					Type:               execution.JobConfigConditionProgressing,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             "NoActiveJobs",
+				},
+				{
+					Type:               execution.JobConfigConditionScheduleActive,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             "NoSchedule",
+				},
+			},
+		},
+		{
+			name: "Executing with invalid schedule",
+			rjc: &execution.JobConfig{
+				Spec: execution.JobConfigSpec{
+					Schedule: &execution.ScheduleSpec{
+						Cron: &cronSchedule1,
+					},
+				},
+				Status: execution.JobConfigStatus{
+					Active: 1,
+					Conditions: []metav1.Condition{
+						{
+							Type:               execution.JobConfigConditionReady,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: earlier,
+							Reason:             "Ready",
+						},
+						{
+							Type:               execution.JobConfigConditionScheduleActive,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: earlier,
+							Reason:             "ScheduleEnabled",
+						},
+					},
+				},
+			},
+			scheduleErr: errors.New("cannot parse cron schedule"),
+			want: []metav1.Condition{
+				{
+					Type:               execution.JobConfigConditionReady,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             "InvalidSchedule",
+					Message:            "cannot parse cron schedule",
+				},
+				{
+					Type:               execution.JobConfigConditionScheduleActive,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: earlier,
+					Reason:             "ScheduleEnabled",
+				},
+				{
+					Type:               execution.JobConfigConditionProgressing,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             string(execution.JobConfigExecuting),
+					Message:            "JobConfig has 1 active and 0 queued Jobs",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := jobconfig.GetConditions(tt.rjc, tt.scheduleErr)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("GetConditions() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+const (
+	annotationKeyLastAppliedConfiguration = "kubectl.kubernetes.io/last-applied-configuration"
+
+	historyLimitWarning = "history limits are not supported, finished Jobs are instead deleted after " +
+		"spec.executionOverrides.ttlSecondsAfterFinished"
+
+	additionalRetriesWarning = "additional retries are not supported, all failures count towards the backoffLimit"
+)
+
+// ConvertCronJob converts a batch/v1 CronJob into an equivalent JobConfig, to
+// ease migrations from CronJobs. Fields of the CronJob which do not have a
+// direct equivalent in the JobConfig are either approximated or dropped, and a
+// warning is returned for each of them.
+func ConvertCronJob(cronJob *batchv1.CronJob) (*execution.JobConfig, []string, error) {
+	var warnings []string
+	warn := func(field, format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf("%v: %v", field, fmt.Sprintf(format, args...)))
+	}
+
+	cronJob = cronJob.DeepCopy()
+	spec := cronJob.Spec
+	expression, timezone := parseCronJobSchedule(spec.Schedule)
+	if expression == "" {
+		return nil, nil, fmt.Errorf("cronjob %v has no schedule", cronJob.GetName())
+	}
+
+	rjc := &execution.JobConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: execution.GroupVersion.String(),
+			Kind:       execution.KindJobConfig,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cronJob.GetName(),
+			Namespace:   cronJob.GetNamespace(),
+			Labels:      cronJob.GetLabels(),
+			Annotations: filterAnnotations(cronJob.GetAnnotations()),
+		},
+		Spec: execution.JobConfigSpec{
+			Schedule: &execution.ScheduleSpec{
+				Cron: &execution.CronSchedule{
+					Expression: expression,
+					Timezone:   timezone,
+				},
+				Disabled: spec.Suspend != nil && *spec.Suspend,
+			},
+		},
+	}
+
+	switch spec.ConcurrencyPolicy {
+	case batchv1.AllowConcurrent, "":
+		rjc.Spec.Concurrency.Policy = execution.ConcurrencyPolicyAllow
+	case batchv1.ForbidConcurrent:
+		rjc.Spec.Concurrency.Policy = execution.ConcurrencyPolicyForbid
+	case batchv1.ReplaceConcurrent:
+		rjc.Spec.Concurrency.Policy = execution.ConcurrencyPolicyEnqueue
+		warn("spec.concurrencyPolicy", "Replace is not supported, converted to %v which starts the new Job "+
+			"after the running Job has finished instead of killing it", execution.ConcurrencyPolicyEnqueue)
+	default:
+		return nil, nil, fmt.Errorf("unknown concurrency policy %v", spec.ConcurrencyPolicy)
+	}
+
+	if spec.StartingDeadlineSeconds != nil {
+		rjc.Spec.Concurrency.ExpireAfterSeconds = spec.StartingDeadlineSeconds
+	}
+
+	if spec.SuccessfulJobsHistoryLimit != nil {
+		warn("spec.successfulJobsHistoryLimit", historyLimitWarning)
+	}
+	if spec.FailedJobsHistoryLimit != nil {
+		warn("spec.failedJobsHistoryLimit", historyLimitWarning)
+	}
+
+	template, templateWarnings := convertJobTemplate(spec.JobTemplate)
+	rjc.Spec.Template = template
+	warnings = append(warnings, templateWarnings...)
+
+	if ttl := spec.JobTemplate.Spec.TTLSecondsAfterFinished; ttl != nil {
+		ttlSeconds := int64(*ttl)
+		rjc.Spec.ExecutionOverrides = &execution.JobExecutionOverrides{
+			TTLSecondsAfterFinished: &ttlSeconds,
+		}
+	}
+
+	return rjc, warnings, nil
+}
+
+// convertJobTemplate converts the JobTemplateSpec of a CronJob into a
+// JobTemplate.
+func convertJobTemplate(jobTemplate batchv1.JobTemplateSpec) (execution.JobTemplate, []string) {
+	var warnings []string
+	warn := func(field, format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf("spec.jobTemplate.%v: %v", field, fmt.Sprintf(format, args...)))
+	}
+
+	jobSpec := jobTemplate.Spec
+	template := execution.JobTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      jobTemplate.GetLabels(),
+			Annotations: jobTemplate.GetAnnotations(),
+		},
+		Spec: execution.JobTemplateSpec{
+			Task: execution.JobTaskSpec{
+				Template: *jobSpec.Template.DeepCopy(),
+			},
+			MaxRuntimeSeconds: jobSpec.ActiveDeadlineSeconds,
+		},
+	}
+
+	// Each retry of a Job creates a new Pod, so maxAttempts is one more than the
+	// number of retries allowed by backoffLimit.
+	if jobSpec.BackoffLimit != nil {
+		maxAttempts := *jobSpec.BackoffLimit + 1
+		template.Spec.MaxAttempts = &maxAttempts
+	}
+
+	if jobSpec.Parallelism != nil && *jobSpec.Parallelism > 1 {
+		warn("spec.parallelism", "parallel tasks are not supported, each Job will only run a single task at a time")
+	}
+	if jobSpec.Completions != nil && *jobSpec.Completions > 1 {
+		warn("spec.completions", "multiple completions are not supported, each Job will only succeed once")
+	}
+	if jobSpec.CompletionMode != nil && *jobSpec.CompletionMode == batchv1.IndexedCompletion {
+		warn("spec.completionMode", "indexed completion is not supported")
+	}
+	if jobSpec.Selector != nil || jobSpec.ManualSelector != nil {
+		warn("spec.selector", "custom selectors are not supported, tasks are selected by the Job's UID")
+	}
+	if jobSpec.Suspend != nil && *jobSpec.Suspend {
+		warn("spec.suspend", "suspending Jobs on creation is not supported, "+
+			"use spec.schedule.disabled to stop scheduling new Jobs")
+	}
+
+	return template, warnings
+}
+
+// parseCronJobSchedule returns the cron expression and timezone of a CronJob
+// schedule, which may be prefixed with CRON_TZ= or TZ= to specify a timezone.
+func parseCronJobSchedule(schedule string) (string, string) {
+	schedule = strings.TrimSpace(schedule)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if !strings.HasPrefix(schedule, prefix) {
+			continue
+		}
+		tokens := strings.SplitN(strings.TrimPrefix(schedule, prefix), " ", 2)
+		if len(tokens) < 2 {
+			return "", tokens[0]
+		}
+		return strings.TrimSpace(tokens[1]), tokens[0]
+	}
+	return schedule, ""
+}
+
+// filterAnnotations returns the annotations without those that should not be
+// copied from the CronJob.
+func filterAnnotations(annotations map[string]string) map[string]string {
+	if _, ok := annotations[annotationKeyLastAppliedConfiguration]; !ok {
+		return annotations
+	}
+	filtered := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != annotationKeyLastAppliedConfiguration {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// ConvertToCronJob converts a JobConfig into an equivalent batch/v1 CronJob,
+// which can be used as a fallback or to migrate away from Furiko. Features of
+// the JobConfig which are not supported by CronJobs are either approximated or
+// dropped, and a warning is returned for each of them. JobConfigs without a
+// cron schedule, or whose tasks are not created as Pods from an inline
+// template, cannot be converted.
+func ConvertToCronJob(rjc *execution.JobConfig) (*batchv1.CronJob, []string, error) {
+	var warnings []string
+	warn := func(field, format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf("%v: %v", field, fmt.Sprintf(format, args...)))
+	}
+
+	rjc = rjc.DeepCopy()
+	spec := rjc.Spec
+	schedule := spec.Schedule
+	if schedule == nil || schedule.Cron == nil || schedule.Cron.Expression == "" {
+		return nil, nil, fmt.Errorf("jobconfig %v has no cron schedule", rjc.GetName())
+	}
+
+	cronJob := &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        rjc.GetName(),
+			Namespace:   rjc.GetNamespace(),
+			Labels:      rjc.GetLabels(),
+			Annotations: filterAnnotations(rjc.GetAnnotations()),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                schedule.Cron.Expression,
+			StartingDeadlineSeconds: spec.Concurrency.ExpireAfterSeconds,
+		},
+	}
+
+	if tz := schedule.Cron.Timezone; tz != "" {
+		cronJob.Spec.Schedule = fmt.Sprintf("CRON_TZ=%v %v", tz, schedule.Cron.Expression)
+		warn("spec.schedule.cron.timezone", "converted to a CRON_TZ= prefix in the schedule, "+
+			"which is not officially supported by all Kubernetes versions")
+	}
+	if schedule.Disabled {
+		cronJob.Spec.Suspend = pointer.Bool(true)
+	}
+	if schedule.Constraints != nil {
+		warn("spec.schedule.constraints", "schedule constraints are not supported and will be dropped")
+	}
+
+	switch spec.Concurrency.Policy {
+	case execution.ConcurrencyPolicyAllow:
+		cronJob.Spec.ConcurrencyPolicy = batchv1.AllowConcurrent
+	case execution.ConcurrencyPolicyForbid:
+		cronJob.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+	case execution.ConcurrencyPolicyEnqueue:
+		cronJob.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+		warn("spec.concurrency.policy", "Enqueue is not supported, converted to %v which skips the new Job "+
+			"if the previous Job is still running", batchv1.ForbidConcurrent)
+	default:
+		return nil, nil, fmt.Errorf("unknown concurrency policy %v", spec.Concurrency.Policy)
+	}
+	if spec.Concurrency.Group != nil {
+		warn("spec.concurrency.group", "concurrency groups are not supported and will be dropped")
+	}
+
+	jobTemplate, templateWarnings, err := convertToJobTemplate(spec.Template)
+	if err != nil {
+		return nil, nil, err
+	}
+	cronJob.Spec.JobTemplate = jobTemplate
+	warnings = append(warnings, templateWarnings...)
+
+	if overrides := spec.ExecutionOverrides; overrides != nil && overrides.TTLSecondsAfterFinished != nil {
+		ttl := int32(*overrides.TTLSecondsAfterFinished)
+		cronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished = &ttl
+	}
+
+	if spec.Option != nil && len(spec.Option.Options) > 0 {
+		warn("spec.option", "job options are not supported, and their values will not be substituted "+
+			"into the pod template")
+	}
+	if len(spec.Notifications) > 0 {
+		warn("spec.notifications", "notifications are not supported and will be dropped")
+	}
+	if len(spec.Alerts) > 0 {
+		warn("spec.alerts", "alerts are not supported and will be dropped")
+	}
+
+	return cronJob, warnings, nil
+}
+
+// convertToJobTemplate converts the JobTemplate of a JobConfig into a
+// batchv1.JobTemplateSpec.
+func convertToJobTemplate(template execution.JobTemplate) (batchv1.JobTemplateSpec, []string, error) {
+	var warnings []string
+	warn := func(field, format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf("spec.template.%v: %v", field, fmt.Sprintf(format, args...)))
+	}
+
+	spec := template.Spec
+	switch {
+	case len(spec.Steps) > 0:
+		return batchv1.JobTemplateSpec{}, nil, errors.New("jobs with steps cannot be converted")
+	case spec.Task.External != nil:
+		return batchv1.JobTemplateSpec{}, nil, errors.New("external tasks cannot be converted")
+	case spec.Task.TemplateRef != nil:
+		return batchv1.JobTemplateSpec{}, nil, errors.New("tasks referencing a TaskTemplate cannot be converted")
+	}
+
+	podTemplate := spec.Task.Template
+	if podTemplate.Spec.RestartPolicy == "" {
+		podTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	// Context variables such as ${job.name} are only substituted by Furiko.
+	data, err := json.Marshal(podTemplate)
+	if err != nil {
+		return batchv1.JobTemplateSpec{}, nil, errors.Wrapf(err, "cannot marshal pod template")
+	}
+	if strings.Contains(string(data), "${") {
+		warn("spec.task.template", "context variables are not supported and will not be substituted")
+	}
+
+	jobTemplate := batchv1.JobTemplateSpec{
+		ObjectMeta: template.ObjectMeta,
+		Spec: batchv1.JobSpec{
+			Template:              podTemplate,
+			ActiveDeadlineSeconds: spec.MaxRuntimeSeconds,
+		},
+	}
+
+	// CronJobs retry with a backoffLimit of 6 by default, whereas JobConfigs only
+	// make a single attempt by default.
+	backoffLimit := int32(0)
+	if spec.MaxAttempts != nil && *spec.MaxAttempts > 0 {
+		backoffLimit = *spec.MaxAttempts - 1
+	}
+	jobTemplate.Spec.BackoffLimit = &backoffLimit
+
+	if spec.RetryDelaySeconds != nil && *spec.RetryDelaySeconds > 0 {
+		warn("spec.retryDelaySeconds", "retry delays are not supported, failed Pods will be retried "+
+			"with an exponential backoff instead")
+	}
+	if spec.MaxInitFailureRetries != nil {
+		warn("spec.maxInitFailureRetries", additionalRetriesWarning)
+	}
+	if spec.MaxEvictionRetries != nil {
+		warn("spec.maxEvictionRetries", additionalRetriesWarning)
+	}
+	if spec.TaskNameTemplate != "" {
+		warn("spec.taskNameTemplate", "task name templates are not supported and will be dropped")
+	}
+	if spec.RetryMode == execution.RetryModeInPlace {
+		warn("spec.retryMode", "in-place retries are not supported, each retry creates a new Pod")
+	}
+	if spec.TaskDeletionPolicy == execution.TaskDeletionPolicyOrphan {
+		warn("spec.taskDeletionPolicy", "orphaning tasks is not supported, Pods are deleted with their Job")
+	}
+	if len(spec.Patches) > 0 {
+		warn("spec.patches", "template patches are not supported and will be dropped")
+	}
+	if spec.Callback != nil {
+		warn("spec.callback", "callbacks are not supported and will be dropped")
+	}
+
+	return jobTemplate, warnings, nil
+}
@@ -0,0 +1,442 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+)
+
+func TestConvertCronJob(t *testing.T) {
+	podTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:  "container",
+					Image: "alpine",
+					Args:  []string{"echo", "hello"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		cronJob      *batchv1.CronJob
+		want         *execution.JobConfig
+		wantWarnings []string
+		wantErr      string
+	}{
+		{
+			name: "basic CronJob",
+			cronJob: &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cronjob",
+					Namespace: "test",
+					Labels: map[string]string{
+						"app": "cronjob",
+					},
+					Annotations: map[string]string{
+						"kubectl.kubernetes.io/last-applied-configuration": "{}",
+						"owner": "team-a",
+					},
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule:                "*/5 * * * *",
+					ConcurrencyPolicy:       batchv1.ForbidConcurrent,
+					StartingDeadlineSeconds: pointer.Int64(60),
+					Suspend:                 pointer.Bool(true),
+					JobTemplate: batchv1.JobTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"app": "job",
+							},
+						},
+						Spec: batchv1.JobSpec{
+							BackoffLimit:            pointer.Int32(2),
+							ActiveDeadlineSeconds:   pointer.Int64(3600),
+							TTLSecondsAfterFinished: pointer.Int32(86400),
+							Template:                podTemplate,
+						},
+					},
+				},
+			},
+			want: &execution.JobConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "execution.furiko.io/v1alpha1",
+					Kind:       "JobConfig",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cronjob",
+					Namespace: "test",
+					Labels: map[string]string{
+						"app": "cronjob",
+					},
+					Annotations: map[string]string{
+						"owner": "team-a",
+					},
+				},
+				Spec: execution.JobConfigSpec{
+					Schedule: &execution.ScheduleSpec{
+						Cron: &execution.CronSchedule{
+							Expression: "*/5 * * * *",
+						},
+						Disabled: true,
+					},
+					Concurrency: execution.ConcurrencySpec{
+						Policy:             execution.ConcurrencyPolicyForbid,
+						ExpireAfterSeconds: pointer.Int64(60),
+					},
+					Template: execution.JobTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"app": "job",
+							},
+						},
+						Spec: execution.JobTemplateSpec{
+							Task: execution.JobTaskSpec{
+								Template: podTemplate,
+							},
+							MaxAttempts:       pointer.Int32(3),
+							MaxRuntimeSeconds: pointer.Int64(3600),
+						},
+					},
+					ExecutionOverrides: &execution.JobExecutionOverrides{
+						TTLSecondsAfterFinished: pointer.Int64(86400),
+					},
+				},
+			},
+		},
+		{
+			name: "timezone and unsupported fields",
+			cronJob: &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cronjob",
+					Namespace: "test",
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule:                   "CRON_TZ=Asia/Singapore 0 10 * * *",
+					ConcurrencyPolicy:          batchv1.ReplaceConcurrent,
+					SuccessfulJobsHistoryLimit: pointer.Int32(3),
+					JobTemplate: batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Parallelism: pointer.Int32(2),
+							Completions: pointer.Int32(4),
+							Template:    podTemplate,
+						},
+					},
+				},
+			},
+			want: &execution.JobConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "execution.furiko.io/v1alpha1",
+					Kind:       "JobConfig",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cronjob",
+					Namespace: "test",
+				},
+				Spec: execution.JobConfigSpec{
+					Schedule: &execution.ScheduleSpec{
+						Cron: &execution.CronSchedule{
+							Expression: "0 10 * * *",
+							Timezone:   "Asia/Singapore",
+						},
+					},
+					Concurrency: execution.ConcurrencySpec{
+						Policy: execution.ConcurrencyPolicyEnqueue,
+					},
+					Template: execution.JobTemplate{
+						Spec: execution.JobTemplateSpec{
+							Task: execution.JobTaskSpec{
+								Template: podTemplate,
+							},
+						},
+					},
+				},
+			},
+			wantWarnings: []string{
+				"spec.concurrencyPolicy: Replace is not supported, converted to Enqueue which starts the new Job " +
+					"after the running Job has finished instead of killing it",
+				"spec.successfulJobsHistoryLimit: history limits are not supported, " +
+					"finished Jobs are instead deleted after spec.executionOverrides.ttlSecondsAfterFinished",
+				"spec.jobTemplate.spec.parallelism: parallel tasks are not supported, " +
+					"each Job will only run a single task at a time",
+				"spec.jobTemplate.spec.completions: multiple completions are not supported, " +
+					"each Job will only succeed once",
+			},
+		},
+		{
+			name: "missing schedule",
+			cronJob: &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cronjob",
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule: "TZ=UTC",
+				},
+			},
+			wantErr: "cronjob cronjob has no schedule",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings, err := jobconfig.ConvertCronJob(tt.cronJob)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("ConvertCronJob() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+			assert.Equal(t, tt.wantWarnings, warnings)
+		})
+	}
+}
+
+func TestConvertToCronJob(t *testing.T) {
+	podTemplate := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:  "container",
+					Image: "alpine",
+					Args:  []string{"echo", "hello"},
+				},
+			},
+		},
+	}
+	podTemplateWithVariables := *podTemplate.DeepCopy()
+	podTemplateWithVariables.Spec.Containers[0].Args = []string{"echo", "${option.name}"}
+
+	tests := []struct {
+		name         string
+		rjc          *execution.JobConfig
+		want         *batchv1.CronJob
+		wantWarnings []string
+		wantErr      string
+	}{
+		{
+			name: "basic JobConfig",
+			rjc: &execution.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jobconfig",
+					Namespace: "test",
+					Labels: map[string]string{
+						"app": "jobconfig",
+					},
+				},
+				Spec: execution.JobConfigSpec{
+					Schedule: &execution.ScheduleSpec{
+						Cron: &execution.CronSchedule{
+							Expression: "*/5 * * * *",
+						},
+						Disabled: true,
+					},
+					Concurrency: execution.ConcurrencySpec{
+						Policy:             execution.ConcurrencyPolicyForbid,
+						ExpireAfterSeconds: pointer.Int64(60),
+					},
+					Template: execution.JobTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"app": "job",
+							},
+						},
+						Spec: execution.JobTemplateSpec{
+							Task: execution.JobTaskSpec{
+								Template: podTemplate,
+							},
+							MaxAttempts:       pointer.Int32(3),
+							MaxRuntimeSeconds: pointer.Int64(3600),
+						},
+					},
+					ExecutionOverrides: &execution.JobExecutionOverrides{
+						TTLSecondsAfterFinished: pointer.Int64(86400),
+					},
+				},
+			},
+			want: &batchv1.CronJob{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "batch/v1",
+					Kind:       "CronJob",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jobconfig",
+					Namespace: "test",
+					Labels: map[string]string{
+						"app": "jobconfig",
+					},
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule:                "*/5 * * * *",
+					ConcurrencyPolicy:       batchv1.ForbidConcurrent,
+					StartingDeadlineSeconds: pointer.Int64(60),
+					Suspend:                 pointer.Bool(true),
+					JobTemplate: batchv1.JobTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								"app": "job",
+							},
+						},
+						Spec: batchv1.JobSpec{
+							BackoffLimit:            pointer.Int32(2),
+							ActiveDeadlineSeconds:   pointer.Int64(3600),
+							TTLSecondsAfterFinished: pointer.Int32(86400),
+							Template:                podTemplate,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Furiko-only features",
+			rjc: &execution.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jobconfig",
+					Namespace: "test",
+				},
+				Spec: execution.JobConfigSpec{
+					Schedule: &execution.ScheduleSpec{
+						Cron: &execution.CronSchedule{
+							Expression: "0 10 * * *",
+							Timezone:   "Asia/Singapore",
+						},
+					},
+					Concurrency: execution.ConcurrencySpec{
+						Policy: execution.ConcurrencyPolicyEnqueue,
+					},
+					Option: &execution.OptionSpec{
+						Options: []execution.Option{
+							{
+								Type: execution.OptionTypeString,
+								Name: "name",
+							},
+						},
+					},
+					Template: execution.JobTemplate{
+						Spec: execution.JobTemplateSpec{
+							Task: execution.JobTaskSpec{
+								Template: podTemplateWithVariables,
+							},
+							RetryDelaySeconds: pointer.Int64(30),
+						},
+					},
+				},
+			},
+			want: &batchv1.CronJob{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "batch/v1",
+					Kind:       "CronJob",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "jobconfig",
+					Namespace: "test",
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule:          "CRON_TZ=Asia/Singapore 0 10 * * *",
+					ConcurrencyPolicy: batchv1.ForbidConcurrent,
+					JobTemplate: batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							BackoffLimit: pointer.Int32(0),
+							Template:     podTemplateWithVariables,
+						},
+					},
+				},
+			},
+			wantWarnings: []string{
+				"spec.schedule.cron.timezone: converted to a CRON_TZ= prefix in the schedule, " +
+					"which is not officially supported by all Kubernetes versions",
+				"spec.concurrency.policy: Enqueue is not supported, converted to Forbid which skips the new Job " +
+					"if the previous Job is still running",
+				"spec.template.spec.task.template: context variables are not supported and will not be substituted",
+				"spec.template.spec.retryDelaySeconds: retry delays are not supported, " +
+					"failed Pods will be retried with an exponential backoff instead",
+				"spec.option: job options are not supported, and their values will not be substituted " +
+					"into the pod template",
+			},
+		},
+		{
+			name: "no schedule",
+			rjc: &execution.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "jobconfig",
+				},
+			},
+			wantErr: "jobconfig jobconfig has no cron schedule",
+		},
+		{
+			name: "steps cannot be converted",
+			rjc: &execution.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "jobconfig",
+				},
+				Spec: execution.JobConfigSpec{
+					Schedule: &execution.ScheduleSpec{
+						Cron: &execution.CronSchedule{
+							Expression: "0 10 * * *",
+						},
+					},
+					Concurrency: execution.ConcurrencySpec{
+						Policy: execution.ConcurrencyPolicyAllow,
+					},
+					Template: execution.JobTemplate{
+						Spec: execution.JobTemplateSpec{
+							Steps: []execution.JobStepSpec{
+								{
+									Name: "step",
+									Task: execution.JobTaskSpec{
+										Template: podTemplate,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "jobs with steps cannot be converted",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings, err := jobconfig.ConvertToCronJob(tt.rjc)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("ConvertToCronJob() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+			assert.Equal(t, tt.wantWarnings, warnings)
+		})
+	}
+}
@@ -17,6 +17,8 @@
 package jobconfig
 
 import (
+	"encoding/json"
+	"hash/fnv"
 	"strconv"
 	"time"
 
@@ -44,13 +46,19 @@ func NewJobFromJobConfig(
 		return nil, errors.Wrapf(err, "cannot create template variables")
 	}
 
+	// Compute the template revision.
+	revision, err := GetTemplateRevision(jobConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot compute template revision")
+	}
+
 	// Initialise Job
 	job := &execution.Job{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        jobName,
 			Namespace:   jobConfig.Namespace,
 			Labels:      makeLabels(jobConfig),
-			Annotations: makeAnnotations(jobConfig, jobType, createTime),
+			Annotations: makeAnnotations(jobConfig, jobType, createTime, revision),
 			Finalizers: []string{
 				// Add finalizer so that we can synchronize deletion of dependents in the
 				// JobController.
@@ -71,6 +79,18 @@ func NewJobFromJobConfig(
 	return job, nil
 }
 
+// GetTemplateRevision returns a revision that identifies the current template
+// spec of the JobConfig, which is computed from a hash of the template spec.
+func GetTemplateRevision(rjc *execution.JobConfig) (string, error) {
+	data, err := json.Marshal(rjc.Spec.Template.Spec)
+	if err != nil {
+		return "", err
+	}
+	hasher := fnv.New64a()
+	_, _ = hasher.Write(data)
+	return strconv.FormatUint(hasher.Sum64(), 16), nil
+}
+
 func makeSubstitutions(rjc *execution.JobConfig) (map[string]string, error) {
 	// Evaluate all options' defaults.
 	defaultSubs, err := options.MakeDefaultOptions(rjc.Spec.Option)
@@ -98,14 +118,18 @@ func makeLabels(rjc *execution.JobConfig) labels.Set {
 	return desiredLabels
 }
 
-func makeAnnotations(rjc *execution.JobConfig, jobType execution.JobType, createTime time.Time) labels.Set {
+func makeAnnotations(
+	rjc *execution.JobConfig, jobType execution.JobType, createTime time.Time, revision string,
+) labels.Set {
 	template := rjc.Spec.Template
 	desiredAnnotations := make(labels.Set, len(template.Annotations))
 	for k, v := range template.Annotations {
 		desiredAnnotations[k] = v
 	}
 
-	additionalAnnotations := map[string]string{}
+	additionalAnnotations := map[string]string{
+		AnnotationKeyTemplateRevision: revision,
+	}
 
 	// Add schedule time label only if it was scheduled.
 	if jobType == execution.JobTypeScheduled {
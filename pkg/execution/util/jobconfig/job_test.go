@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig_test
+
+import (
+	"testing"
+
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+)
+
+func TestGetTemplateRevision(t *testing.T) {
+	newJobConfig := func(maxAttempts int32, labels map[string]string) *execution.JobConfig {
+		rjc := &execution.JobConfig{}
+		rjc.Spec.Template.Labels = labels
+		rjc.Spec.Template.Spec.MaxAttempts = pointer.Int32(maxAttempts)
+		return rjc
+	}
+
+	revision, err := jobconfig.GetTemplateRevision(newJobConfig(1, nil))
+	if err != nil {
+		t.Fatalf("GetTemplateRevision() error = %v", err)
+	}
+	if revision == "" {
+		t.Errorf("GetTemplateRevision() returned empty revision")
+	}
+
+	tests := []struct {
+		name     string
+		rjc      *execution.JobConfig
+		wantSame bool
+	}{
+		{
+			name:     "same template",
+			rjc:      newJobConfig(1, nil),
+			wantSame: true,
+		},
+		{
+			name:     "template metadata is ignored",
+			rjc:      newJobConfig(1, map[string]string{"key": "value"}),
+			wantSame: true,
+		},
+		{
+			name: "different template spec",
+			rjc:  newJobConfig(2, nil),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jobconfig.GetTemplateRevision(tt.rjc)
+			if err != nil {
+				t.Fatalf("GetTemplateRevision() error = %v", err)
+			}
+			if same := got == revision; same != tt.wantSame {
+				t.Errorf("GetTemplateRevision() = %v, compared to %v, wantSame %v", got, revision, tt.wantSame)
+			}
+		})
+	}
+}
@@ -36,6 +36,30 @@ var (
 	// AnnotationKeyOptionSpecHash stores the hash of the OptionSpec at the point in
 	// time when a Job's optionValues are evaluated based on the JobConfig's Option.
 	AnnotationKeyOptionSpecHash = executiongroup.AddGroupToLabel("option-spec-hash")
+
+	// AnnotationKeyOptionJSONSchema stores the JSON Schema of the JobConfig's
+	// OptionSpec, which describes the optionValues that a Job for the JobConfig
+	// accepts.
+	AnnotationKeyOptionJSONSchema = executiongroup.AddGroupToLabel("option-json-schema")
+
+	// AnnotationKeyTemplateRevision stores the revision of the JobConfig's template
+	// that the Job's template was last copied from.
+	AnnotationKeyTemplateRevision = executiongroup.AddGroupToLabel("template-revision")
+
+	// AnnotationKeyAlertsOpen stores a comma-separated list of the indexes of the
+	// JobConfig's alerts which currently have an open incident.
+	AnnotationKeyAlertsOpen = executiongroup.AddGroupToLabel("alerts-open")
+
+	// AnnotationKeyTriggerTokenSecret stores the name of a Secret in the
+	// JobConfig's namespace, whose TriggerTokenSecretKey contains the token that
+	// allows the JobConfig to be triggered over HTTP.
+	AnnotationKeyTriggerTokenSecret = executiongroup.AddGroupToLabel("trigger-token-secret")
+)
+
+const (
+	// TriggerTokenSecretKey is the key in the Secret referenced by
+	// AnnotationKeyTriggerTokenSecret that contains the trigger token.
+	TriggerTokenSecretKey = "token"
 )
 
 // LabelJobsForJobConfig returns a labels.Set that labels all Jobs for a JobConfig.
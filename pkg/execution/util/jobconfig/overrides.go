@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig
+
+import (
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// ApplyExecutionOverrides returns the JobExecutionConfig to be used for Jobs
+// of the given JobConfig, with the JobConfig's executionOverrides layered over
+// cfg. The original cfg is not modified, and is returned as-is if rjc is nil or
+// does not specify any overrides.
+func ApplyExecutionOverrides(
+	cfg *configv1alpha1.JobExecutionConfig, rjc *execution.JobConfig,
+) *configv1alpha1.JobExecutionConfig {
+	if rjc == nil || rjc.Spec.ExecutionOverrides == nil {
+		return cfg
+	}
+	overrides := rjc.Spec.ExecutionOverrides
+
+	newCfg := cfg.DeepCopy()
+	if overrides.TTLSecondsAfterFinished != nil {
+		newCfg.DefaultTTLSecondsAfterFinished = overrides.TTLSecondsAfterFinished
+	}
+	if overrides.PendingTimeoutSeconds != nil {
+		newCfg.DefaultPendingTimeoutSeconds = overrides.PendingTimeoutSeconds
+	}
+	if overrides.DeleteKillingTasksTimeoutSeconds != nil {
+		newCfg.DeleteKillingTasksTimeoutSeconds = overrides.DeleteKillingTasksTimeoutSeconds
+	}
+	if overrides.ForceDeleteKillingTasksTimeoutSeconds != nil {
+		newCfg.ForceDeleteKillingTasksTimeoutSeconds = overrides.ForceDeleteKillingTasksTimeoutSeconds
+	}
+	return newCfg
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+)
+
+func TestApplyExecutionOverrides(t *testing.T) {
+	cfg := &configv1alpha1.JobExecutionConfig{
+		DefaultTTLSecondsAfterFinished:        pointer.Int64(3600),
+		DefaultPendingTimeoutSeconds:          pointer.Int64(900),
+		DeleteKillingTasksTimeoutSeconds:      pointer.Int64(180),
+		ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(120),
+	}
+
+	tests := []struct {
+		name string
+		rjc  *execution.JobConfig
+		want *configv1alpha1.JobExecutionConfig
+	}{
+		{
+			name: "no JobConfig",
+			want: cfg,
+		},
+		{
+			name: "no overrides",
+			rjc:  &execution.JobConfig{},
+			want: cfg,
+		},
+		{
+			name: "override all fields",
+			rjc: &execution.JobConfig{
+				Spec: execution.JobConfigSpec{
+					ExecutionOverrides: &execution.JobExecutionOverrides{
+						TTLSecondsAfterFinished:               pointer.Int64(60),
+						PendingTimeoutSeconds:                 pointer.Int64(30),
+						DeleteKillingTasksTimeoutSeconds:      pointer.Int64(0),
+						ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(0),
+					},
+				},
+			},
+			want: &configv1alpha1.JobExecutionConfig{
+				DefaultTTLSecondsAfterFinished:        pointer.Int64(60),
+				DefaultPendingTimeoutSeconds:          pointer.Int64(30),
+				DeleteKillingTasksTimeoutSeconds:      pointer.Int64(0),
+				ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(0),
+			},
+		},
+		{
+			name: "override some fields",
+			rjc: &execution.JobConfig{
+				Spec: execution.JobConfigSpec{
+					ExecutionOverrides: &execution.JobExecutionOverrides{
+						PendingTimeoutSeconds: pointer.Int64(30),
+					},
+				},
+			},
+			want: &configv1alpha1.JobExecutionConfig{
+				DefaultTTLSecondsAfterFinished:        pointer.Int64(3600),
+				DefaultPendingTimeoutSeconds:          pointer.Int64(30),
+				DeleteKillingTasksTimeoutSeconds:      pointer.Int64(180),
+				ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(120),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			original := cfg.DeepCopy()
+			got := jobconfig.ApplyExecutionOverrides(cfg, tt.rjc)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("ApplyExecutionOverrides() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+			if !cmp.Equal(original, cfg) {
+				t.Errorf("ApplyExecutionOverrides() modified original config\ndiff = %v", cmp.Diff(original, cfg))
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// MergeResourceUsage merges the observed resource usage of containers into the
+// existing list of ContainerResourceUsage, retaining the peak usage of each
+// container. Returns a new list of ContainerResourceUsage.
+func MergeResourceUsage(
+	existing []execution.ContainerResourceUsage,
+	observed map[string]corev1.ResourceList,
+	now metav1.Time,
+) []execution.ContainerResourceUsage {
+	merged := make([]execution.ContainerResourceUsage, 0, len(existing)+len(observed))
+	seen := make(map[string]struct{}, len(existing))
+
+	for _, usage := range existing {
+		newUsage := usage.DeepCopy()
+		seen[usage.Name] = struct{}{}
+		if resources, ok := observed[usage.Name]; ok {
+			newUsage.CPU = maxQuantity(newUsage.CPU, resources, corev1.ResourceCPU)
+			newUsage.Memory = maxQuantity(newUsage.Memory, resources, corev1.ResourceMemory)
+			newUsage.Samples++
+			newUsage.LastUpdated = now
+		}
+		merged = append(merged, *newUsage)
+	}
+
+	// Append newly observed containers in the order of the container names.
+	for _, name := range sortedKeys(observed) {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		resources := observed[name]
+		merged = append(merged, execution.ContainerResourceUsage{
+			Name:        name,
+			CPU:         maxQuantity(nil, resources, corev1.ResourceCPU),
+			Memory:      maxQuantity(nil, resources, corev1.ResourceMemory),
+			Samples:     1,
+			LastUpdated: now,
+		})
+	}
+
+	return merged
+}
+
+// ApplyResourceRecommendations returns a copy of the PodTemplateSpec with
+// resource requests of each container set to its recorded peak usage, with an
+// additional margin in percent. Requests will not be set higher than the
+// container's limits, and containers without any recorded usage are left
+// unchanged.
+func ApplyResourceRecommendations(
+	template corev1.PodTemplateSpec,
+	usages []execution.ContainerResourceUsage,
+	marginPercent int64,
+) corev1.PodTemplateSpec {
+	newTemplate := template.DeepCopy()
+	usageMap := make(map[string]execution.ContainerResourceUsage, len(usages))
+	for _, usage := range usages {
+		usageMap[usage.Name] = usage
+	}
+
+	for i, container := range newTemplate.Spec.Containers {
+		usage, ok := usageMap[container.Name]
+		if !ok {
+			continue
+		}
+
+		recommendations := corev1.ResourceList{}
+		if usage.CPU != nil {
+			milli := usage.CPU.MilliValue() * (100 + marginPercent) / 100
+			recommendations[corev1.ResourceCPU] = *resource.NewMilliQuantity(milli, resource.DecimalSI)
+		}
+		if usage.Memory != nil {
+			value := usage.Memory.Value() * (100 + marginPercent) / 100
+			recommendations[corev1.ResourceMemory] = *resource.NewQuantity(value, resource.BinarySI)
+		}
+
+		for name, quantity := range recommendations {
+			if quantity.IsZero() {
+				continue
+			}
+			if limit, ok := container.Resources.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+				quantity = limit
+			}
+			if newTemplate.Spec.Containers[i].Resources.Requests == nil {
+				newTemplate.Spec.Containers[i].Resources.Requests = corev1.ResourceList{}
+			}
+			newTemplate.Spec.Containers[i].Resources.Requests[name] = quantity
+		}
+	}
+
+	return *newTemplate
+}
+
+func maxQuantity(existing *resource.Quantity, resources corev1.ResourceList, name corev1.ResourceName) *resource.Quantity {
+	observed, ok := resources[name]
+	if !ok {
+		return existing
+	}
+	if existing == nil || observed.Cmp(*existing) > 0 {
+		return &observed
+	}
+	return existing
+}
+
+func sortedKeys(m map[string]corev1.ResourceList) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig_test
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestMergeResourceUsage(t *testing.T) {
+	now := testutils.Mkmtime("2021-02-09T04:06:00Z")
+	existing := []execution.ContainerResourceUsage{
+		{
+			Name:    "main",
+			CPU:     quantityPtr("500m"),
+			Memory:  quantityPtr("128Mi"),
+			Samples: 3,
+		},
+		{
+			Name:    "unobserved",
+			CPU:     quantityPtr("100m"),
+			Samples: 1,
+		},
+	}
+	observed := map[string]corev1.ResourceList{
+		"main": {
+			corev1.ResourceCPU:    resource.MustParse("250m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+		"sidecar": {
+			corev1.ResourceCPU: resource.MustParse("10m"),
+		},
+	}
+
+	got := jobconfig.MergeResourceUsage(existing, observed, now)
+	if len(got) != 3 {
+		t.Fatalf("MergeResourceUsage() returned %v items, want 3", len(got))
+	}
+	assertUsage(t, got[0], "main", "500m", "256Mi", 4)
+	assertUsage(t, got[1], "unobserved", "100m", "", 1)
+	assertUsage(t, got[2], "sidecar", "10m", "", 1)
+	if !got[0].LastUpdated.Equal(&now) || !got[1].LastUpdated.IsZero() {
+		t.Errorf("MergeResourceUsage() did not update LastUpdated correctly")
+	}
+}
+
+func TestApplyResourceRecommendations(t *testing.T) {
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("200Mi"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+				},
+			},
+		},
+	}
+	usages := []execution.ContainerResourceUsage{
+		{
+			Name:   "main",
+			CPU:    quantityPtr("500m"),
+			Memory: quantityPtr("256Mi"),
+		},
+	}
+
+	got := jobconfig.ApplyResourceRecommendations(template, usages, 20)
+	requests := got.Spec.Containers[0].Resources.Requests
+	if cpu := requests[corev1.ResourceCPU]; cpu.String() != "600m" {
+		t.Errorf("ApplyResourceRecommendations() cpu = %v, want 600m", cpu.String())
+	}
+	if memory := requests[corev1.ResourceMemory]; memory.String() != "200Mi" {
+		t.Errorf("ApplyResourceRecommendations() memory = %v, want 200Mi", memory.String())
+	}
+	if len(got.Spec.Containers[1].Resources.Requests) != 0 {
+		t.Errorf("ApplyResourceRecommendations() should not set requests for container without usage")
+	}
+	if cpu := template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU]; cpu.String() != "4" {
+		t.Errorf("ApplyResourceRecommendations() should not mutate original template")
+	}
+}
+
+func assertUsage(t *testing.T, usage execution.ContainerResourceUsage, name, cpu, memory string, samples int64) {
+	t.Helper()
+	if usage.Name != name {
+		t.Errorf("name = %v, want %v", usage.Name, name)
+	}
+	if got := quantityString(usage.CPU); got != cpu {
+		t.Errorf("%v cpu = %v, want %v", name, got, cpu)
+	}
+	if got := quantityString(usage.Memory); got != memory {
+		t.Errorf("%v memory = %v, want %v", name, got, memory)
+	}
+	if usage.Samples != samples {
+		t.Errorf("%v samples = %v, want %v", name, usage.Samples, samples)
+	}
+}
+
+func quantityPtr(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+	return &quantity
+}
+
+func quantityString(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return ""
+	}
+	return quantity.String()
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// UpdateJobSummary updates the cumulative Job counters, the number of
+// consecutive failures and last run summaries of a JobConfigStatus from the list
+// of all of the JobConfig's Jobs.
+//
+// Since Jobs may be deleted after they are finished, the counters cannot be
+// computed from the list of Jobs alone. Instead, the last started, succeeded
+// and failed times are used as high watermarks, such that only Jobs which
+// started or finished after the watermark are added to the counters. Jobs
+// which started or finished at exactly the same time as the watermark but were
+// not yet observed when the watermark was last updated will not be counted.
+func UpdateJobSummary(status *execution.JobConfigStatus, rjs []*execution.Job) {
+	lastStarted := status.LastStarted
+	lastSucceeded := status.LastSucceeded
+	lastFailed := status.LastFailed
+
+	// Newly counted finished Jobs, used to update the number of consecutive failures.
+	var newlyFinished []*execution.JobConditionFinished
+
+	for _, rj := range rjs {
+		if startTime := rj.Status.StartTime; !startTime.IsZero() && !isCounted(lastStarted, startTime) {
+			status.TotalStarted++
+			status.LastStarted = ktime.TimeMax(status.LastStarted, startTime)
+		}
+
+		finished := rj.Status.Condition.Finished
+		if finished == nil {
+			continue
+		}
+		finishTime := finished.FinishedAt.DeepCopy()
+		switch {
+		case finished.Result == execution.JobResultSuccess:
+			if !isCounted(lastSucceeded, finishTime) {
+				status.TotalSucceeded++
+				status.LastSucceeded = ktime.TimeMax(status.LastSucceeded, finishTime)
+				newlyFinished = append(newlyFinished, finished)
+			}
+		case finished.Result.IsFailed():
+			if !isCounted(lastFailed, finishTime) {
+				status.TotalFailed++
+				status.LastFailed = ktime.TimeMax(status.LastFailed, finishTime)
+				newlyFinished = append(newlyFinished, finished)
+			}
+		}
+	}
+
+	// Apply newly finished Jobs in the order that they finished.
+	sort.SliceStable(newlyFinished, func(i, j int) bool {
+		return newlyFinished[i].FinishedAt.Before(&newlyFinished[j].FinishedAt)
+	})
+	for _, finished := range newlyFinished {
+		if finished.Result.IsFailed() {
+			status.ConsecutiveFailures++
+		} else {
+			status.ConsecutiveFailures = 0
+		}
+	}
+
+	if lastJob := getLastJob(rjs); lastJob != nil {
+		if status.LastJob == nil || status.LastJob.UID == lastJob.UID ||
+			!lastJob.CreationTimestamp.Before(&status.LastJob.CreationTimestamp) {
+			status.LastJob = NewJobSummary(lastJob)
+		}
+	}
+}
+
+// NewJobSummary returns a JobSummary for the given Job.
+func NewJobSummary(rj *execution.Job) *execution.JobSummary {
+	summary := &execution.JobSummary{
+		UID:               rj.GetUID(),
+		Name:              rj.GetName(),
+		CreationTimestamp: rj.GetCreationTimestamp(),
+		Phase:             rj.Status.Phase,
+		StartTime:         rj.Status.StartTime.DeepCopy(),
+	}
+	if finished := rj.Status.Condition.Finished; finished != nil {
+		summary.Result = finished.Result
+		summary.FinishTime = finished.FinishedAt.DeepCopy()
+	}
+	return summary
+}
+
+// getLastJob returns the most recently created Job, or nil if the list is empty.
+func getLastJob(rjs []*execution.Job) *execution.Job {
+	var lastJob *execution.Job
+	for _, rj := range rjs {
+		if lastJob == nil || lastJob.CreationTimestamp.Before(&rj.CreationTimestamp) ||
+			(lastJob.CreationTimestamp.Equal(&rj.CreationTimestamp) && lastJob.Name < rj.Name) {
+			lastJob = rj
+		}
+	}
+	return lastJob
+}
+
+// isCounted returns true if ts is not later than the watermark, which means
+// that it was already added to the counters.
+func isCounted(watermark, ts *metav1.Time) bool {
+	return !watermark.IsZero() && !ts.After(watermark.Time)
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobconfig_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestUpdateJobSummary(t *testing.T) {
+	newJob := func(name, createTime, startTime, finishTime string, result execution.JobResult) *execution.Job {
+		rj := &execution.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				UID:               types.UID("uid-" + name),
+				CreationTimestamp: testutils.Mkmtime(createTime),
+			},
+			Status: execution.JobStatus{
+				Phase: execution.JobQueued,
+			},
+		}
+		if startTime != "" {
+			rj.Status.Phase = execution.JobRunning
+			rj.Status.StartTime = testutils.Mkmtimep(startTime)
+		}
+		if finishTime != "" {
+			rj.Status.Phase = execution.JobSucceeded
+			if result.IsFailed() {
+				rj.Status.Phase = execution.JobRetryLimitExceeded
+			}
+			rj.Status.Condition.Finished = &execution.JobConditionFinished{
+				FinishedAt: testutils.Mkmtime(finishTime),
+				Result:     result,
+			}
+		}
+		return rj
+	}
+
+	job1 := newJob("job1", "2022-04-01T04:00:00Z", "2022-04-01T04:00:01Z", "2022-04-01T04:01:00Z",
+		execution.JobResultSuccess)
+	job2 := newJob("job2", "2022-04-01T05:00:00Z", "2022-04-01T05:00:01Z", "2022-04-01T05:01:00Z",
+		execution.JobResultTaskFailed)
+	job3 := newJob("job3", "2022-04-01T06:00:00Z", "2022-04-01T06:00:01Z", "", "")
+	job4 := newJob("job4", "2022-04-01T07:00:00Z", "", "", "")
+	job5 := newJob("job5", "2022-04-01T08:00:00Z", "2022-04-01T08:00:01Z", "2022-04-01T08:01:00Z",
+		execution.JobResultKilled)
+
+	tests := []struct {
+		name   string
+		status execution.JobConfigStatus
+		rjs    []*execution.Job
+		want   execution.JobConfigStatus
+	}{
+		{
+			name: "no jobs",
+		},
+		{
+			name: "count all jobs",
+			rjs:  []*execution.Job{job1, job2, job3, job4},
+			want: execution.JobConfigStatus{
+				TotalStarted:        3,
+				TotalSucceeded:      1,
+				TotalFailed:         1,
+				ConsecutiveFailures: 1,
+				LastStarted:         testutils.Mkmtimep("2022-04-01T06:00:01Z"),
+				LastSucceeded:       testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				LastFailed:          testutils.Mkmtimep("2022-04-01T05:01:00Z"),
+				LastJob:             jobconfig.NewJobSummary(job4),
+			},
+		},
+		{
+			name: "reset consecutive failures after success",
+			status: execution.JobConfigStatus{
+				TotalStarted:        2,
+				TotalFailed:         2,
+				ConsecutiveFailures: 2,
+				LastStarted:         testutils.Mkmtimep("2022-04-01T03:00:01Z"),
+				LastFailed:          testutils.Mkmtimep("2022-04-01T03:01:00Z"),
+			},
+			rjs: []*execution.Job{job1},
+			want: execution.JobConfigStatus{
+				TotalStarted:   3,
+				TotalSucceeded: 1,
+				TotalFailed:    2,
+				LastStarted:    testutils.Mkmtimep("2022-04-01T04:00:01Z"),
+				LastSucceeded:  testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				LastFailed:     testutils.Mkmtimep("2022-04-01T03:01:00Z"),
+				LastJob:        jobconfig.NewJobSummary(job1),
+			},
+		},
+		{
+			name: "count consecutive failures in order of finish time",
+			rjs:  []*execution.Job{job5, job2, job1},
+			want: execution.JobConfigStatus{
+				TotalStarted:        3,
+				TotalSucceeded:      1,
+				TotalFailed:         2,
+				ConsecutiveFailures: 2,
+				LastStarted:         testutils.Mkmtimep("2022-04-01T08:00:01Z"),
+				LastSucceeded:       testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				LastFailed:          testutils.Mkmtimep("2022-04-01T08:01:00Z"),
+				LastJob:             jobconfig.NewJobSummary(job5),
+			},
+		},
+		{
+			name: "do not count jobs twice",
+			status: execution.JobConfigStatus{
+				TotalStarted:   2,
+				TotalSucceeded: 1,
+				LastStarted:    testutils.Mkmtimep("2022-04-01T05:00:01Z"),
+				LastSucceeded:  testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				LastJob:        jobconfig.NewJobSummary(job2),
+			},
+			rjs: []*execution.Job{job1, job2, job3},
+			want: execution.JobConfigStatus{
+				TotalStarted:        3,
+				TotalSucceeded:      1,
+				TotalFailed:         1,
+				ConsecutiveFailures: 1,
+				LastStarted:         testutils.Mkmtimep("2022-04-01T06:00:01Z"),
+				LastSucceeded:       testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				LastFailed:          testutils.Mkmtimep("2022-04-01T05:01:00Z"),
+				LastJob:             jobconfig.NewJobSummary(job3),
+			},
+		},
+		{
+			name: "keep counters and last job after jobs are deleted",
+			status: execution.JobConfigStatus{
+				TotalStarted:   3,
+				TotalSucceeded: 1,
+				TotalFailed:    1,
+				LastStarted:    testutils.Mkmtimep("2022-04-01T06:00:01Z"),
+				LastSucceeded:  testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				LastFailed:     testutils.Mkmtimep("2022-04-01T05:01:00Z"),
+				LastJob:        jobconfig.NewJobSummary(job3),
+			},
+			rjs: []*execution.Job{job1},
+			want: execution.JobConfigStatus{
+				TotalStarted:   3,
+				TotalSucceeded: 1,
+				TotalFailed:    1,
+				LastStarted:    testutils.Mkmtimep("2022-04-01T06:00:01Z"),
+				LastSucceeded:  testutils.Mkmtimep("2022-04-01T04:01:00Z"),
+				LastFailed:     testutils.Mkmtimep("2022-04-01T05:01:00Z"),
+				LastJob:        jobconfig.NewJobSummary(job3),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status.DeepCopy()
+			jobconfig.UpdateJobSummary(status, tt.rjs)
+			if !cmp.Equal(&tt.want, status) {
+				t.Errorf("UpdateJobSummary() not equal\ndiff = %v", cmp.Diff(&tt.want, status))
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobgroup
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+var (
+	// LabelKeyJobGroup stores the name of the JobGroup in the same namespace that
+	// the Job is a member of.
+	LabelKeyJobGroup = executiongroup.AddGroupToLabel("job-group")
+)
+
+// LabelJobsForJobGroup returns a labels.Set that labels all Jobs for a JobGroup.
+func LabelJobsForJobGroup(rjg *execution.JobGroup) labels.Set {
+	return labels.Set{
+		LabelKeyJobGroup: rjg.GetName(),
+	}
+}
+
+// GetJobGroupName returns the name of the JobGroup that the Job is a member of,
+// or false if the Job is not a member of any JobGroup.
+func GetJobGroupName(rj *execution.Job) (string, bool) {
+	name, ok := rj.Labels[LabelKeyJobGroup]
+	return name, ok && name != ""
+}
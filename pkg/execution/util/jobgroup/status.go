@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobgroup
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+// GetStatus returns the status of a JobGroup, aggregated from its member Jobs.
+func GetStatus(rjg *execution.JobGroup, rjs []*execution.Job) execution.JobGroupStatus {
+	status := execution.JobGroupStatus{
+		Total: int64(len(rjs)),
+	}
+
+	var lastFinished *metav1.Time
+	for _, rj := range rjs {
+		if startTime := rj.Status.StartTime; !startTime.IsZero() {
+			if status.StartTime.IsZero() || startTime.Before(status.StartTime) {
+				status.StartTime = startTime.DeepCopy()
+			}
+		}
+
+		switch {
+		case job.IsQueued(rj):
+			status.Queued++
+			continue
+		case job.IsActive(rj):
+			status.Active++
+			continue
+		case rj.Status.Phase == execution.JobSucceeded:
+			status.Succeeded++
+		case rj.Status.Phase == execution.JobKilled:
+			status.Killed++
+		default:
+			status.Failed++
+		}
+
+		if finished := rj.Status.Condition.Finished; finished != nil {
+			lastFinished = ktime.TimeMax(lastFinished, &finished.FinishedAt)
+		}
+	}
+
+	status.Phase = getPhase(rjg, status)
+	if status.Phase.IsTerminal() {
+		status.CompletionTime = lastFinished.DeepCopy()
+		if status.CompletionTime.IsZero() {
+			status.CompletionTime = rjg.Status.CompletionTime.DeepCopy()
+		}
+		if status.CompletionTime.IsZero() {
+			status.CompletionTime = ktime.Now()
+		}
+	}
+
+	return status
+}
+
+func getPhase(rjg *execution.JobGroup, status execution.JobGroupStatus) execution.JobGroupPhase {
+	switch {
+	case status.Total == 0:
+		return execution.JobGroupPending
+	case status.Queued > 0 || status.Active > 0:
+		if status.StartTime.IsZero() {
+			return execution.JobGroupPending
+		}
+		return execution.JobGroupRunning
+	case rjg.Spec.KillTimestamp != nil && status.Killed > 0:
+		return execution.JobGroupKilled
+	case status.Failed > 0 || status.Killed > 0:
+		return execution.JobGroupFailed
+	}
+	return execution.JobGroupSucceeded
+}
+
+// GetTTLExpiry returns the time that the JobGroup should be deleted, or false if
+// the JobGroup should not be deleted.
+func GetTTLExpiry(rjg *execution.JobGroup) (time.Time, bool) {
+	ttl := rjg.Spec.TTLSecondsAfterFinished
+	if ttl == nil || !rjg.Status.Phase.IsTerminal() || rjg.Status.CompletionTime.IsZero() {
+		return time.Time{}, false
+	}
+	return rjg.Status.CompletionTime.Add(time.Duration(*ttl) * time.Second), true
+}
+
+// ShouldKillJob returns true if the JobGroup's kill timestamp should be
+// propagated to the Job, which is the case if the Job is not yet finished, and
+// it does not already have a kill timestamp that is earlier or has passed.
+func ShouldKillJob(rjg *execution.JobGroup, rj *execution.Job) bool {
+	killTimestamp := rjg.Spec.KillTimestamp
+	if killTimestamp == nil || rj.Status.Phase.IsTerminal() {
+		return false
+	}
+	if jobKillTimestamp := rj.Spec.KillTimestamp; jobKillTimestamp != nil {
+		return killTimestamp.Before(jobKillTimestamp) && ktime.Now().Before(jobKillTimestamp)
+	}
+	return true
+}
@@ -0,0 +1,250 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobgroup_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobgroup"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	startTime1  = "2022-04-01T04:00:00Z"
+	startTime2  = "2022-04-01T04:00:05Z"
+	finishTime1 = "2022-04-01T04:01:00Z"
+	finishTime2 = "2022-04-01T04:02:00Z"
+	killTime    = "2022-04-01T04:03:00Z"
+	laterTime   = "2022-04-01T05:00:00Z"
+	now         = "2022-04-01T04:30:00Z"
+)
+
+func newJob(phase execution.JobPhase, startTime, finishTime string) *execution.Job {
+	rj := &execution.Job{
+		Status: execution.JobStatus{
+			Phase: phase,
+		},
+	}
+	if startTime != "" {
+		rj.Status.StartTime = testutils.Mkmtimep(startTime)
+	}
+	if finishTime != "" {
+		rj.Status.Condition.Finished = &execution.JobConditionFinished{
+			FinishedAt: testutils.Mkmtime(finishTime),
+		}
+	}
+	return rj
+}
+
+func TestGetStatus(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(testutils.Mktime(now))
+
+	killedGroup := &execution.JobGroup{
+		Spec: execution.JobGroupSpec{
+			KillTimestamp: testutils.Mkmtimep(killTime),
+		},
+	}
+
+	tests := []struct {
+		name string
+		rjg  *execution.JobGroup
+		rjs  []*execution.Job
+		want execution.JobGroupStatus
+	}{
+		{
+			name: "no jobs",
+			rjg:  &execution.JobGroup{},
+			want: execution.JobGroupStatus{
+				Phase: execution.JobGroupPending,
+			},
+		},
+		{
+			name: "queued jobs",
+			rjg:  &execution.JobGroup{},
+			rjs: []*execution.Job{
+				newJob(execution.JobQueued, "", ""),
+				newJob(execution.JobQueued, "", ""),
+			},
+			want: execution.JobGroupStatus{
+				Phase:  execution.JobGroupPending,
+				Total:  2,
+				Queued: 2,
+			},
+		},
+		{
+			name: "running jobs",
+			rjg:  &execution.JobGroup{},
+			rjs: []*execution.Job{
+				newJob(execution.JobRunning, startTime2, ""),
+				newJob(execution.JobSucceeded, startTime1, finishTime1),
+				newJob(execution.JobQueued, "", ""),
+			},
+			want: execution.JobGroupStatus{
+				Phase:     execution.JobGroupRunning,
+				Total:     3,
+				Queued:    1,
+				Active:    1,
+				Succeeded: 1,
+				StartTime: testutils.Mkmtimep(startTime1),
+			},
+		},
+		{
+			name: "all jobs succeeded",
+			rjg:  &execution.JobGroup{},
+			rjs: []*execution.Job{
+				newJob(execution.JobSucceeded, startTime2, finishTime2),
+				newJob(execution.JobSucceeded, startTime1, finishTime1),
+			},
+			want: execution.JobGroupStatus{
+				Phase:          execution.JobGroupSucceeded,
+				Total:          2,
+				Succeeded:      2,
+				StartTime:      testutils.Mkmtimep(startTime1),
+				CompletionTime: testutils.Mkmtimep(finishTime2),
+			},
+		},
+		{
+			name: "some jobs failed",
+			rjg:  &execution.JobGroup{},
+			rjs: []*execution.Job{
+				newJob(execution.JobRetryLimitExceeded, startTime2, finishTime2),
+				newJob(execution.JobSucceeded, startTime1, finishTime1),
+			},
+			want: execution.JobGroupStatus{
+				Phase:          execution.JobGroupFailed,
+				Total:          2,
+				Succeeded:      1,
+				Failed:         1,
+				StartTime:      testutils.Mkmtimep(startTime1),
+				CompletionTime: testutils.Mkmtimep(finishTime2),
+			},
+		},
+		{
+			name: "killed jobs without group kill",
+			rjg:  &execution.JobGroup{},
+			rjs: []*execution.Job{
+				newJob(execution.JobKilled, startTime1, finishTime1),
+			},
+			want: execution.JobGroupStatus{
+				Phase:          execution.JobGroupFailed,
+				Total:          1,
+				Killed:         1,
+				StartTime:      testutils.Mkmtimep(startTime1),
+				CompletionTime: testutils.Mkmtimep(finishTime1),
+			},
+		},
+		{
+			name: "killed group",
+			rjg:  killedGroup,
+			rjs: []*execution.Job{
+				newJob(execution.JobKilled, startTime1, killTime),
+				newJob(execution.JobSucceeded, startTime1, finishTime1),
+			},
+			want: execution.JobGroupStatus{
+				Phase:          execution.JobGroupKilled,
+				Total:          2,
+				Succeeded:      1,
+				Killed:         1,
+				StartTime:      testutils.Mkmtimep(startTime1),
+				CompletionTime: testutils.Mkmtimep(killTime),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := jobgroup.GetStatus(tt.rjg, tt.rjs)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("GetStatus() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestShouldKillJob(t *testing.T) {
+	ktime.Clock = clock.NewFakeClock(testutils.Mktime(now))
+
+	tests := []struct {
+		name             string
+		killTimestamp    *metav1.Time
+		jobKillTimestamp *metav1.Time
+		phase            execution.JobPhase
+		want             bool
+	}{
+		{
+			name:  "no kill timestamp",
+			phase: execution.JobRunning,
+		},
+		{
+			name:          "unfinished job",
+			killTimestamp: testutils.Mkmtimep(laterTime),
+			phase:         execution.JobRunning,
+			want:          true,
+		},
+		{
+			name:          "finished job",
+			killTimestamp: testutils.Mkmtimep(laterTime),
+			phase:         execution.JobSucceeded,
+		},
+		{
+			name:             "job has later kill timestamp",
+			killTimestamp:    testutils.Mkmtimep(now),
+			jobKillTimestamp: testutils.Mkmtimep(laterTime),
+			phase:            execution.JobQueued,
+			want:             true,
+		},
+		{
+			name:             "job has earlier kill timestamp",
+			killTimestamp:    testutils.Mkmtimep(laterTime),
+			jobKillTimestamp: testutils.Mkmtimep(now),
+			phase:            execution.JobRunning,
+		},
+		{
+			name:             "job kill timestamp already passed",
+			killTimestamp:    testutils.Mkmtimep(laterTime),
+			jobKillTimestamp: testutils.Mkmtimep(killTime),
+			phase:            execution.JobKilling,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rjg := &execution.JobGroup{
+				Spec: execution.JobGroupSpec{
+					KillTimestamp: tt.killTimestamp,
+				},
+			}
+			rj := &execution.Job{
+				Spec: execution.JobSpec{
+					KillTimestamp: tt.jobKillTimestamp,
+				},
+				Status: execution.JobStatus{
+					Phase: tt.phase,
+				},
+			}
+			if got := jobgroup.ShouldKillJob(rjg, rj); got != tt.want {
+				t.Errorf("ShouldKillJob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
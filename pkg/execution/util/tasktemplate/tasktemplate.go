@@ -0,0 +1,160 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasktemplate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	lister "github.com/furiko-io/furiko/pkg/generated/listers/execution/v1alpha1"
+)
+
+// HasReferences returns true if any task in the JobTemplateSpec references a
+// TaskTemplate.
+func HasReferences(spec *execution.JobTemplateSpec) bool {
+	if spec.Task.TemplateRef != nil {
+		return true
+	}
+	for _, step := range spec.Steps {
+		if step.Task.TemplateRef != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveJobTemplateSpec resolves the TaskTemplate references of all tasks in
+// the JobTemplateSpec in-place, using TaskTemplates from the lister.
+func ResolveJobTemplateSpec(
+	spec *execution.JobTemplateSpec,
+	lister lister.TaskTemplateNamespaceLister,
+	fldPath *field.Path,
+) field.ErrorList {
+	if len(spec.Steps) == 0 {
+		return ResolveTaskSpec(&spec.Task, lister, fldPath.Child("task"))
+	}
+
+	errorList := field.ErrorList{}
+	for i := range spec.Steps {
+		errorList = append(errorList, ResolveTaskSpec(&spec.Steps[i].Task, lister,
+			fldPath.Child("steps").Index(i).Child("task"))...)
+	}
+	return errorList
+}
+
+// ResolveTaskSpec resolves the TaskTemplate reference of a task in-place, using
+// the TaskTemplate from the lister as the base for the task.
+func ResolveTaskSpec(
+	task *execution.JobTaskSpec,
+	lister lister.TaskTemplateNamespaceLister,
+	fldPath *field.Path,
+) field.ErrorList {
+	ref := task.TemplateRef
+	if ref == nil {
+		return nil
+	}
+
+	refPath := fldPath.Child("templateRef", "name")
+	if ref.Name == "" {
+		return field.ErrorList{field.Required(refPath, "")}
+	}
+
+	template, err := lister.Get(ref.Name)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(refPath, ref.Name)}
+		}
+		return field.ErrorList{field.InternalError(refPath, errors.Wrapf(err, "cannot get tasktemplate"))}
+	}
+
+	merged, err := MergeTaskSpec(&template.Spec.Task, task)
+	if err != nil {
+		return field.ErrorList{field.Invalid(refPath, ref.Name, fmt.Sprintf("cannot merge TaskTemplate: %v", err))}
+	}
+
+	*task = *merged
+	return nil
+}
+
+// MergeTaskSpec returns a new JobTaskSpec with overrides merged into base using
+// a strategic merge patch. Fields that are specified in overrides take
+// precedence, and lists with a merge key such as containers and env are merged
+// by their keys. The returned JobTaskSpec never references another TaskTemplate.
+func MergeTaskSpec(base, overrides *execution.JobTaskSpec) (*execution.JobTaskSpec, error) {
+	base = base.DeepCopy()
+	base.TemplateRef = nil
+	overrides = overrides.DeepCopy()
+	overrides.TemplateRef = nil
+
+	original, err := json.Marshal(base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal base")
+	}
+	patch, err := marshalWithoutNulls(overrides)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal overrides")
+	}
+	merged, err := strategicpatch.StrategicMergePatch(original, patch, execution.JobTaskSpec{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot apply strategic merge patch")
+	}
+
+	newTask := &execution.JobTaskSpec{}
+	if err := json.Unmarshal(merged, newTask); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal merged task")
+	}
+	return newTask, nil
+}
+
+// marshalWithoutNulls marshals the value to JSON, removing all null values.
+// Unspecified fields which are not omitted when marshaled (e.g. containers in a
+// PodSpec) would otherwise be interpreted as deletions in a merge patch.
+func marshalWithoutNulls(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	return json.Marshal(removeNulls(obj))
+}
+
+func removeNulls(obj interface{}) interface{} {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if val == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = removeNulls(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = removeNulls(val)
+		}
+	}
+	return obj
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasktemplate_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/util/tasktemplate"
+)
+
+var (
+	taskSpecBase = execution.JobTaskSpec{
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"app": "base",
+				},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: "base",
+				Containers: []corev1.Container{
+					{
+						Name:  "container",
+						Image: "alpine:3.16",
+						Args:  []string{"echo", "base"},
+						Env: []corev1.EnvVar{
+							{Name: "FOO", Value: "foo"},
+						},
+					},
+				},
+			},
+		},
+		PendingTimeoutSeconds: pointer.Int64(300),
+	}
+)
+
+func TestMergeTaskSpec(t *testing.T) {
+	tests := []struct {
+		name      string
+		base      execution.JobTaskSpec
+		overrides execution.JobTaskSpec
+		want      execution.JobTaskSpec
+	}{
+		{
+			name: "no overrides",
+			base: taskSpecBase,
+			overrides: execution.JobTaskSpec{
+				TemplateRef: &execution.TaskTemplateReference{Name: "template"},
+			},
+			want: taskSpecBase,
+		},
+		{
+			name: "override fields",
+			base: taskSpecBase,
+			overrides: execution.JobTaskSpec{
+				TemplateRef: &execution.TaskTemplateReference{Name: "template"},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"team": "platform",
+						},
+					},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "override",
+					},
+				},
+				PendingTimeoutSeconds: pointer.Int64(600),
+			},
+			want: execution.JobTaskSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{
+							"app":  "base",
+							"team": "platform",
+						},
+					},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "override",
+						Containers:         taskSpecBase.Template.Spec.Containers,
+					},
+				},
+				PendingTimeoutSeconds: pointer.Int64(600),
+			},
+		},
+		{
+			name: "merge containers by name",
+			base: taskSpecBase,
+			overrides: execution.JobTaskSpec{
+				TemplateRef: &execution.TaskTemplateReference{Name: "template"},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "container",
+								Args: []string{"echo", "override"},
+								Env: []corev1.EnvVar{
+									{Name: "BAR", Value: "bar"},
+								},
+							},
+							{
+								Name:  "sidecar",
+								Image: "busybox",
+							},
+						},
+					},
+				},
+			},
+			want: execution.JobTaskSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: taskSpecBase.Template.ObjectMeta,
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "base",
+						Containers: []corev1.Container{
+							{
+								Name:  "container",
+								Image: "alpine:3.16",
+								Args:  []string{"echo", "override"},
+								Env: []corev1.EnvVar{
+									{Name: "BAR", Value: "bar"},
+									{Name: "FOO", Value: "foo"},
+								},
+							},
+							{
+								Name:  "sidecar",
+								Image: "busybox",
+							},
+						},
+					},
+				},
+				PendingTimeoutSeconds: pointer.Int64(300),
+			},
+		},
+		{
+			name: "do not resolve nested references",
+			base: func() execution.JobTaskSpec {
+				spec := taskSpecBase.DeepCopy()
+				spec.TemplateRef = &execution.TaskTemplateReference{Name: "other"}
+				return *spec
+			}(),
+			overrides: execution.JobTaskSpec{
+				TemplateRef: &execution.TaskTemplateReference{Name: "template"},
+			},
+			want: taskSpecBase,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tasktemplate.MergeTaskSpec(&tt.base, &tt.overrides)
+			if err != nil {
+				t.Fatalf("MergeTaskSpec() error = %v", err)
+			}
+			if !cmp.Equal(&tt.want, got) {
+				t.Errorf("MergeTaskSpec() not equal\ndiff = %v", cmp.Diff(&tt.want, got))
+			}
+		})
+	}
+}
@@ -47,6 +47,10 @@ var (
 			},
 		},
 	}
+
+	pvcSpecBasic = corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+	}
 )
 
 func TestValidatePodTemplateSpec(t *testing.T) {
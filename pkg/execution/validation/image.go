@@ -0,0 +1,209 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
+)
+
+const (
+	// defaultImageRegistry is the registry that images are pulled from if the
+	// image does not specify one.
+	defaultImageRegistry = "docker.io"
+
+	// defaultImageTag is the tag that is used if the image specifies neither a
+	// tag nor a digest.
+	defaultImageTag = "latest"
+)
+
+// ValidateJobConfigImagePolicy validates the images in a JobConfig's pod
+// templates against the configured image policy. Images which contain context
+// variables cannot be resolved until the Job is created, and are skipped.
+func (v *Validator) ValidateJobConfigImagePolicy(rjc *v1alpha1.JobConfig) field.ErrorList {
+	policy, errs := v.loadImagePolicy()
+	if policy == nil {
+		return errs
+	}
+
+	template := &rjc.Spec.Template.Spec
+	fldPath := field.NewPath("spec", "template", "spec")
+	if len(template.Steps) == 0 {
+		return ValidatePodSpecImages(&template.Task.Template.Spec, policy, fldPath.Child("task", "template", "spec"))
+	}
+
+	allErrs := field.ErrorList{}
+	for i, step := range template.Steps {
+		allErrs = append(allErrs, ValidatePodSpecImages(&step.Task.Template.Spec, policy,
+			fldPath.Child("steps").Index(i).Child("task", "template", "spec"))...)
+	}
+	return allErrs
+}
+
+// ValidateJobImagePolicy validates the images in a Job's pod templates against
+// the configured image policy, after applying patches and substituting job
+// context variables.
+func (v *Validator) ValidateJobImagePolicy(rj *v1alpha1.Job) field.ErrorList {
+	if rj.Spec.Template == nil {
+		return nil
+	}
+	policy, errs := v.loadImagePolicy()
+	if policy == nil {
+		return errs
+	}
+
+	// Patches are validated separately, so we fall back to the original template
+	// if the patches cannot be applied.
+	render := func(template corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+		if patched, err := variablecontext.ApplyPodTemplatePatches(rj, template); err == nil {
+			return patched
+		}
+		return template
+	}
+
+	fldPath := field.NewPath("spec", "template")
+	if len(rj.Spec.Template.Steps) == 0 {
+		newRj := rj.DeepCopy()
+		newRj.Spec.Template.Task.Template = render(rj.Spec.Template.Task.Template)
+		template := variablecontext.SubstitutePodTemplateSpecForJob(newRj)
+		return ValidatePodSpecImages(&template.Spec, policy, fldPath.Child("task", "template", "spec"))
+	}
+
+	allErrs := field.ErrorList{}
+	for i, step := range rj.Spec.Template.Steps {
+		step := step.DeepCopy()
+		step.Task.Template = render(step.Task.Template)
+		template := variablecontext.SubstitutePodTemplateSpecForStep(rj, step)
+		allErrs = append(allErrs, ValidatePodSpecImages(&template.Spec, policy,
+			fldPath.Child("steps").Index(i).Child("task", "template", "spec"))...)
+	}
+	return allErrs
+}
+
+// loadImagePolicy returns the configured image policy, or nil if there is none.
+func (v *Validator) loadImagePolicy() (*configv1alpha1.ImagePolicySpec, field.ErrorList) {
+	cfg, err := v.ctrlContext.Configs().Jobs()
+	if err != nil {
+		return nil, field.ErrorList{
+			field.InternalError(field.NewPath(""), errors.Wrapf(err, "cannot load config")),
+		}
+	}
+	return cfg.ImagePolicy, nil
+}
+
+// ValidatePodSpecImages validates the images of all containers and init
+// containers in a *corev1.PodSpec against an image policy.
+func ValidatePodSpecImages(
+	spec *corev1.PodSpec, policy *configv1alpha1.ImagePolicySpec, fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, container := range spec.InitContainers {
+		allErrs = append(allErrs, ValidateImage(container.Image, policy, fldPath.Child("initContainers").Index(i).Child("image"))...)
+	}
+	for i, container := range spec.Containers {
+		allErrs = append(allErrs, ValidateImage(container.Image, policy, fldPath.Child("containers").Index(i).Child("image"))...)
+	}
+	return allErrs
+}
+
+// ValidateImage validates a single image against an image policy. Images that
+// still contain context variables are not validated.
+func ValidateImage(image string, policy *configv1alpha1.ImagePolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if image == "" || strings.Contains(image, "${") {
+		return allErrs
+	}
+
+	ref := parseImageReference(image)
+
+	if len(policy.AllowedRegistries) > 0 && !ref.matchesAnyRegistry(policy.AllowedRegistries) {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			fmt.Sprintf("image %v is not from an allowed registry: %v", image, strings.Join(policy.AllowedRegistries, ", "))))
+	}
+
+	if policy.RequireDigest && ref.digest == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("image %v must be referenced by digest", image)))
+	}
+
+	if ref.digest == "" {
+		for _, tag := range policy.BlockedTags {
+			if ref.tag == tag {
+				allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("image %v uses blocked tag %v", image, tag)))
+				break
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// imageReference is a parsed container image reference.
+type imageReference struct {
+	// repository is the fully-qualified repository, including the registry.
+	repository string
+	tag        string
+	digest     string
+}
+
+// parseImageReference parses an image in the form of
+// [registry/]repository[:tag][@digest], normalizing the repository to include
+// the default registry if the image does not specify one.
+func parseImageReference(image string) imageReference {
+	var ref imageReference
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.digest = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.tag = name[:i], name[i+1:]
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = defaultImageTag
+	}
+
+	// The first component is only a registry if it looks like a hostname.
+	if i := strings.Index(name, "/"); i < 0 || !isRegistryHost(name[:i]) {
+		name = defaultImageRegistry + "/" + name
+	}
+	ref.repository = name
+	return ref
+}
+
+func isRegistryHost(component string) bool {
+	return strings.ContainsAny(component, ".:") || component == "localhost"
+}
+
+// matchesAnyRegistry returns true if the image's repository is in any of the
+// given registries. Each registry may also include a path prefix, such as
+// "gcr.io/my-project".
+func (r imageReference) matchesAnyRegistry(registries []string) bool {
+	for _, registry := range registries {
+		registry = strings.TrimSuffix(registry, "/")
+		if strings.HasPrefix(r.repository, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/validation"
+)
+
+const (
+	imageDigest = "sha256:8914eb54f968791faf6a8638949e480fef81e697984fba772b3976835194c6d4"
+)
+
+func TestValidateImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		image   string
+		policy  *configv1alpha1.ImagePolicySpec
+		wantErr string
+	}{
+		{
+			name:   "empty policy",
+			image:  "alpine",
+			policy: &configv1alpha1.ImagePolicySpec{},
+		},
+		{
+			name:  "default registry is allowed",
+			image: "alpine:3.16",
+			policy: &configv1alpha1.ImagePolicySpec{
+				AllowedRegistries: []string{"docker.io"},
+			},
+		},
+		{
+			name:  "registry is not allowed",
+			image: "gcr.io/project/alpine:3.16",
+			policy: &configv1alpha1.ImagePolicySpec{
+				AllowedRegistries: []string{"docker.io"},
+			},
+			wantErr: "image: Forbidden: image gcr.io/project/alpine:3.16 is not from an allowed registry: docker.io",
+		},
+		{
+			name:  "registry with path prefix is allowed",
+			image: "gcr.io/project/alpine:3.16",
+			policy: &configv1alpha1.ImagePolicySpec{
+				AllowedRegistries: []string{"gcr.io/project"},
+			},
+		},
+		{
+			name:  "registry with path prefix does not match other paths",
+			image: "gcr.io/project-other/alpine:3.16",
+			policy: &configv1alpha1.ImagePolicySpec{
+				AllowedRegistries: []string{"gcr.io/project"},
+			},
+			wantErr: "image: Forbidden: image gcr.io/project-other/alpine:3.16 is not from an allowed registry",
+		},
+		{
+			name:  "registry with port is allowed",
+			image: "registry.example.com:5000/alpine",
+			policy: &configv1alpha1.ImagePolicySpec{
+				AllowedRegistries: []string{"registry.example.com:5000"},
+			},
+		},
+		{
+			name:  "organization is not treated as registry",
+			image: "library/alpine",
+			policy: &configv1alpha1.ImagePolicySpec{
+				AllowedRegistries: []string{"docker.io"},
+			},
+		},
+		{
+			name:  "digest is required",
+			image: "alpine:3.16",
+			policy: &configv1alpha1.ImagePolicySpec{
+				RequireDigest: true,
+			},
+			wantErr: "image: Forbidden: image alpine:3.16 must be referenced by digest",
+		},
+		{
+			name:  "digest is provided",
+			image: "alpine:3.16@" + imageDigest,
+			policy: &configv1alpha1.ImagePolicySpec{
+				RequireDigest: true,
+			},
+		},
+		{
+			name:  "blocked tag",
+			image: "alpine:latest",
+			policy: &configv1alpha1.ImagePolicySpec{
+				BlockedTags: []string{"latest"},
+			},
+			wantErr: "image: Forbidden: image alpine:latest uses blocked tag latest",
+		},
+		{
+			name:  "missing tag is treated as latest",
+			image: "registry.example.com:5000/alpine",
+			policy: &configv1alpha1.ImagePolicySpec{
+				BlockedTags: []string{"latest"},
+			},
+			wantErr: "image: Forbidden: image registry.example.com:5000/alpine uses blocked tag latest",
+		},
+		{
+			name:  "blocked tag is allowed with digest",
+			image: "alpine:latest@" + imageDigest,
+			policy: &configv1alpha1.ImagePolicySpec{
+				BlockedTags: []string{"latest"},
+			},
+		},
+		{
+			name:  "skip images with context variables",
+			image: "${option.registry}/alpine:${option.tag}",
+			policy: &configv1alpha1.ImagePolicySpec{
+				AllowedRegistries: []string{"docker.io"},
+				RequireDigest:     true,
+				BlockedTags:       []string{"latest"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := validation.ValidateImage(tt.image, tt.policy, field.NewPath("image")).ToAggregate()
+			if checkError(err, tt.wantErr) {
+				t.Errorf("ValidateImage() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
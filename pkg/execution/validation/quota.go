@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	executionlister "github.com/furiko-io/furiko/pkg/generated/listers/execution/v1alpha1"
+)
+
+const (
+	// adhocJobQuotaWindow is the duration over which ad-hoc Jobs are counted
+	// towards the AdhocJobQuota.
+	adhocJobQuotaWindow = time.Hour
+)
+
+// ValidateJobAdhocQuota validates that the user who created an ad-hoc Job has
+// not exceeded the configured AdhocJobQuota. Users are identified using the
+// CreatedBy annotation, which is set by the mutating webhook. Jobs which have
+// been deleted are no longer counted towards the quota. The JobConfig may be
+// nil if the Job does not belong to any JobConfig.
+func (v *Validator) ValidateJobAdhocQuota(rj *v1alpha1.Job, rjc *v1alpha1.JobConfig) field.ErrorList {
+	username := jobutil.GetCreatedBy(rj)
+	if rj.Spec.Type != v1alpha1.JobTypeAdhoc || username == "" {
+		return nil
+	}
+
+	cfg, err := v.ctrlContext.Configs().Jobs()
+	if err != nil {
+		return field.ErrorList{
+			field.InternalError(field.NewPath(""), errors.Wrapf(err, "cannot load config")),
+		}
+	}
+	quota := cfg.AdhocJobQuota
+	if quota == nil {
+		return nil
+	}
+	for _, exempt := range quota.ExemptUsers {
+		if username == exempt {
+			return nil
+		}
+	}
+
+	rjs, err := v.getJobLister(rj.Namespace).List(labels.Everything())
+	if err != nil {
+		return field.ErrorList{
+			field.InternalError(field.NewPath(""), errors.Wrapf(err, "cannot list jobs")),
+		}
+	}
+
+	// Count the number of ad-hoc Jobs created by the same user in the window.
+	since := Clock.Now().Add(-adhocJobQuotaWindow)
+	var countNamespace, countJobConfig int64
+	for _, other := range rjs {
+		if other.Spec.Type != v1alpha1.JobTypeAdhoc || jobutil.GetCreatedBy(other) != username ||
+			other.CreationTimestamp.Time.Before(since) {
+			continue
+		}
+		countNamespace++
+		if rjc != nil && other.Labels[jobconfig.LabelKeyJobConfigUID] == string(rjc.UID) {
+			countJobConfig++
+		}
+	}
+
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "type")
+	if max := quota.MaxJobsPerJobConfig; rjc != nil && max != nil && *max > 0 && countJobConfig >= *max {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf(
+			"user %v cannot create more than %v ad-hoc Jobs per hour for JobConfig %v",
+			username, *max, rjc.Name,
+		)))
+	}
+	if max := quota.MaxJobsPerNamespace; max != nil && *max > 0 && countNamespace >= *max {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf(
+			"user %v cannot create more than %v ad-hoc Jobs per hour in namespace %v",
+			username, *max, rj.Namespace,
+		)))
+	}
+
+	return allErrs
+}
+
+func (v *Validator) getJobLister(namespace string) executionlister.JobNamespaceLister {
+	return v.ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs().Lister().Jobs(namespace)
+}
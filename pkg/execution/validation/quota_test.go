@@ -0,0 +1,242 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/execution/validation"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+const (
+	quotaUser      = "user@example.com"
+	quotaOtherUser = "system:serviceaccount:default:other"
+)
+
+func TestValidateJobAdhocQuota(t *testing.T) {
+	rjc := &v1alpha1.JobConfig{
+		ObjectMeta: objectMetaJobConfig,
+	}
+
+	newJob := func(name, createdBy string, jobType v1alpha1.JobType, rjc *v1alpha1.JobConfig, createTime string) *v1alpha1.Job {
+		rj := &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         metav1.NamespaceDefault,
+				CreationTimestamp: testutils.Mkmtime(createTime),
+			},
+			Spec: v1alpha1.JobSpec{
+				Type: jobType,
+			},
+		}
+		if createdBy != "" {
+			rj.Annotations = map[string]string{
+				jobutil.AnnotationKeyCreatedBy: createdBy,
+			}
+		}
+		if rjc != nil {
+			rj.Labels = map[string]string{
+				jobconfig.LabelKeyJobConfigUID: string(rjc.UID),
+			}
+		}
+		return rj
+	}
+
+	newConfigs := func(quota *configv1alpha1.AdhocJobQuotaSpec) controllercontext.ConfigsMap {
+		return controllercontext.ConfigsMap{
+			configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+				AdhocJobQuota: quota,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		cfgs    controllercontext.ConfigsMap
+		rj      *v1alpha1.Job
+		rjc     *v1alpha1.JobConfig
+		rjs     []*v1alpha1.Job
+		wantErr string
+	}{
+		{
+			name: "no quota",
+			rj:   newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc:  rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+			},
+		},
+		{
+			name: "within quota for JobConfig",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(2),
+			}),
+			rj:  newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+			},
+		},
+		{
+			name: "exceeded quota for JobConfig",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(2),
+			}),
+			rj:  newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+				newJob("job-2", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:01:00Z"),
+			},
+			wantErr: "spec.type: Forbidden: user user@example.com cannot create more than 2 ad-hoc Jobs per hour for JobConfig jobconfig-sample",
+		},
+		{
+			name: "do not count Jobs outside of window",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(2),
+			}),
+			rj:  newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T03:00:00Z"),
+				newJob("job-2", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:01:00Z"),
+			},
+		},
+		{
+			name: "do not count Jobs from other users",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(2),
+			}),
+			rj:  newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaOtherUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+				newJob("job-2", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:01:00Z"),
+			},
+		},
+		{
+			name: "do not count scheduled Jobs",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(2),
+			}),
+			rj:  newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeScheduled, rjc, "2021-02-09T04:00:00Z"),
+				newJob("job-2", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:01:00Z"),
+			},
+		},
+		{
+			name: "exceeded quota for namespace",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(2),
+				MaxJobsPerNamespace: pointer.Int64(2),
+			}),
+			rj: newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, nil, mockStartTime),
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+				newJob("job-2", quotaUser, v1alpha1.JobTypeAdhoc, nil, "2021-02-09T04:01:00Z"),
+			},
+			wantErr: "spec.type: Forbidden: user user@example.com cannot create more than 2 ad-hoc Jobs per hour in namespace default",
+		},
+		{
+			name: "disabled quota",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(0),
+				MaxJobsPerNamespace: pointer.Int64(0),
+			}),
+			rj:  newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+			},
+		},
+		{
+			name: "exempt user",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(1),
+				ExemptUsers:         []string{quotaUser},
+			}),
+			rj:  newJob("job", quotaUser, v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", quotaUser, v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+			},
+		},
+		{
+			name: "skip Job without creator",
+			cfgs: newConfigs(&configv1alpha1.AdhocJobQuotaSpec{
+				MaxJobsPerJobConfig: pointer.Int64(1),
+			}),
+			rj:  newJob("job", "", v1alpha1.JobTypeAdhoc, rjc, mockStartTime),
+			rjc: rjc,
+			rjs: []*v1alpha1.Job{
+				newJob("job-1", "", v1alpha1.JobTypeAdhoc, rjc, "2021-02-09T04:00:00Z"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			validation.Clock = clock.NewFakeClock(testutils.Mktime(mockStartTime))
+			validator := setupWithJobs(t, tt.cfgs, tt.rjs)
+			err := validator.ValidateJobAdhocQuota(tt.rj, tt.rjc).ToAggregate()
+			if checkError(err, tt.wantErr) {
+				t.Errorf("ValidateJobAdhocQuota() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func setupWithJobs(t *testing.T, cfgs controllercontext.ConfigsMap, rjs []*v1alpha1.Job) *validation.Validator {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrlContext := mock.NewContext()
+	ctrlContext.MockConfigs().SetConfigs(cfgs)
+	hasSynced := ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs().Informer().HasSynced
+	if err := ctrlContext.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rj := range rjs {
+		_, err := ctrlContext.Clientsets().Furiko().ExecutionV1alpha1().Jobs(rj.Namespace).
+			Create(ctx, rj, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("cannot create Job: %v", err)
+		}
+	}
+
+	if !cache.WaitForCacheSync(ctx.Done(), hasSynced) {
+		t.Fatalf("cannot sync caches")
+	}
+
+	return validation.NewValidator(ctrlContext)
+}
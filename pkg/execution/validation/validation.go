@@ -19,13 +19,19 @@ package validation
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
 
+	"github.com/furiko-io/cronexpr"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
 	apivalidation "k8s.io/kubernetes/pkg/apis/core/validation"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
@@ -34,9 +40,13 @@ import (
 	"github.com/furiko-io/furiko/pkg/core/tzutils"
 	"github.com/furiko-io/furiko/pkg/core/validation"
 	"github.com/furiko-io/furiko/pkg/execution/util/cronparser"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/execution/util/tasktemplate"
+	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
 	executionlister "github.com/furiko-io/furiko/pkg/generated/listers/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/utils/jsonyaml"
 )
 
 const (
@@ -49,6 +59,8 @@ const (
 	// The Job creates tasks with a suffix like `.20`, so the name has to be 60
 	// characters.
 	maxJobNameLen = apimachineryvalidation.DNS1035LabelMaxLength - 3
+
+	msgImmutable = "field is immutable"
 )
 
 var (
@@ -68,19 +80,31 @@ func NewValidator(ctrlContext controllercontext.Context) *Validator {
 func (v *Validator) ValidateJobConfig(rjc *v1alpha1.JobConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validation.ValidateMaxLength(rjc.Name, maxJobConfigNameLen, field.NewPath("metadata").Child("name"))...)
-	allErrs = append(allErrs, v.ValidateJobConfigSpec(&rjc.Spec, field.NewPath("spec"))...)
+
+	// Validate the template with any TaskTemplate references resolved, since the
+	// task may only specify overrides on top of the TaskTemplate.
+	spec := rjc.Spec.DeepCopy()
+	if errs := tasktemplate.ResolveJobTemplateSpec(&spec.Template.Spec, v.getTaskTemplateLister(rjc.Namespace),
+		field.NewPath("spec", "template", "spec")); len(errs) > 0 {
+		return append(allErrs, errs...)
+	}
+
+	allErrs = append(allErrs, v.ValidateJobConfigSpec(spec, &rjc.ObjectMeta, field.NewPath("spec"))...)
 	return allErrs
 }
 
 // ValidateJobConfigCreate validates creation of a *v1alpha1.JobConfig.
 func (v *Validator) ValidateJobConfigCreate(rjc *v1alpha1.JobConfig) field.ErrorList {
-	// No special handling.
-	return nil
+	return v.ValidateJobConfigImagePolicy(rjc)
 }
 
 // ValidateJobConfigUpdate validates update of a *v1alpha1.JobConfig.
 func (v *Validator) ValidateJobConfigUpdate(oldRjc, rjc *v1alpha1.JobConfig) field.ErrorList {
-	// No special handling.
+	// Only enforce the image policy if the template was changed, so that existing
+	// JobConfigs can still be updated after the policy is made stricter.
+	if !reflect.DeepEqual(oldRjc.Spec.Template, rjc.Spec.Template) {
+		return v.ValidateJobConfigImagePolicy(rjc)
+	}
 	return nil
 }
 
@@ -107,6 +131,14 @@ func (v *Validator) ValidateJobCreate(rj *v1alpha1.Job) field.ErrorList {
 		return errs
 	}
 
+	if errs := v.ValidateJobImagePolicy(rj); len(errs) > 0 {
+		return errs
+	}
+
+	if errs := v.ValidateJobAdhocQuota(rj, rjc); len(errs) > 0 {
+		return errs
+	}
+
 	cfg, err := v.ctrlContext.Configs().JobConfigs()
 	if err != nil {
 		return field.ErrorList{
@@ -142,6 +174,10 @@ func (v *Validator) validateJobCreateWithJobConfig(
 		))
 	}
 
+	// Validate optionValues against the JobConfig's OptionSpec.
+	allErrs = append(allErrs, v.ValidateJobOptionValues(rj.Spec.OptionValues, rjc.Spec.Option,
+		field.NewPath("spec", "optionValues"))...)
+
 	// Cannot enqueue beyond max queue length.
 	if max := cfg.MaxEnqueuedJobs; max != nil && rjc != nil && rjc.Status.Queued >= *max {
 		allErrs = append(allErrs, field.Forbidden(
@@ -153,16 +189,55 @@ func (v *Validator) validateJobCreateWithJobConfig(
 	return allErrs
 }
 
+// ValidateJobOptionValues validates the optionValues of a Job against the
+// OptionSpec of its JobConfig.
+func (v *Validator) ValidateJobOptionValues(optionValues string, spec *v1alpha1.OptionSpec, fldPath *field.Path) field.ErrorList {
+	values := make(map[string]interface{})
+	if len(optionValues) > 0 {
+		if err := jsonyaml.UnmarshalString(optionValues, &values); err != nil {
+			err := errors.Wrapf(err, "cannot unmarshal as json or yaml")
+			return field.ErrorList{field.Invalid(fldPath, optionValues, err.Error())}
+		}
+	}
+	return options.ValidateOptionValues(values, spec, fldPath)
+}
+
 // ValidateJobUpdate validates update of a *v1alpha1.Job.
 func (v *Validator) ValidateJobUpdate(oldRj, rj *v1alpha1.Job) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, v.ValidateJobMetadataUpdate(&oldRj.ObjectMeta, &rj.ObjectMeta, field.NewPath("metadata"))...)
 	allErrs = append(allErrs, v.ValidateJobSpecUpdate(&oldRj.Spec, &rj.Spec, field.NewPath("spec"))...)
 
+	// Immutable fields may only be updated as a break-glass edit.
+	if !jobutil.IsImmutableUpdateAllowed(rj) {
+		allErrs = append(allErrs, v.ValidateJobImmutable(oldRj, rj)...)
+	}
+
+	return allErrs
+}
+
+// ValidateJobImmutable validates that immutable fields of a *v1alpha1.Job are
+// not updated. These checks can be bypassed using the AllowImmutableUpdate
+// annotation.
+func (v *Validator) ValidateJobImmutable(oldRj, rj *v1alpha1.Job) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+	allErrs = append(allErrs, validation.ValidateImmutableField(rj.Spec.ConfigName, oldRj.Spec.ConfigName, fldPath.Child("configName"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(rj.Spec.Type, oldRj.Spec.Type, fldPath.Child("type"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(rj.Spec.OptionValues, oldRj.Spec.OptionValues, fldPath.Child("optionValues"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(rj.Spec.Substitutions, oldRj.Spec.Substitutions, fldPath.Child("substitutions"), msgImmutable)...)
+
 	// Once Job is started, not allowed to update startPolicy.
 	if !rj.Status.StartTime.IsZero() {
 		allErrs = append(allErrs, validation.ValidateImmutableField(rj.Spec.StartPolicy, oldRj.Spec.StartPolicy,
-			field.NewPath("spec.startPolicy"), "cannot update startPolicy once Job is started")...)
+			fldPath.Child("startPolicy"), "cannot update startPolicy once Job is started")...)
+	}
+
+	// Once Job is started, not allowed to update template. The template of a
+	// queued Job may be refreshed from its JobConfig before it is started.
+	if !oldRj.Status.StartTime.IsZero() {
+		allErrs = append(allErrs, v.ValidateJobTemplateSpecImmutable(oldRj.Spec.Template, rj.Spec.Template,
+			fldPath.Child("template"))...)
 	}
 
 	return allErrs
@@ -173,22 +248,35 @@ func (v *Validator) ValidateJobMetadataUpdate(oldMetadata, metadata *metav1.Obje
 	allErrs := field.ErrorList{}
 
 	// Cannot update JobConfig UID label.
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(
+	allErrs = append(allErrs, validation.ValidateImmutableField(
 		metadata.Labels[jobconfig.LabelKeyJobConfigUID],
 		oldMetadata.Labels[jobconfig.LabelKeyJobConfigUID],
 		fldPath.Child("labels").Key(jobconfig.LabelKeyJobConfigUID),
+		msgImmutable,
+	)...)
+
+	// Cannot update the user who created the Job.
+	allErrs = append(allErrs, validation.ValidateImmutableField(
+		metadata.Annotations[jobutil.AnnotationKeyCreatedBy],
+		oldMetadata.Annotations[jobutil.AnnotationKeyCreatedBy],
+		fldPath.Child("annotations").Key(jobutil.AnnotationKeyCreatedBy),
+		msgImmutable,
 	)...)
 
 	return allErrs
 }
 
 // ValidateJobConfigSpec validates a *v1alpha1.JobConfigSpec.
-func (v *Validator) ValidateJobConfigSpec(spec *v1alpha1.JobConfigSpec, fldPath *field.Path) field.ErrorList {
+func (v *Validator) ValidateJobConfigSpec(spec *v1alpha1.JobConfigSpec, metadata *metav1.ObjectMeta, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, v.ValidateJobTemplate(&spec.Template, fldPath.Child("template"))...)
 	allErrs = append(allErrs, v.ValidateConcurrencySpec(spec.Concurrency, fldPath.Child("concurrency"))...)
-	allErrs = append(allErrs, v.ValidateScheduleSpec(spec.Schedule, fldPath.Child("schedule"))...)
+	allErrs = append(allErrs, v.ValidateScheduleSpec(spec.Schedule, metadata, fldPath.Child("schedule"))...)
 	allErrs = append(allErrs, v.ValidateOptionSpec(spec.Option, fldPath.Child("option"))...)
+	allErrs = append(allErrs, v.ValidateTemplatePolicy(spec.TemplatePolicy, fldPath.Child("templatePolicy"))...)
+	allErrs = append(allErrs, v.ValidateJobExecutionOverrides(spec.ExecutionOverrides, fldPath.Child("executionOverrides"))...)
+	allErrs = append(allErrs, v.ValidateNotificationSpecs(spec.Notifications, fldPath.Child("notifications"))...)
+	allErrs = append(allErrs, v.ValidateAlertSpecs(spec.Alerts, fldPath.Child("alerts"))...)
 	return allErrs
 }
 
@@ -203,11 +291,33 @@ func (v *Validator) ValidateJobTemplate(spec *v1alpha1.JobTemplate, fldPath *fie
 func (v *Validator) ValidateConcurrencySpec(spec v1alpha1.ConcurrencySpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, v.ValidateConcurrencyPolicy(spec.Policy, fldPath.Child("policy"))...)
+	if spec.ExpireAfterSeconds != nil {
+		allErrs = append(allErrs, validation.ValidateGT(*spec.ExpireAfterSeconds, 0, fldPath.Child("expireAfterSeconds"))...)
+	}
+	if spec.Group != nil {
+		allErrs = append(allErrs, v.ValidateConcurrencyGroupSpec(spec.Group, fldPath.Child("group"))...)
+	}
+	return allErrs
+}
+
+// ValidateConcurrencyGroupSpec validates a *v1alpha1.ConcurrencyGroupSpec.
+func (v *Validator) ValidateConcurrencyGroupSpec(spec *v1alpha1.ConcurrencyGroupSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range apimachineryvalidation.IsDNS1123Label(spec.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), spec.Name, msg))
+		}
+	}
+	if spec.MaxConcurrency != nil {
+		allErrs = append(allErrs, validation.ValidateGT(*spec.MaxConcurrency, 0, fldPath.Child("maxConcurrency"))...)
+	}
 	return allErrs
 }
 
 // ValidateScheduleSpec validates a *v1alpha1.ScheduleSpec.
-func (v *Validator) ValidateScheduleSpec(spec *v1alpha1.ScheduleSpec, fldPath *field.Path) field.ErrorList {
+func (v *Validator) ValidateScheduleSpec(spec *v1alpha1.ScheduleSpec, metadata *metav1.ObjectMeta, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if spec == nil {
 		return allErrs
@@ -219,7 +329,7 @@ func (v *Validator) ValidateScheduleSpec(spec *v1alpha1.ScheduleSpec, fldPath *f
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("cron"), "may not specify more than 1 schedule type"))
 		} else {
 			numScheduleTypes++
-			allErrs = append(allErrs, v.ValidateCronSchedule(spec.Cron, fldPath.Child("cron"))...)
+			allErrs = append(allErrs, v.ValidateCronSchedule(spec.Cron, metadata, fldPath.Child("cron"))...)
 		}
 	}
 
@@ -230,11 +340,12 @@ func (v *Validator) ValidateScheduleSpec(spec *v1alpha1.ScheduleSpec, fldPath *f
 	return allErrs
 }
 
-// ValidateCronSchedule validates a *v1alpha1.CronSchedule.
-func (v *Validator) ValidateCronSchedule(spec *v1alpha1.CronSchedule, fldPath *field.Path) field.ErrorList {
+// ValidateCronSchedule validates a *v1alpha1.CronSchedule for the JobConfig
+// with the given metadata.
+func (v *Validator) ValidateCronSchedule(spec *v1alpha1.CronSchedule, metadata *metav1.ObjectMeta, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(spec.Expression) > 0 {
-		allErrs = append(allErrs, v.ValidateCronScheduleExpression(spec.Expression, fldPath.Child("expression"))...)
+		allErrs = append(allErrs, v.ValidateCronScheduleExpression(spec.Expression, metadata, fldPath.Child("expression"))...)
 	}
 	if len(spec.Timezone) > 0 {
 		allErrs = append(allErrs, v.ValidateTimezone(spec.Timezone, fldPath.Child("timezone"))...)
@@ -263,26 +374,215 @@ func (v *Validator) ValidateConcurrencyPolicy(concurrencyPolicy v1alpha1.Concurr
 	return allErrs
 }
 
-// ValidateCronScheduleExpression validates a CronSchedule expression.
-func (v *Validator) ValidateCronScheduleExpression(cronSchedule string, fldPath *field.Path) field.ErrorList {
+// ValidateTemplatePolicy validates a v1alpha1.TemplatePolicy.
+func (v *Validator) ValidateTemplatePolicy(templatePolicy v1alpha1.TemplatePolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch templatePolicy {
+	case "", v1alpha1.TemplatePolicySnapshot, v1alpha1.TemplatePolicyReference:
+		break
+	default:
+		validValues := []string{
+			string(v1alpha1.TemplatePolicySnapshot),
+			string(v1alpha1.TemplatePolicyReference),
+		}
+		allErrs = append(allErrs, field.NotSupported(fldPath, templatePolicy, validValues))
+	}
+	return allErrs
+}
+
+// ValidateJobExecutionOverrides validates a *v1alpha1.JobExecutionOverrides.
+func (v *Validator) ValidateJobExecutionOverrides(spec *v1alpha1.JobExecutionOverrides, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec == nil {
+		return allErrs
+	}
+	if spec.TTLSecondsAfterFinished != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.TTLSecondsAfterFinished, fldPath.Child("ttlSecondsAfterFinished"))...)
+	}
+	if spec.PendingTimeoutSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.PendingTimeoutSeconds, fldPath.Child("pendingTimeoutSeconds"))...)
+	}
+	if spec.DeleteKillingTasksTimeoutSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.DeleteKillingTasksTimeoutSeconds, fldPath.Child("deleteKillingTasksTimeoutSeconds"))...)
+	}
+	if spec.ForceDeleteKillingTasksTimeoutSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.ForceDeleteKillingTasksTimeoutSeconds, fldPath.Child("forceDeleteKillingTasksTimeoutSeconds"))...)
+	}
+	return allErrs
+}
+
+// ValidateNotificationSpecs validates a list of v1alpha1.NotificationSpec.
+func (v *Validator) ValidateNotificationSpecs(specs []v1alpha1.NotificationSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, spec := range specs {
+		allErrs = append(allErrs, v.ValidateNotificationSpec(spec, fldPath.Index(i))...)
+	}
+	return allErrs
+}
+
+// ValidateNotificationSpec validates a v1alpha1.NotificationSpec.
+func (v *Validator) ValidateNotificationSpec(spec v1alpha1.NotificationSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch spec.Type {
+	case v1alpha1.NotificationTypeSlack, v1alpha1.NotificationTypeTeams:
+		allErrs = append(allErrs, v.ValidateWebhookURLSecretRef(spec.WebhookURLSecretRef, fldPath.Child("webhookURLSecretRef"))...)
+	case v1alpha1.NotificationTypeEmail:
+		allErrs = append(allErrs, v.ValidateEmailNotificationSpec(spec.Email, fldPath.Child("email"))...)
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), ""))
+	default:
+		validValues := []string{
+			string(v1alpha1.NotificationTypeSlack),
+			string(v1alpha1.NotificationTypeTeams),
+			string(v1alpha1.NotificationTypeEmail),
+		}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), spec.Type, validValues))
+	}
+
+	var longRunning bool
+	if len(spec.On) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("on"), "at least one trigger must be specified"))
+	}
+	for i, trigger := range spec.On {
+		switch trigger {
+		case v1alpha1.NotificationTriggerSuccess, v1alpha1.NotificationTriggerFailure:
+			break
+		case v1alpha1.NotificationTriggerLongRunning:
+			longRunning = true
+		default:
+			validValues := []string{
+				string(v1alpha1.NotificationTriggerSuccess),
+				string(v1alpha1.NotificationTriggerFailure),
+				string(v1alpha1.NotificationTriggerLongRunning),
+			}
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("on").Index(i), trigger, validValues))
+		}
+	}
+
+	thresholdPath := fldPath.Child("longRunningThresholdSeconds")
+	if spec.LongRunningThresholdSeconds != nil {
+		allErrs = append(allErrs, validation.ValidateGT(*spec.LongRunningThresholdSeconds, 0, thresholdPath)...)
+	} else if longRunning {
+		allErrs = append(allErrs, field.Required(thresholdPath, "required for LongRunning trigger"))
+	}
+
+	return allErrs
+}
+
+// ValidateWebhookURLSecretRef validates a *corev1.SecretKeySelector that refers
+// to a webhook URL.
+func (v *Validator) ValidateWebhookURLSecretRef(ref *corev1.SecretKeySelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ref == nil {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+		return allErrs
+	}
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+	if ref.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), ""))
+	}
+	return allErrs
+}
+
+// ValidateEmailNotificationSpec validates a *v1alpha1.EmailNotificationSpec.
+func (v *Validator) ValidateEmailNotificationSpec(spec *v1alpha1.EmailNotificationSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec == nil {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+		return allErrs
+	}
+	if len(spec.To) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("to"), "at least one recipient must be specified"))
+	}
+	for i, address := range spec.To {
+		if _, err := mail.ParseAddress(address); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("to").Index(i), address, err.Error()))
+		}
+	}
+	for i, address := range spec.Cc {
+		if _, err := mail.ParseAddress(address); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cc").Index(i), address, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// ValidateAlertSpecs validates a list of v1alpha1.AlertSpec.
+func (v *Validator) ValidateAlertSpecs(specs []v1alpha1.AlertSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, spec := range specs {
+		allErrs = append(allErrs, v.ValidateAlertSpec(spec, fldPath.Index(i))...)
+	}
+	return allErrs
+}
+
+// ValidateAlertSpec validates a v1alpha1.AlertSpec.
+func (v *Validator) ValidateAlertSpec(spec v1alpha1.AlertSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch spec.Type {
+	case v1alpha1.AlertTypePagerDuty, v1alpha1.AlertTypeOpsgenie:
+		break
+	case "":
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), ""))
+	default:
+		validValues := []string{
+			string(v1alpha1.AlertTypePagerDuty),
+			string(v1alpha1.AlertTypeOpsgenie),
+		}
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), spec.Type, validValues))
+	}
+
+	refPath := fldPath.Child("routingKeySecretRef")
+	if spec.RoutingKeySecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+	}
+	if spec.RoutingKeySecretRef.Key == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("key"), ""))
+	}
+
+	allErrs = append(allErrs, validation.ValidateGTE(spec.ConsecutiveFailures, 0, fldPath.Child("consecutiveFailures"))...)
+
+	return allErrs
+}
+
+// ValidateCronScheduleExpression validates a CronSchedule expression for the
+// JobConfig with the given metadata. The expression is parsed in exactly the
+// same way as the cron controller would, using the namespace's Cron config.
+func (v *Validator) ValidateCronScheduleExpression(cronSchedule string, metadata *metav1.ObjectMeta, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	// Load the Cron config to determine how to parse the cron expression.
-	cfg, err := v.ctrlContext.Configs().Cron()
+	cfg, err := v.ctrlContext.Configs().CronForNamespace(metadata.Namespace)
 	if err != nil {
 		allErrs = append(allErrs, field.InternalError(fldPath, errors.Wrapf(err, "cannot load cron config")))
 		return allErrs
 	}
-	parser := cronparser.NewParser(cfg)
 
-	// Ok to use an empty hash ID for validation.
-	if _, err := parser.Parse(cronSchedule, ""); err != nil {
-		allErrs = append(allErrs, field.Invalid(fldPath, cronSchedule, "cannot parse cron schedule"))
+	if _, err := parseCronExpression(cfg, cronSchedule, metadata); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, cronSchedule, fmt.Sprintf("cannot parse cron schedule: %v", err)))
 	}
 
 	return allErrs
 }
 
+// parseCronExpression parses the cron expression with the JobConfig's
+// namespaced name as the hash ID, which is the same as the cron controller.
+func parseCronExpression(
+	cfg *configv1alpha1.CronExecutionConfig,
+	cronSchedule string,
+	metadata *metav1.ObjectMeta,
+) (*cronexpr.Expression, error) {
+	namespacedName, err := cache.MetaNamespaceKeyFunc(metadata)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get namespaced name")
+	}
+	return cronparser.NewParser(cfg).Parse(cronSchedule, namespacedName)
+}
+
 // ValidateTimezone validates a Timezone.
 func (v *Validator) ValidateTimezone(timezone string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -314,27 +614,47 @@ func (v *Validator) ValidateJobSpec(spec *v1alpha1.JobSpec, fldPath *field.Path)
 	if spec.TTLSecondsAfterFinished != nil {
 		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.TTLSecondsAfterFinished, fldPath.Child("ttlSecondsAfterFinished"))...)
 	}
+	if spec.RetainFinishedTasksSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.RetainFinishedTasksSeconds, fldPath.Child("retainFinishedTasksSeconds"))...)
+	}
 	return allErrs
 }
 
 // ValidateJobSpecUpdate validates update of a *v1alpha1.JobSpec.
 func (v *Validator) ValidateJobSpecUpdate(oldSpec, spec *v1alpha1.JobSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(spec.ConfigName, oldSpec.ConfigName, fldPath.Child("configName"))...)
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(spec.Type, oldSpec.Type, fldPath.Child("type"))...)
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(spec.OptionValues, oldSpec.OptionValues, fldPath.Child("optionValues"))...)
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(spec.Substitutions, oldSpec.Substitutions, fldPath.Child("substitutions"))...)
-	allErrs = append(allErrs, v.ValidateJobTemplateSpecImmutable(oldSpec.Template, spec.Template, fldPath.Child("template"))...)
 	allErrs = append(allErrs, v.ValidateKillTimestampUpdate(oldSpec.KillTimestamp, spec.KillTimestamp, fldPath.Child("killTimestamp"))...)
 	return allErrs
 }
 
 // ValidateJobTemplateSpecImmutable validates that fields in a Job's *v1alpha1.JobTemplateSpec are immutable.
+// Only maxRuntimeSeconds and taskDeletionPolicy may be updated, so that a running Job can be given more time or
+// have its tasks orphaned before it is deleted. Any other field not explicitly listed is also immutable.
 func (v *Validator) ValidateJobTemplateSpecImmutable(oldTemplate, template *v1alpha1.JobTemplateSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(template.Task, oldTemplate.Task, fldPath.Child("task"))...)
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(template.MaxAttempts, oldTemplate.MaxAttempts, fldPath.Child("maxAttempts"))...)
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(template.RetryDelaySeconds, oldTemplate.RetryDelaySeconds, fldPath.Child("retryDelaySeconds"))...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.Task, oldTemplate.Task, fldPath.Child("task"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.Steps, oldTemplate.Steps, fldPath.Child("steps"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.MaxAttempts, oldTemplate.MaxAttempts, fldPath.Child("maxAttempts"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.RetryDelaySeconds, oldTemplate.RetryDelaySeconds, fldPath.Child("retryDelaySeconds"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.RetryMode, oldTemplate.RetryMode, fldPath.Child("retryMode"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.MaxInitFailureRetries, oldTemplate.MaxInitFailureRetries, fldPath.Child("maxInitFailureRetries"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.MaxEvictionRetries, oldTemplate.MaxEvictionRetries, fldPath.Child("maxEvictionRetries"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.Patches, oldTemplate.Patches, fldPath.Child("patches"), msgImmutable)...)
+	allErrs = append(allErrs, validation.ValidateImmutableField(template.TaskNameTemplate, oldTemplate.TaskNameTemplate, fldPath.Child("taskNameTemplate"), msgImmutable)...)
+
+	// Catch any remaining fields that were not validated above.
+	if len(allErrs) == 0 {
+		oldRemaining, remaining := oldTemplate.DeepCopy(), template.DeepCopy()
+		for _, t := range []*v1alpha1.JobTemplateSpec{oldRemaining, remaining} {
+			if t == nil {
+				continue
+			}
+			t.MaxRuntimeSeconds = nil
+			t.TaskDeletionPolicy = ""
+		}
+		allErrs = append(allErrs, validation.ValidateImmutableField(remaining, oldRemaining, fldPath, msgImmutable)...)
+	}
+
 	return allErrs
 }
 
@@ -374,6 +694,31 @@ func (v *Validator) ValidateStartPolicySpec(spec *v1alpha1.StartPolicySpec, fldP
 	allErrs := field.ErrorList{}
 	if spec != nil {
 		allErrs = append(allErrs, v.ValidateConcurrencyPolicy(spec.ConcurrencyPolicy, fldPath.Child("concurrencyPolicy"))...)
+		for i, dependency := range spec.DependsOn {
+			allErrs = append(allErrs, v.ValidateJobDependency(dependency, fldPath.Child("dependsOn").Index(i))...)
+		}
+		if spec.DependsOnTimeoutSeconds != nil {
+			allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.DependsOnTimeoutSeconds, fldPath.Child("dependsOnTimeoutSeconds"))...)
+		}
+		if spec.ExpireAfterSeconds != nil {
+			allErrs = append(allErrs, validation.ValidateGT(*spec.ExpireAfterSeconds, 0, fldPath.Child("expireAfterSeconds"))...)
+		}
+	}
+	return allErrs
+}
+
+// ValidateJobDependency validates a v1alpha1.JobDependency.
+func (v *Validator) ValidateJobDependency(dependency v1alpha1.JobDependency, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case dependency.JobName == "" && dependency.JobConfigName == "":
+		allErrs = append(allErrs, field.Required(fldPath, "must specify one of jobName or jobConfigName"))
+	case dependency.JobName != "" && dependency.JobConfigName != "":
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("jobConfigName"), dependency.JobConfigName,
+			"cannot specify both jobName and jobConfigName"))
+	}
+	if dependency.Timezone != "" {
+		allErrs = append(allErrs, v.ValidateTimezone(dependency.Timezone, fldPath.Child("timezone"))...)
 	}
 	return allErrs
 }
@@ -381,16 +726,220 @@ func (v *Validator) ValidateStartPolicySpec(spec *v1alpha1.StartPolicySpec, fldP
 // ValidateJobTemplateSpec validates a *v1alpha1.JobTemplateSpec.
 func (v *Validator) ValidateJobTemplateSpec(template *v1alpha1.JobTemplateSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, v.ValidateJobTaskSpec(&template.Task, fldPath.Child("task"))...)
+	if len(template.Steps) > 0 {
+		allErrs = append(allErrs, v.ValidateJobSteps(template.Steps, fldPath.Child("steps"))...)
+	} else {
+		allErrs = append(allErrs, v.ValidateJobTaskSpec(&template.Task, fldPath.Child("task"))...)
+	}
 	if template.MaxAttempts != nil {
 		allErrs = append(allErrs, v.ValidateMaxRetryAttempts(*template.MaxAttempts, fldPath.Child("maxAttempts"))...)
 	}
 	if template.RetryDelaySeconds != nil {
 		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*template.RetryDelaySeconds, fldPath.Child("retryDelaySeconds"))...)
 	}
+	if template.MaxInitFailureRetries != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*template.MaxInitFailureRetries), fldPath.Child("maxInitFailureRetries"))...)
+	}
+	if template.MaxEvictionRetries != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(int64(*template.MaxEvictionRetries), fldPath.Child("maxEvictionRetries"))...)
+	}
+	if template.MaxRuntimeSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*template.MaxRuntimeSeconds, fldPath.Child("maxRuntimeSeconds"))...)
+	}
+	for i, patch := range template.Patches {
+		allErrs = append(allErrs, v.ValidateJobTemplatePatch(patch, template, fldPath.Child("patches").Index(i))...)
+	}
+	allErrs = append(allErrs, v.ValidateRetryMode(template.RetryMode, fldPath.Child("retryMode"))...)
+	if template.RetryMode == v1alpha1.RetryModeInPlace && len(template.Task.VolumeClaimTemplates) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("task", "volumeClaimTemplates"),
+			"cannot be used together with InPlace retryMode"))
+	}
+	allErrs = append(allErrs, v.ValidateTaskDeletionPolicy(template.TaskDeletionPolicy, fldPath.Child("taskDeletionPolicy"))...)
+	if template.TaskDeletionPolicy == v1alpha1.TaskDeletionPolicyOrphan && len(template.Steps) == 0 &&
+		template.Task.External != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("taskDeletionPolicy"),
+			"cannot orphan tasks together with external"))
+	}
+	if template.Callback != nil {
+		allErrs = append(allErrs, v.ValidateCallbackSpec(template.Callback, fldPath.Child("callback"))...)
+	}
+	return allErrs
+}
+
+// ValidateCallbackSpec validates a *v1alpha1.CallbackSpec.
+func (v *Validator) ValidateCallbackSpec(spec *v1alpha1.CallbackSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.URL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(spec.URL); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), spec.URL, err.Error()))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), spec.URL, "must be an absolute http or https URL"))
+	}
+	refPath := fldPath.Child("signingKeySecretRef")
+	if spec.SigningKeySecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+	}
+	if spec.SigningKeySecretRef.Key == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("key"), ""))
+	}
+	return allErrs
+}
+
+// ValidateTaskDeletionPolicy validates a v1alpha1.TaskDeletionPolicy.
+func (v *Validator) ValidateTaskDeletionPolicy(policy v1alpha1.TaskDeletionPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch policy {
+	case "", v1alpha1.TaskDeletionPolicyDelete, v1alpha1.TaskDeletionPolicyOrphan:
+		break
+	default:
+		validValues := []string{
+			string(v1alpha1.TaskDeletionPolicyDelete),
+			string(v1alpha1.TaskDeletionPolicyOrphan),
+		}
+		allErrs = append(allErrs, field.NotSupported(fldPath, policy, validValues))
+	}
+	return allErrs
+}
+
+// ValidateRetryMode validates a v1alpha1.RetryMode.
+func (v *Validator) ValidateRetryMode(retryMode v1alpha1.RetryMode, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch retryMode {
+	case "", v1alpha1.RetryModeNewTask, v1alpha1.RetryModeInPlace:
+		break
+	default:
+		validValues := []string{
+			string(v1alpha1.RetryModeNewTask),
+			string(v1alpha1.RetryModeInPlace),
+		}
+		allErrs = append(allErrs, field.NotSupported(fldPath, retryMode, validValues))
+	}
+	return allErrs
+}
+
+// ValidateJobTemplatePatch validates a v1alpha1.JobTemplatePatch.
+func (v *Validator) ValidateJobTemplatePatch(
+	patch v1alpha1.JobTemplatePatch, template *v1alpha1.JobTemplateSpec, fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if patch.Condition.Variable == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("condition", "variable"), ""))
+	}
+	if len(patch.Patch.Raw) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("patch"), ""))
+		return allErrs
+	}
+
+	// Ensure that the patch can be applied to all pod templates.
+	podTemplates := []corev1.PodTemplateSpec{template.Task.Template}
+	if len(template.Steps) > 0 {
+		podTemplates = podTemplates[:0]
+		for _, step := range template.Steps {
+			podTemplates = append(podTemplates, step.Task.Template)
+		}
+	}
+	for _, podTemplate := range podTemplates {
+		if _, err := variablecontext.ApplyPodTemplatePatch(podTemplate, patch.Patch.Raw); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("patch"), string(patch.Patch.Raw), err.Error()))
+			break
+		}
+	}
+
 	return allErrs
 }
 
+// ValidateJobSteps validates a list of v1alpha1.JobStepSpec.
+func (v *Validator) ValidateJobSteps(steps []v1alpha1.JobStepSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := make(map[string]int, len(steps))
+	for i, step := range steps {
+		idxPath := fldPath.Index(i)
+		allErrs = append(allErrs, v.ValidateJobStepName(step.Name, idxPath.Child("name"))...)
+		if _, ok := names[step.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), step.Name))
+		}
+		names[step.Name] = i
+		allErrs = append(allErrs, v.ValidateJobTaskSpec(&step.Task, idxPath.Child("task"))...)
+		if step.Task.External != nil {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("task", "external"), "cannot be used for steps"))
+		}
+	}
+
+	// Validate dependencies only after we know all step names.
+	for i, step := range steps {
+		for j, dependency := range step.DependsOn {
+			depPath := fldPath.Index(i).Child("dependsOn").Index(j)
+			if dependency == step.Name {
+				allErrs = append(allErrs, field.Invalid(depPath, dependency, "step cannot depend on itself"))
+			} else if _, ok := names[dependency]; !ok {
+				allErrs = append(allErrs, field.NotFound(depPath, dependency))
+			}
+		}
+	}
+
+	// Steps must form a directed acyclic graph.
+	if len(allErrs) == 0 && hasCyclicSteps(steps) {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "steps cannot have cyclic dependencies"))
+	}
+
+	return allErrs
+}
+
+// ValidateJobStepName validates the name of a step.
+func (v *Validator) ValidateJobStepName(name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if name == "" {
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+		return allErrs
+	}
+	for _, msg := range apimachineryvalidation.IsDNS1123Label(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "cannot consist of only digits"))
+	}
+	return allErrs
+}
+
+// hasCyclicSteps returns true if the dependencies between steps contain a cycle.
+func hasCyclicSteps(steps []v1alpha1.JobStepSpec) bool {
+	dependencies := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		dependencies[step.Name] = step.DependsOn
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int, len(steps))
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch states[name] {
+		case visiting:
+			return true
+		case visited:
+			return false
+		}
+		states[name] = visiting
+		for _, dependency := range dependencies[name] {
+			if visit(dependency) {
+				return true
+			}
+		}
+		states[name] = visited
+		return false
+	}
+
+	for _, step := range steps {
+		if visit(step.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *Validator) ValidateMaxRetryAttempts(attempts int32, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -407,13 +956,168 @@ func (v *Validator) ValidateMaxRetryAttempts(attempts int32, fldPath *field.Path
 // ValidateJobTaskSpec validates a *v1alpha1.JobTaskSpec.
 func (v *Validator) ValidateJobTaskSpec(spec *v1alpha1.JobTaskSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	allErrs = append(allErrs, v.ValidateTaskTemplate(&spec.Template, fldPath.Child("template"))...)
+	if spec.TemplateRef != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("templateRef"), "must be resolved by the mutating webhook"))
+	}
+	if spec.External != nil {
+		allErrs = append(allErrs, v.ValidateExternalTaskTemplate(spec.External, fldPath.Child("external"))...)
+		if len(spec.RequiredContainers) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("requiredContainers"), "cannot be used together with external"))
+		}
+		if len(spec.VolumeClaimTemplates) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("volumeClaimTemplates"), "cannot be used together with external"))
+		}
+		if spec.PreKillHook != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("preKillHook"), "cannot be used together with external"))
+		}
+		if spec.StallDetection != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("stallDetection"), "cannot be used together with external"))
+		}
+	} else {
+		allErrs = append(allErrs, v.ValidateTaskTemplate(withVolumeClaimTemplates(spec), fldPath.Child("template"))...)
+	}
 	if spec.PendingTimeoutSeconds != nil {
 		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.PendingTimeoutSeconds, fldPath.Child("pendingTimeoutSeconds"))...)
 	}
+	for i, reason := range spec.FailFastPendingReasons {
+		if reason == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("failFastPendingReasons").Index(i), ""))
+		}
+	}
+	if spec.RunningTimeoutSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.RunningTimeoutSeconds, fldPath.Child("runningTimeoutSeconds"))...)
+	}
+	if spec.KillGracePeriodSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*spec.KillGracePeriodSeconds, fldPath.Child("killGracePeriodSeconds"))...)
+	}
+	if len(spec.RequiredContainers) > 0 {
+		containers := make(map[string]struct{}, len(spec.Template.Spec.Containers))
+		for _, container := range spec.Template.Spec.Containers {
+			containers[container.Name] = struct{}{}
+		}
+		for i, name := range spec.RequiredContainers {
+			if _, ok := containers[name]; !ok {
+				allErrs = append(allErrs, field.NotFound(fldPath.Child("requiredContainers").Index(i), name))
+			}
+		}
+	}
+	if spec.PreKillHook != nil && spec.External == nil {
+		allErrs = append(allErrs, v.ValidateTaskPreKillHook(spec.PreKillHook, spec.Template, fldPath.Child("preKillHook"))...)
+	}
+	if spec.StallDetection != nil {
+		allErrs = append(allErrs, validation.ValidateGT(spec.StallDetection.TimeoutSeconds, 0,
+			fldPath.Child("stallDetection", "timeoutSeconds"))...)
+	}
+	allErrs = append(allErrs, v.ValidateTaskVolumeClaimTemplates(spec, fldPath.Child("volumeClaimTemplates"))...)
 	return allErrs
 }
 
+// ValidateTaskPreKillHook validates a *v1alpha1.TaskPreKillHook.
+func (v *Validator) ValidateTaskPreKillHook(
+	hook *v1alpha1.TaskPreKillHook, template corev1.PodTemplateSpec, fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(hook.Command) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("command"), ""))
+	}
+	if hook.Container != "" {
+		var found bool
+		for _, container := range template.Spec.Containers {
+			if container.Name == hook.Container {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("container"), hook.Container))
+		}
+	}
+	if hook.TimeoutSeconds != nil {
+		allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(*hook.TimeoutSeconds, fldPath.Child("timeoutSeconds"))...)
+	}
+	return allErrs
+}
+
+// ValidateExternalTaskTemplate validates a *v1alpha1.ExternalTaskTemplate.
+func (v *Validator) ValidateExternalTaskTemplate(
+	template *v1alpha1.ExternalTaskTemplate, fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if template.RunnerName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("runnerName"), ""))
+	}
+	if len(template.Command) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("command"), ""))
+	}
+	for i, envVar := range template.Env {
+		if envVar.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("env").Index(i).Child("name"), ""))
+		}
+	}
+	return allErrs
+}
+
+// ValidateTaskVolumeClaimTemplates validates the volumeClaimTemplates of a JobTaskSpec.
+func (v *Validator) ValidateTaskVolumeClaimTemplates(spec *v1alpha1.JobTaskSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	volumes := make(map[string]struct{}, len(spec.Template.Spec.Volumes))
+	for _, volume := range spec.Template.Spec.Volumes {
+		volumes[volume.Name] = struct{}{}
+	}
+	names := make(map[string]struct{}, len(spec.VolumeClaimTemplates))
+	for i, claimTemplate := range spec.VolumeClaimTemplates {
+		idxPath := fldPath.Index(i)
+		namePath := idxPath.Child("name")
+		if claimTemplate.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, ""))
+		}
+		for _, msg := range apimachineryvalidation.IsDNS1123Label(claimTemplate.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, claimTemplate.Name, msg))
+		}
+		if _, ok := names[claimTemplate.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(namePath, claimTemplate.Name))
+		}
+		if _, ok := volumes[claimTemplate.Name]; ok {
+			allErrs = append(allErrs, field.Invalid(namePath, claimTemplate.Name, "conflicts with existing volume in template"))
+		}
+		names[claimTemplate.Name] = struct{}{}
+		if len(claimTemplate.Spec.AccessModes) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("spec", "accessModes"), ""))
+		}
+	}
+	return allErrs
+}
+
+// withVolumeClaimTemplates returns a copy of the task's pod template with
+// volumes added for each volumeClaimTemplate, so that volumeMounts which
+// reference them can be validated.
+func withVolumeClaimTemplates(spec *v1alpha1.JobTaskSpec) *corev1.PodTemplateSpec {
+	if len(spec.VolumeClaimTemplates) == 0 {
+		return &spec.Template
+	}
+	template := spec.Template.DeepCopy()
+	names := make(map[string]struct{}, len(template.Spec.Volumes)+len(spec.VolumeClaimTemplates))
+	for _, volume := range template.Spec.Volumes {
+		names[volume.Name] = struct{}{}
+	}
+	for _, claimTemplate := range spec.VolumeClaimTemplates {
+		// Invalid names are reported by ValidateTaskVolumeClaimTemplates instead.
+		if _, ok := names[claimTemplate.Name]; ok || claimTemplate.Name == "" {
+			continue
+		}
+		names[claimTemplate.Name] = struct{}{}
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: claimTemplate.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimTemplate.Name,
+				},
+			},
+		})
+	}
+	return template
+}
+
 // ValidateTaskTemplate validates a *corev1.PodTemplateSpec.
 func (v *Validator) ValidateTaskTemplate(spec *corev1.PodTemplateSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -431,3 +1135,8 @@ func (v *Validator) getJobConfigLister(namespace string) executionlister.JobConf
 	return v.ctrlContext.Informers().Furiko().Execution().V1alpha1().JobConfigs().Lister().
 		JobConfigs(namespace)
 }
+
+func (v *Validator) getTaskTemplateLister(namespace string) executionlister.TaskTemplateNamespaceLister {
+	return v.ctrlContext.Informers().Furiko().Execution().V1alpha1().TaskTemplates().Lister().
+		TaskTemplates(namespace)
+}
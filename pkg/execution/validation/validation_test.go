@@ -33,6 +33,7 @@ import (
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
 	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
 	"github.com/furiko-io/furiko/pkg/execution/validation"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
@@ -218,11 +219,24 @@ var (
 )
 
 func TestValidateJobConfig(t *testing.T) {
+	taskTemplate := &v1alpha1.TaskTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "tasktemplate",
+		},
+		Spec: v1alpha1.TaskTemplateSpec{
+			Task: v1alpha1.JobTaskSpec{
+				Template: podTemplateSpecBasic,
+			},
+		},
+	}
+
 	tests := []struct {
-		name    string
-		rjc     *v1alpha1.JobConfig
-		cfgs    map[configv1alpha1.ConfigName]runtime.Object
-		wantErr string
+		name          string
+		rjc           *v1alpha1.JobConfig
+		cfgs          map[configv1alpha1.ConfigName]runtime.Object
+		taskTemplates []*v1alpha1.TaskTemplate
+		wantErr       string
 	}{
 		{
 			name: "basic job config",
@@ -303,6 +317,307 @@ func TestValidateJobConfig(t *testing.T) {
 			},
 			wantErr: "spec.concurrency.policy: Unsupported value: \"invalid\"",
 		},
+		{
+			name: "invalid concurrency.expireAfterSeconds",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template: jobTemplateSpecBasic,
+					Concurrency: v1alpha1.ConcurrencySpec{
+						Policy:             v1alpha1.ConcurrencyPolicyEnqueue,
+						ExpireAfterSeconds: pointer.Int64(0),
+					},
+				},
+			},
+			wantErr: "spec.concurrency.expireAfterSeconds: Invalid value: 0: must be greater than 0",
+		},
+		{
+			name: "valid concurrency.group",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template: jobTemplateSpecBasic,
+					Concurrency: v1alpha1.ConcurrencySpec{
+						Policy: v1alpha1.ConcurrencyPolicyEnqueue,
+						Group: &v1alpha1.ConcurrencyGroupSpec{
+							Name:           "database",
+							MaxConcurrency: pointer.Int64(2),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "missing concurrency.group.name",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template: jobTemplateSpecBasic,
+					Concurrency: v1alpha1.ConcurrencySpec{
+						Policy: v1alpha1.ConcurrencyPolicyEnqueue,
+						Group:  &v1alpha1.ConcurrencyGroupSpec{},
+					},
+				},
+			},
+			wantErr: "spec.concurrency.group.name: Required value",
+		},
+		{
+			name: "invalid concurrency.group.maxConcurrency",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template: jobTemplateSpecBasic,
+					Concurrency: v1alpha1.ConcurrencySpec{
+						Policy: v1alpha1.ConcurrencyPolicyEnqueue,
+						Group: &v1alpha1.ConcurrencyGroupSpec{
+							Name:           "database",
+							MaxConcurrency: pointer.Int64(0),
+						},
+					},
+				},
+			},
+			wantErr: "spec.concurrency.group.maxConcurrency: Invalid value: 0: must be greater than 0",
+		},
+		{
+			name: "valid templatePolicy",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:       jobTemplateSpecBasic,
+					Concurrency:    concurrencySpecBasic,
+					TemplatePolicy: v1alpha1.TemplatePolicyReference,
+				},
+			},
+		},
+		{
+			name: "invalid templatePolicy",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:       jobTemplateSpecBasic,
+					Concurrency:    concurrencySpecBasic,
+					TemplatePolicy: "invalid",
+				},
+			},
+			wantErr: "spec.templatePolicy: Unsupported value: \"invalid\"",
+		},
+		{
+			name: "valid executionOverrides",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					ExecutionOverrides: &v1alpha1.JobExecutionOverrides{
+						TTLSecondsAfterFinished:               pointer.Int64(60),
+						ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(0),
+					},
+				},
+			},
+		},
+		{
+			name: "negative executionOverrides",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					ExecutionOverrides: &v1alpha1.JobExecutionOverrides{
+						PendingTimeoutSeconds: pointer.Int64(-1),
+					},
+				},
+			},
+			wantErr: "spec.executionOverrides.pendingTimeoutSeconds: Invalid value: -1",
+		},
+		{
+			name: "valid notifications",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Notifications: []v1alpha1.NotificationSpec{
+						{
+							Type: v1alpha1.NotificationTypeSlack,
+							WebhookURLSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "slack"},
+								Key:                  "url",
+							},
+							On: []v1alpha1.NotificationTrigger{
+								v1alpha1.NotificationTriggerFailure,
+								v1alpha1.NotificationTriggerLongRunning,
+							},
+							LongRunningThresholdSeconds: pointer.Int64(3600),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid notification type",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Notifications: []v1alpha1.NotificationSpec{
+						{
+							Type: "Discord",
+							WebhookURLSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "slack"},
+								Key:                  "url",
+							},
+							On: []v1alpha1.NotificationTrigger{v1alpha1.NotificationTriggerFailure},
+						},
+					},
+				},
+			},
+			wantErr: "spec.notifications[0].type: Unsupported value: \"Discord\"",
+		},
+		{
+			name: "notification with LongRunning trigger without threshold",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Notifications: []v1alpha1.NotificationSpec{
+						{
+							Type: v1alpha1.NotificationTypeTeams,
+							WebhookURLSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "teams"},
+								Key:                  "url",
+							},
+							On: []v1alpha1.NotificationTrigger{v1alpha1.NotificationTriggerLongRunning},
+						},
+					},
+				},
+			},
+			wantErr: "spec.notifications[0].longRunningThresholdSeconds: Required value",
+		},
+		{
+			name: "valid email notification",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Notifications: []v1alpha1.NotificationSpec{
+						{
+							Type: v1alpha1.NotificationTypeEmail,
+							Email: &v1alpha1.EmailNotificationSpec{
+								To: []string{"team@example.com"},
+								Cc: []string{"Oncall <oncall@example.com>"},
+							},
+							On: []v1alpha1.NotificationTrigger{v1alpha1.NotificationTriggerFailure},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "email notification with invalid recipient",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Notifications: []v1alpha1.NotificationSpec{
+						{
+							Type: v1alpha1.NotificationTypeEmail,
+							Email: &v1alpha1.EmailNotificationSpec{
+								To: []string{"team"},
+							},
+							On: []v1alpha1.NotificationTrigger{v1alpha1.NotificationTriggerFailure},
+						},
+					},
+				},
+			},
+			wantErr: "spec.notifications[0].email.to[0]: Invalid value: \"team\"",
+		},
+		{
+			name: "Slack notification without webhookURLSecretRef",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Notifications: []v1alpha1.NotificationSpec{
+						{
+							Type: v1alpha1.NotificationTypeSlack,
+							On:   []v1alpha1.NotificationTrigger{v1alpha1.NotificationTriggerFailure},
+						},
+					},
+				},
+			},
+			wantErr: "spec.notifications[0].webhookURLSecretRef: Required value",
+		},
+		{
+			name: "valid alerts",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Alerts: []v1alpha1.AlertSpec{
+						{
+							Type: v1alpha1.AlertTypePagerDuty,
+							RoutingKeySecretRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "pagerduty"},
+								Key:                  "key",
+							},
+							ConsecutiveFailures: 3,
+						},
+						{
+							Type: v1alpha1.AlertTypeOpsgenie,
+							RoutingKeySecretRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "opsgenie"},
+								Key:                  "key",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid alert type",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Alerts: []v1alpha1.AlertSpec{
+						{
+							Type: "VictorOps",
+							RoutingKeySecretRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "victorops"},
+								Key:                  "key",
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.alerts[0].type: Unsupported value: \"VictorOps\"",
+		},
+		{
+			name: "alert without routingKeySecretRef",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Alerts: []v1alpha1.AlertSpec{
+						{
+							Type: v1alpha1.AlertTypePagerDuty,
+						},
+					},
+				},
+			},
+			wantErr: "[spec.alerts[0].routingKeySecretRef.name: Required value, spec.alerts[0].routingKeySecretRef.key: Required value]",
+		},
+		{
+			name: "alert with negative consecutiveFailures",
+			rjc: &v1alpha1.JobConfig{
+				Spec: v1alpha1.JobConfigSpec{
+					Template:    jobTemplateSpecBasic,
+					Concurrency: concurrencySpecBasic,
+					Alerts: []v1alpha1.AlertSpec{
+						{
+							Type: v1alpha1.AlertTypeOpsgenie,
+							RoutingKeySecretRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "opsgenie"},
+								Key:                  "key",
+							},
+							ConsecutiveFailures: -1,
+						},
+					},
+				},
+			},
+			wantErr: "spec.alerts[0].consecutiveFailures: Invalid value: -1",
+		},
 		{
 			name: "schedule without any schedule types",
 			rjc: &v1alpha1.JobConfig{
@@ -376,6 +691,79 @@ func TestValidateJobConfig(t *testing.T) {
 			},
 			wantErr: "spec.template.spec.task.template.spec.containers: Required value",
 		},
+		{
+			name: "task referencing TaskTemplate",
+			rjc: &v1alpha1.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: v1alpha1.JobConfigSpec{
+					Template: v1alpha1.JobTemplate{
+						Spec: v1alpha1.JobTemplateSpec{
+							Task: v1alpha1.JobTaskSpec{
+								TemplateRef: &v1alpha1.TaskTemplateReference{
+									Name: "tasktemplate",
+								},
+							},
+						},
+					},
+					Concurrency: concurrencySpecBasic,
+				},
+			},
+			taskTemplates: []*v1alpha1.TaskTemplate{taskTemplate},
+		},
+		{
+			name: "task referencing TaskTemplate that does not exist",
+			rjc: &v1alpha1.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: v1alpha1.JobConfigSpec{
+					Template: v1alpha1.JobTemplate{
+						Spec: v1alpha1.JobTemplateSpec{
+							Task: v1alpha1.JobTaskSpec{
+								TemplateRef: &v1alpha1.TaskTemplateReference{
+									Name: "tasktemplate",
+								},
+							},
+						},
+					},
+					Concurrency: concurrencySpecBasic,
+				},
+			},
+			wantErr: "spec.template.spec.task.templateRef.name: Not found: \"tasktemplate\"",
+		},
+		{
+			name: "validate task merged with TaskTemplate",
+			rjc: &v1alpha1.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: v1alpha1.JobConfigSpec{
+					Template: v1alpha1.JobTemplate{
+						Spec: v1alpha1.JobTemplateSpec{
+							Task: v1alpha1.JobTaskSpec{
+								TemplateRef: &v1alpha1.TaskTemplateReference{
+									Name: "tasktemplate",
+								},
+								Template: corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name: "container2",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					Concurrency: concurrencySpecBasic,
+				},
+			},
+			taskTemplates: []*v1alpha1.TaskTemplate{taskTemplate},
+			wantErr:       "spec.template.spec.task.template.spec.containers[0].image: Required value",
+		},
 		{
 			name: "cannot use restartPolicy Always",
 			rjc: &v1alpha1.JobConfig{
@@ -401,12 +789,25 @@ func TestValidateJobConfig(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
 			original := tt.rjc.DeepCopy()
 			ctrlContext := mock.NewContext()
 			ctrlContext.MockConfigs().SetConfigs(tt.cfgs)
-			if err := ctrlContext.Start(context.Background()); err != nil {
+			hasSynced := ctrlContext.Informers().Furiko().Execution().V1alpha1().TaskTemplates().Informer().HasSynced
+			if err := ctrlContext.Start(ctx); err != nil {
 				t.Fatal(err)
 			}
+			for _, taskTemplate := range tt.taskTemplates {
+				_, err := ctrlContext.Clientsets().Furiko().ExecutionV1alpha1().TaskTemplates(tt.rjc.Namespace).
+					Create(ctx, taskTemplate, metav1.CreateOptions{})
+				if err != nil {
+					t.Fatalf("cannot create TaskTemplate: %v", err)
+				}
+			}
+			if !cache.WaitForCacheSync(ctx.Done(), hasSynced) {
+				t.Fatalf("cannot sync caches")
+			}
 			validator := validation.NewValidator(ctrlContext)
 			err := validator.ValidateJobConfig(tt.rjc).ToAggregate()
 			if checkError(err, tt.wantErr) {
@@ -507,51 +908,692 @@ func TestValidateJob(t *testing.T) {
 			wantErr: "spec.startPolicy.concurrencyPolicy: Unsupported value: \"invalid\"",
 		},
 		{
-			name: "invalid ttlSecondsAfterFinished",
+			name: "valid startPolicy.expireAfterSeconds",
 			rj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
-					Type:                    v1alpha1.JobTypeAdhoc,
-					Template:                &jobTemplateSpecBasic.Spec,
-					TTLSecondsAfterFinished: pointer.Int64(-300),
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+					StartPolicy: &v1alpha1.StartPolicySpec{
+						ConcurrencyPolicy:  v1alpha1.ConcurrencyPolicyEnqueue,
+						ExpireAfterSeconds: pointer.Int64(600),
+					},
 				},
 			},
-			wantErr: "spec.ttlSecondsAfterFinished: Invalid value: -300",
 		},
 		{
-			name: "maxAttempts too large",
+			name: "invalid startPolicy.expireAfterSeconds",
 			rj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
 					Type:     v1alpha1.JobTypeAdhoc,
-					Template: &jobTemplateSpecTooManyRetries.Spec,
+					Template: &jobTemplateSpecBasic.Spec,
+					StartPolicy: &v1alpha1.StartPolicySpec{
+						ConcurrencyPolicy:  v1alpha1.ConcurrencyPolicyEnqueue,
+						ExpireAfterSeconds: pointer.Int64(-1),
+					},
 				},
 			},
-			wantErr: "spec.template.maxAttempts: Invalid value: 100: must be less than or equal to 50",
+			wantErr: "spec.startPolicy.expireAfterSeconds: Invalid value: -1: must be greater than 0",
 		},
 		{
-			name: "invalid pod template",
+			name: "valid steps",
 			rj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
 					Type: v1alpha1.JobTypeAdhoc,
 					Template: &v1alpha1.JobTemplateSpec{
-						Task: v1alpha1.JobTaskSpec{
-							Template: podTemplateSpecEmpty,
+						Steps: []v1alpha1.JobStepSpec{
+							{Name: "prepare", Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+							{Name: "run", DependsOn: []string{"prepare"}, Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+							{Name: "publish", DependsOn: []string{"run"}, Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
 						},
 					},
 				},
 			},
-			wantErr: "spec.template.task.template.spec.containers: Required value",
 		},
 		{
-			name: "cannot use restartPolicy Always",
+			name: "duplicate step name",
 			rj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
 					Type: v1alpha1.JobTypeAdhoc,
 					Template: &v1alpha1.JobTemplateSpec{
-						Task: v1alpha1.JobTaskSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{Name: "prepare", Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+							{Name: "prepare", Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.steps[1].name: Duplicate value: \"prepare\"",
+		},
+		{
+			name: "numeric step name",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{Name: "1", Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.steps[0].name: Invalid value: \"1\": cannot consist of only digits",
+		},
+		{
+			name: "step depends on unknown step",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{Name: "prepare", DependsOn: []string{"unknown"}, Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.steps[0].dependsOn[0]: Not found: \"unknown\"",
+		},
+		{
+			name: "step depends on itself",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{Name: "prepare", DependsOn: []string{"prepare"}, Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.steps[0].dependsOn[0]: Invalid value: \"prepare\": step cannot depend on itself",
+		},
+		{
+			name: "steps with cyclic dependencies",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{Name: "a", DependsOn: []string{"b"}, Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+							{Name: "b", DependsOn: []string{"a"}, Task: v1alpha1.JobTaskSpec{Template: podTemplateSpecBasic}},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.steps: Invalid value: \"\": steps cannot have cyclic dependencies",
+		},
+		{
+			name: "valid dependsOn",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+					StartPolicy: &v1alpha1.StartPolicySpec{
+						ConcurrencyPolicy: v1alpha1.ConcurrencyPolicyAllow,
+						DependsOn: []v1alpha1.JobDependency{
+							{JobName: "job-1"},
+							{JobConfigName: "jobconfig-1", Timezone: "Asia/Singapore"},
+						},
+						DependsOnTimeoutSeconds: pointer.Int64(3600),
+					},
+				},
+			},
+		},
+		{
+			name: "empty dependsOn item",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+					StartPolicy: &v1alpha1.StartPolicySpec{
+						ConcurrencyPolicy: v1alpha1.ConcurrencyPolicyAllow,
+						DependsOn:         []v1alpha1.JobDependency{{}},
+					},
+				},
+			},
+			wantErr: "spec.startPolicy.dependsOn[0]: Required value",
+		},
+		{
+			name: "dependsOn item with both jobName and jobConfigName",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+					StartPolicy: &v1alpha1.StartPolicySpec{
+						ConcurrencyPolicy: v1alpha1.ConcurrencyPolicyAllow,
+						DependsOn: []v1alpha1.JobDependency{
+							{JobName: "job-1", JobConfigName: "jobconfig-1"},
+						},
+					},
+				},
+			},
+			wantErr: "spec.startPolicy.dependsOn[0].jobConfigName: Invalid value",
+		},
+		{
+			name: "invalid ttlSecondsAfterFinished",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:                    v1alpha1.JobTypeAdhoc,
+					Template:                &jobTemplateSpecBasic.Spec,
+					TTLSecondsAfterFinished: pointer.Int64(-300),
+				},
+			},
+			wantErr: "spec.ttlSecondsAfterFinished: Invalid value: -300",
+		},
+		{
+			name: "invalid retainFinishedTasksSeconds",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:                       v1alpha1.JobTypeAdhoc,
+					Template:                   &jobTemplateSpecBasic.Spec,
+					RetainFinishedTasksSeconds: pointer.Int64(-60),
+				},
+			},
+			wantErr: "spec.retainFinishedTasksSeconds: Invalid value: -60",
+		},
+		{
+			name: "invalid killGracePeriodSeconds",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template:               podTemplateSpecBasic,
+							KillGracePeriodSeconds: pointer.Int64(-30),
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.killGracePeriodSeconds: Invalid value: -30",
+		},
+		{
+			name: "invalid runningTimeoutSeconds",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template:              podTemplateSpecBasic,
+							RunningTimeoutSeconds: pointer.Int64(-60),
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.runningTimeoutSeconds: Invalid value: -60",
+		},
+		{
+			name: "empty failFastPendingReasons",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template:               podTemplateSpecBasic,
+							FailFastPendingReasons: []string{"ErrImagePull", ""},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.failFastPendingReasons[1]: Required value",
+		},
+		{
+			name: "valid preKillHook",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+							PreKillHook: &v1alpha1.TaskPreKillHook{
+								Command:        []string{"/bin/checkpoint"},
+								TimeoutSeconds: pointer.Int64(60),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "preKillHook without command",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template:    podTemplateSpecBasic,
+							PreKillHook: &v1alpha1.TaskPreKillHook{},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.preKillHook.command: Required value",
+		},
+		{
+			name: "preKillHook with unknown container",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+							PreKillHook: &v1alpha1.TaskPreKillHook{
+								Container: "sidecar",
+								Command:   []string{"/bin/checkpoint"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.preKillHook.container: Not found: \"sidecar\"",
+		},
+		{
+			name: "valid stallDetection",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+							StallDetection: &v1alpha1.TaskStallDetection{
+								TimeoutSeconds:   300,
+								KillStalledTasks: true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "stallDetection without timeoutSeconds",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template:       podTemplateSpecBasic,
+							StallDetection: &v1alpha1.TaskStallDetection{},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.stallDetection.timeoutSeconds: Invalid value",
+		},
+		{
+			name: "stallDetection cannot be used with external",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							External: &v1alpha1.ExternalTaskTemplate{
+								RunnerName: "runner",
+								Command:    []string{"echo", "Hello world"},
+							},
+							StallDetection: &v1alpha1.TaskStallDetection{
+								TimeoutSeconds: 300,
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.stallDetection: Forbidden",
+		},
+		{
+			name: "valid external task",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							External: &v1alpha1.ExternalTaskTemplate{
+								RunnerName: "runner",
+								Command:    []string{"echo", "Hello world"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "external task without command",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							External: &v1alpha1.ExternalTaskTemplate{
+								RunnerName: "runner",
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.external.command: Required value",
+		},
+		{
+			name: "external task for step",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Steps: []v1alpha1.JobStepSpec{
+							{
+								Name: "run",
+								Task: v1alpha1.JobTaskSpec{
+									External: &v1alpha1.ExternalTaskTemplate{
+										RunnerName: "runner",
+										Command:    []string{"echo", "Hello world"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.steps[0].task.external: Forbidden: cannot be used for steps",
+		},
+		{
+			name: "invalid maxRuntimeSeconds",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						MaxRuntimeSeconds: pointer.Int64(-1),
+					},
+				},
+			},
+			wantErr: "spec.template.maxRuntimeSeconds: Invalid value: -1",
+		},
+		{
+			name: "invalid retryMode",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						RetryMode: "Replace",
+					},
+				},
+			},
+			wantErr: "spec.template.retryMode: Unsupported value: \"Replace\"",
+		},
+		{
+			name: "InPlace retryMode with volumeClaimTemplates",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+							VolumeClaimTemplates: []v1alpha1.TaskVolumeClaimTemplate{
+								{Name: "scratch", Spec: pvcSpecBasic},
+							},
+						},
+						RetryMode: v1alpha1.RetryModeInPlace,
+					},
+				},
+			},
+			wantErr: "spec.template.task.volumeClaimTemplates: Forbidden: cannot be used together with InPlace retryMode",
+		},
+		{
+			name: "valid taskDeletionPolicy",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						TaskDeletionPolicy: v1alpha1.TaskDeletionPolicyOrphan,
+					},
+				},
+			},
+		},
+		{
+			name: "invalid taskDeletionPolicy",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						TaskDeletionPolicy: "Retain",
+					},
+				},
+			},
+			wantErr: "spec.template.taskDeletionPolicy: Unsupported value: \"Retain\"",
+		},
+		{
+			name: "Orphan taskDeletionPolicy with external",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							External: &v1alpha1.ExternalTaskTemplate{
+								RunnerName: "runner",
+								Command:    []string{"echo", "Hello world"},
+							},
+						},
+						TaskDeletionPolicy: v1alpha1.TaskDeletionPolicyOrphan,
+					},
+				},
+			},
+			wantErr: "spec.template.taskDeletionPolicy: Forbidden: cannot orphan tasks together with external",
+		},
+		{
+			name: "valid callback",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						Callback: &v1alpha1.CallbackSpec{
+							URL: "https://orchestrator.example.com/callback",
+							SigningKeySecretRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "callback"},
+								Key:                  "key",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "callback with relative URL",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						Callback: &v1alpha1.CallbackSpec{
+							URL: "/callback",
+							SigningKeySecretRef: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "callback"},
+								Key:                  "key",
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.callback.url: Invalid value: \"/callback\": must be an absolute http or https URL",
+		},
+		{
+			name: "callback without signingKeySecretRef",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						Callback: &v1alpha1.CallbackSpec{
+							URL: "https://orchestrator.example.com/callback",
+						},
+					},
+				},
+			},
+			wantErr: "[spec.template.callback.signingKeySecretRef.name: Required value, spec.template.callback.signingKeySecretRef.key: Required value]",
+		},
+		{
+			name: "requiredContainers not found",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template:           podTemplateSpecBasic,
+							RequiredContainers: []string{"sidecar"},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.requiredContainers[0]: Not found: \"sidecar\"",
+		},
+		{
+			name: "duplicate volumeClaimTemplates",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+							VolumeClaimTemplates: []v1alpha1.TaskVolumeClaimTemplate{
+								{Name: "scratch", Spec: pvcSpecBasic},
+								{Name: "scratch", Spec: pvcSpecBasic},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.volumeClaimTemplates[1].name: Duplicate value: \"scratch\"",
+		},
+		{
+			name: "invalid patch",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						Patches: []v1alpha1.JobTemplatePatch{
+							{
+								Condition: v1alpha1.JobTemplatePatchCondition{
+									Variable: "option.use_gpu",
+								},
+								Patch: runtime.RawExtension{
+									Raw: []byte(`{"spec":{"containers":"invalid"}}`),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.patches[0].patch: Invalid value",
+		},
+		{
+			name: "patch without condition variable",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecBasic,
+						},
+						Patches: []v1alpha1.JobTemplatePatch{
+							{
+								Patch: runtime.RawExtension{
+									Raw: []byte(`{"spec":{"nodeSelector":{"gpu":"true"}}}`),
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.patches[0].condition.variable: Required value",
+		},
+		{
+			name: "maxAttempts too large",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecTooManyRetries.Spec,
+				},
+			},
+			wantErr: "spec.template.maxAttempts: Invalid value: 100: must be less than or equal to 50",
+		},
+		{
+			name: "invalid pod template",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: podTemplateSpecEmpty,
+						},
+					},
+				},
+			},
+			wantErr: "spec.template.task.template.spec.containers: Required value",
+		},
+		{
+			name: "cannot use restartPolicy Always",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
 							Template: corev1.PodTemplateSpec{
 								Spec: corev1.PodSpec{
 									Containers:    []corev1.Container{{Name: "container", Image: "alpine"}},
@@ -634,7 +1676,7 @@ func TestValidateJobUpdate(t *testing.T) {
 					StartTime: startTime,
 				},
 			},
-			wantErr: "spec.startPolicy: Invalid value: v1alpha1.StartPolicySpec{ConcurrencyPolicy:\"Allow\", StartAfter:<nil>}: cannot update startPolicy once Job is started",
+			wantErr: "spec.startPolicy: Forbidden: cannot update startPolicy once Job is started",
 		},
 		{
 			name: "immutable label JobConfig UID",
@@ -651,21 +1693,58 @@ func TestValidateJobUpdate(t *testing.T) {
 				},
 			},
 			newRj: &v1alpha1.Job{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: objectMetaJob.Name,
-					Labels: map[string]string{
-						jobconfig.LabelKeyJobConfigUID: "abc",
-					},
-				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: objectMetaJob.Name,
+					Labels: map[string]string{
+						jobconfig.LabelKeyJobConfigUID: "abc",
+					},
+				},
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+			wantErr: "metadata.labels[execution.furiko.io/job-config-uid]: Forbidden: field is immutable",
+		},
+		{
+			name: "immutable field ConfigName",
+			oldRj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+			newRj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					ConfigName: "jobconfig",
+					Type:       v1alpha1.JobTypeAdhoc,
+					Template:   &jobTemplateSpecBasic.Spec,
+				},
+			},
+			wantErr: "spec.configName: Forbidden: field is immutable",
+		},
+		{
+			name: "immutable field type",
+			oldRj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+			newRj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
-					Type:     v1alpha1.JobTypeAdhoc,
+					Type:     v1alpha1.JobTypeScheduled,
 					Template: &jobTemplateSpecBasic.Spec,
 				},
 			},
-			wantErr: "metadata.labels[execution.furiko.io/job-config-uid]: Invalid value: \"abc\": field is immutable",
+			wantErr: "spec.type: Forbidden: field is immutable",
 		},
 		{
-			name: "immutable field ConfigName",
+			name: "can update template if not started",
 			oldRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
@@ -676,66 +1755,86 @@ func TestValidateJobUpdate(t *testing.T) {
 			newRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
-					ConfigName: "jobconfig",
-					Type:       v1alpha1.JobTypeAdhoc,
-					Template:   &jobTemplateSpecBasic.Spec,
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecMoreRetries.Spec,
 				},
 			},
-			wantErr: "spec.configName: Invalid value: \"jobconfig\": field is immutable",
 		},
 		{
-			name: "immutable field type",
+			name: "immutable field task",
 			oldRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
 					Type:     v1alpha1.JobTypeAdhoc,
 					Template: &jobTemplateSpecBasic.Spec,
 				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
+				},
 			},
 			newRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
-					Type:     v1alpha1.JobTypeScheduled,
-					Template: &jobTemplateSpecBasic.Spec,
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecLongPendingTimeout.Spec,
+				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
 				},
 			},
-			wantErr: "spec.type: Invalid value: \"Scheduled\": field is immutable",
+			wantErr: "spec.template.task: Forbidden: field is immutable",
 		},
 		{
-			name: "immutable field task",
+			name: "immutable field maxAttempts",
 			oldRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
 					Type:     v1alpha1.JobTypeAdhoc,
 					Template: &jobTemplateSpecBasic.Spec,
 				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
+				},
 			},
 			newRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
 					Type:     v1alpha1.JobTypeAdhoc,
-					Template: &jobTemplateSpecLongPendingTimeout.Spec,
+					Template: &jobTemplateSpecMoreRetries.Spec,
+				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
 				},
 			},
-			wantErr: "spec.template.task: Invalid value",
+			wantErr: "spec.template.maxAttempts: Forbidden: field is immutable",
 		},
 		{
-			name: "immutable field maxAttempts",
+			name: "immutable field taskNameTemplate",
 			oldRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
 					Type:     v1alpha1.JobTypeAdhoc,
 					Template: &jobTemplateSpecBasic.Spec,
 				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
+				},
 			},
 			newRj: &v1alpha1.Job{
 				ObjectMeta: objectMetaJob,
 				Spec: v1alpha1.JobSpec{
-					Type:     v1alpha1.JobTypeAdhoc,
-					Template: &jobTemplateSpecMoreRetries.Spec,
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: func() *v1alpha1.JobTemplateSpec {
+						spec := jobTemplateSpecBasic.Spec.DeepCopy()
+						spec.TaskNameTemplate = "${job.name}-${task.retry_index}"
+						return spec
+					}(),
+				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
 				},
 			},
-			wantErr: "spec.template.maxAttempts: Invalid value: 10: field is immutable",
+			wantErr: "spec.template.taskNameTemplate: Forbidden: field is immutable",
 		},
 		{
 			name: "can set KillTimestamp",
@@ -835,6 +1934,87 @@ func TestValidateJobUpdate(t *testing.T) {
 			},
 			wantErr: "spec.killTimestamp: Invalid value: 2021-02-09 04:15:00 +0000 UTC: field is immutable once passed",
 		},
+		{
+			name: "can update maxRuntimeSeconds after Job is started",
+			oldRj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
+				},
+			},
+			newRj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: func() *v1alpha1.JobTemplateSpec {
+						spec := jobTemplateSpecBasic.Spec.DeepCopy()
+						spec.MaxRuntimeSeconds = pointer.Int64(3600)
+						return spec
+					}(),
+				},
+				Status: v1alpha1.JobStatus{
+					StartTime: startTime,
+				},
+			},
+		},
+		{
+			name: "can update immutable field with annotation",
+			oldRj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJob,
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+			newRj: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      objectMetaJob.Name,
+					Namespace: objectMetaJob.Namespace,
+					Annotations: map[string]string{
+						jobutil.AnnotationKeyAllowImmutableUpdate: "true",
+					},
+				},
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeScheduled,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+		},
+		{
+			name: "cannot update JobConfig UID label with annotation",
+			oldRj: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: objectMetaJob.Name,
+					Labels: map[string]string{
+						jobconfig.LabelKeyJobConfigUID: string(objectMetaJobConfig.UID),
+					},
+				},
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+			newRj: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: objectMetaJob.Name,
+					Labels: map[string]string{
+						jobconfig.LabelKeyJobConfigUID: "abc",
+					},
+					Annotations: map[string]string{
+						jobutil.AnnotationKeyAllowImmutableUpdate: "true",
+					},
+				},
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+			wantErr: "metadata.labels[execution.furiko.io/job-config-uid]: Forbidden: field is immutable",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -865,6 +2045,23 @@ func TestValidateJobUpdate(t *testing.T) {
 	}
 }
 
+var optionSpecForJobCreate = &v1alpha1.OptionSpec{
+	Options: []v1alpha1.Option{
+		{
+			Type: v1alpha1.OptionTypeBool,
+			Name: "dry_run",
+			Bool: &v1alpha1.BoolOptionConfig{
+				Format: v1alpha1.BoolOptionFormatTrueFalse,
+			},
+		},
+		{
+			Type:     v1alpha1.OptionTypeString,
+			Name:     "target",
+			Required: true,
+		},
+	},
+}
+
 func TestValidateJobCreate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1048,6 +2245,216 @@ func TestValidateJobCreate(t *testing.T) {
 			},
 			wantErr: "spec.startPolicy: Forbidden: cannot create new Job for JobConfig jobconfig-sample, which would exceed maximum queue length of 5",
 		},
+		{
+			name: "can create Job with valid optionValues",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type:         v1alpha1.JobTypeAdhoc,
+					Template:     &jobTemplateSpecBasic.Spec,
+					OptionValues: `{"dry_run":true,"target":"db-1"}`,
+				},
+			},
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						Option: optionSpecForJobCreate,
+					},
+				},
+			},
+		},
+		{
+			name: "cannot create Job with unknown option name",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type:         v1alpha1.JobTypeAdhoc,
+					Template:     &jobTemplateSpecBasic.Spec,
+					OptionValues: `{"dry_run":true,"target":"db-1","unknown":"x"}`,
+				},
+			},
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						Option: optionSpecForJobCreate,
+					},
+				},
+			},
+			wantErr: `spec.optionValues: Unsupported value: "unknown": supported values: "dry_run", "target"`,
+		},
+		{
+			name: "cannot create Job with option type mismatch",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type:         v1alpha1.JobTypeAdhoc,
+					Template:     &jobTemplateSpecBasic.Spec,
+					OptionValues: `{"dry_run":"yes","target":"db-1"}`,
+				},
+			},
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						Option: optionSpecForJobCreate,
+					},
+				},
+			},
+			wantErr: "spec.optionValues[dry_run]",
+		},
+		{
+			name: "cannot create Job with missing required option",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type:         v1alpha1.JobTypeAdhoc,
+					Template:     &jobTemplateSpecBasic.Spec,
+					OptionValues: `{"dry_run":true}`,
+				},
+			},
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						Option: optionSpecForJobCreate,
+					},
+				},
+			},
+			wantErr: "spec.optionValues[target]: Required value",
+		},
+		{
+			name: "cannot create Job failing option rule",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type:         v1alpha1.JobTypeAdhoc,
+					Template:     &jobTemplateSpecBasic.Spec,
+					OptionValues: `{"dry_run":false,"target":"db-1"}`,
+				},
+			},
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						Option: &v1alpha1.OptionSpec{
+							Options: optionSpecForJobCreate.Options,
+							Rules: []v1alpha1.OptionRule{
+								{
+									Expression: `option.dry_run || option.target != "db-1"`,
+									Message:    "db-1 can only be targeted in dry run",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: "spec.optionValues: Forbidden: db-1 can only be targeted in dry run",
+		},
+		{
+			name: "cannot create Job with blocked image tag",
+			cfgs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					ImagePolicy: &configv1alpha1.ImagePolicySpec{
+						BlockedTags: []string{"latest"},
+					},
+				},
+			},
+			rj: &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					Type:     v1alpha1.JobTypeAdhoc,
+					Template: &jobTemplateSpecBasic.Spec,
+				},
+			},
+			wantErr: "spec.template.task.template.spec.containers[0].image: Forbidden: image alpine uses blocked tag latest",
+		},
+		{
+			name: "can create Job with substituted image from allowed registry",
+			cfgs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					ImagePolicy: &configv1alpha1.ImagePolicySpec{
+						AllowedRegistries: []string{"registry.example.com"},
+						BlockedTags:       []string{"latest"},
+					},
+				},
+			},
+			rj: &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "container",
+											Image: "registry.example.com/alpine:${option.tag}",
+										},
+									},
+								},
+							},
+						},
+					},
+					Substitutions: map[string]string{
+						"option.tag": "3.16",
+					},
+				},
+			},
+		},
+		{
+			name: "cannot create Job with substituted image from disallowed registry",
+			cfgs: controllercontext.ConfigsMap{
+				configv1alpha1.JobExecutionConfigName: &configv1alpha1.JobExecutionConfig{
+					ImagePolicy: &configv1alpha1.ImagePolicySpec{
+						AllowedRegistries: []string{"registry.example.com"},
+					},
+				},
+			},
+			rj: &v1alpha1.Job{
+				Spec: v1alpha1.JobSpec{
+					Type: v1alpha1.JobTypeAdhoc,
+					Template: &v1alpha1.JobTemplateSpec{
+						Task: v1alpha1.JobTaskSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{
+									Containers: []corev1.Container{
+										{
+											Name:  "container",
+											Image: "${option.registry}/alpine:3.16",
+										},
+									},
+								},
+							},
+						},
+					},
+					Substitutions: map[string]string{
+						"option.registry": "quay.io",
+					},
+				},
+			},
+			wantErr: "spec.template.task.template.spec.containers[0].image: Forbidden: image quay.io/alpine:3.16 is not from an allowed registry",
+		},
+		{
+			name: "cannot create Job with invalid optionValues",
+			rj: &v1alpha1.Job{
+				ObjectMeta: objectMetaJobWithAllReferences,
+				Spec: v1alpha1.JobSpec{
+					Type:         v1alpha1.JobTypeAdhoc,
+					Template:     &jobTemplateSpecBasic.Spec,
+					OptionValues: `[`,
+				},
+			},
+			rjcs: []*v1alpha1.JobConfig{
+				{
+					ObjectMeta: objectMetaJobConfig,
+					Spec: v1alpha1.JobConfigSpec{
+						Option: optionSpecForJobCreate,
+					},
+				},
+			},
+			wantErr: "spec.optionValues: Invalid value",
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/tzutils"
+)
+
+const (
+	// numNextScheduleTimes is the number of upcoming schedule times to return in
+	// warnings for a JobConfig's cron schedule.
+	numNextScheduleTimes = 3
+
+	defaultTimezone = "UTC"
+)
+
+// GetJobConfigWarnings returns warnings for a *v1alpha1.JobConfig, which
+// includes any deprecated fields or values used and feedback about its schedule.
+// Unlike validation errors, warnings will not cause the JobConfig to be rejected.
+func (v *Validator) GetJobConfigWarnings(rjc *v1alpha1.JobConfig) []string {
+	var warnings []string
+	if spec := rjc.Spec.Schedule; spec != nil && spec.Cron != nil {
+		fldPath := field.NewPath("spec", "schedule")
+		warnings = append(warnings, v.GetTimezoneWarnings(spec.Cron.Timezone, fldPath.Child("cron", "timezone"))...)
+		warnings = append(warnings, v.GetScheduleWarnings(spec, rjc, fldPath)...)
+	}
+	return warnings
+}
+
+// GetTimezoneWarnings returns warnings if the Timezone is a deprecated tzdata
+// name, with a pointer to its canonical replacement.
+func (v *Validator) GetTimezoneWarnings(timezone string, fldPath *field.Path) []string {
+	var warnings []string
+	if replacement, ok := tzutils.GetDeprecatedTimezoneReplacement(timezone); ok {
+		warnings = append(warnings, fmt.Sprintf("%v: timezone %q is deprecated, use %q instead",
+			fldPath, timezone, replacement))
+	}
+	return warnings
+}
+
+// GetScheduleWarnings returns a warning containing the next few times that the
+// JobConfig will be scheduled, so that mistakes in the cron expression can be
+// caught immediately. No warnings are returned if the schedule is disabled or
+// invalid, since invalid schedules are rejected by validation.
+func (v *Validator) GetScheduleWarnings(spec *v1alpha1.ScheduleSpec, rjc *v1alpha1.JobConfig, fldPath *field.Path) []string {
+	if spec.Disabled || spec.Cron == nil || len(spec.Cron.Expression) == 0 {
+		return nil
+	}
+
+	cfg, err := v.ctrlContext.Configs().CronForNamespace(rjc.Namespace)
+	if err != nil {
+		return nil
+	}
+	expr, err := parseCronExpression(cfg, spec.Cron.Expression, &rjc.ObjectMeta)
+	if err != nil {
+		return nil
+	}
+
+	tzstring := spec.Cron.Timezone
+	if tzstring == "" {
+		tzstring = defaultTimezone
+		if tz := cfg.DefaultTimezone; tz != nil && len(*tz) > 0 {
+			tzstring = *tz
+		}
+	}
+	timezone, err := tzutils.ParseTimezone(tzstring)
+	if err != nil {
+		return nil
+	}
+
+	fromTime := Clock.Now()
+	if constraints := spec.Constraints; constraints != nil {
+		if nbf := constraints.NotBefore; !nbf.IsZero() && fromTime.Before(nbf.Time) {
+			fromTime = nbf.Time.Add(-time.Nanosecond)
+		}
+	}
+
+	nextTimes := make([]string, 0, numNextScheduleTimes)
+	next := fromTime.In(timezone)
+	for len(nextTimes) < numNextScheduleTimes {
+		next = expr.Next(next)
+		if next.IsZero() {
+			break
+		}
+		if constraints := spec.Constraints; constraints != nil {
+			if naf := constraints.NotAfter; !naf.IsZero() && next.After(naf.Time) {
+				break
+			}
+		}
+		nextTimes = append(nextTimes, next.Format(time.RFC3339))
+	}
+
+	fldPath = fldPath.Child("cron", "expression")
+	if len(nextTimes) == 0 {
+		return []string{fmt.Sprintf("%v: schedule %q will never be triggered", fldPath, spec.Cron.Expression)}
+	}
+	return []string{fmt.Sprintf("%v: schedule %q will next be triggered at %v (%v)",
+		fldPath, spec.Cron.Expression, strings.Join(nextTimes, ", "), tzstring)}
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/validation"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func TestGetJobConfigWarnings(t *testing.T) {
+	validation.Clock = clock.NewFakeClock(testutils.Mktime(mockStartTime))
+
+	newJobConfig := func(spec *v1alpha1.ScheduleSpec) *v1alpha1.JobConfig {
+		return &v1alpha1.JobConfig{
+			ObjectMeta: objectMetaJobConfig,
+			Spec: v1alpha1.JobConfigSpec{
+				Schedule: spec,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		cfgs map[configv1alpha1.ConfigName]runtime.Object
+		rjc  *v1alpha1.JobConfig
+		want []string
+	}{
+		{
+			name: "no schedule",
+			rjc:  newJobConfig(nil),
+		},
+		{
+			name: "disabled schedule",
+			rjc: newJobConfig(&v1alpha1.ScheduleSpec{
+				Cron: &v1alpha1.CronSchedule{
+					Expression: "0 5 * * *",
+				},
+				Disabled: true,
+			}),
+		},
+		{
+			name: "invalid schedule",
+			rjc: newJobConfig(&v1alpha1.ScheduleSpec{
+				Cron: &v1alpha1.CronSchedule{
+					Expression: "500 10 * * *",
+				},
+			}),
+		},
+		{
+			name: "next schedule times",
+			rjc: newJobConfig(&v1alpha1.ScheduleSpec{
+				Cron: &v1alpha1.CronSchedule{
+					Expression: "0 5 * * *",
+					Timezone:   "Asia/Singapore",
+				},
+			}),
+			want: []string{
+				`spec.schedule.cron.expression: schedule "0 5 * * *" will next be triggered at ` +
+					`2021-02-10T05:00:00+08:00, 2021-02-11T05:00:00+08:00, 2021-02-12T05:00:00+08:00 (Asia/Singapore)`,
+			},
+		},
+		{
+			name: "next schedule times with default timezone from config",
+			cfgs: map[configv1alpha1.ConfigName]runtime.Object{
+				configv1alpha1.CronExecutionConfigName: &configv1alpha1.CronExecutionConfig{
+					DefaultTimezone: pointer.String("Asia/Tokyo"),
+				},
+			},
+			rjc: newJobConfig(&v1alpha1.ScheduleSpec{
+				Cron: &v1alpha1.CronSchedule{
+					Expression: "0 5 * * *",
+				},
+			}),
+			want: []string{
+				`spec.schedule.cron.expression: schedule "0 5 * * *" will next be triggered at ` +
+					`2021-02-10T05:00:00+09:00, 2021-02-11T05:00:00+09:00, 2021-02-12T05:00:00+09:00 (Asia/Tokyo)`,
+			},
+		},
+		{
+			name: "next schedule times with constraints",
+			rjc: newJobConfig(&v1alpha1.ScheduleSpec{
+				Cron: &v1alpha1.CronSchedule{
+					Expression: "0 5 * * *",
+				},
+				Constraints: &v1alpha1.ScheduleContraints{
+					NotBefore: testutils.Mkmtimep("2021-03-01T00:00:00Z"),
+					NotAfter:  testutils.Mkmtimep("2021-03-02T12:00:00Z"),
+				},
+			}),
+			want: []string{
+				`spec.schedule.cron.expression: schedule "0 5 * * *" will next be triggered at ` +
+					`2021-03-01T05:00:00Z, 2021-03-02T05:00:00Z (UTC)`,
+			},
+		},
+		{
+			name: "schedule that will never be triggered",
+			rjc: newJobConfig(&v1alpha1.ScheduleSpec{
+				Cron: &v1alpha1.CronSchedule{
+					Expression: "0 5 * * *",
+				},
+				Constraints: &v1alpha1.ScheduleContraints{
+					NotAfter: testutils.Mkmtimep("2021-02-09T04:30:00Z"),
+				},
+			}),
+			want: []string{
+				`spec.schedule.cron.expression: schedule "0 5 * * *" will never be triggered`,
+			},
+		},
+		{
+			name: "deprecated timezone",
+			rjc: newJobConfig(&v1alpha1.ScheduleSpec{
+				Cron: &v1alpha1.CronSchedule{
+					Expression: "0 5 * * *",
+					Timezone:   "Singapore",
+				},
+			}),
+			want: []string{
+				`spec.schedule.cron.timezone: timezone "Singapore" is deprecated, use "Asia/Singapore" instead`,
+				`spec.schedule.cron.expression: schedule "0 5 * * *" will next be triggered at ` +
+					`2021-02-10T05:00:00+08:00, 2021-02-11T05:00:00+08:00, 2021-02-12T05:00:00+08:00 (Singapore)`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrlContext := mock.NewContext()
+			ctrlContext.MockConfigs().SetConfigs(tt.cfgs)
+			if err := ctrlContext.Start(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			validator := validation.NewValidator(ctrlContext)
+			got := validator.GetJobConfigWarnings(tt.rjc)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("GetJobConfigWarnings() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variablecontext
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+)
+
+// ApplyPodTemplatePatches returns a PodTemplateSpec after applying all patches
+// in the Job's template whose conditions are satisfied. Conditions are evaluated
+// against the Job's substitutions and job context variables.
+func ApplyPodTemplatePatches(rj *execution.Job, podTemplate v1.PodTemplateSpec) (v1.PodTemplateSpec, error) {
+	if rj.Spec.Template == nil || len(rj.Spec.Template.Patches) == 0 {
+		return podTemplate, nil
+	}
+
+	// Substitutions specified in the JobSpec takes highest priority.
+	variables := ContextProvider.MakeVariablesFromJob(rj)
+	for k, v := range rj.Spec.Substitutions {
+		variables[k] = v
+	}
+
+	template := podTemplate.DeepCopy()
+	for i, patch := range rj.Spec.Template.Patches {
+		if !EvaluatePatchCondition(patch.Condition, variables) {
+			continue
+		}
+		newTemplate, err := ApplyPodTemplatePatch(*template, patch.Patch.Raw)
+		if err != nil {
+			return podTemplate, errors.Wrapf(err, "cannot apply patch %v", i)
+		}
+		template = &newTemplate
+	}
+
+	return *template, nil
+}
+
+// ApplyPodTemplatePatch applies a single strategic merge patch to a
+// PodTemplateSpec.
+func ApplyPodTemplatePatch(podTemplate v1.PodTemplateSpec, patch []byte) (v1.PodTemplateSpec, error) {
+	var newTemplate v1.PodTemplateSpec
+	original, err := json.Marshal(podTemplate)
+	if err != nil {
+		return newTemplate, errors.Wrapf(err, "cannot marshal pod template")
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, patch, v1.PodTemplateSpec{})
+	if err != nil {
+		return newTemplate, errors.Wrapf(err, "cannot apply strategic merge patch")
+	}
+	if err := json.Unmarshal(patched, &newTemplate); err != nil {
+		return newTemplate, errors.Wrapf(err, "cannot unmarshal patched pod template")
+	}
+	return newTemplate, nil
+}
+
+// EvaluatePatchCondition returns true if the condition is satisfied given the
+// map of context variables.
+func EvaluatePatchCondition(condition execution.JobTemplatePatchCondition, variables map[string]string) bool {
+	value := variables[condition.Variable]
+	if len(condition.Values) == 0 {
+		return value == "true"
+	}
+	for _, v := range condition.Values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
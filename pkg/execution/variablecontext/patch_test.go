@@ -0,0 +1,170 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variablecontext_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
+)
+
+var (
+	patchPodTemplate = v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "container",
+					Image: "hello-world",
+				},
+			},
+		},
+	}
+
+	patchGPU = execution.JobTemplatePatch{
+		Condition: execution.JobTemplatePatchCondition{
+			Variable: "option.use_gpu",
+		},
+		Patch: runtime.RawExtension{
+			Raw: []byte(`{"spec":{"containers":[{"name":"container","resources":{"limits":{"nvidia.com/gpu":"1"}}}]}}`),
+		},
+	}
+
+	patchRegion = execution.JobTemplatePatch{
+		Condition: execution.JobTemplatePatchCondition{
+			Variable: "option.region",
+			Values:   []string{"us-east-1", "us-west-2"},
+		},
+		Patch: runtime.RawExtension{
+			Raw: []byte(`{"spec":{"nodeSelector":{"region":"us"}}}`),
+		},
+	}
+
+	patchInvalid = execution.JobTemplatePatch{
+		Condition: execution.JobTemplatePatchCondition{
+			Variable: "job.type",
+			Values:   []string{"Adhoc"},
+		},
+		Patch: runtime.RawExtension{
+			Raw: []byte(`{"spec":{"containers":"invalid"}}`),
+		},
+	}
+
+	patchPodTemplateGPU = v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  "container",
+					Image: "hello-world",
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							"nvidia.com/gpu": resource.MustParse("1"),
+						},
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestApplyPodTemplatePatches(t *testing.T) {
+	tests := []struct {
+		name          string
+		patches       []execution.JobTemplatePatch
+		substitutions map[string]string
+		want          v1.PodTemplateSpec
+		wantErr       bool
+	}{
+		{
+			name: "no patches",
+			want: patchPodTemplate,
+		},
+		{
+			name:    "condition not satisfied",
+			patches: []execution.JobTemplatePatch{patchGPU, patchRegion},
+			substitutions: map[string]string{
+				"option.use_gpu": "false",
+				"option.region":  "ap-southeast-1",
+			},
+			want: patchPodTemplate,
+		},
+		{
+			name:    "condition satisfied with true value",
+			patches: []execution.JobTemplatePatch{patchGPU},
+			substitutions: map[string]string{
+				"option.use_gpu": "true",
+			},
+			want: patchPodTemplateGPU,
+		},
+		{
+			name:    "condition satisfied with matching value",
+			patches: []execution.JobTemplatePatch{patchGPU, patchRegion},
+			substitutions: map[string]string{
+				"option.use_gpu": "true",
+				"option.region":  "us-west-2",
+			},
+			want: func() v1.PodTemplateSpec {
+				template := patchPodTemplateGPU.DeepCopy()
+				template.Spec.NodeSelector = map[string]string{"region": "us"}
+				return *template
+			}(),
+		},
+		{
+			name:    "cannot apply invalid patch",
+			patches: []execution.JobTemplatePatch{patchInvalid},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobNamespace,
+					Name:      jobName,
+				},
+				Spec: execution.JobSpec{
+					Type: execution.JobTypeAdhoc,
+					Template: &execution.JobTemplateSpec{
+						Task: execution.JobTaskSpec{
+							Template: patchPodTemplate,
+						},
+						Patches: tt.patches,
+					},
+					Substitutions: tt.substitutions,
+				},
+			}
+			got, err := variablecontext.ApplyPodTemplatePatches(rj, rj.Spec.Template.Task.Template)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApplyPodTemplatePatches() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("ApplyPodTemplatePatches() not equal:\n%v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
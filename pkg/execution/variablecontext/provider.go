@@ -89,9 +89,13 @@ func (c *defaultProvider) MakeVariablesFromJob(rj *execution.Job) map[string]str
 }
 
 func (c *defaultProvider) MakeVariablesFromTask(rj *execution.Job, task *tasks.TaskTemplate) map[string]string {
-	return map[string]string{
+	variables := map[string]string{
 		"task.name":        task.Name,
 		"task.namespace":   rj.GetNamespace(), // assumes Task shares namespace with Job
 		"task.retry_index": strconv.Itoa(int(task.RetryIndex)),
 	}
+	if task.Step != "" {
+		variables["task.step"] = task.Step
+	}
+	return variables
 }
@@ -30,8 +30,18 @@ type subFunc func(s string) string
 // after substituting context variables for the job context. Uses the Job's
 // substitutions field to specify overrides for the job context.
 func SubstitutePodTemplateSpecForJob(rj *execution.Job) v1.PodTemplateSpec {
+	return substitutePodTemplateSpecForJob(rj, rj.Spec.Template.Task.Template)
+}
+
+// SubstitutePodTemplateSpecForStep returns a PodTemplateSpec for a given step
+// in a Job after substituting context variables for the job context.
+func SubstitutePodTemplateSpecForStep(rj *execution.Job, step *execution.JobStepSpec) v1.PodTemplateSpec {
+	return substitutePodTemplateSpecForJob(rj, step.Task.Template)
+}
+
+func substitutePodTemplateSpecForJob(rj *execution.Job, podTemplate v1.PodTemplateSpec) v1.PodTemplateSpec {
 	var subMaps []map[string]string
-	template := rj.Spec.Template.Task.Template.DeepCopy()
+	template := podTemplate.DeepCopy()
 
 	// Substitutions specified in the JobSpec takes highest priority.
 	if len(rj.Spec.Substitutions) > 0 {
@@ -120,3 +130,38 @@ func substituteContainer(container v1.Container, sub subFunc) v1.Container {
 
 	return *newContainer
 }
+
+// SubstituteExternalTaskTemplateForTask returns an ExternalTaskTemplate after
+// substituting context variables for both the job and task context.
+func SubstituteExternalTaskTemplateForTask(
+	rj *execution.Job, template *tasks.TaskTemplate, external execution.ExternalTaskTemplate,
+) execution.ExternalTaskTemplate {
+	var subMaps []map[string]string
+	newTemplate := external.DeepCopy()
+
+	// Substitutions specified in the JobSpec takes highest priority.
+	if len(rj.Spec.Substitutions) > 0 {
+		subMaps = append(subMaps, rj.Spec.Substitutions)
+	}
+
+	// Substitute job and task context variables. Unlike Pods, the job context is
+	// not substituted separately by the controller.
+	subMaps = append(subMaps, ContextProvider.MakeVariablesFromJob(rj))
+	subMaps = append(subMaps, ContextProvider.MakeVariablesFromTask(rj, template))
+
+	removePrefixes := ContextProvider.GetAllPrefixes()
+	removePrefixes = append(removePrefixes, "option.")
+
+	sub := func(s string) string {
+		return options.SubstituteVariableMaps(s, subMaps, removePrefixes)
+	}
+
+	for i, cmd := range newTemplate.Command {
+		newTemplate.Command[i] = sub(cmd)
+	}
+	for i, envVar := range newTemplate.Env {
+		newTemplate.Env[i].Value = sub(envVar.Value)
+	}
+
+	return *newTemplate
+}
@@ -271,3 +271,73 @@ func TestSubstitutePodSpecForTask(t *testing.T) {
 		})
 	}
 }
+
+func TestSubstituteExternalTaskTemplateForTask(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables map[string]string
+		external  execution.ExternalTaskTemplate
+		want      execution.ExternalTaskTemplate
+	}{
+		{
+			name: "substitute job and task variables",
+			external: execution.ExternalTaskTemplate{
+				RunnerName: "runner",
+				Command:    []string{"echo", "${job.name}", "${task.name}"},
+				Env: []execution.ExternalTaskEnvVar{
+					{Name: "TASK_NAME", Value: "${task.name}"},
+				},
+			},
+			want: execution.ExternalTaskTemplate{
+				RunnerName: "runner",
+				Command:    []string{"echo", jobName, taskName},
+				Env: []execution.ExternalTaskEnvVar{
+					{Name: "TASK_NAME", Value: taskName},
+				},
+			},
+		},
+		{
+			name: "substitute custom variables",
+			variables: map[string]string{
+				"option.arg": "World",
+			},
+			external: execution.ExternalTaskTemplate{
+				RunnerName: "runner",
+				Command:    []string{"echo", "Hello ${option.arg}"},
+				Env: []execution.ExternalTaskEnvVar{
+					{Name: "UNKNOWN_ARG_NAME", Value: "${option.unknown_arg}"},
+				},
+			},
+			want: execution.ExternalTaskTemplate{
+				RunnerName: "runner",
+				Command:    []string{"echo", "Hello World"},
+				Env: []execution.ExternalTaskEnvVar{
+					{Name: "UNKNOWN_ARG_NAME", Value: ""},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rj := &execution.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobNamespace,
+					Name:      jobName,
+				},
+				Spec: execution.JobSpec{
+					Template:      &execution.JobTemplateSpec{},
+					Substitutions: tt.variables,
+				},
+			}
+			template := &tasks.TaskTemplate{
+				Name:       taskName,
+				RetryIndex: 1,
+			}
+			got := variablecontext.SubstituteExternalTaskTemplateForTask(rj, template, tt.external)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("SubstituteExternalTaskTemplateForTask() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -21,11 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	executiongroup "github.com/furiko-io/furiko/apis/execution"
@@ -33,12 +36,14 @@ import (
 	"github.com/furiko-io/furiko/pkg/execution/mutation"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
 	"github.com/furiko-io/furiko/pkg/runtime/webhook"
 	"github.com/furiko-io/furiko/pkg/utils/cmp"
 )
 
 const (
-	webhookName = "JobConfigMutatingWebhook"
+	webhookName             = "JobConfigMutatingWebhook"
+	waitForCacheSyncTimeout = 3 * time.Minute
 )
 
 var (
@@ -47,13 +52,19 @@ var (
 
 type Webhook struct {
 	controllercontext.Context
+	hasSynced []cache.InformerSynced
 }
 
 var _ controllermanager.Webhook = (*Webhook)(nil)
 
 func NewWebhook(ctrlContext controllercontext.Context) (*Webhook, error) {
+	executionConfigInformer := ctrlContext.Informers().Furiko().Execution().V1alpha1().ExecutionConfigs()
+
 	webhook := &Webhook{
 		Context: ctrlContext,
+		hasSynced: []cache.InformerSynced{
+			executionConfigInformer.Informer().HasSynced,
+		},
 	}
 	return webhook, nil
 }
@@ -67,6 +78,15 @@ func (w *Webhook) Ready() bool {
 }
 
 func (w *Webhook) Start(ctx context.Context) error {
+	defer utilruntime.HandleCrash()
+	klog.InfoS("jobconfigmutatingwebhook: starting webhook")
+
+	if err := controllerutil.WaitForNamedCacheSyncWithTimeout(ctx, webhookName, waitForCacheSyncTimeout,
+		w.hasSynced...); err != nil {
+		klog.ErrorS(err, "jobconfigmutatingwebhook: cache sync timeout")
+		return err
+	}
+
 	atomic.StoreUint64(&readiness, 1)
 	klog.InfoS("jobconfigmutatingwebhook: started webhook")
 	return nil
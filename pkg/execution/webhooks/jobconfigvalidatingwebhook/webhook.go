@@ -21,12 +21,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	executiongroup "github.com/furiko-io/furiko/apis/execution"
@@ -34,10 +37,12 @@ import (
 	"github.com/furiko-io/furiko/pkg/execution/validation"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
 )
 
 const (
-	webhookName = "JobConfigValidatingWebhook"
+	webhookName             = "JobConfigValidatingWebhook"
+	waitForCacheSyncTimeout = 3 * time.Minute
 )
 
 var (
@@ -46,14 +51,21 @@ var (
 
 type Webhook struct {
 	controllercontext.Context
+	hasSynced []cache.InformerSynced
 }
 
 var _ controllermanager.Webhook = (*Webhook)(nil)
 
 func NewWebhook(ctrlContext controllercontext.Context) (*Webhook, error) {
+	taskTemplateInformer := ctrlContext.Informers().Furiko().Execution().V1alpha1().TaskTemplates()
+
 	webhook := &Webhook{
 		Context: ctrlContext,
+		hasSynced: []cache.InformerSynced{
+			taskTemplateInformer.Informer().HasSynced,
+		},
 	}
+
 	return webhook, nil
 }
 
@@ -66,6 +78,15 @@ func (w *Webhook) Ready() bool {
 }
 
 func (w *Webhook) Start(ctx context.Context) error {
+	defer utilruntime.HandleCrash()
+	klog.InfoS("jobconfigvalidatingwebhook: starting webhook")
+
+	if err := controllerutil.WaitForNamedCacheSyncWithTimeout(ctx, webhookName, waitForCacheSyncTimeout,
+		w.hasSynced...); err != nil {
+		klog.ErrorS(err, "jobconfigvalidatingwebhook: cache sync timeout")
+		return err
+	}
+
 	atomic.StoreUint64(&readiness, 1)
 	klog.InfoS("jobconfigvalidatingwebhook: started webhook")
 	return nil
@@ -126,6 +147,9 @@ func (w *Webhook) Handle(
 		resp.Allowed = true
 	}
 
+	// Notify clients of any deprecated fields or values without rejecting them.
+	resp.Warnings = validation.NewValidator(w).GetJobConfigWarnings(rjc)
+
 	return resp, nil
 }
 
@@ -30,10 +30,12 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 
 	executiongroup "github.com/furiko-io/furiko/apis/execution"
 	executionv1alpha1 "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/execution/mutation"
+	jobutil "github.com/furiko-io/furiko/pkg/execution/util/job"
 	executioninformers "github.com/furiko-io/furiko/pkg/generated/informers/externalversions/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
@@ -61,12 +63,16 @@ var _ controllermanager.Webhook = (*Webhook)(nil)
 
 func NewWebhook(ctrlContext controllercontext.Context) (*Webhook, error) {
 	jobconfigInformer := ctrlContext.Informers().Furiko().Execution().V1alpha1().JobConfigs()
+	executionConfigInformer := ctrlContext.Informers().Furiko().Execution().V1alpha1().ExecutionConfigs()
+	taskTemplateInformer := ctrlContext.Informers().Furiko().Execution().V1alpha1().TaskTemplates()
 
 	hook := &Webhook{
 		Context:           ctrlContext,
 		jobconfigInformer: jobconfigInformer,
 		hasSynced: []cache.InformerSynced{
 			jobconfigInformer.Informer().HasSynced,
+			executionConfigInformer.Informer().HasSynced,
+			taskTemplateInformer.Informer().HasSynced,
 		},
 	}
 
@@ -179,6 +185,21 @@ func (w *Webhook) Handle(
 }
 
 // Patch returns the result after mutating a Job in-place.
+//
+// For dry-run creation requests, the task templates will also be rendered with
+// all options and context variables substituted, so that the returned object can
+// be used to preview exactly what will be run.
+//
+// The username of the user who created the Job is also stored on the Job, which
+// is used to enforce per-user quotas on ad-hoc Jobs.
 func (w *Webhook) Patch(req *admissionv1.AdmissionRequest, oldRj, rj *executionv1alpha1.Job) *webhook.Result {
-	return mutation.NewJobPatcher(w).Patch(req.Operation, oldRj, rj)
+	patcher := mutation.NewJobPatcher(w)
+	result := patcher.Patch(req.Operation, oldRj, rj)
+	if req.Operation == admissionv1.Create {
+		jobutil.SetCreatedBy(rj, req.UserInfo.Username)
+	}
+	if len(result.Errors) == 0 && req.Operation == admissionv1.Create && pointer.BoolDeref(req.DryRun, false) {
+		result.Merge(patcher.Render(rj))
+	}
+	return result
 }
@@ -60,12 +60,14 @@ var _ controllermanager.Webhook = (*Webhook)(nil)
 
 func NewWebhook(ctrlContext controllercontext.Context) (*Webhook, error) {
 	jobconfigInformer := ctrlContext.Informers().Furiko().Execution().V1alpha1().JobConfigs()
+	jobInformer := ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs()
 
 	webhook := &Webhook{
 		Context:           ctrlContext,
 		jobconfigInformer: jobconfigInformer,
 		hasSynced: []cache.InformerSynced{
 			jobconfigInformer.Informer().HasSynced,
+			jobInformer.Informer().HasSynced,
 		},
 	}
 
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package furikotest contains utilities for users to unit test their JobConfigs
+// without a cluster, such as whether a cron schedule together with a
+// concurrency policy produces the expected Jobs, or what Pods will be created
+// for a given set of option values.
+//
+// Simulations are run against in-memory clientsets and a fake clock, and are
+// fully deterministic:
+//
+//	sim, err := furikotest.NewSimulator(ctx, startTime, jobConfig)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer sim.Close()
+//	sim.Duration = furikotest.FixedDuration(7 * time.Minute)
+//	if err := sim.RunUntil(ctx, startTime.Add(time.Hour)); err != nil {
+//		t.Fatal(err)
+//	}
+//	for _, run := range sim.Runs() {
+//		...
+//	}
+//
+// The final Pods of a JobConfig can be rendered and compared against golden
+// files, which are updated by running tests with FURIKO_UPDATE_GOLDEN=true:
+//
+//	pods, err := furikotest.RenderPods(ctx, jobConfig, furikotest.RenderOptions{
+//		OptionValues: map[string]interface{}{"name": "world"},
+//		Time:         startTime,
+//	})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	furikotest.AssertGolden(t, "testdata/pods.yaml", pods)
+package furikotest
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package envtest
+
+import (
+	"io/fs"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/furiko-io/furiko/config/crd"
+)
+
+// CRDs returns the CustomResourceDefinitions of all Furiko resources.
+func CRDs() ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	paths, err := fs.Glob(crd.Bases, "bases/*.yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list crd manifests")
+	}
+
+	crds := make([]*apiextensionsv1.CustomResourceDefinition, 0, len(paths))
+	for _, path := range paths {
+		data, err := fs.ReadFile(crd.Bases, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read %v", path)
+		}
+		obj := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, obj); err != nil {
+			return nil, errors.Wrapf(err, "cannot unmarshal %v", path)
+		}
+		crds = append(crds, obj)
+	}
+
+	return crds, nil
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package envtest provides a harness for integration tests against a real
+// kube-apiserver and etcd with the Furiko CRDs installed, for use by
+// downstream projects that build on Furiko, such as custom executors or
+// webhooks.
+//
+// The harness uses the controller-runtime envtest package, which requires the
+// kube-apiserver and etcd binaries. Tests using the harness are skipped if the
+// binaries are not configured, either via Options.BinaryAssetsDirectory or the
+// KUBEBUILDER_ASSETS environment variable. Within this repository, run
+// "make test-integration" to download the binaries and run the tests.
+package envtest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crenvtest "sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	furikoscheme "github.com/furiko-io/furiko/pkg/generated/clientset/versioned/scheme"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+)
+
+const (
+	// EnvBinaryAssets is the environment variable used by envtest to locate the
+	// kube-apiserver and etcd binaries.
+	EnvBinaryAssets = "KUBEBUILDER_ASSETS"
+)
+
+// Options contains the options for starting a Harness.
+type Options struct {
+	// BinaryAssetsDirectory is the directory containing the kube-apiserver and
+	// etcd binaries. Defaults to the value of KUBEBUILDER_ASSETS.
+	BinaryAssetsDirectory string
+
+	// CRDs contains additional CustomResourceDefinitions to install, such as
+	// those of custom executors.
+	CRDs []*apiextensionsv1.CustomResourceDefinition
+
+	// BootstrapConfig is used to set up the controller context. Defaults to an
+	// empty config if not specified.
+	BootstrapConfig *configv1alpha1.BootstrapConfigSpec
+}
+
+// Harness is a running test environment with the Furiko CRDs installed.
+type Harness struct {
+	// Environment is the underlying envtest environment.
+	Environment *crenvtest.Environment
+
+	// Config is the rest.Config to connect to the test kube-apiserver.
+	Config *rest.Config
+
+	// Client is a generic client that can read and write both Kubernetes and
+	// Furiko objects.
+	Client client.Client
+
+	// Context is a controller context that is connected to the test
+	// kube-apiserver, which can be used to set up controllers and webhooks.
+	// It is not started by the harness.
+	Context controllercontext.Context
+}
+
+// Start starts a new test environment, which will be stopped when the test
+// and all of its subtests complete. The test is skipped if the envtest
+// binaries are not configured.
+func Start(t testing.TB, opts Options) *Harness {
+	t.Helper()
+	if opts.BinaryAssetsDirectory == "" && os.Getenv(EnvBinaryAssets) == "" {
+		t.Skipf("skipping integration test, %v is not set", EnvBinaryAssets)
+	}
+
+	h, err := NewHarness(opts)
+	if err != nil {
+		t.Fatalf("cannot start test environment: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := h.Stop(); err != nil {
+			t.Errorf("cannot stop test environment: %v", err)
+		}
+	})
+
+	return h
+}
+
+// NewHarness starts a new test environment. Stop must be called to clean up
+// the environment once it is no longer needed.
+func NewHarness(opts Options) (*Harness, error) {
+	crds, err := CRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := NewScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	env := &crenvtest.Environment{
+		Scheme:                scheme,
+		CRDs:                  append(crds, opts.CRDs...),
+		BinaryAssetsDirectory: opts.BinaryAssetsDirectory,
+	}
+	cfg, err := env.Start()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot start envtest environment")
+	}
+
+	h := &Harness{
+		Environment: env,
+		Config:      cfg,
+	}
+
+	if err := h.setUp(scheme, opts); err != nil {
+		_ = env.Stop()
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *Harness) setUp(scheme *runtime.Scheme, opts Options) error {
+	c, err := client.New(h.Config, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.Wrapf(err, "cannot create client")
+	}
+	h.Client = c
+
+	bootstrapConfig := opts.BootstrapConfig
+	if bootstrapConfig == nil {
+		bootstrapConfig = &configv1alpha1.BootstrapConfigSpec{}
+	}
+	ctrlContext, err := controllercontext.NewForConfig(h.Config, bootstrapConfig)
+	if err != nil {
+		return errors.Wrapf(err, "cannot create controller context")
+	}
+	h.Context = ctrlContext
+
+	return nil
+}
+
+// Stop stops the test environment.
+func (h *Harness) Stop() error {
+	return h.Environment.Stop()
+}
+
+// Load creates all of the given objects in order, such as Namespaces,
+// JobConfigs and Jobs used as fixtures in a test.
+func (h *Harness) Load(ctx context.Context, objs ...client.Object) error {
+	for _, obj := range objs {
+		if err := h.Client.Create(ctx, obj); err != nil {
+			return errors.Wrapf(err, "cannot create %T %v", obj, client.ObjectKeyFromObject(obj))
+		}
+	}
+	return nil
+}
+
+// NewScheme returns a new runtime.Scheme containing both Kubernetes and Furiko
+// types.
+func NewScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrapf(err, "cannot add kubernetes types to scheme")
+	}
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrapf(err, "cannot add apiextensions types to scheme")
+	}
+	if err := furikoscheme.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrapf(err, "cannot add furiko types to scheme")
+	}
+	return scheme, nil
+}
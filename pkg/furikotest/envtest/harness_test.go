@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package envtest_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/furikotest/envtest"
+)
+
+func TestCRDs(t *testing.T) {
+	crds, err := envtest.CRDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, len(crds))
+	for _, crd := range crds {
+		names = append(names, crd.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{
+		"executionconfigs.execution.furiko.io",
+		"externaltasks.execution.furiko.io",
+		"furikoconfigs.execution.furiko.io",
+		"jobconfigs.execution.furiko.io",
+		"jobgroups.execution.furiko.io",
+		"jobs.execution.furiko.io",
+		"tasktemplates.execution.furiko.io",
+	}, names)
+}
+
+func TestHarness(t *testing.T) {
+	h := envtest.Start(t, envtest.Options{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := h.Load(ctx,
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		},
+		&execution.JobConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "jobconfig", Namespace: "test"},
+			Spec: execution.JobConfigSpec{
+				Concurrency: execution.ConcurrencySpec{
+					Policy: execution.ConcurrencyPolicyForbid,
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rjc, err := h.Context.Clientsets().Furiko().ExecutionV1alpha1().JobConfigs("test").
+		Get(ctx, "jobconfig", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, execution.ConcurrencyPolicyForbid, rjc.Spec.Concurrency.Policy)
+}
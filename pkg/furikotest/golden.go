@@ -0,0 +1,67 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package furikotest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// EnvUpdateGolden is the environment variable that, when set to true, causes
+	// AssertGolden to overwrite golden files with the actual output instead of
+	// comparing against them.
+	EnvUpdateGolden = "FURIKO_UPDATE_GOLDEN"
+)
+
+// AssertGolden marshals the object as YAML and compares it against the
+// contents of the golden file at path, failing the test if they differ.
+//
+// Golden files can be created or updated by running the tests with
+// FURIKO_UPDATE_GOLDEN=true.
+func AssertGolden(t testing.TB, path string, obj interface{}) {
+	t.Helper()
+	got, err := yaml.Marshal(obj)
+	if err != nil {
+		t.Fatalf("cannot marshal object: %v", err)
+	}
+
+	if update, _ := strconv.ParseBool(os.Getenv(EnvUpdateGolden)); update {
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("cannot create directory for golden file: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0600); err != nil {
+			t.Fatalf("cannot write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		t.Fatalf("cannot read golden file, run with %v=true to create it: %v", EnvUpdateGolden, err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("output does not match golden file %v, run with %v=true to update it\ndiff = %v",
+			path, EnvUpdateGolden, cmp.Diff(string(want), string(got)))
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package furikotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/mutation"
+	"github.com/furiko-io/furiko/pkg/execution/taskexecutor/podtaskexecutor"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/execution/validation"
+	"github.com/furiko-io/furiko/pkg/execution/variablecontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/runtime/webhook"
+)
+
+// RenderOptions contains the options used to render the Pods of a JobConfig.
+type RenderOptions struct {
+	// OptionValues specifies the values for the JobConfig's options.
+	OptionValues map[string]interface{}
+
+	// Time is the creation time of the Job. Defaults to the current time, which
+	// should be avoided for golden file comparison.
+	Time time.Time
+
+	// TaskTemplates contains any TaskTemplates referenced by the JobConfig.
+	TaskTemplates []*execution.TaskTemplate
+}
+
+// RenderPods returns the Pods that would be created for the first task of a
+// Job started from the JobConfig, after the JobConfig and Job are defaulted by
+// the mutating webhook and all option values and context variables are
+// substituted. A Pod is returned for each step if the JobConfig has steps.
+//
+// Dynamic configuration, such as namespace-level task template defaults and
+// resource recommendations, are not taken into account.
+func RenderPods(ctx context.Context, rjc *execution.JobConfig, opts RenderOptions) ([]*corev1.Pod, error) {
+	ctrlContext := mock.NewContext()
+	mutator := mutation.NewMutator(ctrlContext)
+
+	rjc = rjc.DeepCopy()
+	if rjc.UID == "" {
+		rjc.UID = types.UID(fmt.Sprintf("%v-%v", rjc.Namespace, rjc.Name))
+	}
+	if err := resultError(mutator.MutateJobConfig(rjc), mutator.MutateCreateJobConfig(rjc)); err != nil {
+		return nil, errors.Wrapf(err, "cannot mutate jobconfig")
+	}
+	if errs := validation.NewValidator(ctrlContext).ValidateJobConfig(rjc); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid jobconfig")
+	}
+
+	// Add objects to the clientset before starting informers, so that they are
+	// guaranteed to be in the informer caches once synced.
+	client := ctrlContext.MockClientsets().FurikoMock()
+	if err := client.Tracker().Add(rjc); err != nil {
+		return nil, errors.Wrapf(err, "cannot add jobconfig")
+	}
+	for _, template := range opts.TaskTemplates {
+		if err := client.Tracker().Add(template); err != nil {
+			return nil, errors.Wrapf(err, "cannot add tasktemplate %v", template.Name)
+		}
+	}
+	informers := ctrlContext.Informers().Furiko().Execution().V1alpha1()
+	hasSynced := []cache.InformerSynced{
+		informers.JobConfigs().Informer().HasSynced,
+		informers.TaskTemplates().Informer().HasSynced,
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := ctrlContext.Start(ctx); err != nil {
+		return nil, errors.Wrapf(err, "cannot start context")
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), hasSynced...) {
+		return nil, errors.New("caches not synced")
+	}
+
+	rj, err := newRenderJob(rjc, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := resultError(mutator.MutateCreateJob(rj), mutator.MutateJob(rj)); err != nil {
+		return nil, errors.Wrapf(err, "cannot mutate job")
+	}
+
+	return renderPods(rj)
+}
+
+func newRenderJob(rjc *execution.JobConfig, opts RenderOptions) (*execution.Job, error) {
+	createTime := opts.Time
+	if createTime.IsZero() {
+		createTime = time.Now()
+	}
+
+	name := jobconfig.GenerateName(rjc.Name, createTime)
+	rj := &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         rjc.Namespace,
+			UID:               types.UID(fmt.Sprintf("%v-%v", rjc.Namespace, name)),
+			CreationTimestamp: metav1.NewTime(createTime),
+		},
+		Spec: execution.JobSpec{
+			ConfigName: rjc.Name,
+		},
+	}
+
+	if len(opts.OptionValues) > 0 {
+		optionValues, err := json.Marshal(opts.OptionValues)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal option values")
+		}
+		rj.Spec.OptionValues = string(optionValues)
+	}
+
+	return rj, nil
+}
+
+// renderPods applies template patches and substitutes job context variables in
+// the same way as the JobController, before creating the Pods.
+func renderPods(rj *execution.Job) ([]*corev1.Pod, error) {
+	if len(rj.Spec.Template.Steps) == 0 {
+		template, err := variablecontext.ApplyPodTemplatePatches(rj, rj.Spec.Template.Task.Template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot apply patches")
+		}
+		rj.Spec.Template.Task.Template = template
+		rj.Spec.Template.Task.Template = variablecontext.SubstitutePodTemplateSpecForJob(rj)
+		pod, err := podtaskexecutor.NewPod(rj, 1)
+		if err != nil {
+			return nil, err
+		}
+		return []*corev1.Pod{pod}, nil
+	}
+
+	pods := make([]*corev1.Pod, 0, len(rj.Spec.Template.Steps))
+	for i := range rj.Spec.Template.Steps {
+		step := &rj.Spec.Template.Steps[i]
+		template, err := variablecontext.ApplyPodTemplatePatches(rj, step.Task.Template)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot apply patches for step %v", step.Name)
+		}
+		step.Task.Template = template
+		step.Task.Template = variablecontext.SubstitutePodTemplateSpecForStep(rj, step)
+	}
+	for _, step := range rj.Spec.Template.Steps {
+		pod, err := podtaskexecutor.NewPodForStep(rj, step.Name)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+// resultError returns an error aggregating the errors of all webhook results.
+func resultError(results ...*webhook.Result) error {
+	var errs field.ErrorList
+	for _, result := range results {
+		errs = append(errs, result.Errors...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs.ToAggregate()
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package furikotest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/furikotest"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+var (
+	renderOptionSpec = &execution.OptionSpec{
+		Options: []execution.Option{
+			{
+				Type:     execution.OptionTypeString,
+				Name:     "name",
+				Required: true,
+			},
+			{
+				Type: execution.OptionTypeString,
+				Name: "greeting",
+				String: &execution.StringOptionConfig{
+					Default: "Hello",
+				},
+			},
+		},
+	}
+
+	renderPodTemplate = corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "container",
+					Image: "alpine",
+					Args: []string{
+						"echo",
+						"${option.greeting} ${option.name} from ${job.name} (attempt ${task.retry_index})",
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestRenderPods(t *testing.T) {
+	tests := []struct {
+		name      string
+		jobConfig *execution.JobConfig
+		opts      furikotest.RenderOptions
+		golden    string
+		wantErr   bool
+	}{
+		{
+			name: "single task",
+			jobConfig: &execution.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "jobconfig", Namespace: "test"},
+				Spec: execution.JobConfigSpec{
+					Concurrency: execution.ConcurrencySpec{
+						Policy: execution.ConcurrencyPolicyAllow,
+					},
+					Option: renderOptionSpec,
+					Template: execution.JobTemplate{
+						Spec: execution.JobTemplateSpec{
+							Task: execution.JobTaskSpec{
+								Template: renderPodTemplate,
+							},
+						},
+					},
+				},
+			},
+			opts: furikotest.RenderOptions{
+				OptionValues: map[string]interface{}{"name": "world"},
+			},
+			golden: "testdata/render_single_task.yaml",
+		},
+		{
+			name: "steps",
+			jobConfig: &execution.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "jobconfig", Namespace: "test"},
+				Spec: execution.JobConfigSpec{
+					Concurrency: execution.ConcurrencySpec{
+						Policy: execution.ConcurrencyPolicyAllow,
+					},
+					Option: renderOptionSpec,
+					Template: execution.JobTemplate{
+						Spec: execution.JobTemplateSpec{
+							Steps: []execution.JobStepSpec{
+								{
+									Name: "first",
+									Task: execution.JobTaskSpec{Template: renderPodTemplate},
+								},
+								{
+									Name:      "second",
+									DependsOn: []string{"first"},
+									Task:      execution.JobTaskSpec{Template: renderPodTemplate},
+								},
+							},
+						},
+					},
+				},
+			},
+			opts: furikotest.RenderOptions{
+				OptionValues: map[string]interface{}{"name": "world", "greeting": "Hi"},
+			},
+			golden: "testdata/render_steps.yaml",
+		},
+		{
+			name: "missing required option",
+			jobConfig: &execution.JobConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "jobconfig", Namespace: "test"},
+				Spec: execution.JobConfigSpec{
+					Concurrency: execution.ConcurrencySpec{
+						Policy: execution.ConcurrencyPolicyAllow,
+					},
+					Option: renderOptionSpec,
+					Template: execution.JobTemplate{
+						Spec: execution.JobTemplateSpec{
+							Task: execution.JobTaskSpec{
+								Template: renderPodTemplate,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			opts := tt.opts
+			opts.Time = testutils.Mktime("2022-04-01T00:00:00Z")
+			pods, err := furikotest.RenderPods(ctx, tt.jobConfig, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderPods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			furikotest.AssertGolden(t, tt.golden, pods)
+		})
+	}
+}
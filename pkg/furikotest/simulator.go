@@ -0,0 +1,452 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package furikotest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/execution/controllers/croncontroller"
+	"github.com/furiko-io/furiko/pkg/execution/util/jobconfig"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	// DefaultRunDuration is the duration that Jobs run for if the Simulator's
+	// Duration is not set.
+	DefaultRunDuration = time.Minute
+
+	cacheSyncTimeout = 10 * time.Second
+)
+
+// Run is the outcome of a single schedule of a JobConfig in a simulation.
+type Run struct {
+	// Namespace and name of the JobConfig.
+	Namespace string
+	JobConfig string
+
+	// Name of the Job that was created, or empty if the schedule was skipped by
+	// the CronController.
+	Name string
+
+	// ScheduleTime is the time that the JobConfig was scheduled at.
+	ScheduleTime time.Time
+
+	// StartTime is the time that the Job was started, or nil if it was never
+	// started.
+	StartTime *time.Time
+
+	// FinishTime is the time that the Job finished, or nil if it is still queued
+	// or running at the end of the simulation.
+	FinishTime *time.Time
+
+	// Result of the Job, or empty if it has not finished. Skipped schedules do not
+	// have a result.
+	Result execution.JobResult
+
+	// Message explains why the Job was not started, if any.
+	Message string
+
+	rj       *execution.Job
+	finishAt time.Time
+}
+
+// Skipped returns true if no Job was created for the schedule.
+func (r *Run) Skipped() bool {
+	return r.Name == ""
+}
+
+// Active returns true if the Job is started but not yet finished.
+func (r *Run) Active() bool {
+	return r.StartTime != nil && r.FinishTime == nil
+}
+
+// Queued returns true if the Job is not yet started or finished.
+func (r *Run) Queued() bool {
+	return !r.Skipped() && r.StartTime == nil && r.FinishTime == nil
+}
+
+func (r *Run) String() string {
+	if r.Skipped() {
+		return fmt.Sprintf("%v skipped: %v", r.ScheduleTime.Format(time.RFC3339), r.Message)
+	}
+	s := fmt.Sprintf("%v %v", r.ScheduleTime.Format(time.RFC3339), r.Name)
+	if r.StartTime != nil {
+		s += fmt.Sprintf(" started=%v", r.StartTime.Format(time.RFC3339))
+	}
+	if r.FinishTime != nil {
+		s += fmt.Sprintf(" finished=%v result=%v", r.FinishTime.Format(time.RFC3339), r.Result)
+	}
+	return s
+}
+
+// DurationFunc returns the duration that a Job runs for once it is started.
+type DurationFunc func(run *Run) time.Duration
+
+// FixedDuration returns a DurationFunc where all Jobs run for d.
+func FixedDuration(d time.Duration) DurationFunc {
+	return func(_ *Run) time.Duration {
+		return d
+	}
+}
+
+// Simulator runs the CronController's scheduling of JobConfigs against a fake
+// clock, and starts the created Jobs following the JobQueueController's
+// concurrency policies and start deadlines. All Jobs succeed after running for
+// their Duration.
+//
+// The CronController's CronWorker and Reconciler are used as-is, so cron
+// expressions, timezones, schedule constraints and back-scheduling behave the
+// same as in the controller. Starting of Jobs only models the concurrency
+// policy and expireAfterSeconds of each Job; other admission checks of the
+// JobQueueController, such as namespace limits, concurrency groups and
+// dependencies, are not simulated.
+type Simulator struct {
+	// Duration returns the duration that each Job runs for. Defaults to
+	// DefaultRunDuration.
+	Duration DurationFunc
+
+	// Step is the interval at which the clock is advanced. Defaults to the
+	// CronController's CronWorkerInterval.
+	Step time.Duration
+
+	ctrlContext *mock.Context
+	clock       *clock.FakeClock
+	jobConfigs  map[string]*execution.JobConfig
+	worker      *croncontroller.CronWorker
+	reconciler  *croncontroller.Reconciler
+	restore     func()
+
+	mu      sync.Mutex
+	runs    []*Run
+	pending []string
+	jobs    map[string]*Run
+}
+
+var (
+	_ croncontroller.EnqueueHandler            = (*Simulator)(nil)
+	_ croncontroller.ExecutionControlInterface = (*Simulator)(nil)
+	_ croncontroller.Recorder                  = (*Simulator)(nil)
+	_ controllercontext.ActiveJobStore         = (*Simulator)(nil)
+)
+
+// NewSimulator returns a new Simulator for the JobConfigs, starting at the given
+// time. Since the CronController uses package-level clocks, only one Simulator
+// can be used at a time, and Close must be called to restore the clocks.
+func NewSimulator(ctx context.Context, now time.Time, jobConfigs ...*execution.JobConfig) (*Simulator, error) {
+	fakeClock := clock.NewFakeClock(now)
+	prevCronClock, prevClock := croncontroller.Clock, ktime.Clock
+	croncontroller.Clock, ktime.Clock = fakeClock, fakeClock
+
+	s := &Simulator{
+		ctrlContext: mock.NewContext(),
+		clock:       fakeClock,
+		jobConfigs:  make(map[string]*execution.JobConfig, len(jobConfigs)),
+		jobs:        make(map[string]*Run),
+		restore: func() {
+			croncontroller.Clock, ktime.Clock = prevCronClock, prevClock
+		},
+	}
+
+	cronContext := croncontroller.NewContext(s.ctrlContext, nil)
+	s.worker = croncontroller.NewCronWorker(cronContext, s)
+	s.reconciler = croncontroller.NewReconciler(cronContext, s, s, s, nil)
+
+	if err := s.ctrlContext.Start(ctx); err != nil {
+		s.Close()
+		return nil, errors.Wrapf(err, "cannot start context")
+	}
+
+	client := s.ctrlContext.Clientsets().Furiko().ExecutionV1alpha1()
+	for _, rjc := range jobConfigs {
+		rjc = rjc.DeepCopy()
+		if rjc.UID == "" {
+			rjc.UID = types.UID(fmt.Sprintf("%v-%v", rjc.Namespace, rjc.Name))
+		}
+		created, err := client.JobConfigs(rjc.Namespace).Create(ctx, rjc, metav1.CreateOptions{})
+		if err != nil {
+			s.Close()
+			return nil, errors.Wrapf(err, "cannot create jobconfig %v", rjc.Name)
+		}
+		s.jobConfigs[namespacedKey(created.Namespace, created.Name)] = created
+	}
+
+	if err := s.waitForCacheSync(ctx, cronContext); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Context returns the controller context with in-memory clientsets that the
+// simulation is run against, which can be used to set dynamic configs.
+func (s *Simulator) Context() *mock.Context {
+	return s.ctrlContext
+}
+
+// Clock returns the fake clock of the simulation.
+func (s *Simulator) Clock() *clock.FakeClock {
+	return s.clock
+}
+
+// Close restores the package-level clocks.
+func (s *Simulator) Close() {
+	s.restore()
+}
+
+// Runs returns all runs so far, in order of schedule time.
+func (s *Simulator) Runs() []*Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	runs := make([]*Run, len(s.runs))
+	copy(runs, s.runs)
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].ScheduleTime.Before(runs[j].ScheduleTime)
+	})
+	return runs
+}
+
+// RunUntil advances the clock in steps until the given time, scheduling,
+// starting and finishing Jobs at each step.
+func (s *Simulator) RunUntil(ctx context.Context, end time.Time) error {
+	step := s.Step
+	if step <= 0 {
+		step = croncontroller.CronWorkerInterval
+	}
+	for now := s.clock.Now(); !now.After(end); now = now.Add(step) {
+		s.clock.SetTime(now)
+		if err := s.tick(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tick runs a single step of the simulation at the current time.
+func (s *Simulator) tick(ctx context.Context) error {
+	now := s.clock.Now()
+
+	// Finish Jobs first, so that enqueued Jobs can start in the same step.
+	s.mu.Lock()
+	for _, run := range s.runs {
+		if run.Active() && !run.finishAt.After(now) {
+			run.finish(run.finishAt, execution.JobResultSuccess, "")
+		}
+	}
+	s.mu.Unlock()
+
+	// Create Jobs for all due schedules.
+	s.worker.Work()
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+	for _, key := range pending {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		if err := s.reconciler.SyncOne(ctx, namespace, name, 0); err != nil {
+			return errors.Wrapf(err, "cannot sync %v", key)
+		}
+	}
+
+	// Start queued Jobs in order of schedule time.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, run := range s.runs {
+		if run.Queued() {
+			s.admit(run, now)
+		}
+	}
+
+	return nil
+}
+
+// admit starts, rejects or expires a queued Job, following the
+// JobQueueController's handling of concurrency policies and start deadlines.
+func (s *Simulator) admit(run *Run, now time.Time) {
+	rjc := s.jobConfigs[namespacedKey(run.Namespace, run.JobConfig)]
+	policy := rjc.Spec.Concurrency.Policy
+	expireAfter := rjc.Spec.Concurrency.ExpireAfterSeconds
+	if spec := run.rj.Spec.StartPolicy; spec != nil {
+		policy = spec.ConcurrencyPolicy
+		if spec.ExpireAfterSeconds != nil {
+			expireAfter = spec.ExpireAfterSeconds
+		}
+	}
+
+	if expireAfter != nil && *expireAfter > 0 {
+		window := time.Duration(*expireAfter) * time.Second
+		if !run.ScheduleTime.Add(window).After(now) {
+			run.finish(now, execution.JobResultExpired,
+				fmt.Sprintf("Job was not started within %v after it was due to start", window))
+			return
+		}
+	}
+
+	active := s.countActive(run.Namespace, run.JobConfig)
+	switch {
+	case policy == execution.ConcurrencyPolicyForbid && active > 0:
+		run.finish(now, execution.JobResultAdmissionError,
+			fmt.Sprintf("Cannot start new Job, %v has %v active Jobs but concurrency policy is %v",
+				run.JobConfig, active, policy))
+		return
+	case policy == execution.ConcurrencyPolicyEnqueue && active > 0:
+		return
+	}
+
+	startTime := now
+	run.StartTime = &startTime
+	run.finishAt = startTime.Add(s.duration(run))
+}
+
+func (r *Run) finish(now time.Time, result execution.JobResult, message string) {
+	r.FinishTime = &now
+	r.Result = result
+	r.Message = message
+}
+
+func (s *Simulator) duration(run *Run) time.Duration {
+	if s.Duration == nil {
+		return DefaultRunDuration
+	}
+	return s.Duration(run)
+}
+
+// countActive returns the number of started but unfinished Jobs. Must be
+// called with the lock held.
+func (s *Simulator) countActive(namespace, name string) int64 {
+	var count int64
+	for _, run := range s.runs {
+		if run.Namespace == namespace && run.JobConfig == name && run.Active() {
+			count++
+		}
+	}
+	return count
+}
+
+// EnqueueJobConfig implements croncontroller.EnqueueHandler.
+func (s *Simulator) EnqueueJobConfig(rjc *execution.JobConfig, scheduleTime time.Time) error {
+	key, err := croncontroller.JobConfigKeyFunc(rjc, scheduleTime)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending = append(s.pending, key)
+	return nil
+}
+
+// CreateJob implements croncontroller.ExecutionControlInterface.
+func (s *Simulator) CreateJob(_ context.Context, rjc *execution.JobConfig, rj *execution.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[namespacedKey(rj.Namespace, rj.Name)]; ok {
+		return nil
+	}
+	run := &Run{
+		Namespace: rjc.Namespace,
+		JobConfig: rjc.Name,
+		Name:      rj.Name,
+		rj:        rj.DeepCopy(),
+	}
+	if ts := jobconfig.GetLabelScheduleTime(rj); ts != nil {
+		run.ScheduleTime = ts.Time
+	}
+	s.jobs[namespacedKey(rj.Namespace, rj.Name)] = run
+	s.runs = append(s.runs, run)
+	return nil
+}
+
+// CreatedJob implements croncontroller.Recorder.
+func (s *Simulator) CreatedJob(_ context.Context, _ *execution.JobConfig, _ *execution.Job) {}
+
+// CreateJobFailed implements croncontroller.Recorder.
+func (s *Simulator) CreateJobFailed(_ context.Context, _ *execution.JobConfig, _ *execution.Job, _ string) {
+}
+
+// SkippedJobSchedule implements croncontroller.Recorder.
+func (s *Simulator) SkippedJobSchedule(
+	_ context.Context, rjc *execution.JobConfig, scheduleTime time.Time, message string,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs = append(s.runs, &Run{
+		Namespace:    rjc.Namespace,
+		JobConfig:    rjc.Name,
+		ScheduleTime: scheduleTime,
+		Message:      message,
+	})
+}
+
+// CountActiveJobsForConfig implements controllercontext.ActiveJobStore.
+func (s *Simulator) CountActiveJobsForConfig(rjc *execution.JobConfig) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.countActive(rjc.Namespace, rjc.Name)
+}
+
+// CheckAndAdd implements controllercontext.ActiveJobStore. Jobs are only
+// counted once they are started by the Simulator.
+func (s *Simulator) CheckAndAdd(rjc *execution.JobConfig, oldCount int64) bool {
+	return s.CountActiveJobsForConfig(rjc) == oldCount
+}
+
+// Delete implements controllercontext.ActiveJobStore.
+func (s *Simulator) Delete(_ *execution.JobConfig) {}
+
+func (s *Simulator) waitForCacheSync(ctx context.Context, cronContext *croncontroller.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, cacheSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), cronContext.HasSynced...) {
+		return errors.New("caches not synced")
+	}
+
+	// Wait for all JobConfigs to be observed by the informer.
+	lister := s.ctrlContext.Informers().Furiko().Execution().V1alpha1().JobConfigs().Lister()
+	for _, rjc := range s.jobConfigs {
+		for {
+			if _, err := lister.JobConfigs(rjc.Namespace).Get(rjc.Name); err == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return errors.Wrapf(ctx.Err(), "jobconfig %v not observed by informer", rjc.Name)
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	}
+	return nil
+}
+
+func namespacedKey(namespace, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package furikotest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/furikotest"
+	"github.com/furiko-io/furiko/pkg/utils/testutils"
+)
+
+func newJobConfig(policy execution.ConcurrencyPolicy, expireAfter *int64) *execution.JobConfig {
+	return &execution.JobConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jobconfig",
+			Namespace: "test",
+		},
+		Spec: execution.JobConfigSpec{
+			Schedule: &execution.ScheduleSpec{
+				Cron: &execution.CronSchedule{
+					Expression: "0/5 * * * *",
+				},
+			},
+			Concurrency: execution.ConcurrencySpec{
+				Policy:             policy,
+				ExpireAfterSeconds: expireAfter,
+			},
+		},
+	}
+}
+
+type wantRun struct {
+	schedule string
+	start    string
+	finish   string
+	result   execution.JobResult
+	skipped  bool
+}
+
+func TestSimulator(t *testing.T) {
+	tests := []struct {
+		name      string
+		jobConfig *execution.JobConfig
+		want      []wantRun
+	}{
+		{
+			name:      "allow",
+			jobConfig: newJobConfig(execution.ConcurrencyPolicyAllow, nil),
+			want: []wantRun{
+				{schedule: "00:05", start: "00:05", finish: "00:12", result: execution.JobResultSuccess},
+				{schedule: "00:10", start: "00:10", finish: "00:17", result: execution.JobResultSuccess},
+				{schedule: "00:15", start: "00:15"},
+				{schedule: "00:20", start: "00:20"},
+			},
+		},
+		{
+			name:      "forbid",
+			jobConfig: newJobConfig(execution.ConcurrencyPolicyForbid, nil),
+			want: []wantRun{
+				{schedule: "00:05", start: "00:05", finish: "00:12", result: execution.JobResultSuccess},
+				{schedule: "00:10", skipped: true},
+				{schedule: "00:15", start: "00:15"},
+				{schedule: "00:20", skipped: true},
+			},
+		},
+		{
+			name:      "enqueue",
+			jobConfig: newJobConfig(execution.ConcurrencyPolicyEnqueue, nil),
+			want: []wantRun{
+				{schedule: "00:05", start: "00:05", finish: "00:12", result: execution.JobResultSuccess},
+				{schedule: "00:10", start: "00:12", finish: "00:19", result: execution.JobResultSuccess},
+				{schedule: "00:15", start: "00:19"},
+				{schedule: "00:20"},
+			},
+		},
+		{
+			name:      "enqueue with expiry",
+			jobConfig: newJobConfig(execution.ConcurrencyPolicyEnqueue, pointer.Int64(180)),
+			want: []wantRun{
+				{schedule: "00:05", start: "00:05", finish: "00:12", result: execution.JobResultSuccess},
+				{schedule: "00:10", start: "00:12", finish: "00:19", result: execution.JobResultSuccess},
+				{schedule: "00:15", finish: "00:18", result: execution.JobResultExpired},
+				{schedule: "00:20", start: "00:20"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			start := testutils.Mktime("2022-04-01T00:00:00Z")
+			sim, err := furikotest.NewSimulator(ctx, start, tt.jobConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sim.Close()
+			sim.Duration = furikotest.FixedDuration(7 * time.Minute)
+			assert.NoError(t, sim.RunUntil(ctx, start.Add(21*time.Minute)))
+
+			mktime := func(s string) time.Time {
+				return testutils.Mktime("2022-04-01T" + s + ":00Z")
+			}
+			runs := sim.Runs()
+			if !assert.Len(t, runs, len(tt.want), "%v", runs) {
+				return
+			}
+			for i, want := range tt.want {
+				run := runs[i]
+				assert.Equal(t, mktime(want.schedule), run.ScheduleTime.UTC(), "run %v", run)
+				assert.Equal(t, want.skipped, run.Skipped(), "run %v", run)
+				assert.Equal(t, want.result, run.Result, "run %v", run)
+				if want.start != "" && assert.NotNil(t, run.StartTime, "run %v", run) {
+					assert.Equal(t, mktime(want.start), run.StartTime.UTC(), "run %v", run)
+				} else if want.start == "" {
+					assert.Nil(t, run.StartTime, "run %v", run)
+				}
+				if want.finish != "" && assert.NotNil(t, run.FinishTime, "run %v", run) {
+					assert.Equal(t, mktime(want.finish), run.FinishTime.UTC(), "run %v", run)
+				} else if want.finish == "" {
+					assert.Nil(t, run.FinishTime, "run %v", run)
+				}
+			}
+		})
+	}
+}
@@ -30,6 +30,11 @@ type ExecutionV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	JobsGetter
 	JobConfigsGetter
+	ExternalTasksGetter
+	ExecutionConfigsGetter
+	JobGroupsGetter
+	TaskTemplatesGetter
+	FurikoConfigsGetter
 }
 
 // ExecutionV1alpha1Client is used to interact with features provided by the execution.furiko.io group.
@@ -45,6 +50,26 @@ func (c *ExecutionV1alpha1Client) JobConfigs(namespace string) JobConfigInterfac
 	return newJobConfigs(c, namespace)
 }
 
+func (c *ExecutionV1alpha1Client) ExternalTasks(namespace string) ExternalTaskInterface {
+	return newExternalTasks(c, namespace)
+}
+
+func (c *ExecutionV1alpha1Client) ExecutionConfigs(namespace string) ExecutionConfigInterface {
+	return newExecutionConfigs(c, namespace)
+}
+
+func (c *ExecutionV1alpha1Client) JobGroups(namespace string) JobGroupInterface {
+	return newJobGroups(c, namespace)
+}
+
+func (c *ExecutionV1alpha1Client) TaskTemplates(namespace string) TaskTemplateInterface {
+	return newTaskTemplates(c, namespace)
+}
+
+func (c *ExecutionV1alpha1Client) FurikoConfigs() FurikoConfigInterface {
+	return newFurikoConfigs(c)
+}
+
 // NewForConfig creates a new ExecutionV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -36,6 +36,26 @@ func (c *FakeExecutionV1alpha1) JobConfigs(namespace string) v1alpha1.JobConfigI
 	return &FakeJobConfigs{c, namespace}
 }
 
+func (c *FakeExecutionV1alpha1) ExternalTasks(namespace string) v1alpha1.ExternalTaskInterface {
+	return &FakeExternalTasks{c, namespace}
+}
+
+func (c *FakeExecutionV1alpha1) ExecutionConfigs(namespace string) v1alpha1.ExecutionConfigInterface {
+	return &FakeExecutionConfigs{c, namespace}
+}
+
+func (c *FakeExecutionV1alpha1) JobGroups(namespace string) v1alpha1.JobGroupInterface {
+	return &FakeJobGroups{c, namespace}
+}
+
+func (c *FakeExecutionV1alpha1) TaskTemplates(namespace string) v1alpha1.TaskTemplateInterface {
+	return &FakeTaskTemplates{c, namespace}
+}
+
+func (c *FakeExecutionV1alpha1) FurikoConfigs() v1alpha1.FurikoConfigInterface {
+	return &FakeFurikoConfigs{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeExecutionV1alpha1) RESTClient() rest.Interface {
@@ -21,3 +21,13 @@ package v1alpha1
 type JobExpansion interface{}
 
 type JobConfigExpansion interface{}
+
+type ExternalTaskExpansion interface{}
+
+type ExecutionConfigExpansion interface{}
+
+type JobGroupExpansion interface{}
+
+type TaskTemplateExpansion interface{}
+
+type FurikoConfigExpansion interface{}
@@ -28,6 +28,16 @@ type Interface interface {
 	Jobs() JobInformer
 	// JobConfigs returns a JobConfigInformer.
 	JobConfigs() JobConfigInformer
+	// ExternalTasks returns a ExternalTaskInformer.
+	ExternalTasks() ExternalTaskInformer
+	// ExecutionConfigs returns a ExecutionConfigInformer.
+	ExecutionConfigs() ExecutionConfigInformer
+	// JobGroups returns a JobGroupInformer.
+	JobGroups() JobGroupInformer
+	// TaskTemplates returns a TaskTemplateInformer.
+	TaskTemplates() TaskTemplateInformer
+	// FurikoConfigs returns a FurikoConfigInformer.
+	FurikoConfigs() FurikoConfigInformer
 }
 
 type version struct {
@@ -50,3 +60,28 @@ func (v *version) Jobs() JobInformer {
 func (v *version) JobConfigs() JobConfigInformer {
 	return &jobConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ExternalTasks returns a ExternalTaskInformer.
+func (v *version) ExternalTasks() ExternalTaskInformer {
+	return &externalTaskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ExecutionConfigs returns a ExecutionConfigInformer.
+func (v *version) ExecutionConfigs() ExecutionConfigInformer {
+	return &executionConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// JobGroups returns a JobGroupInformer.
+func (v *version) JobGroups() JobGroupInformer {
+	return &jobGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TaskTemplates returns a TaskTemplateInformer.
+func (v *version) TaskTemplates() TaskTemplateInformer {
+	return &taskTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// FurikoConfigs returns a FurikoConfigInformer.
+func (v *version) FurikoConfigs() FurikoConfigInformer {
+	return &furikoConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -57,6 +57,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Execution().V1alpha1().Jobs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("jobconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Execution().V1alpha1().JobConfigs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("externaltasks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Execution().V1alpha1().ExternalTasks().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("executionconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Execution().V1alpha1().ExecutionConfigs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("jobgroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Execution().V1alpha1().JobGroups().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tasktemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Execution().V1alpha1().TaskTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("furikoconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Execution().V1alpha1().FurikoConfigs().Informer()}, nil
 
 	}
 
@@ -33,3 +33,39 @@ type JobConfigListerExpansion interface{}
 // JobConfigNamespaceListerExpansion allows custom methods to be added to
 // JobConfigNamespaceLister.
 type JobConfigNamespaceListerExpansion interface{}
+
+// ExternalTaskListerExpansion allows custom methods to be added to
+// ExternalTaskLister.
+type ExternalTaskListerExpansion interface{}
+
+// ExternalTaskNamespaceListerExpansion allows custom methods to be added to
+// ExternalTaskNamespaceLister.
+type ExternalTaskNamespaceListerExpansion interface{}
+
+// ExecutionConfigListerExpansion allows custom methods to be added to
+// ExecutionConfigLister.
+type ExecutionConfigListerExpansion interface{}
+
+// ExecutionConfigNamespaceListerExpansion allows custom methods to be added to
+// ExecutionConfigNamespaceLister.
+type ExecutionConfigNamespaceListerExpansion interface{}
+
+// JobGroupListerExpansion allows custom methods to be added to
+// JobGroupLister.
+type JobGroupListerExpansion interface{}
+
+// JobGroupNamespaceListerExpansion allows custom methods to be added to
+// JobGroupNamespaceLister.
+type JobGroupNamespaceListerExpansion interface{}
+
+// TaskTemplateListerExpansion allows custom methods to be added to
+// TaskTemplateLister.
+type TaskTemplateListerExpansion interface{}
+
+// TaskTemplateNamespaceListerExpansion allows custom methods to be added to
+// TaskTemplateNamespaceLister.
+type TaskTemplateNamespaceListerExpansion interface{}
+
+// FurikoConfigListerExpansion allows custom methods to be added to
+// FurikoConfigLister.
+type FurikoConfigListerExpansion interface{}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certprovisioner
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// caCommonName is the common name of generated CA certificates.
+	caCommonName = "furiko-execution-webhook-ca"
+
+	// certBackdate is the duration that the NotBefore of generated certificates
+	// are backdated by, to tolerate clock skew between the webhook server and the
+	// kube-apiserver.
+	certBackdate = time.Hour
+)
+
+// keyPair is a parsed certificate together with its private key.
+type keyPair struct {
+	Cert    *x509.Certificate
+	Key     crypto.Signer
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// TLSCertificate returns the tls.Certificate for serving with the keyPair.
+func (k *keyPair) TLSCertificate() (*tls.Certificate, error) {
+	cert, err := tls.X509KeyPair(k.CertPEM, k.KeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	cert.Leaf = k.Cert
+	return &cert, nil
+}
+
+// newCA generates a new self-signed CA that is valid for the given duration.
+func newCA(now time.Time, validity time.Duration) (*keyPair, error) {
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: caCommonName,
+		},
+		NotBefore:             now.Add(-certBackdate),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return newKeyPair(template, nil)
+}
+
+// newServingCert generates a new serving certificate for the given DNS names,
+// signed by the given CA. The certificate will not outlive the CA.
+func newServingCert(ca *keyPair, dnsNames []string, now time.Time, validity time.Duration) (*keyPair, error) {
+	notAfter := now.Add(validity)
+	if notAfter.After(ca.Cert.NotAfter) {
+		notAfter = ca.Cert.NotAfter
+	}
+	template := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName: dnsNames[0],
+		},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-certBackdate),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return newKeyPair(template, ca)
+}
+
+// newKeyPair generates a new private key and certificate from the template. If
+// parent is nil, the certificate will be self-signed.
+func newKeyPair(template *x509.Certificate, parent *keyPair) (*keyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot generate private key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot generate serial number")
+	}
+	template.SerialNumber = serial
+
+	parentCert, signer := template, crypto.Signer(key)
+	if parent != nil {
+		parentCert, signer = parent.Cert, parent.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, key.Public(), signer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create certificate")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse created certificate")
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal private key")
+	}
+
+	return &keyPair{
+		Cert:    cert,
+		Key:     key,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// parseKeyPair parses a PEM-encoded certificate and private key. Only the first
+// certificate in certPEM is used.
+func parseKeyPair(certPEM, keyPEM []byte) (*keyPair, error) {
+	certs, err := parseCertificates(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+	block, _ := pem.Decode(certPEM)
+	tlsCert, err := tls.X509KeyPair(pem.EncodeToMemory(block), keyPEM)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid key pair")
+	}
+	signer, ok := tlsCert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("private key is not a signer")
+	}
+	return &keyPair{
+		Cert:    certs[0],
+		Key:     signer,
+		CertPEM: pem.EncodeToMemory(block),
+		KeyPEM:  keyPEM,
+	}, nil
+}
+
+// parseCertificates parses all PEM-encoded certificates in data.
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse certificate")
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// encodeCertificates returns the PEM encoding of all certificates.
+func encodeCertificates(certs []*x509.Certificate) []byte {
+	buf := new(bytes.Buffer)
+	for _, cert := range certs {
+		_ = pem.Encode(buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certprovisioner
+
+import (
+	"time"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
+)
+
+type Config struct {
+	SecretName                      string
+	SecretNamespace                 string
+	ServiceName                     string
+	ServiceNamespace                string
+	MutatingWebhookConfigurations   []string
+	ValidatingWebhookConfigurations []string
+	CustomResourceDefinitions       []string
+	CAValidity                      time.Duration
+	CertValidity                    time.Duration
+	RotateBefore                    time.Duration
+	ResyncInterval                  time.Duration
+}
+
+var (
+	DefaultConfig = &Config{
+		SecretName:       "execution-webhook-certs",
+		SecretNamespace:  "furiko-system",
+		ServiceName:      "execution-webhook-service",
+		ServiceNamespace: "furiko-system",
+		CAValidity:       10 * 365 * 24 * time.Hour,
+		CertValidity:     365 * 24 * time.Hour,
+		RotateBefore:     30 * 24 * time.Hour,
+		ResyncInterval:   10 * time.Minute,
+	}
+)
+
+// NewConfigFromSpec returns a Config from a CertProvisionerSpec. Default values
+// are not applied.
+func NewConfigFromSpec(spec *configv1alpha1.CertProvisionerSpec) *Config {
+	cfg := &Config{}
+	if spec == nil {
+		return cfg
+	}
+	if spec.Secret != nil {
+		cfg.SecretName = spec.Secret.Name
+		cfg.SecretNamespace = spec.Secret.Namespace
+	}
+	if spec.Service != nil {
+		cfg.ServiceName = spec.Service.Name
+		cfg.ServiceNamespace = spec.Service.Namespace
+	}
+	cfg.MutatingWebhookConfigurations = spec.MutatingWebhookConfigurations
+	cfg.ValidatingWebhookConfigurations = spec.ValidatingWebhookConfigurations
+	cfg.CustomResourceDefinitions = spec.CustomResourceDefinitions
+	cfg.CAValidity = spec.CAValidity.Duration
+	cfg.CertValidity = spec.CertValidity.Duration
+	cfg.RotateBefore = spec.RotateBefore.Duration
+	cfg.ResyncInterval = spec.ResyncInterval.Duration
+	return cfg
+}
+
+func (c *Config) PrepareValues() *Config {
+	cfg := c
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	return &Config{
+		SecretName:                      stringDefaulting(cfg.SecretName, DefaultConfig.SecretName),
+		SecretNamespace:                 stringDefaulting(cfg.SecretNamespace, DefaultConfig.SecretNamespace),
+		ServiceName:                     stringDefaulting(cfg.ServiceName, DefaultConfig.ServiceName),
+		ServiceNamespace:                stringDefaulting(cfg.ServiceNamespace, DefaultConfig.ServiceNamespace),
+		MutatingWebhookConfigurations:   cfg.MutatingWebhookConfigurations,   // no defaults provided
+		ValidatingWebhookConfigurations: cfg.ValidatingWebhookConfigurations, // no defaults provided
+		CustomResourceDefinitions:       cfg.CustomResourceDefinitions,       // no defaults provided
+		CAValidity:                      durationDefaulting(cfg.CAValidity, DefaultConfig.CAValidity),
+		CertValidity:                    durationDefaulting(cfg.CertValidity, DefaultConfig.CertValidity),
+		RotateBefore:                    durationDefaulting(cfg.RotateBefore, DefaultConfig.RotateBefore),
+		ResyncInterval:                  durationDefaulting(cfg.ResyncInterval, DefaultConfig.ResyncInterval),
+	}
+}
+
+// DNSNames returns the list of DNS names that the serving certificate should be
+// valid for.
+func (c *Config) DNSNames() []string {
+	return []string{
+		c.ServiceName,
+		c.ServiceName + "." + c.ServiceNamespace,
+		c.ServiceName + "." + c.ServiceNamespace + ".svc",
+		c.ServiceName + "." + c.ServiceNamespace + ".svc.cluster.local",
+	}
+}
+
+func stringDefaulting(value, defaultValue string) string {
+	if value == "" {
+		value = defaultValue
+	}
+	return value
+}
+
+func durationDefaulting(value, defaultValue time.Duration) time.Duration {
+	value = timeutil.DurationMax(0, value)
+	if value == 0 {
+		value = defaultValue
+	}
+	return value
+}
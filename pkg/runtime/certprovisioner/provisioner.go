@@ -0,0 +1,316 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certprovisioner
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
+)
+
+const (
+	// errorRetryInterval is the interval to retry after a failed sync.
+	errorRetryInterval = 10 * time.Second
+)
+
+// Provisioner provisions a self-signed CA and serving certificate for the
+// webhook server, stores them in a Secret shared by all replicas, rotates them
+// before they expire, and patches the CA bundle of webhook configurations and
+// CRD conversion webhooks.
+type Provisioner struct {
+	config        *Config
+	kubernetes    kubernetes.Interface
+	apiextensions apiextensionsclientset.Interface
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// New returns a new Provisioner.
+func New(
+	config *Config,
+	kubernetes kubernetes.Interface,
+	apiextensions apiextensionsclientset.Interface,
+) (*Provisioner, error) {
+	cfg := config.PrepareValues()
+	if cfg.RotateBefore >= cfg.CAValidity {
+		return nil, fmt.Errorf("rotateBefore %v must be less than caValidity %v", cfg.RotateBefore, cfg.CAValidity)
+	}
+	if cfg.RotateBefore >= cfg.CertValidity {
+		return nil, fmt.Errorf("rotateBefore %v must be less than certValidity %v", cfg.RotateBefore, cfg.CertValidity)
+	}
+	return &Provisioner{
+		config:        cfg,
+		kubernetes:    kubernetes,
+		apiextensions: apiextensions,
+	}, nil
+}
+
+// Start provisions the certificates and blocks until they are ready to be
+// served, then continues to rotate them in the background until the context is
+// canceled.
+func (p *Provisioner) Start(ctx context.Context) error {
+	state, err := p.syncSecret(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "cannot provision certificates")
+	}
+
+	nextSync := p.nextSync(state)
+	if err := p.syncCABundles(ctx, state); err != nil {
+		klog.ErrorS(err, "certprovisioner: cannot patch CA bundles, will retry")
+		nextSync = errorRetryInterval
+	}
+
+	go p.run(ctx, nextSync)
+	return nil
+}
+
+// GetCertificate returns the current serving certificate. It can be used as
+// tls.Config.GetCertificate, so that rotated certificates are served without
+// restarting the server.
+func (p *Provisioner) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.cert == nil {
+		return nil, errors.New("certificate is not yet provisioned")
+	}
+	return p.cert, nil
+}
+
+func (p *Provisioner) run(ctx context.Context, nextSync time.Duration) {
+	for {
+		timer := time.NewTimer(nextSync)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		nextSync = errorRetryInterval
+		state, err := p.syncSecret(ctx)
+		if err != nil {
+			klog.ErrorS(err, "certprovisioner: cannot sync certificates, will retry")
+			continue
+		}
+		if err := p.syncCABundles(ctx, state); err != nil {
+			klog.ErrorS(err, "certprovisioner: cannot patch CA bundles, will retry")
+			continue
+		}
+		nextSync = p.nextSync(state)
+	}
+}
+
+// nextSync returns the duration until the next sync, which is the earlier of
+// the next rotation and the resync interval.
+func (p *Provisioner) nextSync(state *certState) time.Duration {
+	untilRotation := state.NextRotation(p.config.RotateBefore).Sub(ktime.Now().Time)
+	return timeutil.DurationMin(p.config.ResyncInterval, timeutil.DurationMax(0, untilRotation))
+}
+
+// syncSecret ensures that the Secret contains valid certificates, and loads the
+// serving certificate to be served.
+func (p *Provisioner) syncSecret(ctx context.Context) (*certState, error) {
+	var state *certState
+	isRetriable := func(err error) bool {
+		return kerrors.IsConflict(err) || kerrors.IsAlreadyExists(err)
+	}
+
+	err := retry.OnError(retry.DefaultRetry, isRetriable, func() error {
+		secrets := p.kubernetes.CoreV1().Secrets(p.config.SecretNamespace)
+		secret, err := secrets.Get(ctx, p.config.SecretName, metav1.GetOptions{})
+		notFound := kerrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return errors.Wrapf(err, "cannot get secret")
+		}
+
+		var current *certState
+		if !notFound {
+			current = loadState(secret.Data)
+		}
+		newState, changed, err := reconcileState(current, p.config, ktime.Now().Time)
+		if err != nil {
+			return errors.Wrapf(err, "cannot generate certificates")
+		}
+
+		if notFound {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      p.config.SecretName,
+					Namespace: p.config.SecretNamespace,
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: newState.Data(),
+			}
+			if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+				return errors.Wrapf(err, "cannot create secret")
+			}
+			klog.InfoS("certprovisioner: created secret with new certificates",
+				"namespace", p.config.SecretNamespace,
+				"name", p.config.SecretName,
+			)
+		} else if changed {
+			newSecret := secret.DeepCopy()
+			if newSecret.Data == nil {
+				newSecret.Data = make(map[string][]byte)
+			}
+			for k, v := range newState.Data() {
+				newSecret.Data[k] = v
+			}
+			if _, err := secrets.Update(ctx, newSecret, metav1.UpdateOptions{}); err != nil {
+				return errors.Wrapf(err, "cannot update secret")
+			}
+			klog.InfoS("certprovisioner: updated secret with rotated certificates",
+				"namespace", p.config.SecretNamespace,
+				"name", p.config.SecretName,
+			)
+		}
+
+		state = newState
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := state.Serving.TLSCertificate()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load serving certificate")
+	}
+	p.mu.Lock()
+	p.cert = cert
+	p.mu.Unlock()
+
+	return state, nil
+}
+
+// syncCABundles patches the CA bundle into all configured webhook
+// configurations and CRD conversion webhooks.
+func (p *Provisioner) syncCABundles(ctx context.Context, state *certState) error {
+	caBundle := encodeCertificates(state.CABundle)
+
+	for _, name := range p.config.MutatingWebhookConfigurations {
+		if err := p.patchMutatingWebhookConfiguration(ctx, name, caBundle); err != nil {
+			return errors.Wrapf(err, "cannot patch mutatingwebhookconfiguration %v", name)
+		}
+	}
+	for _, name := range p.config.ValidatingWebhookConfigurations {
+		if err := p.patchValidatingWebhookConfiguration(ctx, name, caBundle); err != nil {
+			return errors.Wrapf(err, "cannot patch validatingwebhookconfiguration %v", name)
+		}
+	}
+	for _, name := range p.config.CustomResourceDefinitions {
+		if err := p.patchCustomResourceDefinition(ctx, name, caBundle); err != nil {
+			return errors.Wrapf(err, "cannot patch customresourcedefinition %v", name)
+		}
+	}
+
+	return nil
+}
+
+func (p *Provisioner) patchMutatingWebhookConfiguration(ctx context.Context, name string, caBundle []byte) error {
+	client := p.kubernetes.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		newConfig := config.DeepCopy()
+		var changed bool
+		for i := range newConfig.Webhooks {
+			clientConfig := &newConfig.Webhooks[i].ClientConfig
+			if !bytes.Equal(clientConfig.CABundle, caBundle) {
+				clientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		if _, err := client.Update(ctx, newConfig, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		klog.InfoS("certprovisioner: patched CA bundle", "mutatingwebhookconfiguration", name)
+		return nil
+	})
+}
+
+func (p *Provisioner) patchValidatingWebhookConfiguration(ctx context.Context, name string, caBundle []byte) error {
+	client := p.kubernetes.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		newConfig := config.DeepCopy()
+		var changed bool
+		for i := range newConfig.Webhooks {
+			clientConfig := &newConfig.Webhooks[i].ClientConfig
+			if !bytes.Equal(clientConfig.CABundle, caBundle) {
+				clientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		if _, err := client.Update(ctx, newConfig, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		klog.InfoS("certprovisioner: patched CA bundle", "validatingwebhookconfiguration", name)
+		return nil
+	})
+}
+
+func (p *Provisioner) patchCustomResourceDefinition(ctx context.Context, name string, caBundle []byte) error {
+	client := p.apiextensions.ApiextensionsV1().CustomResourceDefinitions()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		conversion := crd.Spec.Conversion
+		if conversion == nil || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+			return errors.New("conversion webhook is not configured")
+		}
+		if bytes.Equal(conversion.Webhook.ClientConfig.CABundle, caBundle) {
+			return nil
+		}
+		newCrd := crd.DeepCopy()
+		newCrd.Spec.Conversion.Webhook.ClientConfig.CABundle = caBundle
+		if _, err := client.Update(ctx, newCrd, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		klog.InfoS("certprovisioner: patched CA bundle", "customresourcedefinition", name)
+		return nil
+	})
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certprovisioner_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/furiko-io/furiko/pkg/runtime/certprovisioner"
+)
+
+func TestProvisioner(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kubeClient := kubernetesfake.NewSimpleClientset(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "mutating"},
+			Webhooks: []admissionregistrationv1.MutatingWebhook{
+				{Name: "mutating.webhook.jobs.execution.furiko.io"},
+			},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "validating"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{Name: "validation.webhook.jobs.execution.furiko.io"},
+			},
+		},
+	)
+	apiextensionsClient := apiextensionsfake.NewSimpleClientset(
+		&apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "jobs.execution.furiko.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Conversion: &apiextensionsv1.CustomResourceConversion{
+					Strategy: apiextensionsv1.WebhookConverter,
+					Webhook: &apiextensionsv1.WebhookConversion{
+						ClientConfig: &apiextensionsv1.WebhookClientConfig{},
+					},
+				},
+			},
+		},
+	)
+
+	provisioner, err := certprovisioner.New(&certprovisioner.Config{
+		MutatingWebhookConfigurations:   []string{"mutating"},
+		ValidatingWebhookConfigurations: []string{"validating"},
+		CustomResourceDefinitions:       []string{"jobs.execution.furiko.io"},
+	}, kubeClient, apiextensionsClient)
+	if err != nil {
+		t.Fatalf("cannot create provisioner: %v", err)
+	}
+
+	if _, err := provisioner.GetCertificate(nil); err == nil {
+		t.Errorf("expected error before certificate is provisioned")
+	}
+	if err := provisioner.Start(ctx); err != nil {
+		t.Fatalf("cannot start provisioner: %v", err)
+	}
+
+	// Secret should be created.
+	defaults := certprovisioner.DefaultConfig
+	secret, err := kubeClient.CoreV1().Secrets(defaults.SecretNamespace).
+		Get(ctx, defaults.SecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("cannot get secret: %v", err)
+	}
+	caBundle := secret.Data[certprovisioner.SecretKeyCABundle]
+	if len(caBundle) == 0 {
+		t.Fatalf("expected CA bundle in secret")
+	}
+
+	// Serving certificate should be loaded and trusted by the CA bundle.
+	cert, err := provisioner.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("cannot get certificate: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(caBundle)
+	if _, err := cert.Leaf.Verify(x509.VerifyOptions{
+		DNSName: "execution-webhook-service.furiko-system.svc",
+		Roots:   pool,
+	}); err != nil {
+		t.Errorf("cannot verify serving certificate: %v", err)
+	}
+
+	// CA bundles should be patched.
+	mutating, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().
+		Get(ctx, "mutating", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("cannot get mutatingwebhookconfiguration: %v", err)
+	}
+	if !bytes.Equal(mutating.Webhooks[0].ClientConfig.CABundle, caBundle) {
+		t.Errorf("expected mutatingwebhookconfiguration CA bundle to be patched")
+	}
+	validating, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().
+		Get(ctx, "validating", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("cannot get validatingwebhookconfiguration: %v", err)
+	}
+	if !bytes.Equal(validating.Webhooks[0].ClientConfig.CABundle, caBundle) {
+		t.Errorf("expected validatingwebhookconfiguration CA bundle to be patched")
+	}
+	crd, err := apiextensionsClient.ApiextensionsV1().CustomResourceDefinitions().
+		Get(ctx, "jobs.execution.furiko.io", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("cannot get customresourcedefinition: %v", err)
+	}
+	if !bytes.Equal(crd.Spec.Conversion.Webhook.ClientConfig.CABundle, caBundle) {
+		t.Errorf("expected customresourcedefinition CA bundle to be patched")
+	}
+
+	// Another replica should reuse the existing certificates.
+	other, err := certprovisioner.New(nil, kubeClient, apiextensionsClient)
+	if err != nil {
+		t.Fatalf("cannot create provisioner: %v", err)
+	}
+	if err := other.Start(ctx); err != nil {
+		t.Fatalf("cannot start provisioner: %v", err)
+	}
+	otherCert, err := other.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("cannot get certificate: %v", err)
+	}
+	if !otherCert.Leaf.Equal(cert.Leaf) {
+		t.Errorf("expected replicas to share the same serving certificate")
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := certprovisioner.DefaultConfig.PrepareValues()
+	cfg.RotateBefore = cfg.CertValidity
+	if _, err := certprovisioner.New(cfg, kubernetesfake.NewSimpleClientset(), apiextensionsfake.NewSimpleClientset()); err == nil {
+		t.Errorf("expected error when rotateBefore is not less than certValidity")
+	}
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certprovisioner
+
+import (
+	"crypto/x509"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Keys of the Secret which the certificates are stored in. These are
+// compatible with the keys used by kube-webhook-certgen, so that an existing
+// Secret can be taken over by the provisioner.
+const (
+	// SecretKeyCABundle contains the PEM-encoded CA bundle, which contains the
+	// current CA certificate followed by any previous CA certificates that have
+	// not yet expired.
+	SecretKeyCABundle = "ca"
+
+	// SecretKeyCAKey contains the PEM-encoded private key of the current CA.
+	SecretKeyCAKey = "ca-key"
+
+	// SecretKeyCert contains the PEM-encoded serving certificate.
+	SecretKeyCert = "cert"
+
+	// SecretKeyKey contains the PEM-encoded private key of the serving
+	// certificate.
+	SecretKeyKey = "key"
+)
+
+// certState is the set of certificates stored in the Secret.
+type certState struct {
+	// CA is the current CA, which is used to sign serving certificates.
+	CA *keyPair
+
+	// CABundle contains the current CA certificate, followed by previous CA
+	// certificates that have not yet expired. Keeping previous CAs in the bundle
+	// allows serving certificates signed by them to continue to be trusted while
+	// the CA is being rotated.
+	CABundle []*x509.Certificate
+
+	// Serving is the serving certificate.
+	Serving *keyPair
+}
+
+// loadState loads the certState from the data of a Secret. Any certificates
+// that cannot be parsed will be left empty.
+func loadState(data map[string][]byte) *certState {
+	state := &certState{}
+	if bundle, err := parseCertificates(data[SecretKeyCABundle]); err == nil {
+		state.CABundle = bundle
+	}
+	if ca, err := parseKeyPair(data[SecretKeyCABundle], data[SecretKeyCAKey]); err == nil {
+		state.CA = ca
+	}
+	if serving, err := parseKeyPair(data[SecretKeyCert], data[SecretKeyKey]); err == nil {
+		state.Serving = serving
+	}
+	return state
+}
+
+// Data returns the data to be stored in the Secret.
+func (s *certState) Data() map[string][]byte {
+	return map[string][]byte{
+		SecretKeyCABundle: encodeCertificates(s.CABundle),
+		SecretKeyCAKey:    s.CA.KeyPEM,
+		SecretKeyCert:     s.Serving.CertPEM,
+		SecretKeyKey:      s.Serving.KeyPEM,
+	}
+}
+
+// NextRotation returns the time that the next certificate should be rotated.
+func (s *certState) NextRotation(rotateBefore time.Duration) time.Time {
+	notAfter := s.Serving.Cert.NotAfter
+	if s.CA.Cert.NotAfter.Before(notAfter) {
+		notAfter = s.CA.Cert.NotAfter
+	}
+	return notAfter.Add(-rotateBefore)
+}
+
+// reconcileState returns the desired certState, generating any certificates
+// that are missing, invalid or due for rotation. Returns true if the state was
+// changed and needs to be persisted.
+func reconcileState(current *certState, cfg *Config, now time.Time) (*certState, bool, error) {
+	state := &certState{}
+	if current != nil {
+		*state = *current
+	}
+	var changed bool
+
+	// Rotate the CA, which also requires a new serving certificate.
+	if state.CA == nil || needsRotation(state.CA.Cert, now, cfg.RotateBefore) {
+		ca, err := newCA(now, cfg.CAValidity)
+		if err != nil {
+			return nil, false, err
+		}
+		state.CA = ca
+		state.Serving = nil
+		changed = true
+	}
+
+	// Ensure that the current CA is first in the bundle, and prune expired CAs.
+	bundle := []*x509.Certificate{state.CA.Cert}
+	for _, cert := range state.CABundle {
+		if cert.Equal(state.CA.Cert) || !now.Before(cert.NotAfter) {
+			continue
+		}
+		bundle = append(bundle, cert)
+	}
+	if !equalCertificates(bundle, state.CABundle) {
+		changed = true
+	}
+	state.CABundle = bundle
+
+	// Rotate the serving certificate.
+	if !isServingCertValid(state.Serving, state.CA, cfg, now) {
+		serving, err := newServingCert(state.CA, cfg.DNSNames(), now, cfg.CertValidity)
+		if err != nil {
+			return nil, false, err
+		}
+		state.Serving = serving
+		changed = true
+	}
+
+	return state, changed, nil
+}
+
+// isServingCertValid returns true if the serving certificate is signed by the
+// CA, is valid for all DNS names, and is not due for rotation.
+func isServingCertValid(serving, ca *keyPair, cfg *Config, now time.Time) bool {
+	if serving == nil {
+		return false
+	}
+	if err := serving.Cert.CheckSignatureFrom(ca.Cert); err != nil {
+		return false
+	}
+	if !sets.NewString(serving.Cert.DNSNames...).Equal(sets.NewString(cfg.DNSNames()...)) {
+		return false
+	}
+	return !needsRotation(serving.Cert, now, cfg.RotateBefore)
+}
+
+// needsRotation returns true if the certificate expires within rotateBefore.
+func needsRotation(cert *x509.Certificate, now time.Time, rotateBefore time.Duration) bool {
+	return !now.Before(cert.NotAfter.Add(-rotateBefore))
+}
+
+func equalCertificates(a, b []*x509.Certificate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package certprovisioner
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	testConfig = (&Config{
+		ServiceName:      "webhook-service",
+		ServiceNamespace: "test",
+		CAValidity:       365 * 24 * time.Hour,
+		CertValidity:     30 * 24 * time.Hour,
+		RotateBefore:     7 * 24 * time.Hour,
+	}).PrepareValues()
+
+	testNow = time.Date(2022, 4, 1, 4, 0, 0, 0, time.UTC)
+)
+
+func mustReconcileState(t *testing.T, current *certState, now time.Time) (*certState, bool) {
+	state, changed, err := reconcileState(current, testConfig, now)
+	if err != nil {
+		t.Fatalf("reconcileState() error = %v", err)
+	}
+	return state, changed
+}
+
+func TestReconcileState(t *testing.T) {
+	// Generate new certificates.
+	state, changed := mustReconcileState(t, nil, testNow)
+	if !changed {
+		t.Errorf("expected new state to be changed")
+	}
+	if len(state.CABundle) != 1 || !state.CABundle[0].Equal(state.CA.Cert) {
+		t.Errorf("expected CA bundle to only contain current CA")
+	}
+	if err := state.Serving.Cert.CheckSignatureFrom(state.CA.Cert); err != nil {
+		t.Errorf("expected serving cert to be signed by CA: %v", err)
+	}
+	if err := state.Serving.Cert.VerifyHostname("webhook-service.test.svc"); err != nil {
+		t.Errorf("expected serving cert to be valid for service: %v", err)
+	}
+	if next, want := state.NextRotation(testConfig.RotateBefore), testNow.Add(23*24*time.Hour); !next.Equal(want) {
+		t.Errorf("NextRotation() = %v, want %v", next, want)
+	}
+
+	// Round trip through the Secret data, should not change.
+	loaded := loadState(state.Data())
+	newState, changed := mustReconcileState(t, loaded, testNow.Add(time.Hour))
+	if changed {
+		t.Errorf("expected loaded state to be unchanged")
+	}
+	if !newState.Serving.Cert.Equal(state.Serving.Cert) {
+		t.Errorf("expected serving cert to be unchanged")
+	}
+
+	// Serving cert is due for rotation, but CA is not.
+	rotated, changed := mustReconcileState(t, loaded, testNow.Add(24*24*time.Hour))
+	if !changed {
+		t.Errorf("expected serving cert to be rotated")
+	}
+	if !rotated.CA.Cert.Equal(state.CA.Cert) {
+		t.Errorf("expected CA to be unchanged")
+	}
+	if rotated.Serving.Cert.Equal(state.Serving.Cert) {
+		t.Errorf("expected serving cert to be changed")
+	}
+
+	// CA is due for rotation, previous CA should be kept in the bundle.
+	caRotateTime := testNow.Add(360 * 24 * time.Hour)
+	caRotated, changed := mustReconcileState(t, loaded, caRotateTime)
+	if !changed {
+		t.Errorf("expected CA to be rotated")
+	}
+	if caRotated.CA.Cert.Equal(state.CA.Cert) {
+		t.Errorf("expected CA to be changed")
+	}
+	if len(caRotated.CABundle) != 2 || !caRotated.CABundle[1].Equal(state.CA.Cert) {
+		t.Errorf("expected CA bundle to contain current and previous CA")
+	}
+	if err := caRotated.Serving.Cert.CheckSignatureFrom(caRotated.CA.Cert); err != nil {
+		t.Errorf("expected serving cert to be signed by new CA: %v", err)
+	}
+
+	// Previous CA should be pruned from the bundle once expired.
+	pruned, changed := mustReconcileState(t, loadState(caRotated.Data()), testNow.Add(366*24*time.Hour))
+	if !changed {
+		t.Errorf("expected expired CA to be pruned")
+	}
+	if len(pruned.CABundle) != 1 || !pruned.CABundle[0].Equal(caRotated.CA.Cert) {
+		t.Errorf("expected CA bundle to only contain current CA")
+	}
+}
+
+func TestReconcileState_TakeOverSecret(t *testing.T) {
+	existing, _ := mustReconcileState(t, nil, testNow)
+
+	// Secrets created by kube-webhook-certgen do not store the CA private key.
+	data := existing.Data()
+	delete(data, SecretKeyCAKey)
+
+	state, changed := mustReconcileState(t, loadState(data), testNow)
+	if !changed {
+		t.Errorf("expected state to be changed")
+	}
+	if state.CA.Cert.Equal(existing.CA.Cert) {
+		t.Errorf("expected new CA to be generated")
+	}
+	if len(state.CABundle) != 2 || !state.CABundle[1].Equal(existing.CA.Cert) {
+		t.Errorf("expected CA bundle to contain existing CA")
+	}
+}
+
+func TestReconcileState_ServiceChanged(t *testing.T) {
+	existing, _ := mustReconcileState(t, nil, testNow)
+
+	cfg := *testConfig
+	cfg.ServiceName = "other-service"
+	state, changed, err := reconcileState(loadState(existing.Data()), &cfg, testNow)
+	if err != nil {
+		t.Fatalf("reconcileState() error = %v", err)
+	}
+	if !changed {
+		t.Errorf("expected serving cert to be regenerated")
+	}
+	if !state.CA.Cert.Equal(existing.CA.Cert) {
+		t.Errorf("expected CA to be unchanged")
+	}
+	if err := state.Serving.Cert.VerifyHostname("other-service.test.svc"); err != nil {
+		t.Errorf("expected serving cert to be valid for new service: %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/imdario/mergo"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	furiko "github.com/furiko-io/furiko/pkg/generated/clientset/versioned"
+	furikoinformers "github.com/furiko-io/furiko/pkg/generated/informers/externalversions"
+	executionlisters "github.com/furiko-io/furiko/pkg/generated/listers/execution/v1alpha1"
+)
+
+// ExecutionConfigLoader is a dynamic NamespacedLoader that starts an informer
+// to watch ExecutionConfigs in all namespaces, which override the global
+// configuration for Jobs and JobConfigs in their namespace.
+type ExecutionConfigLoader struct {
+	client furiko.Interface
+	lister executionlisters.ExecutionConfigLister
+}
+
+var _ NamespacedLoader = (*ExecutionConfigLoader)(nil)
+
+func NewExecutionConfigLoader(client furiko.Interface) *ExecutionConfigLoader {
+	return &ExecutionConfigLoader{
+		client: client,
+	}
+}
+
+func (c *ExecutionConfigLoader) Name() string {
+	return "ExecutionConfigLoader"
+}
+
+func (c *ExecutionConfigLoader) Start(ctx context.Context) error {
+	klog.V(4).InfoS("configloader: config loader starting", "loader", c.Name())
+	informerFactory := furikoinformers.NewSharedInformerFactory(c.client, time.Minute*10)
+	informer := informerFactory.Execution().V1alpha1().ExecutionConfigs()
+	c.lister = informer.Lister()
+
+	informerFactory.Start(ctx.Done())
+
+	// Wait for caches to be synced with a timeout.
+	syncCtx, cancel := context.WithTimeout(ctx, time.Minute*3)
+	defer cancel()
+	if ok := cache.WaitForNamedCacheSync(c.Name(), syncCtx.Done(), informer.Informer().HasSynced); !ok {
+		klog.Error("configloader: failed to sync caches", "loader", c.Name())
+		return errors.New("failed to sync caches")
+	}
+
+	return nil
+}
+
+// LoadNamespaced returns the config overrides from all ExecutionConfigs in the
+// given namespace, merged in lexicographical order of their names. If there are
+// no ExecutionConfigs in the namespace, an empty config will be returned.
+func (c *ExecutionConfigLoader) LoadNamespaced(configName configv1alpha1.ConfigName, namespace string) (Config, error) {
+	if c.lister == nil {
+		return nil, errors.New("loader is not started")
+	}
+	executionConfigs, err := c.lister.ExecutionConfigs(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list executionconfigs")
+	}
+	return MergeExecutionConfigs(executionConfigs, configName)
+}
+
+// MergeExecutionConfigs returns the Config that overrides the given config name
+// from all ExecutionConfigs, merged in lexicographical order of their names.
+func MergeExecutionConfigs(
+	executionConfigs []*execution.ExecutionConfig,
+	configName configv1alpha1.ConfigName,
+) (Config, error) {
+	sorted := make([]*execution.ExecutionConfig, len(executionConfigs))
+	copy(sorted, executionConfigs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	res := make(Config)
+	for _, executionConfig := range sorted {
+		loaded, err := ExecutionConfigToConfig(executionConfig, configName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot convert executionconfig %v", executionConfig.Name)
+		}
+		if err := mergo.Merge(&res, loaded, mergo.WithOverride); err != nil {
+			return nil, errors.Wrapf(err, "cannot merge configs")
+		}
+	}
+
+	return res, nil
+}
+
+// ExecutionConfigToConfig returns the Config that overrides the given config
+// name from an ExecutionConfig.
+func ExecutionConfigToConfig(
+	executionConfig *execution.ExecutionConfig,
+	configName configv1alpha1.ConfigName,
+) (Config, error) {
+	switch configName {
+	case configv1alpha1.JobExecutionConfigName:
+		overrides := executionConfig.Spec.Jobs
+		if overrides == nil {
+			return nil, nil
+		}
+		cfg, err := toConfig(overrides)
+		if err != nil {
+			return nil, err
+		}
+
+		// The concurrency limit of the namespace is stored in a map keyed by
+		// namespace, which takes precedence over the global limit.
+		if limit, ok := cfg["maxConcurrentJobs"]; ok {
+			delete(cfg, "maxConcurrentJobs")
+			cfg["namespaceMaxConcurrentJobs"] = map[string]interface{}{
+				executionConfig.Namespace: limit,
+			}
+		}
+		return cfg, nil
+
+	case configv1alpha1.CronExecutionConfigName:
+		if overrides := executionConfig.Spec.Cron; overrides != nil {
+			return toConfig(overrides)
+		}
+	}
+
+	return nil, nil
+}
+
+// toConfig converts a struct into a Config using its JSON representation.
+func toConfig(v interface{}) (Config, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	cfg := make(Config)
+	if err := json.Unmarshal(bytes, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
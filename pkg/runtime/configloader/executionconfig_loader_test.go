@@ -0,0 +1,184 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/fake"
+	"github.com/furiko-io/furiko/pkg/runtime/configloader"
+)
+
+const (
+	executionConfigNamespace = "test-namespace"
+)
+
+func TestExecutionConfigLoader(t *testing.T) {
+	tests := []struct {
+		name             string
+		global           MockConfig
+		executionConfigs []*execution.ExecutionConfig
+		namespace        string
+		want             *configv1alpha1.JobExecutionConfig
+	}{
+		{
+			name: "no ExecutionConfigs",
+			global: MockConfig{
+				configv1alpha1.JobExecutionConfigName: {
+					"defaultTTLSecondsAfterFinished": 180,
+				},
+			},
+			namespace: executionConfigNamespace,
+			want: &configv1alpha1.JobExecutionConfig{
+				DefaultTTLSecondsAfterFinished: pointer.Int64(180),
+			},
+		},
+		{
+			name: "override global config",
+			global: MockConfig{
+				configv1alpha1.JobExecutionConfigName: {
+					"defaultTTLSecondsAfterFinished": 180,
+					"defaultPendingTimeoutSeconds":   900,
+				},
+			},
+			executionConfigs: []*execution.ExecutionConfig{
+				newExecutionConfig("config", executionConfigNamespace, &execution.JobExecutionConfigOverrides{
+					DefaultTTLSecondsAfterFinished: pointer.Int64(60),
+				}),
+			},
+			namespace: executionConfigNamespace,
+			want: &configv1alpha1.JobExecutionConfig{
+				DefaultTTLSecondsAfterFinished: pointer.Int64(60),
+				DefaultPendingTimeoutSeconds:   pointer.Int64(900),
+			},
+		},
+		{
+			name: "do not override config for other namespaces",
+			global: MockConfig{
+				configv1alpha1.JobExecutionConfigName: {
+					"defaultTTLSecondsAfterFinished": 180,
+				},
+			},
+			executionConfigs: []*execution.ExecutionConfig{
+				newExecutionConfig("config", executionConfigNamespace, &execution.JobExecutionConfigOverrides{
+					DefaultTTLSecondsAfterFinished: pointer.Int64(60),
+				}),
+			},
+			namespace: "other-namespace",
+			want: &configv1alpha1.JobExecutionConfig{
+				DefaultTTLSecondsAfterFinished: pointer.Int64(180),
+			},
+		},
+		{
+			name: "merge multiple ExecutionConfigs in name order",
+			executionConfigs: []*execution.ExecutionConfig{
+				newExecutionConfig("config-b", executionConfigNamespace, &execution.JobExecutionConfigOverrides{
+					DefaultTTLSecondsAfterFinished: pointer.Int64(120),
+				}),
+				newExecutionConfig("config-a", executionConfigNamespace, &execution.JobExecutionConfigOverrides{
+					DefaultTTLSecondsAfterFinished: pointer.Int64(60),
+					DefaultPendingTimeoutSeconds:   pointer.Int64(300),
+				}),
+			},
+			namespace: executionConfigNamespace,
+			want: &configv1alpha1.JobExecutionConfig{
+				DefaultTTLSecondsAfterFinished: pointer.Int64(120),
+				DefaultPendingTimeoutSeconds:   pointer.Int64(300),
+			},
+		},
+		{
+			name: "merge maxConcurrentJobs with global namespace limits",
+			global: MockConfig{
+				configv1alpha1.JobExecutionConfigName: {
+					"maxConcurrentJobsPerNamespace": 10,
+					"namespaceMaxConcurrentJobs": map[string]interface{}{
+						"other-namespace":        5,
+						executionConfigNamespace: 20,
+					},
+				},
+			},
+			executionConfigs: []*execution.ExecutionConfig{
+				newExecutionConfig("config", executionConfigNamespace, &execution.JobExecutionConfigOverrides{
+					MaxConcurrentJobs: pointer.Int64(30),
+				}),
+			},
+			namespace: executionConfigNamespace,
+			want: &configv1alpha1.JobExecutionConfig{
+				MaxConcurrentJobsPerNamespace: pointer.Int64(10),
+				NamespaceMaxConcurrentJobs: map[string]int64{
+					"other-namespace":        5,
+					executionConfigNamespace: 30,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+
+			client := fake.NewSimpleClientset()
+			for _, executionConfig := range tt.executionConfigs {
+				if _, err := client.ExecutionV1alpha1().ExecutionConfigs(executionConfig.Namespace).
+					Create(ctx, executionConfig, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("cannot create ExecutionConfig: %v", err)
+				}
+			}
+
+			mgr := configloader.NewConfigManager()
+			mgr.AddConfigLoaders(newMockConfigLoader(tt.global))
+			mgr.AddNamespacedConfigLoaders(configloader.NewExecutionConfigLoader(client))
+			if err := mgr.Start(ctx); err != nil {
+				t.Fatalf("cannot start ConfigManager: %v", err)
+			}
+
+			got := &configv1alpha1.JobExecutionConfig{}
+			if err := mgr.LoadAndUnmarshalNamespacedConfig(
+				configv1alpha1.JobExecutionConfigName, tt.namespace, got,
+			); err != nil {
+				t.Fatalf("cannot load config: %v", err)
+			}
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("LoadAndUnmarshalNamespacedConfig() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func newExecutionConfig(
+	name, namespace string, jobs *execution.JobExecutionConfigOverrides,
+) *execution.ExecutionConfig {
+	return &execution.ExecutionConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: execution.ExecutionConfigSpec{
+			Jobs: jobs,
+		},
+	}
+}
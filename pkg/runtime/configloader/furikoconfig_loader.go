@@ -0,0 +1,224 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	furiko "github.com/furiko-io/furiko/pkg/generated/clientset/versioned"
+	furikoinformers "github.com/furiko-io/furiko/pkg/generated/informers/externalversions"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	defaultFurikoConfigName = "default"
+
+	updateStatusTimeout = time.Second * 10
+)
+
+// FurikoConfigLoader is a dynamic Loader that starts an informer to watch
+// changes on a cluster-scoped FurikoConfig with a specific name. If
+// reportStatus is true, the status of the FurikoConfig will be updated with
+// the last loaded generation after each reload.
+type FurikoConfigLoader struct {
+	client       furiko.Interface
+	mu           sync.RWMutex
+	cache        *configCache
+	name         string
+	reportStatus bool
+}
+
+var _ Loader = (*FurikoConfigLoader)(nil)
+
+func NewFurikoConfigLoader(client furiko.Interface, name string, reportStatus bool) *FurikoConfigLoader {
+	if name == "" {
+		name = defaultFurikoConfigName
+	}
+	return &FurikoConfigLoader{
+		client:       client,
+		cache:        newConfigCache(),
+		name:         name,
+		reportStatus: reportStatus,
+	}
+}
+
+func (c *FurikoConfigLoader) Name() string {
+	return "FurikoConfigLoader"
+}
+
+func (c *FurikoConfigLoader) Start(ctx context.Context) error {
+	klog.V(4).InfoS("configloader: config loader starting", "loader", c.Name())
+
+	// Create shared informer factory watching only the specified FurikoConfig.
+	informerFactory := furikoinformers.NewSharedInformerFactoryWithOptions(c.client, time.Minute*10,
+		furikoinformers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", c.name).String()
+		}),
+	)
+	informer := informerFactory.Execution().V1alpha1().FurikoConfigs().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.handleUpdate(ctx, obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.handleUpdate(ctx, newObj)
+		},
+		DeleteFunc: c.handleDelete,
+	})
+
+	informerFactory.Start(ctx.Done())
+
+	// Wait for caches to be synced with a timeout.
+	syncCtx, cancel := context.WithTimeout(ctx, time.Minute*3)
+	defer cancel()
+	if ok := cache.WaitForNamedCacheSync(c.Name(), syncCtx.Done(), informer.HasSynced); !ok {
+		klog.Error("configloader: failed to sync caches", "loader", c.Name())
+		return errors.New("failed to sync caches")
+	}
+
+	return nil
+}
+
+// Load returns the config stored in the FurikoConfig for the given config name.
+// If the FurikoConfig or the config name does not exist, an empty config will
+// be returned.
+func (c *FurikoConfigLoader) Load(configName configv1alpha1.ConfigName) (Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if value, ok := c.cache.Load(configName); ok {
+		return value, nil
+	}
+	return nil, nil
+}
+
+func (c *FurikoConfigLoader) handleUpdate(ctx context.Context, obj interface{}) {
+	furikoConfig, ok := obj.(*execution.FurikoConfig)
+	if !ok {
+		klog.ErrorS(fmt.Errorf("cannot convert %T to FurikoConfig", obj),
+			"configloader: unable to handle event", "loader", c.Name())
+		return
+	}
+
+	// Ignore update if it is not the FurikoConfig we are watching.
+	if furikoConfig.Name != c.name {
+		return
+	}
+
+	klog.V(4).InfoS("configloader: config loader observed update",
+		"loader", c.Name(),
+		"name", furikoConfig.Name,
+		"generation", furikoConfig.Generation,
+	)
+
+	newCache, err := furikoConfigToConfigCache(furikoConfig)
+	if err != nil {
+		klog.ErrorS(err, "configloader: cannot load config", "loader", c.Name())
+	} else {
+		c.setCache(newCache)
+	}
+
+	if c.reportStatus {
+		if err := c.updateStatus(ctx, furikoConfig, err); err != nil {
+			klog.ErrorS(err, "configloader: cannot update status", "loader", c.Name(), "name", furikoConfig.Name)
+		}
+	}
+}
+
+func (c *FurikoConfigLoader) handleDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if furikoConfig, ok := obj.(*execution.FurikoConfig); !ok || furikoConfig.Name != c.name {
+		return
+	}
+	klog.V(4).InfoS("configloader: config loader observed delete", "loader", c.Name(), "name", c.name)
+	c.setCache(newConfigCache())
+}
+
+func (c *FurikoConfigLoader) setCache(newCache *configCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = newCache
+}
+
+// updateStatus updates the status of the FurikoConfig with the result of
+// loading its latest generation.
+func (c *FurikoConfigLoader) updateStatus(
+	ctx context.Context,
+	furikoConfig *execution.FurikoConfig,
+	loadErr error,
+) error {
+	newFurikoConfig := furikoConfig.DeepCopy()
+	newStatus := &newFurikoConfig.Status
+	condition := metav1.Condition{
+		Type:               execution.FurikoConfigConditionLoaded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: furikoConfig.Generation,
+		LastTransitionTime: *ktime.Now(),
+		Reason:             "Loaded",
+	}
+	if loadErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "LoadFailed"
+		condition.Message = loadErr.Error()
+	} else {
+		newStatus.ObservedGeneration = furikoConfig.Generation
+	}
+	apimeta.SetStatusCondition(&newStatus.Conditions, condition)
+
+	if equality.Semantic.DeepEqual(furikoConfig.Status, newFurikoConfig.Status) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateStatusTimeout)
+	defer cancel()
+	_, err := c.client.ExecutionV1alpha1().FurikoConfigs().UpdateStatus(ctx, newFurikoConfig, metav1.UpdateOptions{})
+	return err
+}
+
+// furikoConfigToConfigCache converts the spec of a FurikoConfig into a Config
+// for each config name.
+func furikoConfigToConfigCache(furikoConfig *execution.FurikoConfig) (*configCache, error) {
+	newCache := newConfigCache()
+	spec := furikoConfig.Spec
+	for configName, v := range map[configv1alpha1.ConfigName]interface{}{
+		configv1alpha1.JobExecutionConfigName:        spec.Jobs,
+		configv1alpha1.JobConfigExecutionConfigName:  spec.JobConfigs,
+		configv1alpha1.CronExecutionConfigName:       spec.Cron,
+		configv1alpha1.ControllerExecutionConfigName: spec.Controllers,
+	} {
+		cfg, err := toConfig(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot convert %v", configName)
+		}
+		newCache.Store(configName, cfg)
+	}
+	return newCache, nil
+}
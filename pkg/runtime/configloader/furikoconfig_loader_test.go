@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/fake"
+	"github.com/furiko-io/furiko/pkg/runtime/configloader"
+)
+
+const (
+	furikoConfigName = "test-config"
+)
+
+func TestFurikoConfigLoader(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	client := fake.NewSimpleClientset()
+	mgr := configloader.NewConfigManager()
+	mgr.AddConfigLoaders(
+		newMockConfigLoader(MockConfig{
+			configv1alpha1.JobExecutionConfigName: {
+				"defaultTTLSecondsAfterFinished": 180,
+				"defaultPendingTimeoutSeconds":   900,
+			},
+		}),
+		configloader.NewFurikoConfigLoader(client, furikoConfigName, true),
+	)
+	err := mgr.Start(ctx)
+	assert.NoError(t, err)
+
+	// No FurikoConfig, should only have values from lower priority loaders.
+	cfg, err := loadJobControllerConfig(mgr)
+	assert.NoError(t, err)
+	assert.Equal(t, pointer.Int64(180), cfg.DefaultTTLSecondsAfterFinished)
+	assert.Equal(t, pointer.Int64(900), cfg.DefaultPendingTimeoutSeconds)
+
+	// Ignore FurikoConfigs with other names.
+	_, err = client.ExecutionV1alpha1().FurikoConfigs().Create(ctx,
+		newFurikoConfig("other-config", 1, pointer.Int64(60)), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	time.Sleep(fakeclientsetSleepDuration)
+	cfg, err = loadJobControllerConfig(mgr)
+	assert.NoError(t, err)
+	assert.Equal(t, pointer.Int64(180), cfg.DefaultTTLSecondsAfterFinished)
+
+	// Create FurikoConfig, should take precedence.
+	_, err = client.ExecutionV1alpha1().FurikoConfigs().Create(ctx,
+		newFurikoConfig(furikoConfigName, 1, pointer.Int64(60)), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	time.Sleep(fakeclientsetSleepDuration)
+	cfg, err = loadJobControllerConfig(mgr)
+	assert.NoError(t, err)
+	assert.Equal(t, pointer.Int64(60), cfg.DefaultTTLSecondsAfterFinished)
+	assert.Equal(t, pointer.Int64(900), cfg.DefaultPendingTimeoutSeconds)
+	assertFurikoConfigLoaded(ctx, t, client, 1)
+
+	// Update FurikoConfig.
+	_, err = client.ExecutionV1alpha1().FurikoConfigs().Update(ctx,
+		newFurikoConfig(furikoConfigName, 2, pointer.Int64(120)), metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	time.Sleep(fakeclientsetSleepDuration)
+	cfg, err = loadJobControllerConfig(mgr)
+	assert.NoError(t, err)
+	assert.Equal(t, pointer.Int64(120), cfg.DefaultTTLSecondsAfterFinished)
+	assertFurikoConfigLoaded(ctx, t, client, 2)
+
+	// Delete FurikoConfig, should fall back to lower priority loaders.
+	err = client.ExecutionV1alpha1().FurikoConfigs().Delete(ctx, furikoConfigName, metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	time.Sleep(fakeclientsetSleepDuration)
+	cfg, err = loadJobControllerConfig(mgr)
+	assert.NoError(t, err)
+	assert.Equal(t, pointer.Int64(180), cfg.DefaultTTLSecondsAfterFinished)
+}
+
+func assertFurikoConfigLoaded(ctx context.Context, t *testing.T, client *fake.Clientset, generation int64) {
+	furikoConfig, err := client.ExecutionV1alpha1().FurikoConfigs().Get(ctx, furikoConfigName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, generation, furikoConfig.Status.ObservedGeneration)
+	if assert.Len(t, furikoConfig.Status.Conditions, 1) {
+		condition := furikoConfig.Status.Conditions[0]
+		assert.Equal(t, execution.FurikoConfigConditionLoaded, condition.Type)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, generation, condition.ObservedGeneration)
+	}
+}
+
+func newFurikoConfig(name string, generation int64, defaultTTLSecondsAfterFinished *int64) *execution.FurikoConfig {
+	return &execution.FurikoConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Generation: generation,
+		},
+		Spec: execution.FurikoConfigSpec{
+			Jobs: &configv1alpha1.JobExecutionConfig{
+				DefaultTTLSecondsAfterFinished: defaultTTLSecondsAfterFinished,
+			},
+		},
+	}
+}
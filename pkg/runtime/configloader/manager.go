@@ -32,39 +32,97 @@ import (
 // ConfigManager manages ConfigLoaders and merges structured configuration
 // values from multiple sources. The order in which the configurations are
 // merged are based on the order of when each Loader is added to the
-// ConfigManager.
+// ConfigManager. Namespaced configs are merged on top of all global configs,
+// in the order of when each NamespacedLoader is added.
 type ConfigManager struct {
-	loaders []Loader
-	started bool
-	cache   sync.Map
+	loaders           []Loader
+	namespacedLoaders []NamespacedLoader
+	started           bool
+	cache             sync.Map
+
+	statusMu       sync.RWMutex
+	configStatuses map[configv1alpha1.ConfigName]*ConfigStatus
+	loaderStatuses map[string]*LoaderStatus
+}
+
+// namespacedCacheKey is the cache key for last known good values of namespaced
+// configs.
+type namespacedCacheKey struct {
+	configName configv1alpha1.ConfigName
+	namespace  string
 }
 
 func NewConfigManager() *ConfigManager {
-	return &ConfigManager{}
+	return &ConfigManager{
+		configStatuses: make(map[configv1alpha1.ConfigName]*ConfigStatus),
+		loaderStatuses: make(map[string]*LoaderStatus),
+	}
 }
 
 func (c *ConfigManager) AddConfigLoaders(loader ...Loader) {
 	c.loaders = append(c.loaders, loader...)
 }
 
+// AddNamespacedConfigLoaders adds NamespacedLoaders, which take precedence
+// over all Loaders when loading namespaced configs.
+func (c *ConfigManager) AddNamespacedConfigLoaders(loader ...NamespacedLoader) {
+	c.namespacedLoaders = append(c.namespacedLoaders, loader...)
+}
+
 func (c *ConfigManager) Start(ctx context.Context) error {
 	for _, loader := range c.loaders {
 		if err := loader.Start(ctx); err != nil {
 			return errors.Wrapf(err, "cannot load %v", loader.Name())
 		}
 	}
+	for _, loader := range c.namespacedLoaders {
+		if err := loader.Start(ctx); err != nil {
+			return errors.Wrapf(err, "cannot load %v", loader.Name())
+		}
+	}
 	c.started = true
 	return nil
 }
 
 // LoadAndUnmarshalConfig will load and unmarshal the given config name into out.
 //
-// If an error is encountered, it will return a previously known good value if
-// available, and log the error. Otherwise, if there is no previously cached
+// If an error is encountered, or the loaded config fails validation, it will
+// return a previously known good value if available, and log the error. Otherwise, if there is no previously cached
 // value for configName, then the error will be propagated back to the caller.
 func (c *ConfigManager) LoadAndUnmarshalConfig(configName configv1alpha1.ConfigName, out interface{}) error {
-	err := c.loadAndUnmarshalConfigWithError(configName, out)
+	config, err := c.loadAndUnmarshalConfigWithError(configName, out, c.loadConfig)
+	if err == nil {
+		c.observeConfig(configName, config)
+	}
+	return c.handleLoadResult(configName, configName, out, err)
+}
+
+// LoadAndUnmarshalNamespacedConfig will load and unmarshal the given config
+// name for a specific namespace into out. Configs loaded from
+// NamespacedLoaders for the namespace take precedence over global configs.
+//
+// Error handling is identical to LoadAndUnmarshalConfig.
+func (c *ConfigManager) LoadAndUnmarshalNamespacedConfig(
+	configName configv1alpha1.ConfigName,
+	namespace string,
+	out interface{},
+) error {
+	load := func(configName configv1alpha1.ConfigName) (Config, error) {
+		return c.loadNamespacedConfig(configName, namespace)
+	}
+	_, err := c.loadAndUnmarshalConfigWithError(configName, out, load)
+	cacheKey := namespacedCacheKey{configName: configName, namespace: namespace}
+	return c.handleLoadResult(cacheKey, configName, out, err)
+}
 
+// handleLoadResult stores the loaded value in the cache if err is nil,
+// otherwise falls back to the cached value if available.
+func (c *ConfigManager) handleLoadResult(
+	cacheKey interface{},
+	configName configv1alpha1.ConfigName,
+	out interface{},
+	err error,
+) error {
 	// Return cached value and log error.
 	// We use reflection to write into the value referenced by the pointer out.
 	if err != nil {
@@ -79,7 +137,7 @@ func (c *ConfigManager) LoadAndUnmarshalConfig(configName configv1alpha1.ConfigN
 		}
 
 		// Here we load the previously cached value into the pointer.
-		loadVal, ok := c.cache.Load(configName)
+		loadVal, ok := c.cache.Load(cacheKey)
 		if ok {
 			dataVal := reflect.ValueOf(loadVal)
 
@@ -102,26 +160,39 @@ func (c *ConfigManager) LoadAndUnmarshalConfig(configName configv1alpha1.ConfigN
 	}
 
 	// Store in cache.
-	c.cache.Store(configName, out)
+	c.cache.Store(cacheKey, out)
 	return nil
 }
 
-func (c *ConfigManager) loadAndUnmarshalConfigWithError(configName configv1alpha1.ConfigName, out interface{}) error {
+func (c *ConfigManager) loadAndUnmarshalConfigWithError(
+	configName configv1alpha1.ConfigName,
+	out interface{},
+	load func(configName configv1alpha1.ConfigName) (Config, error),
+) (Config, error) {
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		TagName: "json",
 		Result:  out,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	configMap, err := c.loadConfig(configName)
+	configMap, err := load(configName)
 	if err != nil {
-		return errors.Wrapf(err, "cannot load config %v", configName)
+		dynamicConfigLoadErrorsTotal.WithLabelValues(string(configName), errorKindLoad).Inc()
+		return nil, errors.Wrapf(err, "cannot load config %v", configName)
 	}
 	if err := decoder.Decode(configMap); err != nil {
-		return errors.Wrapf(err, "cannot decode %v", configName)
+		dynamicConfigLoadErrorsTotal.WithLabelValues(string(configName), errorKindDecode).Inc()
+		return nil, errors.Wrapf(err, "cannot decode %v", configName)
 	}
-	return nil
+
+	// Reject invalid or inconsistent configs, so that the last known good value
+	// will continue to be used instead.
+	if err := ValidateConfig(out); err != nil {
+		dynamicConfigLoadErrorsTotal.WithLabelValues(string(configName), errorKindValidate).Inc()
+		return nil, errors.Wrapf(err, "invalid config %v", configName)
+	}
+	return configMap, nil
 }
 
 // loadConfig will load the given config name from all loaders.
@@ -144,6 +215,39 @@ func (c *ConfigManager) loadConfig(configName configv1alpha1.ConfigName) (res Co
 	res = make(Config)
 	for _, loader := range c.loaders {
 		loaded, err := loader.Load(configName)
+		c.observeLoader(loader.Name(), err)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot load %v", loader.Name())
+		}
+		if err := mergo.Merge(&res, loaded, mergo.WithOverride); err != nil {
+			return nil, errors.Wrapf(err, "cannot merge configs")
+		}
+	}
+
+	return res, nil
+}
+
+// loadNamespacedConfig will load the given config name from all loaders, and
+// merge the namespaced configs for the given namespace on top of it.
+func (c *ConfigManager) loadNamespacedConfig(configName configv1alpha1.ConfigName, namespace string) (res Config, err error) {
+	res, err = c.loadConfig(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle panic from mergo.
+	defer func() {
+		if e := recover(); e != nil {
+			err = errors.New("recovered from panic")
+			if recovered, ok := e.(error); ok {
+				err = recovered
+			}
+		}
+	}()
+
+	for _, loader := range c.namespacedLoaders {
+		loaded, err := loader.LoadNamespaced(configName, namespace)
+		c.observeLoader(loader.Name(), err)
 		if err != nil {
 			return nil, errors.Wrapf(err, "cannot load %v", loader.Name())
 		}
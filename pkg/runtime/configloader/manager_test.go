@@ -524,3 +524,49 @@ func checkConfig(t *testing.T, mgr *configloader.ConfigManager, want *Config, wa
 		t.Errorf("LoadAndUnmarshalConfig() not equal, diff = %v", cmp.Diff(want, cfg))
 	}
 }
+
+func TestConfigManager_Validation(t *testing.T) {
+	loader := newMockDynamicConfigLoader(MockConfig{
+		configv1alpha1.JobExecutionConfigName: {
+			"defaultPendingTimeoutSeconds": 900,
+			"ttlAfterFinishedPolicy":       "DeleteJob",
+		},
+	})
+	mgr := configloader.NewConfigManager()
+	mgr.AddConfigLoaders(loader)
+	if err := mgr.Start(context.Background()); err != nil {
+		t.Fatalf("cannot start ConfigManager: %v", err)
+	}
+
+	want := &configv1alpha1.JobExecutionConfig{
+		DefaultPendingTimeoutSeconds: pointer.Int64(900),
+		TTLAfterFinishedPolicy:       configv1alpha1.TTLAfterFinishedPolicyDeleteJob,
+	}
+	cfg, err := loadJobControllerConfig(mgr)
+	if err != nil {
+		t.Fatalf("cannot load config: %v", err)
+	}
+	if !cmp.Equal(want, cfg) {
+		t.Errorf("LoadAndUnmarshalConfig() not equal, diff = %v", cmp.Diff(want, cfg))
+	}
+
+	// Invalid update should be rejected in favour of the last known good value.
+	loader.SetConfig(MockConfig{
+		configv1alpha1.JobExecutionConfigName: {
+			"defaultPendingTimeoutSeconds": -1,
+			"ttlAfterFinishedPolicy":       "DeleteJob",
+		},
+	})
+	cfg, err = loadJobControllerConfig(mgr)
+	if err != nil {
+		t.Fatalf("cannot load config: %v", err)
+	}
+	if !cmp.Equal(want, cfg) {
+		t.Errorf("LoadAndUnmarshalConfig() not equal, diff = %v", cmp.Diff(want, cfg))
+	}
+
+	// Invalid config without any previously known good value should be an error.
+	if _, err := loadJobControllerConfig(configloader.NewConfigManager()); err == nil {
+		t.Errorf("LoadAndUnmarshalConfig() expected error")
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	promNamespace = "furiko"
+)
+
+const (
+	errorKindLoad     = "load"
+	errorKindDecode   = "decode"
+	errorKindValidate = "validate"
+)
+
+var (
+	dynamicConfigLoadErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "dynamic_config_load_errors_total",
+			Help:      "Total number of dynamic config loads which failed and fell back to the last known good value",
+		},
+		[]string{"config_name", "error_kind"},
+	)
+
+	remoteConfigFetchErrorsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "dynamic_config_remote_fetch_errors_total",
+			Help:      "Total number of failed attempts to fetch or verify the remote dynamic config document",
+		},
+	)
+
+	dynamicConfigInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: promNamespace,
+			Name:      "dynamic_config_info",
+			Help:      "Hash of each dynamic config that is currently in use, always set to 1",
+		},
+		[]string{"config_name", "hash"},
+	)
+
+	dynamicConfigLoaderLastLoadTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: promNamespace,
+			Name:      "dynamic_config_loader_last_load_timestamp_seconds",
+			Help:      "Unix timestamp of when each dynamic config loader last loaded a config successfully",
+		},
+		[]string{"loader"},
+	)
+
+	dynamicConfigLoaderErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      "dynamic_config_loader_errors_total",
+			Help:      "Total number of errors returned by each dynamic config loader",
+		},
+		[]string{"loader"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		dynamicConfigLoadErrorsTotal,
+		remoteConfigFetchErrorsTotal,
+		dynamicConfigInfo,
+		dynamicConfigLoaderLastLoadTimestamp,
+		dynamicConfigLoaderErrorsTotal,
+	)
+}
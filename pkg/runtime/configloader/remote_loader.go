@@ -0,0 +1,325 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	defaultRemotePollInterval = time.Minute
+	defaultS3Region           = "us-east-1"
+	s3Service                 = "s3"
+	remoteFetchTimeout        = time.Second * 30
+	remoteMaxDocumentSize     = 1 << 20
+
+	// emptyPayloadHash is the SHA-256 hash of an empty request body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// RemoteLoader is a dynamic Loader that polls a remote HTTP(S) or S3 endpoint
+// for a config document. The ETag of the last fetched document is used to
+// avoid downloading unchanged documents, and the document may optionally be
+// verified using an Ed25519 signature. Requests to S3 are signed with
+// credentials from the default credential chain of the AWS SDK if any are
+// found.
+type RemoteLoader struct {
+	client       *http.Client
+	url          remoteURL
+	signatureURL remoteURL
+	publicKey    ed25519.PublicKey
+	pollInterval time.Duration
+	s3Region     string
+	credentials  aws.CredentialsProvider
+	signer       *v4.Signer
+
+	mu    sync.RWMutex
+	cache *configCache
+	etag  string
+}
+
+var _ Loader = (*RemoteLoader)(nil)
+
+func NewRemoteLoader(cfg *configv1alpha1.RemoteConfigLoaderSpec) (*RemoteLoader, error) {
+	s3Region := cfg.S3Region
+	if s3Region == "" {
+		s3Region = defaultS3Region
+	}
+	documentURL, err := resolveRemoteURL(cfg.URL, s3Region)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(s3Region)}
+	if creds := cfg.S3Credentials; creds != nil && creds.AccessKeyID != "" && creds.SecretAccessKey != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(awscredentials.NewStaticCredentialsProvider(
+			creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken,
+		)))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load aws config")
+	}
+
+	loader := &RemoteLoader{
+		client:       &http.Client{Timeout: remoteFetchTimeout},
+		url:          documentURL,
+		pollInterval: defaultRemotePollInterval,
+		s3Region:     s3Region,
+		credentials:  awsConfig.Credentials,
+		signer:       v4.NewSigner(),
+		cache:        newConfigCache(),
+	}
+
+	if cfg.PollIntervalSeconds != nil && *cfg.PollIntervalSeconds > 0 {
+		loader.pollInterval = time.Duration(*cfg.PollIntervalSeconds) * time.Second
+	}
+
+	if cfg.PublicKey != "" {
+		key, err := base64.StdEncoding.DecodeString(cfg.PublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode public key")
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key size %v, expected %v", len(key), ed25519.PublicKeySize)
+		}
+		loader.publicKey = key
+		loader.signatureURL = remoteURL{url: documentURL.url + ".sig", s3: documentURL.s3}
+		if cfg.SignatureURL != "" {
+			signatureURL, err := resolveRemoteURL(cfg.SignatureURL, s3Region)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid signature url")
+			}
+			loader.signatureURL = signatureURL
+		}
+	}
+
+	return loader, nil
+}
+
+func (c *RemoteLoader) Name() string {
+	return "RemoteLoader"
+}
+
+// Start fetches the remote document once, and returns an error if it could
+// not be fetched. The document is subsequently polled in the background.
+func (c *RemoteLoader) Start(ctx context.Context) error {
+	klog.V(4).InfoS("configloader: config loader starting", "loader", c.Name(), "url", c.url.url)
+	if err := c.fetch(ctx); err != nil {
+		return errors.Wrapf(err, "cannot fetch remote config")
+	}
+	go wait.UntilWithContext(ctx, c.poll, c.pollInterval)
+	return nil
+}
+
+// Load returns the unmarshaled config data for the given config name from the
+// last successfully fetched document. If the config name does not exist in
+// the document, an empty config will be returned.
+func (c *RemoteLoader) Load(configName configv1alpha1.ConfigName) (Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if value, ok := c.cache.Load(configName); ok {
+		return value, nil
+	}
+	return nil, nil
+}
+
+func (c *RemoteLoader) poll(ctx context.Context) {
+	if err := c.fetch(ctx); err != nil {
+		remoteConfigFetchErrorsTotal.Inc()
+		klog.ErrorS(err, "configloader: cannot fetch remote config, using last fetched value",
+			"loader", c.Name(), "url", c.url.url)
+	}
+}
+
+// fetch downloads the document if it was changed since the last fetch, and
+// updates the cache only if the document could be verified and unmarshaled.
+func (c *RemoteLoader) fetch(ctx context.Context) error {
+	c.mu.RLock()
+	etag := c.etag
+	c.mu.RUnlock()
+
+	data, newETag, modified, err := c.get(ctx, c.url, etag)
+	if err != nil {
+		return err
+	}
+	if !modified {
+		return nil
+	}
+
+	if c.publicKey != nil {
+		signature, _, _, err := c.get(ctx, c.signatureURL, "")
+		if err != nil {
+			return errors.Wrapf(err, "cannot fetch signature")
+		}
+		if err := c.verify(data, signature); err != nil {
+			return err
+		}
+	}
+
+	newCache, err := c.unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	klog.V(4).InfoS("configloader: config loader observed update", "loader", c.Name(), "url", c.url.url,
+		"etag", newETag)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = newCache
+	c.etag = newETag
+	return nil
+}
+
+// get performs a conditional GET request, and returns the response body and
+// ETag. If the document was not modified since etag, modified will be false.
+func (c *RemoteLoader) get(
+	ctx context.Context, documentURL remoteURL, etag string,
+) (data []byte, newETag string, modified bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, documentURL.url, nil)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if documentURL.s3 {
+		if err := c.signS3(ctx, req); err != nil {
+			return nil, "", false, err
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, etag, false, nil
+	default:
+		return nil, "", false, fmt.Errorf("unexpected status code %v from %v", resp.StatusCode, documentURL.url)
+	}
+
+	data, err = io.ReadAll(io.LimitReader(resp.Body, remoteMaxDocumentSize+1))
+	if err != nil {
+		return nil, "", false, errors.Wrapf(err, "cannot read response body")
+	}
+	if len(data) > remoteMaxDocumentSize {
+		return nil, "", false, fmt.Errorf("document exceeds maximum size of %v bytes", remoteMaxDocumentSize)
+	}
+
+	return data, resp.Header.Get("ETag"), true, nil
+}
+
+// signS3 signs the request to S3 with credentials from the credential chain.
+// If no credentials can be retrieved, the request is left unsigned so that
+// publicly readable objects can still be fetched anonymously.
+func (c *RemoteLoader) signS3(ctx context.Context, req *http.Request) error {
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		klog.V(4).InfoS("configloader: cannot retrieve aws credentials, sending unsigned request",
+			"loader", c.Name(), "err", err)
+		return nil
+	}
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	if err := c.signer.SignHTTP(ctx, creds, req, emptyPayloadHash, s3Service, c.s3Region, ktime.Now().Time); err != nil {
+		return errors.Wrapf(err, "cannot sign request")
+	}
+	return nil
+}
+
+func (c *RemoteLoader) verify(data, signature []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.Wrapf(err, "cannot decode signature")
+	}
+	if !ed25519.Verify(c.publicKey, data, decoded) {
+		return errors.New("signature verification failed")
+	}
+	return nil
+}
+
+func (c *RemoteLoader) unmarshal(data []byte) (*configCache, error) {
+	var document map[string]Config
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	if err := decoder.Decode(&document); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal remote config")
+	}
+	newCache := newConfigCache()
+	for name, value := range document {
+		newCache.Store(configv1alpha1.ConfigName(name), value)
+	}
+	return newCache, nil
+}
+
+// remoteURL is an HTTP(S) URL to fetch, which refers to an S3 object if s3 is
+// true.
+type remoteURL struct {
+	url string
+	s3  bool
+}
+
+// resolveRemoteURL returns the HTTP(S) URL to fetch the given URL from. URLs
+// using the s3 scheme are converted to the virtual-hosted-style URL of the
+// bucket, or a path-style URL if AWS_ENDPOINT_URL_S3 is set.
+func resolveRemoteURL(rawURL, s3Region string) (remoteURL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return remoteURL{}, errors.Wrapf(err, "cannot parse url")
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return remoteURL{url: u.String()}, nil
+	case "s3":
+		key := strings.TrimPrefix(u.EscapedPath(), "/")
+		if u.Host == "" || key == "" {
+			return remoteURL{}, fmt.Errorf("s3 url must be in the form s3://bucket/key: %v", rawURL)
+		}
+		if endpoint := os.Getenv("AWS_ENDPOINT_URL_S3"); endpoint != "" {
+			return remoteURL{url: fmt.Sprintf("%v/%v/%v", strings.TrimSuffix(endpoint, "/"), u.Host, key), s3: true}, nil
+		}
+		return remoteURL{url: fmt.Sprintf("https://%v.s3.%v.amazonaws.com/%v", u.Host, s3Region, key), s3: true}, nil
+	}
+	return remoteURL{}, fmt.Errorf("unsupported url scheme %v", u.Scheme)
+}
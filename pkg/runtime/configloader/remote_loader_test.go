@@ -0,0 +1,294 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/configloader"
+)
+
+type remoteServer struct {
+	mu          sync.Mutex
+	privateKey  ed25519.PrivateKey
+	document    string
+	signature   string
+	notModified int
+}
+
+func (s *remoteServer) SetDocument(document string, sign bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.document = document
+	s.signature = "invalid"
+	if sign {
+		s.signature = base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, []byte(document)))
+	}
+}
+
+func (s *remoteServer) NotModified() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.notModified
+}
+
+func (s *remoteServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.URL.Path {
+	case "/config.yaml":
+		etag := fmt.Sprintf(`"%x"`, len(s.document)+len(s.signature))
+		if r.Header.Get("If-None-Match") == etag {
+			s.notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(s.document))
+	case "/config.yaml.sig":
+		_, _ = w.Write([]byte(s.signature))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRemoteLoader(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	handler := &remoteServer{privateKey: privateKey}
+	handler.SetDocument(`
+jobs:
+  defaultPendingTimeoutSeconds: 180
+`, true)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	loader, err := configloader.NewRemoteLoader(&configv1alpha1.RemoteConfigLoaderSpec{
+		URL:                 server.URL + "/config.yaml",
+		PollIntervalSeconds: pointer.Int64(1),
+		PublicKey:           base64.StdEncoding.EncodeToString(publicKey),
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	mgr := configloader.NewConfigManager()
+	mgr.AddConfigLoaders(loader)
+	assert.NoError(t, mgr.Start(ctx))
+
+	loadPendingTimeout := func() *int64 {
+		cfg, err := loadJobControllerConfig(mgr)
+		assert.NoError(t, err)
+		return cfg.DefaultPendingTimeoutSeconds
+	}
+	assert.Equal(t, pointer.Int64(180), loadPendingTimeout())
+
+	// Unchanged document should not be downloaded again.
+	assert.Eventually(t, func() bool {
+		return handler.NotModified() > 0
+	}, time.Second*3, time.Millisecond*100)
+
+	// Document with invalid signature should not be used.
+	handler.SetDocument(`{"jobs": {"defaultPendingTimeoutSeconds": 190}}`, false)
+	time.Sleep(time.Millisecond * 1500)
+	assert.Equal(t, pointer.Int64(180), loadPendingTimeout())
+
+	// Update with valid signature.
+	handler.SetDocument(`{"jobs": {"defaultPendingTimeoutSeconds": 190}}`, true)
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(pointer.Int64(190), loadPendingTimeout())
+	}, time.Second*3, time.Millisecond*100)
+}
+
+func TestRemoteLoader_StartError(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	handler := &remoteServer{privateKey: privateKey}
+	handler.SetDocument(`{"jobs": {"defaultPendingTimeoutSeconds": 190}}`, false)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{
+			name: "invalid signature",
+			url:  server.URL + "/config.yaml",
+		},
+		{
+			name: "not found",
+			url:  server.URL + "/notfound.yaml",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			loader, err := configloader.NewRemoteLoader(&configv1alpha1.RemoteConfigLoaderSpec{
+				URL:       tt.url,
+				PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+			})
+			assert.NoError(t, err)
+			assert.Error(t, loader.Start(context.Background()))
+		})
+	}
+}
+
+func TestRemoteLoader_S3(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials *configv1alpha1.AWSCredentialsSpec
+		env         map[string]string
+		wantKeyID   string
+	}{
+		{
+			name: "anonymous",
+		},
+		{
+			name: "static credentials",
+			credentials: &configv1alpha1.AWSCredentialsSpec{
+				AccessKeyID:     "AKIDSTATIC",
+				SecretAccessKey: "secret",
+			},
+			wantKeyID: "AKIDSTATIC",
+		},
+		{
+			name: "credentials from env",
+			env: map[string]string{
+				"AWS_ACCESS_KEY_ID":     "AKIDENV",
+				"AWS_SECRET_ACCESS_KEY": "secret",
+			},
+			wantKeyID: "AKIDENV",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorization, contentSHA256 string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/bucket/furiko/config.yaml" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				authorization = r.Header.Get("Authorization")
+				contentSHA256 = r.Header.Get("X-Amz-Content-Sha256")
+				_, _ = w.Write([]byte(`{"jobs": {"defaultPendingTimeoutSeconds": 190}}`))
+			}))
+			defer server.Close()
+
+			t.Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+			t.Setenv("AWS_ACCESS_KEY_ID", "")
+			t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+			t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+			t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+			t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "")
+			t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			loader, err := configloader.NewRemoteLoader(&configv1alpha1.RemoteConfigLoaderSpec{
+				URL:           "s3://bucket/furiko/config.yaml",
+				S3Region:      "ap-southeast-1",
+				S3Credentials: tt.credentials,
+			})
+			assert.NoError(t, err)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			assert.NoError(t, loader.Start(ctx))
+
+			if tt.wantKeyID == "" {
+				assert.Equal(t, "", authorization)
+				return
+			}
+			assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential="+tt.wantKeyID+"/"),
+				"unexpected authorization header: %v", authorization)
+			assert.Contains(t, authorization, "/ap-southeast-1/s3/aws4_request")
+			assert.NotEmpty(t, contentSHA256)
+		})
+	}
+}
+
+func TestNewRemoteLoader(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *configv1alpha1.RemoteConfigLoaderSpec
+		wantErr bool
+	}{
+		{
+			name: "https url",
+			cfg: &configv1alpha1.RemoteConfigLoaderSpec{
+				URL: "https://config.example.com/furiko.yaml",
+			},
+		},
+		{
+			name: "s3 url",
+			cfg: &configv1alpha1.RemoteConfigLoaderSpec{
+				URL:      "s3://bucket/furiko/config.yaml",
+				S3Region: "ap-southeast-1",
+			},
+		},
+		{
+			name: "s3 url without key",
+			cfg: &configv1alpha1.RemoteConfigLoaderSpec{
+				URL: "s3://bucket",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported scheme",
+			cfg: &configv1alpha1.RemoteConfigLoaderSpec{
+				URL: "ftp://config.example.com/furiko.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid public key",
+			cfg: &configv1alpha1.RemoteConfigLoaderSpec{
+				URL:       "https://config.example.com/furiko.yaml",
+				PublicKey: base64.StdEncoding.EncodeToString([]byte("too short")),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := configloader.NewRemoteLoader(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRemoteLoader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
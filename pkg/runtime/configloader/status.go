@@ -0,0 +1,189 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+const (
+	// hashLength is the number of hex characters of the SHA-256 digest to use as
+	// a config hash.
+	hashLength = 16
+)
+
+// Status is the observed state of a ConfigManager, which can be used to verify
+// which config snapshot is currently in use.
+type Status struct {
+	// Version is a hash computed from all active config hashes, which changes
+	// whenever any of the configs changes.
+	Version string `json:"version"`
+
+	// Configs contains the status of each config that was successfully loaded.
+	// Namespaced configs are not included.
+	Configs map[configv1alpha1.ConfigName]ConfigStatus `json:"configs"`
+
+	// Loaders contains the status of each Loader and NamespacedLoader, in the
+	// order that they were added.
+	Loaders []LoaderStatus `json:"loaders"`
+}
+
+// ConfigStatus is the observed state of a single config.
+type ConfigStatus struct {
+	// Hash of the merged config that is currently in use.
+	Hash string `json:"hash"`
+
+	// Time when the config was last loaded successfully.
+	LastLoaded time.Time `json:"lastLoaded"`
+
+	// Time when the hash of the config last changed.
+	LastChanged time.Time `json:"lastChanged"`
+}
+
+// LoaderStatus is the observed state of a single loader.
+type LoaderStatus struct {
+	// Name of the loader.
+	Name string `json:"name"`
+
+	// Time when the loader last loaded a config successfully.
+	LastLoaded *time.Time `json:"lastLoaded,omitempty"`
+
+	// Total number of errors returned by the loader.
+	TotalErrors int64 `json:"totalErrors"`
+
+	// The last error returned by the loader, if any.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// GetStatus returns the current Status of the ConfigManager.
+func (c *ConfigManager) GetStatus() *Status {
+	c.statusMu.RLock()
+	defer c.statusMu.RUnlock()
+
+	status := &Status{
+		Configs: make(map[configv1alpha1.ConfigName]ConfigStatus, len(c.configStatuses)),
+	}
+	for configName, configStatus := range c.configStatuses {
+		status.Configs[configName] = *configStatus
+	}
+	status.Version = computeVersion(status.Configs)
+
+	names := make([]string, 0, len(c.loaders)+len(c.namespacedLoaders))
+	for _, loader := range c.loaders {
+		names = append(names, loader.Name())
+	}
+	for _, loader := range c.namespacedLoaders {
+		names = append(names, loader.Name())
+	}
+	for _, name := range names {
+		loaderStatus := LoaderStatus{Name: name}
+		if observed, ok := c.loaderStatuses[name]; ok {
+			loaderStatus = *observed
+		}
+		status.Loaders = append(status.Loaders, loaderStatus)
+	}
+
+	return status
+}
+
+// observeConfig records that the merged config was loaded successfully.
+func (c *ConfigManager) observeConfig(configName configv1alpha1.ConfigName, config Config) {
+	hash, err := hashConfig(config)
+	if err != nil {
+		return
+	}
+
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+
+	now := ktime.Clock.Now()
+	status, ok := c.configStatuses[configName]
+	if !ok {
+		status = &ConfigStatus{}
+		c.configStatuses[configName] = status
+	}
+	status.LastLoaded = now
+	if status.Hash != hash {
+		if status.Hash != "" {
+			dynamicConfigInfo.DeleteLabelValues(string(configName), status.Hash)
+		}
+		dynamicConfigInfo.WithLabelValues(string(configName), hash).Set(1)
+		status.Hash = hash
+		status.LastChanged = now
+	}
+}
+
+// observeLoader records the result of loading a config from the named loader.
+func (c *ConfigManager) observeLoader(name string, err error) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+
+	status, ok := c.loaderStatuses[name]
+	if !ok {
+		status = &LoaderStatus{Name: name}
+		c.loaderStatuses[name] = status
+	}
+
+	if err != nil {
+		status.TotalErrors++
+		status.LastError = err.Error()
+		dynamicConfigLoaderErrorsTotal.WithLabelValues(name).Inc()
+		return
+	}
+
+	now := ktime.Clock.Now()
+	status.LastLoaded = &now
+	dynamicConfigLoaderLastLoadTimestamp.WithLabelValues(name).Set(float64(now.Unix()))
+}
+
+// hashConfig returns a stable hash of the given config. Map keys are sorted when
+// marshaling to JSON, so equal configs always produce the same hash.
+func hashConfig(config Config) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return hashBytes(data), nil
+}
+
+// computeVersion returns a hash of all config hashes.
+func computeVersion(configs map[configv1alpha1.ConfigName]ConfigStatus) string {
+	configNames := make([]string, 0, len(configs))
+	for configName := range configs {
+		configNames = append(configNames, string(configName))
+	}
+	sort.Strings(configNames)
+
+	hashes := make([]string, 0, len(configNames))
+	for _, configName := range configNames {
+		hashes = append(hashes, configName+"="+configs[configv1alpha1.ConfigName(configName)].Hash)
+	}
+	data, _ := json.Marshal(hashes)
+	return hashBytes(data)
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
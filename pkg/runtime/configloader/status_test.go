@@ -0,0 +1,109 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/furiko-io/furiko/pkg/runtime/configloader"
+	"github.com/furiko-io/furiko/pkg/utils/ktime"
+)
+
+func TestConfigManager_GetStatus(t *testing.T) {
+	now := time.Date(2022, 4, 1, 4, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	ktime.Clock = fakeClock
+
+	loader := newMockDynamicConfigLoader(MockConfig{
+		ConfigName: {
+			"defaultTTLSecondsAfterFinished": 180,
+		},
+	})
+	mgr := configloader.NewConfigManager()
+	mgr.AddConfigLoaders(loader)
+	assert.NoError(t, mgr.Start(context.Background()))
+
+	// No configs loaded yet.
+	status := mgr.GetStatus()
+	assert.Empty(t, status.Configs)
+	assert.Equal(t, []configloader.LoaderStatus{{Name: "Mock"}}, status.Loaders)
+
+	// Load initial config.
+	checkConfig(t, mgr, &Config{DefaultTTLSecondsAfterFinished: 180}, false)
+	status = mgr.GetStatus()
+	initial := status.Configs[ConfigName]
+	assert.NotEmpty(t, initial.Hash)
+	assert.Equal(t, now, initial.LastLoaded)
+	assert.Equal(t, now, initial.LastChanged)
+	assert.NotEmpty(t, status.Version)
+	assert.Equal(t, &now, status.Loaders[0].LastLoaded)
+
+	// Reloading the same config should not change the hash.
+	fakeClock.Step(time.Minute)
+	checkConfig(t, mgr, &Config{DefaultTTLSecondsAfterFinished: 180}, false)
+	reloaded := mgr.GetStatus()
+	assert.Equal(t, initial.Hash, reloaded.Configs[ConfigName].Hash)
+	assert.Equal(t, status.Version, reloaded.Version)
+	assert.Equal(t, now.Add(time.Minute), reloaded.Configs[ConfigName].LastLoaded)
+	assert.Equal(t, now, reloaded.Configs[ConfigName].LastChanged)
+
+	// Updating the config should change the hash and version.
+	fakeClock.Step(time.Minute)
+	loader.SetConfig(MockConfig{
+		ConfigName: {
+			"defaultTTLSecondsAfterFinished": 181,
+		},
+	})
+	checkConfig(t, mgr, &Config{DefaultTTLSecondsAfterFinished: 181}, false)
+	updated := mgr.GetStatus()
+	assert.NotEqual(t, initial.Hash, updated.Configs[ConfigName].Hash)
+	assert.NotEqual(t, status.Version, updated.Version)
+	assert.Equal(t, now.Add(2*time.Minute), updated.Configs[ConfigName].LastChanged)
+
+	// Invalid configs should not change the hash of the config in use.
+	loader.SetConfig(MockConfig{
+		ConfigName: {
+			"defaultTTLSecondsAfterFinished": "hello",
+		},
+	})
+	checkConfig(t, mgr, &Config{DefaultTTLSecondsAfterFinished: 181}, false)
+	assert.Equal(t, updated.Configs[ConfigName].Hash, mgr.GetStatus().Configs[ConfigName].Hash)
+}
+
+func TestConfigManager_GetStatus_LoaderErrors(t *testing.T) {
+	mgr := configloader.NewConfigManager()
+	mgr.AddConfigLoaders(newMockErrorLoader())
+	assert.NoError(t, mgr.Start(context.Background()))
+
+	checkConfig(t, mgr, nil, true)
+	checkConfig(t, mgr, nil, true)
+
+	status := mgr.GetStatus()
+	assert.Empty(t, status.Configs)
+	assert.Equal(t, []configloader.LoaderStatus{
+		{
+			Name:        "Mock",
+			TotalErrors: 2,
+			LastError:   "load config error",
+		},
+	}, status.Loaders)
+}
@@ -31,3 +31,12 @@ type Loader interface {
 	Start(context.Context) error
 	Load(configName configv1alpha1.ConfigName) (Config, error)
 }
+
+// NamespacedLoader knows how to load a Config for a specific namespace given a
+// config name. Namespaced configs take precedence over configs loaded by all
+// Loaders.
+type NamespacedLoader interface {
+	Name() string
+	Start(context.Context) error
+	LoadNamespaced(configName configv1alpha1.ConfigName, namespace string) (Config, error)
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader
+
+import (
+	"net/mail"
+	"net/url"
+	"text/template"
+
+	"github.com/furiko-io/cronexpr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/core/tzutils"
+)
+
+// ValidateConfig validates a dynamic config after it has been unmarshaled, and
+// returns an error if it contains invalid or inconsistent values. Types which
+// are not dynamic configs are not validated.
+func ValidateConfig(out interface{}) error {
+	var errs field.ErrorList
+	switch cfg := out.(type) {
+	case *configv1alpha1.JobExecutionConfig:
+		errs = validateJobExecutionConfig(cfg)
+	case *configv1alpha1.JobConfigExecutionConfig:
+		errs = validateJobConfigExecutionConfig(cfg)
+	case *configv1alpha1.CronExecutionConfig:
+		errs = validateCronExecutionConfig(cfg)
+	}
+	return errs.ToAggregate()
+}
+
+func validateJobExecutionConfig(cfg *configv1alpha1.JobExecutionConfig) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateNonNegative(cfg.DefaultTTLSecondsAfterFinished,
+		field.NewPath("defaultTTLSecondsAfterFinished"))...)
+	errs = append(errs, validateNonNegative(cfg.DefaultPendingTimeoutSeconds,
+		field.NewPath("defaultPendingTimeoutSeconds"))...)
+	errs = append(errs, validateNonNegative(cfg.DefaultMaxRuntimeSeconds,
+		field.NewPath("defaultMaxRuntimeSeconds"))...)
+	errs = append(errs, validateNonNegative(cfg.DeleteKillingTasksTimeoutSeconds,
+		field.NewPath("deleteKillingTasksTimeoutSeconds"))...)
+	errs = append(errs, validateNonNegative(cfg.ForceDeleteKillingTasksTimeoutSeconds,
+		field.NewPath("forceDeleteKillingTasksTimeoutSeconds"))...)
+	errs = append(errs, validateNonNegative(cfg.NodeLostReplaceTimeoutSeconds,
+		field.NewPath("nodeLostReplaceTimeoutSeconds"))...)
+	errs = append(errs, validateNonNegative(cfg.MaxFinishedJobsPerJobConfig,
+		field.NewPath("maxFinishedJobsPerJobConfig"))...)
+	errs = append(errs, validateNonNegative(cfg.MaxConcurrentJobsPerNamespace,
+		field.NewPath("maxConcurrentJobsPerNamespace"))...)
+	errs = append(errs, validateNonNegative(cfg.MaxDetailedTaskRefs,
+		field.NewPath("maxDetailedTaskRefs"))...)
+
+	switch cfg.TTLAfterFinishedPolicy {
+	case "", configv1alpha1.TTLAfterFinishedPolicyDeleteJob, configv1alpha1.TTLAfterFinishedPolicyDeleteTasks:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("ttlAfterFinishedPolicy"), cfg.TTLAfterFinishedPolicy,
+			[]string{
+				string(configv1alpha1.TTLAfterFinishedPolicyDeleteJob),
+				string(configv1alpha1.TTLAfterFinishedPolicyDeleteTasks),
+			}))
+	}
+
+	for namespace, limit := range cfg.NamespaceMaxConcurrentJobs {
+		limit := limit
+		errs = append(errs, validateNonNegative(&limit, field.NewPath("namespaceMaxConcurrentJobs").Key(namespace))...)
+	}
+
+	errs = append(errs, validateRateLimit(cfg.GlobalStartRateLimit, field.NewPath("globalStartRateLimit"))...)
+	errs = append(errs, validateRateLimit(cfg.NamespaceStartRateLimit, field.NewPath("namespaceStartRateLimit"))...)
+
+	if quota := cfg.AdhocJobQuota; quota != nil {
+		fldPath := field.NewPath("adhocJobQuota")
+		errs = append(errs, validateNonNegative(quota.MaxJobsPerJobConfig, fldPath.Child("maxJobsPerJobConfig"))...)
+		errs = append(errs, validateNonNegative(quota.MaxJobsPerNamespace, fldPath.Child("maxJobsPerNamespace"))...)
+
+		// The per-JobConfig quota can never be reached if it exceeds the
+		// per-namespace quota, which usually indicates a misconfiguration.
+		if perJobConfig, perNamespace := quota.MaxJobsPerJobConfig, quota.MaxJobsPerNamespace; perJobConfig != nil &&
+			perNamespace != nil && *perNamespace > 0 && *perJobConfig > *perNamespace {
+			errs = append(errs, field.Invalid(fldPath.Child("maxJobsPerJobConfig"), *perJobConfig,
+				"cannot be greater than maxJobsPerNamespace"))
+		}
+	}
+
+	if spec := cfg.CloudEvents; spec != nil {
+		fldPath := field.NewPath("cloudEvents")
+		errs = append(errs, validateHTTPURL(spec.Target, fldPath.Child("target"))...)
+		errs = append(errs, validateNonNegative(spec.TimeoutSeconds, fldPath.Child("timeoutSeconds"))...)
+	}
+
+	if spec := cfg.Notifications; spec != nil {
+		fldPath := field.NewPath("notifications")
+		errs = append(errs, validateNonNegative(spec.TimeoutSeconds, fldPath.Child("timeoutSeconds"))...)
+		if email := spec.Email; email != nil {
+			errs = append(errs, validateEmailNotifications(email, fldPath.Child("email"))...)
+		}
+	}
+
+	if spec := cfg.Alerting; spec != nil {
+		fldPath := field.NewPath("alerting")
+		errs = append(errs, validateHTTPURL(spec.PagerDutyEventsURL, fldPath.Child("pagerDutyEventsURL"))...)
+		errs = append(errs, validateHTTPURL(spec.OpsgenieAPIURL, fldPath.Child("opsgenieAPIURL"))...)
+		errs = append(errs, validateNonNegative(spec.TimeoutSeconds, fldPath.Child("timeoutSeconds"))...)
+	}
+
+	if spec := cfg.Callbacks; spec != nil {
+		fldPath := field.NewPath("callbacks")
+		if spec.MaxAttempts != nil && *spec.MaxAttempts < 1 {
+			errs = append(errs, field.Invalid(fldPath.Child("maxAttempts"), *spec.MaxAttempts, "must be at least 1"))
+		}
+		errs = append(errs, validateNonNegative(spec.BackoffSeconds, fldPath.Child("backoffSeconds"))...)
+		errs = append(errs, validateNonNegative(spec.MaxBackoffSeconds, fldPath.Child("maxBackoffSeconds"))...)
+		errs = append(errs, validateNonNegative(spec.TimeoutSeconds, fldPath.Child("timeoutSeconds"))...)
+	}
+
+	return errs
+}
+
+// validateHTTPURL validates that the value is a http or https URL if it is not empty.
+func validateHTTPURL(value string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if value == "" {
+		return errs
+	}
+	if u, err := url.Parse(value); err != nil {
+		errs = append(errs, field.Invalid(fldPath, value, err.Error()))
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		errs = append(errs, field.Invalid(fldPath, value, "must be a http or https URL"))
+	}
+	return errs
+}
+
+func validateEmailNotifications(spec *configv1alpha1.EmailNotificationsSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if spec.Host == "" {
+		errs = append(errs, field.Required(fldPath.Child("host"), ""))
+	}
+	if spec.Port < 0 || spec.Port > 65535 {
+		errs = append(errs, field.Invalid(fldPath.Child("port"), spec.Port, "must be a valid port number"))
+	}
+	if spec.From == "" {
+		errs = append(errs, field.Required(fldPath.Child("from"), ""))
+	} else if _, err := mail.ParseAddress(spec.From); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("from"), spec.From, err.Error()))
+	}
+	if _, err := template.New("").Parse(spec.SubjectTemplate); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("subjectTemplate"), spec.SubjectTemplate, err.Error()))
+	}
+	if _, err := template.New("").Parse(spec.BodyTemplate); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("bodyTemplate"), spec.BodyTemplate, err.Error()))
+	}
+	return errs
+}
+
+func validateJobConfigExecutionConfig(cfg *configv1alpha1.JobConfigExecutionConfig) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateNonNegative(cfg.MaxEnqueuedJobs, field.NewPath("maxEnqueuedJobs"))...)
+	errs = append(errs, validateNonNegative(cfg.ResourceUsageSampleIntervalSeconds,
+		field.NewPath("resourceUsageSampleIntervalSeconds"))...)
+	errs = append(errs, validateNonNegative(cfg.ResourceRecommendationMarginPercent,
+		field.NewPath("resourceRecommendationMarginPercent"))...)
+
+	if prices := cfg.ResourcePrices; prices != nil {
+		fldPath := field.NewPath("resourcePrices")
+		if price := prices.CPUCoreHour; price != nil && price.Sign() < 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("cpuCoreHour"), price.String(), "must be non-negative"))
+		}
+		if price := prices.MemoryGiBHour; price != nil && price.Sign() < 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("memoryGiBHour"), price.String(), "must be non-negative"))
+		}
+	}
+
+	return errs
+}
+
+func validateCronExecutionConfig(cfg *configv1alpha1.CronExecutionConfig) field.ErrorList {
+	var errs field.ErrorList
+
+	switch cronexpr.CronFormat(cfg.CronFormat) {
+	case "", cronexpr.CronFormatStandard, cronexpr.CronFormatQuartz:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("cronFormat"), cfg.CronFormat,
+			[]string{string(cronexpr.CronFormatStandard), string(cronexpr.CronFormatQuartz)}))
+	}
+
+	if tz := cfg.DefaultTimezone; tz != nil {
+		if _, err := tzutils.ParseTimezone(*tz); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("defaultTimezone"), *tz, err.Error()))
+		}
+	}
+
+	errs = append(errs, validateNonNegative(cfg.MaxMissedSchedules, field.NewPath("maxMissedSchedules"))...)
+	if cfg.MaxDowntimeThresholdSeconds < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("maxDowntimeThresholdSeconds"),
+			cfg.MaxDowntimeThresholdSeconds, "must be non-negative"))
+	}
+
+	return errs
+}
+
+func validateRateLimit(spec *configv1alpha1.RateLimitSpec, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if spec == nil {
+		return errs
+	}
+	if spec.QPS < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("qps"), spec.QPS, "must be non-negative"))
+	}
+	if spec.Burst < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("burst"), spec.Burst, "must be non-negative"))
+	}
+	return errs
+}
+
+func validateNonNegative(value *int64, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if value != nil && *value < 0 {
+		errs = append(errs, field.Invalid(fldPath, *value, "must be non-negative"))
+	}
+	return errs
+}
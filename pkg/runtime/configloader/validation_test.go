@@ -0,0 +1,216 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configloader_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/config"
+	"github.com/furiko-io/furiko/pkg/runtime/configloader"
+)
+
+func TestValidateConfig(t *testing.T) {
+	negativePrice := resource.MustParse("-1")
+	tests := []struct {
+		name    string
+		cfg     interface{}
+		wantErr bool
+	}{
+		{
+			name: "unknown type",
+			cfg:  &Config{DefaultPendingTimeoutSeconds: -1},
+		},
+		{
+			name: "default JobExecutionConfig",
+			cfg:  config.DefaultJobExecutionConfig,
+		},
+		{
+			name: "default JobConfigExecutionConfig",
+			cfg:  config.DefaultJobConfigExecutionConfig,
+		},
+		{
+			name: "default CronExecutionConfig",
+			cfg:  config.DefaultCronExecutionConfig,
+		},
+		{
+			name: "default ControllerExecutionConfig",
+			cfg:  config.DefaultControllerExecutionConfig,
+		},
+		{
+			name: "negative timeout",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				ForceDeleteKillingTasksTimeoutSeconds: pointer.Int64(-1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid TTLAfterFinishedPolicy",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				TTLAfterFinishedPolicy: "DeleteEverything",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative namespace concurrency limit",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				NamespaceMaxConcurrentJobs: map[string]int64{"default": -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative rate limit",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				GlobalStartRateLimit: &configv1alpha1.RateLimitSpec{QPS: -0.5},
+			},
+			wantErr: true,
+		},
+		{
+			name: "adhoc quota per JobConfig greater than per namespace",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				AdhocJobQuota: &configv1alpha1.AdhocJobQuotaSpec{
+					MaxJobsPerJobConfig: pointer.Int64(10),
+					MaxJobsPerNamespace: pointer.Int64(5),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "adhoc quota per JobConfig with unlimited namespace quota",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				AdhocJobQuota: &configv1alpha1.AdhocJobQuotaSpec{
+					MaxJobsPerJobConfig: pointer.Int64(10),
+					MaxJobsPerNamespace: pointer.Int64(0),
+				},
+			},
+		},
+		{
+			name: "valid CloudEvents target",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				CloudEvents: &configv1alpha1.CloudEventsSpec{
+					Target: "https://events.example.com/furiko",
+				},
+			},
+		},
+		{
+			name: "invalid CloudEvents target",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				CloudEvents: &configv1alpha1.CloudEventsSpec{
+					Target: "events.example.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid alerting URL",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				Alerting: &configv1alpha1.AlertingSpec{
+					OpsgenieAPIURL: "api.eu.opsgenie.com",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid callbacks",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				Callbacks: &configv1alpha1.CallbacksSpec{
+					MaxAttempts:    pointer.Int64(3),
+					BackoffSeconds: pointer.Int64(0),
+				},
+			},
+		},
+		{
+			name: "callbacks with zero maxAttempts",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				Callbacks: &configv1alpha1.CallbacksSpec{
+					MaxAttempts: pointer.Int64(0),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid email notifications",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				Notifications: &configv1alpha1.NotificationsSpec{
+					Email: &configv1alpha1.EmailNotificationsSpec{
+						Host:            "smtp.example.com",
+						From:            "Furiko <furiko@example.com>",
+						SubjectTemplate: "{{ .Title }}",
+					},
+				},
+			},
+		},
+		{
+			name: "email notifications without from",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				Notifications: &configv1alpha1.NotificationsSpec{
+					Email: &configv1alpha1.EmailNotificationsSpec{
+						Host: "smtp.example.com",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid email body template",
+			cfg: &configv1alpha1.JobExecutionConfig{
+				Notifications: &configv1alpha1.NotificationsSpec{
+					Email: &configv1alpha1.EmailNotificationsSpec{
+						Host:         "smtp.example.com",
+						From:         "furiko@example.com",
+						BodyTemplate: "{{ .Title",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative resource price",
+			cfg: &configv1alpha1.JobConfigExecutionConfig{
+				ResourcePrices: &configv1alpha1.ResourcePrices{
+					CPUCoreHour: &negativePrice,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid cron format",
+			cfg: &configv1alpha1.CronExecutionConfig{
+				CronFormat: "unix",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid default timezone",
+			cfg: &configv1alpha1.CronExecutionConfig{
+				DefaultTimezone: pointer.String("Mars/Olympus_Mons"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := configloader.ValidateConfig(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,28 +18,37 @@ package controllercontext
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+	"github.com/furiko-io/furiko/pkg/runtime/faultinjection"
+	"github.com/furiko-io/furiko/pkg/runtime/features"
 )
 
 // Context is a shared controller context that can be safely shared between controllers.
 type Context interface {
 	Start(ctx context.Context) error
+	RESTConfig() *rest.Config
 	Clientsets() Clientsets
 	Configs() Configs
 	Stores() Stores
 	Informers() Informers
+	NewEventBroadcaster() record.EventBroadcaster
 }
 
 type ctrlContext struct {
-	restConfig *rest.Config
-	configMgr  Configs
-	storeMgr   Stores
-	clientsets Clientsets
-	informers  Informers
+	restConfig        *rest.Config
+	configMgr         Configs
+	storeMgr          Stores
+	clientsets        Clientsets
+	informers         Informers
+	correlatorOptions record.CorrelatorOptions
 }
 
 var _ Context = &ctrlContext{}
@@ -47,7 +56,18 @@ var _ Context = &ctrlContext{}
 // NewForConfig prepares a new Context from a kubeconfig and controller manager config spec.
 func NewForConfig(cfg *rest.Config, ctrlConfig *configv1alpha1.BootstrapConfigSpec) (Context, error) {
 	c := &ctrlContext{
-		restConfig: cfg,
+		restConfig:        cfg,
+		correlatorOptions: controllerutil.NewCorrelatorOptions(ctrlConfig.EventRecorder),
+	}
+
+	// Inject faults into API requests, only meant for testing.
+	if spec := ctrlConfig.FaultInjection; spec != nil {
+		if !features.Enabled(features.FaultInjection) {
+			return nil, fmt.Errorf("faultInjection requires the %v feature gate", features.FaultInjection)
+		}
+		klog.Warning("controllercontext: fault injection is enabled, this should only be used for testing")
+		cfg = rest.CopyConfig(cfg)
+		cfg.Wrap(faultinjection.NewWrapper(spec))
 	}
 
 	// Set up clientsets.
@@ -58,10 +78,18 @@ func NewForConfig(cfg *rest.Config, ctrlConfig *configv1alpha1.BootstrapConfigSp
 	c.clientsets = clientsets
 
 	// Set up shared informer factories.
-	c.informers = SetUpInformers(c.clientsets, ctrlConfig)
+	informers, err := SetUpInformers(c.clientsets, ctrlConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot set up informers")
+	}
+	c.informers = informers
 
 	// Set up config manager.
-	c.configMgr = SetUpConfigManager(ctrlConfig, c.Clientsets().Kubernetes())
+	configMgr, err := SetUpConfigManager(ctrlConfig, c.Clientsets())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot set up config manager")
+	}
+	c.configMgr = configMgr
 
 	// Set up stores.
 	c.storeMgr = NewContextStores()
@@ -69,6 +97,11 @@ func NewForConfig(cfg *rest.Config, ctrlConfig *configv1alpha1.BootstrapConfigSp
 	return c, nil
 }
 
+// RESTConfig returns the rest.Config used to set up the clientsets.
+func (c *ctrlContext) RESTConfig() *rest.Config {
+	return c.restConfig
+}
+
 func (c *ctrlContext) Start(ctx context.Context) error {
 	// Start config manager.
 	if err := c.configMgr.Start(ctx); err != nil {
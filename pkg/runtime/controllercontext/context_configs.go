@@ -21,7 +21,6 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/kubernetes"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/runtime/configloader"
@@ -41,6 +40,10 @@ type Configs interface {
 	Jobs() (*configv1alpha1.JobExecutionConfig, error)
 	JobConfigs() (*configv1alpha1.JobConfigExecutionConfig, error)
 	Cron() (*configv1alpha1.CronExecutionConfig, error)
+	Controllers() (*configv1alpha1.ControllerExecutionConfig, error)
+	JobsForNamespace(namespace string) (*configv1alpha1.JobExecutionConfig, error)
+	CronForNamespace(namespace string) (*configv1alpha1.CronExecutionConfig, error)
+	GetStatus() *configloader.Status
 }
 
 type ContextConfigs struct {
@@ -63,6 +66,9 @@ func (c *ContextConfigs) AllConfigs() (map[configv1alpha1.ConfigName]runtime.Obj
 		configv1alpha1.CronExecutionConfigName: func() (runtime.Object, error) {
 			return c.Cron()
 		},
+		configv1alpha1.ControllerExecutionConfigName: func() (runtime.Object, error) {
+			return c.Controllers()
+		},
 	}
 
 	configs := make(map[configv1alpha1.ConfigName]runtime.Object)
@@ -104,8 +110,39 @@ func (c *ContextConfigs) Cron() (*configv1alpha1.CronExecutionConfig, error) {
 	return &config, nil
 }
 
+// Controllers returns the controller dynamic configuration.
+func (c *ContextConfigs) Controllers() (*configv1alpha1.ControllerExecutionConfig, error) {
+	var config configv1alpha1.ControllerExecutionConfig
+	if err := c.LoadAndUnmarshalConfig(configv1alpha1.ControllerExecutionConfigName, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// JobsForNamespace returns the job dynamic configuration for the given
+// namespace, which includes any overrides from ExecutionConfigs in the
+// namespace.
+func (c *ContextConfigs) JobsForNamespace(namespace string) (*configv1alpha1.JobExecutionConfig, error) {
+	var config configv1alpha1.JobExecutionConfig
+	if err := c.LoadAndUnmarshalNamespacedConfig(configv1alpha1.JobExecutionConfigName, namespace, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// CronForNamespace returns the cron dynamic configuration for the given
+// namespace, which includes any overrides from ExecutionConfigs in the
+// namespace.
+func (c *ContextConfigs) CronForNamespace(namespace string) (*configv1alpha1.CronExecutionConfig, error) {
+	var config configv1alpha1.CronExecutionConfig
+	if err := c.LoadAndUnmarshalNamespacedConfig(configv1alpha1.CronExecutionConfigName, namespace, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
 // SetUpConfigManager sets up the ConfigManager and returns a composed Configs interface.
-func SetUpConfigManager(cfg *configv1alpha1.BootstrapConfigSpec, client kubernetes.Interface) Configs {
+func SetUpConfigManager(cfg *configv1alpha1.BootstrapConfigSpec, clientsets Clientsets) (Configs, error) {
 	configManager := configloader.NewConfigManager()
 	var configMapNamespace, configMapName, secretNamespace, secretName string
 	if cfg := cfg.DynamicConfigs; cfg != nil {
@@ -118,10 +155,31 @@ func SetUpConfigManager(cfg *configv1alpha1.BootstrapConfigSpec, client kubernet
 			secretName = cfg.Name
 		}
 	}
+	configManager.AddConfigLoaders(configloader.NewDefaultsLoader())
+	if cfg := cfg.DynamicConfigs; cfg != nil && cfg.Remote != nil {
+		loader, err := configloader.NewRemoteLoader(cfg.Remote)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot set up remote config loader")
+		}
+		configManager.AddConfigLoaders(loader)
+	}
+	configManager.AddConfigLoaders(
+		configloader.NewConfigMapLoader(clientsets.Kubernetes(), configMapNamespace, configMapName),
+	)
+	if cfg := cfg.DynamicConfigs; cfg != nil && cfg.FurikoConfig != nil {
+		var reportStatus bool
+		if cfg.FurikoConfig.ReportStatus != nil {
+			reportStatus = *cfg.FurikoConfig.ReportStatus
+		}
+		configManager.AddConfigLoaders(
+			configloader.NewFurikoConfigLoader(clientsets.Furiko(), cfg.FurikoConfig.Name, reportStatus),
+		)
+	}
 	configManager.AddConfigLoaders(
-		configloader.NewDefaultsLoader(),
-		configloader.NewConfigMapLoader(client, configMapNamespace, configMapName),
-		configloader.NewSecretLoader(client, secretNamespace, secretName),
+		configloader.NewSecretLoader(clientsets.Kubernetes(), secretNamespace, secretName),
+	)
+	configManager.AddNamespacedConfigLoaders(
+		configloader.NewExecutionConfigLoader(clientsets.Furiko()),
 	)
-	return NewContextConfigs(configManager)
+	return NewContextConfigs(configManager), nil
 }
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllercontext
+
+import (
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// NewEventBroadcaster returns a new EventBroadcaster that records Events to the
+// API server, subject to the aggregation and rate limits in the bootstrap
+// config. Each controller should use its own EventBroadcaster, and shut it down
+// to flush any pending Events.
+func (c *ctrlContext) NewEventBroadcaster() record.EventBroadcaster {
+	broadcaster := record.NewBroadcasterWithCorrelatorOptions(c.correlatorOptions)
+	broadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
+		Interface: c.Clientsets().Kubernetes().CoreV1().Events(""),
+	})
+	return broadcaster
+}
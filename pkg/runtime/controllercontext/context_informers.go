@@ -20,9 +20,18 @@ import (
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	kubernetes "k8s.io/client-go/informers"
+	kubernetesclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	executionv1alpha1 "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	furikoclientset "github.com/furiko-io/furiko/pkg/generated/clientset/versioned"
 	furiko "github.com/furiko-io/furiko/pkg/generated/informers/externalversions"
 )
 
@@ -44,6 +53,7 @@ type Informers interface {
 type contextInformers struct {
 	kubernetes kubernetes.SharedInformerFactory
 	furiko     furiko.SharedInformerFactory
+	namespaces *namespaceSelectorFilter
 }
 
 var _ Informers = &contextInformers{}
@@ -56,20 +66,157 @@ func (c *contextInformers) Furiko() furiko.SharedInformerFactory {
 	return c.furiko
 }
 
-func SetUpInformers(clientsets Clientsets, cfg *configv1alpha1.BootstrapConfigSpec) Informers {
+func SetUpInformers(clientsets Clientsets, cfg *configv1alpha1.BootstrapConfigSpec) (Informers, error) {
 	defaultResync := cfg.DefaultResync.Duration
 	if defaultResync == 0 {
 		defaultResync = defaultDefaultResync
 	}
 
+	// Override resync periods for individual informers.
+	kubernetesResync := make(map[metav1.Object]time.Duration)
+	furikoResync := make(map[metav1.Object]time.Duration)
+	if spec := cfg.Informers; spec != nil {
+		if resync := getResyncPeriod(spec.Pods); resync > 0 {
+			kubernetesResync[&corev1.Pod{}] = resync
+		}
+		if resync := getResyncPeriod(spec.Jobs); resync > 0 {
+			furikoResync[&executionv1alpha1.Job{}] = resync
+		}
+		if resync := getResyncPeriod(spec.JobConfigs); resync > 0 {
+			furikoResync[&executionv1alpha1.JobConfig{}] = resync
+		}
+	}
+
 	informers := &contextInformers{}
-	informers.kubernetes = kubernetes.NewSharedInformerFactory(clientsets.Kubernetes(), defaultResync)
-	informers.furiko = furiko.NewSharedInformerFactory(clientsets.Furiko(), defaultResync)
-	return informers
+	informers.kubernetes = kubernetes.NewSharedInformerFactoryWithOptions(clientsets.Kubernetes(), defaultResync,
+		kubernetes.WithCustomResyncConfig(kubernetesResync))
+	informers.furiko = furiko.NewSharedInformerFactoryWithOptions(clientsets.Furiko(), defaultResync,
+		furiko.WithCustomResyncConfig(furikoResync))
+
+	scope := &InformerScope{
+		LabelSelectors: make(map[string]labels.Selector),
+	}
+	var filters NamespaceFilters
+
+	// Only watch objects in selected namespaces.
+	if spec := cfg.Sharding; spec != nil && spec.NamespaceSelector != nil {
+		filter, err := newNamespaceSelectorFilter(clientsets.Kubernetes(), spec.NamespaceSelector, defaultResync)
+		if err != nil {
+			return nil, err
+		}
+		informers.namespaces = filter
+		filters = append(filters, filter)
+	}
+
+	if spec := cfg.Informers; spec != nil {
+		if len(spec.Namespaces) > 0 {
+			filters = append(filters, NewNamespaceAllowlist(spec.Namespaces...))
+		}
+		for resource, scopeSpec := range map[string]*configv1alpha1.InformerScopeSpec{
+			"jobs":       spec.Jobs,
+			"jobconfigs": spec.JobConfigs,
+			"pods":       spec.Pods,
+		} {
+			if scopeSpec == nil || scopeSpec.LabelSelector == nil {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(scopeSpec.LabelSelector)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid label selector for %v", resource)
+			}
+			scope.LabelSelectors[resource] = selector
+		}
+	}
+
+	if len(filters) > 0 {
+		scope.Namespaces = filters
+	}
+	if scope.Namespaces != nil || len(scope.LabelSelectors) > 0 {
+		RegisterScopedInformers(informers.kubernetes, informers.furiko, scope)
+	}
+
+	return informers, nil
+}
+
+// getResyncPeriod returns the resync period for an informer, or 0 to use the
+// default resync period.
+func getResyncPeriod(spec *configv1alpha1.InformerScopeSpec) time.Duration {
+	if spec == nil {
+		return 0
+	}
+	return spec.ResyncPeriod.Duration
 }
 
 func (c *contextInformers) Start(ctx context.Context) error {
+	// The namespace filter must be synced before listing any objects.
+	if c.namespaces != nil {
+		if err := c.namespaces.Start(ctx); err != nil {
+			return err
+		}
+	}
+
 	c.Kubernetes().Start(ctx.Done())
 	c.Furiko().Start(ctx.Done())
 	return nil
 }
+
+// InformerScope restricts the objects that are watched by informers.
+type InformerScope struct {
+	// Namespaces filters objects by their namespace. If nil, objects in all
+	// namespaces are watched.
+	Namespaces NamespaceFilter
+
+	// LabelSelectors maps resource names to the label selector that is used to
+	// list and watch objects of that resource.
+	LabelSelectors map[string]labels.Selector
+}
+
+// RegisterScopedInformers registers informers for all namespaced types used by
+// the controllers, such that they only contain objects within the given scope.
+// Must be called before any informers are obtained from the factories.
+func RegisterScopedInformers(
+	kubernetesFactory kubernetes.SharedInformerFactory,
+	furikoFactory furiko.SharedInformerFactory,
+	scope *InformerScope,
+) {
+	kubernetesFactory.InformerFor(&corev1.Pod{},
+		func(client kubernetesclientset.Interface, resync time.Duration) cache.SharedIndexInformer {
+			return newScopedInformer(client.CoreV1().RESTClient(), "pods", &corev1.Pod{}, resync, scope)
+		})
+
+	furikoResources := map[string]runtime.Object{
+		"executionconfigs": &executionv1alpha1.ExecutionConfig{},
+		"externaltasks":    &executionv1alpha1.ExternalTask{},
+		"jobconfigs":       &executionv1alpha1.JobConfig{},
+		"jobgroups":        &executionv1alpha1.JobGroup{},
+		"jobs":             &executionv1alpha1.Job{},
+		"tasktemplates":    &executionv1alpha1.TaskTemplate{},
+	}
+	for resource, obj := range furikoResources {
+		resource, obj := resource, obj
+		furikoFactory.InformerFor(obj,
+			func(client furikoclientset.Interface, resync time.Duration) cache.SharedIndexInformer {
+				return newScopedInformer(client.ExecutionV1alpha1().RESTClient(), resource, obj, resync, scope)
+			})
+	}
+}
+
+func newScopedInformer(
+	client cache.Getter,
+	resource string,
+	obj runtime.Object,
+	resync time.Duration,
+	scope *InformerScope,
+) cache.SharedIndexInformer {
+	var lw cache.ListerWatcher = cache.NewFilteredListWatchFromClient(client, resource, metav1.NamespaceAll,
+		func(options *metav1.ListOptions) {
+			if selector, ok := scope.LabelSelectors[resource]; ok {
+				options.LabelSelector = selector.String()
+			}
+		})
+	if scope.Namespaces != nil {
+		lw = NewFilteredListWatch(lw, scope.Namespaces)
+	}
+	return cache.NewSharedIndexInformer(lw, obj, resync,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllercontext_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+)
+
+func TestSetUpInformers_ResyncPeriod(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientsets := mock.NewClientsets()
+	informers, err := controllercontext.SetUpInformers(clientsets, &configv1alpha1.BootstrapConfigSpec{
+		DefaultResync: metav1.Duration{Duration: time.Hour},
+		Informers: &configv1alpha1.InformersSpec{
+			Jobs: &configv1alpha1.InformerScopeSpec{
+				ResyncPeriod: metav1.Duration{Duration: time.Second},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	// Count resyncs, which are delivered as updates with the same object.
+	countResyncs := func(informer cache.SharedIndexInformer, count *uint64) {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, _ interface{}) {
+				atomic.AddUint64(count, 1)
+			},
+		})
+	}
+	var jobResyncs, jobConfigResyncs uint64
+	countResyncs(informers.Furiko().Execution().V1alpha1().Jobs().Informer(), &jobResyncs)
+	countResyncs(informers.Furiko().Execution().V1alpha1().JobConfigs().Informer(), &jobConfigResyncs)
+
+	_, err = clientsets.Furiko().ExecutionV1alpha1().Jobs("default").
+		Create(ctx, newJob("default", "job1"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = clientsets.Furiko().ExecutionV1alpha1().JobConfigs("default").
+		Create(ctx, &execution.JobConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "jobconfig1"}},
+			metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, informers.Start(ctx))
+
+	// Jobs should be resynced using the overridden resync period, but JobConfigs
+	// should use the default resync period.
+	assert.Eventually(t, func() bool {
+		return atomic.LoadUint64(&jobResyncs) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, uint64(0), atomic.LoadUint64(&jobConfigResyncs))
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllercontext
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	kubernetes "k8s.io/client-go/informers"
+	kubernetesclientset "k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespaceFilter determines if objects in a namespace should be watched.
+type NamespaceFilter interface {
+	Matches(namespace string) bool
+}
+
+// namespaceSelectorFilter is a NamespaceFilter that matches namespaces using a
+// label selector, backed by an informer of only the selected Namespaces.
+type namespaceSelectorFilter struct {
+	factory kubernetes.SharedInformerFactory
+	lister  corev1listers.NamespaceLister
+	synced  cache.InformerSynced
+}
+
+var _ NamespaceFilter = (*namespaceSelectorFilter)(nil)
+
+func newNamespaceSelectorFilter(
+	client kubernetesclientset.Interface,
+	selector *metav1.LabelSelector,
+	resync time.Duration,
+) (*namespaceSelectorFilter, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid namespace selector")
+	}
+	factory := kubernetes.NewSharedInformerFactoryWithOptions(client, resync,
+		kubernetes.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = labelSelector.String()
+		}),
+	)
+	informer := factory.Core().V1().Namespaces()
+	return &namespaceSelectorFilter{
+		factory: factory,
+		lister:  informer.Lister(),
+		synced:  informer.Informer().HasSynced,
+	}, nil
+}
+
+// Start starts the Namespace informer and blocks until it is synced.
+func (f *namespaceSelectorFilter) Start(ctx context.Context) error {
+	f.factory.Start(ctx.Done())
+	if !cache.WaitForNamedCacheSync("namespace-selector", ctx.Done(), f.synced) {
+		return errors.New("cannot sync namespace informer")
+	}
+	return nil
+}
+
+func (f *namespaceSelectorFilter) Matches(namespace string) bool {
+	_, err := f.lister.Get(namespace)
+	return err == nil
+}
+
+// NamespaceAllowlist is a NamespaceFilter that matches a fixed set of namespaces.
+type NamespaceAllowlist map[string]struct{}
+
+var _ NamespaceFilter = NamespaceAllowlist{}
+
+// NewNamespaceAllowlist returns a NamespaceAllowlist for the given namespaces.
+func NewNamespaceAllowlist(namespaces ...string) NamespaceAllowlist {
+	allowlist := make(NamespaceAllowlist, len(namespaces))
+	for _, namespace := range namespaces {
+		allowlist[namespace] = struct{}{}
+	}
+	return allowlist
+}
+
+func (a NamespaceAllowlist) Matches(namespace string) bool {
+	_, ok := a[namespace]
+	return ok
+}
+
+// NamespaceFilters is a NamespaceFilter that matches a namespace only if all
+// of its filters match.
+type NamespaceFilters []NamespaceFilter
+
+var _ NamespaceFilter = NamespaceFilters{}
+
+func (f NamespaceFilters) Matches(namespace string) bool {
+	for _, filter := range f {
+		if !filter.Matches(namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewFilteredListWatch wraps a ListerWatcher to drop all objects in namespaces
+// which are not matched by the filter. Delete events are always passed through,
+// so that objects are not left behind in the cache.
+func NewFilteredListWatch(lw cache.ListerWatcher, filter NamespaceFilter) cache.ListerWatcher {
+	matches := func(obj runtime.Object) bool {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return false
+		}
+		return filter.Matches(accessor.GetNamespace())
+	}
+
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			list, err := lw.List(options)
+			if err != nil {
+				return nil, err
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot extract list")
+			}
+			filtered := make([]runtime.Object, 0, len(items))
+			for _, item := range items {
+				if matches(item) {
+					filtered = append(filtered, item)
+				}
+			}
+			if err := meta.SetList(list, filtered); err != nil {
+				return nil, errors.Wrapf(err, "cannot set list")
+			}
+			return list, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			w, err := lw.Watch(options)
+			if err != nil {
+				return nil, err
+			}
+			return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+				switch in.Type {
+				case watch.Added, watch.Modified:
+					return in, matches(in.Object)
+				}
+				return in, true
+			}), nil
+		},
+	}
+}
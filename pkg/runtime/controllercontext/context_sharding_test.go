@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllercontext_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/generated/clientset/versioned/fake"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
+)
+
+type namespaceSet map[string]bool
+
+func (s namespaceSet) Matches(namespace string) bool {
+	return s[namespace]
+}
+
+func newJob(namespace, name string) *execution.Job {
+	return &execution.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+}
+
+func TestNewFilteredListWatch(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(
+		newJob("tenant-a", "job1"),
+		newJob("tenant-b", "job2"),
+		newJob("tenant-a", "job3"),
+	)
+	lw := controllercontext.NewFilteredListWatch(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return client.ExecutionV1alpha1().Jobs(metav1.NamespaceAll).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return client.ExecutionV1alpha1().Jobs(metav1.NamespaceAll).Watch(ctx, options)
+		},
+	}, namespaceSet{"tenant-a": true})
+
+	// Only objects in matching namespaces should be listed.
+	list, err := lw.List(metav1.ListOptions{})
+	assert.NoError(t, err)
+	var names []string
+	for _, rj := range list.(*execution.JobList).Items {
+		names = append(names, rj.Name)
+	}
+	assert.ElementsMatch(t, []string{"job1", "job3"}, names)
+
+	w, err := lw.Watch(metav1.ListOptions{})
+	assert.NoError(t, err)
+	defer w.Stop()
+
+	// Create events for non-matching namespaces should be dropped.
+	_, err = client.ExecutionV1alpha1().Jobs("tenant-b").Create(ctx, newJob("tenant-b", "job4"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	_, err = client.ExecutionV1alpha1().Jobs("tenant-a").Create(ctx, newJob("tenant-a", "job5"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assertEvent(t, w, watch.Added, "job5")
+
+	// Delete events should always be passed through.
+	err = client.ExecutionV1alpha1().Jobs("tenant-b").Delete(ctx, "job2", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assertEvent(t, w, watch.Deleted, "job2")
+}
+
+func TestNamespaceFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    controllercontext.NamespaceFilter
+		namespace string
+		want      bool
+	}{
+		{
+			name:      "allowlist matches",
+			filter:    controllercontext.NewNamespaceAllowlist("tenant-a", "tenant-b"),
+			namespace: "tenant-b",
+			want:      true,
+		},
+		{
+			name:      "allowlist does not match",
+			filter:    controllercontext.NewNamespaceAllowlist("tenant-a", "tenant-b"),
+			namespace: "tenant-c",
+		},
+		{
+			name:      "no filters",
+			filter:    controllercontext.NamespaceFilters{},
+			namespace: "tenant-a",
+			want:      true,
+		},
+		{
+			name: "all filters match",
+			filter: controllercontext.NamespaceFilters{
+				namespaceSet{"tenant-a": true, "tenant-b": true},
+				controllercontext.NewNamespaceAllowlist("tenant-a"),
+			},
+			namespace: "tenant-a",
+			want:      true,
+		},
+		{
+			name: "only some filters match",
+			filter: controllercontext.NamespaceFilters{
+				namespaceSet{"tenant-a": true, "tenant-b": true},
+				controllercontext.NewNamespaceAllowlist("tenant-a"),
+			},
+			namespace: "tenant-b",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.namespace); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.namespace, got, tt.want)
+			}
+		})
+	}
+}
+
+func assertEvent(t *testing.T, w watch.Interface, eventType watch.EventType, name string) {
+	select {
+	case event := <-w.ResultChan():
+		assert.Equal(t, eventType, event.Type)
+		assert.Equal(t, name, event.Object.(*execution.Job).Name)
+	case <-time.After(time.Second):
+		t.Errorf("timed out waiting for %v event for %v", eventType, name)
+	}
+}
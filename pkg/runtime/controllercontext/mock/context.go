@@ -20,6 +20,9 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 )
@@ -46,6 +49,22 @@ func NewContext() *Context {
 	return c
 }
 
+// RESTConfig always returns nil, since the mock context does not connect to any
+// apiserver.
+func (c *Context) RESTConfig() *rest.Config {
+	return nil
+}
+
+// NewEventBroadcaster returns a new EventBroadcaster that records Events to the
+// mock clientset.
+func (c *Context) NewEventBroadcaster() record.EventBroadcaster {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&v1core.EventSinkImpl{
+		Interface: c.Clientsets().Kubernetes().CoreV1().Events(""),
+	})
+	return broadcaster
+}
+
 func (c *Context) Clientsets() controllercontext.Clientsets {
 	return c.MockClientsets()
 }
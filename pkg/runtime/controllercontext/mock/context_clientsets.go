@@ -17,8 +17,11 @@
 package mock
 
 import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 
 	furiko "github.com/furiko-io/furiko/pkg/generated/clientset/versioned"
 	furikofake "github.com/furiko-io/furiko/pkg/generated/clientset/versioned/fake"
@@ -32,9 +35,28 @@ type Clientsets struct {
 
 // NewClientsets returns a new Clientsets using fake clients.
 func NewClientsets() *Clientsets {
+	kubernetesClient := fake.NewSimpleClientset()
+	kubernetesClient.PrependReactor("patch", "*", applyPatchReactor(kubernetesClient.Tracker()))
+	furikoClient := furikofake.NewSimpleClientset()
+	furikoClient.PrependReactor("patch", "*", applyPatchReactor(furikoClient.Tracker()))
 	return &Clientsets{
-		kubernetes: fake.NewSimpleClientset(),
-		furiko:     furikofake.NewSimpleClientset(),
+		kubernetes: kubernetesClient,
+		furiko:     furikoClient,
+	}
+}
+
+// applyPatchReactor handles server-side apply patches, which are not supported
+// by the fake object tracker, by treating them as JSON merge patches. This does
+// not remove fields that were previously applied by the same field manager.
+func applyPatchReactor(tracker ktesting.ObjectTracker) ktesting.ReactionFunc {
+	react := ktesting.ObjectReaction(tracker)
+	return func(action ktesting.Action) (bool, runtime.Object, error) {
+		patchAction, ok := action.(ktesting.PatchActionImpl)
+		if !ok || patchAction.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		patchAction.PatchType = types.MergePatchType
+		return react(patchAction)
 	}
 }
 
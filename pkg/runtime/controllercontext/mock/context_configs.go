@@ -24,13 +24,15 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
 	"github.com/furiko-io/furiko/pkg/runtime/configloader"
 	"github.com/furiko-io/furiko/pkg/runtime/controllercontext"
 )
 
 type Configs struct {
 	controllercontext.Configs
-	configLoader *ConfigLoader
+	configLoader           *ConfigLoader
+	namespacedConfigLoader *NamespacedConfigLoader
 }
 
 // NewConfigs returns a new dynamic config manager that supports overriding a
@@ -38,13 +40,16 @@ type Configs struct {
 func NewConfigs() *Configs {
 	mgr := configloader.NewConfigManager()
 	configLoader := NewMockConfigLoader()
+	namespacedConfigLoader := NewMockNamespacedConfigLoader()
 	mgr.AddConfigLoaders(
 		configloader.NewDefaultsLoader(),
 		configLoader,
 	)
+	mgr.AddNamespacedConfigLoaders(namespacedConfigLoader)
 	return &Configs{
-		Configs:      controllercontext.NewContextConfigs(mgr),
-		configLoader: configLoader,
+		Configs:                controllercontext.NewContextConfigs(mgr),
+		configLoader:           configLoader,
+		namespacedConfigLoader: namespacedConfigLoader,
 	}
 }
 
@@ -55,11 +60,22 @@ func (c *Configs) SetConfigs(cfgs map[configv1alpha1.ConfigName]runtime.Object)
 	}
 }
 
+// SetExecutionConfigs is a convenience method to set namespaced configs from
+// ExecutionConfigs.
+func (c *Configs) SetExecutionConfigs(executionConfigs ...*execution.ExecutionConfig) {
+	c.MockNamespacedConfigLoader().SetExecutionConfigs(executionConfigs...)
+}
+
 // MockConfigLoader returns the mock configloader.Loader.
 func (c *Configs) MockConfigLoader() *ConfigLoader {
 	return c.configLoader
 }
 
+// MockNamespacedConfigLoader returns the mock configloader.NamespacedLoader.
+func (c *Configs) MockNamespacedConfigLoader() *NamespacedConfigLoader {
+	return c.namespacedConfigLoader
+}
+
 var _ controllercontext.Configs = (*Configs)(nil)
 
 type ConfigLoader struct {
@@ -104,3 +120,42 @@ func (c *ConfigLoader) SetConfig(configName configv1alpha1.ConfigName, config ru
 	defer c.mu.Unlock()
 	c.configs[configName] = config
 }
+
+type NamespacedConfigLoader struct {
+	executionConfigs map[string][]*execution.ExecutionConfig
+	mu               sync.RWMutex
+}
+
+var _ configloader.NamespacedLoader = (*NamespacedConfigLoader)(nil)
+
+func NewMockNamespacedConfigLoader() *NamespacedConfigLoader {
+	return &NamespacedConfigLoader{
+		executionConfigs: make(map[string][]*execution.ExecutionConfig),
+	}
+}
+
+func (c *NamespacedConfigLoader) Name() string {
+	return "MockNamespaced"
+}
+
+func (c *NamespacedConfigLoader) Start(ctx context.Context) error {
+	return nil
+}
+
+func (c *NamespacedConfigLoader) LoadNamespaced(
+	configName configv1alpha1.ConfigName,
+	namespace string,
+) (configloader.Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return configloader.MergeExecutionConfigs(c.executionConfigs[namespace], configName)
+}
+
+func (c *NamespacedConfigLoader) SetExecutionConfigs(executionConfigs ...*execution.ExecutionConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, executionConfig := range executionConfigs {
+		namespace := executionConfig.Namespace
+		c.executionConfigs[namespace] = append(c.executionConfigs[namespace], executionConfig)
+	}
+}
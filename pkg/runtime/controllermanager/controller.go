@@ -18,6 +18,7 @@ package controllermanager
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,6 +32,10 @@ import (
 	"github.com/furiko-io/furiko/pkg/runtime/leaderelection"
 )
 
+const (
+	defaultDrainTimeout = 30 * time.Second
+)
+
 // Controller encapsulates a routine that can be run and shut down.
 type Controller interface {
 	Runnable
@@ -48,9 +53,10 @@ type Controller interface {
 // optionally performs leader election.
 type ControllerManager struct {
 	*BaseManager
-	controllers []Controller
-	stores      []Store
-	coordinator leaderelection.Coordinator
+	controllers  []Controller
+	stores       []Store
+	coordinator  leaderelection.Coordinator
+	drainTimeout time.Duration
 }
 
 func NewControllerManager(
@@ -59,7 +65,11 @@ func NewControllerManager(
 	defaultLeaseName string,
 ) (*ControllerManager, error) {
 	m := &ControllerManager{
-		BaseManager: NewBaseManager(ctrlContext),
+		BaseManager:  NewBaseManager(ctrlContext),
+		drainTimeout: defaultDrainTimeout,
+	}
+	if ctrlCfg.DrainTimeout.Duration > 0 {
+		m.drainTimeout = ctrlCfg.DrainTimeout.Duration
 	}
 
 	// Enable leader election.
@@ -126,7 +136,8 @@ func (m *ControllerManager) Start(ctx context.Context, startTimeout time.Duratio
 	}
 
 	// We only let the controller manager pass the readiness probe at this phase
-	// once all pre-flight checks are done.
+	// once all pre-flight checks are done. Readiness additionally requires all
+	// controllers' caches to be synced, see GetReadiness.
 	atomic.StoreUint64(&m.readiness, 1)
 
 	// Start election and block until we are elected.
@@ -170,6 +181,22 @@ func (m *ControllerManager) Start(ctx context.Context, startTimeout time.Duratio
 	return nil
 }
 
+// GetReadiness returns an error if the controller manager is not yet fully
+// initialized, or if any controller's informer caches have not yet been synced.
+// Since informers are started regardless of leader election, this allows
+// standby replicas to become ready once their caches are warm.
+func (m *ControllerManager) GetReadiness() error {
+	if err := m.BaseManager.GetReadiness(); err != nil {
+		return err
+	}
+	for _, controller := range m.controllers {
+		if health := controller.GetHealth(); !health.CachesSynced {
+			return fmt.Errorf("caches not yet synced for %v", health.Name)
+		}
+	}
+	return nil
+}
+
 // GetHealth returns a list of all controllers' health statuses.
 func (m *ControllerManager) GetHealth() []HealthStatus {
 	healths := make([]HealthStatus, 0, len(m.controllers))
@@ -189,8 +216,11 @@ func (m *ControllerManager) GetHealth() []HealthStatus {
 func (m *ControllerManager) ShutdownAndWait(ctx context.Context) {
 	klog.Infof("controllermanager: shutting down")
 
-	// Shut down all runnables.
-	m.BaseManager.ShutdownAndWait(ctx)
+	// Shut down all runnables, allowing in-flight work to be drained up to the
+	// drain timeout.
+	drainCtx, cancel := context.WithTimeout(ctx, m.drainTimeout)
+	defer cancel()
+	m.BaseManager.ShutdownAndWait(drainCtx)
 
 	// Only give up lease once all controllers have been shut down fully.
 	if m.coordinator != nil {
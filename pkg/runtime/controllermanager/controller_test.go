@@ -23,6 +23,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
 	"k8s.io/utils/pointer"
 
 	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
@@ -53,6 +57,7 @@ type mockController struct {
 	mock             mockRunnable
 	shutdownDuration time.Duration
 	shutdownDone     uint64
+	cachesNotSynced  bool
 }
 
 func newMockController(name string, mock mockRunnable) *mockController {
@@ -70,8 +75,9 @@ func (c *mockController) Run(ctx context.Context) error {
 
 func (c *mockController) GetHealth() controllermanager.HealthStatus {
 	return controllermanager.HealthStatus{
-		Name:    c.name,
-		Healthy: true,
+		Name:         c.name,
+		Healthy:      true,
+		CachesSynced: !c.cachesNotSynced,
 	}
 }
 
@@ -315,6 +321,50 @@ func TestControllerManager_Shutdown(t *testing.T) {
 	}
 }
 
+func TestControllerManager_ShutdownReleasesLease(t *testing.T) {
+	c := mock.NewContext()
+	mgr, err := controllermanager.NewControllerManager(c, configv1alpha1.ControllerManagerConfigSpec{
+		LeaderElection: &configv1alpha1.LeaderElectionSpec{
+			Enabled: pointer.Bool(true),
+		},
+	}, "execution-controller")
+	assert.NoError(t, err)
+	controller := newMockController("mock", mockRunnable{})
+	controller.shutdownDuration = time.Millisecond * 100
+	mgr.Add(controller)
+
+	// Record if the lease is released before the controller has shut down.
+	var releasedEarly uint64
+	c.MockClientsets().KubernetesMock().PrependReactor("update", "leases",
+		func(action ktesting.Action) (bool, runtime.Object, error) {
+			lease, ok := action.(ktesting.UpdateAction).GetObject().(*coordinationv1.Lease)
+			if ok && pointer.StringDeref(lease.Spec.HolderIdentity, "") == "" &&
+				atomic.LoadUint64(&controller.shutdownDone) == 0 {
+				atomic.StoreUint64(&releasedEarly, 1)
+			}
+			return false, nil, nil
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, mgr.Start(ctx, time.Second))
+
+	getHolder := func() string {
+		lease, err := c.Clientsets().Kubernetes().CoordinationV1().Leases("furiko-system").
+			Get(context.Background(), "execution-controller", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return pointer.StringDeref(lease.Spec.HolderIdentity, "")
+	}
+	assert.NotEmpty(t, getHolder())
+
+	// Lease should not be released until shutdown is complete.
+	cancel()
+	mgr.ShutdownAndWait(context.Background())
+	assert.Equal(t, uint64(1), atomic.LoadUint64(&controller.shutdownDone))
+	assert.Equal(t, uint64(0), atomic.LoadUint64(&releasedEarly))
+	assert.Empty(t, getHolder())
+}
+
 func TestControllerManager_GetHealth(t *testing.T) {
 	c := mock.NewContext()
 	mgr, err := controllermanager.NewControllerManager(c, configv1alpha1.ControllerManagerConfigSpec{}, "")
@@ -333,6 +383,54 @@ func TestControllerManager_GetHealth(t *testing.T) {
 	assert.Len(t, mgr.GetHealth(), 2)
 }
 
+func TestControllerManager_GetReadiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		controllers []*mockController
+		wantErr     bool
+	}{
+		{
+			name: "no controllers",
+		},
+		{
+			name: "all caches synced",
+			controllers: []*mockController{
+				newMockController("mock1", mockRunnable{}),
+				newMockController("mock2", mockRunnable{}),
+			},
+		},
+		{
+			name: "caches not synced",
+			controllers: []*mockController{
+				newMockController("mock1", mockRunnable{}),
+				{
+					name:            "mock2",
+					cachesNotSynced: true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := mock.NewContext()
+			mgr, err := controllermanager.NewControllerManager(c, configv1alpha1.ControllerManagerConfigSpec{}, "")
+			assert.NoError(t, err)
+			for _, controller := range tt.controllers {
+				mgr.Add(controller)
+			}
+
+			// Not ready before starting.
+			assert.Error(t, mgr.GetReadiness())
+
+			assert.NoError(t, mgr.Start(context.Background(), 0))
+			if err := mgr.GetReadiness(); (err != nil) != tt.wantErr {
+				t.Errorf("GetReadiness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func assertErrorIs(target error) assert.ErrorAssertionFunc {
 	return func(t assert.TestingT, err error, i ...interface{}) bool {
 		return assert.ErrorIs(t, err, target, i...)
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllermanager
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// controllersWildcard enables all controllers that are not explicitly disabled.
+	controllersWildcard = "*"
+
+	// controllersDisablePrefix is the prefix that disables a controller.
+	controllersDisablePrefix = "-"
+)
+
+// ParseControllers parses a comma-separated list of controllers, ignoring any
+// empty entries.
+func ParseControllers(value string) []string {
+	var controllers []string
+	for _, controller := range strings.Split(value, ",") {
+		if controller = strings.TrimSpace(controller); controller != "" {
+			controllers = append(controllers, controller)
+		}
+	}
+	return controllers
+}
+
+// IsControllerEnabled returns whether the controller with the given name is
+// enabled by the list of controllers. Each entry in the list may be '*' to
+// enable all controllers, 'foo' to enable the controller named 'foo', or '-foo'
+// to disable the controller named 'foo'. Names are matched case-insensitively.
+// An empty list enables all controllers.
+func IsControllerEnabled(name string, controllers []string) bool {
+	if len(controllers) == 0 {
+		return true
+	}
+
+	var hasWildcard bool
+	for _, controller := range controllers {
+		switch {
+		case controller == controllersWildcard:
+			hasWildcard = true
+		case strings.EqualFold(controller, controllersDisablePrefix+name):
+			return false
+		case strings.EqualFold(controller, name):
+			return true
+		}
+	}
+
+	return hasWildcard
+}
+
+// ValidateControllers returns an error if the list of controllers refers to
+// any controller that is not in names.
+func ValidateControllers(controllers []string, names []string) error {
+	for _, controller := range controllers {
+		if controller == controllersWildcard {
+			continue
+		}
+		name := strings.TrimPrefix(controller, controllersDisablePrefix)
+		if !containsFold(names, name) {
+			return fmt.Errorf("unknown controller %v, must be one of %v", name, names)
+		}
+	}
+	return nil
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
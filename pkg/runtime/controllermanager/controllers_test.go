@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllermanager_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+)
+
+func TestParseControllers(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:  "single controller",
+			value: "JobController",
+			want:  []string{"JobController"},
+		},
+		{
+			name:  "trim spaces and empty entries",
+			value: " *, -CronController,, ",
+			want:  []string{"*", "-CronController"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, controllermanager.ParseControllers(tt.value))
+		})
+	}
+}
+
+func TestIsControllerEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		controllers []string
+		want        bool
+	}{
+		{
+			name: "empty list enables all",
+			want: true,
+		},
+		{
+			name:        "wildcard",
+			controllers: []string{"*"},
+			want:        true,
+		},
+		{
+			name:        "explicitly enabled",
+			controllers: []string{"JobController"},
+			want:        true,
+		},
+		{
+			name:        "case-insensitive",
+			controllers: []string{"jobcontroller"},
+			want:        true,
+		},
+		{
+			name:        "not in list",
+			controllers: []string{"CronController"},
+			want:        false,
+		},
+		{
+			name:        "disabled with wildcard",
+			controllers: []string{"*", "-jobcontroller"},
+			want:        false,
+		},
+		{
+			name:        "other controller disabled with wildcard",
+			controllers: []string{"*", "-CronController"},
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, controllermanager.IsControllerEnabled("JobController", tt.controllers))
+		})
+	}
+}
+
+func TestValidateControllers(t *testing.T) {
+	names := []string{"CronController", "JobController"}
+	tests := []struct {
+		name        string
+		controllers []string
+		wantErr     bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:        "valid",
+			controllers: []string{"*", "-cronController", "JobController"},
+		},
+		{
+			name:        "unknown controller",
+			controllers: []string{"JobControler"},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown disabled controller",
+			controllers: []string{"*", "-TTLController"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := controllermanager.ValidateControllers(tt.controllers, names)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateControllers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllermanager
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/furiko-io/furiko/pkg/runtime/configloader"
+)
+
+const (
+	// workqueueDepthMetric is the name of the metric registered by
+	// controller-runtime for the current depth of each named workqueue.
+	workqueueDepthMetric = "workqueue_depth"
+)
+
+// DebugStatus contains the internal state of a manager for troubleshooting.
+type DebugStatus struct {
+	// Current number of items in each workqueue, keyed by the workqueue name.
+	WorkqueueLengths map[string]int `json:"workqueueLengths"`
+
+	// Current number of objects in each started informer's cache, keyed by the
+	// object type.
+	InformerCacheSizes map[string]int `json:"informerCacheSizes"`
+
+	// Status of the dynamic configs that are currently in use.
+	Configs *configloader.Status `json:"configs,omitempty"`
+}
+
+// GetDebugStatus returns the current DebugStatus of the manager.
+func (m *BaseManager) GetDebugStatus() (*DebugStatus, error) {
+	lengths, err := getWorkqueueLengths()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get workqueue lengths")
+	}
+
+	// Passing a closed channel does not wait for any informers to be synced.
+	closed := make(chan struct{})
+	close(closed)
+
+	sizes := make(map[string]int)
+	kubernetes := m.ctrlContext.Informers().Kubernetes()
+	addInformerCacheSizes(sizes, kubernetes.WaitForCacheSync(closed), func(obj runtime.Object) cache.SharedIndexInformer {
+		return kubernetes.InformerFor(obj, nil)
+	})
+	furiko := m.ctrlContext.Informers().Furiko()
+	addInformerCacheSizes(sizes, furiko.WaitForCacheSync(closed), func(obj runtime.Object) cache.SharedIndexInformer {
+		return furiko.InformerFor(obj, nil)
+	})
+
+	return &DebugStatus{
+		WorkqueueLengths:   lengths,
+		InformerCacheSizes: sizes,
+		Configs:            m.ctrlContext.Configs().GetStatus(),
+	}, nil
+}
+
+// getWorkqueueLengths returns the depth of all named workqueues from the
+// metrics registry.
+func getWorkqueueLengths() (map[string]int, error) {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	lengths := make(map[string]int)
+	for _, family := range families {
+		if family.GetName() != workqueueDepthMetric {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "name" {
+					lengths[label.GetValue()] = int(metric.GetGauge().GetValue())
+				}
+			}
+		}
+	}
+
+	return lengths, nil
+}
+
+// addInformerCacheSizes adds the cache sizes of the given started informers.
+// Since the informers have already been started, informerFor will return the
+// existing informer for the type.
+func addInformerCacheSizes(
+	sizes map[string]int,
+	started map[reflect.Type]bool,
+	informerFor func(obj runtime.Object) cache.SharedIndexInformer,
+) {
+	for typ := range started {
+		obj, ok := reflect.New(typ.Elem()).Interface().(runtime.Object)
+		if !ok {
+			continue
+		}
+		name := fmt.Sprintf("%v.%v", typ.Elem().PkgPath(), typ.Elem().Name())
+		sizes[name] = len(informerFor(obj).GetStore().ListKeys())
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllermanager_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+)
+
+func TestBaseManager_GetDebugStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctrlContext := mock.NewContext()
+	hasSynced := ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs().Informer().HasSynced
+	if err := ctrlContext.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"job1", "job2"} {
+		rj := &execution.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+			},
+		}
+		if _, err := ctrlContext.Clientsets().Furiko().ExecutionV1alpha1().Jobs(rj.Namespace).
+			Create(ctx, rj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("cannot create Job: %v", err)
+		}
+	}
+	if !cache.WaitForCacheSync(ctx.Done(), hasSynced) {
+		t.Fatalf("cannot sync caches")
+	}
+	assert.Eventually(t, func() bool {
+		return len(ctrlContext.Informers().Furiko().Execution().V1alpha1().Jobs().Informer().GetStore().ListKeys()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	mgr := controllermanager.NewBaseManager(ctrlContext)
+	before, err := mgr.GetDebugStatus()
+	assert.NoError(t, err)
+
+	// Metrics are shared by all workqueues with the same name, so we compare
+	// against the length before adding items.
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "TestDebugStatusQueue")
+	defer queue.ShutDown()
+	queue.Add("item1")
+	queue.Add("item2")
+	queue.Add("item3")
+
+	status, err := mgr.GetDebugStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, status.WorkqueueLengths["TestDebugStatusQueue"]-before.WorkqueueLengths["TestDebugStatusQueue"])
+	assert.Equal(t, map[string]int{
+		"github.com/furiko-io/furiko/apis/execution/v1alpha1.Job": 2,
+	}, status.InformerCacheSizes)
+}
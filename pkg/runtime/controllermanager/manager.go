@@ -18,6 +18,7 @@ package controllermanager
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -34,6 +35,18 @@ type HealthStatus struct {
 	Name    string `json:"name"`
 	Healthy bool   `json:"healthy"`
 	Message string `json:"message,omitempty"`
+
+	// CachesSynced is true if all informer caches used by the controller have
+	// been synced.
+	CachesSynced bool `json:"cachesSynced"`
+
+	// QueueDepth is the number of items currently waiting in the controller's
+	// workqueues.
+	QueueDepth int `json:"queueDepth"`
+
+	// LastSuccessfulSync is the time at which the controller last successfully
+	// reconciled an item, or nil if it has yet to do so.
+	LastSuccessfulSync *time.Time `json:"lastSuccessfulSync,omitempty"`
 }
 
 // BaseManager is the base manager that manages runnables, readiness and
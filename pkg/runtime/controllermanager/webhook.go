@@ -18,6 +18,7 @@ package controllermanager
 
 import (
 	"context"
+	"fmt"
 	"sync/atomic"
 
 	"github.com/pkg/errors"
@@ -54,6 +55,7 @@ type Webhook interface {
 type WebhookManager struct {
 	*BaseManager
 	webhooks []Webhook
+	serving  uint64
 }
 
 func NewWebhookManager(ctrlContext controllercontext.Context) *WebhookManager {
@@ -103,6 +105,31 @@ func (m *WebhookManager) Start(ctx context.Context) error {
 	return nil
 }
 
+// SetServing marks that the webhook server is listening and ready to serve
+// requests. The webhook manager will not pass the readiness probe until this is
+// called.
+func (m *WebhookManager) SetServing() {
+	atomic.StoreUint64(&m.serving, 1)
+}
+
+// GetReadiness returns an error if the webhook manager is not yet fully
+// initialized, if the webhook server is not yet serving, or if any webhook is
+// not yet ready.
+func (m *WebhookManager) GetReadiness() error {
+	if err := m.BaseManager.GetReadiness(); err != nil {
+		return err
+	}
+	if atomic.LoadUint64(&m.serving) == 0 {
+		return errors.New("webhook server not yet serving")
+	}
+	for _, webhook := range m.webhooks {
+		if !webhook.Ready() {
+			return fmt.Errorf("webhook %v not yet ready", webhook.Name())
+		}
+	}
+	return nil
+}
+
 // StartWebhooksAndWait starts all the given Webhooks in the background and
 // blocks until they are all ready. If any controller's Start method returns an
 // error, this method returns and cancels the context. To terminate controllers,
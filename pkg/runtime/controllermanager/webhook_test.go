@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllermanager_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+
+	"github.com/furiko-io/furiko/pkg/runtime/controllercontext/mock"
+	"github.com/furiko-io/furiko/pkg/runtime/controllermanager"
+)
+
+type mockWebhook struct {
+	name  string
+	ready bool
+}
+
+var _ controllermanager.Webhook = (*mockWebhook)(nil)
+
+func (w *mockWebhook) Name() string {
+	return w.name
+}
+
+func (w *mockWebhook) Start(_ context.Context) error {
+	w.ready = true
+	return nil
+}
+
+func (w *mockWebhook) Ready() bool {
+	return w.ready
+}
+
+func (w *mockWebhook) Path() string {
+	return "/" + w.name
+}
+
+func (w *mockWebhook) Handle(
+	_ context.Context,
+	_ *admissionv1.AdmissionRequest,
+) (*admissionv1.AdmissionResponse, error) {
+	return &admissionv1.AdmissionResponse{Allowed: true}, nil
+}
+
+func (w *mockWebhook) Shutdown(_ context.Context) {}
+
+func TestWebhookManager_GetReadiness(t *testing.T) {
+	mgr := controllermanager.NewWebhookManager(mock.NewContext())
+	mgr.Add(&mockWebhook{name: "mock1"}, &mockWebhook{name: "mock2"})
+
+	// Not ready before starting.
+	assert.Error(t, mgr.GetReadiness())
+
+	// Not ready until the webhook server is serving.
+	assert.NoError(t, mgr.Start(context.Background()))
+	assert.Error(t, mgr.GetReadiness())
+
+	mgr.SetServing()
+	assert.NoError(t, mgr.GetReadiness())
+}
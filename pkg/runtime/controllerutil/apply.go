@@ -0,0 +1,57 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllerutil
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+)
+
+// NewStatusApplyPatch returns a server-side apply patch for the status
+// subresource of the object with the given kind, namespace and name.
+//
+// The field manager that applies the patch will take ownership of all fields
+// that are present in status, and fields that it previously owned but are no
+// longer present will be removed. As such, controllers that only manage a
+// subset of the status should only specify those fields in status.
+func NewStatusApplyPatch(gvk schema.GroupVersionKind, namespace, name string, status interface{}) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata": map[string]interface{}{
+			"namespace": namespace,
+			"name":      name,
+		},
+		"status": status,
+	})
+}
+
+// NewApplyPatchOptions returns the PatchOptions for a server-side apply patch
+// using the given field manager.
+//
+// Conflicts are always forced, since each controller is the source of truth for
+// the fields that it manages, and other field managers should not be fighting
+// over ownership of the same fields.
+func NewApplyPatchOptions(fieldManager string) metav1.PatchOptions {
+	return metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        pointer.Bool(true),
+	}
+}
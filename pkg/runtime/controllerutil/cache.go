@@ -52,3 +52,13 @@ func WaitForNamedCacheSyncWithTimeout(
 
 	return nil
 }
+
+// HasSynced returns true if all of the given informers have synced.
+func HasSynced(cacheSyncs ...cache.InformerSynced) bool {
+	for _, synced := range cacheSyncs {
+		if !synced() {
+			return false
+		}
+	}
+	return true
+}
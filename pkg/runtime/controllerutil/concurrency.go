@@ -41,3 +41,19 @@ func GetConcurrencyOrDefaultNumber(concurrency *configv1alpha1.Concurrency, defa
 	}
 	return defaultNumber
 }
+
+// GetDynamicConcurrency returns the concurrency of a single controller from
+// the dynamic ControllerExecutionConfig if it is specified, otherwise falls
+// back to the given concurrency from the bootstrap config.
+func GetDynamicConcurrency(
+	cfg *configv1alpha1.ControllerExecutionConfig,
+	concurrency *configv1alpha1.Concurrency,
+	get func(spec *configv1alpha1.ExecutionControllerConcurrencySpec) *configv1alpha1.Concurrency,
+) *configv1alpha1.Concurrency {
+	if cfg != nil && cfg.Concurrency != nil {
+		if override := get(cfg.Concurrency); override != nil {
+			return override
+		}
+	}
+	return concurrency
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllerutil
+
+import (
+	"k8s.io/client-go/tools/record"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+// NewCorrelatorOptions returns the CorrelatorOptions for an EventBroadcaster
+// from the given EventRecorderSpec. Unspecified fields are left empty, which
+// will use the defaults from client-go.
+func NewCorrelatorOptions(spec *configv1alpha1.EventRecorderSpec) record.CorrelatorOptions {
+	var options record.CorrelatorOptions
+	if spec == nil {
+		return options
+	}
+
+	if spec.BurstSize != nil && *spec.BurstSize > 0 {
+		options.BurstSize = int(*spec.BurstSize)
+	}
+	if spec.QPS != nil && *spec.QPS > 0 {
+		options.QPS = float32(*spec.QPS)
+	}
+	if spec.MaxEventsPerObjectPerMinute != nil && *spec.MaxEventsPerObjectPerMinute > 0 {
+		options.QPS = float32(*spec.MaxEventsPerObjectPerMinute) / 60
+	}
+	if spec.MaxSimilarEvents != nil && *spec.MaxSimilarEvents > 0 {
+		options.MaxEvents = int(*spec.MaxSimilarEvents)
+	}
+	if spec.AggregationIntervalSeconds != nil && *spec.AggregationIntervalSeconds > 0 {
+		options.MaxIntervalInSeconds = int(*spec.AggregationIntervalSeconds)
+	}
+
+	return options
+}
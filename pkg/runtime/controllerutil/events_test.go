@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllerutil_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+)
+
+func TestNewCorrelatorOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *configv1alpha1.EventRecorderSpec
+		want record.CorrelatorOptions
+	}{
+		{
+			name: "nil spec",
+		},
+		{
+			name: "empty spec",
+			spec: &configv1alpha1.EventRecorderSpec{},
+		},
+		{
+			name: "all fields",
+			spec: &configv1alpha1.EventRecorderSpec{
+				BurstSize:                  pointer.Int64(10),
+				QPS:                        pointer.Float64(0.5),
+				MaxSimilarEvents:           pointer.Int64(5),
+				AggregationIntervalSeconds: pointer.Int64(300),
+			},
+			want: record.CorrelatorOptions{
+				BurstSize:            10,
+				QPS:                  0.5,
+				MaxEvents:            5,
+				MaxIntervalInSeconds: 300,
+			},
+		},
+		{
+			name: "max events per object per minute takes precedence",
+			spec: &configv1alpha1.EventRecorderSpec{
+				QPS:                         pointer.Float64(0.5),
+				MaxEventsPerObjectPerMinute: pointer.Int64(6),
+			},
+			want: record.CorrelatorOptions{
+				QPS: 0.1,
+			},
+		},
+		{
+			name: "ignore non-positive values",
+			spec: &configv1alpha1.EventRecorderSpec{
+				BurstSize:                   pointer.Int64(0),
+				QPS:                         pointer.Float64(-1),
+				MaxEventsPerObjectPerMinute: pointer.Int64(0),
+				MaxSimilarEvents:            pointer.Int64(-1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := controllerutil.NewCorrelatorOptions(tt.spec)
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("NewCorrelatorOptions() not equal\ndiff = %v", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllerutil
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+// Defaults are taken from workqueue.DefaultControllerRateLimiter.
+const (
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+	defaultRateLimiterQPS       = 10
+	defaultRateLimiterBurst     = 100
+)
+
+// NewRateLimiter returns a workqueue.RateLimiter from the given spec. Any
+// unspecified values will use the same defaults as
+// workqueue.DefaultControllerRateLimiter.
+func NewRateLimiter(spec *configv1alpha1.RateLimiterSpec) workqueue.RateLimiter {
+	baseDelay := defaultRateLimiterBaseDelay
+	maxDelay := defaultRateLimiterMaxDelay
+	qps := rate.Limit(defaultRateLimiterQPS)
+	burst := defaultRateLimiterBurst
+
+	if spec != nil {
+		if spec.BaseDelayMilliseconds != nil && *spec.BaseDelayMilliseconds > 0 {
+			baseDelay = time.Duration(*spec.BaseDelayMilliseconds) * time.Millisecond
+		}
+		if spec.MaxDelaySeconds != nil && *spec.MaxDelaySeconds > 0 {
+			maxDelay = time.Duration(*spec.MaxDelaySeconds) * time.Second
+		}
+		if spec.QPS != nil && *spec.QPS > 0 {
+			qps = rate.Limit(*spec.QPS)
+		}
+		if spec.Burst != nil && *spec.Burst > 0 {
+			burst = int(*spec.Burst)
+		}
+	}
+
+	// The max delay should never be shorter than the base delay.
+	if maxDelay < baseDelay {
+		maxDelay = baseDelay
+	}
+
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(qps, burst)},
+	)
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllerutil_test
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *configv1alpha1.RateLimiterSpec
+		want []time.Duration
+	}{
+		{
+			name: "defaults",
+			want: []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		{
+			name: "custom base and max delay",
+			spec: &configv1alpha1.RateLimiterSpec{
+				BaseDelayMilliseconds: pointer.Int64(1000),
+				MaxDelaySeconds:       pointer.Int64(3),
+			},
+			want: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name: "max delay shorter than base delay",
+			spec: &configv1alpha1.RateLimiterSpec{
+				BaseDelayMilliseconds: pointer.Int64(5000),
+				MaxDelaySeconds:       pointer.Int64(1),
+			},
+			want: []time.Duration{5 * time.Second, 5 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := controllerutil.NewRateLimiter(tt.spec)
+			for i, want := range tt.want {
+				if got := limiter.When("item"); got != want {
+					t.Errorf("When() #%v = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRateLimiter_Bucket(t *testing.T) {
+	limiter := controllerutil.NewRateLimiter(&configv1alpha1.RateLimiterSpec{
+		QPS:   pointer.Float64(1),
+		Burst: pointer.Int64(2),
+	})
+
+	// Burst should be exhausted after 2 distinct items.
+	for _, item := range []string{"a", "b"} {
+		if got := limiter.When(item); got > 5*time.Millisecond {
+			t.Errorf("When(%v) = %v, expected no delay from burst", item, got)
+		}
+	}
+	if got := limiter.When("c"); got < 500*time.Millisecond {
+		t.Errorf("When(c) = %v, expected delay from overall QPS", got)
+	}
+}
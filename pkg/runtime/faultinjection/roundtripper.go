@@ -0,0 +1,186 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package faultinjection
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+var (
+	mutatingMethods = sets.NewString(http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete)
+)
+
+// RoundTripper is a http.RoundTripper that injects faults into mutating
+// requests made to the API server.
+type RoundTripper struct {
+	delegate  http.RoundTripper
+	spec      *configv1alpha1.FaultInjectionSpec
+	resources sets.String
+	sleep     func(time.Duration)
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+var _ http.RoundTripper = (*RoundTripper)(nil)
+
+// NewRoundTripper returns a new RoundTripper that injects faults according to
+// spec, and sends all other requests to the delegate.
+func NewRoundTripper(delegate http.RoundTripper, spec *configv1alpha1.FaultInjectionSpec) *RoundTripper {
+	seed := time.Now().UnixNano()
+	if spec.Seed != nil {
+		seed = *spec.Seed
+	}
+	return &RoundTripper{
+		delegate:  delegate,
+		spec:      spec,
+		resources: sets.NewString(spec.Resources...),
+		sleep:     time.Sleep,
+		rand:      rand.New(rand.NewSource(seed)), // nolint:gosec
+	}
+}
+
+// NewWrapper returns a function that wraps a http.RoundTripper with fault
+// injection, to be used with rest.Config's Wrap method.
+func NewWrapper(spec *configv1alpha1.FaultInjectionSpec) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return NewRoundTripper(rt, spec)
+	}
+}
+
+// SetSleep overrides the function used to delay requests, for testing.
+func (r *RoundTripper) SetSleep(sleep func(time.Duration)) {
+	r.sleep = sleep
+}
+
+func (r *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !r.shouldInject(req) {
+		return r.delegate.RoundTrip(req)
+	}
+
+	// Evaluate all probabilities up front, such that the sequence of random
+	// numbers does not depend on which faults were injected.
+	r.mu.Lock()
+	shouldDelay := r.roll(r.spec.DelayProbability)
+	shouldError := r.roll(r.spec.ErrorProbability)
+	shouldConflict := r.roll(r.spec.ConflictProbability)
+	r.mu.Unlock()
+
+	if shouldDelay {
+		klog.V(4).InfoS("faultinjection: delaying request",
+			"method", req.Method, "path", req.URL.Path, "delay", r.spec.Delay.Duration)
+		r.sleep(r.spec.Delay.Duration)
+	}
+
+	switch {
+	case shouldError:
+		klog.V(4).InfoS("faultinjection: injecting error", "method", req.Method, "path", req.URL.Path)
+		return newStatusResponse(req, http.StatusInternalServerError, metav1.StatusReasonInternalError)
+	case shouldConflict:
+		klog.V(4).InfoS("faultinjection: injecting conflict", "method", req.Method, "path", req.URL.Path)
+		return newStatusResponse(req, http.StatusConflict, metav1.StatusReasonConflict)
+	}
+
+	return r.delegate.RoundTrip(req)
+}
+
+func (r *RoundTripper) shouldInject(req *http.Request) bool {
+	if !mutatingMethods.Has(req.Method) {
+		return false
+	}
+	if r.resources.Len() > 0 && !r.resources.Has(parseResource(req.URL.Path)) {
+		return false
+	}
+	return true
+}
+
+func (r *RoundTripper) roll(probability *float64) bool {
+	value := r.rand.Float64()
+	return probability != nil && value < *probability
+}
+
+// parseResource returns the resource from the path of an API request, e.g.
+// /apis/execution.furiko.io/v1alpha1/namespaces/default/jobs/job-1/status
+// returns "jobs".
+func parseResource(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	// Strip the API prefix and group version.
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		parts = parts[3:]
+	default:
+		return ""
+	}
+
+	// Strip the namespace, unless the resource is the namespace itself.
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		parts = parts[2:]
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[0]
+}
+
+func newStatusResponse(req *http.Request, code int, reason metav1.StatusReason) (*http.Response, error) {
+	status := &metav1.Status{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Status",
+			APIVersion: "v1",
+		},
+		Status:  metav1.StatusFailure,
+		Message: "fault injected for " + req.Method + " " + req.URL.Path,
+		Reason:  reason,
+		Code:    int32(code),
+	}
+	body, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drain and close the request body, since it will not be sent.
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(code),
+		StatusCode:    code,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package faultinjection_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/faultinjection"
+)
+
+func TestRoundTripper(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      *configv1alpha1.FaultInjectionSpec
+		create    bool
+		wantErr   func(error) bool
+		wantDelay time.Duration
+	}{
+		{
+			name:   "no faults",
+			spec:   &configv1alpha1.FaultInjectionSpec{},
+			create: true,
+		},
+		{
+			name: "inject error",
+			spec: &configv1alpha1.FaultInjectionSpec{
+				ErrorProbability: pointer.Float64(1),
+			},
+			create:  true,
+			wantErr: kerrors.IsInternalError,
+		},
+		{
+			name: "inject conflict",
+			spec: &configv1alpha1.FaultInjectionSpec{
+				ConflictProbability: pointer.Float64(1),
+			},
+			create:  true,
+			wantErr: kerrors.IsConflict,
+		},
+		{
+			name: "inject delay",
+			spec: &configv1alpha1.FaultInjectionSpec{
+				DelayProbability: pointer.Float64(1),
+				Delay:            metav1.Duration{Duration: time.Minute},
+			},
+			create:    true,
+			wantDelay: time.Minute,
+		},
+		{
+			name: "do not inject for non-mutating requests",
+			spec: &configv1alpha1.FaultInjectionSpec{
+				ErrorProbability: pointer.Float64(1),
+			},
+		},
+		{
+			name: "do not inject for other resources",
+			spec: &configv1alpha1.FaultInjectionSpec{
+				ErrorProbability: pointer.Float64(1),
+				Resources:        []string{"jobs"},
+			},
+			create: true,
+		},
+		{
+			name: "inject for matching resources",
+			spec: &configv1alpha1.FaultInjectionSpec{
+				ErrorProbability: pointer.Float64(1),
+				Resources:        []string{"pods"},
+			},
+			create:  true,
+			wantErr: kerrors.IsInternalError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"kind":"Pod","apiVersion":"v1","metadata":{"name":"pod"}}`))
+			}))
+			defer server.Close()
+
+			var delay time.Duration
+			cfg := &rest.Config{Host: server.URL}
+			cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+				injector := faultinjection.NewRoundTripper(rt, tt.spec)
+				injector.SetSleep(func(d time.Duration) { delay += d })
+				return injector
+			})
+			client, err := kubernetes.NewForConfig(cfg)
+			assert.NoError(t, err)
+
+			pods := client.CoreV1().Pods("default")
+			if tt.create {
+				_, err = pods.Create(context.Background(), &corev1.Pod{}, metav1.CreateOptions{})
+			} else {
+				_, err = pods.Get(context.Background(), "pod", metav1.GetOptions{})
+			}
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
+func TestRoundTripper_Seed(t *testing.T) {
+	spec := &configv1alpha1.FaultInjectionSpec{
+		ErrorProbability: pointer.Float64(0.5),
+		Seed:             pointer.Int64(42),
+	}
+
+	// Same seed should inject faults for the same sequence of requests.
+	run := func() []bool {
+		rt := faultinjection.NewRoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		}), spec)
+		results := make([]bool, 0, 20)
+		for i := 0; i < 20; i++ {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/pods", nil)
+			resp, err := rt.RoundTrip(req)
+			assert.NoError(t, err)
+			results = append(results, resp.StatusCode != http.StatusOK)
+		}
+		return results
+	}
+
+	first := run()
+	assert.Equal(t, first, run())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
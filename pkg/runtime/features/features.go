@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ExternalTaskExecutor enables the ExternalTask executor, which runs tasks on
+	// external runners instead of as Pods.
+	//
+	// beta: default enabled
+	ExternalTaskExecutor featuregate.Feature = "ExternalTaskExecutor"
+
+	// FaultInjection allows faults to be injected into requests made to the API
+	// server using the faultInjection bootstrap config. Only meant for testing.
+	//
+	// alpha: default disabled
+	FaultInjection featuregate.Feature = "FaultInjection"
+
+	// ResourceRecommendations sets the resource requests of new tasks based on
+	// the resource usage recorded in their JobConfig's status. Requests will
+	// never be set higher than the container's limits.
+	//
+	// alpha: default disabled
+	ResourceRecommendations featuregate.Feature = "ResourceRecommendations"
+)
+
+const (
+	allAlphaGate featuregate.Feature = "AllAlpha"
+	allBetaGate  featuregate.Feature = "AllBeta"
+)
+
+// defaultFeatureGates contains the default settings of all known features.
+// To add a new feature, define a key for it above and add it here.
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	ExternalTaskExecutor:    {Default: true, PreRelease: featuregate.Beta},
+	FaultInjection:          {Default: false, PreRelease: featuregate.Alpha},
+	ResourceRecommendations: {Default: false, PreRelease: featuregate.Alpha},
+}
+
+var (
+	// DefaultMutableFeatureGate is a mutable version of DefaultFeatureGate, and
+	// should only be mutated at startup by the main package of each component.
+	DefaultMutableFeatureGate = featuregate.NewFeatureGate()
+
+	// DefaultFeatureGate is a shared global FeatureGate. Use Enabled to check if a
+	// feature is enabled.
+	DefaultFeatureGate featuregate.FeatureGate = DefaultMutableFeatureGate
+)
+
+func init() {
+	if err := DefaultMutableFeatureGate.Add(defaultFeatureGates); err != nil {
+		panic(err)
+	}
+}
+
+// Enabled returns true if the given feature is enabled in the DefaultFeatureGate.
+func Enabled(feature featuregate.Feature) bool {
+	return DefaultFeatureGate.Enabled(feature)
+}
+
+// Configure sets the features in the gate from the bootstrap config, followed by
+// the value of the --feature-gates flag (e.g. "Foo=true,Bar=false"), such that
+// the flag takes precedence over the bootstrap config.
+func Configure(gate featuregate.MutableFeatureGate, fromConfig map[string]bool, fromFlag string) error {
+	if err := gate.SetFromMap(fromConfig); err != nil {
+		return errors.Wrapf(err, "invalid featureGates in config")
+	}
+	if fromFlag != "" {
+		if err := gate.Set(fromFlag); err != nil {
+			return errors.Wrapf(err, "invalid --feature-gates flag")
+		}
+	}
+	return nil
+}
+
+// LogAndObserve logs the enabled state of all known features and exports it as
+// a metric.
+func LogAndObserve(gate featuregate.MutableFeatureGate) {
+	all := gate.GetAll()
+	states := make([]string, 0, len(all))
+	for feature, spec := range all {
+		// Skip meta-features that only toggle other features.
+		if feature == allAlphaGate || feature == allBetaGate {
+			continue
+		}
+		enabled := gate.Enabled(feature)
+		ObserveFeatureEnabled(feature, spec, enabled)
+		states = append(states, fmt.Sprintf("%v=%v", feature, enabled))
+	}
+
+	sort.Strings(states)
+	klog.Infof("features: feature gates: %v", strings.Join(states, ","))
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package features_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/furiko-io/furiko/pkg/runtime/features"
+)
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		name       string
+		fromConfig map[string]bool
+		fromFlag   string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name: "default",
+			want: true,
+		},
+		{
+			name:       "disable from config",
+			fromConfig: map[string]bool{"ExternalTaskExecutor": false},
+			want:       false,
+		},
+		{
+			name:     "disable from flag",
+			fromFlag: "ExternalTaskExecutor=false",
+			want:     false,
+		},
+		{
+			name:       "flag takes precedence over config",
+			fromConfig: map[string]bool{"ExternalTaskExecutor": false},
+			fromFlag:   "ExternalTaskExecutor=true",
+			want:       true,
+		},
+		{
+			name:       "unknown feature in config",
+			fromConfig: map[string]bool{"UnknownFeature": true},
+			wantErr:    true,
+		},
+		{
+			name:     "invalid flag",
+			fromFlag: "ExternalTaskExecutor",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			gate := features.DefaultMutableFeatureGate.DeepCopy()
+			err := features.Configure(gate, tt.fromConfig, tt.fromFlag)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Configure() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, gate.Enabled(features.ExternalTaskExecutor))
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package features
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	promNamespace = "furiko"
+)
+
+var (
+	featureEnabled = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: promNamespace,
+			Name:      "feature_enabled",
+			Help:      "Whether a feature gate is enabled (1) or disabled (0)",
+		},
+		[]string{"name", "stage"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		featureEnabled,
+	)
+}
+
+func ObserveFeatureEnabled(feature featuregate.Feature, spec featuregate.FeatureSpec, enabled bool) {
+	stage := string(spec.PreRelease)
+	if spec.PreRelease == featuregate.GA {
+		stage = "GA"
+	}
+	var value float64
+	if enabled {
+		value = 1
+	}
+	featureEnabled.WithLabelValues(string(feature), stage).Set(value)
+}
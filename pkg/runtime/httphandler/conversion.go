@@ -0,0 +1,72 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httphandler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	executiongroup "github.com/furiko-io/furiko/apis/execution"
+	executionv1alpha1 "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	executionv1beta1 "github.com/furiko-io/furiko/apis/execution/v1beta1"
+)
+
+// conversionResources is the list of resources that have multiple served
+// versions and require conversion.
+var conversionResources = []string{
+	"jobs",
+	"jobconfigs",
+}
+
+// ServeConversionWebhooks prepares CRD conversion webhooks to be served. Each
+// CRD is served on its own path for clarity, although all of them share the
+// same handler.
+func ServeConversionWebhooks(mux *http.ServeMux) error {
+	handler, err := NewConversionHandler()
+	if err != nil {
+		return err
+	}
+	for _, resource := range conversionResources {
+		path := fmt.Sprintf("/convert/%v.%v", resource, executiongroup.GroupName)
+		mux.Handle(path, handler)
+		klog.V(4).Infof("httphandler: added http handler for conversion webhook at %v", path)
+	}
+	return nil
+}
+
+// NewConversionHandler returns a http.Handler that converts objects between all
+// versions of the execution API group.
+func NewConversionHandler() (http.Handler, error) {
+	scheme := runtime.NewScheme()
+	if err := executionv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrapf(err, "cannot add %v to scheme", executionv1alpha1.GroupVersion)
+	}
+	if err := executionv1beta1.AddToScheme(scheme); err != nil {
+		return nil, errors.Wrapf(err, "cannot add %v to scheme", executionv1beta1.GroupVersion)
+	}
+
+	handler := &conversion.Webhook{}
+	if err := handler.InjectScheme(scheme); err != nil {
+		return nil, errors.Wrapf(err, "cannot set up conversion webhook")
+	}
+	return handler, nil
+}
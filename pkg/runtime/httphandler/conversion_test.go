@@ -0,0 +1,283 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httphandler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	executionv1alpha1 "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	executionv1beta1 "github.com/furiko-io/furiko/apis/execution/v1beta1"
+	"github.com/furiko-io/furiko/pkg/runtime/httphandler"
+)
+
+var (
+	createTime = metav1.NewTime(time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC))
+	startTime  = metav1.NewTime(time.Date(2022, 3, 1, 10, 0, 5, 0, time.UTC))
+	finishTime = metav1.NewTime(time.Date(2022, 3, 1, 10, 1, 0, 0, time.UTC))
+
+	v1alpha1JobFinished = &executionv1alpha1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: executionv1alpha1.GroupVersion.String(),
+			Kind:       executionv1alpha1.KindJob,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: createTime,
+		},
+		Spec: executionv1alpha1.JobSpec{
+			Type:         executionv1alpha1.JobTypeAdhoc,
+			OptionValues: `{"dry_run":true}`,
+		},
+		Status: executionv1alpha1.JobStatus{
+			Phase: executionv1alpha1.JobSucceeded,
+			Condition: executionv1alpha1.JobCondition{
+				Finished: &executionv1alpha1.JobConditionFinished{
+					StartedAt:  &startTime,
+					FinishedAt: finishTime,
+					Result:     executionv1alpha1.JobResultSuccess,
+					Message:    "Job finished successfully",
+				},
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:               executionv1alpha1.JobConditionTypeProgressing,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: finishTime,
+					Reason:             string(executionv1alpha1.JobSucceeded),
+					Message:            "Job is finished",
+				},
+				{
+					Type:               executionv1alpha1.JobConditionTypeFinished,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: finishTime,
+					Reason:             string(executionv1alpha1.JobResultSuccess),
+					Message:            "Job finished successfully",
+				},
+			},
+			StartTime:    &startTime,
+			CreatedTasks: 1,
+		},
+	}
+
+	v1beta1JobFinished = &executionv1beta1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: executionv1beta1.GroupVersion.String(),
+			Kind:       executionv1beta1.KindJob,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: createTime,
+		},
+		Spec: executionv1beta1.JobSpec{
+			Type:         executionv1beta1.JobTypeAdhoc,
+			OptionValues: &runtime.RawExtension{Raw: []byte(`{"dry_run":true}`)},
+		},
+		Status: executionv1beta1.JobStatus{
+			Phase: executionv1beta1.JobSucceeded,
+			Conditions: []metav1.Condition{
+				{
+					Type:               executionv1beta1.JobConditionFinished,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: finishTime,
+					Reason:             string(executionv1beta1.JobResultSuccess),
+					Message:            "Job finished successfully",
+				},
+				{
+					Type:               executionv1beta1.JobConditionProgressing,
+					Status:             metav1.ConditionFalse,
+					LastTransitionTime: finishTime,
+					Reason:             string(executionv1beta1.JobSucceeded),
+					Message:            "Job is finished",
+				},
+			},
+			StartTime:    &startTime,
+			CreatedTasks: 1,
+		},
+	}
+)
+
+func TestConversionHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		object        runtime.Object
+		targetVersion string
+		want          runtime.Object
+		into          runtime.Object
+	}{
+		{
+			name:          "convert Job from v1alpha1 to v1beta1",
+			object:        v1alpha1JobFinished,
+			targetVersion: executionv1beta1.GroupVersion.String(),
+			want:          v1beta1JobFinished,
+			into:          &executionv1beta1.Job{},
+		},
+		{
+			name:          "convert Job from v1beta1 to v1alpha1",
+			object:        v1beta1JobFinished,
+			targetVersion: executionv1alpha1.GroupVersion.String(),
+			want:          v1alpha1JobFinished,
+			into:          &executionv1alpha1.Job{},
+		},
+		{
+			name: "convert Job with YAML optionValues from v1alpha1 to v1beta1",
+			object: &executionv1alpha1.Job{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: executionv1alpha1.GroupVersion.String(),
+					Kind:       executionv1alpha1.KindJob,
+				},
+				Spec: executionv1alpha1.JobSpec{
+					OptionValues: "dry_run: true",
+				},
+				Status: executionv1alpha1.JobStatus{
+					Condition: executionv1alpha1.JobCondition{
+						Queueing: &executionv1alpha1.JobConditionQueueing{},
+					},
+				},
+			},
+			targetVersion: executionv1beta1.GroupVersion.String(),
+			want: &executionv1beta1.Job{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: executionv1beta1.GroupVersion.String(),
+					Kind:       executionv1beta1.KindJob,
+				},
+				Spec: executionv1beta1.JobSpec{
+					OptionValues: &runtime.RawExtension{Raw: []byte(`{"dry_run":true}`)},
+				},
+				Status: executionv1beta1.JobStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   executionv1beta1.JobConditionQueueing,
+							Status: metav1.ConditionTrue,
+							Reason: executionv1beta1.JobConditionQueueing,
+						},
+					},
+				},
+			},
+			into: &executionv1beta1.Job{},
+		},
+		{
+			name: "convert JobConfig from v1alpha1 to v1beta1",
+			object: &executionv1alpha1.JobConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: executionv1alpha1.GroupVersion.String(),
+					Kind:       executionv1alpha1.KindJobConfig,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: executionv1alpha1.JobConfigSpec{
+					Concurrency: executionv1alpha1.ConcurrencySpec{
+						Policy: executionv1alpha1.ConcurrencyPolicyForbid,
+					},
+				},
+				Status: executionv1alpha1.JobConfigStatus{
+					State:  executionv1alpha1.JobConfigReady,
+					Queued: 1,
+				},
+			},
+			targetVersion: executionv1beta1.GroupVersion.String(),
+			want: &executionv1beta1.JobConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: executionv1beta1.GroupVersion.String(),
+					Kind:       executionv1beta1.KindJobConfig,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: executionv1beta1.JobConfigSpec{
+					Concurrency: executionv1beta1.ConcurrencySpec{
+						Policy: executionv1beta1.ConcurrencyPolicyForbid,
+					},
+				},
+				Status: executionv1beta1.JobConfigStatus{
+					State:  executionv1beta1.JobConfigReady,
+					Queued: 1,
+				},
+			},
+			into: &executionv1beta1.JobConfig{},
+		},
+	}
+
+	handler, err := httphandler.NewConversionHandler()
+	if err != nil {
+		t.Fatalf("cannot create conversion handler: %v", err)
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.object)
+			if err != nil {
+				t.Fatal(err)
+			}
+			review := &apiextensionsv1.ConversionReview{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+					Kind:       "ConversionReview",
+				},
+				Request: &apiextensionsv1.ConversionRequest{
+					UID:               uid,
+					DesiredAPIVersion: tt.targetVersion,
+					Objects:           []runtime.RawExtension{{Raw: raw}},
+				},
+			}
+			body, err := json.Marshal(review)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/convert", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			resp := &apiextensionsv1.ConversionReview{}
+			if err := json.NewDecoder(w.Body).Decode(resp); err != nil {
+				t.Fatalf("cannot decode response: %v", err)
+			}
+			if resp.Response == nil {
+				t.Fatalf("missing response")
+			}
+			if resp.Response.Result.Status != metav1.StatusSuccess {
+				t.Fatalf("conversion failed: %v", resp.Response.Result.Message)
+			}
+			if len(resp.Response.ConvertedObjects) != 1 {
+				t.Fatalf("expected 1 converted object, got %v", len(resp.Response.ConvertedObjects))
+			}
+			if err := json.Unmarshal(resp.Response.ConvertedObjects[0].Raw, tt.into); err != nil {
+				t.Fatalf("cannot decode converted object: %v", err)
+			}
+			if !cmp.Equal(tt.want, tt.into) {
+				t.Errorf("converted object not equal\ndiff = %v", cmp.Diff(tt.want, tt.into))
+			}
+		})
+	}
+}
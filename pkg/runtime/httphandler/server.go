@@ -18,6 +18,7 @@ package httphandler
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"time"
 
@@ -41,11 +42,20 @@ var (
 type Manager interface {
 	GetReadiness() error
 	GetHealth() []controllermanager.HealthStatus
+	GetDebugStatus() (*controllermanager.DebugStatus, error)
+}
+
+// Handler is an additional HTTP handler that is served by ListenAndServe.
+type Handler interface {
+	http.Handler
+
+	// Pattern returns the pattern that the handler should be registered with.
+	Pattern() string
 }
 
 // ListenAndServe listens on the given TCP address and gracefully stops when the
 // given context is canceled, setting up all HTTP handlers.
-func ListenAndServe(ctx context.Context, config *configv1alpha1.HTTPSpec, mgr Manager) error {
+func ListenAndServe(ctx context.Context, config *configv1alpha1.HTTPSpec, mgr Manager, handlers ...Handler) error {
 	if config == nil {
 		config = defaultHTTPConfig
 	}
@@ -62,15 +72,25 @@ func ListenAndServe(ctx context.Context, config *configv1alpha1.HTTPSpec, mgr Ma
 
 	ServeMetrics(mux, config.Metrics)
 	ServeHealth(mux, config.Health, mgr)
-	return listenAndServe(ctx, addr, server)
+	ServeDebug(mux, config.Debug, mgr)
+	for _, handler := range handlers {
+		mux.Handle(handler.Pattern(), handler)
+		klog.V(4).Infof("httphandler: added http handler for %v", handler.Pattern())
+	}
+	return listenAndServe(ctx, addr, server, nil)
 }
 
 // ListenAndServeWebhooks listens on the given TCP address and gracefully stops when the
-// given context is canceled, setting up all webhooks handlers.
+// given context is canceled, setting up all webhooks handlers. If getCertificate
+// is not nil, it is used to load the serving certificate instead of the
+// certificate files specified in the config. If onServing is not nil, it is
+// called once the server is listening and about to serve requests.
 func ListenAndServeWebhooks(
 	ctx context.Context,
 	config *configv1alpha1.WebhookServerSpec,
 	webhooks []controllermanager.Webhook,
+	getCertificate GetCertificateFunc,
+	onServing func(),
 ) error {
 	if config == nil {
 		config = defaultWebhooksConfig
@@ -81,21 +101,33 @@ func ListenAndServeWebhooks(
 	}
 
 	mux := http.NewServeMux()
-	server := newTLSServer(&http.Server{
+	httpServer := &http.Server{
 		Addr:    addr,
 		Handler: mux,
-	}, config.TLSCertFile, config.TLSPrivateKeyFile)
+	}
+	server := newTLSServer(httpServer, config.TLSCertFile, config.TLSPrivateKeyFile)
+	if getCertificate != nil {
+		server = newDynamicTLSServer(httpServer, getCertificate)
+	}
 
 	ServeWebhooks(mux, webhooks)
-	return listenAndServe(ctx, addr, server)
+	if err := ServeConversionWebhooks(mux); err != nil {
+		return errors.Wrapf(err, "cannot set up conversion webhooks")
+	}
+	return listenAndServe(ctx, addr, server, onServing)
 }
 
 type Server interface {
-	ListenAndServe() error
+	Serve(l net.Listener) error
 	Shutdown(context.Context) error
 }
 
-func listenAndServe(ctx context.Context, addr string, server Server) error {
+func listenAndServe(ctx context.Context, addr string, server Server, onServing func()) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
 	go func() {
 		<-ctx.Done()
 		klog.Infof("httphandler: shutting down http server on %v", addr)
@@ -108,7 +140,10 @@ func listenAndServe(ctx context.Context, addr string, server Server) error {
 	}()
 
 	klog.Infof("httphandler: http server listening on %v", addr)
-	if err := server.ListenAndServe(); errors.Is(err, http.ErrServerClosed) {
+	if onServing != nil {
+		onServing()
+	}
+	if err := server.Serve(listener); errors.Is(err, http.ErrServerClosed) {
 		return nil
 	} else if err != nil {
 		return err
@@ -17,12 +17,18 @@
 package httphandler
 
 import (
+	"crypto/tls"
+	"net"
 	"net/http"
 
 	"github.com/pkg/errors"
 )
 
-// tlsServer is a wrapper around *http.Server that overrides the ListenAndServe
+// GetCertificateFunc returns a certificate for a TLS handshake. It has the
+// same signature as tls.Config.GetCertificate.
+type GetCertificateFunc func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+
+// tlsServer is a wrapper around *http.Server that overrides the Serve
 // implementation for TLS.
 type tlsServer struct {
 	*http.Server
@@ -30,6 +36,19 @@ type tlsServer struct {
 	keyFile  string
 }
 
+// newDynamicTLSServer returns a tlsServer that loads its certificate using
+// getCertificate on every TLS handshake, which allows certificates to be
+// rotated without restarting the server.
+func newDynamicTLSServer(server *http.Server, getCertificate GetCertificateFunc) *tlsServer {
+	server.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: getCertificate,
+	}
+	return &tlsServer{
+		Server: server,
+	}
+}
+
 func newTLSServer(server *http.Server, certFile, keyFile string) *tlsServer {
 	return &tlsServer{
 		Server:   server,
@@ -40,12 +59,15 @@ func newTLSServer(server *http.Server, certFile, keyFile string) *tlsServer {
 
 var _ Server = (*tlsServer)(nil)
 
-func (s *tlsServer) ListenAndServe() error {
+func (s *tlsServer) Serve(l net.Listener) error {
+	if s.TLSConfig != nil && s.TLSConfig.GetCertificate != nil {
+		return s.ServeTLS(l, "", "")
+	}
 	if s.certFile == "" {
 		return errors.New("certFile must be specified")
 	}
 	if s.keyFile == "" {
 		return errors.New("keyFile must be specified")
 	}
-	return s.ListenAndServeTLS(s.certFile, s.keyFile)
+	return s.ServeTLS(l, s.certFile, s.keyFile)
 }
@@ -36,7 +36,6 @@ import (
 )
 
 // ServeWebhooks prepares admission webhooks to be served.
-// TODO(irvinlim): Handle conversion webhooks here in the future too.
 func ServeWebhooks(mux *http.ServeMux, webhooks []controllermanager.Webhook) {
 	for _, webhook := range webhooks {
 		mux.HandleFunc(webhook.Path(), HandleAdmissionWebhook(webhook))
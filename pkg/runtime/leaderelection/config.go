@@ -19,6 +19,8 @@ package leaderelection
 import (
 	"time"
 
+	"k8s.io/client-go/tools/leaderelection"
+
 	timeutil "github.com/furiko-io/furiko/pkg/utils/time"
 )
 
@@ -44,10 +46,23 @@ func (c *Config) PrepareValues() *Config {
 	if cfg == nil {
 		cfg = &Config{}
 	}
+
+	// If only a shorter lease duration is specified, scale down the defaults for
+	// the renew deadline and retry period such that they remain valid.
+	leaseDuration := durationDefaulting(cfg.LeaseDuration, DefaultConfig.LeaseDuration)
+	renewDeadline := durationDefaulting(cfg.RenewDeadline, DefaultConfig.RenewDeadline)
+	if cfg.RenewDeadline <= 0 && renewDeadline >= leaseDuration {
+		renewDeadline = leaseDuration / 2
+	}
+	retryPeriod := durationDefaulting(cfg.RetryPeriod, DefaultConfig.RetryPeriod)
+	if cfg.RetryPeriod <= 0 && float64(retryPeriod)*leaderelection.JitterFactor >= float64(renewDeadline) {
+		retryPeriod = renewDeadline / 3
+	}
+
 	return &Config{
-		LeaseDuration:  durationDefaulting(cfg.LeaseDuration, DefaultConfig.LeaseDuration),
-		RenewDeadline:  durationDefaulting(cfg.RenewDeadline, DefaultConfig.RenewDeadline),
-		RetryPeriod:    durationDefaulting(cfg.RetryPeriod, DefaultConfig.RetryPeriod),
+		LeaseDuration:  leaseDuration,
+		RenewDeadline:  renewDeadline,
+		RetryPeriod:    retryPeriod,
 		LeaseName:      cfg.LeaseName, // no defaults provided
 		LeaseNamespace: stringDefaulting(cfg.LeaseNamespace, DefaultConfig.LeaseNamespace),
 	}
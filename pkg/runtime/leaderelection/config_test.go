@@ -76,6 +76,31 @@ func TestConfig_Prepare(t *testing.T) {
 				RetryPeriod:    leaderelection.DefaultConfig.RetryPeriod,
 			},
 		},
+		{
+			name: "scale down defaults for short lease duration",
+			cfg: &leaderelection.Config{
+				LeaseDuration: time.Second * 10,
+			},
+			expected: &leaderelection.Config{
+				LeaseNamespace: leaderelection.DefaultConfig.LeaseNamespace,
+				LeaseDuration:  time.Second * 10,
+				RenewDeadline:  time.Second * 5,
+				RetryPeriod:    time.Second * 5 / 3,
+			},
+		},
+		{
+			name: "do not scale down explicit values",
+			cfg: &leaderelection.Config{
+				LeaseDuration: time.Second * 10,
+				RenewDeadline: time.Second * 8,
+			},
+			expected: &leaderelection.Config{
+				LeaseNamespace: leaderelection.DefaultConfig.LeaseNamespace,
+				LeaseDuration:  time.Second * 10,
+				RenewDeadline:  time.Second * 8,
+				RetryPeriod:    leaderelection.DefaultConfig.RetryPeriod,
+			},
+		},
 		{
 			name: "do not override any value",
 			cfg: &leaderelection.Config{
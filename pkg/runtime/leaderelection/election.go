@@ -140,9 +140,12 @@ func (c *electionCoordinator) GetLeaseID() string {
 
 // Wait starts the election and blocks until elected as leader.
 func (c *electionCoordinator) Wait(ctx context.Context) error {
-	// Create subcontext.
-	ctx, cancel := context.WithCancel(ctx)
-	c.electionLoopCtx = ctx
+	// The election loop is not derived from ctx, so that the lease continues to
+	// be renewed after ctx is canceled (e.g. on SIGTERM) until GiveUp is called.
+	// This allows the lease to be released only after all controllers have been
+	// shut down, so that another candidate can take over immediately.
+	electionCtx, cancel := context.WithCancel(context.Background())
+	c.electionLoopCtx = electionCtx
 	c.cancelElection = cancel
 
 	klog.InfoS("leaderelection: starting election", "lease", c.name, "lease_id", c.id)
@@ -153,7 +156,7 @@ func (c *electionCoordinator) Wait(ctx context.Context) error {
 		defer c.electionLoopWg.Done()
 
 		// Blocks until context is canceled or lease is lost.
-		c.elector.Run(ctx)
+		c.elector.Run(electionCtx)
 		klog.V(4).InfoS("leaderelection: election loop done", "lease", c.name, "lease_id", c.id)
 	}()
 
@@ -168,7 +171,9 @@ func (c *electionCoordinator) Wait(ctx context.Context) error {
 		klog.ErrorS(ctx.Err(), "leaderelection: cancelling participation in election",
 			"lease", c.name, "lease_id", c.id)
 
-		// Wait for election loop to be canceled before returning
+		// Stop participating in the election, and wait for election loop to be
+		// canceled before returning.
+		cancel()
 		c.electionLoopWg.Wait()
 		return ctx.Err()
 	}
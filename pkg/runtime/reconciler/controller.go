@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -37,16 +38,32 @@ type Reconciler interface {
 	SyncOne(ctx context.Context, namespace, name string, numRequeues int) error
 }
 
+const (
+	defaultConcurrencyResyncInterval = 30 * time.Second
+)
+
 // Controller is a reconciler controller template that handles concurrency,
 // workqueue and retries with a Reconciler handler.
 type Controller struct {
+	// Unix nanoseconds of the last successful sync, accessed atomically.
+	lastSuccessfulSync int64
+
 	handler Reconciler
 	queue   workqueue.RateLimitingInterface
 	wg      sync.WaitGroup
 
+	// Contains a stop function for each running worker.
+	mu      sync.Mutex
+	workers []context.CancelFunc
+
 	// SplitMetaNamespaceKey is the function used to split a key into namespace and name.
 	// Defaults to cache.SplitMetaNamespaceKey.
 	SplitMetaNamespaceKey func(string) (string, string, error)
+
+	// ConcurrencyResyncInterval is the interval at which the Reconciler's
+	// concurrency is checked for changes, after which workers will be started or
+	// stopped accordingly.
+	ConcurrencyResyncInterval time.Duration
 }
 
 // NewController creates a Controller that uses the given Reconciler to handle
@@ -56,23 +73,104 @@ func NewController(handler Reconciler, queue workqueue.RateLimitingInterface) *C
 		handler: handler,
 		queue:   queue,
 
-		SplitMetaNamespaceKey: cache.SplitMetaNamespaceKey,
+		SplitMetaNamespaceKey:     cache.SplitMetaNamespaceKey,
+		ConcurrencyResyncInterval: defaultConcurrencyResyncInterval,
 	}
 }
 
 func (w *Controller) Start(ctx context.Context) {
+	// Start workers in the background.
+	w.scaleWorkers(ctx)
+
+	// Periodically scale workers in the background if the concurrency changes.
+	go wait.UntilWithContext(ctx, w.scaleWorkers, w.ConcurrencyResyncInterval)
+}
+
+// scaleWorkers starts or stops workers until the number of running workers
+// matches the Reconciler's concurrency. Stopped workers will exit once they
+// are done with their current or next item from the workqueue.
+func (w *Controller) scaleWorkers(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Do not start new workers once the workqueue is shutting down.
+	if w.queue.ShuttingDown() {
+		return
+	}
+
 	concurrency := w.handler.Concurrency()
-	w.wg.Add(concurrency)
+	current := len(w.workers)
+	if concurrency == current {
+		return
+	}
 
-	// Start workers in the background.
-	for i := 0; i < concurrency; i++ {
+	for len(w.workers) < concurrency {
+		workerCtx, cancel := context.WithCancel(ctx)
+		w.workers = append(w.workers, cancel)
+		w.wg.Add(1)
 		go func() {
 			defer w.wg.Done()
-			wait.UntilWithContext(ctx, w.worker, time.Second)
+			wait.UntilWithContext(workerCtx, func(workerCtx context.Context) {
+				w.worker(ctx, workerCtx)
+
+				// Exit the worker once the workqueue is shut down.
+				if w.queue.ShuttingDown() {
+					cancel()
+				}
+			}, time.Second)
 		}()
 	}
 
-	ObserveWorkersTotal(w.handler.Name(), w.handler.Concurrency())
+	for len(w.workers) > concurrency {
+		last := len(w.workers) - 1
+		w.workers[last]()
+		w.workers = w.workers[:last]
+	}
+
+	if current > 0 {
+		klog.InfoS("reconciler: scaled workers", "worker", w.handler.Name(), "from", current, "to", concurrency)
+	}
+	ObserveWorkersTotal(w.handler.Name(), concurrency)
+}
+
+// QueueDepth returns the number of items waiting in the workqueue.
+func (w *Controller) QueueDepth() int {
+	return w.queue.Len()
+}
+
+// LastSuccessfulSync returns the time of the last successful sync, or nil if no
+// item has been successfully synced yet.
+func (w *Controller) LastSuccessfulSync() *time.Time {
+	nanos := atomic.LoadInt64(&w.lastSuccessfulSync)
+	if nanos == 0 {
+		return nil
+	}
+	ts := time.Unix(0, nanos)
+	return &ts
+}
+
+// Drain shuts down the workqueue such that no new items are accepted or picked
+// up by workers, and blocks until all in-flight syncs are complete. If the
+// context is canceled before all syncs are complete, the context's error is
+// returned.
+func (w *Controller) Drain(ctx context.Context) error {
+	// Hold the lock to prevent new workers from being started concurrently.
+	w.mu.Lock()
+	w.queue.ShutDown()
+	w.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // Wait until all reconciler workers have exited. They should exit once they are
@@ -82,9 +180,12 @@ func (w *Controller) Wait() {
 	w.wg.Wait()
 }
 
-func (w *Controller) worker(ctx context.Context) {
+// worker performs work until the workqueue is shut down, or until workerCtx is
+// canceled. The parent context is used for syncs, such that stopping a worker
+// does not interrupt an in-flight sync.
+func (w *Controller) worker(ctx, workerCtx context.Context) {
 	// Perform work until told to quit.
-	for w.work(ctx) {
+	for workerCtx.Err() == nil && w.work(ctx) {
 	}
 }
 
@@ -98,6 +199,12 @@ func (w *Controller) work(ctx context.Context) bool {
 	// Call Done so that processing can take place for the key again after return.
 	defer w.queue.Done(item)
 
+	// Do not pick up any remaining items once the workqueue is shutting down, only
+	// in-flight syncs are drained.
+	if w.queue.ShuttingDown() {
+		return false
+	}
+
 	// Process a single item from the workqueue.
 	err := w.syncItem(ctx, item)
 
@@ -133,6 +240,7 @@ func (w *Controller) syncItem(ctx context.Context, item interface{}) error {
 
 	// Ignore errors when it is created successfully.
 	if err == nil {
+		atomic.StoreInt64(&w.lastSuccessfulSync, time.Now().UnixNano())
 		return nil
 	}
 
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package reconciler_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/furiko-io/furiko/pkg/runtime/reconciler"
+)
+
+type mockReconciler struct {
+	syncDuration time.Duration
+	started      chan struct{}
+	synced       uint64
+	canceled     uint64
+}
+
+var _ reconciler.Reconciler = (*mockReconciler)(nil)
+
+func newMockReconciler(syncDuration time.Duration) *mockReconciler {
+	return &mockReconciler{
+		syncDuration: syncDuration,
+		started:      make(chan struct{}, 10),
+	}
+}
+
+func (r *mockReconciler) Name() string {
+	return "MockReconciler"
+}
+
+func (r *mockReconciler) Concurrency() int {
+	return 1
+}
+
+func (r *mockReconciler) MaxRequeues() int {
+	return 0
+}
+
+func (r *mockReconciler) SyncOne(ctx context.Context, _, _ string, _ int) error {
+	r.started <- struct{}{}
+	select {
+	case <-ctx.Done():
+		atomic.AddUint64(&r.canceled, 1)
+		return ctx.Err()
+	case <-time.After(r.syncDuration):
+	}
+	atomic.AddUint64(&r.synced, 1)
+	return nil
+}
+
+func TestController_Drain(t *testing.T) {
+	tests := []struct {
+		name         string
+		syncDuration time.Duration
+		drainTimeout time.Duration
+		wantErr      bool
+		wantSynced   uint64
+		wantCanceled uint64
+	}{
+		{
+			name:         "drain in-flight sync",
+			syncDuration: time.Millisecond * 50,
+			drainTimeout: time.Second,
+			wantSynced:   1,
+		},
+		{
+			name:         "cancel in-flight sync after drain timeout",
+			syncDuration: time.Second * 5,
+			drainTimeout: time.Millisecond * 50,
+			wantErr:      true,
+			wantCanceled: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			recon := newMockReconciler(tt.syncDuration)
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			ctrl := reconciler.NewController(recon, queue)
+			ctrl.Start(ctx)
+
+			// Wait for the first item to be in-flight, then enqueue another item that
+			// should not be processed.
+			queue.Add("default/item1")
+			<-recon.started
+			queue.Add("default/item2")
+
+			drainCtx, drainCancel := context.WithTimeout(context.Background(), tt.drainTimeout)
+			defer drainCancel()
+			if err := ctrl.Drain(drainCtx); (err != nil) != tt.wantErr {
+				t.Errorf("Drain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// Cancel remaining syncs.
+			cancel()
+			ctrl.Wait()
+
+			assert.Equal(t, tt.wantSynced, atomic.LoadUint64(&recon.synced))
+			assert.Equal(t, tt.wantCanceled, atomic.LoadUint64(&recon.canceled))
+			assert.Equal(t, tt.wantSynced > 0, ctrl.LastSuccessfulSync() != nil)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package statsd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+)
+
+const (
+	FlavorStatsD    = "statsd"
+	FlavorDogStatsD = "dogstatsd"
+
+	defaultPrefix   = "furiko."
+	defaultInterval = 10 * time.Second
+
+	// Maximum size of a single UDP packet, chosen to avoid fragmentation on most
+	// networks.
+	maxPacketSize = 1432
+)
+
+// Exporter periodically gathers metrics from a Prometheus registry and sends
+// them to a StatsD agent. Gauges and untyped metrics are sent as gauges, while
+// counters, histograms and summaries are sent as counters of their increase
+// since the previous export.
+type Exporter struct {
+	gatherer prometheus.Gatherer
+	conn     net.Conn
+	flavor   string
+	prefix   string
+	tags     []string
+	interval time.Duration
+
+	mu       sync.Mutex
+	counters map[string]float64
+}
+
+// NewExporter returns a new Exporter which sends metrics from the gatherer to
+// the configured StatsD agent.
+func NewExporter(cfg *configv1alpha1.StatsDSpec, gatherer prometheus.Gatherer) (*Exporter, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("address must be specified")
+	}
+
+	e := &Exporter{
+		gatherer: gatherer,
+		flavor:   cfg.Flavor,
+		prefix:   defaultPrefix,
+		interval: defaultInterval,
+		counters: make(map[string]float64),
+	}
+	switch e.flavor {
+	case "":
+		e.flavor = FlavorDogStatsD
+	case FlavorStatsD, FlavorDogStatsD:
+	default:
+		return nil, fmt.Errorf("unsupported flavor: %v", cfg.Flavor)
+	}
+	if cfg.Prefix != nil {
+		e.prefix = *cfg.Prefix
+	}
+	if cfg.IntervalSeconds > 0 {
+		e.interval = time.Duration(cfg.IntervalSeconds) * time.Second
+	}
+	for key, value := range cfg.Tags {
+		e.tags = append(e.tags, formatTag(key, value))
+	}
+	sort.Strings(e.tags)
+
+	conn, err := net.Dial("udp", cfg.Address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot dial %v", cfg.Address)
+	}
+	e.conn = conn
+
+	return e, nil
+}
+
+// Start exports metrics periodically in the background until the context is
+// canceled.
+func (e *Exporter) Start(ctx context.Context) {
+	go func() {
+		defer e.conn.Close()
+		wait.Until(func() {
+			if err := e.Export(); err != nil {
+				klog.ErrorS(err, "statsd: cannot export metrics")
+			}
+		}, e.interval, ctx.Done())
+	}()
+}
+
+// Export gathers all metrics and sends them to the StatsD agent.
+func (e *Exporter) Export() error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return errors.Wrapf(err, "cannot gather metrics")
+	}
+
+	e.mu.Lock()
+	lines := e.format(families)
+	e.mu.Unlock()
+
+	return e.send(lines)
+}
+
+// format returns the StatsD lines for all metrics in the families.
+func (e *Exporter) format(families []*dto.MetricFamily) []string {
+	var lines []string
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			switch family.GetType() {
+			case dto.MetricType_GAUGE:
+				lines = append(lines, e.gauge(family.GetName(), metric.GetLabel(), metric.GetGauge().GetValue()))
+			case dto.MetricType_UNTYPED:
+				lines = append(lines, e.gauge(family.GetName(), metric.GetLabel(), metric.GetUntyped().GetValue()))
+			case dto.MetricType_COUNTER:
+				lines = e.appendCounter(lines, family.GetName(), metric.GetLabel(), metric.GetCounter().GetValue())
+			case dto.MetricType_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				lines = e.appendCounter(lines, family.GetName()+"_count", metric.GetLabel(),
+					float64(histogram.GetSampleCount()))
+				lines = e.appendCounter(lines, family.GetName()+"_sum", metric.GetLabel(), histogram.GetSampleSum())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				lines = e.appendCounter(lines, family.GetName()+"_count", metric.GetLabel(),
+					float64(summary.GetSampleCount()))
+				lines = e.appendCounter(lines, family.GetName()+"_sum", metric.GetLabel(), summary.GetSampleSum())
+			}
+		}
+	}
+	return lines
+}
+
+func (e *Exporter) gauge(name string, labels []*dto.LabelPair, value float64) string {
+	return e.line(name, labels, value, "g")
+}
+
+// appendCounter appends a line for the increase of the counter since the
+// previous export, if it has increased. Counters which decreased are assumed to
+// have been reset.
+func (e *Exporter) appendCounter(lines []string, name string, labels []*dto.LabelPair, value float64) []string {
+	key := seriesKey(name, labels)
+	delta := value - e.counters[key]
+	if delta < 0 {
+		delta = value
+	}
+	e.counters[key] = value
+	if delta == 0 {
+		return lines
+	}
+	return append(lines, e.line(name, labels, delta, "c"))
+}
+
+func (e *Exporter) line(name string, labels []*dto.LabelPair, value float64, metricType string) string {
+	var sb strings.Builder
+	sb.WriteString(sanitize(e.prefix + name))
+	if e.flavor == FlavorStatsD {
+		for _, label := range labels {
+			sb.WriteString(".")
+			sb.WriteString(strings.ReplaceAll(sanitize(label.GetValue()), ".", "_"))
+		}
+	}
+	sb.WriteString(":")
+	sb.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+	sb.WriteString("|")
+	sb.WriteString(metricType)
+
+	if e.flavor == FlavorDogStatsD {
+		tags := make([]string, 0, len(e.tags)+len(labels))
+		tags = append(tags, e.tags...)
+		for _, label := range labels {
+			tags = append(tags, formatTag(label.GetName(), label.GetValue()))
+		}
+		if len(tags) > 0 {
+			sb.WriteString("|#")
+			sb.WriteString(strings.Join(tags, ","))
+		}
+	}
+	return sb.String()
+}
+
+// send writes the lines to the connection, batching them into packets of up to
+// maxPacketSize bytes.
+func (e *Exporter) send(lines []string) error {
+	var packet []byte
+	flush := func() error {
+		if len(packet) == 0 {
+			return nil
+		}
+		_, err := e.conn.Write(packet)
+		packet = packet[:0]
+		return err
+	}
+	for _, line := range lines {
+		if len(packet) > 0 && len(packet)+1+len(line) > maxPacketSize {
+			if err := flush(); err != nil {
+				return errors.Wrapf(err, "cannot send metrics")
+			}
+		}
+		if len(packet) > 0 {
+			packet = append(packet, '\n')
+		}
+		packet = append(packet, line...)
+	}
+	if err := flush(); err != nil {
+		return errors.Wrapf(err, "cannot send metrics")
+	}
+	return nil
+}
+
+func seriesKey(name string, labels []*dto.LabelPair) string {
+	parts := make([]string, 0, len(labels)+1)
+	parts = append(parts, name)
+	for _, label := range labels {
+		parts = append(parts, label.GetName()+"="+label.GetValue())
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatTag(key, value string) string {
+	return sanitize(key) + ":" + sanitize(value)
+}
+
+// sanitize replaces characters which are reserved by the StatsD protocol.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '|', '@', '#', ',', '\n':
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2022 The Furiko Authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package statsd_test
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	configv1alpha1 "github.com/furiko-io/furiko/apis/config/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/statsd"
+)
+
+func newRegistry() (*prometheus.Registry, *prometheus.CounterVec, prometheus.Gauge, prometheus.Histogram) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobs_created_total",
+	}, []string{"namespace"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "queue_depth",
+	})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "run_duration_seconds",
+		Buckets: []float64{1, 10},
+	})
+	registry.MustRegister(counter, gauge, histogram)
+	return registry, counter, gauge, histogram
+}
+
+// readLines reads all lines from UDP packets received within a short duration.
+func readLines(t *testing.T, conn net.PacketConn) []string {
+	var lines []string
+	buf := make([]byte, 65536)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond)); err != nil {
+			t.Fatalf("cannot set deadline: %v", err)
+		}
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+		lines = append(lines, strings.Split(string(buf[:n]), "\n")...)
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func TestExporter(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        configv1alpha1.StatsDSpec
+		wantFirst  []string
+		wantSecond []string
+	}{
+		{
+			name: "dogstatsd",
+			cfg: configv1alpha1.StatsDSpec{
+				Tags: map[string]string{"env": "prod", "cluster": "c1"},
+			},
+			wantFirst: []string{
+				"furiko.jobs_created_total:2|c|#cluster:c1,env:prod,namespace:default",
+				"furiko.jobs_created_total:3|c|#cluster:c1,env:prod,namespace:test",
+				"furiko.queue_depth:5|g|#cluster:c1,env:prod",
+				"furiko.run_duration_seconds_count:1|c|#cluster:c1,env:prod",
+				"furiko.run_duration_seconds_sum:2.5|c|#cluster:c1,env:prod",
+			},
+			wantSecond: []string{
+				"furiko.jobs_created_total:1|c|#cluster:c1,env:prod,namespace:test",
+				"furiko.queue_depth:4|g|#cluster:c1,env:prod",
+			},
+		},
+		{
+			name: "statsd",
+			cfg: configv1alpha1.StatsDSpec{
+				Flavor: statsd.FlavorStatsD,
+				Prefix: pointer.String("batch."),
+			},
+			wantFirst: []string{
+				"batch.jobs_created_total.default:2|c",
+				"batch.jobs_created_total.test:3|c",
+				"batch.queue_depth:5|g",
+				"batch.run_duration_seconds_count:1|c",
+				"batch.run_duration_seconds_sum:2.5|c",
+			},
+			wantSecond: []string{
+				"batch.jobs_created_total.test:1|c",
+				"batch.queue_depth:4|g",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("cannot listen: %v", err)
+			}
+			defer conn.Close()
+
+			registry, counter, gauge, histogram := newRegistry()
+			cfg := tt.cfg
+			cfg.Address = conn.LocalAddr().String()
+			exporter, err := statsd.NewExporter(&cfg, registry)
+			if err != nil {
+				t.Fatalf("cannot create exporter: %v", err)
+			}
+
+			counter.WithLabelValues("test").Add(3)
+			counter.WithLabelValues("default").Add(2)
+			gauge.Set(5)
+			histogram.Observe(2.5)
+			assert.NoError(t, exporter.Export())
+			if got := readLines(t, conn); !cmp.Equal(tt.wantFirst, got) {
+				t.Errorf("Export() lines not equal\ndiff = %v", cmp.Diff(tt.wantFirst, got))
+			}
+
+			// Only increases of counters should be sent.
+			counter.WithLabelValues("test").Inc()
+			gauge.Set(4)
+			assert.NoError(t, exporter.Export())
+			if got := readLines(t, conn); !cmp.Equal(tt.wantSecond, got) {
+				t.Errorf("Export() lines not equal\ndiff = %v", cmp.Diff(tt.wantSecond, got))
+			}
+		})
+	}
+}
+
+func TestNewExporter_Invalid(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	_, err := statsd.NewExporter(&configv1alpha1.StatsDSpec{}, registry)
+	assert.Error(t, err)
+	_, err = statsd.NewExporter(&configv1alpha1.StatsDSpec{Address: "127.0.0.1:8125", Flavor: "graphite"}, registry)
+	assert.Error(t, err)
+}
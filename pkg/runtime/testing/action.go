@@ -24,6 +24,7 @@ import (
 
 	executiongroup "github.com/furiko-io/furiko/apis/execution"
 	execution "github.com/furiko-io/furiko/apis/execution/v1alpha1"
+	"github.com/furiko-io/furiko/pkg/runtime/controllerutil"
 )
 
 var (
@@ -37,13 +38,14 @@ var (
 	resourcePod       = NewGroupVersionResource("core", "v1", "pods")
 	resourceJob       = NewGroupVersionResource(executiongroup.GroupName, execution.Version, "jobs")
 	resourceJobConfig = NewGroupVersionResource(executiongroup.GroupName, execution.Version, "jobconfigs")
+	resourceJobGroup  = NewGroupVersionResource(executiongroup.GroupName, execution.Version, "jobgroups")
 )
 
 // Action describes a single expected action to be taken.
 type Action struct {
 	ktesting.Action
 
-	// If true, will not check the given object for equality.
+	// If true, will not check the given object or patch for equality.
 	IgnoreObject bool
 }
 
@@ -114,6 +116,21 @@ func NewPatchAction(
 	return WrapAction(ktesting.NewPatchAction(resource, namespace, name, pt, patch))
 }
 
+// NewApplyStatusAction returns an Action that applies the given status using
+// server-side apply.
+func NewApplyStatusAction(
+	resource schema.GroupVersionResource,
+	gvk schema.GroupVersionKind,
+	namespace, name string,
+	status interface{},
+) Action {
+	patch, err := controllerutil.NewStatusApplyPatch(gvk, namespace, name, status)
+	if err != nil {
+		panic(err) // panic ok for tests
+	}
+	return WrapAction(ktesting.NewPatchSubresourceAction(resource, namespace, name, types.ApplyPatchType, patch, "status"))
+}
+
 func NewDeleteAction(resource schema.GroupVersionResource, namespace, name string) Action {
 	return WrapAction(ktesting.NewDeleteAction(resource, namespace, name))
 }
@@ -150,6 +167,10 @@ func NewUpdateJobStatusAction(namespace string, object runtime.Object) Action {
 	return WrapAction(ktesting.NewUpdateSubresourceAction(resourceJob, "status", namespace, object))
 }
 
+func NewApplyJobStatusAction(namespace, name string, status interface{}) Action {
+	return NewApplyStatusAction(resourceJob, execution.GVKJob, namespace, name, status)
+}
+
 func NewPatchJobAction(namespace, name string, pt types.PatchType, patch []byte) Action {
 	return WrapAction(ktesting.NewPatchAction(resourceJob, namespace, name, pt, patch))
 }
@@ -170,6 +191,10 @@ func NewUpdateJobConfigStatusAction(namespace string, object runtime.Object) Act
 	return WrapAction(ktesting.NewUpdateSubresourceAction(resourceJobConfig, "status", namespace, object))
 }
 
+func NewApplyJobConfigStatusAction(namespace, name string, status interface{}) Action {
+	return NewApplyStatusAction(resourceJobConfig, execution.GVKJobConfig, namespace, name, status)
+}
+
 func NewPatchJobConfigAction(namespace, name string, pt types.PatchType, patch []byte) Action {
 	return WrapAction(ktesting.NewPatchAction(resourceJobConfig, namespace, name, pt, patch))
 }
@@ -177,3 +202,11 @@ func NewPatchJobConfigAction(namespace, name string, pt types.PatchType, patch [
 func NewDeleteJobConfigAction(namespace, name string) Action {
 	return WrapAction(ktesting.NewDeleteAction(resourceJobConfig, namespace, name))
 }
+
+func NewApplyJobGroupStatusAction(namespace, name string, status interface{}) Action {
+	return NewApplyStatusAction(resourceJobGroup, execution.GVKJobGroup, namespace, name, status)
+}
+
+func NewDeleteJobGroupAction(namespace, name string) Action {
+	return WrapAction(ktesting.NewDeleteAction(resourceJobGroup, namespace, name))
+}
@@ -295,10 +295,14 @@ func (r *ReconcilerTest) initializeFixture(
 	switch f := fixture.(type) {
 	case *corev1.Pod:
 		_, err = client.Kubernetes().CoreV1().Pods(f.Namespace).Create(ctx, f, metav1.CreateOptions{})
+	case *corev1.Secret:
+		_, err = client.Kubernetes().CoreV1().Secrets(f.Namespace).Create(ctx, f, metav1.CreateOptions{})
 	case *execution.Job:
 		_, err = client.Furiko().ExecutionV1alpha1().Jobs(f.Namespace).Create(ctx, f, metav1.CreateOptions{})
 	case *execution.JobConfig:
 		_, err = client.Furiko().ExecutionV1alpha1().JobConfigs(f.Namespace).Create(ctx, f, metav1.CreateOptions{})
+	case *execution.JobGroup:
+		_, err = client.Furiko().ExecutionV1alpha1().JobGroups(f.Namespace).Create(ctx, f, metav1.CreateOptions{})
 	}
 	return err
 }
@@ -102,7 +102,7 @@ func CompareAction(want Action, got ktesting.Action) error {
 	}
 
 	// Compare by PatchGetter.
-	if wantObj, ok := want.Action.(PatchGetter); ok {
+	if wantObj, ok := want.Action.(PatchGetter); ok && !want.IgnoreObject {
 		if gotObj, ok := got.(PatchGetter); ok {
 			if err := ComparePatches(wantObj, gotObj); err != nil {
 				return err
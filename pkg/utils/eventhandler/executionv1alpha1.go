@@ -51,3 +51,18 @@ func Executionv1alpha1Job(obj interface{}) (*executionv1alpha1.Job, error) {
 
 	return nil, NewUnexpectedTypeError(&executionv1alpha1.Job{}, obj)
 }
+
+// Executionv1alpha1ExternalTask casts obj into *executionv1alpha1.ExternalTask.
+func Executionv1alpha1ExternalTask(obj interface{}) (*executionv1alpha1.ExternalTask, error) {
+	switch t := obj.(type) {
+	case *executionv1alpha1.ExternalTask:
+		return t, nil
+	case cache.DeletedFinalStateUnknown:
+		obj, ok := t.Obj.(*executionv1alpha1.ExternalTask)
+		if ok {
+			return obj, nil
+		}
+	}
+
+	return nil, NewUnexpectedTypeError(&executionv1alpha1.ExternalTask{}, obj)
+}